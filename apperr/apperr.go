@@ -0,0 +1,151 @@
+// Package apperr defines the errors returned by stores and services. Each
+// error carries a Code that handlers translate into an HTTP status.
+package apperr
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+type Code string
+
+const (
+	CodeNotFound             Code = "not_found"
+	CodeConflict             Code = "conflict"
+	CodeValidation           Code = "validation_failed"
+	CodeForbidden            Code = "forbidden"
+	CodePreconditionFailed   Code = "precondition_failed"
+	CodePreconditionRequired Code = "precondition_required"
+	CodeIdempotencyKeyReused Code = "idempotency_key_reused"
+
+	// CodeInternal reports errors that have no status of their own; their
+	// message is never shown to clients.
+	CodeInternal Code = "internal"
+)
+
+// Status returns the HTTP status that reports errors with the code, or 0 for
+// codes without one.
+func (c Code) Status() int {
+	switch c {
+	case CodeNotFound:
+		return http.StatusNotFound
+	case CodeConflict:
+		return http.StatusConflict
+	case CodeValidation:
+		return http.StatusBadRequest
+	case CodeForbidden:
+		return http.StatusForbidden
+	case CodePreconditionFailed:
+		return http.StatusPreconditionFailed
+	case CodePreconditionRequired:
+		return http.StatusPreconditionRequired
+	case CodeIdempotencyKeyReused:
+		return http.StatusUnprocessableEntity
+	}
+
+	return 0
+}
+
+// FieldError explains why a single input field was rejected.
+type FieldError struct {
+	Field  string `json:"field"`
+	Reason string `json:"reason"`
+}
+
+type Error struct {
+	Code    Code
+	Message string
+	Fields  []FieldError
+}
+
+func (e *Error) Error() string {
+	return e.Message
+}
+
+// Is matches errors with the same code and message, so that callers and tests
+// can compare against a freshly built error.
+func (e *Error) Is(target error) bool {
+	var t *Error
+	if !errors.As(target, &t) {
+		return false
+	}
+
+	return e.Code == t.Code && e.Message == t.Message
+}
+
+// NotFound reports a missing entity, identified by its ID or another unique key.
+func NotFound(entity string, key any) *Error {
+	return &Error{Code: CodeNotFound, Message: fmt.Sprintf("%s %v not found", entity, key)}
+}
+
+func Conflict(message string) *Error {
+	return &Error{Code: CodeConflict, Message: message}
+}
+
+func Validation(fields ...FieldError) *Error {
+	names := make([]string, len(fields))
+	for i, f := range fields {
+		names[i] = f.Field
+	}
+
+	return &Error{Code: CodeValidation, Message: "invalid " + strings.Join(names, ", "), Fields: fields}
+}
+
+func Field(name, reason string) FieldError {
+	return FieldError{Field: name, Reason: reason}
+}
+
+func Forbidden(message string) *Error {
+	return &Error{Code: CodeForbidden, Message: message}
+}
+
+func PreconditionFailed(message string) *Error {
+	return &Error{Code: CodePreconditionFailed, Message: message}
+}
+
+func PreconditionRequired(message string) *Error {
+	return &Error{Code: CodePreconditionRequired, Message: message}
+}
+
+// IdempotencyKeyReused reports an Idempotency-Key sent again with a different request.
+func IdempotencyKeyReused(message string) *Error {
+	return &Error{Code: CodeIdempotencyKeyReused, Message: message}
+}
+
+// Write answers a request with err in the body gofr renders for handler
+// errors: {"error": {"message": ..., "code": ..., "details": [...]}}. Errors
+// whose code has no status are reported as a bare 500.
+func Write(w http.ResponseWriter, err error) {
+	var (
+		e      *Error
+		status = http.StatusInternalServerError
+		body   = map[string]any{"code": CodeInternal, "message": "internal server error"}
+	)
+
+	if errors.As(err, &e) && e.Code.Status() != 0 {
+		status = e.Code.Status()
+		body = map[string]any{"code": e.Code, "message": e.Message}
+
+		if len(e.Fields) > 0 {
+			body["details"] = e.Fields
+		}
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+
+	_ = json.NewEncoder(w).Encode(map[string]any{"error": body})
+}
+
+// CodeOf returns the code of the first *Error in err's chain, or "" if there is none.
+func CodeOf(err error) Code {
+	var e *Error
+	if errors.As(err, &e) {
+		return e.Code
+	}
+
+	return ""
+}
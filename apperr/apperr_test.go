@@ -0,0 +1,129 @@
+package apperr
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"TaskManager2/utils"
+)
+
+func TestError_Is(t *testing.T) {
+	tests := []struct {
+		description string
+		err         error
+		target      error
+		want        bool
+	}{
+		{"same code and message", NotFound("task", 1), NotFound("task", 1), true},
+		{"wrapped", fmt.Errorf("loading: %w", NotFound("task", 1)), NotFound("task", 1), true},
+		{"other id", NotFound("task", 1), NotFound("task", 2), false},
+		{"other code", Conflict("task 1 not found"), NotFound("task", 1), false},
+		{"plain error", NotFound("task", 1), utils.ErrTest, false},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.description, func(t *testing.T) {
+			if got := errors.Is(tc.err, tc.target); got != tc.want {
+				t.Errorf("expected %v, got %v", tc.want, got)
+			}
+		})
+	}
+}
+
+func TestValidation(t *testing.T) {
+	err := Validation(Field("name", "must not be empty"), Field("email", "must be a valid email address"))
+
+	if err.Code != CodeValidation || err.Message != "invalid name, email" || len(err.Fields) != 2 {
+		t.Errorf("unexpected error %+v", err)
+	}
+}
+
+func TestCodeOf(t *testing.T) {
+	tests := []struct {
+		description string
+		err         error
+		want        Code
+	}{
+		{"app error", Forbidden("not yours"), CodeForbidden},
+		{"wrapped", fmt.Errorf("update: %w", PreconditionFailed("stale")), CodePreconditionFailed},
+		{"plain error", utils.ErrTest, ""},
+		{"nil", nil, ""},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.description, func(t *testing.T) {
+			if got := CodeOf(tc.err); got != tc.want {
+				t.Errorf("expected %q, got %q", tc.want, got)
+			}
+		})
+	}
+}
+
+func TestCode_Status(t *testing.T) {
+	tests := []struct {
+		code Code
+		want int
+	}{
+		{CodeNotFound, http.StatusNotFound},
+		{CodeValidation, http.StatusBadRequest},
+		{CodeIdempotencyKeyReused, http.StatusUnprocessableEntity},
+		{"unknown", 0},
+	}
+
+	for _, tc := range tests {
+		t.Run(string(tc.code), func(t *testing.T) {
+			if got := tc.code.Status(); got != tc.want {
+				t.Errorf("expected %d, got %d", tc.want, got)
+			}
+		})
+	}
+}
+
+func TestWrite(t *testing.T) {
+	tests := []struct {
+		description string
+		err         error
+		wantStatus  int
+		wantBody    string
+	}{
+		{
+			description: "app error",
+			err:         Validation(Field("body", "is too large")),
+			wantStatus:  http.StatusBadRequest,
+			wantBody: `{"error":{"code":"validation_failed","details":[{"field":"body","reason":"is too large"}],` +
+				`"message":"invalid body"}}`,
+		},
+		{
+			description: "app error without fields",
+			err:         Conflict("in progress"),
+			wantStatus:  http.StatusConflict,
+			wantBody:    `{"error":{"code":"conflict","message":"in progress"}}`,
+		},
+		{
+			description: "unknown error",
+			err:         utils.ErrTest,
+			wantStatus:  http.StatusInternalServerError,
+			wantBody:    `{"error":{"code":"internal","message":"internal server error"}}`,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.description, func(t *testing.T) {
+			w := httptest.NewRecorder()
+
+			Write(w, tc.err)
+
+			if w.Code != tc.wantStatus || strings.TrimSpace(w.Body.String()) != tc.wantBody {
+				t.Errorf("expected %d %s, got %d %s", tc.wantStatus, tc.wantBody, w.Code, w.Body.String())
+			}
+
+			if got := w.Header().Get("Content-Type"); got != "application/json" {
+				t.Errorf("expected a JSON response, got %q", got)
+			}
+		})
+	}
+}
@@ -0,0 +1,90 @@
+package audit
+
+import (
+	"encoding/json"
+	"reflect"
+	"time"
+
+	"gofr.dev/pkg/gofr"
+
+	"TaskManager2/middleware"
+	"TaskManager2/models"
+)
+
+const (
+	EntityTask = "task"
+	EntityUser = "user"
+	EntityView = "view"
+
+	ActionCreate  = "create"
+	ActionUpdate  = "update"
+	ActionDelete  = "delete"
+	ActionRestore = "restore"
+	ActionPurge   = "purge"
+)
+
+// NewEntry describes the change of an entity from before to after, attributing
+// it to the actor and request on ctx. before is nil for creations and after is
+// nil for deletions.
+func NewEntry(ctx *gofr.Context, entity string, id int64, action string, before, after any) (*models.AuditEntry, error) {
+	changes, err := Diff(before, after)
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.AuditEntry{
+		Entity:    entity,
+		EntityID:  id,
+		Action:    action,
+		Actor:     middleware.Actor(ctx),
+		RequestID: middleware.RequestID(ctx),
+		Changes:   changes,
+		CreatedAt: time.Now().UTC(),
+	}, nil
+}
+
+// Diff compares the JSON representation of before and after and returns the
+// fields whose values differ.
+func Diff(before, after any) (map[string]models.Change, error) {
+	b, err := fields(before)
+	if err != nil {
+		return nil, err
+	}
+
+	a, err := fields(after)
+	if err != nil {
+		return nil, err
+	}
+
+	changes := make(map[string]models.Change)
+
+	for name, value := range a {
+		if !reflect.DeepEqual(b[name], value) {
+			changes[name] = models.Change{Before: b[name], After: value}
+		}
+	}
+
+	for name, value := range b {
+		if _, ok := a[name]; !ok {
+			changes[name] = models.Change{Before: value}
+		}
+	}
+
+	return changes, nil
+}
+
+func fields(v any) (map[string]any, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+
+	var m map[string]any
+
+	err = json.Unmarshal(data, &m)
+	if err != nil {
+		return nil, err
+	}
+
+	return m, nil
+}
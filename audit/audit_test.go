@@ -0,0 +1,63 @@
+package audit
+
+import (
+	"reflect"
+	"testing"
+
+	"TaskManager2/models"
+)
+
+func TestDiff(t *testing.T) {
+	tests := []struct {
+		description string
+		before      any
+		after       any
+		want        map[string]models.Change
+	}{
+		{
+			description: "create",
+			before:      (*models.User)(nil),
+			after:       &models.User{ID: 1, Name: "Bob", Email: "bob@example.com"},
+			want: map[string]models.Change{
+				"id":    {After: float64(1)},
+				"name":  {After: "Bob"},
+				"email": {After: "bob@example.com"},
+			},
+		},
+		{
+			description: "update",
+			before:      &models.Task{ID: 1, Title: "draft", UserID: 2},
+			after:       &models.Task{ID: 1, Title: "final", Status: true, UserID: 2},
+			want: map[string]models.Change{
+				"title":  {Before: "draft", After: "final"},
+				"status": {Before: false, After: true},
+			},
+		},
+		{
+			description: "delete",
+			before:      &models.Task{ID: 1, Tags: []string{"ops"}, UserID: 2},
+			after:       nil,
+			want: map[string]models.Change{
+				"id":      {Before: float64(1)},
+				"title":   {Before: ""},
+				"status":  {Before: false},
+				"user_id": {Before: float64(2)},
+				"tags":    {Before: []any{"ops"}},
+				"version": {Before: float64(0)},
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.description, func(t *testing.T) {
+			got, err := Diff(tc.before, tc.after)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if !reflect.DeepEqual(got, tc.want) {
+				t.Errorf("expected %v, got %v", tc.want, got)
+			}
+		})
+	}
+}
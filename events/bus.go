@@ -0,0 +1,53 @@
+package events
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"time"
+
+	"gofr.dev/pkg/gofr"
+
+	"TaskManager2/middleware"
+	"TaskManager2/models"
+)
+
+const idBytes = 16
+
+// Bus hands every emitted event to each of its sinks in turn. The sinks see
+// the same event, so its ID identifies it across webhooks and the broker.
+type Bus struct {
+	sinks []Sink
+}
+
+func NewBus(sinks ...Sink) *Bus {
+	return &Bus{sinks: sinks}
+}
+
+// Emit records the event, stamped with the actor and request ID on ctx, in
+// every sink. It stops at the first sink that fails.
+func (b *Bus) Emit(ctx *gofr.Context, name string, data any) error {
+	e := models.Event{
+		ID:         newID(),
+		Name:       name,
+		OccurredAt: time.Now().UTC().Truncate(time.Second),
+		Actor:      middleware.Actor(ctx),
+		RequestID:  middleware.RequestID(ctx),
+		Data:       data,
+	}
+
+	for _, sink := range b.sinks {
+		err := sink.Record(ctx, &e)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func newID() string {
+	b := make([]byte, idBytes)
+	_, _ = rand.Read(b)
+
+	return hex.EncodeToString(b)
+}
@@ -0,0 +1,71 @@
+package events
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"go.uber.org/mock/gomock"
+	"gofr.dev/pkg/gofr"
+	"gofr.dev/pkg/gofr/container"
+
+	"TaskManager2/middleware"
+	"TaskManager2/models"
+	"TaskManager2/utils"
+)
+
+func TestBus_Emit(t *testing.T) {
+	mockContainer, _ := container.NewMockContainer(t)
+	ctx := &gofr.Context{
+		Context:   t.Context(),
+		Request:   nil,
+		Container: mockContainer,
+	}
+
+	req := httptest.NewRequest(http.MethodPost, "/task", http.NoBody)
+	req.Header.Set(middleware.ActorHeader, "7")
+	req.Header.Set(middleware.RequestIDHeader, "req-1")
+
+	middleware.RequestMetadata(http.HandlerFunc(func(_ http.ResponseWriter, r *http.Request) {
+		ctx.Context = r.Context()
+	})).ServeHTTP(httptest.NewRecorder(), req)
+
+	controller := gomock.NewController(t)
+	first := NewMockSink(controller)
+	second := NewMockSink(controller)
+	bus := NewBus(first, second)
+	task := &models.Task{ID: 9}
+
+	var recorded *models.Event
+
+	first.EXPECT().Record(ctx, gomock.Any()).DoAndReturn(func(_ *gofr.Context, e *models.Event) error {
+		recorded = e
+
+		return nil
+	})
+	second.EXPECT().Record(ctx, gomock.Any()).DoAndReturn(func(_ *gofr.Context, e *models.Event) error {
+		if e != recorded {
+			t.Error("expected both sinks to record the same event")
+		}
+
+		return nil
+	})
+
+	err := bus.Emit(ctx, models.EventTaskCreated, task)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(recorded.ID) != 32 || recorded.Name != models.EventTaskCreated || recorded.Actor != "7" || recorded.RequestID != "req-1" ||
+		recorded.Data != task || recorded.OccurredAt.IsZero() {
+		t.Errorf("unexpected event %+v", recorded)
+	}
+
+	first.EXPECT().Record(ctx, gomock.Any()).Return(utils.ErrTest)
+
+	err = bus.Emit(ctx, models.EventTaskCreated, task)
+	if !errors.Is(err, utils.ErrTest) {
+		t.Errorf("expected %v, got %v", utils.ErrTest, err)
+	}
+}
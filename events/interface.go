@@ -0,0 +1,13 @@
+package events
+
+import (
+	"gofr.dev/pkg/gofr"
+
+	"TaskManager2/models"
+)
+
+// Sink records events. It is called within the transaction of the change the
+// event reports, so what it writes is committed or rolled back with it.
+type Sink interface {
+	Record(*gofr.Context, *models.Event) error
+}
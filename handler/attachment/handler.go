@@ -0,0 +1,69 @@
+package attachment
+
+import (
+	"strconv"
+
+	"gofr.dev/pkg/gofr"
+	"gofr.dev/pkg/gofr/http/response"
+
+	"TaskManager2/apperr"
+)
+
+var (
+	errInvalidID           = apperr.Validation(apperr.Field("id", "must be an integer"))
+	errInvalidAttachmentID = apperr.Validation(apperr.Field("attachment_id", "must be an integer"))
+)
+
+type handler struct {
+	service Service
+}
+
+func New(service Service) *handler {
+	return &handler{service: service}
+}
+
+func (h *handler) GetByTask(ctx *gofr.Context) (any, error) {
+	id, err := strconv.Atoi(ctx.PathParam("id"))
+	if err != nil {
+		return nil, errInvalidID
+	}
+
+	attachments, err := h.service.GetByTask(ctx, int64(id))
+	if err != nil {
+		return nil, err
+	}
+
+	return attachments, nil
+}
+
+// Get downloads the content of an attachment.
+func (h *handler) Get(ctx *gofr.Context) (any, error) {
+	id, err := strconv.Atoi(ctx.PathParam("id"))
+	if err != nil {
+		return nil, errInvalidID
+	}
+
+	attachmentID, err := strconv.Atoi(ctx.PathParam("attachment_id"))
+	if err != nil {
+		return nil, errInvalidAttachmentID
+	}
+
+	a, err := h.service.Get(ctx, int64(id), int64(attachmentID))
+	if err != nil {
+		return nil, err
+	}
+
+	return response.File{Content: a.Content, ContentType: contentType(a.ContentType)}, nil
+}
+
+// contentType is the type an attachment is served as. Attachments come from
+// outside, so only types that browsers display without running anything keep
+// their type, and everything else is served as opaque bytes.
+func contentType(t string) string {
+	switch t {
+	case "text/plain", "text/csv", "image/png", "image/jpeg", "image/gif", "application/pdf":
+		return t
+	default:
+		return "application/octet-stream"
+	}
+}
@@ -0,0 +1,179 @@
+package attachment
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"github.com/gorilla/mux"
+	"go.uber.org/mock/gomock"
+	"gofr.dev/pkg/gofr"
+	"gofr.dev/pkg/gofr/container"
+	gofrhttp "gofr.dev/pkg/gofr/http"
+	"gofr.dev/pkg/gofr/http/response"
+
+	"TaskManager2/apperr"
+	"TaskManager2/models"
+)
+
+func TestHandler_GetByTask(t *testing.T) {
+	controller := gomock.NewController(t)
+	mockSvc := NewMockService(controller)
+	attachmentHandler := New(mockSvc)
+
+	mockContainer, _ := container.NewMockContainer(t)
+
+	ctx := &gofr.Context{
+		Context:   t.Context(),
+		Request:   nil,
+		Container: mockContainer,
+	}
+
+	attachments := []models.Attachment{{ID: 2, TaskID: 3, Filename: "notes.txt", ContentType: "text/plain", Size: 5}}
+
+	testcases := []struct {
+		name             string
+		requestID        string
+		mockExpect       func()
+		expectedResponse any
+		expectedError    error
+	}{
+		{
+			"success",
+			"3",
+			func() {
+				mockSvc.EXPECT().GetByTask(ctx, int64(3)).Return(attachments, nil)
+			},
+			attachments,
+			nil,
+		},
+		{
+			"invalid id",
+			"abc",
+			func() {},
+			nil,
+			errInvalidID,
+		},
+		{
+			"service error",
+			"3",
+			func() {
+				mockSvc.EXPECT().GetByTask(ctx, int64(3)).Return(nil, apperr.NotFound("task", 3))
+			},
+			nil,
+			apperr.NotFound("task", 3),
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.mockExpect()
+
+			req := httptest.NewRequest(http.MethodGet, "/task/{id}/attachments", http.NoBody)
+			req = mux.SetURLVars(req, map[string]string{"id": tc.requestID})
+			ctx.Request = gofrhttp.NewRequest(req)
+
+			res, err := attachmentHandler.GetByTask(ctx)
+			if !errors.Is(err, tc.expectedError) {
+				t.Errorf("error, expected %v, got %v", tc.expectedError, err)
+			}
+
+			if !reflect.DeepEqual(res, tc.expectedResponse) {
+				t.Errorf("expected: %v, got: %v", tc.expectedResponse, res)
+			}
+		})
+	}
+}
+
+func TestHandler_Get(t *testing.T) {
+	controller := gomock.NewController(t)
+	mockSvc := NewMockService(controller)
+	attachmentHandler := New(mockSvc)
+
+	mockContainer, _ := container.NewMockContainer(t)
+
+	ctx := &gofr.Context{
+		Context:   t.Context(),
+		Request:   nil,
+		Container: mockContainer,
+	}
+
+	testcases := []struct {
+		name             string
+		requestID        string
+		attachmentID     string
+		mockExpect       func()
+		expectedResponse any
+		expectedError    error
+	}{
+		{
+			"success",
+			"3",
+			"2",
+			func() {
+				mockSvc.EXPECT().Get(ctx, int64(3), int64(2)).
+					Return(&models.Attachment{ID: 2, TaskID: 3, ContentType: "image/png", Content: []byte("png")}, nil)
+			},
+			response.File{Content: []byte("png"), ContentType: "image/png"},
+			nil,
+		},
+		{
+			"html served as bytes",
+			"3",
+			"2",
+			func() {
+				mockSvc.EXPECT().Get(ctx, int64(3), int64(2)).
+					Return(&models.Attachment{ID: 2, TaskID: 3, ContentType: "text/html", Content: []byte("<script>")}, nil)
+			},
+			response.File{Content: []byte("<script>"), ContentType: "application/octet-stream"},
+			nil,
+		},
+		{
+			"invalid id",
+			"abc",
+			"2",
+			func() {},
+			nil,
+			errInvalidID,
+		},
+		{
+			"invalid attachment id",
+			"3",
+			"abc",
+			func() {},
+			nil,
+			errInvalidAttachmentID,
+		},
+		{
+			"service error",
+			"3",
+			"2",
+			func() {
+				mockSvc.EXPECT().Get(ctx, int64(3), int64(2)).Return(nil, apperr.NotFound("attachment", 2))
+			},
+			nil,
+			apperr.NotFound("attachment", 2),
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.mockExpect()
+
+			req := httptest.NewRequest(http.MethodGet, "/task/{id}/attachments/{attachment_id}", http.NoBody)
+			req = mux.SetURLVars(req, map[string]string{"id": tc.requestID, "attachment_id": tc.attachmentID})
+			ctx.Request = gofrhttp.NewRequest(req)
+
+			res, err := attachmentHandler.Get(ctx)
+			if !errors.Is(err, tc.expectedError) {
+				t.Errorf("error, expected %v, got %v", tc.expectedError, err)
+			}
+
+			if !reflect.DeepEqual(res, tc.expectedResponse) {
+				t.Errorf("expected: %v, got: %v", tc.expectedResponse, res)
+			}
+		})
+	}
+}
@@ -0,0 +1,12 @@
+package attachment
+
+import (
+	"gofr.dev/pkg/gofr"
+
+	"TaskManager2/models"
+)
+
+type Service interface {
+	GetByTask(*gofr.Context, int64) ([]models.Attachment, error)
+	Get(*gofr.Context, int64, int64) (*models.Attachment, error)
+}
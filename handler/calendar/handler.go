@@ -0,0 +1,88 @@
+package calendar
+
+import (
+	"strconv"
+
+	"gofr.dev/pkg/gofr"
+	"gofr.dev/pkg/gofr/http/response"
+
+	"TaskManager2/apperr"
+)
+
+var (
+	errInvalidBody = apperr.Validation(apperr.Field("calendar", "must be a text/calendar body, or base64 in a JSON object"))
+	errInvalidID   = apperr.Validation(apperr.Field("id", "must be an integer"))
+)
+
+type handler struct {
+	service Service
+}
+
+func New(service Service) *handler {
+	return &handler{service: service}
+}
+
+func (h *handler) PostToken(ctx *gofr.Context) (any, error) {
+	id, err := strconv.Atoi(ctx.PathParam("id"))
+	if err != nil {
+		return nil, errInvalidID
+	}
+
+	token, err := h.service.CreateToken(ctx, int64(id))
+	if err != nil {
+		return nil, err
+	}
+
+	return token, nil
+}
+
+func (h *handler) DeleteToken(ctx *gofr.Context) (any, error) {
+	id, err := strconv.Atoi(ctx.PathParam("id"))
+	if err != nil {
+		return nil, errInvalidID
+	}
+
+	err = h.service.DeleteToken(ctx, int64(id))
+	if err != nil {
+		return nil, err
+	}
+
+	return nil, nil
+}
+
+// Feed serves the calendar of the user with the token in the path. It needs
+// no other credentials, so calendar apps can subscribe to it.
+func (h *handler) Feed(ctx *gofr.Context) (any, error) {
+	feed, err := h.service.Feed(ctx, ctx.PathParam("token"), ctx.Param("component"))
+	if err != nil {
+		return nil, err
+	}
+
+	return response.File{Content: feed, ContentType: "text/calendar; charset=utf-8"}, nil
+}
+
+// Import adds the entries of a calendar as tasks of the user. The
+// middleware.RawBody middleware turns a text/calendar body into the JSON
+// object bound here.
+func (h *handler) Import(ctx *gofr.Context) (any, error) {
+	id, err := strconv.Atoi(ctx.PathParam("id"))
+	if err != nil {
+		return nil, errInvalidID
+	}
+
+	var body struct {
+		Calendar []byte `json:"calendar"`
+	}
+
+	err = ctx.Bind(&body)
+	if err != nil || len(body.Calendar) == 0 {
+		return nil, errInvalidBody
+	}
+
+	result, err := h.service.Import(ctx, int64(id), body.Calendar)
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
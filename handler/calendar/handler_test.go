@@ -0,0 +1,206 @@
+package calendar
+
+import (
+	"bytes"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"github.com/gorilla/mux"
+	"go.uber.org/mock/gomock"
+	"gofr.dev/pkg/gofr"
+	"gofr.dev/pkg/gofr/container"
+	gofrhttp "gofr.dev/pkg/gofr/http"
+	"gofr.dev/pkg/gofr/http/response"
+
+	"TaskManager2/apperr"
+	"TaskManager2/models"
+	"TaskManager2/utils"
+)
+
+func TestHandler_Tokens(t *testing.T) {
+	controller := gomock.NewController(t)
+	mockSvc := NewMockService(controller)
+	calendarHandler := New(mockSvc)
+
+	mockContainer, _ := container.NewMockContainer(t)
+
+	ctx := &gofr.Context{
+		Context:   t.Context(),
+		Request:   nil,
+		Container: mockContainer,
+	}
+
+	token := &models.CalendarToken{UserID: 1, Token: "abc", Path: "/calendar/abc.ics"}
+
+	testcases := []struct {
+		name             string
+		method           string
+		requestID        string
+		mockExpect       func()
+		expectedResponse any
+		expectedError    error
+	}{
+		{"create", http.MethodPost, "1", func() { mockSvc.EXPECT().CreateToken(ctx, int64(1)).Return(token, nil) }, token, nil},
+		{"create error", http.MethodPost, "2", func() {
+			mockSvc.EXPECT().CreateToken(ctx, int64(2)).Return(nil, apperr.NotFound("user", 2))
+		}, nil, apperr.NotFound("user", 2)},
+		{"create invalid id", http.MethodPost, "abc", func() {}, nil, errInvalidID},
+		{"delete", http.MethodDelete, "1", func() { mockSvc.EXPECT().DeleteToken(ctx, int64(1)).Return(nil) }, nil, nil},
+		{"delete error", http.MethodDelete, "1", func() {
+			mockSvc.EXPECT().DeleteToken(ctx, int64(1)).Return(utils.ErrTest)
+		}, nil, utils.ErrTest},
+		{"delete invalid id", http.MethodDelete, "abc", func() {}, nil, errInvalidID},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.mockExpect()
+
+			req := httptest.NewRequest(tc.method, "/user/{id}/calendar-token", http.NoBody)
+			req = mux.SetURLVars(req, map[string]string{"id": tc.requestID})
+			ctx.Request = gofrhttp.NewRequest(req)
+
+			var (
+				res any
+				err error
+			)
+
+			if tc.method == http.MethodPost {
+				res, err = calendarHandler.PostToken(ctx)
+			} else {
+				res, err = calendarHandler.DeleteToken(ctx)
+			}
+
+			if !errors.Is(err, tc.expectedError) {
+				t.Errorf("error, expected %v, got %v", tc.expectedError, err)
+			}
+
+			if !reflect.DeepEqual(res, tc.expectedResponse) {
+				t.Errorf("expected: %v, got: %v", tc.expectedResponse, res)
+			}
+		})
+	}
+}
+
+func TestHandler_Feed(t *testing.T) {
+	controller := gomock.NewController(t)
+	mockSvc := NewMockService(controller)
+	calendarHandler := New(mockSvc)
+
+	mockContainer, _ := container.NewMockContainer(t)
+
+	ctx := &gofr.Context{
+		Context:   t.Context(),
+		Request:   nil,
+		Container: mockContainer,
+	}
+
+	mockSvc.EXPECT().Feed(ctx, "abc", "vevent").Return([]byte("BEGIN:VCALENDAR"), nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/calendar/abc.ics?component=vevent", http.NoBody)
+	req = mux.SetURLVars(req, map[string]string{"token": "abc"})
+	ctx.Request = gofrhttp.NewRequest(req)
+
+	res, err := calendarHandler.Feed(ctx)
+
+	expected := response.File{Content: []byte("BEGIN:VCALENDAR"), ContentType: "text/calendar; charset=utf-8"}
+	if err != nil || !reflect.DeepEqual(res, expected) {
+		t.Errorf("expected %v, got %v, %v", expected, res, err)
+	}
+
+	mockSvc.EXPECT().Feed(ctx, "abc", "").Return(nil, apperr.NotFound("calendar", "for this token"))
+
+	req = httptest.NewRequest(http.MethodGet, "/calendar/abc.ics", http.NoBody)
+	req = mux.SetURLVars(req, map[string]string{"token": "abc"})
+	ctx.Request = gofrhttp.NewRequest(req)
+
+	_, err = calendarHandler.Feed(ctx)
+	if !errors.Is(err, apperr.NotFound("calendar", "for this token")) {
+		t.Errorf("expected not found, got %v", err)
+	}
+}
+
+func TestHandler_Import(t *testing.T) {
+	controller := gomock.NewController(t)
+	mockSvc := NewMockService(controller)
+	calendarHandler := New(mockSvc)
+
+	mockContainer, _ := container.NewMockContainer(t)
+
+	ctx := &gofr.Context{
+		Context:   t.Context(),
+		Request:   nil,
+		Container: mockContainer,
+	}
+
+	result := &models.CalendarImport{Created: []int64{11}, Duplicates: []string{}, Cancelled: []string{}}
+
+	testcases := []struct {
+		name             string
+		requestID        string
+		requestBody      string
+		mockExpect       func()
+		expectedResponse any
+		expectedError    error
+	}{
+		{
+			"success",
+			"1",
+			`{"calendar": "SGk="}`,
+			func() {
+				mockSvc.EXPECT().Import(ctx, int64(1), []byte("Hi")).Return(result, nil)
+			},
+			result,
+			nil,
+		},
+		{
+			"invalid id",
+			"abc",
+			`{"calendar": "SGk="}`,
+			func() {},
+			nil,
+			errInvalidID,
+		},
+		{
+			"empty calendar",
+			"1",
+			`{}`,
+			func() {},
+			nil,
+			errInvalidBody,
+		},
+		{
+			"service error",
+			"1",
+			`{"calendar": "SGk="}`,
+			func() {
+				mockSvc.EXPECT().Import(ctx, int64(1), []byte("Hi")).Return(nil, utils.ErrTest)
+			},
+			nil,
+			utils.ErrTest,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.mockExpect()
+
+			req := httptest.NewRequest(http.MethodPost, "/user/{id}/calendar/import", bytes.NewReader([]byte(tc.requestBody)))
+			req.Header.Set("Content-Type", "application/json")
+			req = mux.SetURLVars(req, map[string]string{"id": tc.requestID})
+			ctx.Request = gofrhttp.NewRequest(req)
+
+			res, err := calendarHandler.Import(ctx)
+			if !errors.Is(err, tc.expectedError) {
+				t.Errorf("error, expected %v, got %v", tc.expectedError, err)
+			}
+
+			if !reflect.DeepEqual(res, tc.expectedResponse) {
+				t.Errorf("expected: %v, got: %v", tc.expectedResponse, res)
+			}
+		})
+	}
+}
@@ -0,0 +1,14 @@
+package calendar
+
+import (
+	"gofr.dev/pkg/gofr"
+
+	"TaskManager2/models"
+)
+
+type Service interface {
+	CreateToken(*gofr.Context, int64) (*models.CalendarToken, error)
+	DeleteToken(*gofr.Context, int64) error
+	Feed(*gofr.Context, string, string) ([]byte, error)
+	Import(*gofr.Context, int64, []byte) (*models.CalendarImport, error)
+}
@@ -0,0 +1,193 @@
+package collab
+
+import (
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"gofr.dev/pkg/gofr"
+	"gofr.dev/pkg/gofr/container"
+	"gofr.dev/pkg/gofr/websocket"
+
+	"TaskManager2/apperr"
+	"TaskManager2/handler/httperr"
+	"TaskManager2/middleware"
+	"TaskManager2/models"
+)
+
+const (
+	// Path is the route of the board channel.
+	Path = "/ws/board"
+	// IssuerKeyHeader carries the shared secret of the gateway that
+	// authenticates users and fetches board tokens for them.
+	IssuerKeyHeader = "X-Board-Issuer-Key"
+)
+
+// connectionHeader carries the ID the middleware generates for each
+// connection, replacing any the client sent.
+const connectionHeader = "X-Board-Connection"
+
+const connectionIDBytes = 16
+
+// writeTimeout bounds a write of board messages to a connection, so that a
+// client that stopped reading does not hold up the others for long.
+const writeTimeout = 10 * time.Second
+
+var (
+	errNoToken        = apperr.Forbidden("the board channel needs a token from POST /board/token")
+	errInvalidIssuer  = apperr.Forbidden("board tokens are issued with a valid " + IssuerKeyHeader + " header")
+	errInvalidBody    = apperr.Validation(apperr.Field("body", "must be a JSON object"))
+	errInvalidMessage = apperr.Validation(apperr.Field("message", "must be a JSON object"))
+	errInvalidType    = apperr.Validation(apperr.Field("type", "must be one of join, leave, move and typing"))
+)
+
+type handler struct {
+	service   Service
+	issuerKey string
+}
+
+// New returns a handler that issues board tokens to the gateway presenting
+// issuerKey. An empty key issues none, so no connection can be opened.
+func New(service Service, issuerKey string) *handler {
+	return &handler{service: service, issuerKey: issuerKey}
+}
+
+// Middleware authenticates upgrades to the board channel by the board token
+// in their token query parameter, which browsers use as they cannot set
+// headers on them, and gives each connection its own ID. Upgrades without a
+// valid token are rejected before the upgrade.
+func (h *handler) Middleware(_ *container.Container, inner http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != Path {
+			inner.ServeHTTP(w, r)
+
+			return
+		}
+
+		token := r.URL.Query().Get("token")
+		if token == "" {
+			httperr.Write(w, errNoToken)
+
+			return
+		}
+
+		user, err := h.service.Authenticate(token)
+		if err != nil {
+			httperr.Write(w, err)
+
+			return
+		}
+
+		ctx := middleware.WithActor(r.Context(), user)
+		ctx = middleware.WithHeader(ctx, connectionHeader, newConnectionID())
+
+		inner.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
+
+// Token issues a board token to the user named in the body. Only the gateway
+// that authenticated the user may ask for one, so the user is never taken
+// from the client.
+func (h *handler) Token(ctx *gofr.Context) (any, error) {
+	if h.issuerKey == "" || subtle.ConstantTimeCompare([]byte(middleware.Header(ctx, IssuerKeyHeader)), []byte(h.issuerKey)) != 1 {
+		return nil, errInvalidIssuer
+	}
+
+	var req models.BoardTokenRequest
+
+	err := ctx.Bind(&req)
+	if err != nil {
+		return nil, errInvalidBody
+	}
+
+	token, err := h.service.IssueToken(&req)
+	if err != nil {
+		return nil, err
+	}
+
+	return token, nil
+}
+
+// Handle serves a message of a connection to the board channel. gofr calls it
+// for each message and writes what it returns to the connection; rejected
+// messages are answered with an error message instead of closing it.
+func (h *handler) Handle(ctx *gofr.Context) (any, error) {
+	conn := middleware.Header(ctx, connectionHeader)
+
+	var raw string
+
+	err := ctx.Bind(&raw)
+	if err != nil {
+		// The connection is gone.
+		leaveErr := h.service.Leave(ctx, conn)
+		if leaveErr != nil {
+			ctx.Logger.Errorf("leaving board: %v", leaveErr)
+		}
+
+		return nil, err
+	}
+
+	var msg models.BoardMessage
+
+	err = json.Unmarshal([]byte(raw), &msg)
+	if err != nil {
+		return reply(ctx, errInvalidMessage), nil
+	}
+
+	res, err := h.dispatch(ctx, conn, &msg)
+	if err != nil {
+		return reply(ctx, err), nil
+	}
+
+	// A nil *models.BoardMessage would be written as null.
+	if res == nil {
+		return nil, nil
+	}
+
+	return res, nil
+}
+
+func (h *handler) dispatch(ctx *gofr.Context, conn string, msg *models.BoardMessage) (*models.BoardMessage, error) {
+	switch msg.Type {
+	case models.BoardJoin:
+		socket := ctx.GetConnectionFromContext(ctx)
+		// The deadline is cleared again for the replies gofr writes.
+		send := func(b []byte) error {
+			err := socket.SetWriteDeadline(time.Now().Add(writeTimeout))
+			if err != nil {
+				return err
+			}
+
+			defer func() { _ = socket.SetWriteDeadline(time.Time{}) }()
+
+			return socket.WriteMessage(websocket.TextMessage, b)
+		}
+
+		return h.service.Join(ctx, conn, send, msg.Board)
+	case models.BoardLeave:
+		return nil, h.service.Leave(ctx, conn)
+	case models.BoardMove, models.BoardTyping:
+		return nil, h.service.Relay(ctx, conn, msg)
+	default:
+		return nil, errInvalidType
+	}
+}
+
+func newConnectionID() string {
+	b := make([]byte, connectionIDBytes)
+	_, _ = rand.Read(b)
+
+	return hex.EncodeToString(b)
+}
+
+func reply(ctx *gofr.Context, err error) *models.BoardMessage {
+	mapped := httperr.From(err)
+	if mapped.Status == http.StatusInternalServerError {
+		ctx.Logger.Errorf("unhandled error: %v", err)
+	}
+
+	return &models.BoardMessage{Type: models.BoardError, Code: mapped.Code, Error: mapped.Message, Details: mapped.Details}
+}
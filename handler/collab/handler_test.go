@@ -0,0 +1,289 @@
+package collab
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+
+	"go.uber.org/mock/gomock"
+	"gofr.dev/pkg/gofr"
+	"gofr.dev/pkg/gofr/container"
+	gofrhttp "gofr.dev/pkg/gofr/http"
+
+	"TaskManager2/apperr"
+	"TaskManager2/middleware"
+	"TaskManager2/models"
+	"TaskManager2/utils"
+)
+
+const issuerKey = "issuer-key"
+
+// frame stands in for a WebSocket connection delivering one message. Only
+// Bind is called on it.
+type frame struct {
+	gofr.Request
+
+	data string
+	err  error
+}
+
+func (f frame) Bind(v any) error {
+	if f.err != nil {
+		return f.err
+	}
+
+	*v.(*string) = f.data
+
+	return nil
+}
+
+// handshake returns the context of a WebSocket upgrade request from alice,
+// as authenticated by the middleware on connection c1.
+func handshake(t *testing.T) context.Context {
+	t.Helper()
+
+	var ctx context.Context
+
+	req := httptest.NewRequest(http.MethodGet, "/ws/board", http.NoBody)
+
+	middleware.RequestMetadata(http.HandlerFunc(func(_ http.ResponseWriter, r *http.Request) {
+		ctx = r.Context()
+	})).ServeHTTP(httptest.NewRecorder(), req)
+
+	ctx = middleware.WithActor(ctx, "alice")
+
+	return middleware.WithHeader(ctx, connectionHeader, "c1")
+}
+
+func TestHandler_Handle(t *testing.T) {
+	controller := gomock.NewController(t)
+	mockSvc := NewMockService(controller)
+	collabHandler := New(mockSvc, issuerKey)
+
+	mockContainer, _ := container.NewMockContainer(t)
+	ctx := &gofr.Context{
+		Context:   handshake(t),
+		Request:   nil,
+		Container: mockContainer,
+	}
+
+	presence := &models.BoardMessage{Type: "presence", Board: 7, Users: []string{"alice"}}
+
+	testcases := []struct {
+		name             string
+		message          frame
+		mockExpect       func()
+		expectedResponse any
+		expectedError    error
+	}{
+		{
+			"join",
+			frame{data: `{"type": "join", "board": 7}`},
+			func() {
+				mockSvc.EXPECT().Join(ctx, "c1", gomock.Any(), int64(7)).Return(presence, nil)
+			},
+			presence,
+			nil,
+		},
+		{
+			"move",
+			frame{data: `{"type": "move", "task_id": 3, "data": {"column": "done"}}`},
+			func() {
+				mockSvc.EXPECT().Relay(ctx, "c1", &models.BoardMessage{Type: "move", TaskID: 3, Data: []byte(`{"column": "done"}`)}).
+					Return(nil)
+			},
+			nil,
+			nil,
+		},
+		{
+			"typing",
+			frame{data: `{"type": "typing", "task_id": 3}`},
+			func() {
+				mockSvc.EXPECT().Relay(ctx, "c1", &models.BoardMessage{Type: "typing", TaskID: 3}).Return(nil)
+			},
+			nil,
+			nil,
+		},
+		{
+			"leave",
+			frame{data: `{"type": "leave"}`},
+			func() {
+				mockSvc.EXPECT().Leave(ctx, "c1").Return(nil)
+			},
+			nil,
+			nil,
+		},
+		{
+			"invalid message",
+			frame{data: `{"type":`},
+			func() {},
+			&models.BoardMessage{Type: "error", Code: apperr.CodeValidation, Error: errInvalidMessage.Message,
+				Details: errInvalidMessage.Fields},
+			nil,
+		},
+		{
+			"unknown type",
+			frame{data: `{"type": "wave"}`},
+			func() {},
+			&models.BoardMessage{Type: "error", Code: apperr.CodeValidation, Error: errInvalidType.Message,
+				Details: errInvalidType.Fields},
+			nil,
+		},
+		{
+			"service error",
+			frame{data: `{"type": "join", "board": 8}`},
+			func() {
+				mockSvc.EXPECT().Join(ctx, "c1", gomock.Any(), int64(8)).Return(nil, apperr.NotFound("task", 8))
+			},
+			&models.BoardMessage{Type: "error", Code: apperr.CodeNotFound, Error: "task 8 not found"},
+			nil,
+		},
+		{
+			"connection closed",
+			frame{err: utils.ErrTest},
+			func() {
+				mockSvc.EXPECT().Leave(ctx, "c1").Return(nil)
+			},
+			nil,
+			utils.ErrTest,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.mockExpect()
+
+			ctx.Request = tc.message
+
+			res, err := collabHandler.Handle(ctx)
+			if !errors.Is(err, tc.expectedError) {
+				t.Errorf("error, expected %v, got %v", tc.expectedError, err)
+			}
+
+			if !reflect.DeepEqual(res, tc.expectedResponse) {
+				t.Errorf("expected: %+v, got: %+v", tc.expectedResponse, res)
+			}
+		})
+	}
+}
+
+func TestHandler_Middleware(t *testing.T) {
+	controller := gomock.NewController(t)
+	mockSvc := NewMockService(controller)
+	collabHandler := New(mockSvc, issuerKey)
+
+	testcases := []struct {
+		description    string
+		target         string
+		mockExpect     func()
+		expectedStatus int
+		expectedActor  string
+	}{
+		{"token", "/ws/board?token=t1", func() { mockSvc.EXPECT().Authenticate("t1").Return("bob", nil) }, http.StatusOK, "bob"},
+		{"invalid token", "/ws/board?token=t2", func() {
+			mockSvc.EXPECT().Authenticate("t2").Return("", apperr.Forbidden("the board token is invalid or expired"))
+		}, http.StatusForbidden, ""},
+		{"no token", "/ws/board", func() {}, http.StatusForbidden, ""},
+		{"other path", "/task?token=t1", func() {}, http.StatusOK, "alice"},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.description, func(t *testing.T) {
+			tc.mockExpect()
+
+			var actor, conn string
+
+			inner := http.HandlerFunc(func(_ http.ResponseWriter, r *http.Request) {
+				actor = middleware.Actor(r.Context())
+				conn = middleware.Header(r.Context(), connectionHeader)
+			})
+
+			req := httptest.NewRequest(http.MethodGet, tc.target, http.NoBody)
+			req.Header.Set("X-User-ID", "alice")
+			req.Header.Set(connectionHeader, "c1")
+
+			rec := httptest.NewRecorder()
+			middleware.RequestMetadata(collabHandler.Middleware(nil, inner)).ServeHTTP(rec, req)
+
+			if rec.Code != tc.expectedStatus || actor != tc.expectedActor {
+				t.Errorf("expected %d as %q, got %d as %q", tc.expectedStatus, tc.expectedActor, rec.Code, actor)
+			}
+
+			if tc.expectedActor == "bob" && (conn == "c1" || len(conn) != 2*connectionIDBytes) {
+				t.Errorf("expected a generated connection ID, got %q", conn)
+			}
+		})
+	}
+}
+
+func TestHandler_Token(t *testing.T) {
+	controller := gomock.NewController(t)
+	mockSvc := NewMockService(controller)
+	collabHandler := New(mockSvc, issuerKey)
+	mockContainer, _ := container.NewMockContainer(t)
+	token := &models.BoardToken{Token: "t1"}
+
+	testcases := []struct {
+		description      string
+		key              string
+		body             string
+		mockExpect       func()
+		expectedResponse any
+		expectedError    error
+	}{
+		{
+			"issued",
+			issuerKey,
+			`{"user": "bob"}`,
+			func() { mockSvc.EXPECT().IssueToken(&models.BoardTokenRequest{User: "bob"}).Return(token, nil) },
+			token,
+			nil,
+		},
+		{"no issuer key", "", `{"user": "bob"}`, func() {}, nil, errInvalidIssuer},
+		{"wrong issuer key", "guess", `{"user": "bob"}`, func() {}, nil, errInvalidIssuer},
+		{"invalid body", issuerKey, `[]`, func() {}, nil, errInvalidBody},
+		{
+			"service error",
+			issuerKey,
+			`{"user": "bob"}`,
+			func() { mockSvc.EXPECT().IssueToken(gomock.Any()).Return(nil, utils.ErrTest) },
+			nil,
+			utils.ErrTest,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.description, func(t *testing.T) {
+			tc.mockExpect()
+
+			req := httptest.NewRequest(http.MethodPost, "/board/token", strings.NewReader(tc.body))
+			req.Header.Set("Content-Type", "application/json")
+			req.Header.Set("X-User-ID", "alice")
+			req.Header.Set(IssuerKeyHeader, tc.key)
+
+			middleware.RequestMetadata(http.HandlerFunc(func(_ http.ResponseWriter, r *http.Request) {
+				req = r
+			})).ServeHTTP(httptest.NewRecorder(), req)
+
+			ctx := &gofr.Context{Context: req.Context(), Request: gofrhttp.NewRequest(req), Container: mockContainer}
+
+			res, err := collabHandler.Token(ctx)
+			if !errors.Is(err, tc.expectedError) {
+				t.Errorf("error, expected %v, got %v", tc.expectedError, err)
+			}
+
+			if !reflect.DeepEqual(res, tc.expectedResponse) {
+				t.Errorf("expected: %+v, got: %+v", tc.expectedResponse, res)
+			}
+		})
+	}
+
+	_, err := New(mockSvc, "").Token(&gofr.Context{Context: t.Context(), Container: mockContainer})
+	if !errors.Is(err, errInvalidIssuer) {
+		t.Errorf("expected tokens not to be issued without a key, got %v", err)
+	}
+}
@@ -0,0 +1,15 @@
+package collab
+
+import (
+	"gofr.dev/pkg/gofr"
+
+	"TaskManager2/models"
+)
+
+type Service interface {
+	Join(*gofr.Context, string, func([]byte) error, int64) (*models.BoardMessage, error)
+	Leave(*gofr.Context, string) error
+	Relay(*gofr.Context, string, *models.BoardMessage) error
+	IssueToken(*models.BoardTokenRequest) (*models.BoardToken, error)
+	Authenticate(string) (string, error)
+}
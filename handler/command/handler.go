@@ -0,0 +1,123 @@
+package command
+
+import (
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/go-sql-driver/mysql"
+	"gofr.dev/pkg/gofr"
+	"gofr.dev/pkg/gofr/datasource/pubsub"
+
+	"TaskManager2/apperr"
+	"TaskManager2/models"
+)
+
+// MySQL rejects values too long for their column or with the wrong type for
+// it; the same command is rejected again on every delivery.
+const (
+	errDataTooLong    = 1406
+	errIncorrectValue = 1366
+)
+
+var errInvalidMessage = apperr.Validation(apperr.Field("message", "must be a JSON command"))
+
+// command is the wire form of models.TaskCommand. An update carries a merge
+// patch, decoded as for PATCH /task/{id}, so that it can clear fields.
+type command struct {
+	models.TaskCommand
+
+	Patch map[string]json.RawMessage `json:"patch"`
+}
+
+type handler struct {
+	service         Service
+	topic           string
+	deadLetterTopic string
+}
+
+func New(service Service, topic, deadLetterTopic string) *handler {
+	return &handler{service: service, topic: topic, deadLetterTopic: deadLetterTopic}
+}
+
+// Handle applies a task command received on the topic. Commands that can
+// never be applied, because they are malformed or rejected by the task
+// service or by the database as invalid data, go to the dead-letter topic and
+// are acknowledged. Other errors are returned so the message is not committed
+// and is delivered again.
+func (h *handler) Handle(ctx *gofr.Context) error {
+	return h.handle(ctx, ctx.GetPublisher())
+}
+
+func (h *handler) handle(ctx *gofr.Context, publisher pubsub.Publisher) error {
+	var raw string
+
+	err := ctx.Bind(&raw)
+	if err != nil {
+		return h.deadLetter(ctx, publisher, raw, errInvalidMessage)
+	}
+
+	var wire command
+
+	err = json.Unmarshal([]byte(raw), &wire)
+	if err != nil {
+		return h.deadLetter(ctx, publisher, raw, errInvalidMessage)
+	}
+
+	cmd := wire.TaskCommand
+
+	if wire.Patch != nil {
+		cmd.Patch = &models.TaskPatch{}
+
+		invalid := cmd.Patch.Decode("patch.", wire.Patch)
+		if len(invalid) > 0 {
+			return h.deadLetter(ctx, publisher, raw, apperr.Validation(invalid...))
+		}
+	}
+
+	applied, err := h.service.Apply(ctx, &cmd)
+
+	if cause := poison(err); cause != nil {
+		return h.deadLetter(ctx, publisher, raw, cause)
+	}
+
+	if err != nil {
+		return err
+	}
+
+	if !applied {
+		ctx.Logger.Infof("skipped command %s, already processed", cmd.ID)
+	}
+
+	return nil
+}
+
+// poison returns the reason a command can never be applied, or nil if err is
+// transient or nil.
+func poison(err error) *apperr.Error {
+	var appErr *apperr.Error
+	if errors.As(err, &appErr) {
+		return appErr
+	}
+
+	var mysqlErr *mysql.MySQLError
+	if errors.As(err, &mysqlErr) && (mysqlErr.Number == errDataTooLong || mysqlErr.Number == errIncorrectValue) {
+		return apperr.Validation(apperr.Field("command", mysqlErr.Message))
+	}
+
+	return nil
+}
+
+func (h *handler) deadLetter(ctx *gofr.Context, publisher pubsub.Publisher, raw string, cause *apperr.Error) error {
+	letter, err := json.Marshal(models.DeadLetter{
+		Topic: h.topic, Message: raw, Code: cause.Code, Reason: cause.Message, Details: cause.Fields,
+		FailedAt: time.Now().UTC().Truncate(time.Second),
+	})
+	if err != nil {
+		return err
+	}
+
+	ctx.Logger.Errorf("dead-lettering command from %s: %v", h.topic, cause)
+
+	return publisher.Publish(ctx, h.deadLetterTopic, letter)
+}
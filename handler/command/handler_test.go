@@ -0,0 +1,206 @@
+package command
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/go-sql-driver/mysql"
+	"go.uber.org/mock/gomock"
+	"gofr.dev/pkg/gofr"
+	"gofr.dev/pkg/gofr/container"
+	"gofr.dev/pkg/gofr/datasource/pubsub"
+
+	"TaskManager2/apperr"
+	"TaskManager2/models"
+	"TaskManager2/utils"
+)
+
+// broker stands in for Kafka or Google Pub/Sub: it queues published messages
+// per topic and hands them to a subscriber, keeping those the subscriber does
+// not acknowledge for redelivery.
+type broker struct {
+	topics map[string][][]byte
+}
+
+func newBroker() *broker {
+	return &broker{topics: map[string][][]byte{}}
+}
+
+func (b *broker) Publish(_ context.Context, topic string, message []byte) error {
+	b.topics[topic] = append(b.topics[topic], message)
+
+	return nil
+}
+
+// deliver runs the subscriber once over every queued message of the topic.
+func (b *broker) deliver(ctx *gofr.Context, topic string, subscriber func(*gofr.Context) error) {
+	queued := b.topics[topic]
+	b.topics[topic] = nil
+
+	for _, value := range queued {
+		msg := pubsub.NewMessage(ctx)
+		msg.Topic = topic
+		msg.Value = value
+		ctx.Request = msg
+
+		err := subscriber(ctx)
+		if err != nil {
+			b.topics[topic] = append(b.topics[topic], value)
+		}
+	}
+}
+
+func TestHandler_Handle(t *testing.T) {
+	mockContainer, _ := container.NewMockContainer(t)
+	ctx := &gofr.Context{
+		Context:   t.Context(),
+		Request:   nil,
+		Container: mockContainer,
+	}
+
+	controller := gomock.NewController(t)
+	mockSvc := NewMockService(controller)
+	h := New(mockSvc, "task-commands", "task-commands-dlq")
+	b := newBroker()
+	subscriber := func(ctx *gofr.Context) error { return h.handle(ctx, b) }
+
+	create := `{"id":"m1","version":1,"type":"task.create","task":{"title":"release","user_id":1}}`
+	complete := `{"id":"m2","version":1,"type":"task.complete","task_id":9}`
+	retried := `{"id":"m3","version":1,"type":"task.complete","task_id":3}`
+	tooLong := `{"id":"m4","version":1,"type":"task.complete","task_id":4}`
+
+	for _, msg := range []string{create, create, complete, retried, tooLong, `{"id":`} {
+		_ = b.Publish(ctx, "task-commands", []byte(msg))
+	}
+
+	gomock.InOrder(
+		mockSvc.EXPECT().Apply(ctx, gomock.Any()).DoAndReturn(func(_ *gofr.Context, cmd *models.TaskCommand) (bool, error) {
+			if cmd.ID != "m1" || cmd.Type != models.CommandTaskCreate || cmd.Task.Title != "release" {
+				t.Errorf("unexpected command %+v", cmd)
+			}
+
+			return true, nil
+		}),
+		mockSvc.EXPECT().Apply(ctx, gomock.Any()).Return(false, nil),
+		mockSvc.EXPECT().Apply(ctx, gomock.Any()).Return(false, apperr.NotFound("task", 9)),
+		mockSvc.EXPECT().Apply(ctx, gomock.Any()).Return(false, utils.ErrTest),
+		mockSvc.EXPECT().Apply(ctx, gomock.Any()).Return(false,
+			fmt.Errorf("recording audit: %w", &mysql.MySQLError{Number: 1406, Message: "Data too long for column 'actor'"})),
+	)
+
+	b.deliver(ctx, "task-commands", subscriber)
+
+	if got := b.topics["task-commands"]; len(got) != 1 || string(got[0]) != retried {
+		t.Fatalf("expected only the failed command to be redelivered, got %q", got)
+	}
+
+	letters := b.topics["task-commands-dlq"]
+	if len(letters) != 3 {
+		t.Fatalf("expected 3 dead letters, got %q", letters)
+	}
+
+	for i, want := range []models.DeadLetter{
+		{Topic: "task-commands", Message: complete, Code: apperr.CodeNotFound, Reason: "task 9 not found"},
+		{Topic: "task-commands", Message: tooLong, Code: apperr.CodeValidation, Reason: "invalid command",
+			Details: []apperr.FieldError{{Field: "command", Reason: "Data too long for column 'actor'"}}},
+		{Topic: "task-commands", Message: `{"id":`, Code: apperr.CodeValidation, Reason: "invalid message",
+			Details: []apperr.FieldError{{Field: "message", Reason: "must be a JSON command"}}},
+	} {
+		var got models.DeadLetter
+
+		err := json.Unmarshal(letters[i], &got)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if got.Topic != want.Topic || got.Message != want.Message || got.Code != want.Code || got.Reason != want.Reason ||
+			len(got.Details) != len(want.Details) || got.FailedAt.IsZero() {
+			t.Errorf("expected dead letter %+v, got %+v", want, got)
+		}
+	}
+
+	mockSvc.EXPECT().Apply(ctx, gomock.Any()).Return(true, nil)
+
+	b.deliver(ctx, "task-commands", subscriber)
+
+	if len(b.topics["task-commands"]) != 0 {
+		t.Errorf("expected the redelivered command to be acknowledged, got %q", b.topics["task-commands"])
+	}
+}
+
+func TestHandler_HandlePatch(t *testing.T) {
+	mockContainer, _ := container.NewMockContainer(t)
+	ctx := &gofr.Context{
+		Context:   t.Context(),
+		Request:   nil,
+		Container: mockContainer,
+	}
+
+	controller := gomock.NewController(t)
+	mockSvc := NewMockService(controller)
+	h := New(mockSvc, "task-commands", "task-commands-dlq")
+	b := newBroker()
+
+	clearing := `{"id":"m1","version":1,"type":"task.update","task_id":3,"patch":{"description":null,"due_date":null}}`
+	invalid := `{"id":"m2","version":1,"type":"task.update","task_id":3,"patch":{"title":null,"user_id":2}}`
+
+	for _, msg := range []string{clearing, invalid} {
+		_ = b.Publish(ctx, "task-commands", []byte(msg))
+	}
+
+	mockSvc.EXPECT().Apply(ctx, gomock.Any()).DoAndReturn(func(_ *gofr.Context, cmd *models.TaskCommand) (bool, error) {
+		if cmd.TaskID != 3 || cmd.Patch == nil || cmd.Patch.Description == nil || *cmd.Patch.Description != "" ||
+			!cmd.Patch.ClearDueDate || cmd.Patch.Title != nil {
+			t.Errorf("expected a patch clearing the description and due date, got %+v", cmd.Patch)
+		}
+
+		return true, nil
+	})
+
+	b.deliver(ctx, "task-commands", func(ctx *gofr.Context) error { return h.handle(ctx, b) })
+
+	letters := b.topics["task-commands-dlq"]
+	if len(letters) != 1 {
+		t.Fatalf("expected 1 dead letter, got %q", letters)
+	}
+
+	var got models.DeadLetter
+
+	err := json.Unmarshal(letters[0], &got)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []apperr.FieldError{{Field: "patch.title", Reason: "must be a string"}, {Field: "patch.user_id", Reason: "cannot be patched"}}
+	if got.Message != invalid || got.Code != apperr.CodeValidation || !reflect.DeepEqual(got.Details, want) {
+		t.Errorf("expected the invalid patch to be dead-lettered with %v, got %+v", want, got)
+	}
+}
+
+func TestHandler_HandleDeadLetterError(t *testing.T) {
+	mockContainer, _ := container.NewMockContainer(t)
+	ctx := &gofr.Context{
+		Context:   t.Context(),
+		Request:   nil,
+		Container: mockContainer,
+	}
+
+	msg := pubsub.NewMessage(ctx)
+	msg.Value = []byte("not json")
+	ctx.Request = msg
+
+	err := New(nil, "task-commands", "task-commands-dlq").handle(ctx, failingPublisher{})
+	if !errors.Is(err, utils.ErrTest) {
+		t.Errorf("expected %v so the command is redelivered, got %v", utils.ErrTest, err)
+	}
+}
+
+type failingPublisher struct{}
+
+func (failingPublisher) Publish(context.Context, string, []byte) error {
+	return utils.ErrTest
+}
@@ -0,0 +1,11 @@
+package command
+
+import (
+	"gofr.dev/pkg/gofr"
+
+	"TaskManager2/models"
+)
+
+type Service interface {
+	Apply(*gofr.Context, *models.TaskCommand) (bool, error)
+}
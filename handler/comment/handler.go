@@ -0,0 +1,57 @@
+package comment
+
+import (
+	"strconv"
+
+	"gofr.dev/pkg/gofr"
+
+	"TaskManager2/apperr"
+	"TaskManager2/models"
+)
+
+var errInvalidBody = apperr.Validation(apperr.Field("body", "must be a JSON object"))
+
+type handler struct {
+	service Service
+}
+
+func New(service Service) *handler {
+	return &handler{service: service}
+}
+
+func (h *handler) Post(ctx *gofr.Context) (any, error) {
+	id, err := strconv.Atoi(ctx.PathParam("id"))
+	if err != nil {
+		return nil, apperr.Validation(apperr.Field("id", "must be an integer"))
+	}
+
+	var c models.Comment
+
+	err = ctx.Bind(&c)
+	if err != nil {
+		return nil, errInvalidBody
+	}
+
+	c.TaskID = int64(id)
+
+	created, err := h.service.Create(ctx, &c)
+	if err != nil {
+		return nil, err
+	}
+
+	return created, nil
+}
+
+func (h *handler) GetByTask(ctx *gofr.Context) (any, error) {
+	id, err := strconv.Atoi(ctx.PathParam("id"))
+	if err != nil {
+		return nil, apperr.Validation(apperr.Field("id", "must be an integer"))
+	}
+
+	comments, err := h.service.GetByTask(ctx, int64(id))
+	if err != nil {
+		return nil, err
+	}
+
+	return comments, nil
+}
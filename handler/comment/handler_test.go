@@ -0,0 +1,171 @@
+package comment
+
+import (
+	"bytes"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"github.com/gorilla/mux"
+	"go.uber.org/mock/gomock"
+	"gofr.dev/pkg/gofr"
+	"gofr.dev/pkg/gofr/container"
+	gofrhttp "gofr.dev/pkg/gofr/http"
+
+	"TaskManager2/apperr"
+	"TaskManager2/models"
+	"TaskManager2/utils"
+)
+
+func TestHandler_Post(t *testing.T) {
+	controller := gomock.NewController(t)
+	mockSvc := NewMockService(controller)
+	commentHandler := New(mockSvc)
+
+	mockContainer, _ := container.NewMockContainer(t)
+
+	ctx := &gofr.Context{
+		Context:   t.Context(),
+		Request:   nil,
+		Container: mockContainer,
+	}
+
+	created := &models.Comment{ID: 5, TaskID: 3, Author: "7", Body: "Looks good"}
+
+	testcases := []struct {
+		name             string
+		requestID        string
+		requestBody      string
+		mockExpect       func()
+		expectedResponse any
+		expectedError    error
+	}{
+		{
+			"success",
+			"3",
+			`{"body": "Looks good"}`,
+			func() {
+				mockSvc.EXPECT().Create(ctx, &models.Comment{TaskID: 3, Body: "Looks good"}).Return(created, nil)
+			},
+			created,
+			nil,
+		},
+		{
+			"invalid id",
+			"abc",
+			`{"body": "Looks good"}`,
+			func() {},
+			nil,
+			apperr.Validation(apperr.Field("id", "must be an integer")),
+		},
+		{
+			"bind error",
+			"3",
+			`{"body":`,
+			func() {},
+			nil,
+			errInvalidBody,
+		},
+		{
+			"service error",
+			"3",
+			`{"body": "Looks good"}`,
+			func() {
+				mockSvc.EXPECT().Create(ctx, &models.Comment{TaskID: 3, Body: "Looks good"}).Return(nil, utils.ErrTest)
+			},
+			nil,
+			utils.ErrTest,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.mockExpect()
+
+			req := httptest.NewRequest(http.MethodPost, "/task/{id}/comments", bytes.NewReader([]byte(tc.requestBody)))
+			req.Header.Set("Content-Type", "application/json")
+			req = mux.SetURLVars(req, map[string]string{"id": tc.requestID})
+			ctx.Request = gofrhttp.NewRequest(req)
+
+			res, err := commentHandler.Post(ctx)
+			if !errors.Is(err, tc.expectedError) {
+				t.Errorf("error, expected %v, got %v", tc.expectedError, err)
+			}
+
+			if !reflect.DeepEqual(res, tc.expectedResponse) {
+				t.Errorf("expected: %v, got: %v", tc.expectedResponse, res)
+			}
+		})
+	}
+}
+
+func TestHandler_GetByTask(t *testing.T) {
+	controller := gomock.NewController(t)
+	mockSvc := NewMockService(controller)
+	commentHandler := New(mockSvc)
+
+	mockContainer, _ := container.NewMockContainer(t)
+
+	ctx := &gofr.Context{
+		Context:   t.Context(),
+		Request:   nil,
+		Container: mockContainer,
+	}
+
+	comments := []models.Comment{{ID: 5, TaskID: 3, Author: "7", Body: "Looks good"}}
+
+	testcases := []struct {
+		name             string
+		requestID        string
+		mockExpect       func()
+		expectedResponse any
+		expectedError    error
+	}{
+		{
+			"success",
+			"3",
+			func() {
+				mockSvc.EXPECT().GetByTask(ctx, int64(3)).Return(comments, nil)
+			},
+			comments,
+			nil,
+		},
+		{
+			"invalid id",
+			"abc",
+			func() {},
+			nil,
+			apperr.Validation(apperr.Field("id", "must be an integer")),
+		},
+		{
+			"service error",
+			"3",
+			func() {
+				mockSvc.EXPECT().GetByTask(ctx, int64(3)).Return(nil, apperr.NotFound("task", 3))
+			},
+			nil,
+			apperr.NotFound("task", 3),
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.mockExpect()
+
+			req := httptest.NewRequest(http.MethodGet, "/task/{id}/comments", http.NoBody)
+			req = mux.SetURLVars(req, map[string]string{"id": tc.requestID})
+			ctx.Request = gofrhttp.NewRequest(req)
+
+			res, err := commentHandler.GetByTask(ctx)
+			if !errors.Is(err, tc.expectedError) {
+				t.Errorf("error, expected %v, got %v", tc.expectedError, err)
+			}
+
+			if !reflect.DeepEqual(res, tc.expectedResponse) {
+				t.Errorf("expected: %v, got: %v", tc.expectedResponse, res)
+			}
+		})
+	}
+}
@@ -0,0 +1,12 @@
+package comment
+
+import (
+	"gofr.dev/pkg/gofr"
+
+	"TaskManager2/models"
+)
+
+type Service interface {
+	Create(*gofr.Context, *models.Comment) (*models.Comment, error)
+	GetByTask(*gofr.Context, int64) ([]models.Comment, error)
+}
@@ -0,0 +1,85 @@
+package digest
+
+import (
+	"strconv"
+
+	"gofr.dev/pkg/gofr"
+
+	"TaskManager2/apperr"
+	"TaskManager2/models"
+)
+
+var (
+	errInvalidBody  = apperr.Validation(apperr.Field("body", "must be a JSON object"))
+	errInvalidID    = apperr.Validation(apperr.Field("id", "must be an integer"))
+	errInvalidLimit = apperr.Validation(apperr.Field("limit", "must be an integer"))
+)
+
+type handler struct {
+	service Service
+}
+
+func New(service Service) *handler {
+	return &handler{service: service}
+}
+
+func (h *handler) GetPreference(ctx *gofr.Context) (any, error) {
+	id, err := strconv.Atoi(ctx.PathParam("id"))
+	if err != nil {
+		return nil, errInvalidID
+	}
+
+	p, err := h.service.GetPreference(ctx, int64(id))
+	if err != nil {
+		return nil, err
+	}
+
+	return p, nil
+}
+
+func (h *handler) PutPreference(ctx *gofr.Context) (any, error) {
+	id, err := strconv.Atoi(ctx.PathParam("id"))
+	if err != nil {
+		return nil, errInvalidID
+	}
+
+	var p models.DigestPreference
+
+	err = ctx.Bind(&p)
+	if err != nil {
+		return nil, errInvalidBody
+	}
+
+	p.UserID = int64(id)
+
+	saved, err := h.service.SavePreference(ctx, &p)
+	if err != nil {
+		return nil, err
+	}
+
+	return saved, nil
+}
+
+// GetDigests lists the digests sent, or being sent, to the user.
+func (h *handler) GetDigests(ctx *gofr.Context) (any, error) {
+	id, err := strconv.Atoi(ctx.PathParam("id"))
+	if err != nil {
+		return nil, errInvalidID
+	}
+
+	var limit int
+
+	if v := ctx.Param("limit"); v != "" {
+		limit, err = strconv.Atoi(v)
+		if err != nil {
+			return nil, errInvalidLimit
+		}
+	}
+
+	digests, err := h.service.GetDigests(ctx, int64(id), limit)
+	if err != nil {
+		return nil, err
+	}
+
+	return digests, nil
+}
@@ -0,0 +1,252 @@
+package digest
+
+import (
+	"bytes"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"github.com/gorilla/mux"
+	"go.uber.org/mock/gomock"
+	"gofr.dev/pkg/gofr"
+	"gofr.dev/pkg/gofr/container"
+	gofrhttp "gofr.dev/pkg/gofr/http"
+
+	"TaskManager2/apperr"
+	"TaskManager2/models"
+	"TaskManager2/utils"
+)
+
+func TestHandler_GetPreference(t *testing.T) {
+	controller := gomock.NewController(t)
+	mockSvc := NewMockService(controller)
+	digestHandler := New(mockSvc)
+
+	mockContainer, _ := container.NewMockContainer(t)
+
+	ctx := &gofr.Context{
+		Context:   t.Context(),
+		Request:   nil,
+		Container: mockContainer,
+	}
+
+	p := &models.DigestPreference{UserID: 1, Frequency: "off", TimeOfDay: "08:00", Timezone: "UTC"}
+
+	testcases := []struct {
+		name             string
+		requestID        string
+		mockExpect       func()
+		expectedResponse any
+		expectedError    error
+	}{
+		{
+			"success",
+			"1",
+			func() {
+				mockSvc.EXPECT().GetPreference(ctx, int64(1)).Return(p, nil)
+			},
+			p,
+			nil,
+		},
+		{
+			"invalid id",
+			"abc",
+			func() {},
+			nil,
+			errInvalidID,
+		},
+		{
+			"service error",
+			"1",
+			func() {
+				mockSvc.EXPECT().GetPreference(ctx, int64(1)).Return(nil, apperr.NotFound("user", 1))
+			},
+			nil,
+			apperr.NotFound("user", 1),
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.mockExpect()
+
+			req := httptest.NewRequest(http.MethodGet, "/user/{id}/digest-preference", http.NoBody)
+			req = mux.SetURLVars(req, map[string]string{"id": tc.requestID})
+			ctx.Request = gofrhttp.NewRequest(req)
+
+			res, err := digestHandler.GetPreference(ctx)
+			if !errors.Is(err, tc.expectedError) {
+				t.Errorf("error, expected %v, got %v", tc.expectedError, err)
+			}
+
+			if !reflect.DeepEqual(res, tc.expectedResponse) {
+				t.Errorf("expected: %v, got: %v", tc.expectedResponse, res)
+			}
+		})
+	}
+}
+
+func TestHandler_PutPreference(t *testing.T) {
+	controller := gomock.NewController(t)
+	mockSvc := NewMockService(controller)
+	digestHandler := New(mockSvc)
+
+	mockContainer, _ := container.NewMockContainer(t)
+
+	ctx := &gofr.Context{
+		Context:   t.Context(),
+		Request:   nil,
+		Container: mockContainer,
+	}
+
+	input := &models.DigestPreference{UserID: 1, Frequency: "weekly", TimeOfDay: "08:00", Timezone: "UTC", Weekday: "monday"}
+
+	testcases := []struct {
+		name             string
+		requestID        string
+		requestBody      string
+		mockExpect       func()
+		expectedResponse any
+		expectedError    error
+	}{
+		{
+			"success",
+			"1",
+			`{"frequency": "weekly", "time_of_day": "08:00", "timezone": "UTC", "weekday": "monday"}`,
+			func() {
+				mockSvc.EXPECT().SavePreference(ctx, input).Return(input, nil)
+			},
+			input,
+			nil,
+		},
+		{
+			"invalid id",
+			"abc",
+			`{}`,
+			func() {},
+			nil,
+			errInvalidID,
+		},
+		{
+			"bind error",
+			"1",
+			`{"frequency":`,
+			func() {},
+			nil,
+			errInvalidBody,
+		},
+		{
+			"service error",
+			"1",
+			`{"frequency": "weekly", "time_of_day": "08:00", "timezone": "UTC", "weekday": "monday"}`,
+			func() {
+				mockSvc.EXPECT().SavePreference(ctx, input).Return(nil, utils.ErrTest)
+			},
+			nil,
+			utils.ErrTest,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.mockExpect()
+
+			req := httptest.NewRequest(http.MethodPut, "/user/{id}/digest-preference", bytes.NewReader([]byte(tc.requestBody)))
+			req.Header.Set("Content-Type", "application/json")
+			req = mux.SetURLVars(req, map[string]string{"id": tc.requestID})
+			ctx.Request = gofrhttp.NewRequest(req)
+
+			res, err := digestHandler.PutPreference(ctx)
+			if !errors.Is(err, tc.expectedError) {
+				t.Errorf("error, expected %v, got %v", tc.expectedError, err)
+			}
+
+			if !reflect.DeepEqual(res, tc.expectedResponse) {
+				t.Errorf("expected: %v, got: %v", tc.expectedResponse, res)
+			}
+		})
+	}
+}
+
+func TestHandler_GetDigests(t *testing.T) {
+	controller := gomock.NewController(t)
+	mockSvc := NewMockService(controller)
+	digestHandler := New(mockSvc)
+
+	mockContainer, _ := container.NewMockContainer(t)
+
+	ctx := &gofr.Context{
+		Context:   t.Context(),
+		Request:   nil,
+		Container: mockContainer,
+	}
+
+	digests := []models.Digest{{ID: 7, UserID: 1, Frequency: "daily", Status: "sent"}}
+
+	testcases := []struct {
+		name             string
+		requestID        string
+		query            string
+		mockExpect       func()
+		expectedResponse any
+		expectedError    error
+	}{
+		{
+			"success",
+			"1",
+			"?limit=5",
+			func() {
+				mockSvc.EXPECT().GetDigests(ctx, int64(1), 5).Return(digests, nil)
+			},
+			digests,
+			nil,
+		},
+		{
+			"invalid id",
+			"abc",
+			"",
+			func() {},
+			nil,
+			errInvalidID,
+		},
+		{
+			"invalid limit",
+			"1",
+			"?limit=all",
+			func() {},
+			nil,
+			errInvalidLimit,
+		},
+		{
+			"service error",
+			"1",
+			"",
+			func() {
+				mockSvc.EXPECT().GetDigests(ctx, int64(1), 0).Return(nil, utils.ErrTest)
+			},
+			nil,
+			utils.ErrTest,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.mockExpect()
+
+			req := httptest.NewRequest(http.MethodGet, "/user/{id}/digests"+tc.query, http.NoBody)
+			req = mux.SetURLVars(req, map[string]string{"id": tc.requestID})
+			ctx.Request = gofrhttp.NewRequest(req)
+
+			res, err := digestHandler.GetDigests(ctx)
+			if !errors.Is(err, tc.expectedError) {
+				t.Errorf("error, expected %v, got %v", tc.expectedError, err)
+			}
+
+			if !reflect.DeepEqual(res, tc.expectedResponse) {
+				t.Errorf("expected: %v, got: %v", tc.expectedResponse, res)
+			}
+		})
+	}
+}
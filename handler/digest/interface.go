@@ -0,0 +1,13 @@
+package digest
+
+import (
+	"gofr.dev/pkg/gofr"
+
+	"TaskManager2/models"
+)
+
+type Service interface {
+	GetPreference(*gofr.Context, int64) (*models.DigestPreference, error)
+	SavePreference(*gofr.Context, *models.DigestPreference) (*models.DigestPreference, error)
+	GetDigests(*gofr.Context, int64, int) ([]models.Digest, error)
+}
@@ -0,0 +1,87 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: interface.go
+//
+// Generated by this command:
+//
+//	mockgen -source=interface.go -destination=mock_interface.go -package=digest
+//
+
+// Package digest is a generated GoMock package.
+package digest
+
+import (
+	models "TaskManager2/models"
+	reflect "reflect"
+
+	gomock "go.uber.org/mock/gomock"
+	gofr "gofr.dev/pkg/gofr"
+)
+
+// MockService is a mock of Service interface.
+type MockService struct {
+	ctrl     *gomock.Controller
+	recorder *MockServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockServiceMockRecorder is the mock recorder for MockService.
+type MockServiceMockRecorder struct {
+	mock *MockService
+}
+
+// NewMockService creates a new mock instance.
+func NewMockService(ctrl *gomock.Controller) *MockService {
+	mock := &MockService{ctrl: ctrl}
+	mock.recorder = &MockServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockService) EXPECT() *MockServiceMockRecorder {
+	return m.recorder
+}
+
+// GetDigests mocks base method.
+func (m *MockService) GetDigests(arg0 *gofr.Context, arg1 int64, arg2 int) ([]models.Digest, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDigests", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]models.Digest)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDigests indicates an expected call of GetDigests.
+func (mr *MockServiceMockRecorder) GetDigests(arg0, arg1, arg2 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDigests", reflect.TypeOf((*MockService)(nil).GetDigests), arg0, arg1, arg2)
+}
+
+// GetPreference mocks base method.
+func (m *MockService) GetPreference(arg0 *gofr.Context, arg1 int64) (*models.DigestPreference, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPreference", arg0, arg1)
+	ret0, _ := ret[0].(*models.DigestPreference)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPreference indicates an expected call of GetPreference.
+func (mr *MockServiceMockRecorder) GetPreference(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPreference", reflect.TypeOf((*MockService)(nil).GetPreference), arg0, arg1)
+}
+
+// SavePreference mocks base method.
+func (m *MockService) SavePreference(arg0 *gofr.Context, arg1 *models.DigestPreference) (*models.DigestPreference, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SavePreference", arg0, arg1)
+	ret0, _ := ret[0].(*models.DigestPreference)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SavePreference indicates an expected call of SavePreference.
+func (mr *MockServiceMockRecorder) SavePreference(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SavePreference", reflect.TypeOf((*MockService)(nil).SavePreference), arg0, arg1)
+}
@@ -0,0 +1,109 @@
+// Package httperr turns the errors returned by handlers and services into gofr
+// HTTP errors that carry a machine-readable code and field-level details.
+package httperr
+
+import (
+	"errors"
+	"net/http"
+
+	"gofr.dev/pkg/gofr"
+	gofrhttp "gofr.dev/pkg/gofr/http"
+
+	"TaskManager2/apperr"
+)
+
+// Error is rendered by gofr as {"error": {"message": ..., "code": ..., "details": [...]}}.
+type Error struct {
+	Status  int
+	Code    apperr.Code
+	Message string
+	Details []apperr.FieldError
+}
+
+func (e Error) Error() string {
+	return e.Message
+}
+
+func (e Error) StatusCode() int {
+	return e.Status
+}
+
+// Response adds the code and details to the error body written by gofr.
+func (e Error) Response() map[string]any {
+	res := map[string]any{"code": e.Code}
+	if len(e.Details) > 0 {
+		res["details"] = e.Details
+	}
+
+	return res
+}
+
+// Handle wraps a handler so that every error it returns is mapped by From.
+// Errors without a known mapping are logged and reported as a bare 500.
+func Handle(h gofr.Handler) gofr.Handler {
+	return func(ctx *gofr.Context) (any, error) {
+		res, err := h(ctx)
+		if err == nil {
+			return res, nil
+		}
+
+		mapped := From(err)
+		if mapped.Code == apperr.CodeInternal {
+			ctx.Logger.Errorf("unhandled error: %v", err)
+		}
+
+		return nil, mapped
+	}
+}
+
+// Write renders err as gofr would for a handler, for code that answers
+// requests before they reach one.
+func Write(w http.ResponseWriter, err error) {
+	mapped := From(err)
+
+	apperr.Write(w, &apperr.Error{Code: mapped.Code, Message: mapped.Message, Fields: mapped.Details})
+}
+
+// From maps err to an Error, translating the gofr HTTP errors still raised
+// while binding requests.
+func From(err error) Error {
+	var (
+		appErr        *apperr.Error
+		invalidParam  gofrhttp.ErrorInvalidParam
+		missingParam  gofrhttp.ErrorMissingParam
+		entityMissing gofrhttp.ErrorEntityNotFound
+	)
+
+	switch {
+	case errors.As(err, &appErr):
+		status := appErr.Code.Status()
+		if status == 0 {
+			break
+		}
+
+		return Error{Status: status, Code: appErr.Code, Message: appErr.Message, Details: appErr.Fields}
+	case errors.As(err, &invalidParam):
+		return fromParams(invalidParam.Params, "invalid")
+	case errors.As(err, &missingParam):
+		return fromParams(missingParam.Params, "required")
+	case errors.As(err, &entityMissing):
+		return Error{Status: http.StatusNotFound, Code: apperr.CodeNotFound, Message: entityMissing.Error()}
+	}
+
+	return Error{Status: http.StatusInternalServerError, Code: apperr.CodeInternal, Message: "internal server error"}
+}
+
+func fromParams(params []string, reason string) Error {
+	if len(params) == 0 {
+		return Error{Status: http.StatusBadRequest, Code: apperr.CodeValidation, Message: "invalid request body"}
+	}
+
+	fields := make([]apperr.FieldError, len(params))
+	for i, p := range params {
+		fields[i] = apperr.Field(p, reason)
+	}
+
+	e := apperr.Validation(fields...)
+
+	return Error{Status: http.StatusBadRequest, Code: e.Code, Message: e.Message, Details: e.Fields}
+}
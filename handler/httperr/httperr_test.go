@@ -0,0 +1,139 @@
+package httperr
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"gofr.dev/pkg/gofr"
+	"gofr.dev/pkg/gofr/container"
+	gofrhttp "gofr.dev/pkg/gofr/http"
+
+	"TaskManager2/apperr"
+	"TaskManager2/utils"
+)
+
+func TestFrom(t *testing.T) {
+	tests := []struct {
+		description string
+		err         error
+		want        Error
+	}{
+		{
+			"not found",
+			apperr.NotFound("task", 1),
+			Error{Status: http.StatusNotFound, Code: apperr.CodeNotFound, Message: "task 1 not found"},
+		},
+		{
+			"validation",
+			apperr.Validation(apperr.Field("desc", "must not be empty")),
+			Error{
+				Status: http.StatusBadRequest, Code: apperr.CodeValidation, Message: "invalid desc",
+				Details: []apperr.FieldError{{Field: "desc", Reason: "must not be empty"}},
+			},
+		},
+		{
+			"conflict",
+			apperr.Conflict("already exists"),
+			Error{Status: http.StatusConflict, Code: apperr.CodeConflict, Message: "already exists"},
+		},
+		{
+			"forbidden",
+			apperr.Forbidden("not yours"),
+			Error{Status: http.StatusForbidden, Code: apperr.CodeForbidden, Message: "not yours"},
+		},
+		{
+			"precondition failed",
+			apperr.PreconditionFailed("stale"),
+			Error{Status: http.StatusPreconditionFailed, Code: apperr.CodePreconditionFailed, Message: "stale"},
+		},
+		{
+			"precondition required",
+			apperr.PreconditionRequired("send If-Match"),
+			Error{Status: http.StatusPreconditionRequired, Code: apperr.CodePreconditionRequired, Message: "send If-Match"},
+		},
+		{
+			"idempotency key reused",
+			apperr.IdempotencyKeyReused("different payload"),
+			Error{Status: http.StatusUnprocessableEntity, Code: apperr.CodeIdempotencyKeyReused, Message: "different payload"},
+		},
+		{
+			"gofr missing param",
+			gofrhttp.ErrorMissingParam{Params: []string{"name"}},
+			Error{
+				Status: http.StatusBadRequest, Code: apperr.CodeValidation, Message: "invalid name",
+				Details: []apperr.FieldError{{Field: "name", Reason: "required"}},
+			},
+		},
+		{
+			"gofr invalid param without params",
+			gofrhttp.ErrorInvalidParam{},
+			Error{Status: http.StatusBadRequest, Code: apperr.CodeValidation, Message: "invalid request body"},
+		},
+		{
+			"unknown error",
+			utils.ErrTest,
+			Error{Status: http.StatusInternalServerError, Code: apperr.CodeInternal, Message: "internal server error"},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.description, func(t *testing.T) {
+			got := From(tc.err)
+			if !reflect.DeepEqual(got, tc.want) {
+				t.Errorf("expected %+v, got %+v", tc.want, got)
+			}
+		})
+	}
+}
+
+func TestError_Response(t *testing.T) {
+	err := From(apperr.Validation(apperr.Field("desc", "must not be empty")))
+
+	want := map[string]any{
+		"code":    apperr.CodeValidation,
+		"details": []apperr.FieldError{{Field: "desc", Reason: "must not be empty"}},
+	}
+	if got := err.Response(); !reflect.DeepEqual(got, want) {
+		t.Errorf("expected %v, got %v", want, got)
+	}
+
+	if got := From(apperr.NotFound("task", 1)).Response(); !reflect.DeepEqual(got, map[string]any{"code": apperr.CodeNotFound}) {
+		t.Errorf("expected only a code, got %v", got)
+	}
+}
+
+func TestWrite(t *testing.T) {
+	w := httptest.NewRecorder()
+
+	Write(w, apperr.Conflict("in progress"))
+
+	if w.Code != http.StatusConflict || w.Header().Get("Content-Type") != "application/json" {
+		t.Errorf("expected a 409 JSON response, got %d %q", w.Code, w.Header().Get("Content-Type"))
+	}
+
+	if want := `{"error":{"code":"conflict","message":"in progress"}}` + "\n"; w.Body.String() != want {
+		t.Errorf("expected body %s, got %s", want, w.Body.String())
+	}
+}
+
+func TestHandle(t *testing.T) {
+	mockContainer, _ := container.NewMockContainer(t)
+	ctx := &gofr.Context{Context: t.Context(), Container: mockContainer}
+
+	res, err := Handle(func(*gofr.Context) (any, error) { return "ok", nil })(ctx)
+	if res != "ok" || err != nil {
+		t.Errorf("expected ok and no error, got %v, %v", res, err)
+	}
+
+	_, err = Handle(func(*gofr.Context) (any, error) { return nil, apperr.NotFound("task", 1) })(ctx)
+	if !reflect.DeepEqual(err, From(apperr.NotFound("task", 1))) {
+		t.Errorf("expected mapped not found error, got %v", err)
+	}
+
+	_, err = Handle(func(*gofr.Context) (any, error) { return nil, utils.ErrTest })(ctx)
+	if !reflect.DeepEqual(err, From(utils.ErrTest)) {
+		t.Errorf("expected internal error, got %v", err)
+	}
+}
@@ -0,0 +1,54 @@
+package inbound
+
+import (
+	"crypto/subtle"
+
+	"gofr.dev/pkg/gofr"
+
+	"TaskManager2/apperr"
+	"TaskManager2/middleware"
+)
+
+// TokenHeader carries the shared secret of the mail gateway.
+const TokenHeader = "X-Inbound-Token"
+
+var (
+	errInvalidBody  = apperr.Validation(apperr.Field("message", "must be a raw email, or base64 in a JSON object"))
+	errInvalidToken = apperr.Forbidden("inbound email needs a valid " + TokenHeader + " header")
+)
+
+type handler struct {
+	service Service
+	token   string
+}
+
+// New returns a handler for emails posted by a mail gateway, which sends the
+// token in the TokenHeader header. Anything can be posted without it, so an
+// empty token rejects every request.
+func New(service Service, token string) *handler {
+	return &handler{service: service, token: token}
+}
+
+// Post receives an email. The middleware.RawBody middleware turns a raw
+// message/rfc822 body into the JSON object bound here.
+func (h *handler) Post(ctx *gofr.Context) (any, error) {
+	if h.token == "" || subtle.ConstantTimeCompare([]byte(middleware.Header(ctx, TokenHeader)), []byte(h.token)) != 1 {
+		return nil, errInvalidToken
+	}
+
+	var body struct {
+		Message []byte `json:"message"`
+	}
+
+	err := ctx.Bind(&body)
+	if err != nil || len(body.Message) == 0 {
+		return nil, errInvalidBody
+	}
+
+	received, err := h.service.Receive(ctx, body.Message)
+	if err != nil {
+		return nil, err
+	}
+
+	return received, nil
+}
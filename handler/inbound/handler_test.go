@@ -0,0 +1,112 @@
+package inbound
+
+import (
+	"bytes"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"go.uber.org/mock/gomock"
+	"gofr.dev/pkg/gofr"
+	"gofr.dev/pkg/gofr/container"
+	gofrhttp "gofr.dev/pkg/gofr/http"
+
+	"TaskManager2/middleware"
+	"TaskManager2/models"
+	"TaskManager2/utils"
+)
+
+func TestHandler_Post(t *testing.T) {
+	controller := gomock.NewController(t)
+	mockSvc := NewMockService(controller)
+	inboundHandler := New(mockSvc, "secret")
+
+	mockContainer, _ := container.NewMockContainer(t)
+
+	ctx := &gofr.Context{
+		Context:   t.Context(),
+		Request:   nil,
+		Container: mockContainer,
+	}
+
+	received := &models.InboundEmail{ID: 5, MessageID: "one@example.com", UserID: 1, TaskID: 9}
+
+	testcases := []struct {
+		name             string
+		token            string
+		requestBody      string
+		mockExpect       func()
+		expectedResponse any
+		expectedError    error
+	}{
+		{
+			"success",
+			"secret",
+			`{"message": "SGk="}`,
+			func() {
+				mockSvc.EXPECT().Receive(ctx, []byte("Hi")).Return(received, nil)
+			},
+			received,
+			nil,
+		},
+		{
+			"wrong token",
+			"guess",
+			`{"message": "SGk="}`,
+			func() {},
+			nil,
+			errInvalidToken,
+		},
+		{
+			"empty message",
+			"secret",
+			`{}`,
+			func() {},
+			nil,
+			errInvalidBody,
+		},
+		{
+			"service error",
+			"secret",
+			`{"message": "SGk="}`,
+			func() {
+				mockSvc.EXPECT().Receive(ctx, []byte("Hi")).Return(nil, utils.ErrTest)
+			},
+			nil,
+			utils.ErrTest,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.mockExpect()
+
+			req := httptest.NewRequest(http.MethodPost, "/inbound/email", bytes.NewReader([]byte(tc.requestBody)))
+			req.Header.Set("Content-Type", "application/json")
+			req.Header.Set(TokenHeader, tc.token)
+			req = withRequestMetadata(req)
+			ctx.Context = req.Context()
+			ctx.Request = gofrhttp.NewRequest(req)
+
+			res, err := inboundHandler.Post(ctx)
+			if !errors.Is(err, tc.expectedError) {
+				t.Errorf("error, expected %v, got %v", tc.expectedError, err)
+			}
+
+			if !reflect.DeepEqual(res, tc.expectedResponse) {
+				t.Errorf("expected: %v, got: %v", tc.expectedResponse, res)
+			}
+		})
+	}
+}
+
+// withRequestMetadata passes req through the RequestMetadata middleware so that handlers can read its headers.
+func withRequestMetadata(req *http.Request) *http.Request {
+	middleware.RequestMetadata(http.HandlerFunc(func(_ http.ResponseWriter, r *http.Request) {
+		req = r
+	})).ServeHTTP(httptest.NewRecorder(), req)
+
+	return req
+}
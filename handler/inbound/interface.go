@@ -0,0 +1,11 @@
+package inbound
+
+import (
+	"gofr.dev/pkg/gofr"
+
+	"TaskManager2/models"
+)
+
+type Service interface {
+	Receive(*gofr.Context, []byte) (*models.InboundEmail, error)
+}
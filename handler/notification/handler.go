@@ -0,0 +1,78 @@
+package notification
+
+import (
+	"strconv"
+
+	"gofr.dev/pkg/gofr"
+
+	"TaskManager2/apperr"
+)
+
+var (
+	errInvalidID             = apperr.Validation(apperr.Field("id", "must be an integer"))
+	errInvalidNotificationID = apperr.Validation(apperr.Field("notification_id", "must be an integer"))
+	errInvalidUnread         = apperr.Validation(apperr.Field("unread", "must be true or false"))
+	errInvalidLimit          = apperr.Validation(apperr.Field("limit", "must be an integer"))
+)
+
+type handler struct {
+	service Service
+}
+
+func New(service Service) *handler {
+	return &handler{service: service}
+}
+
+// GetByUser lists the in-app notifications of the user, only the unread ones
+// when the unread query parameter is true.
+func (h *handler) GetByUser(ctx *gofr.Context) (any, error) {
+	id, err := strconv.Atoi(ctx.PathParam("id"))
+	if err != nil {
+		return nil, errInvalidID
+	}
+
+	var (
+		unread bool
+		limit  int
+	)
+
+	if v := ctx.Param("unread"); v != "" {
+		unread, err = strconv.ParseBool(v)
+		if err != nil {
+			return nil, errInvalidUnread
+		}
+	}
+
+	if v := ctx.Param("limit"); v != "" {
+		limit, err = strconv.Atoi(v)
+		if err != nil {
+			return nil, errInvalidLimit
+		}
+	}
+
+	notifications, err := h.service.GetByUser(ctx, int64(id), unread, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	return notifications, nil
+}
+
+func (h *handler) MarkRead(ctx *gofr.Context) (any, error) {
+	id, err := strconv.Atoi(ctx.PathParam("id"))
+	if err != nil {
+		return nil, errInvalidID
+	}
+
+	notificationID, err := strconv.Atoi(ctx.PathParam("notification_id"))
+	if err != nil {
+		return nil, errInvalidNotificationID
+	}
+
+	err = h.service.MarkRead(ctx, int64(id), int64(notificationID))
+	if err != nil {
+		return nil, err
+	}
+
+	return nil, nil
+}
@@ -0,0 +1,187 @@
+package notification
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"github.com/gorilla/mux"
+	"go.uber.org/mock/gomock"
+	"gofr.dev/pkg/gofr"
+	"gofr.dev/pkg/gofr/container"
+	gofrhttp "gofr.dev/pkg/gofr/http"
+
+	"TaskManager2/apperr"
+	"TaskManager2/models"
+)
+
+func TestHandler_GetByUser(t *testing.T) {
+	controller := gomock.NewController(t)
+	mockSvc := NewMockService(controller)
+	notificationHandler := New(mockSvc)
+
+	mockContainer, _ := container.NewMockContainer(t)
+
+	ctx := &gofr.Context{
+		Context:   t.Context(),
+		Request:   nil,
+		Container: mockContainer,
+	}
+
+	notifications := []models.Notification{{ID: 2, UserID: 1, TaskID: 9, ReminderID: 3, Message: "Reminder: Ship it"}}
+
+	testcases := []struct {
+		name             string
+		requestID        string
+		query            string
+		mockExpect       func()
+		expectedResponse any
+		expectedError    error
+	}{
+		{
+			"success",
+			"1",
+			"?unread=true&limit=10",
+			func() {
+				mockSvc.EXPECT().GetByUser(ctx, int64(1), true, 10).Return(notifications, nil)
+			},
+			notifications,
+			nil,
+		},
+		{
+			"defaults",
+			"1",
+			"",
+			func() {
+				mockSvc.EXPECT().GetByUser(ctx, int64(1), false, 0).Return(notifications, nil)
+			},
+			notifications,
+			nil,
+		},
+		{
+			"invalid id",
+			"abc",
+			"",
+			func() {},
+			nil,
+			errInvalidID,
+		},
+		{
+			"invalid unread",
+			"1",
+			"?unread=maybe",
+			func() {},
+			nil,
+			errInvalidUnread,
+		},
+		{
+			"invalid limit",
+			"1",
+			"?limit=many",
+			func() {},
+			nil,
+			errInvalidLimit,
+		},
+		{
+			"service error",
+			"1",
+			"",
+			func() {
+				mockSvc.EXPECT().GetByUser(ctx, int64(1), false, 0).Return(nil, apperr.NotFound("user", 1))
+			},
+			nil,
+			apperr.NotFound("user", 1),
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.mockExpect()
+
+			req := httptest.NewRequest(http.MethodGet, "/user/{id}/notifications"+tc.query, http.NoBody)
+			req = mux.SetURLVars(req, map[string]string{"id": tc.requestID})
+			ctx.Request = gofrhttp.NewRequest(req)
+
+			res, err := notificationHandler.GetByUser(ctx)
+			if !errors.Is(err, tc.expectedError) {
+				t.Errorf("error, expected %v, got %v", tc.expectedError, err)
+			}
+
+			if !reflect.DeepEqual(res, tc.expectedResponse) {
+				t.Errorf("expected: %v, got: %v", tc.expectedResponse, res)
+			}
+		})
+	}
+}
+
+func TestHandler_MarkRead(t *testing.T) {
+	controller := gomock.NewController(t)
+	mockSvc := NewMockService(controller)
+	notificationHandler := New(mockSvc)
+
+	mockContainer, _ := container.NewMockContainer(t)
+
+	ctx := &gofr.Context{
+		Context:   t.Context(),
+		Request:   nil,
+		Container: mockContainer,
+	}
+
+	testcases := []struct {
+		name           string
+		requestID      string
+		notificationID string
+		mockExpect     func()
+		expectedError  error
+	}{
+		{
+			"success",
+			"1",
+			"2",
+			func() {
+				mockSvc.EXPECT().MarkRead(ctx, int64(1), int64(2)).Return(nil)
+			},
+			nil,
+		},
+		{
+			"invalid id",
+			"abc",
+			"2",
+			func() {},
+			errInvalidID,
+		},
+		{
+			"invalid notification id",
+			"1",
+			"abc",
+			func() {},
+			errInvalidNotificationID,
+		},
+		{
+			"not found",
+			"1",
+			"2",
+			func() {
+				mockSvc.EXPECT().MarkRead(ctx, int64(1), int64(2)).Return(apperr.NotFound("notification", 2))
+			},
+			apperr.NotFound("notification", 2),
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.mockExpect()
+
+			req := httptest.NewRequest(http.MethodPost, "/user/{id}/notifications/{notification_id}/read", http.NoBody)
+			req = mux.SetURLVars(req, map[string]string{"id": tc.requestID, "notification_id": tc.notificationID})
+			ctx.Request = gofrhttp.NewRequest(req)
+
+			_, err := notificationHandler.MarkRead(ctx)
+			if !errors.Is(err, tc.expectedError) {
+				t.Errorf("error, expected %v, got %v", tc.expectedError, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,12 @@
+package notification
+
+import (
+	"gofr.dev/pkg/gofr"
+
+	"TaskManager2/models"
+)
+
+type Service interface {
+	GetByUser(*gofr.Context, int64, bool, int) ([]models.Notification, error)
+	MarkRead(*gofr.Context, int64, int64) error
+}
@@ -0,0 +1,80 @@
+package reminder
+
+import (
+	"strconv"
+
+	"gofr.dev/pkg/gofr"
+
+	"TaskManager2/apperr"
+	"TaskManager2/models"
+)
+
+var (
+	errInvalidBody       = apperr.Validation(apperr.Field("body", "must be a JSON object"))
+	errInvalidID         = apperr.Validation(apperr.Field("id", "must be an integer"))
+	errInvalidReminderID = apperr.Validation(apperr.Field("reminder_id", "must be an integer"))
+)
+
+type handler struct {
+	service Service
+}
+
+func New(service Service) *handler {
+	return &handler{service: service}
+}
+
+func (h *handler) Post(ctx *gofr.Context) (any, error) {
+	id, err := strconv.Atoi(ctx.PathParam("id"))
+	if err != nil {
+		return nil, errInvalidID
+	}
+
+	var r models.Reminder
+
+	err = ctx.Bind(&r)
+	if err != nil {
+		return nil, errInvalidBody
+	}
+
+	r.TaskID = int64(id)
+
+	created, err := h.service.Create(ctx, &r)
+	if err != nil {
+		return nil, err
+	}
+
+	return created, nil
+}
+
+func (h *handler) GetByTask(ctx *gofr.Context) (any, error) {
+	id, err := strconv.Atoi(ctx.PathParam("id"))
+	if err != nil {
+		return nil, errInvalidID
+	}
+
+	reminders, err := h.service.GetByTask(ctx, int64(id))
+	if err != nil {
+		return nil, err
+	}
+
+	return reminders, nil
+}
+
+func (h *handler) Delete(ctx *gofr.Context) (any, error) {
+	id, err := strconv.Atoi(ctx.PathParam("id"))
+	if err != nil {
+		return nil, errInvalidID
+	}
+
+	reminderID, err := strconv.Atoi(ctx.PathParam("reminder_id"))
+	if err != nil {
+		return nil, errInvalidReminderID
+	}
+
+	err = h.service.Delete(ctx, int64(id), int64(reminderID))
+	if err != nil {
+		return nil, err
+	}
+
+	return nil, nil
+}
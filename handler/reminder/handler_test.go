@@ -0,0 +1,242 @@
+package reminder
+
+import (
+	"bytes"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"github.com/gorilla/mux"
+	"go.uber.org/mock/gomock"
+	"gofr.dev/pkg/gofr"
+	"gofr.dev/pkg/gofr/container"
+	gofrhttp "gofr.dev/pkg/gofr/http"
+
+	"TaskManager2/apperr"
+	"TaskManager2/models"
+	"TaskManager2/utils"
+)
+
+func TestHandler_Post(t *testing.T) {
+	controller := gomock.NewController(t)
+	mockSvc := NewMockService(controller)
+	reminderHandler := New(mockSvc)
+
+	mockContainer, _ := container.NewMockContainer(t)
+
+	ctx := &gofr.Context{
+		Context:   t.Context(),
+		Request:   nil,
+		Container: mockContainer,
+	}
+
+	before := 30
+	created := &models.Reminder{ID: 5, TaskID: 3, MinutesBeforeDue: &before, Channel: "email", Status: "pending"}
+
+	testcases := []struct {
+		name             string
+		requestID        string
+		requestBody      string
+		mockExpect       func()
+		expectedResponse any
+		expectedError    error
+	}{
+		{
+			"success",
+			"3",
+			`{"minutes_before_due": 30, "channel": "email"}`,
+			func() {
+				mockSvc.EXPECT().Create(ctx, &models.Reminder{TaskID: 3, MinutesBeforeDue: &before, Channel: "email"}).Return(created, nil)
+			},
+			created,
+			nil,
+		},
+		{
+			"invalid id",
+			"abc",
+			`{"minutes_before_due": 30, "channel": "email"}`,
+			func() {},
+			nil,
+			errInvalidID,
+		},
+		{
+			"bind error",
+			"3",
+			`{"channel":`,
+			func() {},
+			nil,
+			errInvalidBody,
+		},
+		{
+			"service error",
+			"3",
+			`{"channel": "email"}`,
+			func() {
+				mockSvc.EXPECT().Create(ctx, &models.Reminder{TaskID: 3, Channel: "email"}).Return(nil, utils.ErrTest)
+			},
+			nil,
+			utils.ErrTest,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.mockExpect()
+
+			req := httptest.NewRequest(http.MethodPost, "/task/{id}/reminders", bytes.NewReader([]byte(tc.requestBody)))
+			req.Header.Set("Content-Type", "application/json")
+			req = mux.SetURLVars(req, map[string]string{"id": tc.requestID})
+			ctx.Request = gofrhttp.NewRequest(req)
+
+			res, err := reminderHandler.Post(ctx)
+			if !errors.Is(err, tc.expectedError) {
+				t.Errorf("error, expected %v, got %v", tc.expectedError, err)
+			}
+
+			if !reflect.DeepEqual(res, tc.expectedResponse) {
+				t.Errorf("expected: %v, got: %v", tc.expectedResponse, res)
+			}
+		})
+	}
+}
+
+func TestHandler_GetByTask(t *testing.T) {
+	controller := gomock.NewController(t)
+	mockSvc := NewMockService(controller)
+	reminderHandler := New(mockSvc)
+
+	mockContainer, _ := container.NewMockContainer(t)
+
+	ctx := &gofr.Context{
+		Context:   t.Context(),
+		Request:   nil,
+		Container: mockContainer,
+	}
+
+	reminders := []models.Reminder{{ID: 5, TaskID: 3, Channel: "in_app", Status: "sent"}}
+
+	testcases := []struct {
+		name             string
+		requestID        string
+		mockExpect       func()
+		expectedResponse any
+		expectedError    error
+	}{
+		{
+			"success",
+			"3",
+			func() {
+				mockSvc.EXPECT().GetByTask(ctx, int64(3)).Return(reminders, nil)
+			},
+			reminders,
+			nil,
+		},
+		{
+			"invalid id",
+			"abc",
+			func() {},
+			nil,
+			errInvalidID,
+		},
+		{
+			"service error",
+			"3",
+			func() {
+				mockSvc.EXPECT().GetByTask(ctx, int64(3)).Return(nil, apperr.NotFound("task", 3))
+			},
+			nil,
+			apperr.NotFound("task", 3),
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.mockExpect()
+
+			req := httptest.NewRequest(http.MethodGet, "/task/{id}/reminders", http.NoBody)
+			req = mux.SetURLVars(req, map[string]string{"id": tc.requestID})
+			ctx.Request = gofrhttp.NewRequest(req)
+
+			res, err := reminderHandler.GetByTask(ctx)
+			if !errors.Is(err, tc.expectedError) {
+				t.Errorf("error, expected %v, got %v", tc.expectedError, err)
+			}
+
+			if !reflect.DeepEqual(res, tc.expectedResponse) {
+				t.Errorf("expected: %v, got: %v", tc.expectedResponse, res)
+			}
+		})
+	}
+}
+
+func TestHandler_Delete(t *testing.T) {
+	controller := gomock.NewController(t)
+	mockSvc := NewMockService(controller)
+	reminderHandler := New(mockSvc)
+
+	mockContainer, _ := container.NewMockContainer(t)
+
+	ctx := &gofr.Context{
+		Context:   t.Context(),
+		Request:   nil,
+		Container: mockContainer,
+	}
+
+	testcases := []struct {
+		name          string
+		requestID     string
+		reminderID    string
+		mockExpect    func()
+		expectedError error
+	}{
+		{
+			"success",
+			"3",
+			"5",
+			func() {
+				mockSvc.EXPECT().Delete(ctx, int64(3), int64(5)).Return(nil)
+			},
+			nil,
+		},
+		{
+			"invalid id",
+			"abc",
+			"5",
+			func() {},
+			errInvalidID,
+		},
+		{
+			"invalid reminder id",
+			"3",
+			"abc",
+			func() {},
+			errInvalidReminderID,
+		},
+		{
+			"service error",
+			"3",
+			"5",
+			func() {
+				mockSvc.EXPECT().Delete(ctx, int64(3), int64(5)).Return(apperr.NotFound("reminder", 5))
+			},
+			apperr.NotFound("reminder", 5),
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.mockExpect()
+
+			req := httptest.NewRequest(http.MethodDelete, "/task/{id}/reminders/{reminder_id}", http.NoBody)
+			req = mux.SetURLVars(req, map[string]string{"id": tc.requestID, "reminder_id": tc.reminderID})
+			ctx.Request = gofrhttp.NewRequest(req)
+
+			_, err := reminderHandler.Delete(ctx)
+			if !errors.Is(err, tc.expectedError) {
+				t.Errorf("error, expected %v, got %v", tc.expectedError, err)
+			}
+		})
+	}
+}
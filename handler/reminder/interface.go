@@ -0,0 +1,13 @@
+package reminder
+
+import (
+	"gofr.dev/pkg/gofr"
+
+	"TaskManager2/models"
+)
+
+type Service interface {
+	Create(*gofr.Context, *models.Reminder) (*models.Reminder, error)
+	GetByTask(*gofr.Context, int64) ([]models.Reminder, error)
+	Delete(*gofr.Context, int64, int64) error
+}
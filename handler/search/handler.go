@@ -0,0 +1,38 @@
+package search
+
+import (
+	"strconv"
+
+	"gofr.dev/pkg/gofr"
+
+	"TaskManager2/apperr"
+)
+
+type handler struct {
+	service Service
+}
+
+func New(service Service) *handler {
+	return &handler{service: service}
+}
+
+// Get searches tasks and comments for the q query parameter.
+func (h *handler) Get(ctx *gofr.Context) (any, error) {
+	var limit int
+
+	if v := ctx.Param("limit"); v != "" {
+		var err error
+
+		limit, err = strconv.Atoi(v)
+		if err != nil {
+			return nil, apperr.Validation(apperr.Field("limit", "must be an integer"))
+		}
+	}
+
+	hits, err := h.service.Search(ctx, ctx.Param("q"), limit)
+	if err != nil {
+		return nil, err
+	}
+
+	return hits, nil
+}
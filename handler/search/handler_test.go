@@ -0,0 +1,95 @@
+package search
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"go.uber.org/mock/gomock"
+	"gofr.dev/pkg/gofr"
+	"gofr.dev/pkg/gofr/container"
+	gofrhttp "gofr.dev/pkg/gofr/http"
+
+	"TaskManager2/apperr"
+	"TaskManager2/models"
+	"TaskManager2/utils"
+)
+
+func TestHandler_Get(t *testing.T) {
+	controller := gomock.NewController(t)
+	mockSvc := NewMockService(controller)
+	searchHandler := New(mockSvc)
+
+	mockContainer, _ := container.NewMockContainer(t)
+
+	ctx := &gofr.Context{
+		Context:   t.Context(),
+		Request:   nil,
+		Container: mockContainer,
+	}
+
+	hits := []models.SearchHit{{Kind: "task", ID: 1, TaskID: 1, Score: 1, Snippet: "<mark>Deploy</mark> it"}}
+
+	testcases := []struct {
+		name             string
+		query            string
+		mockExpect       func()
+		expectedResponse any
+		expectedError    error
+	}{
+		{
+			"success",
+			"?q=%22release+notes%22",
+			func() {
+				mockSvc.EXPECT().Search(ctx, `"release notes"`, 0).Return(hits, nil)
+			},
+			hits,
+			nil,
+		},
+		{
+			"with limit",
+			"?q=deploy&limit=5",
+			func() {
+				mockSvc.EXPECT().Search(ctx, "deploy", 5).Return(hits, nil)
+			},
+			hits,
+			nil,
+		},
+		{
+			"invalid limit",
+			"?q=deploy&limit=five",
+			func() {},
+			nil,
+			apperr.Validation(apperr.Field("limit", "must be an integer")),
+		},
+		{
+			"service error",
+			"?q=deploy",
+			func() {
+				mockSvc.EXPECT().Search(ctx, "deploy", 0).Return(nil, utils.ErrTest)
+			},
+			nil,
+			utils.ErrTest,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.mockExpect()
+
+			req := httptest.NewRequest(http.MethodGet, "/search"+tc.query, http.NoBody)
+			ctx.Request = gofrhttp.NewRequest(req)
+
+			res, err := searchHandler.Get(ctx)
+			if !errors.Is(err, tc.expectedError) {
+				t.Errorf("error, expected %v, got %v", tc.expectedError, err)
+			}
+
+			if !reflect.DeepEqual(res, tc.expectedResponse) {
+				t.Errorf("expected: %v, got: %v", tc.expectedResponse, res)
+			}
+		})
+	}
+}
@@ -0,0 +1,11 @@
+package search
+
+import (
+	"gofr.dev/pkg/gofr"
+
+	"TaskManager2/models"
+)
+
+type Service interface {
+	Search(*gofr.Context, string, int) ([]models.SearchHit, error)
+}
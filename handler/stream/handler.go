@@ -0,0 +1,200 @@
+package stream
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"gofr.dev/pkg/gofr"
+	"gofr.dev/pkg/gofr/container"
+
+	"TaskManager2/apperr"
+	"TaskManager2/handler/httperr"
+	"TaskManager2/models"
+)
+
+const (
+	// Path is answered by Middleware. It must also be registered as a route,
+	// since the router only runs middleware for requests that match one.
+	Path = "/events/stream"
+
+	lastEventIDHeader = "Last-Event-ID"
+
+	// The log also carries the board signals of the collaboration channel,
+	// which are not streamed.
+	taskEventPrefix = "task."
+
+	heartbeat = 15 * time.Second
+	retryMs   = 3000
+)
+
+var (
+	errInvalidUserID      = apperr.Validation(apperr.Field("user_id", "must be an integer"))
+	errInvalidParentID    = apperr.Validation(apperr.Field("parent_id", "must be an integer"))
+	errInvalidLastEventID = apperr.Validation(apperr.Field(lastEventIDHeader, "must be an integer"))
+)
+
+type handler struct {
+	service Service
+}
+
+func New(service Service) *handler {
+	return &handler{service: service}
+}
+
+// Middleware streams task events to GET /events/stream as Server-Sent Events.
+// gofr handlers return a single response, so the stream is served before
+// the request reaches one. A client resuming with Last-Event-ID first gets the
+// events it missed, preceded by a reset event when some of them are no longer
+// kept.
+func (h *handler) Middleware(c *container.Container, inner http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet || r.URL.Path != Path {
+			inner.ServeHTTP(w, r)
+
+			return
+		}
+
+		filter, lastEventID, err := parse(r)
+		if err != nil {
+			httperr.Write(w, err)
+
+			return
+		}
+
+		ctx := &gofr.Context{Context: r.Context(), Container: c}
+
+		live, cancel, err := h.service.Subscribe(ctx, filter)
+		if err != nil {
+			c.Logger.Errorf("subscribing to events: %v", err)
+			httperr.Write(w, err)
+
+			return
+		}
+
+		defer cancel()
+
+		var replayed []models.StreamEvent
+
+		complete := true
+
+		if lastEventID != nil {
+			replayed, complete, err = h.service.Replay(ctx, *lastEventID, filter)
+			if err != nil {
+				c.Logger.Errorf("replaying events: %v", err)
+				httperr.Write(w, err)
+
+				return
+			}
+		}
+
+		h.serve(w, r, live, replayed, complete)
+	})
+}
+
+func (*handler) serve(w http.ResponseWriter, r *http.Request, live <-chan models.StreamEvent, replayed []models.StreamEvent,
+	complete bool) {
+	rc := http.NewResponseController(w)
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("X-Accel-Buffering", "no")
+	w.WriteHeader(http.StatusOK)
+
+	_, _ = fmt.Fprintf(w, "retry: %d\n\n", retryMs)
+
+	if !complete {
+		_, _ = fmt.Fprint(w, "event: reset\ndata: {}\n\n")
+	}
+
+	var replayedUpTo int64
+
+	for i := range replayed {
+		write(w, &replayed[i])
+		replayedUpTo = replayed[i].ID
+	}
+
+	if rc.Flush() != nil {
+		return
+	}
+
+	ticker := time.NewTicker(heartbeat)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+		case e, ok := <-live:
+			if !ok {
+				return
+			}
+
+			if e.ID <= replayedUpTo {
+				continue
+			}
+
+			write(w, &e)
+		case <-ticker.C:
+			_, _ = fmt.Fprint(w, ": ping\n\n")
+		}
+
+		if rc.Flush() != nil {
+			return
+		}
+	}
+}
+
+func write(w http.ResponseWriter, e *models.StreamEvent) {
+	_, _ = fmt.Fprintf(w, "id: %d\nevent: %s\ndata: %s\n\n", e.ID, e.Event, e.Payload)
+}
+
+// parse reads the filter from the query and the event to resume after from
+// the Last-Event-ID header, or the last_event_id parameter for clients that
+// cannot set headers.
+func parse(r *http.Request) (models.StreamFilter, *int64, error) {
+	filter := models.StreamFilter{Prefix: taskEventPrefix}
+
+	query := r.URL.Query()
+
+	if v := query.Get("user_id"); v != "" {
+		id, err := strconv.ParseInt(v, 10, 64)
+		if err != nil {
+			return filter, nil, errInvalidUserID
+		}
+
+		filter.UserID = id
+	}
+
+	if v := query.Get("parent_id"); v != "" {
+		id, err := strconv.ParseInt(v, 10, 64)
+		if err != nil {
+			return filter, nil, errInvalidParentID
+		}
+
+		filter.ParentID = id
+	}
+
+	v := r.Header.Get(lastEventIDHeader)
+	if v == "" {
+		v = query.Get("last_event_id")
+	}
+
+	if v == "" {
+		return filter, nil, nil
+	}
+
+	id, err := strconv.ParseInt(v, 10, 64)
+	if err != nil {
+		return filter, nil, errInvalidLastEventID
+	}
+
+	return filter, &id, nil
+}
+
+// Unreachable is the route handler for Path. Middleware answers the requests
+// first, so it only runs if the middleware is not installed.
+func Unreachable(*gofr.Context) (any, error) {
+	return nil, apperr.NotFound("route", Path)
+}
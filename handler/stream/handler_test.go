@@ -0,0 +1,191 @@
+package stream
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"go.uber.org/mock/gomock"
+	"gofr.dev/pkg/gofr"
+	"gofr.dev/pkg/gofr/container"
+
+	"TaskManager2/models"
+	"TaskManager2/utils"
+)
+
+func TestHandler_Middleware(t *testing.T) {
+	mockContainer, _ := container.NewMockContainer(t)
+	controller := gomock.NewController(t)
+	mockSvc := NewMockService(controller)
+
+	next := http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusTeapot)
+	})
+	server := New(mockSvc).Middleware(mockContainer, next)
+
+	replayed := []models.StreamEvent{
+		{ID: 11, Event: "task.created", UserID: 1, Payload: []byte(`{"id":"e11"}`)},
+		{ID: 12, Event: "task.updated", UserID: 1, Payload: []byte(`{"id":"e12"}`)},
+	}
+
+	testcases := []struct {
+		description    string
+		target         string
+		lastEventID    string
+		mockExpect     func(chan models.StreamEvent)
+		live           []models.StreamEvent
+		expectedStatus int
+		expectedBody   string
+	}{
+		{
+			"live events",
+			"/events/stream?user_id=1&parent_id=2",
+			"",
+			func(ch chan models.StreamEvent) {
+				mockSvc.EXPECT().Subscribe(gomock.Any(), models.StreamFilter{Prefix: "task.", UserID: 1, ParentID: 2}).
+					Return(ch, func() {}, nil)
+			},
+			[]models.StreamEvent{{ID: 13, Event: "task.deleted", Payload: []byte(`{"id":"e13"}`)}},
+			http.StatusOK,
+			"retry: 3000\n\nid: 13\nevent: task.deleted\ndata: {\"id\":\"e13\"}\n\n",
+		},
+		{
+			"resume",
+			"/events/stream?user_id=1",
+			"10",
+			func(ch chan models.StreamEvent) {
+				mockSvc.EXPECT().Subscribe(gomock.Any(), models.StreamFilter{Prefix: "task.", UserID: 1}).Return(ch, func() {}, nil)
+				mockSvc.EXPECT().Replay(gomock.Any(), int64(10), models.StreamFilter{Prefix: "task.", UserID: 1}).Return(replayed, true, nil)
+			},
+			[]models.StreamEvent{replayed[1], {ID: 13, Event: "task.deleted", Payload: []byte(`{"id":"e13"}`)}},
+			http.StatusOK,
+			"retry: 3000\n\nid: 11\nevent: task.created\ndata: {\"id\":\"e11\"}\n\nid: 12\nevent: task.updated\ndata: {\"id\":\"e12\"}\n\n" +
+				"id: 13\nevent: task.deleted\ndata: {\"id\":\"e13\"}\n\n",
+		},
+		{
+			"resume after pruning",
+			"/events/stream?last_event_id=1",
+			"",
+			func(ch chan models.StreamEvent) {
+				mockSvc.EXPECT().Subscribe(gomock.Any(), models.StreamFilter{Prefix: "task."}).Return(ch, func() {}, nil)
+				mockSvc.EXPECT().Replay(gomock.Any(), int64(1), models.StreamFilter{Prefix: "task."}).Return(replayed[1:], false, nil)
+			},
+			nil,
+			http.StatusOK,
+			"retry: 3000\n\nevent: reset\ndata: {}\n\nid: 12\nevent: task.updated\ndata: {\"id\":\"e12\"}\n\n",
+		},
+		{
+			"replay error",
+			"/events/stream",
+			"10",
+			func(ch chan models.StreamEvent) {
+				mockSvc.EXPECT().Subscribe(gomock.Any(), models.StreamFilter{Prefix: "task."}).Return(ch, func() {}, nil)
+				mockSvc.EXPECT().Replay(gomock.Any(), int64(10), models.StreamFilter{Prefix: "task."}).Return(nil, false, utils.ErrTest)
+			},
+			nil,
+			http.StatusInternalServerError,
+			"",
+		},
+		{
+			"subscribe error",
+			"/events/stream",
+			"",
+			func(chan models.StreamEvent) {
+				mockSvc.EXPECT().Subscribe(gomock.Any(), models.StreamFilter{Prefix: "task."}).Return(nil, nil, utils.ErrTest)
+			},
+			nil,
+			http.StatusInternalServerError,
+			"",
+		},
+		{
+			"invalid filter",
+			"/events/stream?user_id=me",
+			"",
+			func(chan models.StreamEvent) {},
+			nil,
+			http.StatusBadRequest,
+			"",
+		},
+		{
+			"invalid last event id",
+			"/events/stream",
+			"latest",
+			func(chan models.StreamEvent) {},
+			nil,
+			http.StatusBadRequest,
+			"",
+		},
+		{
+			"other routes",
+			"/task",
+			"",
+			func(chan models.StreamEvent) {},
+			nil,
+			http.StatusTeapot,
+			"",
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.description, func(t *testing.T) {
+			// The stream ends when the subscription channel is closed.
+			ch := make(chan models.StreamEvent, len(tc.live))
+			for _, e := range tc.live {
+				ch <- e
+			}
+
+			close(ch)
+			tc.mockExpect(ch)
+
+			req := httptest.NewRequest(http.MethodGet, tc.target, http.NoBody)
+			if tc.lastEventID != "" {
+				req.Header.Set("Last-Event-ID", tc.lastEventID)
+			}
+
+			rec := httptest.NewRecorder()
+			server.ServeHTTP(rec, req)
+
+			if rec.Code != tc.expectedStatus {
+				t.Fatalf("expected status %d, got %d", tc.expectedStatus, rec.Code)
+			}
+
+			if tc.expectedStatus == http.StatusOK {
+				if rec.Header().Get("Content-Type") != "text/event-stream" || rec.Body.String() != tc.expectedBody {
+					t.Errorf("expected stream %q, got %q", tc.expectedBody, rec.Body.String())
+				}
+			}
+		})
+	}
+}
+
+func TestHandler_MiddlewareClientGone(t *testing.T) {
+	mockContainer, _ := container.NewMockContainer(t)
+	controller := gomock.NewController(t)
+	mockSvc := NewMockService(controller)
+	server := New(mockSvc).Middleware(mockContainer, http.NotFoundHandler())
+
+	cancelled := false
+	ch := make(chan models.StreamEvent)
+
+	mockSvc.EXPECT().Subscribe(gomock.Any(), models.StreamFilter{Prefix: "task."}).Return(ch, func() { cancelled = true }, nil)
+
+	ctx, cancel := context.WithCancel(t.Context())
+	cancel()
+
+	req := httptest.NewRequestWithContext(ctx, http.MethodGet, "/events/stream", http.NoBody)
+	rec := httptest.NewRecorder()
+	server.ServeHTTP(rec, req)
+
+	if !cancelled || !strings.HasPrefix(rec.Body.String(), "retry:") {
+		t.Errorf("expected the stream to end and unsubscribe, got %q", rec.Body.String())
+	}
+}
+
+func TestUnreachable(t *testing.T) {
+	_, err := Unreachable(&gofr.Context{Context: t.Context()})
+	if err == nil {
+		t.Error("expected an error")
+	}
+}
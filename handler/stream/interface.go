@@ -0,0 +1,12 @@
+package stream
+
+import (
+	"gofr.dev/pkg/gofr"
+
+	"TaskManager2/models"
+)
+
+type Service interface {
+	Subscribe(*gofr.Context, models.StreamFilter) (<-chan models.StreamEvent, func(), error)
+	Replay(*gofr.Context, int64, models.StreamFilter) ([]models.StreamEvent, bool, error)
+}
@@ -1,12 +1,26 @@
 package task
 
 import (
+	"encoding/json"
+	"fmt"
+	"sort"
 	"strconv"
+	"strings"
+	"time"
 
 	"gofr.dev/pkg/gofr"
-	gofrhttp "gofr.dev/pkg/gofr/http"
+	"gofr.dev/pkg/gofr/http/response"
 
+	"TaskManager2/apperr"
+	"TaskManager2/middleware"
 	"TaskManager2/models"
+	"TaskManager2/query"
+)
+
+var (
+	errInvalidBody          = apperr.Validation(apperr.Field("body", "must be a JSON object"))
+	errPreconditionRequired = apperr.PreconditionRequired("the If-Match header is required, send the ETag returned by GET /task/{id}")
+	errInvalidETag          = apperr.PreconditionFailed("the If-Match header does not hold a task ETag")
 )
 
 type handler struct {
@@ -22,7 +36,7 @@ func (h *handler) Post(ctx *gofr.Context) (any, error) {
 
 	err := ctx.Bind(&task)
 	if err != nil {
-		return nil, gofrhttp.ErrorInvalidParam{}
+		return nil, errInvalidBody
 	}
 
 	id, err := h.service.Create(ctx, &task)
@@ -34,7 +48,12 @@ func (h *handler) Post(ctx *gofr.Context) (any, error) {
 }
 
 func (h *handler) GetAll(ctx *gofr.Context) (any, error) {
-	tasks, err := h.service.GetAll(ctx)
+	filter, err := parseFilter(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	tasks, err := h.service.GetAll(ctx, filter)
 	if err != nil {
 		return nil, err
 	}
@@ -42,10 +61,44 @@ func (h *handler) GetAll(ctx *gofr.Context) (any, error) {
 	return tasks, nil
 }
 
+// GetByUser lists the tasks assigned to the user, with the same filters as GetAll.
+func (h *handler) GetByUser(ctx *gofr.Context) (any, error) {
+	id, err := strconv.Atoi(ctx.PathParam("id"))
+	if err != nil {
+		return nil, apperr.Validation(apperr.Field("id", "must be an integer"))
+	}
+
+	filter, err := parseFilter(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	tasks, err := h.service.GetByUser(ctx, int64(id), filter)
+	if err != nil {
+		return nil, err
+	}
+
+	return tasks, nil
+}
+
+func (h *handler) GetUserSummary(ctx *gofr.Context) (any, error) {
+	id, err := strconv.Atoi(ctx.PathParam("id"))
+	if err != nil {
+		return nil, apperr.Validation(apperr.Field("id", "must be an integer"))
+	}
+
+	summary, err := h.service.Summary(ctx, int64(id))
+	if err != nil {
+		return nil, err
+	}
+
+	return summary, nil
+}
+
 func (h *handler) GetByID(ctx *gofr.Context) (any, error) {
 	id, err := strconv.Atoi(ctx.PathParam("id"))
 	if err != nil {
-		return nil, gofrhttp.ErrorInvalidParam{Params: []string{ctx.PathParam("id")}}
+		return nil, apperr.Validation(apperr.Field("id", "must be an integer"))
 	}
 
 	task, err := h.service.GetByID(ctx, int64(id))
@@ -53,17 +106,46 @@ func (h *handler) GetByID(ctx *gofr.Context) (any, error) {
 		return nil, err
 	}
 
-	return task, nil
+	return response.Response{Data: task, Headers: map[string]string{"ETag": etag(task.Version)}}, nil
 }
 
+// GetDescription returns the task's Markdown description rendered as sanitized HTML.
+func (h *handler) GetDescription(ctx *gofr.Context) (any, error) {
+	id, err := strconv.Atoi(ctx.PathParam("id"))
+	if err != nil {
+		return nil, apperr.Validation(apperr.Field("id", "must be an integer"))
+	}
+
+	rendered, err := h.service.RenderDescription(ctx, int64(id))
+	if err != nil {
+		return nil, err
+	}
+
+	return rendered, nil
+}
+
+// Put replaces the task, provided it is still at the version named by the If-Match header.
 func (h *handler) Put(ctx *gofr.Context) (any, error) {
+	id, err := strconv.Atoi(ctx.PathParam("id"))
+	if err != nil {
+		return nil, apperr.Validation(apperr.Field("id", "must be an integer"))
+	}
+
+	version, err := ifMatch(ctx)
+	if err != nil {
+		return nil, err
+	}
+
 	var task models.Task
 
-	err := ctx.Bind(&task)
+	err = ctx.Bind(&task)
 	if err != nil {
-		return nil, gofrhttp.ErrorInvalidParam{}
+		return nil, errInvalidBody
 	}
 
+	task.ID = int64(id)
+	task.Version = version
+
 	err = h.service.Update(ctx, &task)
 	if err != nil {
 		return nil, err
@@ -72,10 +154,48 @@ func (h *handler) Put(ctx *gofr.Context) (any, error) {
 	return nil, nil
 }
 
+// Patch applies a JSON merge patch (RFC 7396) to the task. Only title,
+// description, status and due_date can be patched, and If-Match is honoured
+// when present.
+func (h *handler) Patch(ctx *gofr.Context) (any, error) {
+	id, err := strconv.Atoi(ctx.PathParam("id"))
+	if err != nil {
+		return nil, apperr.Validation(apperr.Field("id", "must be an integer"))
+	}
+
+	var patch models.TaskPatch
+
+	if middleware.Header(ctx, "If-Match") != "" {
+		patch.Version, err = ifMatch(ctx)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	var fields map[string]json.RawMessage
+
+	err = ctx.Bind(&fields)
+	if err != nil || fields == nil {
+		return nil, errInvalidBody
+	}
+
+	invalid := patch.Decode("", fields)
+	if len(invalid) > 0 {
+		return nil, apperr.Validation(invalid...)
+	}
+
+	task, err := h.service.Patch(ctx, int64(id), &patch)
+	if err != nil {
+		return nil, err
+	}
+
+	return response.Response{Data: task, Headers: map[string]string{"ETag": etag(task.Version)}}, nil
+}
+
 func (h *handler) Delete(ctx *gofr.Context) (any, error) {
 	id, err := strconv.Atoi(ctx.PathParam("id"))
 	if err != nil {
-		return nil, gofrhttp.ErrorInvalidParam{Params: []string{ctx.PathParam("id")}}
+		return nil, apperr.Validation(apperr.Field("id", "must be an integer"))
 	}
 
 	err = h.service.Delete(ctx, int64(id))
@@ -85,3 +205,177 @@ func (h *handler) Delete(ctx *gofr.Context) (any, error) {
 
 	return nil, nil
 }
+
+func (h *handler) GetTrash(ctx *gofr.Context) (any, error) {
+	tasks, err := h.service.GetTrash(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return tasks, nil
+}
+
+func (h *handler) Restore(ctx *gofr.Context) (any, error) {
+	id, err := strconv.Atoi(ctx.PathParam("id"))
+	if err != nil {
+		return nil, apperr.Validation(apperr.Field("id", "must be an integer"))
+	}
+
+	err = h.service.Restore(ctx, int64(id))
+	if err != nil {
+		return nil, err
+	}
+
+	return nil, nil
+}
+
+func (h *handler) GetHistory(ctx *gofr.Context) (any, error) {
+	id, err := strconv.Atoi(ctx.PathParam("id"))
+	if err != nil {
+		return nil, apperr.Validation(apperr.Field("id", "must be an integer"))
+	}
+
+	entries, err := h.service.GetHistory(ctx, int64(id))
+	if err != nil {
+		return nil, err
+	}
+
+	return entries, nil
+}
+
+// bulkOperation is the wire form of models.BulkOperation. Updates carry a
+// merge patch, decoded as for PATCH /task/{id}, and transitions a status.
+type bulkOperation struct {
+	Op      string                     `json:"op"`
+	ID      int64                      `json:"id"`
+	Version int64                      `json:"version"`
+	Task    *models.Task               `json:"task"`
+	Patch   map[string]json.RawMessage `json:"patch"`
+	Status  *bool                      `json:"status"`
+}
+
+func (h *handler) Bulk(ctx *gofr.Context) (any, error) {
+	var body struct {
+		Mode       string          `json:"mode"`
+		Operations []bulkOperation `json:"operations"`
+	}
+
+	err := ctx.Bind(&body)
+	if err != nil {
+		return nil, errInvalidBody
+	}
+
+	var (
+		req     = models.BulkRequest{Mode: body.Mode, Operations: make([]models.BulkOperation, len(body.Operations))}
+		invalid []apperr.FieldError
+	)
+
+	for i, op := range body.Operations {
+		req.Operations[i] = models.BulkOperation{Op: op.Op, ID: op.ID, Version: op.Version, Task: op.Task}
+
+		switch op.Op {
+		case models.BulkUpdate:
+			if op.Patch != nil {
+				patch := &models.TaskPatch{}
+				invalid = append(invalid, patch.Decode(fmt.Sprintf("operations[%d].patch.", i), op.Patch)...)
+				req.Operations[i].Patch = patch
+			}
+		case models.BulkTransition:
+			req.Operations[i].Patch = &models.TaskPatch{Status: op.Status}
+		}
+	}
+
+	if len(invalid) > 0 {
+		return nil, apperr.Validation(invalid...)
+	}
+
+	res, err := h.service.Bulk(ctx, &req)
+	if err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+func etag(version int64) string {
+	return `"` + strconv.FormatInt(version, 10) + `"`
+}
+
+// ifMatch returns the version named by the If-Match header. "*" matches any
+// version and is returned as 0.
+func ifMatch(ctx *gofr.Context) (int64, error) {
+	value := strings.TrimSpace(middleware.Header(ctx, "If-Match"))
+
+	switch value {
+	case "":
+		return 0, errPreconditionRequired
+	case "*":
+		return 0, nil
+	}
+
+	version, err := strconv.ParseInt(strings.Trim(strings.TrimPrefix(value, "W/"), `"`), 10, 64)
+	if err != nil || version < 1 {
+		return 0, errInvalidETag
+	}
+
+	return version, nil
+}
+
+// parseFilter reads the listing filters from the query string. Dates are
+// RFC 3339 date-times or plain dates, which mean midnight UTC. The filter
+// parameter takes an expression in the query package's language.
+func parseFilter(ctx *gofr.Context) (*models.TaskFilter, error) {
+	var (
+		filter  = models.TaskFilter{Tag: ctx.Param("tag")}
+		invalid []apperr.FieldError
+	)
+
+	if v := ctx.Param("status"); v != "" {
+		status, err := strconv.ParseBool(v)
+		if err != nil {
+			invalid = append(invalid, apperr.Field("status", "must be true or false"))
+		}
+
+		filter.Status = &status
+	}
+
+	for name, dst := range map[string]**time.Time{"due_before": &filter.DueBefore, "due_after": &filter.DueAfter} {
+		v := ctx.Param(name)
+		if v == "" {
+			continue
+		}
+
+		t, err := parseTime(v)
+		if err != nil {
+			invalid = append(invalid, apperr.Field(name, "must be a date or an RFC 3339 date-time"))
+		}
+
+		*dst = &t
+	}
+
+	if v := ctx.Param("filter"); v != "" {
+		expr, err := query.Parse(v, time.Now().UTC())
+		if err != nil {
+			invalid = append(invalid, apperr.Field("filter", err.Error()))
+		}
+
+		filter.Query = expr
+	}
+
+	if len(invalid) > 0 {
+		sort.Slice(invalid, func(i, j int) bool { return invalid[i].Field < invalid[j].Field })
+
+		return nil, apperr.Validation(invalid...)
+	}
+
+	return &filter, nil
+}
+
+func parseTime(v string) (time.Time, error) {
+	t, err := time.Parse(time.RFC3339, v)
+	if err == nil {
+		return t, nil
+	}
+
+	return time.Parse(time.DateOnly, v)
+}
@@ -2,17 +2,24 @@ package task
 
 import (
 	"bytes"
+	"errors"
 	"net/http"
 	"net/http/httptest"
+	"reflect"
 	"testing"
+	"time"
 
 	"github.com/gorilla/mux"
 	"go.uber.org/mock/gomock"
 	"gofr.dev/pkg/gofr"
 	"gofr.dev/pkg/gofr/container"
 	gofrhttp "gofr.dev/pkg/gofr/http"
+	"gofr.dev/pkg/gofr/http/response"
 
+	"TaskManager2/apperr"
+	"TaskManager2/middleware"
 	"TaskManager2/models"
+	"TaskManager2/query"
 	"TaskManager2/utils"
 )
 
@@ -39,12 +46,12 @@ func TestHandler_Post(t *testing.T) {
 		{
 			"success",
 			`{
-							"desc" : "test task",
+							"title" : "test task",
 							"status" :  false,
 							"user_id" : 2
 						}`,
 			func() {
-				mockSvc.EXPECT().Create(ctx, &models.Task{Desc: "test task", Status: false, UserID: 2}).Return(int64(1), nil)
+				mockSvc.EXPECT().Create(ctx, &models.Task{Title: "test task", Status: false, UserID: 2}).Return(int64(1), nil)
 			},
 			int64(1),
 			nil,
@@ -54,17 +61,17 @@ func TestHandler_Post(t *testing.T) {
 			`describe":"test task","status":false,"user_id":1}`,
 			func() {},
 			nil,
-			gofrhttp.ErrorInvalidParam{},
+			errInvalidBody,
 		},
 		{
 			"service create error",
 			`{
-							"desc" : "test task",
+							"title" : "test task",
 							"status" :  false,
 							"user_id" : 2
 						}`,
 			func() {
-				mockSvc.EXPECT().Create(ctx, &models.Task{Desc: "test task", Status: false, UserID: 2}).Return(int64(0), utils.ErrTest)
+				mockSvc.EXPECT().Create(ctx, &models.Task{Title: "test task", Status: false, UserID: 2}).Return(int64(0), utils.ErrTest)
 			},
 			nil,
 			utils.ErrTest,
@@ -106,24 +113,65 @@ func TestHandler_GetAllHandler(t *testing.T) {
 		Container: mockContainer,
 	}
 
+	status := false
+	dueBefore := time.Date(2026, 11, 1, 0, 0, 0, 0, time.UTC)
+	dueAfter := time.Date(2026, 10, 1, 9, 30, 0, 0, time.UTC)
+
 	testcases := []struct {
 		name             string
+		query            string
 		mockExpect       func()
 		expectedResponse any
 		expectedError    error
 	}{
 		{
 			"success",
+			"",
+			func() {
+				mockSvc.EXPECT().GetAll(ctx, &models.TaskFilter{}).Return([]models.Task{{}}, nil)
+			},
+			[]models.Task{{}},
+			nil,
+		},
+		{
+			"filters",
+			"?status=false&tag=ops&due_before=2026-11-01&due_after=2026-10-01T09:30:00Z",
+			func() {
+				filter := &models.TaskFilter{Status: &status, Tag: "ops", DueBefore: &dueBefore, DueAfter: &dueAfter}
+				mockSvc.EXPECT().GetAll(ctx, filter).Return([]models.Task{{}}, nil)
+			},
+			[]models.Task{{}},
+			nil,
+		},
+		{
+			"filter expression",
+			"?filter=tag:bug+OR+user%3D3",
 			func() {
-				mockSvc.EXPECT().GetAll(ctx).Return([]models.Task{{}}, nil)
+				expr := query.Or{
+					Left:  query.Comparison{Field: query.FieldTag, Op: query.OpMatch, Value: "bug"},
+					Right: query.Comparison{Field: query.FieldUser, Op: query.OpEq, Value: int64(3)},
+				}
+				mockSvc.EXPECT().GetAll(ctx, &models.TaskFilter{Query: expr}).Return([]models.Task{{}}, nil)
 			},
 			[]models.Task{{}},
 			nil,
 		},
+		{
+			"invalid filters",
+			"?status=maybe&due_after=soon&filter=priority>=high",
+			func() {},
+			nil,
+			apperr.Validation(
+				apperr.Field("due_after", "must be a date or an RFC 3339 date-time"),
+				apperr.Field("filter", `unknown field "priority" at position 0`),
+				apperr.Field("status", "must be true or false"),
+			),
+		},
 		{
 			"service GetAll error",
+			"",
 			func() {
-				mockSvc.EXPECT().GetAll(ctx).Return(nil, utils.ErrTest)
+				mockSvc.EXPECT().GetAll(ctx, &models.TaskFilter{}).Return(nil, utils.ErrTest)
 			},
 			nil,
 			utils.ErrTest,
@@ -134,24 +182,165 @@ func TestHandler_GetAllHandler(t *testing.T) {
 		t.Run(tc.name, func(t *testing.T) {
 			tc.mockExpect()
 
-			req := httptest.NewRequest(http.MethodGet, "/", http.NoBody)
+			req := httptest.NewRequest(http.MethodGet, "/task"+tc.query, http.NoBody)
 			req.Header.Set("Content-Type", "application/json")
 			ctx.Request = gofrhttp.NewRequest(req)
 
 			tasks, err := taskHandler.GetAll(ctx)
-			if err != nil && err.Error() != tc.expectedError.Error() {
+			if !errors.Is(err, tc.expectedError) {
 				t.Errorf("error, expected %v, got %v", tc.expectedError, err)
 			}
 
-			switch res := tasks.(type) {
-			case []models.Task:
-				if len(res) != len(tc.expectedResponse.([]models.Task)) {
-					t.Errorf("expected: %v, got: %v", tc.expectedResponse, res)
-				}
-			case nil:
-				if tc.expectedResponse != nil {
-					t.Errorf("expected: %v, got: %v", tc.expectedResponse, res)
-				}
+			if tc.expectedResponse != nil && !reflect.DeepEqual(tasks, tc.expectedResponse) {
+				t.Errorf("expected: %v, got: %v", tc.expectedResponse, tasks)
+			}
+		})
+	}
+}
+
+func TestHandler_GetByUser(t *testing.T) {
+	controller := gomock.NewController(t)
+	mockSvc := NewMockService(controller)
+	taskHandler := New(mockSvc)
+
+	mockContainer, _ := container.NewMockContainer(t)
+	ctx := &gofr.Context{
+		Context:   t.Context(),
+		Request:   nil,
+		Container: mockContainer,
+	}
+
+	status := true
+
+	testcases := []struct {
+		name             string
+		requestID        string
+		query            string
+		mockExpect       func()
+		expectedResponse any
+		expectedError    error
+	}{
+		{
+			"success",
+			"2",
+			"?status=true",
+			func() {
+				mockSvc.EXPECT().GetByUser(ctx, int64(2), &models.TaskFilter{Status: &status}).Return([]models.Task{{ID: 1}}, nil)
+			},
+			[]models.Task{{ID: 1}},
+			nil,
+		},
+		{
+			"Atoi error",
+			"abc",
+			"",
+			func() {},
+			nil,
+			apperr.Validation(apperr.Field("id", "must be an integer")),
+		},
+		{
+			"invalid filter",
+			"2",
+			"?due_before=tomorrow",
+			func() {},
+			nil,
+			apperr.Validation(apperr.Field("due_before", "must be a date or an RFC 3339 date-time")),
+		},
+		{
+			"service error",
+			"2",
+			"",
+			func() {
+				mockSvc.EXPECT().GetByUser(ctx, int64(2), &models.TaskFilter{}).Return(nil, apperr.NotFound("user", 2))
+			},
+			nil,
+			apperr.NotFound("user", 2),
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.mockExpect()
+
+			req := httptest.NewRequest(http.MethodGet, "/user/{id}/tasks"+tc.query, http.NoBody)
+			req = mux.SetURLVars(req, map[string]string{"id": tc.requestID})
+			ctx.Request = gofrhttp.NewRequest(req)
+
+			res, err := taskHandler.GetByUser(ctx)
+			if !errors.Is(err, tc.expectedError) {
+				t.Errorf("error, expected %v, got %v", tc.expectedError, err)
+			}
+
+			if !reflect.DeepEqual(res, tc.expectedResponse) {
+				t.Errorf("expected: %v, got: %v", tc.expectedResponse, res)
+			}
+		})
+	}
+}
+
+func TestHandler_GetUserSummary(t *testing.T) {
+	controller := gomock.NewController(t)
+	mockSvc := NewMockService(controller)
+	taskHandler := New(mockSvc)
+
+	mockContainer, _ := container.NewMockContainer(t)
+	ctx := &gofr.Context{
+		Context:   t.Context(),
+		Request:   nil,
+		Container: mockContainer,
+	}
+
+	summary := &models.TaskSummary{UserID: 2, ByStatus: map[string]int64{"open": 3, "done": 1}, Overdue: 1}
+
+	testcases := []struct {
+		name             string
+		requestID        string
+		mockExpect       func()
+		expectedResponse any
+		expectedError    error
+	}{
+		{
+			"success",
+			"2",
+			func() {
+				mockSvc.EXPECT().Summary(ctx, int64(2)).Return(summary, nil)
+			},
+			summary,
+			nil,
+		},
+		{
+			"Atoi error",
+			"abc",
+			func() {},
+			nil,
+			apperr.Validation(apperr.Field("id", "must be an integer")),
+		},
+		{
+			"service error",
+			"2",
+			func() {
+				mockSvc.EXPECT().Summary(ctx, int64(2)).Return(nil, utils.ErrTest)
+			},
+			nil,
+			utils.ErrTest,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.mockExpect()
+
+			req := httptest.NewRequest(http.MethodGet, "/user/{id}/summary", http.NoBody)
+			req = mux.SetURLVars(req, map[string]string{"id": tc.requestID})
+			ctx.Request = gofrhttp.NewRequest(req)
+
+			res, err := taskHandler.GetUserSummary(ctx)
+			if !errors.Is(err, tc.expectedError) {
+				t.Errorf("error, expected %v, got %v", tc.expectedError, err)
+			}
+
+			if !reflect.DeepEqual(res, tc.expectedResponse) {
+				t.Errorf("expected: %v, got: %v", tc.expectedResponse, res)
 			}
 		})
 	}
@@ -180,9 +369,9 @@ func TestHandler_GetByID(t *testing.T) {
 			"success",
 			"1",
 			func() {
-				mockSvc.EXPECT().GetByID(ctx, int64(1)).Return(&models.Task{ID: 1}, nil)
+				mockSvc.EXPECT().GetByID(ctx, int64(1)).Return(&models.Task{ID: 1, Version: 3}, nil)
 			},
-			&models.Task{ID: 1},
+			response.Response{Data: &models.Task{ID: 1, Version: 3}, Headers: map[string]string{"ETag": `"3"`}},
 			nil,
 		},
 		{
@@ -190,7 +379,7 @@ func TestHandler_GetByID(t *testing.T) {
 			"abc",
 			func() {},
 			nil,
-			gofrhttp.ErrorInvalidParam{Params: []string{"abc"}},
+			apperr.Validation(apperr.Field("id", "must be an integer")),
 		},
 		{
 			"service GetByID error",
@@ -218,21 +407,14 @@ func TestHandler_GetByID(t *testing.T) {
 				t.Errorf("error, expected %v, got %v", tc.expectedError, err)
 			}
 
-			switch res := task.(type) {
-			case models.Task:
-				if res.ID != tc.expectedResponse.(models.Task).ID {
-					t.Errorf("expected: %v, got: %v", tc.expectedResponse, res)
-				}
-			case nil:
-				if tc.expectedResponse != nil {
-					t.Errorf("expected: %v, got: %v", tc.expectedResponse, res)
-				}
+			if tc.expectedResponse != nil && !reflect.DeepEqual(task, tc.expectedResponse) {
+				t.Errorf("expected: %v, got: %v", tc.expectedResponse, task)
 			}
 		})
 	}
 }
 
-func TestHandler_Put(t *testing.T) {
+func TestHandler_GetDescription(t *testing.T) {
 	controller := gomock.NewController(t)
 	mockSvc := NewMockService(controller)
 	taskHandler := New(mockSvc)
@@ -244,43 +426,154 @@ func TestHandler_Put(t *testing.T) {
 		Container: mockContainer,
 	}
 
+	rendered := &models.RenderedDescription{TaskID: 1, Version: 3, HTML: "<h1>Notes</h1>\n"}
+
 	testcases := []struct {
 		name             string
-		requestBody      string
+		requestID        string
 		mockExpect       func()
 		expectedResponse any
 		expectedError    error
 	}{
 		{
 			"success",
-			`{
-							"id" : 4,
-							"desc": "test task",
-							"status": false
-						}`,
+			"1",
+			func() {
+				mockSvc.EXPECT().RenderDescription(ctx, int64(1)).Return(rendered, nil)
+			},
+			rendered,
+			nil,
+		},
+		{
+			"Atoi error",
+			"abc",
+			func() {},
+			nil,
+			apperr.Validation(apperr.Field("id", "must be an integer")),
+		},
+		{
+			"service RenderDescription error",
+			"1",
+			func() {
+				mockSvc.EXPECT().RenderDescription(ctx, int64(1)).Return(nil, utils.ErrTest)
+			},
+			nil,
+			utils.ErrTest,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.mockExpect()
+
+			req := httptest.NewRequest(http.MethodGet, "/task/{id}/description", http.NoBody)
+			req = mux.SetURLVars(req, map[string]string{"id": tc.requestID})
+			ctx.Request = gofrhttp.NewRequest(req)
+
+			res, err := taskHandler.GetDescription(ctx)
+			if err != nil && err.Error() != tc.expectedError.Error() {
+				t.Errorf("error, expected %v, got %v", tc.expectedError, err)
+			}
+
+			if tc.expectedResponse != nil && !reflect.DeepEqual(res, tc.expectedResponse) {
+				t.Errorf("expected: %v, got: %v", tc.expectedResponse, res)
+			}
+		})
+	}
+}
+
+func TestHandler_Put(t *testing.T) {
+	controller := gomock.NewController(t)
+	mockSvc := NewMockService(controller)
+	taskHandler := New(mockSvc)
+
+	mockContainer, _ := container.NewMockContainer(t)
+	ctx := &gofr.Context{
+		Context:   t.Context(),
+		Request:   nil,
+		Container: mockContainer,
+	}
+
+	body := `{"title": "test task", "status": false}`
+
+	testcases := []struct {
+		name          string
+		requestID     string
+		ifMatch       string
+		requestBody   string
+		mockExpect    func()
+		expectedError error
+	}{
+		{
+			"success",
+			"4",
+			`"2"`,
+			body,
+			func() {
+				mockSvc.EXPECT().Update(ctx, &models.Task{ID: 4, Title: "test task", Version: 2}).Return(nil)
+			},
+			nil,
+		},
+		{
+			"weak etag",
+			"4",
+			`W/"2"`,
+			body,
 			func() {
-				mockSvc.EXPECT().Update(ctx, &models.Task{ID: 4, Desc: "test task", Status: false}).Return(nil)
+				mockSvc.EXPECT().Update(ctx, &models.Task{ID: 4, Title: "test task", Version: 2}).Return(nil)
 			},
 			nil,
+		},
+		{
+			"any version",
+			"4",
+			"*",
+			body,
+			func() {
+				mockSvc.EXPECT().Update(ctx, &models.Task{ID: 4, Title: "test task"}).Return(nil)
+			},
 			nil,
 		},
-		{"bind error",
+		{
+			"Atoi error",
+			"abc",
+			`"2"`,
+			body,
+			func() {},
+			apperr.Validation(apperr.Field("id", "must be an integer")),
+		},
+		{
+			"missing If-Match",
+			"4",
+			"",
+			body,
+			func() {},
+			errPreconditionRequired,
+		},
+		{
+			"malformed If-Match",
+			"4",
+			`"abc"`,
+			body,
+			func() {},
+			errInvalidETag,
+		},
+		{
+			"bind error",
+			"4",
+			`"2"`,
 			`describe":"test task","status":false,"user_id":1}`,
 			func() {},
-			nil,
-			gofrhttp.ErrorInvalidParam{},
+			errInvalidBody,
 		},
 		{
 			"service update error",
-			`{
-							"id" : 4,
-							"desc": "test task",
-							"status": false
-						}`,
+			"4",
+			`"2"`,
+			body,
 			func() {
-				mockSvc.EXPECT().Update(ctx, &models.Task{ID: 4, Desc: "test task", Status: false}).Return(utils.ErrTest)
+				mockSvc.EXPECT().Update(ctx, &models.Task{ID: 4, Title: "test task", Version: 2}).Return(utils.ErrTest)
 			},
-			nil,
 			utils.ErrTest,
 		},
 	}
@@ -289,16 +582,260 @@ func TestHandler_Put(t *testing.T) {
 		t.Run(tc.name, func(t *testing.T) {
 			tc.mockExpect()
 
-			body := bytes.NewReader([]byte(tc.requestBody))
-			req := httptest.NewRequest(http.MethodPut, "/1", body)
+			req := httptest.NewRequest(http.MethodPut, "/task/{id}", bytes.NewReader([]byte(tc.requestBody)))
 			req.Header.Set("Content-Type", "application/json")
 
+			if tc.ifMatch != "" {
+				req.Header.Set("If-Match", tc.ifMatch)
+			}
+
+			req = withRequestMetadata(req)
+			req = mux.SetURLVars(req, map[string]string{"id": tc.requestID})
+			ctx.Context = req.Context()
 			ctx.Request = gofrhttp.NewRequest(req)
 
 			_, err := taskHandler.Put(ctx)
-			if err != nil && err.Error() != tc.expectedError.Error() {
+			if !errors.Is(err, tc.expectedError) && !reflect.DeepEqual(err, tc.expectedError) {
+				t.Errorf("error, expected %v, got %v", tc.expectedError, err)
+			}
+		})
+	}
+}
+
+// withRequestMetadata passes req through the RequestMetadata middleware so that handlers can read its headers.
+func withRequestMetadata(req *http.Request) *http.Request {
+	middleware.RequestMetadata(http.HandlerFunc(func(_ http.ResponseWriter, r *http.Request) {
+		req = r
+	})).ServeHTTP(httptest.NewRecorder(), req)
+
+	return req
+}
+
+func TestHandler_Patch(t *testing.T) {
+	controller := gomock.NewController(t)
+	mockSvc := NewMockService(controller)
+	taskHandler := New(mockSvc)
+
+	mockContainer, _ := container.NewMockContainer(t)
+	ctx := &gofr.Context{
+		Context:   t.Context(),
+		Request:   nil,
+		Container: mockContainer,
+	}
+
+	status := true
+	dueDate := time.Date(2026, 11, 1, 0, 0, 0, 0, time.UTC)
+	patched := &models.Task{ID: 4, Title: "draft", Status: true, Version: 3}
+
+	testcases := []struct {
+		name             string
+		requestID        string
+		ifMatch          string
+		requestBody      string
+		mockExpect       func()
+		expectedResponse any
+		expectedError    error
+	}{
+		{
+			"status only",
+			"4",
+			"",
+			`{"status": true}`,
+			func() {
+				mockSvc.EXPECT().Patch(ctx, int64(4), &models.TaskPatch{Status: &status}).Return(patched, nil)
+			},
+			response.Response{Data: patched, Headers: map[string]string{"ETag": `"3"`}},
+			nil,
+		},
+		{
+			"set due date at version",
+			"4",
+			`"2"`,
+			`{"due_date": "2026-11-01T00:00:00Z"}`,
+			func() {
+				mockSvc.EXPECT().Patch(ctx, int64(4), &models.TaskPatch{DueDate: &dueDate, Version: 2}).Return(patched, nil)
+			},
+			response.Response{Data: patched, Headers: map[string]string{"ETag": `"3"`}},
+			nil,
+		},
+		{
+			"clear due date",
+			"4",
+			"",
+			`{"due_date": null}`,
+			func() {
+				mockSvc.EXPECT().Patch(ctx, int64(4), &models.TaskPatch{ClearDueDate: true}).Return(patched, nil)
+			},
+			response.Response{Data: patched, Headers: map[string]string{"ETag": `"3"`}},
+			nil,
+		},
+		{
+			"Atoi error",
+			"abc",
+			"",
+			`{"status": true}`,
+			func() {},
+			nil,
+			apperr.Validation(apperr.Field("id", "must be an integer")),
+		},
+		{
+			"malformed If-Match",
+			"4",
+			"abc",
+			`{"status": true}`,
+			func() {},
+			nil,
+			errInvalidETag,
+		},
+		{
+			"not an object",
+			"4",
+			"",
+			`null`,
+			func() {},
+			nil,
+			errInvalidBody,
+		},
+		{
+			"invalid fields",
+			"4",
+			"",
+			`{"user_id": 2, "status": "done", "title": null, "due_date": "soon"}`,
+			func() {},
+			nil,
+			apperr.Validation(
+				apperr.Field("due_date", "must be an RFC 3339 date-time or null"),
+				apperr.Field("status", "must be a boolean"),
+				apperr.Field("title", "must be a string"),
+				apperr.Field("user_id", "cannot be patched"),
+			),
+		},
+		{
+			"service patch error",
+			"4",
+			"",
+			`{"status": true}`,
+			func() {
+				mockSvc.EXPECT().Patch(ctx, int64(4), &models.TaskPatch{Status: &status}).Return(nil, utils.ErrTest)
+			},
+			nil,
+			utils.ErrTest,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.mockExpect()
+
+			req := httptest.NewRequest(http.MethodPatch, "/task/{id}", bytes.NewReader([]byte(tc.requestBody)))
+			req.Header.Set("Content-Type", "application/json")
+
+			if tc.ifMatch != "" {
+				req.Header.Set("If-Match", tc.ifMatch)
+			}
+
+			req = withRequestMetadata(req)
+			req = mux.SetURLVars(req, map[string]string{"id": tc.requestID})
+			ctx.Context = req.Context()
+			ctx.Request = gofrhttp.NewRequest(req)
+
+			res, err := taskHandler.Patch(ctx)
+			if !errors.Is(err, tc.expectedError) && !reflect.DeepEqual(err, tc.expectedError) {
+				t.Errorf("error, expected %v, got %v", tc.expectedError, err)
+			}
+
+			if tc.expectedResponse != nil && !reflect.DeepEqual(res, tc.expectedResponse) {
+				t.Errorf("expected: %v, got: %v", tc.expectedResponse, res)
+			}
+		})
+	}
+}
+
+func TestHandler_Bulk(t *testing.T) {
+	controller := gomock.NewController(t)
+	mockSvc := NewMockService(controller)
+	taskHandler := New(mockSvc)
+
+	mockContainer, _ := container.NewMockContainer(t)
+	ctx := &gofr.Context{
+		Context:   t.Context(),
+		Request:   nil,
+		Container: mockContainer,
+	}
+
+	done, desc := true, "renamed"
+	result := &models.BulkResponse{Mode: models.BulkBestEffort, Succeeded: 1}
+
+	testcases := []struct {
+		name             string
+		requestBody      string
+		mockExpect       func()
+		expectedResponse any
+		expectedError    error
+	}{
+		{
+			"operations of every kind",
+			`{"mode": "best_effort", "operations": [
+				{"op": "create", "task": {"title": "new", "user_id": 1}},
+				{"op": "update", "id": 2, "version": 4, "patch": {"title": "renamed", "due_date": null}},
+				{"op": "transition", "id": 3, "status": true},
+				{"op": "delete", "id": 5}
+			]}`,
+			func() {
+				mockSvc.EXPECT().Bulk(ctx, &models.BulkRequest{Mode: models.BulkBestEffort, Operations: []models.BulkOperation{
+					{Op: models.BulkCreate, Task: &models.Task{Title: "new", UserID: 1}},
+					{Op: models.BulkUpdate, ID: 2, Version: 4, Patch: &models.TaskPatch{Title: &desc, ClearDueDate: true}},
+					{Op: models.BulkTransition, ID: 3, Patch: &models.TaskPatch{Status: &done}},
+					{Op: models.BulkDelete, ID: 5},
+				}}).Return(result, nil)
+			},
+			result,
+			nil,
+		},
+		{
+			"invalid patch",
+			`{"operations": [{"op": "update", "id": 2, "patch": {"status": "yes", "user_id": 3}}]}`,
+			func() {},
+			nil,
+			apperr.Validation(apperr.Field("operations[0].patch.status", "must be a boolean"),
+				apperr.Field("operations[0].patch.user_id", "cannot be patched")),
+		},
+		{
+			"bind error",
+			`{"operations": `,
+			func() {},
+			nil,
+			errInvalidBody,
+		},
+		{
+			"service error",
+			`{"operations": [{"op": "delete", "id": 5}]}`,
+			func() {
+				mockSvc.EXPECT().Bulk(ctx, &models.BulkRequest{Operations: []models.BulkOperation{{Op: models.BulkDelete, ID: 5}}}).
+					Return(nil, utils.ErrTest)
+			},
+			nil,
+			utils.ErrTest,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.mockExpect()
+
+			req := httptest.NewRequest(http.MethodPost, "/task/bulk", bytes.NewReader([]byte(tc.requestBody)))
+			req.Header.Set("Content-Type", "application/json")
+
+			ctx.Request = gofrhttp.NewRequest(req)
+
+			res, err := taskHandler.Bulk(ctx)
+			if !errors.Is(err, tc.expectedError) {
 				t.Errorf("error, expected %v, got %v", tc.expectedError, err)
 			}
+
+			if tc.expectedResponse != nil && !reflect.DeepEqual(res, tc.expectedResponse) {
+				t.Errorf("expected: %v, got: %v", tc.expectedResponse, res)
+			}
 		})
 	}
 }
@@ -336,7 +873,7 @@ func TestHandler_Delete(t *testing.T) {
 			"abc",
 			func() {},
 			nil,
-			gofrhttp.ErrorInvalidParam{Params: []string{"abc"}},
+			apperr.Validation(apperr.Field("id", "must be an integer")),
 		},
 		{
 			"service GetByID error",
@@ -365,3 +902,172 @@ func TestHandler_Delete(t *testing.T) {
 		})
 	}
 }
+
+func TestHandler_GetTrash(t *testing.T) {
+	controller := gomock.NewController(t)
+	mockSvc := NewMockService(controller)
+	taskHandler := New(mockSvc)
+
+	mockContainer, _ := container.NewMockContainer(t)
+	ctx := &gofr.Context{
+		Context:   t.Context(),
+		Request:   nil,
+		Container: mockContainer,
+	}
+
+	testcases := []struct {
+		name          string
+		mockExpect    func()
+		expectedError error
+	}{
+		{
+			"success",
+			func() {
+				mockSvc.EXPECT().GetTrash(ctx).Return([]models.Task{{ID: 1}}, nil)
+			},
+			nil,
+		},
+		{
+			"service GetTrash error",
+			func() {
+				mockSvc.EXPECT().GetTrash(ctx).Return(nil, utils.ErrTest)
+			},
+			utils.ErrTest,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.mockExpect()
+
+			_, err := taskHandler.GetTrash(ctx)
+			if !errors.Is(err, tc.expectedError) {
+				t.Errorf("error, expected %v, got %v", tc.expectedError, err)
+			}
+		})
+	}
+}
+
+func TestHandler_Restore(t *testing.T) {
+	controller := gomock.NewController(t)
+	mockSvc := NewMockService(controller)
+	taskHandler := New(mockSvc)
+
+	mockContainer, _ := container.NewMockContainer(t)
+	ctx := &gofr.Context{
+		Context:   t.Context(),
+		Request:   nil,
+		Container: mockContainer,
+	}
+
+	testcases := []struct {
+		name          string
+		requestID     string
+		mockExpect    func()
+		expectedError error
+	}{
+		{
+			"success",
+			"1",
+			func() {
+				mockSvc.EXPECT().Restore(ctx, int64(1)).Return(nil)
+			},
+			nil,
+		},
+		{
+			"Atoi error",
+			"abc",
+			func() {},
+			apperr.Validation(apperr.Field("id", "must be an integer")),
+		},
+		{
+			"service Restore error",
+			"1",
+			func() {
+				mockSvc.EXPECT().Restore(ctx, int64(1)).Return(utils.ErrTest)
+			},
+			utils.ErrTest,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.mockExpect()
+
+			req := httptest.NewRequest(http.MethodPost, "/task/1/restore", http.NoBody)
+			req = mux.SetURLVars(req, map[string]string{"id": tc.requestID})
+			ctx.Request = gofrhttp.NewRequest(req)
+
+			_, err := taskHandler.Restore(ctx)
+			if err != nil && err.Error() != tc.expectedError.Error() {
+				t.Errorf("error, expected %v, got %v", tc.expectedError, err)
+			}
+		})
+	}
+}
+
+func TestHandler_GetHistory(t *testing.T) {
+	controller := gomock.NewController(t)
+	mockSvc := NewMockService(controller)
+	taskHandler := New(mockSvc)
+
+	mockContainer, _ := container.NewMockContainer(t)
+	ctx := &gofr.Context{
+		Context:   t.Context(),
+		Request:   nil,
+		Container: mockContainer,
+	}
+
+	testcases := []struct {
+		name             string
+		requestID        string
+		mockExpect       func()
+		expectedResponse any
+		expectedError    error
+	}{
+		{
+			"success",
+			"1",
+			func() {
+				mockSvc.EXPECT().GetHistory(ctx, int64(1)).Return([]models.AuditEntry{{ID: 1, Action: "create"}}, nil)
+			},
+			[]models.AuditEntry{{ID: 1, Action: "create"}},
+			nil,
+		},
+		{
+			"Atoi error",
+			"abc",
+			func() {},
+			nil,
+			apperr.Validation(apperr.Field("id", "must be an integer")),
+		},
+		{
+			"service GetHistory error",
+			"1",
+			func() {
+				mockSvc.EXPECT().GetHistory(ctx, int64(1)).Return(nil, utils.ErrTest)
+			},
+			nil,
+			utils.ErrTest,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.mockExpect()
+
+			req := httptest.NewRequest(http.MethodGet, "/task/1/history", http.NoBody)
+			req = mux.SetURLVars(req, map[string]string{"id": tc.requestID})
+			ctx.Request = gofrhttp.NewRequest(req)
+
+			resp, err := taskHandler.GetHistory(ctx)
+			if err != nil && err.Error() != tc.expectedError.Error() {
+				t.Errorf("error, expected %v, got %v", tc.expectedError, err)
+			}
+
+			if !reflect.DeepEqual(resp, tc.expectedResponse) {
+				t.Errorf("expected: %v, got: %v", tc.expectedResponse, resp)
+			}
+		})
+	}
+}
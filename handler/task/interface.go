@@ -8,8 +8,16 @@ import (
 
 type Service interface {
 	Create(*gofr.Context, *models.Task) (int64, error)
-	GetAll(*gofr.Context) ([]models.Task, error)
+	GetAll(*gofr.Context, *models.TaskFilter) ([]models.Task, error)
+	GetByUser(*gofr.Context, int64, *models.TaskFilter) ([]models.Task, error)
+	Summary(*gofr.Context, int64) (*models.TaskSummary, error)
 	GetByID(*gofr.Context, int64) (*models.Task, error)
+	RenderDescription(*gofr.Context, int64) (*models.RenderedDescription, error)
 	Update(*gofr.Context, *models.Task) error
+	Patch(*gofr.Context, int64, *models.TaskPatch) (*models.Task, error)
 	Delete(*gofr.Context, int64) error
+	GetTrash(*gofr.Context) ([]models.Task, error)
+	Restore(*gofr.Context, int64) error
+	GetHistory(*gofr.Context, int64) ([]models.AuditEntry, error)
+	Bulk(*gofr.Context, *models.BulkRequest) (*models.BulkResponse, error)
 }
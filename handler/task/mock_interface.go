@@ -41,6 +41,21 @@ func (m *MockService) EXPECT() *MockServiceMockRecorder {
 	return m.recorder
 }
 
+// Bulk mocks base method.
+func (m *MockService) Bulk(arg0 *gofr.Context, arg1 *models.BulkRequest) (*models.BulkResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Bulk", arg0, arg1)
+	ret0, _ := ret[0].(*models.BulkResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Bulk indicates an expected call of Bulk.
+func (mr *MockServiceMockRecorder) Bulk(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Bulk", reflect.TypeOf((*MockService)(nil).Bulk), arg0, arg1)
+}
+
 // Create mocks base method.
 func (m *MockService) Create(arg0 *gofr.Context, arg1 *models.Task) (int64, error) {
 	m.ctrl.T.Helper()
@@ -71,18 +86,18 @@ func (mr *MockServiceMockRecorder) Delete(arg0, arg1 any) *gomock.Call {
 }
 
 // GetAll mocks base method.
-func (m *MockService) GetAll(arg0 *gofr.Context) ([]models.Task, error) {
+func (m *MockService) GetAll(arg0 *gofr.Context, arg1 *models.TaskFilter) ([]models.Task, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetAll", arg0)
+	ret := m.ctrl.Call(m, "GetAll", arg0, arg1)
 	ret0, _ := ret[0].([]models.Task)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetAll indicates an expected call of GetAll.
-func (mr *MockServiceMockRecorder) GetAll(arg0 any) *gomock.Call {
+func (mr *MockServiceMockRecorder) GetAll(arg0, arg1 any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAll", reflect.TypeOf((*MockService)(nil).GetAll), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAll", reflect.TypeOf((*MockService)(nil).GetAll), arg0, arg1)
 }
 
 // GetByID mocks base method.
@@ -100,6 +115,110 @@ func (mr *MockServiceMockRecorder) GetByID(arg0, arg1 any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockService)(nil).GetByID), arg0, arg1)
 }
 
+// GetByUser mocks base method.
+func (m *MockService) GetByUser(arg0 *gofr.Context, arg1 int64, arg2 *models.TaskFilter) ([]models.Task, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByUser", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]models.Task)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByUser indicates an expected call of GetByUser.
+func (mr *MockServiceMockRecorder) GetByUser(arg0, arg1, arg2 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByUser", reflect.TypeOf((*MockService)(nil).GetByUser), arg0, arg1, arg2)
+}
+
+// GetHistory mocks base method.
+func (m *MockService) GetHistory(arg0 *gofr.Context, arg1 int64) ([]models.AuditEntry, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetHistory", arg0, arg1)
+	ret0, _ := ret[0].([]models.AuditEntry)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetHistory indicates an expected call of GetHistory.
+func (mr *MockServiceMockRecorder) GetHistory(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetHistory", reflect.TypeOf((*MockService)(nil).GetHistory), arg0, arg1)
+}
+
+// GetTrash mocks base method.
+func (m *MockService) GetTrash(arg0 *gofr.Context) ([]models.Task, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTrash", arg0)
+	ret0, _ := ret[0].([]models.Task)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTrash indicates an expected call of GetTrash.
+func (mr *MockServiceMockRecorder) GetTrash(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTrash", reflect.TypeOf((*MockService)(nil).GetTrash), arg0)
+}
+
+// Patch mocks base method.
+func (m *MockService) Patch(arg0 *gofr.Context, arg1 int64, arg2 *models.TaskPatch) (*models.Task, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Patch", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*models.Task)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Patch indicates an expected call of Patch.
+func (mr *MockServiceMockRecorder) Patch(arg0, arg1, arg2 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Patch", reflect.TypeOf((*MockService)(nil).Patch), arg0, arg1, arg2)
+}
+
+// RenderDescription mocks base method.
+func (m *MockService) RenderDescription(arg0 *gofr.Context, arg1 int64) (*models.RenderedDescription, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RenderDescription", arg0, arg1)
+	ret0, _ := ret[0].(*models.RenderedDescription)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RenderDescription indicates an expected call of RenderDescription.
+func (mr *MockServiceMockRecorder) RenderDescription(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RenderDescription", reflect.TypeOf((*MockService)(nil).RenderDescription), arg0, arg1)
+}
+
+// Restore mocks base method.
+func (m *MockService) Restore(arg0 *gofr.Context, arg1 int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Restore", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Restore indicates an expected call of Restore.
+func (mr *MockServiceMockRecorder) Restore(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Restore", reflect.TypeOf((*MockService)(nil).Restore), arg0, arg1)
+}
+
+// Summary mocks base method.
+func (m *MockService) Summary(arg0 *gofr.Context, arg1 int64) (*models.TaskSummary, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Summary", arg0, arg1)
+	ret0, _ := ret[0].(*models.TaskSummary)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Summary indicates an expected call of Summary.
+func (mr *MockServiceMockRecorder) Summary(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Summary", reflect.TypeOf((*MockService)(nil).Summary), arg0, arg1)
+}
+
 // Update mocks base method.
 func (m *MockService) Update(arg0 *gofr.Context, arg1 *models.Task) error {
 	m.ctrl.T.Helper()
@@ -0,0 +1,84 @@
+package template
+
+import (
+	"strconv"
+
+	"gofr.dev/pkg/gofr"
+
+	"TaskManager2/apperr"
+	"TaskManager2/models"
+)
+
+var errInvalidBody = apperr.Validation(apperr.Field("body", "must be a JSON object"))
+
+type handler struct {
+	service Service
+}
+
+func New(service Service) *handler {
+	return &handler{service: service}
+}
+
+func (h *handler) Post(ctx *gofr.Context) (any, error) {
+	var template models.Template
+
+	err := ctx.Bind(&template)
+	if err != nil {
+		return nil, errInvalidBody
+	}
+
+	if template.Name == "" {
+		return nil, apperr.Validation(apperr.Field("name", "must not be empty"))
+	}
+
+	id, err := h.service.Create(ctx, &template)
+	if err != nil {
+		return nil, err
+	}
+
+	return id, nil
+}
+
+func (h *handler) GetAll(ctx *gofr.Context) (any, error) {
+	templates, err := h.service.GetAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return templates, nil
+}
+
+func (h *handler) GetByID(ctx *gofr.Context) (any, error) {
+	id, err := strconv.Atoi(ctx.PathParam("id"))
+	if err != nil {
+		return nil, apperr.Validation(apperr.Field("id", "must be an integer"))
+	}
+
+	template, err := h.service.GetByID(ctx, int64(id))
+	if err != nil {
+		return nil, err
+	}
+
+	return template, nil
+}
+
+func (h *handler) Instantiate(ctx *gofr.Context) (any, error) {
+	id, err := strconv.Atoi(ctx.PathParam("id"))
+	if err != nil {
+		return nil, apperr.Validation(apperr.Field("id", "must be an integer"))
+	}
+
+	var req models.Instantiation
+
+	err = ctx.Bind(&req)
+	if err != nil {
+		return nil, errInvalidBody
+	}
+
+	tasks, err := h.service.Instantiate(ctx, int64(id), &req)
+	if err != nil {
+		return nil, err
+	}
+
+	return tasks, nil
+}
@@ -0,0 +1,178 @@
+package template
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"github.com/gorilla/mux"
+	"go.uber.org/mock/gomock"
+	"gofr.dev/pkg/gofr"
+	"gofr.dev/pkg/gofr/container"
+	gofrhttp "gofr.dev/pkg/gofr/http"
+
+	"TaskManager2/apperr"
+	"TaskManager2/models"
+	"TaskManager2/utils"
+)
+
+func TestHandler_Post(t *testing.T) {
+	controller := gomock.NewController(t)
+	mockSvc := NewMockService(controller)
+	templateHandler := New(mockSvc)
+
+	mockContainer, _ := container.NewMockContainer(t)
+
+	ctx := &gofr.Context{
+		Context:   t.Context(),
+		Request:   nil,
+		Container: mockContainer,
+	}
+
+	testcases := []struct {
+		name             string
+		requestBody      string
+		mockExpect       func()
+		expectedResponse any
+		expectedError    error
+	}{
+		{
+			"success",
+			`{"name": "release", "tasks": [{"title": "ship"}]}`,
+			func() {
+				mockSvc.EXPECT().Create(ctx, &models.Template{Name: "release", Tasks: []models.TemplateTask{{Title: "ship"}}}).
+					Return(int64(1), nil)
+			},
+			int64(1),
+			nil,
+		},
+		{
+			"bind error",
+			`{"name":`,
+			func() {},
+			nil,
+			errInvalidBody,
+		},
+		{
+			"missing name",
+			`{"tasks": []}`,
+			func() {},
+			nil,
+			apperr.Validation(apperr.Field("name", "must not be empty")),
+		},
+		{
+			"service create error",
+			`{"name": "release"}`,
+			func() {
+				mockSvc.EXPECT().Create(ctx, &models.Template{Name: "release"}).Return(int64(0), utils.ErrTest)
+			},
+			nil,
+			utils.ErrTest,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.mockExpect()
+
+			req := httptest.NewRequest(http.MethodPost, "/template", bytes.NewReader([]byte(tc.requestBody)))
+			req.Header.Set("Content-Type", "application/json")
+
+			ctx.Request = gofrhttp.NewRequest(req)
+
+			id, err := templateHandler.Post(ctx)
+			if err != nil && err.Error() != tc.expectedError.Error() {
+				t.Errorf("error, expected %v, got %v", tc.expectedError, err)
+			}
+
+			if id != tc.expectedResponse {
+				t.Errorf("expected: %v, got: %v", tc.expectedResponse, id)
+			}
+		})
+	}
+}
+
+func TestHandler_Instantiate(t *testing.T) {
+	controller := gomock.NewController(t)
+	mockSvc := NewMockService(controller)
+	templateHandler := New(mockSvc)
+
+	mockContainer, _ := container.NewMockContainer(t)
+
+	ctx := &gofr.Context{
+		Context:   t.Context(),
+		Request:   nil,
+		Container: mockContainer,
+	}
+
+	testcases := []struct {
+		name             string
+		id               string
+		requestBody      string
+		mockExpect       func()
+		expectedResponse any
+		expectedError    error
+	}{
+		{
+			"success",
+			"1",
+			`{"user_id": 2, "variables": {"release_version": "1.2"}}`,
+			func() {
+				mockSvc.EXPECT().Instantiate(ctx, int64(1),
+					&models.Instantiation{UserID: 2, Variables: map[string]string{"release_version": "1.2"}}).
+					Return([]models.Task{{ID: 7}}, nil)
+			},
+			[]models.Task{{ID: 7}},
+			nil,
+		},
+		{
+			"invalid id",
+			"abc",
+			`{}`,
+			func() {},
+			nil,
+			apperr.Validation(apperr.Field("id", "must be an integer")),
+		},
+		{
+			"bind error",
+			"1",
+			`{"user_id":`,
+			func() {},
+			nil,
+			errInvalidBody,
+		},
+		{
+			"service error",
+			"1",
+			`{"user_id": 2}`,
+			func() {
+				mockSvc.EXPECT().Instantiate(ctx, int64(1), &models.Instantiation{UserID: 2}).Return(nil, utils.ErrTest)
+			},
+			nil,
+			utils.ErrTest,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.mockExpect()
+
+			req := httptest.NewRequest(http.MethodPost, "/template/"+tc.id+"/instantiate", bytes.NewReader([]byte(tc.requestBody)))
+			req.Header.Set("Content-Type", "application/json")
+			req = mux.SetURLVars(req, map[string]string{"id": tc.id})
+
+			ctx.Request = gofrhttp.NewRequest(req)
+
+			resp, err := templateHandler.Instantiate(ctx)
+			if err != nil && err.Error() != tc.expectedError.Error() {
+				t.Errorf("error, expected %v, got %v", tc.expectedError, err)
+			}
+
+			if !reflect.DeepEqual(resp, tc.expectedResponse) {
+				t.Errorf("expected: %v, got: %v", tc.expectedResponse, resp)
+			}
+		})
+	}
+}
@@ -0,0 +1,14 @@
+package template
+
+import (
+	"gofr.dev/pkg/gofr"
+
+	"TaskManager2/models"
+)
+
+type Service interface {
+	Create(*gofr.Context, *models.Template) (int64, error)
+	GetAll(*gofr.Context) ([]models.Template, error)
+	GetByID(*gofr.Context, int64) (*models.Template, error)
+	Instantiate(*gofr.Context, int64, *models.Instantiation) ([]models.Task, error)
+}
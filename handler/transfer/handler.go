@@ -0,0 +1,153 @@
+package transfer
+
+import (
+	"net/http"
+	"strconv"
+
+	"gofr.dev/pkg/gofr"
+	"gofr.dev/pkg/gofr/container"
+
+	"TaskManager2/apperr"
+	"TaskManager2/handler/httperr"
+	"TaskManager2/models"
+)
+
+// Path is answered by Middleware. It must also be registered as a route,
+// since the router only runs middleware for requests that match one.
+const Path = "/export"
+
+var (
+	errInvalidFormat = apperr.Validation(apperr.Field("format", "must be one of csv, json, ndjson"))
+	errInvalidID     = apperr.Validation(apperr.Field("id", "must be an integer"))
+	errInvalidBody   = apperr.Validation(apperr.Field("body", "must be a JSON object"))
+)
+
+type handler struct {
+	service Service
+}
+
+func New(service Service) *handler {
+	return &handler{service: service}
+}
+
+// Middleware streams all tasks to GET /export?format=csv|json|ndjson, csv by
+// default. gofr handlers return a single response, which would hold every
+// task in memory, so the export is written before the request reaches one.
+// Errors before the first write get an error response; later ones abort the
+// response, so that clients see it incomplete rather than short.
+func (h *handler) Middleware(c *container.Container, inner http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet || r.URL.Path != Path {
+			inner.ServeHTTP(w, r)
+
+			return
+		}
+
+		format := r.URL.Query().Get("format")
+		if format == "" {
+			format = models.FormatCSV
+		}
+
+		contentType, ok := contentType(format)
+		if !ok {
+			httperr.Write(w, errInvalidFormat)
+
+			return
+		}
+
+		out := &export{w: w, contentType: contentType, filename: "tasks." + format}
+		ctx := &gofr.Context{Context: r.Context(), Container: c}
+
+		err := h.service.Export(ctx, format, out)
+
+		switch {
+		case err == nil:
+			out.start()
+		case !out.started:
+			c.Logger.Errorf("exporting tasks: %v", err)
+			httperr.Write(w, err)
+		default:
+			c.Logger.Errorf("exporting tasks: %v", err)
+			panic(http.ErrAbortHandler)
+		}
+	})
+}
+
+func contentType(format string) (string, bool) {
+	switch format {
+	case models.FormatCSV:
+		return "text/csv; charset=utf-8", true
+	case models.FormatJSON:
+		return "application/json", true
+	case models.FormatNDJSON:
+		return "application/x-ndjson", true
+	default:
+		return "", false
+	}
+}
+
+// export sends the response headers with the first write, which leaves
+// errors before it to be reported with a status.
+type export struct {
+	w           http.ResponseWriter
+	contentType string
+	filename    string
+	started     bool
+}
+
+func (e *export) start() {
+	if e.started {
+		return
+	}
+
+	e.started = true
+
+	e.w.Header().Set("Content-Type", e.contentType)
+	e.w.Header().Set("Content-Disposition", `attachment; filename="`+e.filename+`"`)
+	e.w.Header().Set("X-Content-Type-Options", "nosniff")
+	e.w.WriteHeader(http.StatusOK)
+}
+
+func (e *export) Write(p []byte) (int, error) {
+	e.start()
+
+	return e.w.Write(p)
+}
+
+// Unreachable is the route handler for Path. Middleware answers the requests
+// first, so it only runs if the middleware is not installed.
+func Unreachable(*gofr.Context) (any, error) {
+	return nil, apperr.NotFound("route", Path)
+}
+
+// Import imports the tasks in the request, or queues a job to import them
+// when there are many; GetJob then reports its progress.
+func (h *handler) Import(ctx *gofr.Context) (any, error) {
+	var req models.ImportRequest
+
+	err := ctx.Bind(&req)
+	if err != nil {
+		return nil, errInvalidBody
+	}
+
+	job, err := h.service.Import(ctx, &req)
+	if err != nil {
+		return nil, err
+	}
+
+	return job, nil
+}
+
+func (h *handler) GetJob(ctx *gofr.Context) (any, error) {
+	id, err := strconv.Atoi(ctx.PathParam("id"))
+	if err != nil {
+		return nil, errInvalidID
+	}
+
+	job, err := h.service.GetJob(ctx, int64(id))
+	if err != nil {
+		return nil, err
+	}
+
+	return job, nil
+}
@@ -0,0 +1,237 @@
+package transfer
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"github.com/gorilla/mux"
+	"go.uber.org/mock/gomock"
+	"gofr.dev/pkg/gofr"
+	"gofr.dev/pkg/gofr/container"
+	gofrhttp "gofr.dev/pkg/gofr/http"
+
+	"TaskManager2/apperr"
+	"TaskManager2/models"
+	"TaskManager2/utils"
+)
+
+func TestHandler_Middleware(t *testing.T) {
+	mockContainer, _ := container.NewMockContainer(t)
+	controller := gomock.NewController(t)
+	mockSvc := NewMockService(controller)
+
+	next := http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusTeapot)
+	})
+	server := New(mockSvc).Middleware(mockContainer, next)
+
+	write := func(s string) func(*gofr.Context, string, io.Writer) error {
+		return func(_ *gofr.Context, _ string, w io.Writer) error {
+			_, err := io.WriteString(w, s)
+
+			return err
+		}
+	}
+
+	testcases := []struct {
+		description         string
+		target              string
+		mockExpect          func()
+		expectedStatus      int
+		expectedContentType string
+		expectedBody        string
+	}{
+		{
+			"csv by default",
+			"/export",
+			func() {
+				mockSvc.EXPECT().Export(gomock.Any(), "csv", gomock.Any()).DoAndReturn(write("id,title\n"))
+			},
+			http.StatusOK, "text/csv; charset=utf-8", "id,title\n",
+		},
+		{
+			"ndjson without tasks",
+			"/export?format=ndjson",
+			func() {
+				mockSvc.EXPECT().Export(gomock.Any(), "ndjson", gomock.Any()).Return(nil)
+			},
+			http.StatusOK, "application/x-ndjson", "",
+		},
+		{
+			"error before writing",
+			"/export?format=json",
+			func() {
+				mockSvc.EXPECT().Export(gomock.Any(), "json", gomock.Any()).Return(utils.ErrTest)
+			},
+			http.StatusInternalServerError, "", "",
+		},
+		{"invalid format", "/export?format=xml", func() {}, http.StatusBadRequest, "", ""},
+		{"other routes", "/task", func() {}, http.StatusTeapot, "", ""},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.description, func(t *testing.T) {
+			tc.mockExpect()
+
+			req := httptest.NewRequest(http.MethodGet, tc.target, http.NoBody)
+			rec := httptest.NewRecorder()
+			server.ServeHTTP(rec, req)
+
+			if rec.Code != tc.expectedStatus {
+				t.Fatalf("expected status %d, got %d", tc.expectedStatus, rec.Code)
+			}
+
+			if tc.expectedStatus == http.StatusOK && (rec.Header().Get("Content-Type") != tc.expectedContentType ||
+				rec.Body.String() != tc.expectedBody) {
+				t.Errorf("expected %s %q, got %s %q", tc.expectedContentType, tc.expectedBody, rec.Header().Get("Content-Type"),
+					rec.Body.String())
+			}
+		})
+	}
+}
+
+func TestHandler_MiddlewareAborts(t *testing.T) {
+	mockContainer, _ := container.NewMockContainer(t)
+	controller := gomock.NewController(t)
+	mockSvc := NewMockService(controller)
+	server := New(mockSvc).Middleware(mockContainer, http.NotFoundHandler())
+
+	mockSvc.EXPECT().Export(gomock.Any(), "csv", gomock.Any()).DoAndReturn(func(_ *gofr.Context, _ string, w io.Writer) error {
+		_, _ = io.WriteString(w, "id,title\n")
+
+		return utils.ErrTest
+	})
+
+	defer func() {
+		if r := recover(); r != http.ErrAbortHandler {
+			t.Errorf("expected the response to be aborted, got %v", r)
+		}
+	}()
+
+	server.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/export", http.NoBody))
+}
+
+func TestUnreachable(t *testing.T) {
+	_, err := Unreachable(&gofr.Context{Context: t.Context()})
+	if err == nil {
+		t.Error("expected an error")
+	}
+}
+
+func TestHandler_Import(t *testing.T) {
+	controller := gomock.NewController(t)
+	mockSvc := NewMockService(controller)
+	transferHandler := New(mockSvc)
+
+	mockContainer, _ := container.NewMockContainer(t)
+
+	ctx := &gofr.Context{
+		Context:   t.Context(),
+		Request:   nil,
+		Container: mockContainer,
+	}
+
+	job := &models.ImportJob{ID: 3, Format: "csv", Status: models.ImportPending, Total: 501}
+	req := &models.ImportRequest{Format: "csv", Data: "title\nShip it\n", Mapping: map[string]string{"Name": "title"}, DryRun: true}
+
+	testcases := []struct {
+		name             string
+		requestBody      string
+		mockExpect       func()
+		expectedResponse any
+		expectedError    error
+	}{
+		{
+			"success",
+			`{"format": "csv", "data": "title\nShip it\n", "mapping": {"Name": "title"}, "dry_run": true}`,
+			func() {
+				mockSvc.EXPECT().Import(ctx, req).Return(job, nil)
+			},
+			job,
+			nil,
+		},
+		{"invalid body", `[]`, func() {}, nil, errInvalidBody},
+		{
+			"service error",
+			`{"format": "csv", "data": "title\nShip it\n", "mapping": {"Name": "title"}, "dry_run": true}`,
+			func() {
+				mockSvc.EXPECT().Import(ctx, req).Return(nil, utils.ErrTest)
+			},
+			nil,
+			utils.ErrTest,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.mockExpect()
+
+			r := httptest.NewRequest(http.MethodPost, "/import", bytes.NewReader([]byte(tc.requestBody)))
+			r.Header.Set("Content-Type", "application/json")
+			ctx.Request = gofrhttp.NewRequest(r)
+
+			res, err := transferHandler.Import(ctx)
+			if !errors.Is(err, tc.expectedError) {
+				t.Errorf("error, expected %v, got %v", tc.expectedError, err)
+			}
+
+			if !reflect.DeepEqual(res, tc.expectedResponse) {
+				t.Errorf("expected: %v, got: %v", tc.expectedResponse, res)
+			}
+		})
+	}
+}
+
+func TestHandler_GetJob(t *testing.T) {
+	controller := gomock.NewController(t)
+	mockSvc := NewMockService(controller)
+	transferHandler := New(mockSvc)
+
+	mockContainer, _ := container.NewMockContainer(t)
+
+	ctx := &gofr.Context{
+		Context:   t.Context(),
+		Request:   nil,
+		Container: mockContainer,
+	}
+
+	job := &models.ImportJob{ID: 3, Status: models.ImportImporting, Total: 501, Processed: 200}
+
+	testcases := []struct {
+		name             string
+		requestID        string
+		mockExpect       func()
+		expectedResponse any
+		expectedError    error
+	}{
+		{"success", "3", func() { mockSvc.EXPECT().GetJob(ctx, int64(3)).Return(job, nil) }, job, nil},
+		{"not found", "4", func() {
+			mockSvc.EXPECT().GetJob(ctx, int64(4)).Return(nil, apperr.NotFound("import job", 4))
+		}, nil, apperr.NotFound("import job", 4)},
+		{"invalid id", "abc", func() {}, nil, errInvalidID},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.mockExpect()
+
+			r := httptest.NewRequest(http.MethodGet, "/import/{id}", http.NoBody)
+			r = mux.SetURLVars(r, map[string]string{"id": tc.requestID})
+			ctx.Request = gofrhttp.NewRequest(r)
+
+			res, err := transferHandler.GetJob(ctx)
+			if !errors.Is(err, tc.expectedError) {
+				t.Errorf("error, expected %v, got %v", tc.expectedError, err)
+			}
+
+			if !reflect.DeepEqual(res, tc.expectedResponse) {
+				t.Errorf("expected: %v, got: %v", tc.expectedResponse, res)
+			}
+		})
+	}
+}
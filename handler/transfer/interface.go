@@ -0,0 +1,15 @@
+package transfer
+
+import (
+	"io"
+
+	"gofr.dev/pkg/gofr"
+
+	"TaskManager2/models"
+)
+
+type Service interface {
+	Export(*gofr.Context, string, io.Writer) error
+	Import(*gofr.Context, *models.ImportRequest) (*models.ImportJob, error)
+	GetJob(*gofr.Context, int64) (*models.ImportJob, error)
+}
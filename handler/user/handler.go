@@ -4,12 +4,14 @@ import (
 	"strconv"
 
 	"gofr.dev/pkg/gofr"
-	gofrhttp "gofr.dev/pkg/gofr/http"
 	"gofr.dev/pkg/gofr/http/response"
 
+	"TaskManager2/apperr"
 	"TaskManager2/models"
 )
 
+var errInvalidBody = apperr.Validation(apperr.Field("body", "must be a JSON object"))
+
 type handler struct {
 	service Service
 }
@@ -23,7 +25,7 @@ func (h *handler) Post(ctx *gofr.Context) (any, error) {
 
 	err := ctx.Bind(&task)
 	if err != nil {
-		return nil, gofrhttp.ErrorInvalidParam{}
+		return nil, errInvalidBody
 	}
 
 	id, err := h.service.Create(ctx, &task)
@@ -37,7 +39,7 @@ func (h *handler) Post(ctx *gofr.Context) (any, error) {
 func (h *handler) GetByID(ctx *gofr.Context) (any, error) {
 	id, err := strconv.Atoi(ctx.PathParam("id"))
 	if err != nil {
-		return nil, gofrhttp.ErrorInvalidParam{Params: []string{ctx.PathParam("id")}}
+		return nil, apperr.Validation(apperr.Field("id", "must be an integer"))
 	}
 
 	user, err := h.service.GetByID(ctx, int64(id))
@@ -47,3 +49,18 @@ func (h *handler) GetByID(ctx *gofr.Context) (any, error) {
 
 	return response.Raw{Data: user}, nil
 }
+
+// Get looks a user up by the email query parameter.
+func (h *handler) Get(ctx *gofr.Context) (any, error) {
+	email := ctx.Param("email")
+	if email == "" {
+		return nil, apperr.Validation(apperr.Field("email", "is required"))
+	}
+
+	user, err := h.service.GetByEmail(ctx, email)
+	if err != nil {
+		return nil, err
+	}
+
+	return response.Raw{Data: user}, nil
+}
@@ -2,8 +2,10 @@ package user
 
 import (
 	"bytes"
+	"errors"
 	"net/http"
 	"net/http/httptest"
+	"reflect"
 	"testing"
 
 	"github.com/gorilla/mux"
@@ -11,7 +13,9 @@ import (
 	"gofr.dev/pkg/gofr"
 	"gofr.dev/pkg/gofr/container"
 	gofrhttp "gofr.dev/pkg/gofr/http"
+	"gofr.dev/pkg/gofr/http/response"
 
+	"TaskManager2/apperr"
 	"TaskManager2/models"
 	"TaskManager2/utils"
 )
@@ -53,7 +57,7 @@ func TestHandler_Post(t *testing.T) {
 			`describe":"test task","status":false,"user_id":1}`,
 			func() {},
 			nil,
-			gofrhttp.ErrorInvalidParam{},
+			errInvalidBody,
 		},
 		{
 			"service create error",
@@ -124,7 +128,7 @@ func TestHandler_GetByID(t *testing.T) {
 			"abc",
 			func() {},
 			nil,
-			gofrhttp.ErrorInvalidParam{Params: []string{"abc"}},
+			apperr.Validation(apperr.Field("id", "must be an integer")),
 		},
 		{
 			"service GetByID error",
@@ -165,3 +169,68 @@ func TestHandler_GetByID(t *testing.T) {
 		})
 	}
 }
+
+func TestHandler_Get(t *testing.T) {
+	controller := gomock.NewController(t)
+	mockSvc := NewMockService(controller)
+	userHandler := New(mockSvc)
+
+	mockContainer, _ := container.NewMockContainer(t)
+	ctx := &gofr.Context{
+		Context:   t.Context(),
+		Request:   nil,
+		Container: mockContainer,
+	}
+
+	testcases := []struct {
+		name             string
+		query            string
+		mockExpect       func()
+		expectedResponse any
+		expectedError    error
+	}{
+		{
+			"success",
+			"?email=Test%40Example.com",
+			func() {
+				mockSvc.EXPECT().GetByEmail(ctx, "Test@Example.com").Return(&models.User{ID: 1}, nil)
+			},
+			response.Raw{Data: &models.User{ID: 1}},
+			nil,
+		},
+		{
+			"missing email",
+			"",
+			func() {},
+			nil,
+			apperr.Validation(apperr.Field("email", "is required")),
+		},
+		{
+			"service GetByEmail error",
+			"?email=test%40example.com",
+			func() {
+				mockSvc.EXPECT().GetByEmail(ctx, "test@example.com").Return(nil, apperr.NotFound("user", "test@example.com"))
+			},
+			nil,
+			apperr.NotFound("user", "test@example.com"),
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.mockExpect()
+
+			req := httptest.NewRequest(http.MethodGet, "/user"+tc.query, http.NoBody)
+			ctx.Request = gofrhttp.NewRequest(req)
+
+			res, err := userHandler.Get(ctx)
+			if !errors.Is(err, tc.expectedError) {
+				t.Errorf("error, expected %v, got %v", tc.expectedError, err)
+			}
+
+			if !reflect.DeepEqual(res, tc.expectedResponse) {
+				t.Errorf("expected: %v, got: %v", tc.expectedResponse, res)
+			}
+		})
+	}
+}
@@ -9,4 +9,5 @@ import (
 type Service interface {
 	Create(*gofr.Context, *models.User) (int64, error)
 	GetByID(*gofr.Context, int64) (*models.User, error)
+	GetByEmail(*gofr.Context, string) (*models.User, error)
 }
@@ -0,0 +1,150 @@
+package view
+
+import (
+	"strconv"
+	"strings"
+
+	"gofr.dev/pkg/gofr"
+	"gofr.dev/pkg/gofr/http/response"
+
+	"TaskManager2/apperr"
+	"TaskManager2/middleware"
+	"TaskManager2/models"
+)
+
+var (
+	errInvalidBody          = apperr.Validation(apperr.Field("body", "must be a JSON object"))
+	errInvalidID            = apperr.Validation(apperr.Field("id", "must be an integer"))
+	errPreconditionRequired = apperr.PreconditionRequired("the If-Match header is required, send the ETag returned by GET /view/{id}")
+	errInvalidETag          = apperr.PreconditionFailed("the If-Match header does not hold a view ETag")
+)
+
+type handler struct {
+	service Service
+}
+
+func New(service Service) *handler {
+	return &handler{service: service}
+}
+
+func (h *handler) Post(ctx *gofr.Context) (any, error) {
+	var v models.View
+
+	err := ctx.Bind(&v)
+	if err != nil {
+		return nil, errInvalidBody
+	}
+
+	created, err := h.service.Create(ctx, &v)
+	if err != nil {
+		return nil, err
+	}
+
+	return response.Response{Data: created, Headers: map[string]string{"ETag": etag(created.Version)}}, nil
+}
+
+func (h *handler) GetAll(ctx *gofr.Context) (any, error) {
+	views, err := h.service.GetAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return views, nil
+}
+
+func (h *handler) GetByID(ctx *gofr.Context) (any, error) {
+	id, err := strconv.Atoi(ctx.PathParam("id"))
+	if err != nil {
+		return nil, errInvalidID
+	}
+
+	v, err := h.service.GetByID(ctx, int64(id))
+	if err != nil {
+		return nil, err
+	}
+
+	return response.Response{Data: v, Headers: map[string]string{"ETag": etag(v.Version)}}, nil
+}
+
+// Put replaces the view, provided it is still at the version named by the If-Match header.
+func (h *handler) Put(ctx *gofr.Context) (any, error) {
+	id, err := strconv.Atoi(ctx.PathParam("id"))
+	if err != nil {
+		return nil, errInvalidID
+	}
+
+	version, err := ifMatch(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var v models.View
+
+	err = ctx.Bind(&v)
+	if err != nil {
+		return nil, errInvalidBody
+	}
+
+	v.ID = int64(id)
+	v.Version = version
+
+	updated, err := h.service.Update(ctx, &v)
+	if err != nil {
+		return nil, err
+	}
+
+	return response.Response{Data: updated, Headers: map[string]string{"ETag": etag(updated.Version)}}, nil
+}
+
+func (h *handler) Delete(ctx *gofr.Context) (any, error) {
+	id, err := strconv.Atoi(ctx.PathParam("id"))
+	if err != nil {
+		return nil, errInvalidID
+	}
+
+	err = h.service.Delete(ctx, int64(id))
+	if err != nil {
+		return nil, err
+	}
+
+	return nil, nil
+}
+
+// Tasks runs the view and returns its tasks.
+func (h *handler) Tasks(ctx *gofr.Context) (any, error) {
+	id, err := strconv.Atoi(ctx.PathParam("id"))
+	if err != nil {
+		return nil, errInvalidID
+	}
+
+	res, err := h.service.Tasks(ctx, int64(id))
+	if err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+func etag(version int64) string {
+	return `"` + strconv.FormatInt(version, 10) + `"`
+}
+
+// ifMatch returns the version named by the If-Match header. "*" matches any
+// version and is returned as 0.
+func ifMatch(ctx *gofr.Context) (int64, error) {
+	value := strings.TrimSpace(middleware.Header(ctx, "If-Match"))
+
+	switch value {
+	case "":
+		return 0, errPreconditionRequired
+	case "*":
+		return 0, nil
+	}
+
+	version, err := strconv.ParseInt(strings.Trim(strings.TrimPrefix(value, "W/"), `"`), 10, 64)
+	if err != nil || version < 1 {
+		return 0, errInvalidETag
+	}
+
+	return version, nil
+}
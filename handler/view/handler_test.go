@@ -0,0 +1,374 @@
+package view
+
+import (
+	"bytes"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"github.com/gorilla/mux"
+	"go.uber.org/mock/gomock"
+	"gofr.dev/pkg/gofr"
+	"gofr.dev/pkg/gofr/container"
+	gofrhttp "gofr.dev/pkg/gofr/http"
+	"gofr.dev/pkg/gofr/http/response"
+
+	"TaskManager2/middleware"
+	"TaskManager2/models"
+	"TaskManager2/utils"
+)
+
+func TestHandler_Post(t *testing.T) {
+	controller := gomock.NewController(t)
+	mockSvc := NewMockService(controller)
+	viewHandler := New(mockSvc)
+
+	mockContainer, _ := container.NewMockContainer(t)
+
+	ctx := &gofr.Context{
+		Context:   t.Context(),
+		Request:   nil,
+		Container: mockContainer,
+	}
+
+	created := &models.View{ID: 9, Owner: "7", Name: "Bugs", Visibility: models.ViewPrivate, Filter: "tag:bug", Version: 1}
+
+	testcases := []struct {
+		name             string
+		requestBody      string
+		mockExpect       func()
+		expectedResponse any
+		expectedError    error
+	}{
+		{
+			"success",
+			`{"name": "Bugs", "filter": "tag:bug"}`,
+			func() {
+				mockSvc.EXPECT().Create(ctx, &models.View{Name: "Bugs", Filter: "tag:bug"}).Return(created, nil)
+			},
+			response.Response{Data: created, Headers: map[string]string{"ETag": `"1"`}},
+			nil,
+		},
+		{
+			"bind error",
+			`{"name":`,
+			func() {},
+			nil,
+			errInvalidBody,
+		},
+		{
+			"service error",
+			`{"name": "Bugs"}`,
+			func() {
+				mockSvc.EXPECT().Create(ctx, &models.View{Name: "Bugs"}).Return(nil, utils.ErrTest)
+			},
+			nil,
+			utils.ErrTest,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.mockExpect()
+
+			req := httptest.NewRequest(http.MethodPost, "/view", bytes.NewReader([]byte(tc.requestBody)))
+			req.Header.Set("Content-Type", "application/json")
+			ctx.Request = gofrhttp.NewRequest(req)
+
+			res, err := viewHandler.Post(ctx)
+			if !errors.Is(err, tc.expectedError) {
+				t.Errorf("error, expected %v, got %v", tc.expectedError, err)
+			}
+
+			if !reflect.DeepEqual(res, tc.expectedResponse) {
+				t.Errorf("expected: %v, got: %v", tc.expectedResponse, res)
+			}
+		})
+	}
+}
+
+func TestHandler_GetByID(t *testing.T) {
+	controller := gomock.NewController(t)
+	mockSvc := NewMockService(controller)
+	viewHandler := New(mockSvc)
+
+	mockContainer, _ := container.NewMockContainer(t)
+
+	ctx := &gofr.Context{
+		Context:   t.Context(),
+		Request:   nil,
+		Container: mockContainer,
+	}
+
+	v := &models.View{ID: 9, Owner: "7", Name: "Bugs", Version: 3}
+
+	testcases := []struct {
+		name             string
+		requestID        string
+		mockExpect       func()
+		expectedResponse any
+		expectedError    error
+	}{
+		{
+			"success",
+			"9",
+			func() {
+				mockSvc.EXPECT().GetByID(ctx, int64(9)).Return(v, nil)
+			},
+			response.Response{Data: v, Headers: map[string]string{"ETag": `"3"`}},
+			nil,
+		},
+		{
+			"invalid id",
+			"abc",
+			func() {},
+			nil,
+			errInvalidID,
+		},
+		{
+			"service error",
+			"9",
+			func() {
+				mockSvc.EXPECT().GetByID(ctx, int64(9)).Return(nil, utils.ErrTest)
+			},
+			nil,
+			utils.ErrTest,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.mockExpect()
+
+			req := httptest.NewRequest(http.MethodGet, "/view/{id}", http.NoBody)
+			req = mux.SetURLVars(req, map[string]string{"id": tc.requestID})
+			ctx.Request = gofrhttp.NewRequest(req)
+
+			res, err := viewHandler.GetByID(ctx)
+			if !errors.Is(err, tc.expectedError) {
+				t.Errorf("error, expected %v, got %v", tc.expectedError, err)
+			}
+
+			if !reflect.DeepEqual(res, tc.expectedResponse) {
+				t.Errorf("expected: %v, got: %v", tc.expectedResponse, res)
+			}
+		})
+	}
+}
+
+func TestHandler_Put(t *testing.T) {
+	controller := gomock.NewController(t)
+	mockSvc := NewMockService(controller)
+	viewHandler := New(mockSvc)
+
+	mockContainer, _ := container.NewMockContainer(t)
+
+	ctx := &gofr.Context{
+		Context:   t.Context(),
+		Request:   nil,
+		Container: mockContainer,
+	}
+
+	updated := &models.View{ID: 9, Owner: "7", Name: "Open", Version: 3}
+
+	testcases := []struct {
+		name             string
+		requestID        string
+		ifMatch          string
+		requestBody      string
+		mockExpect       func()
+		expectedResponse any
+		expectedError    error
+	}{
+		{
+			"success",
+			"9",
+			`W/"2"`,
+			`{"name": "Open"}`,
+			func() {
+				mockSvc.EXPECT().Update(ctx, &models.View{ID: 9, Name: "Open", Version: 2}).Return(updated, nil)
+			},
+			response.Response{Data: updated, Headers: map[string]string{"ETag": `"3"`}},
+			nil,
+		},
+		{
+			"any version",
+			"9",
+			"*",
+			`{"name": "Open"}`,
+			func() {
+				mockSvc.EXPECT().Update(ctx, &models.View{ID: 9, Name: "Open"}).Return(updated, nil)
+			},
+			response.Response{Data: updated, Headers: map[string]string{"ETag": `"3"`}},
+			nil,
+		},
+		{
+			"missing If-Match",
+			"9",
+			"",
+			`{"name": "Open"}`,
+			func() {},
+			nil,
+			errPreconditionRequired,
+		},
+		{
+			"malformed If-Match",
+			"9",
+			`"abc"`,
+			`{"name": "Open"}`,
+			func() {},
+			nil,
+			errInvalidETag,
+		},
+		{
+			"invalid id",
+			"abc",
+			`"2"`,
+			`{"name": "Open"}`,
+			func() {},
+			nil,
+			errInvalidID,
+		},
+		{
+			"bind error",
+			"9",
+			`"2"`,
+			`{"name":`,
+			func() {},
+			nil,
+			errInvalidBody,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.mockExpect()
+
+			req := httptest.NewRequest(http.MethodPut, "/view/{id}", bytes.NewReader([]byte(tc.requestBody)))
+			req.Header.Set("Content-Type", "application/json")
+
+			if tc.ifMatch != "" {
+				req.Header.Set("If-Match", tc.ifMatch)
+			}
+
+			req = withRequestMetadata(req)
+			req = mux.SetURLVars(req, map[string]string{"id": tc.requestID})
+			ctx.Context = req.Context()
+			ctx.Request = gofrhttp.NewRequest(req)
+
+			res, err := viewHandler.Put(ctx)
+			if !errors.Is(err, tc.expectedError) {
+				t.Errorf("error, expected %v, got %v", tc.expectedError, err)
+			}
+
+			if !reflect.DeepEqual(res, tc.expectedResponse) {
+				t.Errorf("expected: %v, got: %v", tc.expectedResponse, res)
+			}
+		})
+	}
+}
+
+// withRequestMetadata passes req through the RequestMetadata middleware so that handlers can read its headers.
+func withRequestMetadata(req *http.Request) *http.Request {
+	middleware.RequestMetadata(http.HandlerFunc(func(_ http.ResponseWriter, r *http.Request) {
+		req = r
+	})).ServeHTTP(httptest.NewRecorder(), req)
+
+	return req
+}
+
+func TestHandler_Delete(t *testing.T) {
+	controller := gomock.NewController(t)
+	mockSvc := NewMockService(controller)
+	viewHandler := New(mockSvc)
+
+	mockContainer, _ := container.NewMockContainer(t)
+
+	ctx := &gofr.Context{
+		Context:   t.Context(),
+		Request:   nil,
+		Container: mockContainer,
+	}
+
+	mockSvc.EXPECT().Delete(ctx, int64(9)).Return(nil)
+
+	req := httptest.NewRequest(http.MethodDelete, "/view/{id}", http.NoBody)
+	req = mux.SetURLVars(req, map[string]string{"id": "9"})
+	ctx.Request = gofrhttp.NewRequest(req)
+
+	_, err := viewHandler.Delete(ctx)
+	if err != nil {
+		t.Error(err)
+	}
+}
+
+func TestHandler_Tasks(t *testing.T) {
+	controller := gomock.NewController(t)
+	mockSvc := NewMockService(controller)
+	viewHandler := New(mockSvc)
+
+	mockContainer, _ := container.NewMockContainer(t)
+
+	ctx := &gofr.Context{
+		Context:   t.Context(),
+		Request:   nil,
+		Container: mockContainer,
+	}
+
+	result := &models.ViewResult{ViewID: 9, Version: 1, Groups: []models.ViewGroup{{Tasks: []map[string]any{{"id": 1}}}}}
+
+	testcases := []struct {
+		name             string
+		requestID        string
+		mockExpect       func()
+		expectedResponse any
+		expectedError    error
+	}{
+		{
+			"success",
+			"9",
+			func() {
+				mockSvc.EXPECT().Tasks(ctx, int64(9)).Return(result, nil)
+			},
+			result,
+			nil,
+		},
+		{
+			"invalid id",
+			"abc",
+			func() {},
+			nil,
+			errInvalidID,
+		},
+		{
+			"service error",
+			"9",
+			func() {
+				mockSvc.EXPECT().Tasks(ctx, int64(9)).Return(nil, utils.ErrTest)
+			},
+			nil,
+			utils.ErrTest,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.mockExpect()
+
+			req := httptest.NewRequest(http.MethodGet, "/view/{id}/tasks", http.NoBody)
+			req = mux.SetURLVars(req, map[string]string{"id": tc.requestID})
+			ctx.Request = gofrhttp.NewRequest(req)
+
+			res, err := viewHandler.Tasks(ctx)
+			if !errors.Is(err, tc.expectedError) {
+				t.Errorf("error, expected %v, got %v", tc.expectedError, err)
+			}
+
+			if !reflect.DeepEqual(res, tc.expectedResponse) {
+				t.Errorf("expected: %v, got: %v", tc.expectedResponse, res)
+			}
+		})
+	}
+}
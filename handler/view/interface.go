@@ -0,0 +1,16 @@
+package view
+
+import (
+	"gofr.dev/pkg/gofr"
+
+	"TaskManager2/models"
+)
+
+type Service interface {
+	Create(*gofr.Context, *models.View) (*models.View, error)
+	GetAll(*gofr.Context) ([]models.View, error)
+	GetByID(*gofr.Context, int64) (*models.View, error)
+	Update(*gofr.Context, *models.View) (*models.View, error)
+	Delete(*gofr.Context, int64) error
+	Tasks(*gofr.Context, int64) (*models.ViewResult, error)
+}
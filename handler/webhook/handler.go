@@ -0,0 +1,147 @@
+package webhook
+
+import (
+	"strconv"
+
+	"gofr.dev/pkg/gofr"
+
+	"TaskManager2/apperr"
+	"TaskManager2/models"
+)
+
+var (
+	errInvalidBody       = apperr.Validation(apperr.Field("body", "must be a JSON object"))
+	errInvalidID         = apperr.Validation(apperr.Field("id", "must be an integer"))
+	errInvalidDeliveryID = apperr.Validation(apperr.Field("delivery_id", "must be an integer"))
+	errInvalidLimit      = apperr.Validation(apperr.Field("limit", "must be an integer"))
+)
+
+type handler struct {
+	service Service
+}
+
+func New(service Service) *handler {
+	return &handler{service: service}
+}
+
+func (h *handler) Post(ctx *gofr.Context) (any, error) {
+	var w models.Webhook
+
+	err := ctx.Bind(&w)
+	if err != nil {
+		return nil, errInvalidBody
+	}
+
+	created, err := h.service.Create(ctx, &w)
+	if err != nil {
+		return nil, err
+	}
+
+	return created, nil
+}
+
+func (h *handler) GetAll(ctx *gofr.Context) (any, error) {
+	webhooks, err := h.service.GetAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return webhooks, nil
+}
+
+func (h *handler) GetByID(ctx *gofr.Context) (any, error) {
+	id, err := strconv.Atoi(ctx.PathParam("id"))
+	if err != nil {
+		return nil, errInvalidID
+	}
+
+	w, err := h.service.GetByID(ctx, int64(id))
+	if err != nil {
+		return nil, err
+	}
+
+	return w, nil
+}
+
+// Put replaces the webhook. The secret is kept when none is sent.
+func (h *handler) Put(ctx *gofr.Context) (any, error) {
+	id, err := strconv.Atoi(ctx.PathParam("id"))
+	if err != nil {
+		return nil, errInvalidID
+	}
+
+	var w models.Webhook
+
+	err = ctx.Bind(&w)
+	if err != nil {
+		return nil, errInvalidBody
+	}
+
+	w.ID = int64(id)
+
+	updated, err := h.service.Update(ctx, &w)
+	if err != nil {
+		return nil, err
+	}
+
+	return updated, nil
+}
+
+func (h *handler) Delete(ctx *gofr.Context) (any, error) {
+	id, err := strconv.Atoi(ctx.PathParam("id"))
+	if err != nil {
+		return nil, errInvalidID
+	}
+
+	err = h.service.Delete(ctx, int64(id))
+	if err != nil {
+		return nil, err
+	}
+
+	return nil, nil
+}
+
+// GetDeliveries lists the deliveries of the webhook, optionally only those
+// with the status query parameter.
+func (h *handler) GetDeliveries(ctx *gofr.Context) (any, error) {
+	id, err := strconv.Atoi(ctx.PathParam("id"))
+	if err != nil {
+		return nil, errInvalidID
+	}
+
+	filter := models.DeliveryFilter{WebhookID: int64(id), Status: ctx.Param("status")}
+
+	if v := ctx.Param("limit"); v != "" {
+		filter.Limit, err = strconv.Atoi(v)
+		if err != nil {
+			return nil, errInvalidLimit
+		}
+	}
+
+	deliveries, err := h.service.GetDeliveries(ctx, &filter)
+	if err != nil {
+		return nil, err
+	}
+
+	return deliveries, nil
+}
+
+// Replay sends a delivery of the webhook again.
+func (h *handler) Replay(ctx *gofr.Context) (any, error) {
+	id, err := strconv.Atoi(ctx.PathParam("id"))
+	if err != nil {
+		return nil, errInvalidID
+	}
+
+	deliveryID, err := strconv.Atoi(ctx.PathParam("delivery_id"))
+	if err != nil {
+		return nil, errInvalidDeliveryID
+	}
+
+	d, err := h.service.Replay(ctx, int64(id), int64(deliveryID))
+	if err != nil {
+		return nil, err
+	}
+
+	return d, nil
+}
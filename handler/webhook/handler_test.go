@@ -0,0 +1,390 @@
+package webhook
+
+import (
+	"bytes"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"github.com/gorilla/mux"
+	"go.uber.org/mock/gomock"
+	"gofr.dev/pkg/gofr"
+	"gofr.dev/pkg/gofr/container"
+	gofrhttp "gofr.dev/pkg/gofr/http"
+
+	"TaskManager2/models"
+	"TaskManager2/utils"
+)
+
+func TestHandler_Post(t *testing.T) {
+	controller := gomock.NewController(t)
+	mockSvc := NewMockService(controller)
+	webhookHandler := New(mockSvc)
+
+	mockContainer, _ := container.NewMockContainer(t)
+	ctx := &gofr.Context{
+		Context:   t.Context(),
+		Request:   nil,
+		Container: mockContainer,
+	}
+
+	created := &models.Webhook{ID: 4, URL: "https://example.com/hook", Events: []string{"task.created"}}
+
+	testcases := []struct {
+		name             string
+		requestBody      string
+		mockExpect       func()
+		expectedResponse any
+		expectedError    error
+	}{
+		{
+			"success",
+			`{"url": "https://example.com/hook", "secret": "0123456789abcdef", "events": ["task.created"]}`,
+			func() {
+				mockSvc.EXPECT().Create(ctx, &models.Webhook{URL: "https://example.com/hook", Secret: "0123456789abcdef",
+					Events: []string{"task.created"}}).Return(created, nil)
+			},
+			created,
+			nil,
+		},
+		{
+			"bind error",
+			`{"url":`,
+			func() {},
+			nil,
+			errInvalidBody,
+		},
+		{
+			"service error",
+			`{"url": "https://example.com/hook"}`,
+			func() {
+				mockSvc.EXPECT().Create(ctx, &models.Webhook{URL: "https://example.com/hook"}).Return(nil, utils.ErrTest)
+			},
+			nil,
+			utils.ErrTest,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.mockExpect()
+
+			req := httptest.NewRequest(http.MethodPost, "/webhook", bytes.NewReader([]byte(tc.requestBody)))
+			req.Header.Set("Content-Type", "application/json")
+			ctx.Request = gofrhttp.NewRequest(req)
+
+			res, err := webhookHandler.Post(ctx)
+			if !errors.Is(err, tc.expectedError) {
+				t.Errorf("error, expected %v, got %v", tc.expectedError, err)
+			}
+
+			if !reflect.DeepEqual(res, tc.expectedResponse) {
+				t.Errorf("expected: %v, got: %v", tc.expectedResponse, res)
+			}
+		})
+	}
+}
+
+func TestHandler_Read(t *testing.T) {
+	controller := gomock.NewController(t)
+	mockSvc := NewMockService(controller)
+	webhookHandler := New(mockSvc)
+
+	mockContainer, _ := container.NewMockContainer(t)
+	ctx := &gofr.Context{
+		Context:   t.Context(),
+		Request:   nil,
+		Container: mockContainer,
+	}
+
+	w := &models.Webhook{ID: 4, URL: "https://example.com/hook"}
+
+	mockSvc.EXPECT().GetAll(ctx).Return([]models.Webhook{*w}, nil)
+
+	res, err := webhookHandler.GetAll(ctx)
+	if err != nil || !reflect.DeepEqual(res, []models.Webhook{*w}) {
+		t.Errorf("expected the webhooks, got %v, %v", res, err)
+	}
+
+	for _, tc := range []struct {
+		requestID        string
+		mockExpect       func()
+		expectedResponse any
+		expectedError    error
+	}{
+		{"4", func() { mockSvc.EXPECT().GetByID(ctx, int64(4)).Return(w, nil) }, w, nil},
+		{"abc", func() {}, nil, errInvalidID},
+		{"5", func() { mockSvc.EXPECT().GetByID(ctx, int64(5)).Return(nil, utils.ErrTest) }, nil, utils.ErrTest},
+	} {
+		tc.mockExpect()
+
+		req := mux.SetURLVars(httptest.NewRequest(http.MethodGet, "/webhook/{id}", http.NoBody), map[string]string{"id": tc.requestID})
+		ctx.Request = gofrhttp.NewRequest(req)
+
+		res, err := webhookHandler.GetByID(ctx)
+		if !errors.Is(err, tc.expectedError) {
+			t.Errorf("error, expected %v, got %v", tc.expectedError, err)
+		}
+
+		if !reflect.DeepEqual(res, tc.expectedResponse) {
+			t.Errorf("expected: %v, got: %v", tc.expectedResponse, res)
+		}
+	}
+}
+
+func TestHandler_Put(t *testing.T) {
+	controller := gomock.NewController(t)
+	mockSvc := NewMockService(controller)
+	webhookHandler := New(mockSvc)
+
+	mockContainer, _ := container.NewMockContainer(t)
+	ctx := &gofr.Context{
+		Context:   t.Context(),
+		Request:   nil,
+		Container: mockContainer,
+	}
+
+	updated := &models.Webhook{ID: 4, URL: "https://example.com/new", Events: []string{"task.deleted"}}
+
+	testcases := []struct {
+		name             string
+		requestID        string
+		requestBody      string
+		mockExpect       func()
+		expectedResponse any
+		expectedError    error
+	}{
+		{
+			"success",
+			"4",
+			`{"url": "https://example.com/new", "events": ["task.deleted"]}`,
+			func() {
+				mockSvc.EXPECT().Update(ctx, &models.Webhook{ID: 4, URL: "https://example.com/new", Events: []string{"task.deleted"}}).
+					Return(updated, nil)
+			},
+			updated,
+			nil,
+		},
+		{
+			"invalid id",
+			"abc",
+			`{}`,
+			func() {},
+			nil,
+			errInvalidID,
+		},
+		{
+			"bind error",
+			"4",
+			`{"url":`,
+			func() {},
+			nil,
+			errInvalidBody,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.mockExpect()
+
+			req := httptest.NewRequest(http.MethodPut, "/webhook/{id}", bytes.NewReader([]byte(tc.requestBody)))
+			req.Header.Set("Content-Type", "application/json")
+			req = mux.SetURLVars(req, map[string]string{"id": tc.requestID})
+			ctx.Request = gofrhttp.NewRequest(req)
+
+			res, err := webhookHandler.Put(ctx)
+			if !errors.Is(err, tc.expectedError) {
+				t.Errorf("error, expected %v, got %v", tc.expectedError, err)
+			}
+
+			if !reflect.DeepEqual(res, tc.expectedResponse) {
+				t.Errorf("expected: %v, got: %v", tc.expectedResponse, res)
+			}
+		})
+	}
+}
+
+func TestHandler_Delete(t *testing.T) {
+	controller := gomock.NewController(t)
+	mockSvc := NewMockService(controller)
+	webhookHandler := New(mockSvc)
+
+	mockContainer, _ := container.NewMockContainer(t)
+	ctx := &gofr.Context{
+		Context:   t.Context(),
+		Request:   nil,
+		Container: mockContainer,
+	}
+
+	mockSvc.EXPECT().Delete(ctx, int64(4)).Return(nil)
+
+	req := mux.SetURLVars(httptest.NewRequest(http.MethodDelete, "/webhook/{id}", http.NoBody), map[string]string{"id": "4"})
+	ctx.Request = gofrhttp.NewRequest(req)
+
+	_, err := webhookHandler.Delete(ctx)
+	if err != nil {
+		t.Error(err)
+	}
+}
+
+func TestHandler_GetDeliveries(t *testing.T) {
+	controller := gomock.NewController(t)
+	mockSvc := NewMockService(controller)
+	webhookHandler := New(mockSvc)
+
+	mockContainer, _ := container.NewMockContainer(t)
+	ctx := &gofr.Context{
+		Context:   t.Context(),
+		Request:   nil,
+		Container: mockContainer,
+	}
+
+	deliveries := []models.WebhookDelivery{{ID: 7, WebhookID: 4, Status: models.DeliveryDead}}
+
+	testcases := []struct {
+		name             string
+		requestID        string
+		query            string
+		mockExpect       func()
+		expectedResponse any
+		expectedError    error
+	}{
+		{
+			"success",
+			"4",
+			"?status=dead&limit=10",
+			func() {
+				mockSvc.EXPECT().GetDeliveries(ctx, &models.DeliveryFilter{WebhookID: 4, Status: "dead", Limit: 10}).Return(deliveries, nil)
+			},
+			deliveries,
+			nil,
+		},
+		{
+			"invalid id",
+			"abc",
+			"",
+			func() {},
+			nil,
+			errInvalidID,
+		},
+		{
+			"invalid limit",
+			"4",
+			"?limit=ten",
+			func() {},
+			nil,
+			errInvalidLimit,
+		},
+		{
+			"service error",
+			"4",
+			"",
+			func() {
+				mockSvc.EXPECT().GetDeliveries(ctx, &models.DeliveryFilter{WebhookID: 4}).Return(nil, utils.ErrTest)
+			},
+			nil,
+			utils.ErrTest,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.mockExpect()
+
+			req := httptest.NewRequest(http.MethodGet, "/webhook/{id}/deliveries"+tc.query, http.NoBody)
+			req = mux.SetURLVars(req, map[string]string{"id": tc.requestID})
+			ctx.Request = gofrhttp.NewRequest(req)
+
+			res, err := webhookHandler.GetDeliveries(ctx)
+			if !errors.Is(err, tc.expectedError) {
+				t.Errorf("error, expected %v, got %v", tc.expectedError, err)
+			}
+
+			if !reflect.DeepEqual(res, tc.expectedResponse) {
+				t.Errorf("expected: %v, got: %v", tc.expectedResponse, res)
+			}
+		})
+	}
+}
+
+func TestHandler_Replay(t *testing.T) {
+	controller := gomock.NewController(t)
+	mockSvc := NewMockService(controller)
+	webhookHandler := New(mockSvc)
+
+	mockContainer, _ := container.NewMockContainer(t)
+	ctx := &gofr.Context{
+		Context:   t.Context(),
+		Request:   nil,
+		Container: mockContainer,
+	}
+
+	replayed := &models.WebhookDelivery{ID: 8, WebhookID: 4, Status: models.DeliveryPending}
+
+	testcases := []struct {
+		name             string
+		requestID        string
+		deliveryID       string
+		mockExpect       func()
+		expectedResponse any
+		expectedError    error
+	}{
+		{
+			"success",
+			"4",
+			"7",
+			func() {
+				mockSvc.EXPECT().Replay(ctx, int64(4), int64(7)).Return(replayed, nil)
+			},
+			replayed,
+			nil,
+		},
+		{
+			"invalid id",
+			"abc",
+			"7",
+			func() {},
+			nil,
+			errInvalidID,
+		},
+		{
+			"invalid delivery id",
+			"4",
+			"abc",
+			func() {},
+			nil,
+			errInvalidDeliveryID,
+		},
+		{
+			"service error",
+			"4",
+			"7",
+			func() {
+				mockSvc.EXPECT().Replay(ctx, int64(4), int64(7)).Return(nil, utils.ErrTest)
+			},
+			nil,
+			utils.ErrTest,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.mockExpect()
+
+			req := httptest.NewRequest(http.MethodPost, "/webhook/{id}/deliveries/{delivery_id}/replay", http.NoBody)
+			req = mux.SetURLVars(req, map[string]string{"id": tc.requestID, "delivery_id": tc.deliveryID})
+			ctx.Request = gofrhttp.NewRequest(req)
+
+			res, err := webhookHandler.Replay(ctx)
+			if !errors.Is(err, tc.expectedError) {
+				t.Errorf("error, expected %v, got %v", tc.expectedError, err)
+			}
+
+			if !reflect.DeepEqual(res, tc.expectedResponse) {
+				t.Errorf("expected: %v, got: %v", tc.expectedResponse, res)
+			}
+		})
+	}
+}
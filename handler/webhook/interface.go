@@ -0,0 +1,17 @@
+package webhook
+
+import (
+	"gofr.dev/pkg/gofr"
+
+	"TaskManager2/models"
+)
+
+type Service interface {
+	Create(*gofr.Context, *models.Webhook) (*models.Webhook, error)
+	GetAll(*gofr.Context) ([]models.Webhook, error)
+	GetByID(*gofr.Context, int64) (*models.Webhook, error)
+	Update(*gofr.Context, *models.Webhook) (*models.Webhook, error)
+	Delete(*gofr.Context, int64) error
+	GetDeliveries(*gofr.Context, *models.DeliveryFilter) ([]models.WebhookDelivery, error)
+	Replay(*gofr.Context, int64, int64) (*models.WebhookDelivery, error)
+}
@@ -0,0 +1,331 @@
+// Package ical writes and reads the to-dos and events of iCalendar (RFC 5545)
+// files, reduced to the fields a task has: a summary, a description, a due
+// time and whether it is done.
+package ical
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+)
+
+const (
+	// Todo and Event are the components an entry is written as.
+	Todo  = "VTODO"
+	Event = "VEVENT"
+
+	dateTimeFormat = "20060102T150405Z"
+	localFormat    = "20060102T150405"
+	dateFormat     = "20060102"
+
+	// maxLineOctets is the length content lines are folded at.
+	maxLineOctets = 75
+	// maxLineLength bounds an unfolded line when reading.
+	maxLineLength = 1 << 20
+)
+
+var errUnterminated = errors.New("calendar ends inside a component")
+
+// Entry is a to-do or event. Due is the due time of a to-do and the start of
+// an event. Done is set for completed to-dos, and Cancelled is only read, for
+// cancelled to-dos and events. Recurrence rules are not read, so a recurring
+// entry is its first occurrence.
+type Entry struct {
+	UID         string
+	Summary     string
+	Description string
+	Due         *time.Time
+	Done        bool
+	Cancelled   bool
+	Sequence    int64
+}
+
+// Write writes a calendar named name with the entries as components of the
+// given kind, stamped with now.
+func Write(w io.Writer, name, kind string, entries []Entry, now time.Time) error {
+	b := bufio.NewWriter(w)
+	l := lineWriter{w: b}
+
+	l.line("BEGIN:VCALENDAR")
+	l.line("VERSION:2.0")
+	l.line("PRODID:-//TaskManager//Tasks//EN")
+	l.line("CALSCALE:GREGORIAN")
+	l.line("X-WR-CALNAME:" + escape(name))
+
+	stamp := now.UTC().Format(dateTimeFormat)
+
+	for _, e := range entries {
+		l.line("BEGIN:" + kind)
+		l.line("UID:" + escape(e.UID))
+		l.line("DTSTAMP:" + stamp)
+		l.line(fmt.Sprintf("SEQUENCE:%d", e.Sequence))
+		l.line("SUMMARY:" + escape(e.Summary))
+
+		if e.Description != "" {
+			l.line("DESCRIPTION:" + escape(e.Description))
+		}
+
+		if kind == Todo {
+			writeTodo(&l, &e)
+		} else {
+			writeEvent(&l, &e)
+		}
+
+		l.line("END:" + kind)
+	}
+
+	l.line("END:VCALENDAR")
+
+	if l.err != nil {
+		return l.err
+	}
+
+	return b.Flush()
+}
+
+// writeTodo writes the due time and status of a to-do.
+func writeTodo(l *lineWriter, e *Entry) {
+	if e.Due != nil {
+		l.line("DUE:" + e.Due.UTC().Format(dateTimeFormat))
+	}
+
+	if e.Done {
+		l.line("STATUS:COMPLETED")
+		l.line("PERCENT-COMPLETE:100")
+	} else {
+		l.line("STATUS:NEEDS-ACTION")
+	}
+}
+
+// writeEvent writes an event at the due time. Events have no completed
+// status, so a done task is confirmed but no longer blocks the time.
+func writeEvent(l *lineWriter, e *Entry) {
+	if e.Due != nil {
+		l.line("DTSTART:" + e.Due.UTC().Format(dateTimeFormat))
+	}
+
+	l.line("STATUS:CONFIRMED")
+
+	if e.Done {
+		l.line("TRANSP:TRANSPARENT")
+	}
+}
+
+// lineWriter writes content lines ended by CRLF and folded at 75 octets,
+// without splitting UTF-8 sequences. It keeps the first error.
+type lineWriter struct {
+	w   *bufio.Writer
+	err error
+}
+
+func (l *lineWriter) line(s string) {
+	if l.err != nil {
+		return
+	}
+
+	// The space starting a continuation line counts towards its length.
+	for limit := maxLineOctets; len(s) > limit; limit = maxLineOctets - 1 {
+		n := limit
+		for s[n]&0xC0 == 0x80 {
+			n--
+		}
+
+		_, _ = l.w.WriteString(s[:n] + "\r\n ")
+		s = s[n:]
+	}
+
+	_, l.err = l.w.WriteString(s + "\r\n")
+}
+
+func escape(s string) string {
+	return strings.NewReplacer(`\`, `\\`, ";", `\;`, ",", `\,`, "\r\n", `\n`, "\n", `\n`, "\r", `\n`).Replace(s)
+}
+
+func unescape(s string) string {
+	var b strings.Builder
+
+	for i := 0; i < len(s); i++ {
+		if s[i] != '\\' || i+1 == len(s) {
+			b.WriteByte(s[i])
+
+			continue
+		}
+
+		i++
+
+		if s[i] == 'n' || s[i] == 'N' {
+			b.WriteByte('\n')
+		} else {
+			b.WriteByte(s[i])
+		}
+	}
+
+	return b.String()
+}
+
+// Parse reads the to-dos and events of a calendar, in order. Other components,
+// such as time zone definitions and alarms, are skipped. Times in a time zone
+// that is not an IANA name, and floating times, are read as UTC.
+func Parse(r io.Reader) ([]Entry, error) {
+	lines, err := unfold(r)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		entries []Entry
+		current *Entry
+		kind    string
+		nested  int
+	)
+
+	for _, line := range lines {
+		name, params, value := split(line)
+		if name == "BEGIN" || name == "END" {
+			value = strings.ToUpper(value)
+		}
+
+		switch {
+		case name == "BEGIN" && current == nil && (value == Todo || value == Event):
+			current, kind = &Entry{}, value
+		case name == "BEGIN" && current != nil:
+			nested++
+		case name == "END" && current != nil && nested > 0:
+			nested--
+		case name == "END" && current != nil && value == kind:
+			entries = append(entries, *current)
+			current = nil
+		case current != nil && nested == 0:
+			err = current.set(kind, name, params, value)
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	if current != nil {
+		return nil, errUnterminated
+	}
+
+	return entries, nil
+}
+
+func (e *Entry) set(kind, name string, params map[string]string, value string) error {
+	switch name {
+	case "UID":
+		e.UID = unescape(value)
+	case "SUMMARY":
+		e.Summary = unescape(value)
+	case "DESCRIPTION":
+		e.Description = unescape(value)
+	case "SEQUENCE":
+		_, _ = fmt.Sscan(value, &e.Sequence)
+	case "STATUS":
+		e.Done = kind == Todo && strings.EqualFold(value, "COMPLETED")
+		e.Cancelled = strings.EqualFold(value, "CANCELLED")
+	case "COMPLETED":
+		e.Done = kind == Todo
+	case "DUE", "DTSTART":
+		// A to-do is due at DUE, and at its start only when it has no DUE.
+		if kind == Todo && name == "DTSTART" && e.Due != nil || kind == Event && name == "DUE" {
+			return nil
+		}
+
+		t, err := parseTime(params, value)
+		if err != nil {
+			return fmt.Errorf("%s of %q: %w", name, e.UID, err)
+		}
+
+		e.Due = &t
+	}
+
+	return nil
+}
+
+func parseTime(params map[string]string, value string) (time.Time, error) {
+	if params["VALUE"] == "DATE" || len(value) == len(dateFormat) {
+		return time.Parse(dateFormat, value)
+	}
+
+	if strings.HasSuffix(value, "Z") {
+		return time.Parse(dateTimeFormat, value)
+	}
+
+	loc := time.UTC
+
+	if tzid := strings.Trim(params["TZID"], `"`); tzid != "" {
+		if l, err := time.LoadLocation(tzid); err == nil {
+			loc = l
+		}
+	}
+
+	t, err := time.ParseInLocation(localFormat, value, loc)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	return t.UTC(), nil
+}
+
+// unfold joins folded content lines and drops empty ones.
+func unfold(r io.Reader) ([]string, error) {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(nil, maxLineLength)
+
+	var lines []string
+
+	for scanner.Scan() {
+		line := strings.TrimRight(scanner.Text(), "\r")
+
+		switch {
+		case line == "":
+		case (line[0] == ' ' || line[0] == '\t') && len(lines) > 0:
+			lines[len(lines)-1] += line[1:]
+		default:
+			lines = append(lines, line)
+		}
+	}
+
+	return lines, scanner.Err()
+}
+
+// split splits a content line into its upper-cased name, its parameters with
+// upper-cased names, and its value.
+func split(line string) (name string, params map[string]string, value string) {
+	colon := valueStart(line)
+	if colon < 0 {
+		return strings.ToUpper(line), nil, ""
+	}
+
+	parts := strings.Split(line[:colon], ";")
+	params = make(map[string]string, len(parts)-1)
+
+	for _, p := range parts[1:] {
+		k, v, _ := strings.Cut(p, "=")
+		params[strings.ToUpper(k)] = v
+	}
+
+	return strings.ToUpper(parts[0]), params, line[colon+1:]
+}
+
+// valueStart finds the colon that starts the value, skipping colons in quoted
+// parameter values.
+func valueStart(line string) int {
+	quoted := false
+
+	for i := range len(line) {
+		switch line[i] {
+		case '"':
+			quoted = !quoted
+		case ':':
+			if !quoted {
+				return i
+			}
+		}
+	}
+
+	return -1
+}
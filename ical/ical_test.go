@@ -0,0 +1,108 @@
+package ical
+
+import (
+	"bytes"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestWrite(t *testing.T) {
+	due := time.Date(2026, 10, 21, 9, 30, 0, 0, time.UTC)
+	now := time.Date(2026, 10, 20, 12, 0, 0, 0, time.UTC)
+	entries := []Entry{
+		{UID: "task-9@taskmanager", Summary: "Ship it, now; really", Description: "Line one\nLine two", Due: &due, Sequence: 2},
+		{UID: "task-10@taskmanager", Summary: "Done", Due: &due, Done: true, Sequence: 1},
+	}
+
+	var b bytes.Buffer
+
+	err := Write(&b, "Ada's tasks", Todo, entries, now)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := "BEGIN:VCALENDAR\r\nVERSION:2.0\r\nPRODID:-//TaskManager//Tasks//EN\r\nCALSCALE:GREGORIAN\r\n" +
+		"X-WR-CALNAME:Ada's tasks\r\n" +
+		"BEGIN:VTODO\r\nUID:task-9@taskmanager\r\nDTSTAMP:20261020T120000Z\r\nSEQUENCE:2\r\n" +
+		"SUMMARY:Ship it\\, now\\; really\r\nDESCRIPTION:Line one\\nLine two\r\nDUE:20261021T093000Z\r\n" +
+		"STATUS:NEEDS-ACTION\r\nEND:VTODO\r\n" +
+		"BEGIN:VTODO\r\nUID:task-10@taskmanager\r\nDTSTAMP:20261020T120000Z\r\nSEQUENCE:1\r\nSUMMARY:Done\r\n" +
+		"DUE:20261021T093000Z\r\nSTATUS:COMPLETED\r\nPERCENT-COMPLETE:100\r\nEND:VTODO\r\n" +
+		"END:VCALENDAR\r\n"
+	if b.String() != expected {
+		t.Errorf("expected\n%q\ngot\n%q", expected, b.String())
+	}
+
+	b.Reset()
+
+	err = Write(&b, "Tasks", Event, entries[1:], now)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !strings.Contains(b.String(), "BEGIN:VEVENT\r\n") || !strings.Contains(b.String(), "DTSTART:20261021T093000Z\r\nSTATUS:CONFIRMED\r\n"+
+		"TRANSP:TRANSPARENT\r\n") {
+		t.Errorf("expected a transparent event, got %q", b.String())
+	}
+}
+
+func TestWrite_Folds(t *testing.T) {
+	var b bytes.Buffer
+
+	summary := strings.Repeat("é", 100)
+
+	err := Write(&b, "Tasks", Todo, []Entry{{UID: "1", Summary: summary}}, time.Now())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, line := range strings.Split(strings.TrimSuffix(b.String(), "\r\n"), "\r\n") {
+		if len(line) > maxLineOctets {
+			t.Errorf("line of %d octets: %q", len(line), line)
+		}
+	}
+
+	entries, err := Parse(&b)
+	if err != nil || len(entries) != 1 || entries[0].Summary != summary {
+		t.Errorf("expected the summary to survive folding, got %+v, %v", entries, err)
+	}
+}
+
+func TestParse(t *testing.T) {
+	src := "BEGIN:VCALENDAR\r\nBEGIN:VTIMEZONE\r\nTZID:Europe/Berlin\r\nBEGIN:STANDARD\r\nDTSTART:19701025T030000\r\n" +
+		"END:STANDARD\r\nEND:VTIMEZONE\r\n" +
+		"BEGIN:VTODO\r\nUID:a@example.com\r\nSUMMARY:Write\r\n  report\r\nDTSTART:20261019T080000Z\r\n" +
+		"DUE;TZID=Europe/Berlin:20261021T093000\r\nSTATUS:COMPLETED\r\n" +
+		"BEGIN:VALARM\r\nDESCRIPTION:Alarm\r\nEND:VALARM\r\nEND:VTODO\r\n" +
+		"BEGIN:VEVENT\r\nUID:b@example.com\r\nSUMMARY:Standup\\, daily\r\nDTSTART;VALUE=DATE:20261022\r\n" +
+		"RRULE:FREQ=DAILY\r\nEND:VEVENT\r\n" +
+		"BEGIN:VEVENT\r\nUID:c@example.com\r\nSUMMARY:Off\r\nSTATUS:CANCELLED\r\nEND:VEVENT\r\n" +
+		"END:VCALENDAR\r\n"
+
+	berlin := time.Date(2026, 10, 21, 7, 30, 0, 0, time.UTC)
+	day := time.Date(2026, 10, 22, 0, 0, 0, 0, time.UTC)
+	expected := []Entry{
+		{UID: "a@example.com", Summary: "Write report", Due: &berlin, Done: true},
+		{UID: "b@example.com", Summary: "Standup, daily", Due: &day},
+		{UID: "c@example.com", Summary: "Off", Cancelled: true},
+	}
+
+	entries, err := Parse(strings.NewReader(src))
+	if err != nil || !reflect.DeepEqual(entries, expected) {
+		t.Errorf("expected %+v, got %+v, %v", expected, entries, err)
+	}
+}
+
+func TestParse_Invalid(t *testing.T) {
+	for _, src := range []string{
+		"BEGIN:VCALENDAR\r\nBEGIN:VTODO\r\nUID:a\r\n",
+		"BEGIN:VTODO\r\nUID:a\r\nDUE:tomorrow\r\nEND:VTODO\r\n",
+	} {
+		_, err := Parse(strings.NewReader(src))
+		if err == nil {
+			t.Errorf("expected an error for %q", src)
+		}
+	}
+}
@@ -0,0 +1,16 @@
+package jobs
+
+import (
+	"gofr.dev/pkg/gofr"
+)
+
+// BoardHeartbeat returns a cron job that keeps the users connected to the
+// boards of this replica present, and drops those no replica renewed.
+func BoardHeartbeat(svc CollabService) func(*gofr.Context) {
+	return func(ctx *gofr.Context) {
+		_, err := svc.Heartbeat(ctx)
+		if err != nil {
+			ctx.Logger.Errorf("sending board heartbeats: %v", err)
+		}
+	}
+}
@@ -0,0 +1,39 @@
+package jobs
+
+import (
+	"testing"
+
+	"go.uber.org/mock/gomock"
+	"gofr.dev/pkg/gofr"
+	"gofr.dev/pkg/gofr/container"
+
+	"TaskManager2/utils"
+)
+
+func TestBoardHeartbeat(t *testing.T) {
+	controller := gomock.NewController(t)
+	mockSvc := NewMockCollabService(controller)
+
+	mockContainer, _ := container.NewMockContainer(t)
+	ctx := &gofr.Context{
+		Context:   t.Context(),
+		Request:   nil,
+		Container: mockContainer,
+	}
+
+	tests := []struct {
+		description string
+		err         error
+	}{
+		{"success", nil},
+		{"store error", utils.ErrTest},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.description, func(t *testing.T) {
+			mockSvc.EXPECT().Heartbeat(ctx).Return(3, tc.err)
+
+			BoardHeartbeat(mockSvc)(ctx)
+		})
+	}
+}
@@ -0,0 +1,25 @@
+package jobs
+
+import (
+	"time"
+
+	"gofr.dev/pkg/gofr"
+)
+
+// PurgeProcessedCommands returns a cron job that forgets the queue commands
+// processed more than retentionDays ago. Redeliveries are only recognised
+// within that window.
+func PurgeProcessedCommands(svc CommandService, retentionDays int) func(*gofr.Context) {
+	retention := time.Duration(retentionDays) * day
+
+	return func(ctx *gofr.Context) {
+		purged, err := svc.Purge(ctx, retention)
+		if err != nil {
+			ctx.Logger.Errorf("purging processed commands: %v", err)
+
+			return
+		}
+
+		ctx.Logger.Infof("purged %d processed commands", purged)
+	}
+}
@@ -0,0 +1,21 @@
+package jobs
+
+import (
+	"gofr.dev/pkg/gofr"
+)
+
+// SendDigests returns a cron job that emails the digests that are due.
+func SendDigests(svc DigestService) func(*gofr.Context) {
+	return func(ctx *gofr.Context) {
+		sent, failed, err := svc.Send(ctx)
+		if err != nil {
+			ctx.Logger.Errorf("sending digests: %v", err)
+
+			return
+		}
+
+		if sent+failed > 0 {
+			ctx.Logger.Infof("sent %d digests, %d failed", sent, failed)
+		}
+	}
+}
@@ -0,0 +1,21 @@
+package jobs
+
+import (
+	"time"
+
+	"gofr.dev/pkg/gofr"
+)
+
+// PurgeIdempotencyKeys returns a cron job that deletes expired idempotency records.
+func PurgeIdempotencyKeys(store IdempotencyStore) func(*gofr.Context) {
+	return func(ctx *gofr.Context) {
+		purged, err := store.Purge(ctx, time.Now().UTC())
+		if err != nil {
+			ctx.Logger.Errorf("purging idempotency keys: %v", err)
+
+			return
+		}
+
+		ctx.Logger.Infof("purged %d expired idempotency keys", purged)
+	}
+}
@@ -0,0 +1,39 @@
+package jobs
+
+import (
+	"testing"
+
+	"go.uber.org/mock/gomock"
+	"gofr.dev/pkg/gofr"
+	"gofr.dev/pkg/gofr/container"
+
+	"TaskManager2/utils"
+)
+
+func TestPurgeIdempotencyKeys(t *testing.T) {
+	controller := gomock.NewController(t)
+	mockStore := NewMockIdempotencyStore(controller)
+
+	mockContainer, _ := container.NewMockContainer(t)
+	ctx := &gofr.Context{
+		Context:   t.Context(),
+		Request:   nil,
+		Container: mockContainer,
+	}
+
+	tests := []struct {
+		description string
+		err         error
+	}{
+		{"success", nil},
+		{"purge error", utils.ErrTest},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.description, func(t *testing.T) {
+			mockStore.EXPECT().Purge(ctx, gomock.Any()).Return(int64(2), tc.err)
+
+			PurgeIdempotencyKeys(mockStore)(ctx)
+		})
+	}
+}
@@ -0,0 +1,21 @@
+package jobs
+
+import (
+	"gofr.dev/pkg/gofr"
+)
+
+// ProcessImports returns a cron job that runs the next queued import job.
+func ProcessImports(svc ImportService) func(*gofr.Context) {
+	return func(ctx *gofr.Context) {
+		processed, err := svc.Process(ctx)
+		if err != nil {
+			ctx.Logger.Errorf("processing import job: %v", err)
+
+			return
+		}
+
+		if processed {
+			ctx.Logger.Info("processed an import job")
+		}
+	}
+}
@@ -0,0 +1,41 @@
+package jobs
+
+import (
+	"testing"
+
+	"go.uber.org/mock/gomock"
+	"gofr.dev/pkg/gofr"
+	"gofr.dev/pkg/gofr/container"
+
+	"TaskManager2/utils"
+)
+
+func TestProcessImports(t *testing.T) {
+	controller := gomock.NewController(t)
+	mockSvc := NewMockImportService(controller)
+
+	mockContainer, _ := container.NewMockContainer(t)
+	ctx := &gofr.Context{
+		Context:   t.Context(),
+		Request:   nil,
+		Container: mockContainer,
+	}
+
+	tests := []struct {
+		description string
+		processed   bool
+		err         error
+	}{
+		{"success", true, nil},
+		{"nothing queued", false, nil},
+		{"process error", false, utils.ErrTest},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.description, func(t *testing.T) {
+			mockSvc.EXPECT().Process(ctx).Return(tc.processed, tc.err)
+
+			ProcessImports(mockSvc)(ctx)
+		})
+	}
+}
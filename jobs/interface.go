@@ -0,0 +1,50 @@
+package jobs
+
+import (
+	"time"
+
+	"gofr.dev/pkg/gofr"
+	"gofr.dev/pkg/gofr/datasource/pubsub"
+)
+
+type TrashService interface {
+	PurgeTrash(*gofr.Context, time.Duration) (int64, error)
+}
+
+type IdempotencyStore interface {
+	Purge(*gofr.Context, time.Time) (int64, error)
+}
+
+type WebhookService interface {
+	Deliver(*gofr.Context, int) (int, int, error)
+}
+
+type OutboxService interface {
+	Relay(*gofr.Context, pubsub.Publisher, int) (int, int, error)
+	Purge(*gofr.Context, time.Duration) (int64, error)
+}
+
+type CommandService interface {
+	Purge(*gofr.Context, time.Duration) (int64, error)
+}
+
+type StreamService interface {
+	Poll(*gofr.Context) (int, error)
+	Prune(*gofr.Context) (int64, error)
+}
+
+type CollabService interface {
+	Heartbeat(*gofr.Context) (int, error)
+}
+
+type ReminderService interface {
+	Dispatch(*gofr.Context, int) (int, int, error)
+}
+
+type DigestService interface {
+	Send(*gofr.Context) (int, int, error)
+}
+
+type ImportService interface {
+	Process(*gofr.Context) (bool, error)
+}
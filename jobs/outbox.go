@@ -0,0 +1,48 @@
+package jobs
+
+import (
+	"time"
+
+	"gofr.dev/pkg/gofr"
+)
+
+// RelayOutbox returns a cron job that publishes up to batch due outbox
+// messages to the configured pub/sub backend.
+func RelayOutbox(svc OutboxService, batch int) func(*gofr.Context) {
+	return func(ctx *gofr.Context) {
+		publisher := ctx.GetPublisher()
+		if publisher == nil {
+			ctx.Logger.Errorf("relaying outbox: no pub/sub backend configured")
+
+			return
+		}
+
+		published, failed, err := svc.Relay(ctx, publisher, batch)
+		if err != nil {
+			ctx.Logger.Errorf("relaying outbox: %v", err)
+
+			return
+		}
+
+		if published+failed > 0 {
+			ctx.Logger.Infof("published %d outbox messages, %d failed", published, failed)
+		}
+	}
+}
+
+// PurgeOutbox returns a cron job that deletes the outbox messages published
+// more than retentionDays ago.
+func PurgeOutbox(svc OutboxService, retentionDays int) func(*gofr.Context) {
+	retention := time.Duration(retentionDays) * day
+
+	return func(ctx *gofr.Context) {
+		purged, err := svc.Purge(ctx, retention)
+		if err != nil {
+			ctx.Logger.Errorf("purging outbox: %v", err)
+
+			return
+		}
+
+		ctx.Logger.Infof("purged %d published outbox messages", purged)
+	}
+}
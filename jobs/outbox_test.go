@@ -0,0 +1,55 @@
+package jobs
+
+import (
+	"testing"
+	"time"
+
+	"go.uber.org/mock/gomock"
+	"gofr.dev/pkg/gofr"
+	"gofr.dev/pkg/gofr/container"
+
+	"TaskManager2/utils"
+)
+
+func TestRelayOutbox_NoPublisher(t *testing.T) {
+	controller := gomock.NewController(t)
+	mockSvc := NewMockOutboxService(controller)
+
+	mockContainer, _ := container.NewMockContainer(t)
+	ctx := &gofr.Context{
+		Context:   t.Context(),
+		Request:   nil,
+		Container: mockContainer,
+	}
+
+	// Without a pub/sub backend the relay must leave the outbox untouched.
+	RelayOutbox(mockSvc, 50)(ctx)
+}
+
+func TestPurgeOutbox(t *testing.T) {
+	controller := gomock.NewController(t)
+	mockSvc := NewMockOutboxService(controller)
+
+	mockContainer, _ := container.NewMockContainer(t)
+	ctx := &gofr.Context{
+		Context:   t.Context(),
+		Request:   nil,
+		Container: mockContainer,
+	}
+
+	tests := []struct {
+		description string
+		err         error
+	}{
+		{"success", nil},
+		{"purge error", utils.ErrTest},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.description, func(t *testing.T) {
+			mockSvc.EXPECT().Purge(ctx, 7*24*time.Hour).Return(int64(3), tc.err)
+
+			PurgeOutbox(mockSvc, 7)(ctx)
+		})
+	}
+}
@@ -0,0 +1,21 @@
+package jobs
+
+import (
+	"gofr.dev/pkg/gofr"
+)
+
+// DispatchReminders returns a cron job that sends up to batch due reminders.
+func DispatchReminders(svc ReminderService, batch int) func(*gofr.Context) {
+	return func(ctx *gofr.Context) {
+		sent, failed, err := svc.Dispatch(ctx, batch)
+		if err != nil {
+			ctx.Logger.Errorf("dispatching reminders: %v", err)
+
+			return
+		}
+
+		if sent+failed > 0 {
+			ctx.Logger.Infof("sent %d reminders, %d failed", sent, failed)
+		}
+	}
+}
@@ -0,0 +1,41 @@
+package jobs
+
+import (
+	"testing"
+
+	"go.uber.org/mock/gomock"
+	"gofr.dev/pkg/gofr"
+	"gofr.dev/pkg/gofr/container"
+
+	"TaskManager2/utils"
+)
+
+func TestDispatchReminders(t *testing.T) {
+	controller := gomock.NewController(t)
+	mockSvc := NewMockReminderService(controller)
+
+	mockContainer, _ := container.NewMockContainer(t)
+	ctx := &gofr.Context{
+		Context:   t.Context(),
+		Request:   nil,
+		Container: mockContainer,
+	}
+
+	tests := []struct {
+		description string
+		sent        int
+		err         error
+	}{
+		{"success", 2, nil},
+		{"nothing due", 0, nil},
+		{"dispatch error", 0, utils.ErrTest},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.description, func(t *testing.T) {
+			mockSvc.EXPECT().Dispatch(ctx, 50).Return(tc.sent, 0, tc.err)
+
+			DispatchReminders(mockSvc, 50)(ctx)
+		})
+	}
+}
@@ -0,0 +1,33 @@
+package jobs
+
+import (
+	"gofr.dev/pkg/gofr"
+)
+
+// PollEventLog returns a cron job that sends the task events logged by any
+// replica to the event stream subscribers of this one.
+func PollEventLog(svc StreamService) func(*gofr.Context) {
+	return func(ctx *gofr.Context) {
+		_, err := svc.Poll(ctx)
+		if err != nil {
+			ctx.Logger.Errorf("polling event log: %v", err)
+		}
+	}
+}
+
+// PruneEventLog returns a cron job that trims the event log to its replay
+// window.
+func PruneEventLog(svc StreamService) func(*gofr.Context) {
+	return func(ctx *gofr.Context) {
+		pruned, err := svc.Prune(ctx)
+		if err != nil {
+			ctx.Logger.Errorf("pruning event log: %v", err)
+
+			return
+		}
+
+		if pruned > 0 {
+			ctx.Logger.Infof("pruned %d events from the event log", pruned)
+		}
+	}
+}
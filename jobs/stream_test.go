@@ -0,0 +1,41 @@
+package jobs
+
+import (
+	"testing"
+
+	"go.uber.org/mock/gomock"
+	"gofr.dev/pkg/gofr"
+	"gofr.dev/pkg/gofr/container"
+
+	"TaskManager2/utils"
+)
+
+func TestEventLogJobs(t *testing.T) {
+	controller := gomock.NewController(t)
+	mockSvc := NewMockStreamService(controller)
+
+	mockContainer, _ := container.NewMockContainer(t)
+	ctx := &gofr.Context{
+		Context:   t.Context(),
+		Request:   nil,
+		Container: mockContainer,
+	}
+
+	tests := []struct {
+		description string
+		err         error
+	}{
+		{"success", nil},
+		{"store error", utils.ErrTest},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.description, func(t *testing.T) {
+			mockSvc.EXPECT().Poll(ctx).Return(2, tc.err)
+			mockSvc.EXPECT().Prune(ctx).Return(int64(5), tc.err)
+
+			PollEventLog(mockSvc)(ctx)
+			PruneEventLog(mockSvc)(ctx)
+		})
+	}
+}
@@ -0,0 +1,26 @@
+package jobs
+
+import (
+	"time"
+
+	"gofr.dev/pkg/gofr"
+)
+
+const day = 24 * time.Hour
+
+// PurgeTrash returns a cron job that permanently deletes tasks which have been
+// in the trash for longer than retentionDays.
+func PurgeTrash(svc TrashService, retentionDays int) func(*gofr.Context) {
+	retention := time.Duration(retentionDays) * day
+
+	return func(ctx *gofr.Context) {
+		purged, err := svc.PurgeTrash(ctx, retention)
+		if err != nil {
+			ctx.Logger.Errorf("purging trash: %v", err)
+
+			return
+		}
+
+		ctx.Logger.Infof("purged %d tasks from trash", purged)
+	}
+}
@@ -0,0 +1,40 @@
+package jobs
+
+import (
+	"testing"
+	"time"
+
+	"go.uber.org/mock/gomock"
+	"gofr.dev/pkg/gofr"
+	"gofr.dev/pkg/gofr/container"
+
+	"TaskManager2/utils"
+)
+
+func TestPurgeTrash(t *testing.T) {
+	controller := gomock.NewController(t)
+	mockSvc := NewMockTrashService(controller)
+
+	mockContainer, _ := container.NewMockContainer(t)
+	ctx := &gofr.Context{
+		Context:   t.Context(),
+		Request:   nil,
+		Container: mockContainer,
+	}
+
+	tests := []struct {
+		description string
+		err         error
+	}{
+		{"success", nil},
+		{"purge error", utils.ErrTest},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.description, func(t *testing.T) {
+			mockSvc.EXPECT().PurgeTrash(ctx, 30*24*time.Hour).Return(int64(2), tc.err)
+
+			PurgeTrash(mockSvc, 30)(ctx)
+		})
+	}
+}
@@ -0,0 +1,22 @@
+package jobs
+
+import (
+	"gofr.dev/pkg/gofr"
+)
+
+// DeliverWebhooks returns a cron job that sends up to batch due webhook
+// deliveries.
+func DeliverWebhooks(svc WebhookService, batch int) func(*gofr.Context) {
+	return func(ctx *gofr.Context) {
+		delivered, failed, err := svc.Deliver(ctx, batch)
+		if err != nil {
+			ctx.Logger.Errorf("delivering webhooks: %v", err)
+
+			return
+		}
+
+		if delivered+failed > 0 {
+			ctx.Logger.Infof("delivered %d webhooks, %d failed", delivered, failed)
+		}
+	}
+}
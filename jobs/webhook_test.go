@@ -0,0 +1,41 @@
+package jobs
+
+import (
+	"testing"
+
+	"go.uber.org/mock/gomock"
+	"gofr.dev/pkg/gofr"
+	"gofr.dev/pkg/gofr/container"
+
+	"TaskManager2/utils"
+)
+
+func TestDeliverWebhooks(t *testing.T) {
+	controller := gomock.NewController(t)
+	mockSvc := NewMockWebhookService(controller)
+
+	mockContainer, _ := container.NewMockContainer(t)
+	ctx := &gofr.Context{
+		Context:   t.Context(),
+		Request:   nil,
+		Container: mockContainer,
+	}
+
+	tests := []struct {
+		description string
+		delivered   int
+		err         error
+	}{
+		{"success", 2, nil},
+		{"nothing due", 0, nil},
+		{"deliver error", 0, utils.ErrTest},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.description, func(t *testing.T) {
+			mockSvc.EXPECT().Deliver(ctx, 50).Return(tc.delivered, 0, tc.err)
+
+			DeliverWebhooks(mockSvc, 50)(ctx)
+		})
+	}
+}
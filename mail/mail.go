@@ -0,0 +1,190 @@
+// Package mail sends plain-text and HTML email through an SMTP server, and
+// parses the raw emails received from a mail gateway.
+package mail
+
+import (
+	"bytes"
+	"crypto/tls"
+	"fmt"
+	"io"
+	"mime"
+	"mime/multipart"
+	"mime/quotedprintable"
+	"net"
+	"net/smtp"
+	"net/textproto"
+	"time"
+
+	"gofr.dev/pkg/gofr"
+)
+
+const timeout = 30 * time.Second
+
+// Message is an email to a single recipient. It is sent as plain text, or as
+// alternative plain-text and HTML parts when HTML is set.
+type Message struct {
+	To      string
+	Subject string
+	Text    string
+	HTML    string
+}
+
+type Mailer struct {
+	addr     string
+	from     string
+	username string
+	password string
+}
+
+// New returns a Mailer for the SMTP server at addr, as host:port. The
+// connection is upgraded with STARTTLS when the server offers it, and
+// authenticated with PLAIN when a username is given.
+func New(addr, from, username, password string) *Mailer {
+	return &Mailer{addr: addr, from: from, username: username, password: password}
+}
+
+func (m *Mailer) Send(ctx *gofr.Context, msg *Message) error {
+	host, _, err := net.SplitHostPort(m.addr)
+	if err != nil {
+		return err
+	}
+
+	dialer := net.Dialer{Timeout: timeout}
+
+	conn, err := dialer.DialContext(ctx, "tcp", m.addr)
+	if err != nil {
+		return err
+	}
+
+	err = conn.SetDeadline(time.Now().Add(timeout))
+	if err != nil {
+		_ = conn.Close()
+
+		return err
+	}
+
+	c, err := smtp.NewClient(conn, host)
+	if err != nil {
+		_ = conn.Close()
+
+		return err
+	}
+
+	defer c.Close()
+
+	err = m.hello(c, host)
+	if err != nil {
+		return err
+	}
+
+	return m.send(c, msg)
+}
+
+func (m *Mailer) hello(c *smtp.Client, host string) error {
+	if ok, _ := c.Extension("STARTTLS"); ok {
+		err := c.StartTLS(&tls.Config{ServerName: host, MinVersion: tls.VersionTLS12})
+		if err != nil {
+			return err
+		}
+	}
+
+	if m.username == "" {
+		return nil
+	}
+
+	return c.Auth(smtp.PlainAuth("", m.username, m.password, host))
+}
+
+func (m *Mailer) send(c *smtp.Client, msg *Message) error {
+	b, err := m.compose(msg)
+	if err != nil {
+		return err
+	}
+
+	err = c.Mail(m.from)
+	if err != nil {
+		return err
+	}
+
+	err = c.Rcpt(msg.To)
+	if err != nil {
+		return err
+	}
+
+	w, err := c.Data()
+	if err != nil {
+		return err
+	}
+
+	_, err = w.Write(b)
+	if err != nil {
+		return err
+	}
+
+	err = w.Close()
+	if err != nil {
+		return err
+	}
+
+	return c.Quit()
+}
+
+// compose builds the message. The subject is encoded, so it cannot add
+// headers, and the parts are quoted-printable, so long lines are wrapped.
+func (m *Mailer) compose(msg *Message) ([]byte, error) {
+	var b bytes.Buffer
+
+	_, _ = fmt.Fprintf(&b, "From: %s\r\n", m.from)
+	_, _ = fmt.Fprintf(&b, "To: %s\r\n", msg.To)
+	_, _ = fmt.Fprintf(&b, "Subject: %s\r\n", mime.QEncoding.Encode("utf-8", msg.Subject))
+	_, _ = fmt.Fprintf(&b, "Date: %s\r\n", time.Now().UTC().Format(time.RFC1123Z))
+	_, _ = fmt.Fprintf(&b, "MIME-Version: 1.0\r\n")
+
+	if msg.HTML == "" {
+		_, _ = fmt.Fprintf(&b, "Content-Type: text/plain; charset=utf-8\r\nContent-Transfer-Encoding: quoted-printable\r\n\r\n")
+
+		err := encode(&b, msg.Text)
+		if err != nil {
+			return nil, err
+		}
+
+		return b.Bytes(), nil
+	}
+
+	w := multipart.NewWriter(&b)
+
+	_, _ = fmt.Fprintf(&b, "Content-Type: multipart/alternative; boundary=%s\r\n\r\n", w.Boundary())
+
+	for _, part := range []struct{ contentType, body string }{{"text/plain", msg.Text}, {"text/html", msg.HTML}} {
+		pw, err := w.CreatePart(textproto.MIMEHeader{
+			"Content-Type":              {part.contentType + "; charset=utf-8"},
+			"Content-Transfer-Encoding": {"quoted-printable"},
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		err = encode(pw, part.body)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	err := w.Close()
+	if err != nil {
+		return nil, err
+	}
+
+	return b.Bytes(), nil
+}
+
+func encode(w io.Writer, body string) error {
+	qp := quotedprintable.NewWriter(w)
+
+	_, err := qp.Write([]byte(body))
+	if err != nil {
+		return err
+	}
+
+	return qp.Close()
+}
@@ -0,0 +1,171 @@
+package mail
+
+import (
+	"io"
+	"mime"
+	"mime/multipart"
+	"net"
+	"net/textproto"
+	"reflect"
+	"strings"
+	"testing"
+
+	"gofr.dev/pkg/gofr"
+	"gofr.dev/pkg/gofr/container"
+)
+
+// fakeSMTP serves a single SMTP session on a local port, answering RCPT with
+// rcptReply, and returns its address and a channel that receives the message
+// data once the session ends.
+func fakeSMTP(t *testing.T, rcptReply string) (string, <-chan string) {
+	t.Helper()
+
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Cleanup(func() { _ = l.Close() })
+
+	received := make(chan string, 1)
+
+	go func() {
+		defer close(received)
+
+		conn, err := l.Accept()
+		if err != nil {
+			return
+		}
+
+		defer conn.Close()
+
+		tp := textproto.NewConn(conn)
+		_ = tp.PrintfLine("220 localhost ESMTP")
+
+		var data string
+
+		for {
+			line, err := tp.ReadLine()
+			if err != nil {
+				return
+			}
+
+			switch verb := strings.ToUpper(strings.Fields(line + " ")[0]); verb {
+			case "EHLO", "HELO", "MAIL":
+				_ = tp.PrintfLine("250 OK")
+			case "RCPT":
+				_ = tp.PrintfLine("%s", rcptReply)
+			case "DATA":
+				_ = tp.PrintfLine("354 Go ahead")
+
+				b, _ := io.ReadAll(tp.DotReader())
+				data = string(b)
+
+				_ = tp.PrintfLine("250 Queued")
+			case "QUIT":
+				_ = tp.PrintfLine("221 Bye")
+				received <- data
+
+				return
+			default:
+				_ = tp.PrintfLine("502 Unknown command")
+			}
+		}
+	}()
+
+	return l.Addr().String(), received
+}
+
+func TestMailer_Send(t *testing.T) {
+	mockContainer, _ := container.NewMockContainer(t)
+	ctx := &gofr.Context{
+		Context:   t.Context(),
+		Request:   nil,
+		Container: mockContainer,
+	}
+
+	msg := &Message{To: "ada@example.com", Subject: "Reminder: Ship it\r\nBcc: everyone@example.com", Text: "Hi Ada,\n"}
+
+	addr, received := fakeSMTP(t, "250 OK")
+
+	err := New(addr, "tasks@example.com", "", "").Send(ctx, msg)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	data := <-received
+	for _, want := range []string{"From: tasks@example.com\n", "To: ada@example.com\n", "Subject: =?utf-8?q?Reminder:_Ship_it", "Hi Ada,"} {
+		if !strings.Contains(data, want) {
+			t.Errorf("expected the message to contain %q, got %q", want, data)
+		}
+	}
+
+	header, _, _ := strings.Cut(data, "\n\n")
+	if strings.Contains(header, "\nBcc:") {
+		t.Errorf("expected the subject not to add headers, got %q", data)
+	}
+
+	addr, _ = fakeSMTP(t, "550 No such user")
+
+	err = New(addr, "tasks@example.com", "", "").Send(ctx, msg)
+	if err == nil || !strings.Contains(err.Error(), "No such user") {
+		t.Errorf("expected the rejection, got %v", err)
+	}
+}
+
+func TestMailer_SendHTML(t *testing.T) {
+	mockContainer, _ := container.NewMockContainer(t)
+	ctx := &gofr.Context{
+		Context:   t.Context(),
+		Request:   nil,
+		Container: mockContainer,
+	}
+
+	addr, received := fakeSMTP(t, "250 OK")
+
+	err := New(addr, "tasks@example.com", "", "").Send(ctx, &Message{
+		To: "ada@example.com", Subject: "Digest", Text: "Due today: 1", HTML: "<p>Due today: <b>1</b></p>",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	data := <-received
+
+	header, body, _ := strings.Cut(data, "\n\n")
+
+	_, params, err := mime.ParseMediaType(contentType(header))
+	if err != nil {
+		t.Fatalf("expected a multipart message, got %q", data)
+	}
+
+	r := multipart.NewReader(strings.NewReader(body), params["boundary"])
+
+	var parts []string
+
+	for {
+		p, err := r.NextPart()
+		if err != nil {
+			break
+		}
+
+		b, _ := io.ReadAll(p)
+		parts = append(parts, p.Header.Get("Content-Type")+": "+string(b))
+	}
+
+	expected := []string{"text/plain; charset=utf-8: Due today: 1", "text/html; charset=utf-8: <p>Due today: <b>1</b></p>"}
+	if !reflect.DeepEqual(parts, expected) {
+		t.Errorf("expected parts %q, got %q", expected, parts)
+	}
+}
+
+// contentType returns the Content-Type header of a message header.
+func contentType(header string) string {
+	for line := range strings.SplitSeq(header, "\n") {
+		if v, ok := strings.CutPrefix(line, "Content-Type: "); ok {
+			return v
+		}
+	}
+
+	return ""
+}
@@ -0,0 +1,264 @@
+package mail
+
+import (
+	"bytes"
+	"encoding/base64"
+	"errors"
+	"html"
+	"io"
+	"mime"
+	"mime/multipart"
+	"mime/quotedprintable"
+	netmail "net/mail"
+	"strings"
+)
+
+// maxParts bounds the MIME parts read from a message, nested ones included.
+const maxParts = 100
+
+var (
+	errNoSender     = errors.New("message has no sender")
+	errTooManyParts = errors.New("message has too many parts")
+)
+
+// Received is an email parsed by Parse. Text is the plain-text body, or the
+// text of the HTML body when the message has no plain-text one.
+type Received struct {
+	MessageID   string
+	References  []string
+	From        string
+	Subject     string
+	Text        string
+	Attachments []Attachment
+}
+
+// Attachment is a file attached to an email, or an inline part that is
+// neither the text nor the HTML body.
+type Attachment struct {
+	Filename    string
+	ContentType string
+	Content     []byte
+}
+
+// Parse reads a raw RFC 822 message. References lists the In-Reply-To
+// message IDs followed by those in References, without angle brackets.
+func Parse(raw []byte) (*Received, error) {
+	msg, err := netmail.ReadMessage(bytes.NewReader(raw))
+	if err != nil {
+		return nil, err
+	}
+
+	from, err := msg.Header.AddressList("From")
+	if err != nil {
+		return nil, err
+	}
+
+	if len(from) == 0 {
+		return nil, errNoSender
+	}
+
+	var dec mime.WordDecoder
+
+	subject, err := dec.DecodeHeader(msg.Header.Get("Subject"))
+	if err != nil {
+		subject = msg.Header.Get("Subject")
+	}
+
+	r := &Received{
+		MessageID:  strings.Trim(strings.TrimSpace(msg.Header.Get("Message-Id")), "<>"),
+		References: messageIDs(msg.Header.Get("In-Reply-To") + " " + msg.Header.Get("References")),
+		From:       from[0].Address,
+		Subject:    strings.TrimSpace(subject),
+	}
+
+	var b body
+
+	err = b.read(msg.Header.Get("Content-Type"), msg.Header.Get("Content-Transfer-Encoding"), "", msg.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	r.Text = b.text
+	if r.Text == "" {
+		r.Text = htmlText(b.html)
+	}
+
+	r.Text = strings.TrimSpace(strings.ReplaceAll(r.Text, "\r\n", "\n"))
+	r.Attachments = b.attachments
+
+	return r, nil
+}
+
+// body collects the parts of a message: the first plain-text and HTML bodies,
+// and everything else as attachments.
+type body struct {
+	text        string
+	html        string
+	attachments []Attachment
+	parts       int
+}
+
+func (b *body) read(contentType, encoding, disposition string, r io.Reader) error {
+	b.parts++
+	if b.parts > maxParts {
+		return errTooManyParts
+	}
+
+	mediaType, params, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		mediaType, params = "text/plain", nil
+	}
+
+	if strings.HasPrefix(mediaType, "multipart/") {
+		return b.multipart(r, params["boundary"])
+	}
+
+	content, err := io.ReadAll(decode(r, encoding))
+	if err != nil {
+		return err
+	}
+
+	kind, dispParams, _ := mime.ParseMediaType(disposition)
+	filename := dispParams["filename"]
+
+	if filename == "" {
+		filename = params["name"]
+	}
+
+	switch {
+	case kind != "attachment" && filename == "" && mediaType == "text/plain" && b.text == "":
+		b.text = string(content)
+	case kind != "attachment" && filename == "" && mediaType == "text/html" && b.html == "":
+		b.html = string(content)
+	default:
+		b.attachments = append(b.attachments, Attachment{Filename: filename, ContentType: mediaType, Content: content})
+	}
+
+	return nil
+}
+
+func (b *body) multipart(r io.Reader, boundary string) error {
+	mr := multipart.NewReader(r, boundary)
+
+	for {
+		part, err := mr.NextRawPart()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+
+		if err != nil {
+			return err
+		}
+
+		err = b.read(part.Header.Get("Content-Type"), part.Header.Get("Content-Transfer-Encoding"),
+			part.Header.Get("Content-Disposition"), part)
+		if err != nil {
+			return err
+		}
+	}
+}
+
+func decode(r io.Reader, encoding string) io.Reader {
+	switch strings.ToLower(strings.TrimSpace(encoding)) {
+	case "base64":
+		return base64.NewDecoder(base64.StdEncoding, &spaceless{r: r})
+	case "quoted-printable":
+		return quotedprintable.NewReader(r)
+	default:
+		return r
+	}
+}
+
+// spaceless drops the line breaks and other white space of base64 bodies,
+// which the base64 decoder only skips when they are \r or \n.
+type spaceless struct {
+	r io.Reader
+}
+
+func (s *spaceless) Read(p []byte) (int, error) {
+	n, err := s.r.Read(p)
+
+	out := p[:0]
+
+	for _, c := range p[:n] {
+		if c != ' ' && c != '\t' && c != '\r' && c != '\n' {
+			out = append(out, c)
+		}
+	}
+
+	return len(out), err
+}
+
+// messageIDs lists the message IDs in a header such as References.
+func messageIDs(header string) []string {
+	var ids []string
+
+	for {
+		start := strings.IndexByte(header, '<')
+		if start < 0 {
+			return ids
+		}
+
+		end := strings.IndexByte(header[start:], '>')
+		if end < 0 {
+			return ids
+		}
+
+		if id := strings.TrimSpace(header[start+1 : start+end]); id != "" {
+			ids = append(ids, id)
+		}
+
+		header = header[start+end+1:]
+	}
+}
+
+// htmlText returns the text of an HTML body: tags are dropped, block ends
+// become line breaks, and the contents of script and style elements are
+// skipped.
+func htmlText(s string) string {
+	var b strings.Builder
+
+	for s != "" {
+		start := strings.IndexByte(s, '<')
+		if start < 0 {
+			b.WriteString(html.UnescapeString(s))
+
+			break
+		}
+
+		b.WriteString(html.UnescapeString(s[:start]))
+
+		end := strings.IndexByte(s[start:], '>')
+		if end < 0 {
+			break
+		}
+
+		tag := strings.ToLower(strings.TrimRight(strings.Fields(s[start+1:start+end] + " /")[0], "/"))
+		s = s[start+end+1:]
+
+		switch tag {
+		case "script", "style":
+			i := strings.Index(strings.ToLower(s), "</"+tag)
+			if i < 0 {
+				s = ""
+			} else {
+				s = s[i:]
+			}
+		case "br", "/p", "/div", "/li", "/tr", "/h1", "/h2", "/h3", "/h4", "/h5", "/h6":
+			b.WriteByte('\n')
+		}
+	}
+
+	var lines []string
+
+	for _, line := range strings.Split(b.String(), "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" && (len(lines) == 0 || lines[len(lines)-1] == "") {
+			continue
+		}
+
+		lines = append(lines, line)
+	}
+
+	return strings.Join(lines, "\n")
+}
@@ -0,0 +1,67 @@
+package mail
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		description string
+		raw         string
+		expected    *Received
+	}{
+		{
+			"plain text",
+			"From: Ada Lovelace <ada@example.com>\r\nTo: tasks@example.com\r\nSubject: =?utf-8?q?Ship_it_=E2=9C=93?=\r\n" +
+				"Message-ID: <one@example.com>\r\n\r\nPlease ship it.\r\n",
+			&Received{MessageID: "one@example.com", From: "ada@example.com", Subject: "Ship it ✓", Text: "Please ship it."},
+		},
+		{
+			"reply with HTML and an attachment",
+			"From: ada@example.com\r\nSubject: Re: Ship it\r\nMessage-ID: <two@example.com>\r\n" +
+				"In-Reply-To: <one@example.com>\r\nReferences: <zero@example.com> <one@example.com>\r\n" +
+				"Content-Type: multipart/mixed; boundary=outer\r\n\r\n" +
+				"--outer\r\nContent-Type: multipart/alternative; boundary=inner\r\n\r\n" +
+				"--inner\r\nContent-Type: text/html; charset=utf-8\r\nContent-Transfer-Encoding: quoted-printable\r\n\r\n" +
+				"<p>Done &amp; dusted</p><style>p {}</style><p>Thanks=\r\n!</p>\r\n" +
+				"--inner--\r\n" +
+				"--outer\r\nContent-Type: text/plain; name=notes.txt\r\nContent-Disposition: attachment; filename=notes.txt\r\n" +
+				"Content-Transfer-Encoding: base64\r\n\r\naGVs\r\nbG8=\r\n" +
+				"--outer--\r\n",
+			&Received{
+				MessageID: "two@example.com", References: []string{"one@example.com", "zero@example.com", "one@example.com"},
+				From: "ada@example.com", Subject: "Re: Ship it", Text: "Done & dusted\nThanks!",
+				Attachments: []Attachment{{Filename: "notes.txt", ContentType: "text/plain", Content: []byte("hello")}},
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.description, func(t *testing.T) {
+			got, err := Parse([]byte(tc.raw))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if !reflect.DeepEqual(got, tc.expected) {
+				t.Errorf("expected %+v, got %+v", tc.expected, got)
+			}
+		})
+	}
+}
+
+func TestParse_Invalid(t *testing.T) {
+	for _, raw := range []string{
+		"not a message",
+		"To: tasks@example.com\r\n\r\nNo sender.\r\n",
+		"From: ada@example.com\r\nContent-Type: multipart/mixed; boundary=b\r\n\r\n" +
+			strings.Repeat("--b\r\nContent-Type: text/plain\r\n\r\nx\r\n", maxParts) + "--b--\r\n",
+	} {
+		_, err := Parse([]byte(raw))
+		if err == nil {
+			t.Errorf("expected an error for %.40q", raw)
+		}
+	}
+}
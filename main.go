@@ -1,39 +1,299 @@
 package main
 
 import (
+	"net"
+	"strconv"
+	"time"
+	_ "time/tzdata" // digest time zones on images without tzdata
+
 	"gofr.dev/pkg/gofr"
 
+	"TaskManager2/events"
+	attachmentHandler "TaskManager2/handler/attachment"
+	calendarHandler "TaskManager2/handler/calendar"
+	collabHandler "TaskManager2/handler/collab"
+	commandHandler "TaskManager2/handler/command"
+	commentHandler "TaskManager2/handler/comment"
+	digestHandler "TaskManager2/handler/digest"
+	"TaskManager2/handler/httperr"
+	inboundHandler "TaskManager2/handler/inbound"
+	notificationHandler "TaskManager2/handler/notification"
+	reminderHandler "TaskManager2/handler/reminder"
+	searchHandler "TaskManager2/handler/search"
+	streamHandler "TaskManager2/handler/stream"
 	taskHandler "TaskManager2/handler/task"
+	templateHandler "TaskManager2/handler/template"
+	transferHandler "TaskManager2/handler/transfer"
 	userHandler "TaskManager2/handler/user"
+	viewHandler "TaskManager2/handler/view"
+	webhookHandler "TaskManager2/handler/webhook"
+	"TaskManager2/jobs"
+	"TaskManager2/mail"
+	"TaskManager2/middleware"
 	"TaskManager2/migrations"
+	"TaskManager2/models"
+	"TaskManager2/notify"
+	attachmentService "TaskManager2/service/attachment"
+	calendarService "TaskManager2/service/calendar"
+	collabService "TaskManager2/service/collab"
+	commandService "TaskManager2/service/command"
+	commentService "TaskManager2/service/comment"
+	digestService "TaskManager2/service/digest"
+	inboundService "TaskManager2/service/inbound"
+	notificationService "TaskManager2/service/notification"
+	outboxService "TaskManager2/service/outbox"
+	reminderService "TaskManager2/service/reminder"
+	searchService "TaskManager2/service/search"
+	streamService "TaskManager2/service/stream"
 	taskService "TaskManager2/service/task"
+	templateService "TaskManager2/service/template"
+	transferService "TaskManager2/service/transfer"
 	userService "TaskManager2/service/user"
+	viewService "TaskManager2/service/view"
+	webhookService "TaskManager2/service/webhook"
+	attachmentStore "TaskManager2/store/attachment"
+	auditStore "TaskManager2/store/audit"
+	calendarStore "TaskManager2/store/calendar"
+	commandStore "TaskManager2/store/command"
+	commentStore "TaskManager2/store/comment"
+	digestStore "TaskManager2/store/digest"
+	eventLogStore "TaskManager2/store/eventlog"
+	idempotencyStore "TaskManager2/store/idempotency"
+	importJobStore "TaskManager2/store/importjob"
+	inboundStore "TaskManager2/store/inbound"
+	notificationStore "TaskManager2/store/notification"
+	outboxStore "TaskManager2/store/outbox"
+	reminderStore "TaskManager2/store/reminder"
+	searchStore "TaskManager2/store/search"
 	taskStore "TaskManager2/store/task"
+	templateStore "TaskManager2/store/template"
 	userStore "TaskManager2/store/user"
+	viewStore "TaskManager2/store/view"
+	webhookStore "TaskManager2/store/webhook"
+)
+
+const (
+	webhookTimeout = 10 * time.Second
+	webhookBatch   = 50
+	outboxBatch    = 100
+	reminderBatch  = 100
+
+	// maxInboundEmailSize fits the attachments of an email in a MEDIUMBLOB.
+	maxInboundEmailSize = 10 << 20
+	maxCalendarSize     = 2 << 20
+	// maxIdempotentBodySize fits the largest import, with room for JSON escaping.
+	maxIdempotentBodySize = 16 << 20
 )
 
 func main() {
+	app := gofr.New()
+
+	index := searchStore.New()
 	taskStr := taskStore.New()
 	userStr := userStore.New()
+	templateStr := templateStore.New()
+	auditStr := auditStore.New()
+	idempotencyStr := idempotencyStore.New()
+	commentStr := commentStore.New()
+	viewStr := viewStore.New()
+	webhookStr := webhookStore.New()
+	eventLogStr := eventLogStore.New()
+	notificationStr := notificationStore.New()
+	attachmentStr := attachmentStore.New()
+
+	webhookSvc := webhookService.New(webhookStr, webhookService.Client(webhookTimeout))
+
+	eventLogSize, err := strconv.Atoi(app.Config.GetOrDefault("EVENT_LOG_SIZE", "1000"))
+	if err != nil {
+		app.Logger().Fatalf("invalid EVENT_LOG_SIZE: %v", err)
+	}
+
+	streamSvc := streamService.New(eventLogStr, eventLogSize)
+
+	// Events go to the outbox only when there is a broker to relay them to.
+	sinks := []events.Sink{webhookSvc, streamSvc}
 
-	userSvc := userService.New(userStr)
-	taskSvc := taskService.New(taskStr, userSvc)
+	outboxSvc := outboxService.New(outboxStore.New(), app.Config.GetOrDefault("OUTBOX_TOPIC", "task-manager-events"))
+	if app.Config.Get("PUBSUB_BACKEND") != "" {
+		sinks = append(sinks, outboxSvc)
+	}
+
+	bus := events.NewBus(sinks...)
+	userSvc := userService.New(userStr, auditStr, bus)
+	taskSvc := taskService.New(taskStr, userSvc, auditStr, index, bus)
+	templateSvc := templateService.New(templateStr, taskSvc, userSvc)
+	commentSvc := commentService.New(commentStr, taskSvc, index)
+	searchSvc := searchService.New(index)
+	viewSvc := viewService.New(viewStr, taskSvc, auditStr)
+	commandSvc := commandService.New(commandStore.New(), taskSvc)
+	collabSvc := collabService.New(eventLogStr, streamSvc, taskSvc, app.Config.Get("BOARD_TOKEN_SECRET"))
+	notificationSvc := notificationService.New(notificationStr, userSvc)
+	inboundSvc := inboundService.New(inboundStore.New(), attachmentStr, userSvc, taskSvc, commentSvc)
+	attachmentSvc := attachmentService.New(attachmentStr, taskSvc)
+	calendarSvc := calendarService.New(calendarStore.New(), userSvc, taskSvc)
+	transferSvc := transferService.New(importJobStore.New(), taskSvc, userSvc)
+
+	// Email reminders and digests are only available when an SMTP server is configured.
+	notifiers := map[string]reminderService.Notifier{
+		models.ChannelInApp:   notify.NewInApp(notificationStr),
+		models.ChannelWebhook: notify.NewWebhook(bus),
+	}
+
+	var mailer digestService.Mailer
+
+	if host := app.Config.Get("SMTP_HOST"); host != "" {
+		smtp := mail.New(net.JoinHostPort(host, app.Config.GetOrDefault("SMTP_PORT", "587")),
+			app.Config.Get("SMTP_FROM"), app.Config.Get("SMTP_USERNAME"), app.Config.Get("SMTP_PASSWORD"))
+		notifiers[models.ChannelEmail] = notify.NewEmail(smtp)
+		mailer = smtp
+	}
+
+	reminderSvc := reminderService.New(reminderStore.New(), taskSvc, userSvc, notifiers)
+	digestSvc := digestService.New(digestStore.New(), taskStr, userSvc, mailer)
 
 	taskHndlr := taskHandler.New(taskSvc)
 	userHndlr := userHandler.New(userSvc)
+	templateHndlr := templateHandler.New(templateSvc)
+	commentHndlr := commentHandler.New(commentSvc)
+	searchHndlr := searchHandler.New(searchSvc)
+	viewHndlr := viewHandler.New(viewSvc)
+	webhookHndlr := webhookHandler.New(webhookSvc)
+	streamHndlr := streamHandler.New(streamSvc)
+	collabHndlr := collabHandler.New(collabSvc, app.Config.Get("BOARD_TOKEN_ISSUER_KEY"))
+	reminderHndlr := reminderHandler.New(reminderSvc)
+	notificationHndlr := notificationHandler.New(notificationSvc)
+	digestHndlr := digestHandler.New(digestSvc)
+	inboundHndlr := inboundHandler.New(inboundSvc, app.Config.Get("INBOUND_EMAIL_TOKEN"))
+	attachmentHndlr := attachmentHandler.New(attachmentSvc)
+	calendarHndlr := calendarHandler.New(calendarSvc)
+	transferHndlr := transferHandler.New(transferSvc)
+	commandsTopic := app.Config.GetOrDefault("TASK_COMMANDS_TOPIC", "task-commands")
+	commandHndlr := commandHandler.New(commandSvc, commandsTopic, app.Config.GetOrDefault("TASK_COMMANDS_DLQ_TOPIC", "task-commands-dlq"))
 
-	app := gofr.New()
+	app.UseMiddleware(middleware.RequestMetadata)
+	app.UseMiddleware(middleware.MergePatch)
+	app.UseMiddleware(middleware.RawBody("message/rfc822", "message", maxInboundEmailSize))
+	app.UseMiddleware(middleware.RawBody("text/calendar", "calendar", maxCalendarSize))
+	app.UseMiddlewareWithContainer(streamHndlr.Middleware)
+	app.UseMiddlewareWithContainer(transferHndlr.Middleware)
+	app.UseMiddlewareWithContainer(collabHndlr.Middleware)
 
 	app.Migrate(migrations.All())
 
-	app.GET("/task", taskHndlr.GetAll)
-	app.GET("/task/{id}", taskHndlr.GetByID)
-	app.POST("/task", taskHndlr.Post)
-	app.PUT("/task/{id}", taskHndlr.Put)
-	app.DELETE("/task/{id}", taskHndlr.Delete)
+	idempotencyTTLHours, err := strconv.Atoi(app.Config.GetOrDefault("IDEMPOTENCY_TTL_HOURS", "24"))
+	if err != nil {
+		app.Logger().Fatalf("invalid IDEMPOTENCY_TTL_HOURS: %v", err)
+	}
+
+	app.UseMiddlewareWithContainer(middleware.Idempotency(idempotencyStr, time.Duration(idempotencyTTLHours)*time.Hour,
+		maxIdempotentBodySize, "/task", "/user", "/import"))
+
+	retentionDays, err := strconv.Atoi(app.Config.GetOrDefault("TRASH_RETENTION_DAYS", "30"))
+	if err != nil {
+		app.Logger().Fatalf("invalid TRASH_RETENTION_DAYS: %v", err)
+	}
+
+	app.AddCronJob("0 3 * * *", "purge-trash", jobs.PurgeTrash(taskSvc, retentionDays))
+	app.AddCronJob("0 * * * *", "purge-idempotency-keys", jobs.PurgeIdempotencyKeys(idempotencyStr))
+	app.AddCronJob("*/10 * * * * *", "deliver-webhooks", jobs.DeliverWebhooks(webhookSvc, webhookBatch))
+	app.AddCronJob("* * * * * *", "poll-event-log", jobs.PollEventLog(streamSvc))
+	app.AddCronJob("* * * * *", "prune-event-log", jobs.PruneEventLog(streamSvc))
+	app.AddCronJob("*/30 * * * * *", "board-heartbeat", jobs.BoardHeartbeat(collabSvc))
+	app.AddCronJob("* * * * *", "dispatch-reminders", jobs.DispatchReminders(reminderSvc, reminderBatch))
+	app.AddCronJob("*/5 * * * * *", "process-imports", jobs.ProcessImports(transferSvc))
+
+	if mailer != nil {
+		app.AddCronJob("* * * * *", "send-digests", jobs.SendDigests(digestSvc))
+	}
+
+	outboxRetentionDays, err := strconv.Atoi(app.Config.GetOrDefault("OUTBOX_RETENTION_DAYS", "7"))
+	if err != nil {
+		app.Logger().Fatalf("invalid OUTBOX_RETENTION_DAYS: %v", err)
+	}
+
+	commandRetentionDays, err := strconv.Atoi(app.Config.GetOrDefault("COMMAND_RETENTION_DAYS", "7"))
+	if err != nil {
+		app.Logger().Fatalf("invalid COMMAND_RETENTION_DAYS: %v", err)
+	}
+
+	if app.Config.Get("PUBSUB_BACKEND") != "" {
+		app.AddCronJob("* * * * * *", "relay-outbox", jobs.RelayOutbox(outboxSvc, outboxBatch))
+		app.AddCronJob("30 3 * * *", "purge-outbox", jobs.PurgeOutbox(outboxSvc, outboxRetentionDays))
+		app.AddCronJob("45 3 * * *", "purge-processed-commands", jobs.PurgeProcessedCommands(commandSvc, commandRetentionDays))
+
+		app.Subscribe(commandsTopic, commandHndlr.Handle)
+	}
+
+	app.GET("/task", httperr.Handle(taskHndlr.GetAll))
+	app.GET("/task/{id}", httperr.Handle(taskHndlr.GetByID))
+	app.POST("/task", httperr.Handle(taskHndlr.Post))
+	app.POST("/task/bulk", httperr.Handle(taskHndlr.Bulk))
+	app.PUT("/task/{id}", httperr.Handle(taskHndlr.Put))
+	app.PATCH("/task/{id}", httperr.Handle(taskHndlr.Patch))
+	app.DELETE("/task/{id}", httperr.Handle(taskHndlr.Delete))
+	app.POST("/task/{id}/restore", httperr.Handle(taskHndlr.Restore))
+	app.GET("/task/{id}/history", httperr.Handle(taskHndlr.GetHistory))
+	app.GET("/task/{id}/description", httperr.Handle(taskHndlr.GetDescription))
+	app.GET("/trash", httperr.Handle(taskHndlr.GetTrash))
+
+	app.GET("/task/{id}/comments", httperr.Handle(commentHndlr.GetByTask))
+	app.POST("/task/{id}/comments", httperr.Handle(commentHndlr.Post))
+
+	app.GET("/task/{id}/reminders", httperr.Handle(reminderHndlr.GetByTask))
+	app.POST("/task/{id}/reminders", httperr.Handle(reminderHndlr.Post))
+	app.DELETE("/task/{id}/reminders/{reminder_id}", httperr.Handle(reminderHndlr.Delete))
+
+	app.GET("/task/{id}/attachments", httperr.Handle(attachmentHndlr.GetByTask))
+	app.GET("/task/{id}/attachments/{attachment_id}", httperr.Handle(attachmentHndlr.Get))
+
+	app.POST("/inbound/email", httperr.Handle(inboundHndlr.Post))
+
+	app.GET("/calendar/{token}.ics", httperr.Handle(calendarHndlr.Feed))
+
+	app.GET("/search", httperr.Handle(searchHndlr.Get))
+
+	app.GET(streamHandler.Path, httperr.Handle(streamHandler.Unreachable))
+
+	app.GET(transferHandler.Path, httperr.Handle(transferHandler.Unreachable))
+	app.POST("/import", httperr.Handle(transferHndlr.Import))
+	app.GET("/import/{id}", httperr.Handle(transferHndlr.GetJob))
+
+	app.POST("/board/token", httperr.Handle(collabHndlr.Token))
+	app.WebSocket(collabHandler.Path, collabHndlr.Handle)
+
+	app.GET("/user", httperr.Handle(userHndlr.Get))
+	app.GET("/user/{id}", httperr.Handle(userHndlr.GetByID))
+	app.GET("/user/{id}/tasks", httperr.Handle(taskHndlr.GetByUser))
+	app.GET("/user/{id}/summary", httperr.Handle(taskHndlr.GetUserSummary))
+	app.GET("/user/{id}/notifications", httperr.Handle(notificationHndlr.GetByUser))
+	app.POST("/user/{id}/notifications/{notification_id}/read", httperr.Handle(notificationHndlr.MarkRead))
+	app.GET("/user/{id}/digest-preference", httperr.Handle(digestHndlr.GetPreference))
+	app.PUT("/user/{id}/digest-preference", httperr.Handle(digestHndlr.PutPreference))
+	app.GET("/user/{id}/digests", httperr.Handle(digestHndlr.GetDigests))
+	app.POST("/user/{id}/calendar-token", httperr.Handle(calendarHndlr.PostToken))
+	app.DELETE("/user/{id}/calendar-token", httperr.Handle(calendarHndlr.DeleteToken))
+	app.POST("/user/{id}/calendar/import", httperr.Handle(calendarHndlr.Import))
+	app.POST("/user", httperr.Handle(userHndlr.Post))
+
+	app.GET("/template", httperr.Handle(templateHndlr.GetAll))
+	app.GET("/template/{id}", httperr.Handle(templateHndlr.GetByID))
+	app.POST("/template", httperr.Handle(templateHndlr.Post))
+	app.POST("/template/{id}/instantiate", httperr.Handle(templateHndlr.Instantiate))
+
+	app.GET("/view", httperr.Handle(viewHndlr.GetAll))
+	app.GET("/view/{id}", httperr.Handle(viewHndlr.GetByID))
+	app.POST("/view", httperr.Handle(viewHndlr.Post))
+	app.PUT("/view/{id}", httperr.Handle(viewHndlr.Put))
+	app.DELETE("/view/{id}", httperr.Handle(viewHndlr.Delete))
+	app.GET("/view/{id}/tasks", httperr.Handle(viewHndlr.Tasks))
 
-	app.GET("/user/{id}", userHndlr.GetByID)
-	app.POST("/user", userHndlr.Post)
+	app.GET("/webhook", httperr.Handle(webhookHndlr.GetAll))
+	app.GET("/webhook/{id}", httperr.Handle(webhookHndlr.GetByID))
+	app.POST("/webhook", httperr.Handle(webhookHndlr.Post))
+	app.PUT("/webhook/{id}", httperr.Handle(webhookHndlr.Put))
+	app.DELETE("/webhook/{id}", httperr.Handle(webhookHndlr.Delete))
+	app.GET("/webhook/{id}/deliveries", httperr.Handle(webhookHndlr.GetDeliveries))
+	app.POST("/webhook/{id}/deliveries/{delivery_id}/replay", httperr.Handle(webhookHndlr.Replay))
 
 	app.Run()
 }
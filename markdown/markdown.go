@@ -0,0 +1,482 @@
+// Package markdown renders the Markdown of task descriptions as HTML that is
+// safe to embed in a page. It supports a common subset of CommonMark:
+// headings, paragraphs, block quotes, flat lists, fenced code blocks,
+// thematic breaks, code spans, emphasis, strikethrough, links and autolinks.
+//
+// The output needs no further sanitizing. Every tag in it is written by the
+// renderer, all source text is escaped, raw HTML is shown as text, and links
+// keep only http, https and mailto URLs. Images are rendered as links, so that
+// viewing a description loads nothing.
+package markdown
+
+import (
+	"html"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+const (
+	// maxDepth bounds the nesting of block quotes and of inline markup.
+	// Deeper markup is rendered as text.
+	maxDepth = 16
+
+	// maxListDigits bounds the number of an ordered list item, as in CommonMark.
+	maxListDigits = 9
+
+	headingLevels = 6
+	ruleMarks     = 3
+	fenceLen      = 3
+)
+
+// linkRel keeps links from passing on the page's identity or a referrer.
+const linkRel = `rel="nofollow noopener noreferrer"`
+
+// ToHTML renders src.
+func ToHTML(src string) string {
+	var r renderer
+
+	src = strings.ReplaceAll(strings.ReplaceAll(src, "\r\n", "\n"), "\r", "\n")
+	r.blocks(strings.Split(src, "\n"), 0)
+
+	return r.String()
+}
+
+type renderer struct {
+	strings.Builder
+}
+
+func (r *renderer) blocks(lines []string, depth int) {
+	for i := 0; i < len(lines); {
+		trimmed := strings.TrimSpace(lines[i])
+
+		switch {
+		case trimmed == "":
+			i++
+		case isFence(trimmed):
+			i = r.codeBlock(lines, i)
+		case headingLevel(trimmed) > 0:
+			level := headingLevel(trimmed)
+			tag := "h" + strconv.Itoa(level)
+			text := strings.TrimRight(strings.TrimSpace(trimmed[level:]), "#")
+
+			r.WriteString("<" + tag + ">" + inline(strings.TrimSpace(text), 0) + "</" + tag + ">\n")
+			i++
+		case isRule(trimmed):
+			r.WriteString("<hr>\n")
+			i++
+		case strings.HasPrefix(trimmed, ">") && depth < maxDepth:
+			i = r.blockquote(lines, i, depth)
+		case isListItem(trimmed):
+			i = r.list(lines, i)
+		default:
+			i = r.paragraph(lines, i)
+		}
+	}
+}
+
+// codeBlock renders the fenced code block starting at lines[i] and returns the
+// index of the line after it. An unclosed fence runs to the end.
+func (r *renderer) codeBlock(lines []string, i int) int {
+	opening := strings.TrimSpace(lines[i])
+	fence := opening[:fenceLen]
+	lang := strings.TrimSpace(strings.TrimLeft(opening, fence[:1]))
+
+	r.WriteString("<pre><code")
+
+	if isLanguage(lang) {
+		r.WriteString(` class="language-` + lang + `"`)
+	}
+
+	r.WriteString(">")
+
+	for i++; i < len(lines); i++ {
+		if strings.HasPrefix(strings.TrimSpace(lines[i]), fence) {
+			i++
+
+			break
+		}
+
+		r.WriteString(html.EscapeString(lines[i]) + "\n")
+	}
+
+	r.WriteString("</code></pre>\n")
+
+	return i
+}
+
+func (r *renderer) blockquote(lines []string, i, depth int) int {
+	var quoted []string
+
+	for ; i < len(lines); i++ {
+		trimmed := strings.TrimSpace(lines[i])
+		if !strings.HasPrefix(trimmed, ">") {
+			break
+		}
+
+		quoted = append(quoted, strings.TrimPrefix(trimmed[1:], " "))
+	}
+
+	r.WriteString("<blockquote>\n")
+	r.blocks(quoted, depth+1)
+	r.WriteString("</blockquote>\n")
+
+	return i
+}
+
+// list renders consecutive items of the same kind of list. Indented lines
+// continue the item before them.
+func (r *renderer) list(lines []string, i int) int {
+	ordered, start, _ := listItem(strings.TrimSpace(lines[i]))
+
+	tag := "ul"
+	if ordered {
+		tag = "ol"
+	}
+
+	r.WriteString("<" + tag)
+
+	if ordered && start != 1 {
+		r.WriteString(` start="` + strconv.Itoa(start) + `"`)
+	}
+
+	r.WriteString(">\n")
+
+	for i < len(lines) {
+		itemOrdered, _, first := listItem(strings.TrimSpace(lines[i]))
+		if !isListItem(strings.TrimSpace(lines[i])) || itemOrdered != ordered {
+			break
+		}
+
+		text := []string{first}
+
+		for i++; i < len(lines) && isContinuation(lines[i]); i++ {
+			text = append(text, strings.TrimSpace(lines[i]))
+		}
+
+		r.WriteString("<li>" + inline(strings.Join(text, "\n"), 0) + "</li>\n")
+	}
+
+	r.WriteString("</" + tag + ">\n")
+
+	return i
+}
+
+func (r *renderer) paragraph(lines []string, i int) int {
+	text := []string{strings.TrimSpace(lines[i])}
+
+	for i++; i < len(lines); i++ {
+		trimmed := strings.TrimSpace(lines[i])
+		if trimmed == "" || startsBlock(trimmed) {
+			break
+		}
+
+		text = append(text, trimmed)
+	}
+
+	r.WriteString("<p>" + inline(strings.Join(text, "\n"), 0) + "</p>\n")
+
+	return i
+}
+
+func startsBlock(trimmed string) bool {
+	return isFence(trimmed) || headingLevel(trimmed) > 0 || isRule(trimmed) || strings.HasPrefix(trimmed, ">") || isListItem(trimmed)
+}
+
+func isFence(trimmed string) bool {
+	return strings.HasPrefix(trimmed, "```") || strings.HasPrefix(trimmed, "~~~")
+}
+
+// isLanguage accepts the info strings that can be put in a class attribute as is.
+func isLanguage(lang string) bool {
+	if lang == "" {
+		return false
+	}
+
+	for _, c := range lang {
+		if !isAlnum(c) && c != '-' && c != '_' && c != '+' {
+			return false
+		}
+	}
+
+	return true
+}
+
+// headingLevel returns the level of an ATX heading, or 0.
+func headingLevel(trimmed string) int {
+	level := len(trimmed) - len(strings.TrimLeft(trimmed, "#"))
+	if level == 0 || level > headingLevels {
+		return 0
+	}
+
+	if level < len(trimmed) && trimmed[level] != ' ' && trimmed[level] != '\t' {
+		return 0
+	}
+
+	return level
+}
+
+// isRule reports whether the line is three or more -, * or _ marks, which may
+// be separated by spaces.
+func isRule(trimmed string) bool {
+	mark := trimmed[0]
+	if mark != '-' && mark != '*' && mark != '_' {
+		return false
+	}
+
+	marks := 0
+
+	for i := range len(trimmed) {
+		switch trimmed[i] {
+		case mark:
+			marks++
+		case ' ', '\t':
+		default:
+			return false
+		}
+	}
+
+	return marks >= ruleMarks
+}
+
+func isListItem(trimmed string) bool {
+	_, _, text := listItem(trimmed)
+
+	return text != "" || trimmed == "-" || trimmed == "*" || trimmed == "+"
+}
+
+// listItem splits a list item into its kind, number and text. The text is
+// empty when the line is not a list item.
+func listItem(trimmed string) (ordered bool, start int, text string) {
+	if len(trimmed) >= 2 && strings.ContainsRune("-*+", rune(trimmed[0])) && trimmed[1] == ' ' {
+		return false, 0, strings.TrimSpace(trimmed[2:])
+	}
+
+	digits := len(trimmed) - len(strings.TrimLeft(trimmed, "0123456789"))
+	if digits == 0 || digits > maxListDigits || digits+1 >= len(trimmed) {
+		return false, 0, ""
+	}
+
+	if (trimmed[digits] != '.' && trimmed[digits] != ')') || trimmed[digits+1] != ' ' {
+		return false, 0, ""
+	}
+
+	start, _ = strconv.Atoi(trimmed[:digits])
+
+	return true, start, strings.TrimSpace(trimmed[digits+2:])
+}
+
+func isContinuation(line string) bool {
+	return line != "" && (line[0] == ' ' || line[0] == '\t') && strings.TrimSpace(line) != "" && !startsBlock(strings.TrimSpace(line))
+}
+
+// inline renders the spans in s, escaping everything that is not markup.
+func inline(s string, depth int) string {
+	p := inlineParser{s: s, depth: depth, closing: pairBrackets(s)}
+
+	var b strings.Builder
+
+	for i := 0; i < len(s); {
+		n, out := p.span(i)
+		if n > 0 {
+			b.WriteString(out)
+			i += n
+
+			continue
+		}
+
+		b.WriteString(html.EscapeString(s[i : i+1]))
+		i++
+	}
+
+	return b.String()
+}
+
+// inlineParser holds the text being rendered, how deeply it is nested in
+// other spans and the index of the ] closing each [.
+type inlineParser struct {
+	s       string
+	depth   int
+	closing map[int]int
+}
+
+// pairBrackets matches the unescaped square brackets in s.
+func pairBrackets(s string) map[int]int {
+	var (
+		closing = make(map[int]int)
+		open    []int
+	)
+
+	for i := 0; i < len(s); i++ {
+		switch s[i] {
+		case '\\':
+			i++
+		case '[':
+			open = append(open, i)
+		case ']':
+			if len(open) > 0 {
+				closing[open[len(open)-1]] = i
+				open = open[:len(open)-1]
+			}
+		}
+	}
+
+	return closing
+}
+
+// span renders the markup starting at s[i] and returns the number of bytes it
+// consumed, or 0 when s[i] starts no markup.
+func (p *inlineParser) span(i int) (int, string) {
+	s := p.s
+
+	switch s[i] {
+	case '\\':
+		if i+1 < len(s) && strings.IndexByte(punctuation, s[i+1]) >= 0 {
+			return 2, html.EscapeString(s[i+1 : i+2])
+		}
+	case '\n':
+		return 1, "\n"
+	case '`':
+		return p.codeSpan(i)
+	case '<':
+		return p.autolink(i)
+	case '[':
+		return p.link(i)
+	case '!':
+		if i+1 < len(s) && s[i+1] == '[' {
+			n, out := p.link(i + 1)
+			if n > 0 {
+				return n + 1, out
+			}
+		}
+	case '*', '_', '~':
+		return p.emphasis(i)
+	}
+
+	return 0, ""
+}
+
+const punctuation = "!\"#$%&'()*+,-./:;<=>?@[\\]^_`{|}~"
+
+func (p *inlineParser) codeSpan(i int) (int, string) {
+	s := p.s
+	ticks := len(s[i:]) - len(strings.TrimLeft(s[i:], "`"))
+	fence := s[i : i+ticks]
+
+	end := strings.Index(s[i+ticks:], fence)
+	if end < 0 {
+		return ticks, html.EscapeString(fence)
+	}
+
+	code := s[i+ticks : i+ticks+end]
+	if len(code) > 2 && code[0] == ' ' && code[len(code)-1] == ' ' {
+		code = code[1 : len(code)-1]
+	}
+
+	return 2*ticks + end, "<code>" + html.EscapeString(code) + "</code>"
+}
+
+func (p *inlineParser) autolink(i int) (int, string) {
+	s := p.s
+
+	end := strings.IndexAny(s[i+1:], "<> \t\n")
+	if end < 0 || s[i+1+end] != '>' {
+		return 0, ""
+	}
+
+	target := s[i+1 : i+1+end]
+
+	href, ok := safeURL(target)
+	if !ok {
+		return 0, ""
+	}
+
+	return end + 2, `<a href="` + href + `" ` + linkRel + `>` + html.EscapeString(target) + `</a>`
+}
+
+// link renders [text](url). A link whose URL is not allowed keeps its text.
+// Links do not nest, so the text is rendered without the link markup.
+func (p *inlineParser) link(i int) (int, string) {
+	s := p.s
+
+	closing, ok := p.closing[i]
+	if !ok || closing+1 >= len(s) || s[closing+1] != '(' {
+		return 0, ""
+	}
+
+	end := strings.IndexByte(s[closing+2:], ')')
+	if end < 0 {
+		return 0, ""
+	}
+
+	// the optional title after the URL is dropped
+	target, _, _ := strings.Cut(strings.TrimSpace(s[closing+2:closing+2+end]), " ")
+	n := closing + 3 + end - i
+	text := inline(strings.NewReplacer("[", `\[`, "]", `\]`).Replace(s[i+1:closing]), p.depth+1)
+
+	href, ok := safeURL(strings.Trim(target, "<>"))
+	if !ok {
+		return n, text
+	}
+
+	return n, `<a href="` + href + `" ` + linkRel + `>` + text + `</a>`
+}
+
+// emphasis renders *em*, _em_, **strong**, __strong__ and ~~del~~. An
+// underscore inside a word is text, as in snake_case.
+func (p *inlineParser) emphasis(i int) (int, string) {
+	s := p.s
+	mark := s[i]
+
+	if p.depth >= maxDepth || mark == '_' && i > 0 && isAlnum(rune(s[i-1])) {
+		return 0, ""
+	}
+
+	delim := s[i : i+1]
+	if i+1 < len(s) && s[i+1] == mark {
+		delim += delim
+	} else if mark == '~' {
+		return 0, ""
+	}
+
+	start := i + len(delim)
+	if start >= len(s) || s[start] == ' ' || s[start] == '\n' {
+		return 0, ""
+	}
+
+	end := strings.Index(s[start:], delim)
+	if end <= 0 || s[start+end-1] == ' ' {
+		return 0, ""
+	}
+
+	tag := "em"
+
+	switch {
+	case mark == '~':
+		tag = "del"
+	case len(delim) == 2:
+		tag = "strong"
+	}
+
+	return 2*len(delim) + end, "<" + tag + ">" + inline(s[start:start+end], p.depth+1) + "</" + tag + ">"
+}
+
+// safeURL returns the URL escaped for an attribute, provided it uses an
+// allowed scheme.
+func safeURL(raw string) (string, bool) {
+	u, err := url.Parse(raw)
+	if err != nil {
+		return "", false
+	}
+
+	switch strings.ToLower(u.Scheme) {
+	case "http", "https", "mailto":
+		return html.EscapeString(u.String()), true
+	}
+
+	return "", false
+}
+
+func isAlnum(c rune) bool {
+	return 'a' <= c && c <= 'z' || 'A' <= c && c <= 'Z' || '0' <= c && c <= '9'
+}
@@ -0,0 +1,106 @@
+package markdown
+
+import (
+	"regexp"
+	"strings"
+	"testing"
+)
+
+func TestToHTML(t *testing.T) {
+	tests := []struct {
+		description string
+		input       string
+		want        string
+	}{
+		{
+			"paragraphs and headings",
+			"# Release *notes*\nFirst line\nsecond line\n\n## Next ##",
+			"<h1>Release <em>notes</em></h1>\n<p>First line\nsecond line</p>\n<h2>Next</h2>\n",
+		},
+		{
+			"emphasis, strong, strikethrough and code",
+			"**bold** _it_ ~~old~~ `a <b>` snake_case_name 2 * 3 * 4",
+			"<p><strong>bold</strong> <em>it</em> <del>old</del> <code>a &lt;b&gt;</code> snake_case_name 2 * 3 * 4</p>\n",
+		},
+		{
+			"lists",
+			"- one\n- two\n  continued\n\n3. three\n4) four",
+			"<ul>\n<li>one</li>\n<li>two\ncontinued</li>\n</ul>\n<ol start=\"3\">\n<li>three</li>\n<li>four</li>\n</ol>\n",
+		},
+		{
+			"block quote, rule and fenced code",
+			"> quoted\n> > nested\n\n---\n```go\nif a < b {}\n```",
+			"<blockquote>\n<p>quoted</p>\n<blockquote>\n<p>nested</p>\n</blockquote>\n</blockquote>\n<hr>\n" +
+				"<pre><code class=\"language-go\">if a &lt; b {}\n</code></pre>\n",
+		},
+		{
+			"links",
+			`[docs](https://example.com/a?b=1&c="2" "title") <mailto:a@example.com> ![logo](http://example.com/x.png)`,
+			`<p><a href="https://example.com/a?b=1&amp;c=&#34;2&#34;" rel="nofollow noopener noreferrer">docs</a> ` +
+				`<a href="mailto:a@example.com" rel="nofollow noopener noreferrer">mailto:a@example.com</a> ` +
+				`<a href="http://example.com/x.png" rel="nofollow noopener noreferrer">logo</a></p>` + "\n",
+		},
+		{
+			"raw HTML is text",
+			`<script>alert(1)</script> <img src=x onerror="alert(1)">`,
+			"<p>&lt;script&gt;alert(1)&lt;/script&gt; &lt;img src=x onerror=&#34;alert(1)&#34;&gt;</p>\n",
+		},
+		{
+			"unsafe links keep their text",
+			"[click](javascript:alert(1)) [x](data:text/html,hi) <javascript:alert(1)> [a [b](http://e.com)](vbscript:x)",
+			"<p>click) x &lt;javascript:alert(1)&gt; a [b](http://e.com)</p>\n",
+		},
+		{
+			"escapes",
+			"\\*not em\\* \\<b> ``a ` \"b``",
+			"<p>*not em* &lt;b&gt; <code>a ` &#34;b</code></p>\n",
+		},
+		{
+			"unsafe code language",
+			"```x\" onclick=\"y\ncode\n```",
+			"<pre><code>code\n</code></pre>\n",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.description, func(t *testing.T) {
+			if got := ToHTML(tc.input); got != tc.want {
+				t.Errorf("expected\n%q\ngot\n%q", tc.want, got)
+			}
+		})
+	}
+}
+
+// allowedTag matches the start of a tag that the renderer may write.
+var allowedTag = regexp.MustCompile(`^<(?:/?(?:p|h[1-6]|blockquote|ul|li|pre|em|strong|del|code|a)>|hr>|ol>|ol start="\d+">|` +
+	`/ol>|code class="language-[A-Za-z0-9_+-]+">|a href="(?:https?|mailto):[^"<>]*" rel="nofollow noopener noreferrer">)`)
+
+func FuzzToHTML(f *testing.F) {
+	for _, seed := range []string{
+		"# h\n> q\n- a\n1. b\n```go\nc\n```\n---",
+		`**a** _b_ ~~c~~ ` + "`d`" + ` [e](http://f) <https://g> ![h](mailto:i)`,
+		`<script>alert(1)</script> [x](javascript:alert(1)) [y](https://a" onmouseover="b)`,
+		"***a***___b___[[c](d)](e)\\[f\\]",
+	} {
+		f.Add(seed)
+	}
+
+	f.Fuzz(func(t *testing.T, input string) {
+		out := ToHTML(input)
+
+		for i := strings.IndexByte(out, '<'); i >= 0; i = next(out, i) {
+			if !allowedTag.MatchString(out[i:]) {
+				t.Fatalf("unexpected markup at %d in %q for %q", i, out, input)
+			}
+		}
+	})
+}
+
+func next(s string, i int) int {
+	j := strings.IndexByte(s[i+1:], '<')
+	if j < 0 {
+		return -1
+	}
+
+	return i + 1 + j
+}
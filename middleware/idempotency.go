@@ -0,0 +1,205 @@
+package middleware
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"slices"
+	"time"
+
+	"gofr.dev/pkg/gofr"
+	"gofr.dev/pkg/gofr/container"
+
+	"TaskManager2/apperr"
+	"TaskManager2/models"
+)
+
+const (
+	// IdempotencyKeyHeader lets clients retry a POST without repeating its effect.
+	IdempotencyKeyHeader = "Idempotency-Key"
+	// ReplayedHeader marks a response replayed from an earlier request.
+	ReplayedHeader = "Idempotent-Replayed"
+
+	maxIdempotencyKeyLength = 255
+
+	// claimTimeout bounds how long a request that never finishes, for example
+	// because the server crashed, keeps its key locked. A request still running
+	// extends its claim every claimRenewal.
+	claimTimeout = time.Minute
+	claimRenewal = claimTimeout / 3
+)
+
+// Idempotency answers POST requests to the given paths that repeat an
+// Idempotency-Key with the response stored for the first one, for ttl after
+// it completed. Keys are scoped to the actor and the endpoint, and their
+// bodies are read up to maxSize bytes. Reusing a key with a different body is
+// rejected with 422, and a retry that arrives while the first request is still
+// running with 409. Responses with a 5xx status are not stored, so the request
+// can be retried.
+func Idempotency(store IdempotencyStore, ttl time.Duration, maxSize int64,
+	paths ...string) func(*container.Container, http.Handler) http.Handler {
+	return func(c *container.Container, inner http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			key := r.Header.Get(IdempotencyKeyHeader)
+			if key == "" || r.Method != http.MethodPost || !slices.Contains(paths, r.URL.Path) {
+				inner.ServeHTTP(w, r)
+
+				return
+			}
+
+			if len(key) > maxIdempotencyKeyLength {
+				apperr.Write(w, apperr.Validation(apperr.Field(IdempotencyKeyHeader,
+					fmt.Sprintf("must be at most %d characters", maxIdempotencyKeyLength))))
+
+				return
+			}
+
+			body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxSize))
+
+			var tooLarge *http.MaxBytesError
+
+			switch {
+			case errors.As(err, &tooLarge):
+				apperr.Write(w, apperr.Validation(apperr.Field("body", "is too large")))
+
+				return
+			case err != nil:
+				apperr.Write(w, apperr.Validation(apperr.Field("body", "could not be read")))
+
+				return
+			}
+
+			r.Body = io.NopCloser(bytes.NewReader(body))
+			sum := sha256.Sum256(body)
+			now := time.Now().UTC()
+			ctx := &gofr.Context{Context: r.Context(), Container: c}
+			rec := &models.IdempotencyRecord{
+				Actor:       r.Header.Get(ActorHeader),
+				Endpoint:    r.Method + " " + r.URL.Path,
+				Key:         key,
+				Fingerprint: hex.EncodeToString(sum[:]),
+				ClaimToken:  newToken(),
+				ExpiresAt:   now.Add(claimTimeout),
+			}
+
+			existing, err := store.Claim(ctx, rec, now)
+
+			switch {
+			case err != nil:
+				c.Logger.Errorf("claiming idempotency key: %v", err)
+				apperr.Write(w, err)
+			case existing == nil:
+				serveOnce(ctx, store, ttl, rec, w, r, inner)
+			case existing.Fingerprint != rec.Fingerprint:
+				apperr.Write(w, apperr.IdempotencyKeyReused(
+					fmt.Sprintf("%s %q was already used with a different request", IdempotencyKeyHeader, key)))
+			case existing.StatusCode == 0:
+				apperr.Write(w, apperr.Conflict(
+					fmt.Sprintf("a request with %s %q is still in progress", IdempotencyKeyHeader, key)))
+			default:
+				for name, values := range existing.Header {
+					w.Header()[name] = values
+				}
+
+				w.Header().Set(ReplayedHeader, "true")
+				w.WriteHeader(existing.StatusCode)
+				_, _ = w.Write(existing.Body)
+			}
+		})
+	}
+}
+
+// serveOnce runs the request that claimed rec, holding the claim meanwhile,
+// and stores its response, or releases the key when the response is a server
+// error.
+func serveOnce(ctx *gofr.Context, store IdempotencyStore, ttl time.Duration, rec *models.IdempotencyRecord,
+	w http.ResponseWriter, r *http.Request, inner http.Handler) {
+	recorder := &responseRecorder{ResponseWriter: w, status: http.StatusOK}
+	before := w.Header().Clone()
+	stop, stopped := make(chan struct{}), make(chan struct{})
+
+	go func() {
+		defer close(stopped)
+
+		holdClaim(ctx, store, rec, claimRenewal, stop)
+	}()
+
+	inner.ServeHTTP(recorder, r)
+	close(stop)
+	<-stopped
+
+	if recorder.status >= http.StatusInternalServerError {
+		err := store.Release(ctx, rec)
+		if err != nil {
+			ctx.Logger.Errorf("releasing idempotency key: %v", err)
+		}
+
+		return
+	}
+
+	rec.StatusCode = recorder.status
+	rec.Header = changedHeaders(before, w.Header())
+	rec.Body = recorder.body.Bytes()
+	rec.ExpiresAt = time.Now().UTC().Add(ttl)
+
+	err := store.Complete(ctx, rec)
+	if err != nil {
+		ctx.Logger.Errorf("storing idempotent response: %v", err)
+	}
+}
+
+// holdClaim extends the claim on rec every interval until stop is closed, so
+// that a request running past claimTimeout is not run again by a retry.
+func holdClaim(ctx *gofr.Context, store IdempotencyStore, rec *models.IdempotencyRecord, interval time.Duration,
+	stop <-chan struct{}) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-stop:
+			return
+		case <-ticker.C:
+			err := store.Extend(ctx, rec, time.Now().UTC().Add(claimTimeout))
+			if err != nil {
+				ctx.Logger.Errorf("extending idempotency claim: %v", err)
+			}
+		}
+	}
+}
+
+// changedHeaders returns the headers set between the before and after
+// snapshots, leaving out those set for every request, such as X-Request-ID.
+func changedHeaders(before, after http.Header) http.Header {
+	changed := make(http.Header)
+
+	for name, values := range after {
+		if !slices.Equal(before[name], values) {
+			changed[name] = values
+		}
+	}
+
+	return changed
+}
+
+// responseRecorder passes a response through while keeping a copy of it.
+type responseRecorder struct {
+	http.ResponseWriter
+	status int
+	body   bytes.Buffer
+}
+
+func (r *responseRecorder) WriteHeader(status int) {
+	r.status = status
+	r.ResponseWriter.WriteHeader(status)
+}
+
+func (r *responseRecorder) Write(b []byte) (int, error) {
+	r.body.Write(b)
+
+	return r.ResponseWriter.Write(b)
+}
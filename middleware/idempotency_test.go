@@ -0,0 +1,266 @@
+package middleware
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"go.uber.org/mock/gomock"
+	"gofr.dev/pkg/gofr"
+	"gofr.dev/pkg/gofr/container"
+
+	"TaskManager2/models"
+	"TaskManager2/utils"
+)
+
+func TestIdempotency(t *testing.T) {
+	controller := gomock.NewController(t)
+	mockStore := NewMockIdempotencyStore(controller)
+	mockContainer, _ := container.NewMockContainer(t)
+
+	body := `{"desc":"task","user_id":1}`
+	sum := sha256.Sum256([]byte(body))
+	fingerprint := hex.EncodeToString(sum[:])
+	claimed := func(_ *gofr.Context, rec *models.IdempotencyRecord, _ any) (*models.IdempotencyRecord, error) {
+		if rec.Actor != "7" || rec.Endpoint != "POST /task" || rec.Key != "k1" || rec.Fingerprint != fingerprint ||
+			len(rec.ClaimToken) != 2*tokenBytes {
+			t.Errorf("unexpected claim %+v", rec)
+		}
+
+		return nil, nil
+	}
+	header := http.Header{"Content-Type": {"application/json"}, "Etag": {`"1"`}, "Location": {"/task/1"}}
+	stored := &models.IdempotencyRecord{Fingerprint: fingerprint, StatusCode: http.StatusCreated, Header: header,
+		Body: []byte(`{"data":1}`)}
+
+	tests := []struct {
+		description string
+		method      string
+		path        string
+		key         string
+		body        string
+		innerStatus int
+		mockExpect  func()
+		wantStatus  int
+		wantBody    string
+		wantInner   bool
+		wantReplay  bool
+	}{
+		{
+			description: "no key",
+			method:      http.MethodPost,
+			path:        "/task",
+			innerStatus: http.StatusCreated,
+			mockExpect:  func() {},
+			wantStatus:  http.StatusCreated,
+			wantBody:    `{"data":1}`,
+			wantInner:   true,
+		},
+		{
+			description: "other path",
+			method:      http.MethodPost,
+			path:        "/template",
+			key:         "k1",
+			innerStatus: http.StatusCreated,
+			mockExpect:  func() {},
+			wantStatus:  http.StatusCreated,
+			wantBody:    `{"data":1}`,
+			wantInner:   true,
+		},
+		{
+			description: "first request is stored",
+			method:      http.MethodPost,
+			path:        "/task",
+			key:         "k1",
+			innerStatus: http.StatusCreated,
+			mockExpect: func() {
+				mockStore.EXPECT().Claim(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(claimed)
+				mockStore.EXPECT().Complete(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ *gofr.Context, rec *models.IdempotencyRecord) error {
+						if rec.StatusCode != http.StatusCreated || string(rec.Body) != `{"data":1}` || !reflect.DeepEqual(rec.Header, header) {
+							t.Errorf("unexpected stored response %+v", rec)
+						}
+
+						return utils.ErrTest
+					})
+			},
+			wantStatus: http.StatusCreated,
+			wantBody:   `{"data":1}`,
+			wantInner:  true,
+		},
+		{
+			description: "server error releases the key",
+			method:      http.MethodPost,
+			path:        "/task",
+			key:         "k1",
+			innerStatus: http.StatusInternalServerError,
+			mockExpect: func() {
+				mockStore.EXPECT().Claim(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(claimed)
+				mockStore.EXPECT().Release(gomock.Any(), gomock.Any()).Return(nil)
+			},
+			wantStatus: http.StatusInternalServerError,
+			wantBody:   `{"data":1}`,
+			wantInner:  true,
+		},
+		{
+			description: "retry is replayed",
+			method:      http.MethodPost,
+			path:        "/task",
+			key:         "k1",
+			mockExpect: func() {
+				mockStore.EXPECT().Claim(gomock.Any(), gomock.Any(), gomock.Any()).Return(stored, nil)
+			},
+			wantStatus: http.StatusCreated,
+			wantBody:   `{"data":1}`,
+			wantReplay: true,
+		},
+		{
+			description: "key reused with another payload",
+			method:      http.MethodPost,
+			path:        "/task",
+			key:         "k1",
+			mockExpect: func() {
+				mockStore.EXPECT().Claim(gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(&models.IdempotencyRecord{Fingerprint: "other", StatusCode: http.StatusCreated}, nil)
+			},
+			wantStatus: http.StatusUnprocessableEntity,
+			wantBody:   `{"error":{"code":"idempotency_key_reused","message":"Idempotency-Key \"k1\" was already used with a different request"}}`,
+		},
+		{
+			description: "first request still running",
+			method:      http.MethodPost,
+			path:        "/task",
+			key:         "k1",
+			mockExpect: func() {
+				mockStore.EXPECT().Claim(gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(&models.IdempotencyRecord{Fingerprint: fingerprint}, nil)
+			},
+			wantStatus: http.StatusConflict,
+			wantBody:   `{"error":{"code":"conflict","message":"a request with Idempotency-Key \"k1\" is still in progress"}}`,
+		},
+		{
+			description: "key too long",
+			method:      http.MethodPost,
+			path:        "/task",
+			key:         strings.Repeat("k", 256),
+			mockExpect:  func() {},
+			wantStatus:  http.StatusBadRequest,
+			wantBody: `{"error":{"code":"validation_failed","details":[{"field":"Idempotency-Key",` +
+				`"reason":"must be at most 255 characters"}],"message":"invalid Idempotency-Key"}}`,
+		},
+		{
+			description: "body too large",
+			method:      http.MethodPost,
+			path:        "/task",
+			key:         "k1",
+			body:        strings.Repeat("x", 1<<10+1),
+			mockExpect:  func() {},
+			wantStatus:  http.StatusBadRequest,
+			wantBody: `{"error":{"code":"validation_failed","details":[{"field":"body",` +
+				`"reason":"is too large"}],"message":"invalid body"}}`,
+		},
+		{
+			description: "claim error",
+			method:      http.MethodPost,
+			path:        "/task",
+			key:         "k1",
+			mockExpect: func() {
+				mockStore.EXPECT().Claim(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, utils.ErrTest)
+			},
+			wantStatus: http.StatusInternalServerError,
+			wantBody:   `{"error":{"code":"internal","message":"internal server error"}}`,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.description, func(t *testing.T) {
+			tc.mockExpect()
+
+			var innerCalled bool
+
+			inner := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				innerCalled = true
+
+				if got, _ := io.ReadAll(r.Body); string(got) != body {
+					t.Errorf("expected the handler to read %s, got %s", body, got)
+				}
+
+				w.Header().Set("Content-Type", "application/json")
+				w.Header().Set("ETag", `"1"`)
+				w.Header().Set("Location", "/task/1")
+				w.WriteHeader(tc.innerStatus)
+				_, _ = w.Write([]byte(`{"data":1}`))
+			})
+
+			handler := Idempotency(mockStore, 0, 1<<10, "/task", "/user")(mockContainer, inner)
+
+			reqBody := body
+			if tc.body != "" {
+				reqBody = tc.body
+			}
+
+			req := httptest.NewRequest(tc.method, tc.path, strings.NewReader(reqBody))
+			req.Header.Set(ActorHeader, "7")
+
+			if tc.key != "" {
+				req.Header.Set(IdempotencyKeyHeader, tc.key)
+			}
+
+			w := httptest.NewRecorder()
+			w.Header().Set(RequestIDHeader, "r1")
+			handler.ServeHTTP(w, req)
+
+			if w.Code != tc.wantStatus || strings.TrimSpace(w.Body.String()) != tc.wantBody {
+				t.Errorf("expected %d %s, got %d %s", tc.wantStatus, tc.wantBody, w.Code, w.Body.String())
+			}
+
+			if innerCalled != tc.wantInner {
+				t.Errorf("expected handler called: %v, got: %v", tc.wantInner, innerCalled)
+			}
+
+			if replayed := w.Header().Get(ReplayedHeader) == "true"; replayed != tc.wantReplay {
+				t.Errorf("expected replayed: %v, got: %v", tc.wantReplay, replayed)
+			}
+
+			if tc.wantReplay && (w.Header().Get("ETag") != `"1"` || w.Header().Get("Location") != "/task/1") {
+				t.Errorf("expected the original headers to be replayed, got %v", w.Header())
+			}
+		})
+	}
+}
+
+func TestHoldClaim(t *testing.T) {
+	controller := gomock.NewController(t)
+	mockStore := NewMockIdempotencyStore(controller)
+	mockContainer, _ := container.NewMockContainer(t)
+	ctx := &gofr.Context{Context: t.Context(), Container: mockContainer}
+	rec := &models.IdempotencyRecord{Key: "k1", ClaimToken: "c1"}
+	stop, extended := make(chan struct{}), make(chan struct{}, 2)
+
+	mockStore.EXPECT().Extend(ctx, rec, gomock.Any()).DoAndReturn(func(_ *gofr.Context, _ *models.IdempotencyRecord, until time.Time) error {
+		if until.Before(time.Now()) {
+			t.Errorf("expected the claim to be extended, got %v", until)
+		}
+
+		select {
+		case extended <- struct{}{}:
+		default:
+		}
+
+		return utils.ErrTest
+	}).MinTimes(2)
+
+	go func() {
+		<-extended
+		<-extended
+		close(stop)
+	}()
+
+	holdClaim(ctx, mockStore, rec, time.Millisecond, stop)
+}
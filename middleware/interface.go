@@ -0,0 +1,16 @@
+package middleware
+
+import (
+	"time"
+
+	"gofr.dev/pkg/gofr"
+
+	"TaskManager2/models"
+)
+
+type IdempotencyStore interface {
+	Claim(*gofr.Context, *models.IdempotencyRecord, time.Time) (*models.IdempotencyRecord, error)
+	Extend(*gofr.Context, *models.IdempotencyRecord, time.Time) error
+	Complete(*gofr.Context, *models.IdempotencyRecord) error
+	Release(*gofr.Context, *models.IdempotencyRecord) error
+}
@@ -0,0 +1,21 @@
+package middleware
+
+import (
+	"mime"
+	"net/http"
+)
+
+const mergePatchType = "application/merge-patch+json"
+
+// MergePatch lets handlers bind JSON merge patch bodies, which are plain JSON
+// sent as application/merge-patch+json, in the same way as application/json.
+func MergePatch(inner http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mediaType, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type"))
+		if mediaType == mergePatchType {
+			r.Header.Set("Content-Type", "application/json")
+		}
+
+		inner.ServeHTTP(w, r)
+	})
+}
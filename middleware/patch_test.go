@@ -0,0 +1,39 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestMergePatch(t *testing.T) {
+	tests := []struct {
+		description string
+		contentType string
+		want        string
+	}{
+		{"merge patch", "application/merge-patch+json", "application/json"},
+		{"merge patch with charset", "application/merge-patch+json; charset=utf-8", "application/json"},
+		{"json untouched", "application/json", "application/json"},
+		{"form untouched", "application/x-www-form-urlencoded", "application/x-www-form-urlencoded"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.description, func(t *testing.T) {
+			var got string
+
+			handler := MergePatch(http.HandlerFunc(func(_ http.ResponseWriter, r *http.Request) {
+				got = r.Header.Get("Content-Type")
+			}))
+
+			req := httptest.NewRequest(http.MethodPatch, "/task/1", http.NoBody)
+			req.Header.Set("Content-Type", tc.contentType)
+
+			handler.ServeHTTP(httptest.NewRecorder(), req)
+
+			if got != tc.want {
+				t.Errorf("expected content type %q, got %q", tc.want, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,57 @@
+package middleware
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+	"mime"
+	"net/http"
+
+	"TaskManager2/apperr"
+)
+
+// RawBody lets handlers bind bodies of the given media type, such as raw
+// emails, of at most maxSize bytes as the JSON object {field: <base64 of the
+// body>}. Clients that post JSON send that object directly.
+func RawBody(mediaType, field string, maxSize int64) func(http.Handler) http.Handler {
+	return func(inner http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			got, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type"))
+			if got != mediaType {
+				inner.ServeHTTP(w, r)
+
+				return
+			}
+
+			raw, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxSize))
+
+			var tooLarge *http.MaxBytesError
+
+			switch {
+			case errors.As(err, &tooLarge):
+				apperr.Write(w, apperr.Validation(apperr.Field("body", "is too large")))
+
+				return
+			case err != nil:
+				apperr.Write(w, apperr.Validation(apperr.Field("body", "could not be read")))
+
+				return
+			}
+
+			body, err := json.Marshal(map[string][]byte{field: raw})
+			if err != nil {
+				apperr.Write(w, err)
+
+				return
+			}
+
+			r.Body = io.NopCloser(bytes.NewReader(body))
+			r.ContentLength = int64(len(body))
+			r.Header.Set("Content-Type", "application/json")
+			r.Header.Del("Content-Length")
+
+			inner.ServeHTTP(w, r)
+		})
+	}
+}
@@ -0,0 +1,48 @@
+package middleware
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestRawBody(t *testing.T) {
+	const email = "From: ada@example.com\r\n\r\nHi\r\n"
+
+	tests := []struct {
+		description string
+		contentType string
+		body        string
+		wantStatus  int
+		wantType    string
+		wantBody    string
+	}{
+		{"raw email", "message/rfc822", email, http.StatusOK, "application/json", `{"message":"RnJvbTogYWRhQGV4YW1wbGUuY29tDQoNCkhpDQo="}`},
+		{"json untouched", "application/json", `{"message":"SGk="}`, http.StatusOK, "application/json", `{"message":"SGk="}`},
+		{"too large", "message/rfc822", email + strings.Repeat("x", 64), http.StatusBadRequest, "", ""},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.description, func(t *testing.T) {
+			var gotType, gotBody string
+
+			handler := RawBody("message/rfc822", "message", 64)(http.HandlerFunc(func(_ http.ResponseWriter, r *http.Request) {
+				gotType = r.Header.Get("Content-Type")
+				b, _ := io.ReadAll(r.Body)
+				gotBody = string(b)
+			}))
+
+			req := httptest.NewRequest(http.MethodPost, "/inbound/email", strings.NewReader(tc.body))
+			req.Header.Set("Content-Type", tc.contentType)
+
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, req)
+
+			if rec.Code != tc.wantStatus || gotType != tc.wantType || gotBody != tc.wantBody {
+				t.Errorf("expected %d, %q, %q, got %d, %q, %q", tc.wantStatus, tc.wantType, tc.wantBody, rec.Code, gotType, gotBody)
+			}
+		})
+	}
+}
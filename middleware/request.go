@@ -0,0 +1,135 @@
+package middleware
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+
+	"TaskManager2/apperr"
+)
+
+const (
+	// ActorHeader identifies the user making the request.
+	ActorHeader = "X-User-ID"
+	// RequestIDHeader carries the request ID, generated when the client sends
+	// none or an invalid one.
+	RequestIDHeader = "X-Request-ID"
+
+	// MaxActorLength and MaxRequestIDLength are the widths of the audit log
+	// columns the actor and request ID are recorded in.
+	MaxActorLength     = 50
+	MaxRequestIDLength = 64
+
+	tokenBytes = 16
+)
+
+type headerKey struct{}
+
+type requestIDKey struct{}
+
+// RequestMetadata makes the request headers available to handlers through
+// Header, Actor and RequestID, and echoes the request ID in the response. A
+// request ID that is missing, too long or not printable is replaced by a
+// generated one, and a request with a too long actor is rejected.
+func RequestMetadata(inner http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		id := r.Header.Get(RequestIDHeader)
+		if !validRequestID(id) {
+			id = newToken()
+		}
+
+		w.Header().Set(RequestIDHeader, id)
+
+		if len(r.Header.Get(ActorHeader)) > MaxActorLength {
+			apperr.Write(w, apperr.Validation(apperr.Field(ActorHeader,
+				fmt.Sprintf("must be at most %d characters", MaxActorLength))))
+
+			return
+		}
+
+		ctx := context.WithValue(r.Context(), headerKey{}, r.Header.Clone())
+		ctx = context.WithValue(ctx, requestIDKey{}, id)
+
+		inner.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
+
+// WithMetadata returns a copy of ctx reporting the given actor and request ID,
+// for work such as queued commands that does not arrive over HTTP.
+func WithMetadata(ctx context.Context, actor, requestID string) context.Context {
+	header := http.Header{}
+	if actor != "" {
+		header.Set(ActorHeader, actor)
+	}
+
+	ctx = context.WithValue(ctx, headerKey{}, header)
+
+	return context.WithValue(ctx, requestIDKey{}, requestID)
+}
+
+// WithActor returns a copy of ctx reporting the given actor, for requests
+// whose actor is authenticated by other means than the X-User-ID header.
+func WithActor(ctx context.Context, actor string) context.Context {
+	return WithHeader(ctx, ActorHeader, actor)
+}
+
+// WithHeader returns a copy of ctx whose Header reports value for key, for
+// values the server sets on a request in place of the client.
+func WithHeader(ctx context.Context, key, value string) context.Context {
+	header, _ := ctx.Value(headerKey{}).(http.Header)
+
+	header = header.Clone()
+	if header == nil {
+		header = http.Header{}
+	}
+
+	header.Set(key, value)
+
+	return context.WithValue(ctx, headerKey{}, header)
+}
+
+// Header returns the named request header, or "" outside an HTTP request.
+func Header(ctx context.Context, key string) string {
+	header, ok := ctx.Value(headerKey{}).(http.Header)
+	if !ok {
+		return ""
+	}
+
+	return header.Get(key)
+}
+
+func Actor(ctx context.Context) string {
+	return Header(ctx, ActorHeader)
+}
+
+func RequestID(ctx context.Context) string {
+	id, _ := ctx.Value(requestIDKey{}).(string)
+
+	return id
+}
+
+// validRequestID reports whether a client request ID fits the audit log and
+// holds only printable ASCII without spaces.
+func validRequestID(id string) bool {
+	if id == "" || len(id) > MaxRequestIDLength {
+		return false
+	}
+
+	for i := range len(id) {
+		if id[i] <= ' ' || id[i] > '~' {
+			return false
+		}
+	}
+
+	return true
+}
+
+// newToken returns a random hex string, used for request IDs and claims.
+func newToken() string {
+	b := make([]byte, tokenBytes)
+	_, _ = rand.Read(b)
+
+	return hex.EncodeToString(b)
+}
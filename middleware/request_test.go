@@ -0,0 +1,87 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestRequestMetadata(t *testing.T) {
+	tests := []struct {
+		description   string
+		headers       map[string]string
+		wantActor     string
+		wantRequestID string
+	}{
+		{"headers present", map[string]string{"X-User-ID": "7", "X-Request-ID": "req-1"}, "7", "req-1"},
+		{"request id generated", map[string]string{}, "", ""},
+		{"too long request id replaced", map[string]string{"X-Request-ID": strings.Repeat("r", MaxRequestIDLength+1)}, "", ""},
+		{"unprintable request id replaced", map[string]string{"X-Request-ID": "req\x01"}, "", ""},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.description, func(t *testing.T) {
+			var actor, requestID string
+
+			handler := RequestMetadata(http.HandlerFunc(func(_ http.ResponseWriter, r *http.Request) {
+				actor = Actor(r.Context())
+				requestID = RequestID(r.Context())
+			}))
+
+			req := httptest.NewRequest(http.MethodGet, "/task", http.NoBody)
+			for k, v := range tc.headers {
+				req.Header.Set(k, v)
+			}
+
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, req)
+
+			if actor != tc.wantActor {
+				t.Errorf("expected actor %q, got %q", tc.wantActor, actor)
+			}
+
+			if tc.wantRequestID == "" && (!validRequestID(requestID) || requestID == tc.headers[RequestIDHeader]) {
+				t.Errorf("expected a generated request id, got %q", requestID)
+			}
+
+			if tc.wantRequestID != "" && requestID != tc.wantRequestID {
+				t.Errorf("expected request id %q, got %q", tc.wantRequestID, requestID)
+			}
+
+			if requestID == "" || rec.Header().Get(RequestIDHeader) != requestID {
+				t.Errorf("expected request id %q to be echoed, got %q", requestID, rec.Header().Get(RequestIDHeader))
+			}
+		})
+	}
+}
+
+func TestRequestMetadata_ActorTooLong(t *testing.T) {
+	handler := RequestMetadata(http.HandlerFunc(func(http.ResponseWriter, *http.Request) {
+		t.Error("expected the request to be rejected")
+	}))
+
+	req := httptest.NewRequest(http.MethodGet, "/task", http.NoBody)
+	req.Header.Set(ActorHeader, strings.Repeat("a", MaxActorLength+1))
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusBadRequest {
+		t.Errorf("expected status %d, got %d", http.StatusBadRequest, rec.Code)
+	}
+}
+
+func TestWithMetadata(t *testing.T) {
+	ctx := WithMetadata(t.Context(), "7", "msg-1")
+
+	if Actor(ctx) != "7" || RequestID(ctx) != "msg-1" {
+		t.Errorf("expected actor 7 and request id msg-1, got %q and %q", Actor(ctx), RequestID(ctx))
+	}
+
+	ctx = WithMetadata(t.Context(), "", "msg-2")
+
+	if Actor(ctx) != "" || RequestID(ctx) != "msg-2" {
+		t.Errorf("expected no actor and request id msg-2, got %q and %q", Actor(ctx), RequestID(ctx))
+	}
+}
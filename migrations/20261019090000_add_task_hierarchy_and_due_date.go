@@ -0,0 +1,23 @@
+package migrations
+
+import (
+	"gofr.dev/pkg/gofr/migration"
+)
+
+const alterTasksAddHierarchy = `ALTER TABLE tasks
+    ADD COLUMN parent_id INT NULL,
+    ADD COLUMN due_date DATETIME NULL,
+    ADD CONSTRAINT fk_tasks_parent FOREIGN KEY (parent_id) REFERENCES tasks(id) ON DELETE CASCADE;`
+
+func addTaskHierarchyAndDueDate() migration.Migrate {
+	return migration.Migrate{
+		UP: func(d migration.Datasource) error {
+			_, err := d.SQL.Exec(alterTasksAddHierarchy)
+			if err != nil {
+				return err
+			}
+
+			return nil
+		},
+	}
+}
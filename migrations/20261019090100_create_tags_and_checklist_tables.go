@@ -0,0 +1,42 @@
+package migrations
+
+import (
+	"gofr.dev/pkg/gofr/migration"
+)
+
+const createTableTags = `CREATE TABLE IF NOT EXISTS tags (
+    id INT NOT NULL AUTO_INCREMENT PRIMARY KEY,
+    name VARCHAR(50) NOT NULL UNIQUE
+);`
+
+const createTableTaskTags = `CREATE TABLE IF NOT EXISTS task_tags (
+    task_id INT NOT NULL,
+    tag_id INT NOT NULL,
+    PRIMARY KEY (task_id, tag_id),
+    FOREIGN KEY (task_id) REFERENCES tasks(id) ON DELETE CASCADE,
+    FOREIGN KEY (tag_id) REFERENCES tags(id) ON DELETE CASCADE
+);`
+
+const createTableChecklistItems = `CREATE TABLE IF NOT EXISTS task_checklist_items (
+    id INT NOT NULL AUTO_INCREMENT PRIMARY KEY,
+    task_id INT NOT NULL,
+    position INT NOT NULL,
+    text VARCHAR(255) NOT NULL,
+    done TINYINT(1) NOT NULL DEFAULT 0,
+    FOREIGN KEY (task_id) REFERENCES tasks(id) ON DELETE CASCADE
+);`
+
+func createTagsAndChecklistTables() migration.Migrate {
+	return migration.Migrate{
+		UP: func(d migration.Datasource) error {
+			for _, query := range []string{createTableTags, createTableTaskTags, createTableChecklistItems} {
+				_, err := d.SQL.Exec(query)
+				if err != nil {
+					return err
+				}
+			}
+
+			return nil
+		},
+	}
+}
@@ -0,0 +1,24 @@
+package migrations
+
+import (
+	"gofr.dev/pkg/gofr/migration"
+)
+
+const createTableTaskTemplates = `CREATE TABLE IF NOT EXISTS task_templates (
+    id INT NOT NULL AUTO_INCREMENT PRIMARY KEY,
+    name VARCHAR(100) NOT NULL,
+    definition JSON NOT NULL
+);`
+
+func createTaskTemplatesTable() migration.Migrate {
+	return migration.Migrate{
+		UP: func(d migration.Datasource) error {
+			_, err := d.SQL.Exec(createTableTaskTemplates)
+			if err != nil {
+				return err
+			}
+
+			return nil
+		},
+	}
+}
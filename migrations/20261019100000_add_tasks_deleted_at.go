@@ -0,0 +1,22 @@
+package migrations
+
+import (
+	"gofr.dev/pkg/gofr/migration"
+)
+
+const alterTasksAddDeletedAt = `ALTER TABLE tasks
+    ADD COLUMN deleted_at DATETIME NULL,
+    ADD INDEX idx_tasks_deleted_at (deleted_at);`
+
+func addTasksDeletedAt() migration.Migrate {
+	return migration.Migrate{
+		UP: func(d migration.Datasource) error {
+			_, err := d.SQL.Exec(alterTasksAddDeletedAt)
+			if err != nil {
+				return err
+			}
+
+			return nil
+		},
+	}
+}
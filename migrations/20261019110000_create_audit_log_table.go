@@ -0,0 +1,39 @@
+package migrations
+
+import (
+	"gofr.dev/pkg/gofr/migration"
+)
+
+const createTableAuditLog = `CREATE TABLE IF NOT EXISTS audit_log (
+    id BIGINT NOT NULL AUTO_INCREMENT PRIMARY KEY,
+    entity VARCHAR(20) NOT NULL,
+    entity_id INT NOT NULL,
+    action VARCHAR(20) NOT NULL,
+    actor VARCHAR(50) NOT NULL DEFAULT '',
+    request_id VARCHAR(64) NOT NULL DEFAULT '',
+    changes JSON NOT NULL,
+    created_at DATETIME(6) NOT NULL,
+    INDEX idx_audit_log_entity (entity, entity_id)
+);`
+
+// The audit log is append-only; these triggers reject any attempt to rewrite history.
+const createTriggerAuditLogNoUpdate = `CREATE TRIGGER audit_log_no_update BEFORE UPDATE ON audit_log
+    FOR EACH ROW SIGNAL SQLSTATE '45000' SET MESSAGE_TEXT = 'audit_log is append-only';`
+
+const createTriggerAuditLogNoDelete = `CREATE TRIGGER audit_log_no_delete BEFORE DELETE ON audit_log
+    FOR EACH ROW SIGNAL SQLSTATE '45000' SET MESSAGE_TEXT = 'audit_log is append-only';`
+
+func createAuditLogTable() migration.Migrate {
+	return migration.Migrate{
+		UP: func(d migration.Datasource) error {
+			for _, query := range []string{createTableAuditLog, createTriggerAuditLogNoUpdate, createTriggerAuditLogNoDelete} {
+				_, err := d.SQL.Exec(query)
+				if err != nil {
+					return err
+				}
+			}
+
+			return nil
+		},
+	}
+}
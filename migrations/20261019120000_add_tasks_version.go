@@ -0,0 +1,20 @@
+package migrations
+
+import (
+	"gofr.dev/pkg/gofr/migration"
+)
+
+const alterTasksAddVersion = `ALTER TABLE tasks ADD COLUMN version INT NOT NULL DEFAULT 1;`
+
+func addTasksVersion() migration.Migrate {
+	return migration.Migrate{
+		UP: func(d migration.Datasource) error {
+			_, err := d.SQL.Exec(alterTasksAddVersion)
+			if err != nil {
+				return err
+			}
+
+			return nil
+		},
+	}
+}
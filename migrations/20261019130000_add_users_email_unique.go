@@ -0,0 +1,45 @@
+package migrations
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"gofr.dev/pkg/gofr/migration"
+)
+
+const normaliseUserEmails = `UPDATE users SET email = LOWER(TRIM(email)) WHERE email <> LOWER(TRIM(email));`
+
+const findDuplicateUserEmail = `SELECT email FROM users WHERE email IS NOT NULL GROUP BY email HAVING COUNT(*) > 1 LIMIT 1;`
+
+const alterUsersAddEmailUnique = `ALTER TABLE users ADD UNIQUE INDEX idx_users_email (email);`
+
+func addUsersEmailUnique() migration.Migrate {
+	return migration.Migrate{
+		UP: func(d migration.Datasource) error {
+			_, err := d.SQL.Exec(normaliseUserEmails)
+			if err != nil {
+				return err
+			}
+
+			// duplicates have to be merged by hand before the index can be created
+			var email string
+
+			err = d.SQL.QueryRow(findDuplicateUserEmail).Scan(&email)
+			if err == nil {
+				return fmt.Errorf("users share the email %q, merge them before adding the unique index", email)
+			}
+
+			if !errors.Is(err, sql.ErrNoRows) {
+				return err
+			}
+
+			_, err = d.SQL.Exec(alterUsersAddEmailUnique)
+			if err != nil {
+				return err
+			}
+
+			return nil
+		},
+	}
+}
@@ -0,0 +1,24 @@
+package migrations
+
+import (
+	"gofr.dev/pkg/gofr/migration"
+)
+
+// Tasks completed before this migration keep a NULL completed_at and are not
+// counted in completion windows.
+const alterTasksAddCompletedAt = `ALTER TABLE tasks
+    ADD COLUMN completed_at DATETIME NULL,
+    ADD INDEX idx_tasks_user_id (user_id, deleted_at);`
+
+func addTasksCompletedAt() migration.Migrate {
+	return migration.Migrate{
+		UP: func(d migration.Datasource) error {
+			_, err := d.SQL.Exec(alterTasksAddCompletedAt)
+			if err != nil {
+				return err
+			}
+
+			return nil
+		},
+	}
+}
@@ -0,0 +1,32 @@
+package migrations
+
+import (
+	"gofr.dev/pkg/gofr/migration"
+)
+
+// A row with a NULL status_code is a claim held by a request still in flight.
+const createTableIdempotencyKeys = `CREATE TABLE IF NOT EXISTS idempotency_keys (
+    actor VARCHAR(50) NOT NULL,
+    endpoint VARCHAR(64) NOT NULL,
+    idem_key VARCHAR(255) NOT NULL,
+    fingerprint CHAR(64) NOT NULL,
+    status_code SMALLINT NULL,
+    content_type VARCHAR(255) NULL,
+    body MEDIUMBLOB NULL,
+    expires_at DATETIME NOT NULL,
+    PRIMARY KEY (actor, endpoint, idem_key),
+    INDEX idx_idempotency_keys_expires_at (expires_at)
+);`
+
+func createIdempotencyKeysTable() migration.Migrate {
+	return migration.Migrate{
+		UP: func(d migration.Datasource) error {
+			_, err := d.SQL.Exec(createTableIdempotencyKeys)
+			if err != nil {
+				return err
+			}
+
+			return nil
+		},
+	}
+}
@@ -0,0 +1,33 @@
+package migrations
+
+import (
+	"gofr.dev/pkg/gofr/migration"
+)
+
+const createTableTaskComments = `CREATE TABLE IF NOT EXISTS task_comments (
+    id INT NOT NULL AUTO_INCREMENT PRIMARY KEY,
+    task_id INT NOT NULL,
+    author VARCHAR(50) NOT NULL DEFAULT '',
+    body TEXT NOT NULL,
+    created_at DATETIME NOT NULL,
+    INDEX idx_task_comments_task_id (task_id),
+    FULLTEXT INDEX ft_task_comments_body (body),
+    FOREIGN KEY (task_id) REFERENCES tasks(id) ON DELETE CASCADE
+);`
+
+const alterTasksAddFulltext = `ALTER TABLE tasks ADD FULLTEXT INDEX ft_tasks_description (description);`
+
+func createTaskCommentsAndFulltext() migration.Migrate {
+	return migration.Migrate{
+		UP: func(d migration.Datasource) error {
+			for _, query := range []string{createTableTaskComments, alterTasksAddFulltext} {
+				_, err := d.SQL.Exec(query)
+				if err != nil {
+					return err
+				}
+			}
+
+			return nil
+		},
+	}
+}
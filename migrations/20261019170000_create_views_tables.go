@@ -0,0 +1,45 @@
+package migrations
+
+import (
+	"gofr.dev/pkg/gofr/migration"
+)
+
+const createTableViews = `CREATE TABLE IF NOT EXISTS views (
+    id INT NOT NULL AUTO_INCREMENT PRIMARY KEY,
+    owner VARCHAR(50) NOT NULL,
+    name VARCHAR(100) NOT NULL,
+    visibility VARCHAR(10) NOT NULL,
+    filter TEXT NOT NULL,
+    sort JSON NOT NULL,
+    columns JSON NOT NULL,
+    group_by VARCHAR(20) NOT NULL DEFAULT '',
+    version INT NOT NULL DEFAULT 1,
+    updated_at DATETIME NOT NULL,
+    UNIQUE KEY uq_views_owner_name (owner, name),
+    INDEX idx_views_visibility (visibility)
+);`
+
+// view_tags has no foreign key to tags, so that a view outlives a deleted tag
+// and can report it instead of silently matching nothing.
+const createTableViewTags = `CREATE TABLE IF NOT EXISTS view_tags (
+    view_id INT NOT NULL,
+    tag_id INT NOT NULL,
+    name VARCHAR(50) NOT NULL,
+    PRIMARY KEY (view_id, tag_id),
+    FOREIGN KEY (view_id) REFERENCES views(id) ON DELETE CASCADE
+);`
+
+func createViewsTables() migration.Migrate {
+	return migration.Migrate{
+		UP: func(d migration.Datasource) error {
+			for _, query := range []string{createTableViews, createTableViewTags} {
+				_, err := d.SQL.Exec(query)
+				if err != nil {
+					return err
+				}
+			}
+
+			return nil
+		},
+	}
+}
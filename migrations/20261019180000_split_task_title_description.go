@@ -0,0 +1,104 @@
+package migrations
+
+import (
+	"encoding/json"
+
+	"gofr.dev/pkg/gofr/migration"
+)
+
+// The short description becomes the title, and description starts out empty
+// as a long-form Markdown text. The FULLTEXT index moves to cover both.
+const (
+	fillTasksDescription   = `UPDATE tasks SET description = '' WHERE description IS NULL;`
+	dropTasksFulltext      = `ALTER TABLE tasks DROP INDEX ft_tasks_description;`
+	renameTasksDescription = `ALTER TABLE tasks CHANGE COLUMN description title VARCHAR(150) NOT NULL;`
+	addTasksDescription    = `ALTER TABLE tasks ADD COLUMN description TEXT NOT NULL AFTER title;`
+	addTasksTextFulltext   = `ALTER TABLE tasks ADD FULLTEXT INDEX ft_tasks_text (title, description);`
+)
+
+func splitTaskTitleDescription() migration.Migrate {
+	return migration.Migrate{
+		UP: func(d migration.Datasource) error {
+			for _, query := range []string{
+				fillTasksDescription, dropTasksFulltext, renameTasksDescription, addTasksDescription, addTasksTextFulltext,
+			} {
+				_, err := d.SQL.Exec(query)
+				if err != nil {
+					return err
+				}
+			}
+
+			return renameTemplateDesc(d)
+		},
+	}
+}
+
+// renameTemplateDesc renames the desc of every task in the stored template
+// definitions to title.
+func renameTemplateDesc(d migration.Datasource) error {
+	rows, err := d.SQL.Query("SELECT id, definition FROM task_templates")
+	if err != nil {
+		return err
+	}
+
+	defer rows.Close()
+
+	definitions := make(map[int64][]byte)
+
+	for rows.Next() {
+		var (
+			id         int64
+			definition []byte
+		)
+
+		err = rows.Scan(&id, &definition)
+		if err != nil {
+			return err
+		}
+
+		definitions[id] = definition
+	}
+
+	if rows.Err() != nil {
+		return rows.Err()
+	}
+
+	for id, definition := range definitions {
+		var tasks []map[string]any
+
+		err = json.Unmarshal(definition, &tasks)
+		if err != nil {
+			return err
+		}
+
+		renameDesc(tasks)
+
+		definition, err = json.Marshal(tasks)
+		if err != nil {
+			return err
+		}
+
+		_, err = d.SQL.Exec("UPDATE task_templates SET definition = ? WHERE id = ?", definition, id)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func renameDesc(tasks []map[string]any) {
+	for _, t := range tasks {
+		if desc, ok := t["desc"]; ok {
+			t["title"] = desc
+			delete(t, "desc")
+		}
+
+		subtasks, _ := t["subtasks"].([]any)
+		for _, sub := range subtasks {
+			if m, ok := sub.(map[string]any); ok {
+				renameDesc([]map[string]any{m})
+			}
+		}
+	}
+}
@@ -0,0 +1,30 @@
+package migrations
+
+import (
+	"gofr.dev/pkg/gofr/migration"
+)
+
+// The relay scans unpublished messages by next_attempt_at, which it also
+// pushes forward to claim a message while publishing it.
+const createTableOutbox = `CREATE TABLE IF NOT EXISTS outbox (
+    id BIGINT NOT NULL AUTO_INCREMENT PRIMARY KEY,
+    event_id VARCHAR(32) NOT NULL UNIQUE,
+    event VARCHAR(50) NOT NULL,
+    payload JSON NOT NULL,
+    attempts INT NOT NULL DEFAULT 0,
+    next_attempt_at DATETIME NOT NULL,
+    last_error VARCHAR(500) NOT NULL DEFAULT '',
+    created_at DATETIME NOT NULL,
+    published_at DATETIME NULL,
+    INDEX idx_outbox_due (published_at, next_attempt_at)
+);`
+
+func createOutboxTable() migration.Migrate {
+	return migration.Migrate{
+		UP: func(d migration.Datasource) error {
+			_, err := d.SQL.Exec(createTableOutbox)
+
+			return err
+		},
+	}
+}
@@ -0,0 +1,23 @@
+package migrations
+
+import (
+	"gofr.dev/pkg/gofr/migration"
+)
+
+// processed_commands remembers the IDs of applied queue commands so that
+// redelivered ones are skipped.
+const createTableProcessedCommands = `CREATE TABLE IF NOT EXISTS processed_commands (
+    message_id VARCHAR(100) NOT NULL PRIMARY KEY,
+    processed_at DATETIME NOT NULL,
+    INDEX idx_processed_commands_at (processed_at)
+);`
+
+func createProcessedCommandsTable() migration.Migrate {
+	return migration.Migrate{
+		UP: func(d migration.Datasource) error {
+			_, err := d.SQL.Exec(createTableProcessedCommands)
+
+			return err
+		},
+	}
+}
@@ -0,0 +1,27 @@
+package migrations
+
+import (
+	"gofr.dev/pkg/gofr/migration"
+)
+
+// event_log holds the latest task events for the event stream. Each replica
+// polls it for new rows, and clients resume from it after reconnecting.
+const createTableEventLog = `CREATE TABLE IF NOT EXISTS event_log (
+    id BIGINT NOT NULL AUTO_INCREMENT PRIMARY KEY,
+    event VARCHAR(50) NOT NULL,
+    task_id INT NOT NULL,
+    user_id INT NOT NULL,
+    parent_id INT NULL,
+    payload JSON NOT NULL,
+    created_at DATETIME NOT NULL
+);`
+
+func createEventLogTable() migration.Migrate {
+	return migration.Migrate{
+		UP: func(d migration.Datasource) error {
+			_, err := d.SQL.Exec(createTableEventLog)
+
+			return err
+		},
+	}
+}
@@ -0,0 +1,51 @@
+package migrations
+
+import (
+	"gofr.dev/pkg/gofr/migration"
+)
+
+// The dispatcher scans pending reminders that are due, either at remind_at or
+// minutes_before_due before the due date of their task, and pushes
+// next_attempt_at forward to claim a reminder while sending it.
+const createTableReminders = `CREATE TABLE IF NOT EXISTS reminders (
+    id BIGINT NOT NULL AUTO_INCREMENT PRIMARY KEY,
+    task_id INT NOT NULL,
+    remind_at DATETIME NULL,
+    minutes_before_due INT NULL,
+    channel VARCHAR(20) NOT NULL,
+    status VARCHAR(10) NOT NULL,
+    attempts INT NOT NULL DEFAULT 0,
+    next_attempt_at DATETIME NULL,
+    last_error VARCHAR(500) NOT NULL DEFAULT '',
+    sent_at DATETIME NULL,
+    created_at DATETIME NOT NULL,
+    INDEX idx_reminders_status (status),
+    INDEX idx_reminders_task (task_id),
+    FOREIGN KEY (task_id) REFERENCES tasks(id) ON DELETE CASCADE
+);`
+
+const createTableNotifications = `CREATE TABLE IF NOT EXISTS notifications (
+    id BIGINT NOT NULL AUTO_INCREMENT PRIMARY KEY,
+    user_id INT NOT NULL,
+    task_id INT NOT NULL,
+    reminder_id BIGINT NOT NULL,
+    message VARCHAR(500) NOT NULL,
+    created_at DATETIME NOT NULL,
+    read_at DATETIME NULL,
+    INDEX idx_notifications_user (user_id, id)
+);`
+
+func createRemindersTables() migration.Migrate {
+	return migration.Migrate{
+		UP: func(d migration.Datasource) error {
+			for _, query := range []string{createTableReminders, createTableNotifications} {
+				_, err := d.SQL.Exec(query)
+				if err != nil {
+					return err
+				}
+			}
+
+			return nil
+		},
+	}
+}
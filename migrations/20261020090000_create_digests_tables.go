@@ -0,0 +1,53 @@
+package migrations
+
+import (
+	"gofr.dev/pkg/gofr/migration"
+)
+
+// Tasks created before this migration keep a NULL created_at and never count
+// as new assignments in a digest.
+const alterTasksAddCreatedAt = `ALTER TABLE tasks
+    ADD COLUMN created_at DATETIME NULL,
+    ADD INDEX idx_tasks_user_created (user_id, created_at);`
+
+const createTableDigestPreferences = `CREATE TABLE IF NOT EXISTS digest_preferences (
+    user_id INT NOT NULL PRIMARY KEY,
+    frequency VARCHAR(10) NOT NULL,
+    time_of_day CHAR(5) NOT NULL,
+    timezone VARCHAR(64) NOT NULL,
+    weekday VARCHAR(9) NOT NULL DEFAULT '',
+    updated_at DATETIME NOT NULL,
+    INDEX idx_digest_preferences_frequency (frequency)
+);`
+
+// A digest is claimed by inserting its row, which is unique per user and
+// period, so that only one replica sends it.
+const createTableDigests = `CREATE TABLE IF NOT EXISTS digests (
+    id BIGINT NOT NULL AUTO_INCREMENT PRIMARY KEY,
+    user_id INT NOT NULL,
+    frequency VARCHAR(10) NOT NULL,
+    period_start DATETIME NOT NULL,
+    period_end DATETIME NOT NULL,
+    status VARCHAR(10) NOT NULL,
+    attempts INT NOT NULL DEFAULT 0,
+    claimed_until DATETIME NOT NULL,
+    last_error VARCHAR(500) NOT NULL DEFAULT '',
+    sent_at DATETIME NULL,
+    created_at DATETIME NOT NULL,
+    UNIQUE KEY uq_digests_period (user_id, period_end)
+);`
+
+func createDigestsTables() migration.Migrate {
+	return migration.Migrate{
+		UP: func(d migration.Datasource) error {
+			for _, query := range []string{alterTasksAddCreatedAt, createTableDigestPreferences, createTableDigests} {
+				_, err := d.SQL.Exec(query)
+				if err != nil {
+					return err
+				}
+			}
+
+			return nil
+		},
+	}
+}
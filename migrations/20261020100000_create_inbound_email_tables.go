@@ -0,0 +1,49 @@
+package migrations
+
+import (
+	"gofr.dev/pkg/gofr/migration"
+)
+
+// inbound_emails maps the message IDs of received emails to the task or
+// comment they became, so that a redelivered email is ignored and a reply is
+// added to the task of the email it answers. message_id is NULL for emails
+// without one, which are never matched.
+const createTableInboundEmails = `CREATE TABLE IF NOT EXISTS inbound_emails (
+    id BIGINT NOT NULL AUTO_INCREMENT PRIMARY KEY,
+    message_id VARCHAR(255) NULL,
+    user_id INT NOT NULL,
+    task_id INT NOT NULL,
+    comment_id INT NULL,
+    created_at DATETIME NOT NULL,
+    UNIQUE KEY uq_inbound_emails_message (message_id),
+    INDEX idx_inbound_emails_task (task_id),
+    FOREIGN KEY (task_id) REFERENCES tasks(id) ON DELETE CASCADE
+);`
+
+const createTableAttachments = `CREATE TABLE IF NOT EXISTS attachments (
+    id BIGINT NOT NULL AUTO_INCREMENT PRIMARY KEY,
+    task_id INT NOT NULL,
+    comment_id INT NULL,
+    filename VARCHAR(255) NOT NULL,
+    content_type VARCHAR(255) NOT NULL,
+    size BIGINT NOT NULL,
+    content MEDIUMBLOB NOT NULL,
+    created_at DATETIME NOT NULL,
+    INDEX idx_attachments_task (task_id),
+    FOREIGN KEY (task_id) REFERENCES tasks(id) ON DELETE CASCADE
+);`
+
+func createInboundEmailTables() migration.Migrate {
+	return migration.Migrate{
+		UP: func(d migration.Datasource) error {
+			for _, query := range []string{createTableInboundEmails, createTableAttachments} {
+				_, err := d.SQL.Exec(query)
+				if err != nil {
+					return err
+				}
+			}
+
+			return nil
+		},
+	}
+}
@@ -0,0 +1,40 @@
+package migrations
+
+import (
+	"gofr.dev/pkg/gofr/migration"
+)
+
+// calendar_tokens holds the SHA-256 of each user's calendar feed token, so
+// that the tokens themselves are only known to their users.
+const createTableCalendarTokens = `CREATE TABLE IF NOT EXISTS calendar_tokens (
+    user_id INT NOT NULL PRIMARY KEY,
+    token_hash CHAR(64) NOT NULL,
+    created_at DATETIME NOT NULL,
+    UNIQUE KEY uq_calendar_tokens_hash (token_hash)
+);`
+
+// calendar_imports remembers the UIDs of imported calendar entries per user,
+// so that importing a calendar again skips the entries it already added.
+const createTableCalendarImports = `CREATE TABLE IF NOT EXISTS calendar_imports (
+    user_id INT NOT NULL,
+    uid VARCHAR(255) NOT NULL,
+    task_id INT NOT NULL,
+    created_at DATETIME NOT NULL,
+    PRIMARY KEY (user_id, uid),
+    FOREIGN KEY (task_id) REFERENCES tasks(id) ON DELETE CASCADE
+);`
+
+func createCalendarTables() migration.Migrate {
+	return migration.Migrate{
+		UP: func(d migration.Datasource) error {
+			for _, query := range []string{createTableCalendarTokens, createTableCalendarImports} {
+				_, err := d.SQL.Exec(query)
+				if err != nil {
+					return err
+				}
+			}
+
+			return nil
+		},
+	}
+}
@@ -0,0 +1,39 @@
+package migrations
+
+import (
+	"gofr.dev/pkg/gofr/migration"
+)
+
+// import_jobs holds the imports too large to run within their request, with
+// their data until they finish. Workers pick pending jobs, and jobs whose
+// worker died once claimed_until has passed.
+const createTableImportJobs = `CREATE TABLE IF NOT EXISTS import_jobs (
+    id BIGINT NOT NULL AUTO_INCREMENT PRIMARY KEY,
+    actor VARCHAR(255) NOT NULL DEFAULT '',
+    format VARCHAR(10) NOT NULL,
+    mapping JSON NULL,
+    dry_run BOOLEAN NOT NULL DEFAULT FALSE,
+    data MEDIUMBLOB NOT NULL,
+    status VARCHAR(20) NOT NULL,
+    total INT NOT NULL DEFAULT 0,
+    processed INT NOT NULL DEFAULT 0,
+    created INT NOT NULL DEFAULT 0,
+    errors JSON NULL,
+    error_count INT NOT NULL DEFAULT 0,
+    attempts INT NOT NULL DEFAULT 0,
+    claimed_until DATETIME NULL,
+    last_error VARCHAR(500) NOT NULL DEFAULT '',
+    created_at DATETIME NOT NULL,
+    finished_at DATETIME NULL,
+    INDEX idx_import_jobs_status (status, claimed_until)
+);`
+
+func createImportJobsTable() migration.Migrate {
+	return migration.Migrate{
+		UP: func(d migration.Datasource) error {
+			_, err := d.SQL.Exec(createTableImportJobs)
+
+			return err
+		},
+	}
+}
@@ -0,0 +1,33 @@
+package migrations
+
+import (
+	"gofr.dev/pkg/gofr/migration"
+)
+
+// view_members lists the users a shared view is shared with.
+const createTableViewMembers = `CREATE TABLE IF NOT EXISTS view_members (
+    view_id INT NOT NULL,
+    actor VARCHAR(50) NOT NULL,
+    PRIMARY KEY (view_id, actor),
+    INDEX idx_view_members_actor (actor),
+    FOREIGN KEY (view_id) REFERENCES views(id) ON DELETE CASCADE
+);`
+
+// Views shared before were visible to every user. They become private rather
+// than stay visible to everyone, and their owners choose whom to share them with.
+const unshareViews = `UPDATE views SET visibility = 'private', version = version + 1 WHERE visibility = 'shared';`
+
+func createViewMembersTable() migration.Migrate {
+	return migration.Migrate{
+		UP: func(d migration.Datasource) error {
+			for _, query := range []string{createTableViewMembers, unshareViews} {
+				_, err := d.SQL.Exec(query)
+				if err != nil {
+					return err
+				}
+			}
+
+			return nil
+		},
+	}
+}
@@ -0,0 +1,29 @@
+package migrations
+
+import (
+	"gofr.dev/pkg/gofr/migration"
+)
+
+// Replayed responses carry every header of the original one, such as ETag and
+// Location, rather than its Content-Type alone.
+const (
+	addIdempotencyHeaders  = `ALTER TABLE idempotency_keys ADD COLUMN headers JSON NULL AFTER status_code;`
+	copyIdempotencyHeaders = `UPDATE idempotency_keys SET headers = JSON_OBJECT('Content-Type', JSON_ARRAY(content_type))
+    WHERE content_type IS NOT NULL;`
+	dropIdempotencyContentType = `ALTER TABLE idempotency_keys DROP COLUMN content_type;`
+)
+
+func storeIdempotencyHeaders() migration.Migrate {
+	return migration.Migrate{
+		UP: func(d migration.Datasource) error {
+			for _, query := range []string{addIdempotencyHeaders, copyIdempotencyHeaders, dropIdempotencyContentType} {
+				_, err := d.SQL.Exec(query)
+				if err != nil {
+					return err
+				}
+			}
+
+			return nil
+		},
+	}
+}
@@ -0,0 +1,33 @@
+package migrations
+
+import (
+	"gofr.dev/pkg/gofr/migration"
+)
+
+// webhook_events indexes the events each webhook subscribes to, so recording
+// an event looks up its subscribers instead of reading every webhook.
+const (
+	createTableWebhookEvents = `CREATE TABLE IF NOT EXISTS webhook_events (
+    webhook_id INT NOT NULL,
+    event VARCHAR(50) NOT NULL,
+    PRIMARY KEY (event, webhook_id),
+    FOREIGN KEY (webhook_id) REFERENCES webhooks(id) ON DELETE CASCADE
+);`
+	copyWebhookEvents = `INSERT IGNORE INTO webhook_events (webhook_id, event)
+    SELECT w.id, e.event FROM webhooks w, JSON_TABLE(w.events, '$[*]' COLUMNS (event VARCHAR(50) PATH '$')) e;`
+)
+
+func createWebhookEventsTable() migration.Migrate {
+	return migration.Migrate{
+		UP: func(d migration.Datasource) error {
+			for _, query := range []string{createTableWebhookEvents, copyWebhookEvents} {
+				_, err := d.SQL.Exec(query)
+				if err != nil {
+					return err
+				}
+			}
+
+			return nil
+		},
+	}
+}
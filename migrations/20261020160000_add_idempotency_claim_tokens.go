@@ -0,0 +1,22 @@
+package migrations
+
+import (
+	"gofr.dev/pkg/gofr/migration"
+)
+
+// Each claim carries a token, so that a request whose claim expired and was
+// taken over by a retry cannot complete or release the retry's claim.
+const addIdempotencyClaimTokens = `ALTER TABLE idempotency_keys ADD COLUMN claim_token CHAR(32) NOT NULL DEFAULT '' AFTER fingerprint;`
+
+func addIdempotencyClaimTokenColumn() migration.Migrate {
+	return migration.Migrate{
+		UP: func(d migration.Datasource) error {
+			_, err := d.SQL.Exec(addIdempotencyClaimTokens)
+			if err != nil {
+				return err
+			}
+
+			return nil
+		},
+	}
+}
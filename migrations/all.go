@@ -9,5 +9,30 @@ func All() map[int64]migration.Migrate {
 	return map[int64]migration.Migrate{
 		20250702124827: createTasksTable(),
 		20250702124830: createUsersTable(),
+		20261019090000: addTaskHierarchyAndDueDate(),
+		20261019090100: createTagsAndChecklistTables(),
+		20261019090200: createTaskTemplatesTable(),
+		20261019100000: addTasksDeletedAt(),
+		20261019110000: createAuditLogTable(),
+		20261019120000: addTasksVersion(),
+		20261019130000: addUsersEmailUnique(),
+		20261019140000: addTasksCompletedAt(),
+		20261019150000: createIdempotencyKeysTable(),
+		20261019160000: createTaskCommentsAndFulltext(),
+		20261019170000: createViewsTables(),
+		20261019180000: splitTaskTitleDescription(),
+		20261019190000: createWebhooksTables(),
+		20261019200000: createOutboxTable(),
+		20261019210000: createProcessedCommandsTable(),
+		20261019220000: createEventLogTable(),
+		20261019230000: createRemindersTables(),
+		20261020090000: createDigestsTables(),
+		20261020100000: createInboundEmailTables(),
+		20261020110000: createCalendarTables(),
+		20261020120000: createImportJobsTable(),
+		20261020130000: createViewMembersTable(),
+		20261020140000: storeIdempotencyHeaders(),
+		20261020150000: createWebhookEventsTable(),
+		20261020160000: addIdempotencyClaimTokenColumn(),
 	}
 }
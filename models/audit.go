@@ -0,0 +1,21 @@
+package models
+
+import "time"
+
+// AuditEntry records a single create, update or delete of an entity.
+type AuditEntry struct {
+	ID        int64             `json:"id"`
+	Entity    string            `json:"entity"`
+	EntityID  int64             `json:"entity_id"`
+	Action    string            `json:"action"`
+	Actor     string            `json:"actor,omitempty"`
+	RequestID string            `json:"request_id,omitempty"`
+	Changes   map[string]Change `json:"changes,omitempty"`
+	CreatedAt time.Time         `json:"created_at"`
+}
+
+// Change holds the before and after value of a single field.
+type Change struct {
+	Before any `json:"before"`
+	After  any `json:"after"`
+}
@@ -0,0 +1,51 @@
+package models
+
+import (
+	"encoding/json"
+	"time"
+
+	"TaskManager2/apperr"
+)
+
+// Board message types. Clients send join, leave, move and typing. The server
+// sends presence, error, and the task events of the board under their event
+// name, such as task.updated.
+const (
+	BoardJoin     = "join"
+	BoardLeave    = "leave"
+	BoardMove     = "move"
+	BoardTyping   = "typing"
+	BoardPresence = "presence"
+	BoardError    = "error"
+)
+
+// BoardMessage is a message of the collaboration channel. A board shows the
+// subtasks of a parent task; board 0 shows the top-level tasks.
+//
+// Data is set by the client on a move, such as the column and position the
+// task is dragged to, and is relayed as is. Event is the task event, as sent
+// to webhooks.
+type BoardMessage struct {
+	Type    string              `json:"type"`
+	Board   int64               `json:"board"`
+	TaskID  int64               `json:"task_id,omitempty"`
+	User    string              `json:"user,omitempty"`
+	Users   []string            `json:"users,omitempty"`
+	Data    json.RawMessage     `json:"data,omitempty"`
+	Event   json.RawMessage     `json:"event,omitempty"`
+	Code    apperr.Code         `json:"code,omitempty"`
+	Error   string              `json:"error,omitempty"`
+	Details []apperr.FieldError `json:"details,omitempty"`
+}
+
+// BoardTokenRequest names the user a board token is issued to.
+type BoardTokenRequest struct {
+	User string `json:"user" validate:"required,max=50"`
+}
+
+// BoardToken lets a browser open the board channel as the user it was issued
+// to, by passing Token as the token query parameter of the upgrade request.
+type BoardToken struct {
+	Token     string    `json:"token"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
@@ -0,0 +1,62 @@
+package models
+
+import "TaskManager2/apperr"
+
+// Operations accepted by the bulk endpoint.
+const (
+	BulkCreate     = "create"
+	BulkUpdate     = "update"
+	BulkDelete     = "delete"
+	BulkTransition = "transition"
+)
+
+// Bulk modes. An atomic batch is applied only if every operation is valid; a
+// best-effort batch applies the valid operations and reports the others.
+const (
+	BulkAtomic     = "atomic"
+	BulkBestEffort = "best_effort"
+)
+
+// Outcomes reported for each operation of a bulk request.
+const (
+	BulkOK     = "ok"
+	BulkFailed = "failed"
+)
+
+type BulkRequest struct {
+	Mode       string
+	Operations []BulkOperation
+}
+
+// BulkOperation is a single entry of a bulk request. Task is set for creates
+// and Patch for updates and transitions. A non-zero Version makes updates,
+// transitions and deletes conditional on the task still being at that version.
+type BulkOperation struct {
+	Op      string
+	ID      int64
+	Version int64
+	Task    *Task
+	Patch   *TaskPatch
+}
+
+type BulkResponse struct {
+	Mode      string       `json:"mode"`
+	Succeeded int          `json:"succeeded"`
+	Failed    int          `json:"failed"`
+	Results   []BulkResult `json:"results"`
+}
+
+// BulkResult reports the outcome of the operation at Index in the request.
+type BulkResult struct {
+	Index  int        `json:"index"`
+	Op     string     `json:"op"`
+	ID     int64      `json:"id,omitempty"`
+	Status string     `json:"status"`
+	Error  *BulkError `json:"error,omitempty"`
+}
+
+type BulkError struct {
+	Code    apperr.Code         `json:"code"`
+	Message string              `json:"message"`
+	Details []apperr.FieldError `json:"details,omitempty"`
+}
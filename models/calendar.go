@@ -0,0 +1,20 @@
+package models
+
+import "time"
+
+// CalendarToken gives access to the calendar feed of a user at Path. Only its
+// hash is stored, so Token is only known when it is created.
+type CalendarToken struct {
+	UserID    int64     `json:"user_id"`
+	Token     string    `json:"token"`
+	Path      string    `json:"path"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// CalendarImport is the outcome of importing a calendar: the tasks created,
+// and the UIDs of the entries skipped as already imported or cancelled.
+type CalendarImport struct {
+	Created    []int64  `json:"created"`
+	Duplicates []string `json:"duplicates"`
+	Cancelled  []string `json:"cancelled"`
+}
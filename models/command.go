@@ -0,0 +1,45 @@
+package models
+
+import (
+	"time"
+
+	"TaskManager2/apperr"
+)
+
+// TaskCommandVersion is the only version of the command format understood.
+const TaskCommandVersion = 1
+
+// Task command types.
+const (
+	CommandTaskCreate   = "task.create"
+	CommandTaskUpdate   = "task.update"
+	CommandTaskComplete = "task.complete"
+)
+
+// TaskCommand asks for a change to a task through the message queue. ID is
+// chosen by the sender and makes redelivered commands apply only once. Create
+// carries Task, update carries TaskID and Patch, and complete only TaskID. A
+// non-zero TaskVersion makes an update or complete conditional on the task
+// still being at that version.
+type TaskCommand struct {
+	ID          string     `json:"id"`
+	Version     int        `json:"version"`
+	Type        string     `json:"type"`
+	Actor       string     `json:"actor,omitempty"`
+	TaskID      int64      `json:"task_id,omitempty"`
+	TaskVersion int64      `json:"task_version,omitempty"`
+	Task        *Task      `json:"task,omitempty"`
+	Patch       *TaskPatch `json:"patch,omitempty"`
+}
+
+// DeadLetter is a command that can never be applied, as sent to the
+// dead-letter topic with the reason it was rejected. Message is the command
+// exactly as received.
+type DeadLetter struct {
+	Topic    string              `json:"topic"`
+	Message  string              `json:"message"`
+	Code     apperr.Code         `json:"code"`
+	Reason   string              `json:"reason"`
+	Details  []apperr.FieldError `json:"details,omitempty"`
+	FailedAt time.Time           `json:"failed_at"`
+}
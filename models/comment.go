@@ -0,0 +1,12 @@
+package models
+
+import "time"
+
+// Comment is a note left on a task. Author is the actor who posted it.
+type Comment struct {
+	ID        int64     `json:"id"`
+	TaskID    int64     `json:"task_id"`
+	Author    string    `json:"author"`
+	Body      string    `json:"body" validate:"required,max=5000"`
+	CreatedAt time.Time `json:"created_at"`
+}
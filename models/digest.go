@@ -0,0 +1,66 @@
+package models
+
+import "time"
+
+// Digest frequencies. Users without a preference get no digest.
+const (
+	DigestOff    = "off"
+	DigestDaily  = "daily"
+	DigestWeekly = "weekly"
+)
+
+// Digest statuses. A pending digest is retried until it is sent or has used
+// up its attempts, after which it has failed.
+const (
+	DigestPending = "pending"
+	DigestSent    = "sent"
+	DigestFailed  = "failed"
+)
+
+// DigestPreference is when a user gets a digest email: every day, or every
+// week on Weekday, at TimeOfDay (HH:MM) in Timezone, an IANA time zone name.
+// LastDigest is the end of the period of the user's last digest that is no
+// longer pending.
+type DigestPreference struct {
+	UserID     int64      `json:"user_id"`
+	Frequency  string     `json:"frequency" validate:"required"`
+	TimeOfDay  string     `json:"time_of_day" validate:"required"`
+	Timezone   string     `json:"timezone" validate:"required"`
+	Weekday    string     `json:"weekday,omitempty"`
+	UpdatedAt  time.Time  `json:"updated_at"`
+	LastDigest *time.Time `json:"-"`
+}
+
+// Digest records a digest email of a user. It covers the period since the
+// previous scheduled digest, and PeriodEnd is when it was scheduled.
+type Digest struct {
+	ID           int64      `json:"id"`
+	UserID       int64      `json:"user_id"`
+	Frequency    string     `json:"frequency"`
+	PeriodStart  time.Time  `json:"period_start"`
+	PeriodEnd    time.Time  `json:"period_end"`
+	Status       string     `json:"status"`
+	Attempts     int        `json:"attempts"`
+	ClaimedUntil time.Time  `json:"-"`
+	LastError    string     `json:"last_error,omitempty"`
+	SentAt       *time.Time `json:"sent_at,omitempty"`
+	CreatedAt    time.Time  `json:"created_at"`
+}
+
+// DigestWindow selects the tasks of a digest: open tasks due in
+// [DueFrom, DueTo) and overdue before DueFrom, and tasks completed or
+// assigned in [From, To).
+type DigestWindow struct {
+	From    time.Time
+	To      time.Time
+	DueFrom time.Time
+	DueTo   time.Time
+}
+
+// DigestTasks are the tasks in a digest, by section.
+type DigestTasks struct {
+	Due       []Task
+	Overdue   []Task
+	Completed []Task
+	Assigned  []Task
+}
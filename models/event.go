@@ -0,0 +1,27 @@
+package models
+
+import "time"
+
+// Event types. task.completed is emitted in addition to task.updated when a
+// task moves from open to done. task.reminder carries a ReminderNotice.
+const (
+	EventTaskCreated   = "task.created"
+	EventTaskUpdated   = "task.updated"
+	EventTaskCompleted = "task.completed"
+	EventTaskDeleted   = "task.deleted"
+	EventTaskRestored  = "task.restored"
+	EventTaskReminder  = "task.reminder"
+	EventUserCreated   = "user.created"
+)
+
+// Event is a domain event as sent to webhooks and published to the message
+// broker. ID is unique per event and is kept across retries, replays and
+// transports, so receivers can drop duplicates.
+type Event struct {
+	ID         string    `json:"id"`
+	Name       string    `json:"event"`
+	OccurredAt time.Time `json:"occurred_at"`
+	Actor      string    `json:"actor,omitempty"`
+	RequestID  string    `json:"request_id,omitempty"`
+	Data       any       `json:"data"`
+}
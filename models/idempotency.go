@@ -0,0 +1,23 @@
+package models
+
+import (
+	"net/http"
+	"time"
+)
+
+// IdempotencyRecord keeps the response to a request sent with an
+// Idempotency-Key so that retries can be answered without running it again.
+// StatusCode is zero while the original request is still in flight, and Header
+// holds the headers the handler set on the response. ClaimToken identifies the
+// request holding the key.
+type IdempotencyRecord struct {
+	Actor       string
+	Endpoint    string
+	Key         string
+	Fingerprint string
+	ClaimToken  string
+	StatusCode  int
+	Header      http.Header
+	Body        []byte
+	ExpiresAt   time.Time
+}
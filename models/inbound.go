@@ -0,0 +1,28 @@
+package models
+
+import "time"
+
+// Attachment is a file attached to a task, or to one of its comments when
+// CommentID is set. Content is only loaded for downloads.
+type Attachment struct {
+	ID          int64     `json:"id"`
+	TaskID      int64     `json:"task_id"`
+	CommentID   *int64    `json:"comment_id,omitempty"`
+	Filename    string    `json:"filename"`
+	ContentType string    `json:"content_type"`
+	Size        int64     `json:"size"`
+	Content     []byte    `json:"-"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+// InboundEmail is an email received by the inbound endpoint. It became the
+// task TaskID, or a comment on it when CommentID is set.
+type InboundEmail struct {
+	ID          int64        `json:"id"`
+	MessageID   string       `json:"message_id,omitempty"`
+	UserID      int64        `json:"user_id"`
+	TaskID      int64        `json:"task_id"`
+	CommentID   *int64       `json:"comment_id,omitempty"`
+	Attachments []Attachment `json:"attachments,omitempty"`
+	CreatedAt   time.Time    `json:"created_at"`
+}
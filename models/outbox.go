@@ -0,0 +1,18 @@
+package models
+
+import "time"
+
+// OutboxMessage is an event written in the transaction of the change it
+// reports and waiting to be published to the message broker. PublishedAt is
+// nil until the broker has accepted it.
+type OutboxMessage struct {
+	ID            int64
+	EventID       string
+	Event         string
+	Payload       []byte
+	Attempts      int
+	NextAttemptAt time.Time
+	LastError     string
+	CreatedAt     time.Time
+	PublishedAt   *time.Time
+}
@@ -0,0 +1,55 @@
+package models
+
+import "time"
+
+// Reminder channels.
+const (
+	ChannelInApp   = "in_app"
+	ChannelEmail   = "email"
+	ChannelWebhook = "webhook"
+)
+
+// Reminder statuses. A pending reminder is retried until it is sent or has
+// used up its attempts, after which it has failed.
+const (
+	ReminderPending = "pending"
+	ReminderSent    = "sent"
+	ReminderFailed  = "failed"
+)
+
+// Reminder notifies the assignee of a task, either at RemindAt or
+// MinutesBeforeDue minutes before the due date of the task. A reminder
+// relative to the due date follows changes to it, and waits while the task
+// has none.
+type Reminder struct {
+	ID               int64      `json:"id"`
+	TaskID           int64      `json:"task_id"`
+	RemindAt         *time.Time `json:"remind_at,omitempty"`
+	MinutesBeforeDue *int       `json:"minutes_before_due,omitempty"`
+	Channel          string     `json:"channel" validate:"required"`
+	Status           string     `json:"status"`
+	Attempts         int        `json:"attempts"`
+	NextAttemptAt    *time.Time `json:"-"`
+	LastError        string     `json:"last_error,omitempty"`
+	SentAt           *time.Time `json:"sent_at,omitempty"`
+	CreatedAt        time.Time  `json:"created_at"`
+}
+
+// ReminderNotice is what a notifier sends for a due reminder: the reminder,
+// its task and the assignee of the task, who is notified.
+type ReminderNotice struct {
+	Reminder Reminder `json:"reminder"`
+	Task     Task     `json:"task"`
+	User     User     `json:"user"`
+}
+
+// Notification is an in-app notification of a user.
+type Notification struct {
+	ID         int64      `json:"id"`
+	UserID     int64      `json:"user_id"`
+	TaskID     int64      `json:"task_id"`
+	ReminderID int64      `json:"reminder_id"`
+	Message    string     `json:"message"`
+	CreatedAt  time.Time  `json:"created_at"`
+	ReadAt     *time.Time `json:"read_at,omitempty"`
+}
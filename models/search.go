@@ -0,0 +1,27 @@
+package models
+
+// Kinds of searchable documents.
+const (
+	SearchKindTask    = "task"
+	SearchKindComment = "comment"
+)
+
+// SearchDocument is a text that search can find. TaskID is the task the text
+// belongs to, which for a task document is its own ID.
+type SearchDocument struct {
+	Kind   string
+	ID     int64
+	TaskID int64
+	Text   string
+}
+
+// SearchHit is a document matching a query. Text holds the whole matched
+// text, from which Snippet is cut.
+type SearchHit struct {
+	Kind    string  `json:"kind"`
+	ID      int64   `json:"id"`
+	TaskID  int64   `json:"task_id"`
+	Score   float64 `json:"score"`
+	Snippet string  `json:"snippet"`
+	Text    string  `json:"-"`
+}
@@ -0,0 +1,43 @@
+package models
+
+import (
+	"strings"
+	"time"
+)
+
+// StreamEvent is an entry of the event log shared by the replicas: a task
+// event, or a board signal sent over the collaboration channel. ID orders the
+// log and is sent as the SSE event ID. Payload is the Event as JSON.
+type StreamEvent struct {
+	ID        int64
+	Event     string
+	TaskID    int64
+	UserID    int64
+	ParentID  *int64
+	Payload   []byte
+	CreatedAt time.Time
+}
+
+// StreamFilter selects the events whose type starts with Prefix, of the tasks
+// assigned to a user or under a parent task. Zero fields match every event.
+type StreamFilter struct {
+	Prefix   string
+	UserID   int64
+	ParentID int64
+}
+
+func (f StreamFilter) Matches(e *StreamEvent) bool {
+	if !strings.HasPrefix(e.Event, f.Prefix) {
+		return false
+	}
+
+	if f.UserID != 0 && e.UserID != f.UserID {
+		return false
+	}
+
+	if f.ParentID != 0 && (e.ParentID == nil || *e.ParentID != f.ParentID) {
+		return false
+	}
+
+	return true
+}
@@ -1,8 +1,138 @@
 package models
 
+import (
+	"bytes"
+	"encoding/json"
+	"sort"
+	"time"
+
+	"TaskManager2/apperr"
+	"TaskManager2/query"
+)
+
+// Task is a unit of work. Title is a short plain-text summary and
+// Description holds the details as Markdown.
 type Task struct {
-	ID     int64  `json:"id"`
-	Desc   string `json:"desc"`
-	Status bool   `json:"status"`
-	UserID int64  `json:"user_id"`
+	ID          int64           `json:"id"`
+	Title       string          `json:"title" validate:"required,max=150"`
+	Description string          `json:"description,omitempty" validate:"max=10000"`
+	Status      bool            `json:"status"`
+	UserID      int64           `json:"user_id"`
+	ParentID    *int64          `json:"parent_id,omitempty"`
+	DueDate     *time.Time      `json:"due_date,omitempty"`
+	Tags        []string        `json:"tags,omitempty" validate:"dive,required,max=50"`
+	Checklist   []ChecklistItem `json:"checklist,omitempty"`
+	Subtasks    []Task          `json:"subtasks,omitempty"`
+	Version     int64           `json:"version"`
+	DeletedAt   *time.Time      `json:"deleted_at,omitempty"`
+}
+
+// SearchText is the text of the task that search matches: the title followed
+// by the description.
+func (t *Task) SearchText() string {
+	if t.Description == "" {
+		return t.Title
+	}
+
+	return t.Title + "\n\n" + t.Description
+}
+
+// RenderedDescription is the description of a task as sanitized HTML.
+type RenderedDescription struct {
+	TaskID  int64  `json:"task_id"`
+	Version int64  `json:"version"`
+	HTML    string `json:"html"`
+}
+
+type ChecklistItem struct {
+	ID   int64  `json:"id,omitempty"`
+	Text string `json:"text" validate:"required,max=255"`
+	Done bool   `json:"done"`
+}
+
+// TaskPatch holds the fields set by a JSON merge patch. Nil fields are left
+// unchanged and ClearDueDate removes the due date. A non-zero Version makes
+// the patch conditional on the task still being at that version. ID is only
+// used by batched patches, which carry the target with each patch.
+type TaskPatch struct {
+	ID           int64      `json:"-"`
+	Title        *string    `json:"title" validate:"required,max=150"`
+	Description  *string    `json:"description" validate:"max=10000"`
+	Status       *bool      `json:"status"`
+	DueDate      *time.Time `json:"due_date"`
+	ClearDueDate bool       `json:"-"`
+	Version      int64      `json:"-"`
+}
+
+// Empty reports whether the patch changes no field.
+func (p *TaskPatch) Empty() bool {
+	return p.Title == nil && p.Description == nil && p.Status == nil && p.DueDate == nil && !p.ClearDueDate
+}
+
+// Decode sets the patch fields present in a JSON merge patch document and
+// checks their JSON types, reporting fields under prefix. A null description
+// or due_date removes it; title and status cannot be removed.
+func (p *TaskPatch) Decode(prefix string, fields map[string]json.RawMessage) []apperr.FieldError {
+	var invalid []apperr.FieldError
+
+	for name, value := range fields {
+		null := bytes.Equal(bytes.TrimSpace(value), []byte("null"))
+
+		switch name {
+		case "title":
+			if null || json.Unmarshal(value, &p.Title) != nil {
+				invalid = append(invalid, apperr.Field(prefix+name, "must be a string"))
+			}
+		case "description":
+			if null {
+				p.Description = new(string)
+			} else if json.Unmarshal(value, &p.Description) != nil {
+				invalid = append(invalid, apperr.Field(prefix+name, "must be a string or null"))
+			}
+		case "status":
+			if null || json.Unmarshal(value, &p.Status) != nil {
+				invalid = append(invalid, apperr.Field(prefix+name, "must be a boolean"))
+			}
+		case "due_date":
+			if null {
+				p.ClearDueDate = true
+			} else if json.Unmarshal(value, &p.DueDate) != nil {
+				invalid = append(invalid, apperr.Field(prefix+name, "must be an RFC 3339 date-time or null"))
+			}
+		default:
+			invalid = append(invalid, apperr.Field(prefix+name, "cannot be patched"))
+		}
+	}
+
+	sort.Slice(invalid, func(i, j int) bool { return invalid[i].Field < invalid[j].Field })
+
+	return invalid
+}
+
+// TaskFilter narrows a task listing. Nil and empty fields do not filter.
+// Query is a parsed filter expression that tasks must match as well. Sort
+// orders the listing, which is otherwise ordered by ID.
+type TaskFilter struct {
+	UserID    *int64
+	Status    *bool
+	Tag       string
+	DueBefore *time.Time
+	DueAfter  *time.Time
+	Query     query.Expr
+	Sort      []TaskSort
+}
+
+// TaskSort orders a listing by a filter field such as query.FieldDue.
+type TaskSort struct {
+	Field string
+	Desc  bool
+}
+
+// TaskSummary aggregates the tasks assigned to a user.
+type TaskSummary struct {
+	UserID              int64            `json:"user_id"`
+	ByStatus            map[string]int64 `json:"by_status"`
+	Overdue             int64            `json:"overdue"`
+	CompletedLast7Days  int64            `json:"completed_last_7_days"`
+	CompletedLast30Days int64            `json:"completed_last_30_days"`
 }
@@ -0,0 +1,28 @@
+package models
+
+import "time"
+
+// Template is a reusable tree of tasks. Text fields may contain placeholders
+// such as {{release_version}} that are filled in when the template is instantiated.
+type Template struct {
+	ID    int64          `json:"id"`
+	Name  string         `json:"name" validate:"required,max=100"`
+	Tasks []TemplateTask `json:"tasks" validate:"required"`
+}
+
+// TemplateTask describes a task to be created from a template. DueInDays is
+// relative to the start date given at instantiation.
+type TemplateTask struct {
+	Title       string         `json:"title" validate:"required,max=150"`
+	Description string         `json:"description,omitempty" validate:"max=10000"`
+	DueInDays   *int           `json:"due_in_days,omitempty"`
+	Tags        []string       `json:"tags,omitempty" validate:"dive,required,max=50"`
+	Checklist   []string       `json:"checklist,omitempty" validate:"dive,required,max=255"`
+	Subtasks    []TemplateTask `json:"subtasks,omitempty"`
+}
+
+type Instantiation struct {
+	UserID    int64             `json:"user_id"`
+	StartDate *time.Time        `json:"start_date,omitempty"`
+	Variables map[string]string `json:"variables,omitempty"`
+}
@@ -0,0 +1,65 @@
+package models
+
+import "time"
+
+// Import and export formats. NDJSON has one JSON object per line.
+const (
+	FormatCSV    = "csv"
+	FormatJSON   = "json"
+	FormatNDJSON = "ndjson"
+)
+
+// Import statuses. Jobs start pending, are validated and then imported, and
+// end up succeeded, or validated for dry runs. An import with invalid rows
+// is invalid and imports nothing, and a job that kept failing has failed.
+const (
+	ImportPending    = "pending"
+	ImportValidating = "validating"
+	ImportImporting  = "importing"
+	ImportSucceeded  = "succeeded"
+	ImportValidated  = "validated"
+	ImportInvalid    = "invalid"
+	ImportFailed     = "failed"
+)
+
+// ImportRequest imports the tasks in Data. Mapping maps the source columns,
+// or object keys, to task fields; without it, columns named like a field
+// are imported. A dry run only validates the rows.
+type ImportRequest struct {
+	Format  string            `json:"format" validate:"required"`
+	Data    string            `json:"data" validate:"required"`
+	Mapping map[string]string `json:"mapping,omitempty"`
+	DryRun  bool              `json:"dry_run"`
+}
+
+// ImportRowError explains why a field of a row was rejected. Rows are
+// numbered from 1, not counting the header of CSV data.
+type ImportRowError struct {
+	Row    int    `json:"row"`
+	Field  string `json:"field"`
+	Reason string `json:"reason"`
+}
+
+// ImportJob reports an import. Imports small enough to run within their
+// request have no ID. Processed counts the rows through the current status,
+// Errors holds the first of ErrorCount row errors, and LastError the reason
+// the latest attempt of a job failed.
+type ImportJob struct {
+	ID           int64             `json:"id,omitempty"`
+	Actor        string            `json:"-"`
+	Format       string            `json:"format"`
+	Mapping      map[string]string `json:"mapping,omitempty"`
+	DryRun       bool              `json:"dry_run"`
+	Data         []byte            `json:"-"`
+	Status       string            `json:"status"`
+	Total        int               `json:"total"`
+	Processed    int               `json:"processed"`
+	Created      int               `json:"created"`
+	Errors       []ImportRowError  `json:"errors"`
+	ErrorCount   int               `json:"error_count"`
+	Attempts     int               `json:"-"`
+	ClaimedUntil *time.Time        `json:"-"`
+	LastError    string            `json:"last_error,omitempty"`
+	CreatedAt    time.Time         `json:"created_at"`
+	FinishedAt   *time.Time        `json:"finished_at,omitempty"`
+}
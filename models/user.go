@@ -2,6 +2,6 @@ package models
 
 type User struct {
 	ID    int64  `json:"id"`
-	Name  string `json:"name"`
-	Email string `json:"email"`
+	Name  string `json:"name" validate:"required,max=50"`
+	Email string `json:"email" validate:"required,max=50,email"`
 }
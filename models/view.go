@@ -0,0 +1,50 @@
+package models
+
+import "time"
+
+// View visibilities. A shared view is visible to the team of users it is
+// shared with, while only its owner may change it.
+const (
+	ViewPrivate = "private"
+	ViewShared  = "shared"
+)
+
+// View is a saved task listing. Filter is an expression in the query package's
+// language, Sort lists filter fields, each prefixed with "-" to sort
+// descending, and Columns names the task fields to return. SharedWith lists
+// the users a shared view is shared with. Version counts the changes made to
+// the view.
+type View struct {
+	ID         int64     `json:"id"`
+	Owner      string    `json:"owner"`
+	Name       string    `json:"name" validate:"required,max=100"`
+	Visibility string    `json:"visibility"`
+	SharedWith []string  `json:"shared_with,omitempty" validate:"max=50,dive,required,max=50"`
+	Filter     string    `json:"filter,omitempty"`
+	Sort       []string  `json:"sort,omitempty" validate:"max=5"`
+	Columns    []string  `json:"columns,omitempty"`
+	GroupBy    string    `json:"group_by,omitempty"`
+	Version    int64     `json:"version"`
+	UpdatedAt  time.Time `json:"updated_at"`
+	Tags       []ViewTag `json:"-"`
+}
+
+// ViewTag pins a tag named in a view's filter by its ID, so that the filter
+// can follow the tag when it is renamed.
+type ViewTag struct {
+	ID   int64
+	Name string
+}
+
+// ViewResult holds the tasks of an executed view, reduced to its columns. An
+// ungrouped view has a single group with a null key.
+type ViewResult struct {
+	ViewID  int64       `json:"view_id"`
+	Version int64       `json:"version"`
+	Groups  []ViewGroup `json:"groups"`
+}
+
+type ViewGroup struct {
+	Key   any              `json:"key"`
+	Tasks []map[string]any `json:"tasks"`
+}
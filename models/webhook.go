@@ -0,0 +1,49 @@
+package models
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// Webhook delivery statuses. A pending delivery is retried until it is
+// delivered or has used up its attempts, after which it is dead.
+const (
+	DeliveryPending   = "pending"
+	DeliveryDelivered = "delivered"
+	DeliveryDead      = "dead"
+)
+
+// Webhook subscribes a URL to events. Secret signs the deliveries and is never
+// returned by the API.
+type Webhook struct {
+	ID        int64     `json:"id"`
+	URL       string    `json:"url" validate:"required,max=2000"`
+	Secret    string    `json:"secret,omitempty" validate:"max=200"`
+	Events    []string  `json:"events" validate:"required"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// WebhookDelivery is one event queued for a webhook, together with the outcome
+// of its latest attempt. Secret and URL are filled in for the delivery worker only.
+type WebhookDelivery struct {
+	ID            int64           `json:"id"`
+	WebhookID     int64           `json:"webhook_id"`
+	Event         string          `json:"event"`
+	Payload       json.RawMessage `json:"payload"`
+	Status        string          `json:"status"`
+	Attempts      int             `json:"attempts"`
+	NextAttemptAt *time.Time      `json:"next_attempt_at,omitempty"`
+	ResponseCode  int             `json:"response_code,omitempty"`
+	LastError     string          `json:"last_error,omitempty"`
+	CreatedAt     time.Time       `json:"created_at"`
+	DeliveredAt   *time.Time      `json:"delivered_at,omitempty"`
+	URL           string          `json:"-"`
+	Secret        string          `json:"-"`
+}
+
+// DeliveryFilter selects the deliveries of a webhook. Status is optional.
+type DeliveryFilter struct {
+	WebhookID int64
+	Status    string
+	Limit     int
+}
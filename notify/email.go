@@ -0,0 +1,28 @@
+package notify
+
+import (
+	"fmt"
+
+	"gofr.dev/pkg/gofr"
+
+	"TaskManager2/mail"
+	"TaskManager2/models"
+)
+
+type email struct {
+	mailer Mailer
+}
+
+func NewEmail(mailer Mailer) *email {
+	return &email{mailer: mailer}
+}
+
+// Notify emails the assignee. It cannot take part in a transaction, so an
+// email is sent again if recording that it was sent fails.
+func (e *email) Notify(ctx *gofr.Context, n *models.ReminderNotice) error {
+	return e.mailer.Send(ctx, &mail.Message{
+		To:      n.User.Email,
+		Subject: "Reminder: " + n.Task.Title,
+		Text:    fmt.Sprintf("Hi %s,\n\n%s.\n", n.User.Name, message(n)),
+	})
+}
@@ -0,0 +1,42 @@
+package notify
+
+import (
+	"errors"
+	"testing"
+
+	"go.uber.org/mock/gomock"
+	"gofr.dev/pkg/gofr"
+
+	"TaskManager2/mail"
+	"TaskManager2/models"
+	"TaskManager2/utils"
+)
+
+func TestEmail_Notify(t *testing.T) {
+	var ctx *gofr.Context
+
+	controller := gomock.NewController(t)
+	mockMailer := NewMockMailer(controller)
+	emailNotifier := NewEmail(mockMailer)
+
+	notice := &models.ReminderNotice{
+		Reminder: models.Reminder{ID: 3},
+		Task:     models.Task{ID: 9, Title: "Ship it"},
+		User:     models.User{ID: 1, Name: "Ada", Email: "ada@example.com"},
+	}
+
+	mockMailer.EXPECT().Send(ctx, &mail.Message{To: "ada@example.com", Subject: "Reminder: Ship it", Text: "Hi Ada,\n\nReminder: Ship it.\n"}).
+		Return(nil)
+
+	err := emailNotifier.Notify(ctx, notice)
+	if err != nil {
+		t.Error(err)
+	}
+
+	mockMailer.EXPECT().Send(ctx, gomock.Any()).Return(utils.ErrTest)
+
+	err = emailNotifier.Notify(ctx, notice)
+	if !errors.Is(err, utils.ErrTest) {
+		t.Errorf("expected %v, got %v", utils.ErrTest, err)
+	}
+}
@@ -0,0 +1,26 @@
+package notify
+
+import (
+	"time"
+
+	"gofr.dev/pkg/gofr"
+
+	"TaskManager2/models"
+)
+
+type inApp struct {
+	store NotificationStore
+}
+
+func NewInApp(store NotificationStore) *inApp {
+	return &inApp{store: store}
+}
+
+// Notify adds a notification for the assignee, within the transaction on ctx.
+func (a *inApp) Notify(ctx *gofr.Context, n *models.ReminderNotice) error {
+	_, err := a.store.Create(ctx, &models.Notification{
+		UserID: n.User.ID, TaskID: n.Task.ID, ReminderID: n.Reminder.ID, Message: message(n), CreatedAt: time.Now().UTC().Truncate(time.Second),
+	})
+
+	return err
+}
@@ -0,0 +1,46 @@
+package notify
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"go.uber.org/mock/gomock"
+	"gofr.dev/pkg/gofr"
+
+	"TaskManager2/models"
+	"TaskManager2/utils"
+)
+
+func TestInApp_Notify(t *testing.T) {
+	var ctx *gofr.Context
+
+	controller := gomock.NewController(t)
+	mockStore := NewMockNotificationStore(controller)
+	inAppNotifier := NewInApp(mockStore)
+
+	due := time.Date(2026, 10, 20, 9, 0, 0, 0, time.UTC)
+	notice := &models.ReminderNotice{
+		Reminder: models.Reminder{ID: 3}, Task: models.Task{ID: 9, Title: "Ship it", DueDate: &due}, User: models.User{ID: 1},
+	}
+
+	mockStore.EXPECT().Create(ctx, gomock.Any()).DoAndReturn(func(_ *gofr.Context, n *models.Notification) (int64, error) {
+		if n.UserID != 1 || n.TaskID != 9 || n.ReminderID != 3 || n.Message != "Reminder: Ship it is due Tue, 20 Oct 2026 09:00:00 UTC" {
+			t.Errorf("unexpected notification %+v", n)
+		}
+
+		return 5, nil
+	})
+
+	err := inAppNotifier.Notify(ctx, notice)
+	if err != nil {
+		t.Error(err)
+	}
+
+	mockStore.EXPECT().Create(ctx, gomock.Any()).Return(int64(0), utils.ErrTest)
+
+	err = inAppNotifier.Notify(ctx, notice)
+	if !errors.Is(err, utils.ErrTest) {
+		t.Errorf("expected %v, got %v", utils.ErrTest, err)
+	}
+}
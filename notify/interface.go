@@ -0,0 +1,20 @@
+package notify
+
+import (
+	"gofr.dev/pkg/gofr"
+
+	"TaskManager2/mail"
+	"TaskManager2/models"
+)
+
+type NotificationStore interface {
+	Create(*gofr.Context, *models.Notification) (int64, error)
+}
+
+type Events interface {
+	Emit(*gofr.Context, string, any) error
+}
+
+type Mailer interface {
+	Send(*gofr.Context, *mail.Message) error
+}
@@ -0,0 +1,18 @@
+// Package notify sends due reminders over the channels a reminder can use:
+// in-app notifications, email and webhooks.
+package notify
+
+import (
+	"time"
+
+	"TaskManager2/models"
+)
+
+// message is the text of a reminder.
+func message(n *models.ReminderNotice) string {
+	if n.Task.DueDate == nil {
+		return "Reminder: " + n.Task.Title
+	}
+
+	return "Reminder: " + n.Task.Title + " is due " + n.Task.DueDate.UTC().Format(time.RFC1123)
+}
@@ -0,0 +1,21 @@
+package notify
+
+import (
+	"gofr.dev/pkg/gofr"
+
+	"TaskManager2/models"
+)
+
+type webhook struct {
+	events Events
+}
+
+func NewWebhook(events Events) *webhook {
+	return &webhook{events: events}
+}
+
+// Notify emits a task.reminder event, which is delivered to the webhooks
+// subscribed to it like any other event.
+func (w *webhook) Notify(ctx *gofr.Context, n *models.ReminderNotice) error {
+	return w.events.Emit(ctx, models.EventTaskReminder, n)
+}
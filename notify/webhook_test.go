@@ -0,0 +1,36 @@
+package notify
+
+import (
+	"errors"
+	"testing"
+
+	"go.uber.org/mock/gomock"
+	"gofr.dev/pkg/gofr"
+
+	"TaskManager2/models"
+	"TaskManager2/utils"
+)
+
+func TestWebhook_Notify(t *testing.T) {
+	var ctx *gofr.Context
+
+	controller := gomock.NewController(t)
+	mockEvents := NewMockEvents(controller)
+	webhookNotifier := NewWebhook(mockEvents)
+
+	notice := &models.ReminderNotice{Reminder: models.Reminder{ID: 3}, Task: models.Task{ID: 9}, User: models.User{ID: 1}}
+
+	mockEvents.EXPECT().Emit(ctx, "task.reminder", notice).Return(nil)
+
+	err := webhookNotifier.Notify(ctx, notice)
+	if err != nil {
+		t.Error(err)
+	}
+
+	mockEvents.EXPECT().Emit(ctx, "task.reminder", notice).Return(utils.ErrTest)
+
+	err = webhookNotifier.Notify(ctx, notice)
+	if !errors.Is(err, utils.ErrTest) {
+		t.Errorf("expected %v, got %v", utils.ErrTest, err)
+	}
+}
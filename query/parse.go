@@ -0,0 +1,468 @@
+package query
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+const (
+	maxLength = 500
+	maxDepth  = 20
+	maxTagLen = 50
+	// maxOffset bounds relative times such as now+7d, in units of the offset.
+	maxOffset   = 10000
+	daysPerWeek = 7
+)
+
+// SyntaxError reports where and why a filter was rejected. Pos is the byte
+// offset into the filter, counting from 0.
+type SyntaxError struct {
+	Pos int
+	Msg string
+}
+
+func (e *SyntaxError) Error() string {
+	return fmt.Sprintf("%s at position %d", e.Msg, e.Pos)
+}
+
+// Parse parses a filter. The grammar is
+//
+//	expr       = and { "OR" and }
+//	and        = unary { "AND" unary }
+//	unary      = "NOT" unary | "(" expr ")" | comparison
+//	comparison = field op value
+//	op         = ":" | "=" | "!=" | "<" | "<=" | ">" | ">="
+//
+// Keywords are case-insensitive. A value is either a double-quoted string,
+// with \ escaping the next character, or runs up to the next space or
+// parenthesis. Times are resolved against now: due accepts now, now+7d or
+// now-12h (with h, d or w), a date meaning midnight UTC, or an RFC 3339
+// date-time.
+func Parse(s string, now time.Time) (Expr, error) {
+	if len(s) > maxLength {
+		return nil, syntaxErrorf(maxLength, "filter is longer than %d characters", maxLength)
+	}
+
+	p := parser{src: s, now: now}
+
+	return p.parse()
+}
+
+// RenameTags replaces the tags a filter compares against according to renames,
+// which maps old names to new ones. The rest of the filter is kept as written,
+// so relative times stay relative.
+func RenameTags(s string, renames map[string]string) (string, error) {
+	p := parser{src: s}
+
+	_, err := p.parse()
+	if err != nil {
+		return "", err
+	}
+
+	var (
+		b    strings.Builder
+		last int
+	)
+
+	for _, v := range p.tags {
+		name, ok := renames[v.value]
+		if !ok {
+			continue
+		}
+
+		b.WriteString(s[last:v.start])
+		b.WriteString(quote(name))
+
+		last = v.end
+	}
+
+	b.WriteString(s[last:])
+
+	return b.String(), nil
+}
+
+type parser struct {
+	src   string
+	pos   int
+	depth int
+	now   time.Time
+	// tags records where each tag value was found.
+	tags []span
+}
+
+type span struct {
+	start, end int
+	value      string
+}
+
+func (p *parser) parse() (Expr, error) {
+	e, err := p.or()
+	if err != nil {
+		return nil, err
+	}
+
+	p.skipSpace()
+
+	if p.pos < len(p.src) {
+		if p.src[p.pos] == ')' {
+			return nil, syntaxErrorf(p.pos, `unexpected ")"`)
+		}
+
+		return nil, syntaxErrorf(p.pos, "expected AND, OR or the end of the filter")
+	}
+
+	return e, nil
+}
+
+func syntaxErrorf(pos int, format string, args ...any) *SyntaxError {
+	return &SyntaxError{Pos: pos, Msg: fmt.Sprintf(format, args...)}
+}
+
+func (p *parser) or() (Expr, error) {
+	left, err := p.and()
+	if err != nil {
+		return nil, err
+	}
+
+	for p.keyword("OR") {
+		var right Expr
+
+		right, err = p.and()
+		if err != nil {
+			return nil, err
+		}
+
+		left = Or{Left: left, Right: right}
+	}
+
+	return left, nil
+}
+
+func (p *parser) and() (Expr, error) {
+	left, err := p.unary()
+	if err != nil {
+		return nil, err
+	}
+
+	for p.keyword("AND") {
+		var right Expr
+
+		right, err = p.unary()
+		if err != nil {
+			return nil, err
+		}
+
+		left = And{Left: left, Right: right}
+	}
+
+	return left, nil
+}
+
+func (p *parser) unary() (Expr, error) {
+	p.skipSpace()
+
+	start := p.pos
+	not := p.keyword("NOT")
+
+	if !not && p.peek() != '(' {
+		return p.comparison()
+	}
+
+	p.depth++
+	defer func() { p.depth-- }()
+
+	if p.depth > maxDepth {
+		return nil, syntaxErrorf(start, "filter is nested more than %d levels deep", maxDepth)
+	}
+
+	if not {
+		e, err := p.unary()
+		if err != nil {
+			return nil, err
+		}
+
+		return Not{Expr: e}, nil
+	}
+
+	p.pos++
+
+	e, err := p.or()
+	if err != nil {
+		return nil, err
+	}
+
+	p.skipSpace()
+
+	if p.peek() != ')' {
+		return nil, syntaxErrorf(p.pos, `expected ")" to close the "(" at position %d`, start)
+	}
+
+	p.pos++
+
+	return e, nil
+}
+
+func (p *parser) comparison() (Expr, error) {
+	start := p.pos
+	name := p.ident()
+
+	if name == "" {
+		if p.pos == len(p.src) {
+			return nil, syntaxErrorf(p.pos, "expected a condition")
+		}
+
+		return nil, syntaxErrorf(p.pos, "expected a field name")
+	}
+
+	f, ok := lookup(name)
+	if !ok {
+		return nil, syntaxErrorf(start, "unknown field %q", name)
+	}
+
+	p.skipSpace()
+
+	opPos := p.pos
+	op := p.op()
+
+	if op == "" {
+		return nil, syntaxErrorf(opPos, "expected an operator after %q", name)
+	}
+
+	if !strings.Contains(f.ops, " "+string(op)+" ") {
+		return nil, syntaxErrorf(opPos, "%s cannot use %q", name, op)
+	}
+
+	p.skipSpace()
+
+	valuePos := p.pos
+
+	raw, quoted, err := p.value()
+	if err != nil {
+		return nil, err
+	}
+
+	if !quoted && raw == "none" && f.nullable {
+		if op != OpMatch && op != OpEq && op != OpNe {
+			return nil, syntaxErrorf(opPos, "none cannot be compared with %q", op)
+		}
+
+		return Comparison{Field: name, Op: op, Value: nil}, nil
+	}
+
+	v, ok := f.parse(raw, p.now)
+	if !ok {
+		return nil, syntaxErrorf(valuePos, "invalid value %q for %s, expected %s", raw, name, f.expected)
+	}
+
+	if name == FieldTag {
+		p.tags = append(p.tags, span{start: valuePos, end: p.pos, value: raw})
+	}
+
+	return Comparison{Field: name, Op: op, Value: v}, nil
+}
+
+// keyword consumes the keyword if it comes next as a whole word.
+func (p *parser) keyword(kw string) bool {
+	p.skipSpace()
+
+	end := p.pos + len(kw)
+	if end > len(p.src) || !strings.EqualFold(p.src[p.pos:end], kw) || (end < len(p.src) && isIdent(p.src[end])) {
+		return false
+	}
+
+	p.pos = end
+
+	return true
+}
+
+func (p *parser) ident() string {
+	start := p.pos
+	for p.pos < len(p.src) && isIdent(p.src[p.pos]) {
+		p.pos++
+	}
+
+	return strings.ToLower(p.src[start:p.pos])
+}
+
+func (p *parser) op() Op {
+	for _, op := range []Op{OpNe, OpLe, OpGe, OpMatch, OpEq, OpLt, OpGt} {
+		if strings.HasPrefix(p.src[p.pos:], string(op)) {
+			p.pos += len(op)
+
+			return op
+		}
+	}
+
+	return ""
+}
+
+// value reads a quoted or bare value and reports whether it was quoted.
+func (p *parser) value() (string, bool, error) {
+	start := p.pos
+
+	if p.peek() != '"' {
+		for p.pos < len(p.src) && !isSpace(p.src[p.pos]) && p.src[p.pos] != '(' && p.src[p.pos] != ')' {
+			p.pos++
+		}
+
+		if p.pos == start {
+			return "", false, syntaxErrorf(p.pos, "expected a value")
+		}
+
+		return p.src[start:p.pos], false, nil
+	}
+
+	var b strings.Builder
+
+	for p.pos++; p.pos < len(p.src); p.pos++ {
+		c := p.src[p.pos]
+
+		switch {
+		case c == '"':
+			p.pos++
+
+			return b.String(), true, nil
+		case c == '\\' && p.pos+1 < len(p.src):
+			p.pos++
+			b.WriteByte(p.src[p.pos])
+		default:
+			b.WriteByte(c)
+		}
+	}
+
+	return "", false, syntaxErrorf(start, "unterminated quote")
+}
+
+func (p *parser) peek() byte {
+	if p.pos < len(p.src) {
+		return p.src[p.pos]
+	}
+
+	return 0
+}
+
+func (p *parser) skipSpace() {
+	for p.pos < len(p.src) && isSpace(p.src[p.pos]) {
+		p.pos++
+	}
+}
+
+func isSpace(c byte) bool {
+	return c == ' ' || c == '\t' || c == '\n' || c == '\r'
+}
+
+func isIdent(c byte) bool {
+	return c == '_' || 'a' <= c && c <= 'z' || 'A' <= c && c <= 'Z' || '0' <= c && c <= '9'
+}
+
+// field describes what a field accepts. ops lists the operators, each padded
+// with spaces.
+type field struct {
+	ops      string
+	nullable bool
+	expected string
+	parse    func(raw string, now time.Time) (any, bool)
+}
+
+func lookup(name string) (field, bool) {
+	const (
+		equality = " : = != "
+		ordered  = " : = != < <= > >= "
+	)
+
+	switch name {
+	case FieldID:
+		return field{ops: ordered, expected: "an integer", parse: parseInt}, true
+	case FieldUser:
+		return field{ops: equality, expected: "an integer", parse: parseInt}, true
+	case FieldParent:
+		return field{ops: equality, nullable: true, expected: "an integer or none", parse: parseInt}, true
+	case FieldStatus:
+		return field{ops: equality, expected: "open or done", parse: parseStatus}, true
+	case FieldTag:
+		return field{ops: equality, expected: fmt.Sprintf("at most %d characters", maxTagLen), parse: parseTag}, true
+	case FieldTitle, FieldDesc:
+		return field{ops: equality, expected: "text", parse: parseText}, true
+	case FieldDue:
+		return field{ops: ordered, nullable: true, expected: "now, now+7d, a date, an RFC 3339 date-time or none", parse: parseTime}, true
+	}
+
+	return field{}, false
+}
+
+func parseInt(raw string, _ time.Time) (any, bool) {
+	n, err := strconv.ParseInt(raw, 10, 64)
+
+	return n, err == nil
+}
+
+func parseStatus(raw string, _ time.Time) (any, bool) {
+	switch strings.ToLower(raw) {
+	case "open":
+		return false, true
+	case "done":
+		return true, true
+	}
+
+	return nil, false
+}
+
+func parseTag(raw string, _ time.Time) (any, bool) {
+	return raw, raw != "" && utf8.RuneCountInString(raw) <= maxTagLen
+}
+
+func parseText(raw string, _ time.Time) (any, bool) {
+	return raw, raw != ""
+}
+
+func parseTime(raw string, now time.Time) (any, bool) {
+	if t, err := time.Parse(time.RFC3339, raw); err == nil {
+		return t, true
+	}
+
+	if t, err := time.Parse(time.DateOnly, raw); err == nil {
+		return t, true
+	}
+
+	rest, ok := strings.CutPrefix(strings.ToLower(raw), "now")
+	if !ok {
+		return nil, false
+	}
+
+	if rest == "" {
+		return now, true
+	}
+
+	return relative(rest, now)
+}
+
+// relative applies an offset such as +7d or -12h to now.
+func relative(offset string, now time.Time) (any, bool) {
+	if len(offset) < len("+1d") || (offset[0] != '+' && offset[0] != '-') {
+		return nil, false
+	}
+
+	n, err := strconv.ParseUint(offset[1:len(offset)-1], 10, 32)
+	if err != nil || n > maxOffset {
+		return nil, false
+	}
+
+	units := int(n)
+	if offset[0] == '-' {
+		units = -units
+	}
+
+	switch offset[len(offset)-1] {
+	case 'h':
+		return now.Add(time.Duration(units) * time.Hour), true
+	case 'd':
+		return now.AddDate(0, 0, units), true
+	case 'w':
+		return now.AddDate(0, 0, daysPerWeek*units), true
+	}
+
+	return nil, false
+}
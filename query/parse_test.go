@@ -0,0 +1,262 @@
+package query
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParse(t *testing.T) {
+	now := time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		description string
+		input       string
+		want        Expr
+	}{
+		{
+			description: "single comparison",
+			input:       "status:open",
+			want:        Comparison{Field: FieldStatus, Op: OpMatch, Value: false},
+		},
+		{
+			description: "AND binds tighter than OR",
+			input:       "tag:bug OR tag:ops AND status=done",
+			want: Or{
+				Left: Comparison{Field: FieldTag, Op: OpMatch, Value: "bug"},
+				Right: And{
+					Left:  Comparison{Field: FieldTag, Op: OpMatch, Value: "ops"},
+					Right: Comparison{Field: FieldStatus, Op: OpEq, Value: true},
+				},
+			},
+		},
+		{
+			description: "parentheses, NOT and relative times",
+			input:       "status:open and (tag:bug or not due < now+7d)",
+			want: And{
+				Left: Comparison{Field: FieldStatus, Op: OpMatch, Value: false},
+				Right: Or{
+					Left:  Comparison{Field: FieldTag, Op: OpMatch, Value: "bug"},
+					Right: Not{Expr: Comparison{Field: FieldDue, Op: OpLt, Value: now.AddDate(0, 0, 7)}},
+				},
+			},
+		},
+		{
+			description: "absolute times",
+			input:       "due>=2026-10-01 AND due<2026-10-01T09:30:00Z",
+			want: And{
+				Left:  Comparison{Field: FieldDue, Op: OpGe, Value: time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC)},
+				Right: Comparison{Field: FieldDue, Op: OpLt, Value: time.Date(2026, 10, 1, 9, 30, 0, 0, time.UTC)},
+			},
+		},
+		{
+			description: "relative hours and weeks",
+			input:       "due>now-12h AND due<=NOW+2w",
+			want: And{
+				Left:  Comparison{Field: FieldDue, Op: OpGt, Value: now.Add(-12 * time.Hour)},
+				Right: Comparison{Field: FieldDue, Op: OpLe, Value: now.AddDate(0, 0, 14)},
+			},
+		},
+		{
+			description: "quoted values and none",
+			input:       `desc:"release \"notes\"" AND parent!=none AND user=7`,
+			want: And{
+				Left: And{
+					Left:  Comparison{Field: FieldDesc, Op: OpMatch, Value: `release "notes"`},
+					Right: Comparison{Field: FieldParent, Op: OpNe, Value: nil},
+				},
+				Right: Comparison{Field: FieldUser, Op: OpEq, Value: int64(7)},
+			},
+		},
+		{
+			description: "operators are left-associative",
+			input:       "id=1 AND id=2 AND id=3",
+			want: And{
+				Left: And{
+					Left:  Comparison{Field: FieldID, Op: OpEq, Value: int64(1)},
+					Right: Comparison{Field: FieldID, Op: OpEq, Value: int64(2)},
+				},
+				Right: Comparison{Field: FieldID, Op: OpEq, Value: int64(3)},
+			},
+		},
+		{
+			description: "a quoted none is a value",
+			input:       `tag:"none"`,
+			want:        Comparison{Field: FieldTag, Op: OpMatch, Value: "none"},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.description, func(t *testing.T) {
+			got, err := Parse(tc.input, now)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if !reflect.DeepEqual(got, tc.want) {
+				t.Errorf("expected %v, got %v", tc.want, got)
+			}
+		})
+	}
+}
+
+func TestParse_Errors(t *testing.T) {
+	tests := []struct {
+		input string
+		want  string
+	}{
+		{"", "expected a condition at position 0"},
+		{"status:open AND", "expected a condition at position 15"},
+		{"#", "expected a field name at position 0"},
+		{"priority>=high", `unknown field "priority" at position 0`},
+		{"status open", `expected an operator after "status" at position 7`},
+		{"tag<bug", `tag cannot use "<" at position 3`},
+		{"status:", "expected a value at position 7"},
+		{"status:maybe", `invalid value "maybe" for status, expected open or done at position 7`},
+		{"id=1.5", `invalid value "1.5" for id, expected an integer at position 3`},
+		{"due<now+7y", `invalid value "now+7y" for due, expected now, now+7d, a date, an RFC 3339 date-time or none at position 4`},
+		{"due<now++7d", `invalid value "now++7d" for due, expected now, now+7d, a date, an RFC 3339 date-time or none at position 4`},
+		{"due<none", `none cannot be compared with "<" at position 3`},
+		{`desc:"release notes`, "unterminated quote at position 5"},
+		{"(status:open", `expected ")" to close the "(" at position 0 at position 12`},
+		{"status:open)", `unexpected ")" at position 11`},
+		{"status:open tag:bug", "expected AND, OR or the end of the filter at position 12"},
+		{strings.Repeat("(", 21) + "id=1" + strings.Repeat(")", 21), "filter is nested more than 20 levels deep at position 20"},
+		{strings.Repeat("NOT ", 21) + "id=1", "filter is nested more than 20 levels deep at position 80"},
+		{strings.Repeat(" ", 501), "filter is longer than 500 characters at position 500"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.input, func(t *testing.T) {
+			_, err := Parse(tc.input, time.Now())
+
+			var syntaxErr *SyntaxError
+			if !errors.As(err, &syntaxErr) {
+				t.Fatalf("expected a syntax error, got %v", err)
+			}
+
+			if err.Error() != tc.want {
+				t.Errorf("expected %q, got %q", tc.want, err.Error())
+			}
+		})
+	}
+}
+
+// FuzzParse checks that the parser never panics, that errors point into the
+// input and that an accepted filter prints as a filter that parses and prints
+// the same way.
+func FuzzParse(f *testing.F) {
+	now := time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC)
+
+	for _, seed := range []string{
+		"status:open AND (tag:bug OR NOT due<now+7d)",
+		`desc:"a \"quoted\" \\ value" OR parent=none`,
+		"id>=10 AND user!=3 AND due>2026-10-01T09:30:00+05:30",
+		"((((id=1))))",
+		"NOT NOT status:done",
+		`tag:"unterminated`,
+		"due<now-0h",
+		strings.Repeat("id=1 AND ", 30) + "id=1",
+		"id=1 OR (id=2 OR id=3) AND NOT (id=4 AND id=5)",
+	} {
+		f.Add(seed)
+	}
+
+	f.Fuzz(func(t *testing.T, s string) {
+		e, err := Parse(s, now)
+		if err != nil {
+			var syntaxErr *SyntaxError
+			if !errors.As(err, &syntaxErr) || syntaxErr.Pos < 0 || syntaxErr.Pos > len(s) {
+				t.Fatalf("unexpected error %v for %q", err, s)
+			}
+
+			return
+		}
+
+		printed := e.String()
+
+		again, err := Parse(printed, now)
+		if err != nil {
+			if len(printed) > maxLength {
+				return
+			}
+
+			t.Fatalf("printed filter %q of %q does not parse: %v", printed, s, err)
+		}
+
+		if again.String() != printed {
+			t.Fatalf("printed filter %q of %q parses to %q", printed, s, again.String())
+		}
+	})
+}
+
+func TestExpr_String(t *testing.T) {
+	now := time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		input string
+		want  string
+	}{
+		{"status:open", "status:open"},
+		{"(tag:a OR tag:b) AND (tag:c OR (tag:d AND tag:e))", `(tag:"a" OR tag:"b") AND (tag:"c" OR tag:"d" AND tag:"e")`},
+		{"id=1 OR (id=2 OR id=3)", "id=1 OR (id=2 OR id=3)"},
+		{"((id=1 AND id=2)) AND NOT (id=3 AND id=4)", "id=1 AND id=2 AND NOT (id=3 AND id=4)"},
+		{"due<now+1d AND parent:none", "due<2026-10-20T12:00:00Z AND parent:none"},
+		{`desc:"say \"hi\" \\o/"`, `desc:"say \"hi\" \\o/"`},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.input, func(t *testing.T) {
+			e, err := Parse(tc.input, now)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if got := e.String(); got != tc.want {
+				t.Errorf("expected %s, got %s", tc.want, got)
+			}
+		})
+	}
+}
+
+func TestRenameTags(t *testing.T) {
+	tests := []struct {
+		input   string
+		renames map[string]string
+		want    string
+	}{
+		{"tag:bug AND due<now+7d", map[string]string{"bug": "defect"}, `tag:"defect" AND due<now+7d`},
+		{`tag:"bug" OR tag!=ops OR desc:bug`, map[string]string{"bug": "a \"b\"", "ops": "infra"}, `tag:"a \"b\"" OR tag!="infra" OR desc:bug`},
+		{"tag:bug", map[string]string{"ops": "infra"}, "tag:bug"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.input, func(t *testing.T) {
+			got, err := RenameTags(tc.input, tc.renames)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if got != tc.want {
+				t.Errorf("expected %s, got %s", tc.want, got)
+			}
+		})
+	}
+
+	if _, err := RenameTags("tag:", nil); err == nil {
+		t.Error("expected an error for an invalid filter")
+	}
+}
+
+func TestTags(t *testing.T) {
+	e, err := Parse("tag:bug AND (tag:ops OR NOT tag:bug) AND desc:tag", time.Now())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if got := Tags(e); !reflect.DeepEqual(got, []string{"bug", "ops"}) {
+		t.Errorf("expected [bug ops], got %v", got)
+	}
+}
@@ -0,0 +1,162 @@
+// Package query parses the filter language accepted by task listings, such as
+//
+//	status:open AND (tag:bug OR tag:urgent) AND due<now+7d
+//
+// into an Expr tree. Parsing checks field names, operators and values, so
+// stores only have to map each node to a fixed SQL fragment.
+package query
+
+import (
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+type Op string
+
+// OpMatch is the ":" operator. It means equality, except on title and desc
+// where it matches a substring.
+const (
+	OpMatch Op = ":"
+	OpEq    Op = "="
+	OpNe    Op = "!="
+	OpLt    Op = "<"
+	OpLe    Op = "<="
+	OpGt    Op = ">"
+	OpGe    Op = ">="
+)
+
+// Fields that can be filtered on.
+const (
+	FieldID     = "id"
+	FieldUser   = "user"
+	FieldParent = "parent"
+	FieldStatus = "status"
+	FieldTag    = "tag"
+	FieldTitle  = "title"
+	FieldDesc   = "desc"
+	FieldDue    = "due"
+)
+
+// Expr is And, Or, Not or Comparison. String renders it back into the
+// language, with absolute times and only the parentheses it needs.
+type Expr interface {
+	String() string
+}
+
+type And struct {
+	Left, Right Expr
+}
+
+type Or struct {
+	Left, Right Expr
+}
+
+type Not struct {
+	Expr Expr
+}
+
+// Comparison tests a field against a value. Value is an int64 for id, user and
+// parent, a bool for status (true means done), a string for tag, title and
+// desc and a time.Time for due. A nil Value stands for none, which parent and
+// due accept.
+type Comparison struct {
+	Field string
+	Op    Op
+	Value any
+}
+
+func (e And) String() string {
+	return operand(e.Left, precAnd) + " AND " + operand(e.Right, precAtom)
+}
+
+func (e Or) String() string {
+	return operand(e.Left, precOr) + " OR " + operand(e.Right, precAnd)
+}
+
+func (e Not) String() string {
+	return "NOT " + operand(e.Expr, precAtom)
+}
+
+// Binding strengths, from loosest to tightest.
+const (
+	precOr = iota
+	precAnd
+	precAtom
+)
+
+// operand renders e, parenthesised if it binds looser than minPrec. Both
+// operators are left-associative, so their right operands need one level more.
+func operand(e Expr, minPrec int) string {
+	prec := precAtom
+
+	switch e.(type) {
+	case Or:
+		prec = precOr
+	case And:
+		prec = precAnd
+	}
+
+	if prec < minPrec {
+		return "(" + e.String() + ")"
+	}
+
+	return e.String()
+}
+
+func (e Comparison) String() string {
+	var value string
+
+	switch v := e.Value.(type) {
+	case nil:
+		value = "none"
+	case int64:
+		value = strconv.FormatInt(v, 10)
+	case bool:
+		value = "open"
+		if v {
+			value = "done"
+		}
+	case time.Time:
+		value = v.Format(time.RFC3339Nano)
+	case string:
+		value = quote(v)
+	}
+
+	return e.Field + string(e.Op) + value
+}
+
+// Tags lists the distinct tags that e compares against, in order of appearance.
+func Tags(e Expr) []string {
+	var tags []string
+
+	var walk func(Expr)
+
+	walk = func(e Expr) {
+		switch e := e.(type) {
+		case And:
+			walk(e.Left)
+			walk(e.Right)
+		case Or:
+			walk(e.Left)
+			walk(e.Right)
+		case Not:
+			walk(e.Expr)
+		case Comparison:
+			if tag, ok := e.Value.(string); ok && e.Field == FieldTag && !slices.Contains(tags, tag) {
+				tags = append(tags, tag)
+			}
+		}
+	}
+
+	walk(e)
+
+	return tags
+}
+
+func quote(s string) string {
+	s = strings.ReplaceAll(s, `\`, `\\`)
+
+	return `"` + strings.ReplaceAll(s, `"`, `\"`) + `"`
+}
@@ -0,0 +1,182 @@
+package search
+
+import (
+	"math"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+
+	"gofr.dev/pkg/gofr"
+
+	"TaskManager2/models"
+)
+
+type docKey struct {
+	kind string
+	id   int64
+}
+
+// Memory is an inverted index kept in process memory, for tests. It only
+// knows the documents indexed since it was created, and keeps the changes
+// made in a transaction that is rolled back. Removing a task also hides its
+// comments until the task is indexed again. Memory is safe for concurrent use.
+type Memory struct {
+	mu       sync.RWMutex
+	docs     map[docKey]models.SearchDocument
+	postings map[string]map[docKey][]int
+	hidden   map[int64]bool
+}
+
+func NewMemory() *Memory {
+	return &Memory{
+		docs:     make(map[docKey]models.SearchDocument),
+		postings: make(map[string]map[docKey][]int),
+		hidden:   make(map[int64]bool),
+	}
+}
+
+// Index adds the document, replacing any earlier version of it.
+func (m *Memory) Index(_ *gofr.Context, doc models.SearchDocument) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	key := docKey{doc.Kind, doc.ID}
+	m.remove(key)
+	m.docs[key] = doc
+
+	for pos, t := range tokenize(doc.Text) {
+		if m.postings[t.word] == nil {
+			m.postings[t.word] = make(map[docKey][]int)
+		}
+
+		m.postings[t.word][key] = append(m.postings[t.word][key], pos)
+	}
+
+	if doc.Kind == models.SearchKindTask {
+		delete(m.hidden, doc.ID)
+	}
+
+	return nil
+}
+
+func (m *Memory) Remove(_ *gofr.Context, kind string, id int64) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.remove(docKey{kind, id})
+
+	if kind == models.SearchKindTask {
+		m.hidden[id] = true
+	}
+
+	return nil
+}
+
+func (m *Memory) remove(key docKey) {
+	doc, ok := m.docs[key]
+	if !ok {
+		return
+	}
+
+	for _, t := range tokenize(doc.Text) {
+		delete(m.postings[t.word], key)
+
+		if len(m.postings[t.word]) == 0 {
+			delete(m.postings, t.word)
+		}
+	}
+
+	delete(m.docs, key)
+}
+
+// Search returns up to limit documents matching every term, best first. A
+// document scores the sum, over the terms, of the term's occurrences weighted
+// by its inverse document frequency.
+func (m *Memory) Search(_ *gofr.Context, q *Query, limit int) ([]models.SearchHit, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	var scores map[docKey]float64
+
+	for i, t := range q.Terms {
+		counts := m.match(t)
+		idf := math.Log(1 + float64(len(m.docs))/float64(max(len(counts), 1)))
+		next := make(map[docKey]float64, len(counts))
+
+		for key, n := range counts {
+			if score, ok := scores[key]; ok || i == 0 {
+				next[key] = score + float64(n)*idf
+			}
+		}
+
+		scores = next
+	}
+
+	hits := make([]models.SearchHit, 0, len(scores))
+
+	for key, score := range scores {
+		doc := m.docs[key]
+		if m.hidden[doc.TaskID] {
+			continue
+		}
+
+		hits = append(hits, models.SearchHit{Kind: doc.Kind, ID: doc.ID, TaskID: doc.TaskID, Score: score, Text: doc.Text})
+	}
+
+	sort.Slice(hits, func(i, j int) bool {
+		if hits[i].Score != hits[j].Score {
+			return hits[i].Score > hits[j].Score
+		}
+
+		if hits[i].Kind != hits[j].Kind {
+			return hits[i].Kind > hits[j].Kind
+		}
+
+		return hits[i].ID < hits[j].ID
+	})
+
+	if len(hits) > limit {
+		hits = hits[:limit]
+	}
+
+	return hits, nil
+}
+
+// match counts the occurrences of the term in each document containing it.
+func (m *Memory) match(t Term) map[docKey]int {
+	counts := make(map[docKey]int)
+
+	if t.Prefix {
+		for word, docs := range m.postings {
+			if strings.HasPrefix(word, t.Words[0]) {
+				for key, positions := range docs {
+					counts[key] += len(positions)
+				}
+			}
+		}
+
+		return counts
+	}
+
+	for key, positions := range m.postings[t.Words[0]] {
+		for _, pos := range positions {
+			if m.followedBy(key, pos, t.Words[1:]) {
+				counts[key]++
+			}
+		}
+	}
+
+	return counts
+}
+
+// followedBy reports whether the words appear in the document right after pos.
+func (m *Memory) followedBy(key docKey, pos int, words []string) bool {
+	for i, w := range words {
+		if _, found := slices.BinarySearch(m.postings[w][key], pos+i+1); !found {
+			return false
+		}
+	}
+
+	return true
+}
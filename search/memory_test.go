@@ -0,0 +1,98 @@
+package search
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+
+	"TaskManager2/models"
+)
+
+func hitIDs(hits []models.SearchHit) []string {
+	ids := make([]string, 0, len(hits))
+	for _, h := range hits {
+		ids = append(ids, fmt.Sprintf("%s:%d", h.Kind, h.ID))
+	}
+
+	return ids
+}
+
+func TestMemory_Search(t *testing.T) {
+	m := NewMemory()
+
+	docs := []models.SearchDocument{
+		{Kind: models.SearchKindTask, ID: 1, TaskID: 1, Text: "Write release notes"},
+		{Kind: models.SearchKindTask, ID: 2, TaskID: 2, Text: "Notes on the release, release early"},
+		{Kind: models.SearchKindTask, ID: 3, TaskID: 3, Text: "Deploy to staging"},
+		{Kind: models.SearchKindComment, ID: 1, TaskID: 3, Text: "Release notes are ready for the deploy"},
+	}
+
+	for _, d := range docs {
+		if err := m.Index(nil, d); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	tests := []struct {
+		description string
+		query       string
+		limit       int
+		want        []string
+	}{
+		{description: "word ranked by occurrences", query: "release", limit: 10, want: []string{"task:2", "task:1", "comment:1"}},
+		{description: "phrase keeps word order", query: `"release notes"`, limit: 10, want: []string{"task:1", "comment:1"}},
+		{description: "prefix", query: "dep*", limit: 10, want: []string{"task:3", "comment:1"}},
+		{description: "every term must match", query: "release deploy", limit: 10, want: []string{"comment:1"}},
+		{description: "limit", query: "release", limit: 1, want: []string{"task:2"}},
+		{description: "no match", query: "missing", limit: 10, want: []string{}},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.description, func(t *testing.T) {
+			q, err := Parse(tc.query)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			hits, err := m.Search(nil, q, tc.limit)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if got := hitIDs(hits); !reflect.DeepEqual(got, tc.want) {
+				t.Errorf("expected %v, got %v", tc.want, got)
+			}
+		})
+	}
+}
+
+func TestMemory_Remove(t *testing.T) {
+	m := NewMemory()
+	task := models.SearchDocument{Kind: models.SearchKindTask, ID: 1, TaskID: 1, Text: "Deploy"}
+	comment := models.SearchDocument{Kind: models.SearchKindComment, ID: 1, TaskID: 1, Text: "Deploy soon"}
+
+	_ = m.Index(nil, task)
+	_ = m.Index(nil, comment)
+
+	q, _ := Parse("deploy")
+
+	if err := m.Remove(nil, models.SearchKindTask, 1); err != nil {
+		t.Fatal(err)
+	}
+
+	if hits, _ := m.Search(nil, q, 10); len(hits) != 0 {
+		t.Errorf("expected the task and its comments to be hidden, got %v", hitIDs(hits))
+	}
+
+	_ = m.Index(nil, task)
+
+	if hits, _ := m.Search(nil, q, 10); !reflect.DeepEqual(hitIDs(hits), []string{"task:1", "comment:1"}) {
+		t.Errorf("expected the restored task and its comment, got %v", hitIDs(hits))
+	}
+
+	_ = m.Index(nil, models.SearchDocument{Kind: models.SearchKindTask, ID: 1, TaskID: 1, Text: "Review"})
+
+	if hits, _ := m.Search(nil, q, 10); !reflect.DeepEqual(hitIDs(hits), []string{"comment:1"}) {
+		t.Errorf("expected reindexing to replace the old text, got %v", hitIDs(hits))
+	}
+}
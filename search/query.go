@@ -0,0 +1,171 @@
+// Package search parses full-text queries, highlights matches and provides an
+// in-process inverted index. A query is a list of terms that must all match:
+//
+//	word     the text contains the word
+//	word*    the text contains a word starting with "word"
+//	"a b c"  the text contains these words in this order
+//
+// Words are compared case-insensitively and split on anything that is not a
+// letter or a digit, so "follow-up" is the phrase "follow up".
+package search
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+
+	"TaskManager2/apperr"
+)
+
+const (
+	maxQueryLength = 200
+	maxTerms       = 10
+)
+
+// Term matches a single word, or a phrase when it has several Words. Prefix
+// is only ever set on single words.
+type Term struct {
+	Words  []string
+	Prefix bool
+}
+
+type Query struct {
+	Terms []Term
+}
+
+// Parse reads a query string, reporting problems as a validation error on q.
+func Parse(s string) (*Query, error) {
+	if len(s) > maxQueryLength {
+		return nil, invalid(fmt.Sprintf("must be at most %d characters", maxQueryLength))
+	}
+
+	var (
+		q    Query
+		rest = s
+	)
+
+	for {
+		rest = strings.TrimLeftFunc(rest, unicode.IsSpace)
+		if rest == "" {
+			break
+		}
+
+		var (
+			term  Term
+			token string
+		)
+
+		if rest[0] == '"' {
+			end := strings.IndexByte(rest[1:], '"')
+			if end < 0 {
+				return nil, invalid(fmt.Sprintf("has an unterminated quote at position %d", len(s)-len(rest)))
+			}
+
+			token, rest = rest[1:end+1], rest[end+2:]
+		} else {
+			end := strings.IndexFunc(rest, func(r rune) bool { return unicode.IsSpace(r) || r == '"' })
+			if end < 0 {
+				end = len(rest)
+			}
+
+			token, rest = rest[:end], rest[end:]
+			term.Prefix = strings.HasSuffix(token, "*")
+		}
+
+		for _, t := range tokenize(token) {
+			term.Words = append(term.Words, t.word)
+		}
+
+		switch {
+		case len(term.Words) == 0:
+			continue
+		case len(term.Words) > 1:
+			term.Prefix = false
+		}
+
+		q.Terms = append(q.Terms, term)
+	}
+
+	switch {
+	case len(q.Terms) == 0:
+		return nil, invalid("must contain at least one word")
+	case len(q.Terms) > maxTerms:
+		return nil, invalid(fmt.Sprintf("must have at most %d terms", maxTerms))
+	}
+
+	return &q, nil
+}
+
+func invalid(reason string) error {
+	return apperr.Validation(apperr.Field("q", reason))
+}
+
+// BooleanMode renders the query for MySQL's MATCH ... AGAINST (... IN BOOLEAN
+// MODE), where every term is required. Words only hold letters and digits, so
+// they cannot smuggle in boolean operators.
+func (q *Query) BooleanMode() string {
+	parts := make([]string, len(q.Terms))
+
+	for i, t := range q.Terms {
+		switch {
+		case len(t.Words) > 1:
+			parts[i] = `+"` + strings.Join(t.Words, " ") + `"`
+		case t.Prefix:
+			parts[i] = "+" + t.Words[0] + "*"
+		default:
+			parts[i] = "+" + t.Words[0]
+		}
+	}
+
+	return strings.Join(parts, " ")
+}
+
+// token is a lower-cased word of a text, with its byte offsets in the text.
+type token struct {
+	word       string
+	start, end int
+}
+
+func tokenize(text string) []token {
+	var (
+		tokens []token
+		start  = -1
+	)
+
+	for i, r := range text {
+		isWord := unicode.IsLetter(r) || unicode.IsDigit(r)
+
+		switch {
+		case isWord && start < 0:
+			start = i
+		case !isWord && start >= 0:
+			tokens = append(tokens, token{word: strings.ToLower(text[start:i]), start: start, end: i})
+			start = -1
+		}
+	}
+
+	if start >= 0 {
+		tokens = append(tokens, token{word: strings.ToLower(text[start:]), start: start, end: len(text)})
+	}
+
+	return tokens
+}
+
+// matches reports whether the words starting at tokens[i] match the term.
+func (t Term) matches(tokens []token, i int) bool {
+	if i+len(t.Words) > len(tokens) {
+		return false
+	}
+
+	if t.Prefix {
+		return strings.HasPrefix(tokens[i].word, t.Words[0])
+	}
+
+	for j, w := range t.Words {
+		if tokens[i+j].word != w {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,99 @@
+package search
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	"TaskManager2/apperr"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		description string
+		input       string
+		want        []Term
+		wantErr     error
+	}{
+		{
+			description: "words, prefixes and phrases",
+			input:       `Deploy rel* "release notes"`,
+			want: []Term{
+				{Words: []string{"deploy"}},
+				{Words: []string{"rel"}, Prefix: true},
+				{Words: []string{"release", "notes"}},
+			},
+		},
+		{
+			description: "punctuation splits words into a phrase",
+			input:       `follow-up`,
+			want:        []Term{{Words: []string{"follow", "up"}}},
+		},
+		{
+			description: "boolean operators are not special",
+			input:       `+urgent -done @ (x)`,
+			want:        []Term{{Words: []string{"urgent"}}, {Words: []string{"done"}}, {Words: []string{"x"}}},
+		},
+		{
+			description: "a star inside a phrase is ignored",
+			input:       `"release no*"`,
+			want:        []Term{{Words: []string{"release", "no"}}},
+		},
+		{
+			description: "empty query",
+			input:       `  * "" `,
+			wantErr:     apperr.Validation(apperr.Field("q", "must contain at least one word")),
+		},
+		{
+			description: "unterminated quote",
+			input:       `deploy "release notes`,
+			wantErr:     apperr.Validation(apperr.Field("q", "has an unterminated quote at position 7")),
+		},
+		{
+			description: "too many terms",
+			input:       strings.Repeat("a ", 11),
+			wantErr:     apperr.Validation(apperr.Field("q", "must have at most 10 terms")),
+		},
+		{
+			description: "too long",
+			input:       strings.Repeat("a", 201),
+			wantErr:     apperr.Validation(apperr.Field("q", "must be at most 200 characters")),
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.description, func(t *testing.T) {
+			q, err := Parse(tc.input)
+			if !errors.Is(err, tc.wantErr) {
+				t.Fatalf("expected error %v, got %v", tc.wantErr, err)
+			}
+
+			var fields []apperr.FieldError
+			if err != nil {
+				var appErr *apperr.Error
+				errors.As(err, &appErr)
+				fields = appErr.Fields
+			}
+
+			if tc.wantErr == nil && !reflect.DeepEqual(q.Terms, tc.want) {
+				t.Errorf("expected terms %+v, got %+v", tc.want, q.Terms)
+			}
+
+			if tc.wantErr != nil && len(fields) != 1 {
+				t.Errorf("expected one field error, got %v", fields)
+			}
+		})
+	}
+}
+
+func TestQuery_BooleanMode(t *testing.T) {
+	q, err := Parse(`Deploy rel* "release notes"`)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if got, want := q.BooleanMode(), `+deploy +rel* +"release notes"`; got != want {
+		t.Errorf("expected %s, got %s", want, got)
+	}
+}
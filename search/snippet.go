@@ -0,0 +1,85 @@
+package search
+
+import (
+	"html"
+	"strings"
+)
+
+const (
+	snippetLength = 160
+	// snippetLead is how much text is kept before the first match.
+	snippetLead = 40
+)
+
+// Snippet cuts about snippetLength bytes of text around the first match of q,
+// never splitting a word. The text is HTML-escaped and every matched word is
+// wrapped in <mark>, so the result can be inserted into a page as is. Text
+// cut off on either side is replaced by an ellipsis.
+func Snippet(text string, q *Query) string {
+	tokens := tokenize(text)
+	marked := make([]bool, len(tokens))
+	first := -1
+
+	for i := range tokens {
+		for _, t := range q.Terms {
+			if !t.matches(tokens, i) {
+				continue
+			}
+
+			for j := range t.Words {
+				marked[i+j] = true
+			}
+
+			if first < 0 {
+				first = i
+			}
+		}
+	}
+
+	start, end := 0, len(text)
+
+	if first >= 0 && tokens[first].start > snippetLead {
+		start = tokens[first].start
+		for i := first; i > 0 && tokens[first].start-tokens[i-1].start <= snippetLead; i-- {
+			start = tokens[i-1].start
+		}
+	}
+
+	if end-start > snippetLength {
+		end = start
+		for _, t := range tokens {
+			if t.start >= start && t.end-start <= snippetLength {
+				end = t.end
+			}
+		}
+	}
+
+	var b strings.Builder
+
+	if start > 0 {
+		b.WriteString("…")
+	}
+
+	pos := start
+
+	for i, t := range tokens {
+		if !marked[i] || t.start < start || t.end > end {
+			continue
+		}
+
+		b.WriteString(html.EscapeString(text[pos:t.start]))
+		b.WriteString("<mark>")
+		b.WriteString(html.EscapeString(text[t.start:t.end]))
+		b.WriteString("</mark>")
+
+		pos = t.end
+	}
+
+	b.WriteString(html.EscapeString(text[pos:end]))
+
+	if end < len(text) {
+		b.WriteString("…")
+	}
+
+	return b.String()
+}
@@ -0,0 +1,56 @@
+package search
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestSnippet(t *testing.T) {
+	long := strings.Repeat("lorem ipsum ", 10)
+
+	tests := []struct {
+		description string
+		text        string
+		query       string
+		want        string
+	}{
+		{
+			description: "marks words, prefixes and phrases",
+			text:        "Write the Release notes before deploying",
+			query:       `"release notes" deploy*`,
+			want:        "Write the <mark>Release</mark> <mark>notes</mark> before <mark>deploying</mark>",
+		},
+		{
+			description: "escapes html",
+			text:        `<script>alert("x")</script> deploy`,
+			query:       "deploy",
+			want:        `&lt;script&gt;alert(&#34;x&#34;)&lt;/script&gt; <mark>deploy</mark>`,
+		},
+		{
+			description: "cuts around the first match without splitting words",
+			text:        long + "deploy the service " + long,
+			query:       "deploy",
+			want: "…" + strings.Repeat("lorem ipsum ", 3) + "<mark>deploy</mark> the service " +
+				strings.Repeat("lorem ipsum ", 8) + "lorem…",
+		},
+		{
+			description: "no match keeps the start",
+			text:        long + long,
+			query:       "deploy",
+			want:        strings.Repeat("lorem ipsum ", 12) + "lorem ipsum…",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.description, func(t *testing.T) {
+			q, err := Parse(tc.query)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if got := Snippet(tc.text, q); got != tc.want {
+				t.Errorf("expected\n%q\ngot\n%q", tc.want, got)
+			}
+		})
+	}
+}
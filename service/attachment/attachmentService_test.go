@@ -0,0 +1,103 @@
+package attachment
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"go.uber.org/mock/gomock"
+	"gofr.dev/pkg/gofr"
+
+	"TaskManager2/apperr"
+	"TaskManager2/models"
+)
+
+func TestService_GetByTask(t *testing.T) {
+	var ctx *gofr.Context
+
+	controller := gomock.NewController(t)
+	mockStore := NewMockStore(controller)
+	mockTasks := NewMockTaskService(controller)
+	attachmentService := New(mockStore, mockTasks)
+
+	attachments := []models.Attachment{{ID: 2, TaskID: 9, Filename: "notes.txt"}}
+
+	mockTasks.EXPECT().GetByID(ctx, int64(9)).Return(&models.Task{ID: 9}, nil)
+	mockStore.EXPECT().GetByTask(ctx, int64(9)).Return(attachments, nil)
+
+	got, err := attachmentService.GetByTask(ctx, 9)
+	if err != nil || !reflect.DeepEqual(got, attachments) {
+		t.Errorf("expected %+v, got %+v, %v", attachments, got, err)
+	}
+
+	mockTasks.EXPECT().GetByID(ctx, int64(8)).Return(nil, apperr.NotFound("task", 8))
+
+	_, err = attachmentService.GetByTask(ctx, 8)
+	if !errors.Is(err, apperr.NotFound("task", 8)) {
+		t.Errorf("expected not found, got %v", err)
+	}
+}
+
+func TestService_Get(t *testing.T) {
+	var ctx *gofr.Context
+
+	controller := gomock.NewController(t)
+	mockStore := NewMockStore(controller)
+	mockTasks := NewMockTaskService(controller)
+	attachmentService := New(mockStore, mockTasks)
+
+	a := &models.Attachment{ID: 2, TaskID: 9, Filename: "notes.txt", Content: []byte("hello")}
+
+	testcases := []struct {
+		description   string
+		taskID        int64
+		mockExpect    func()
+		expected      *models.Attachment
+		expectedError error
+	}{
+		{
+			"of the task",
+			9,
+			func() {
+				mockTasks.EXPECT().GetByID(ctx, int64(9)).Return(&models.Task{ID: 9}, nil)
+				mockStore.EXPECT().GetByID(ctx, int64(2)).Return(a, nil)
+			},
+			a,
+			nil,
+		},
+		{
+			"of another task",
+			7,
+			func() {
+				mockTasks.EXPECT().GetByID(ctx, int64(7)).Return(&models.Task{ID: 7}, nil)
+				mockStore.EXPECT().GetByID(ctx, int64(2)).Return(a, nil)
+			},
+			nil,
+			apperr.NotFound("attachment", 2),
+		},
+		{
+			"unknown task",
+			8,
+			func() {
+				mockTasks.EXPECT().GetByID(ctx, int64(8)).Return(nil, apperr.NotFound("task", 8))
+			},
+			nil,
+			apperr.NotFound("task", 8),
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.description, func(t *testing.T) {
+			tc.mockExpect()
+
+			got, err := attachmentService.Get(ctx, tc.taskID, 2)
+			if !errors.Is(err, tc.expectedError) {
+				t.Fatalf("expected %v, got %v", tc.expectedError, err)
+			}
+
+			if !reflect.DeepEqual(got, tc.expected) {
+				t.Errorf("expected %+v, got %+v", tc.expected, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,16 @@
+package attachment
+
+import (
+	"gofr.dev/pkg/gofr"
+
+	"TaskManager2/models"
+)
+
+type Store interface {
+	GetByID(*gofr.Context, int64) (*models.Attachment, error)
+	GetByTask(*gofr.Context, int64) ([]models.Attachment, error)
+}
+
+type TaskService interface {
+	GetByID(*gofr.Context, int64) (*models.Task, error)
+}
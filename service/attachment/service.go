@@ -0,0 +1,46 @@
+package attachment
+
+import (
+	"gofr.dev/pkg/gofr"
+
+	"TaskManager2/apperr"
+	"TaskManager2/models"
+)
+
+type service struct {
+	store Store
+	tasks TaskService
+}
+
+func New(store Store, tasks TaskService) *service {
+	return &service{store: store, tasks: tasks}
+}
+
+// GetByTask lists the attachments of an existing task, without their content.
+func (s *service) GetByTask(ctx *gofr.Context, taskID int64) ([]models.Attachment, error) {
+	_, err := s.tasks.GetByID(ctx, taskID)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.store.GetByTask(ctx, taskID)
+}
+
+// Get returns an attachment of an existing task with its content.
+func (s *service) Get(ctx *gofr.Context, taskID, id int64) (*models.Attachment, error) {
+	_, err := s.tasks.GetByID(ctx, taskID)
+	if err != nil {
+		return nil, err
+	}
+
+	a, err := s.store.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if a.TaskID != taskID {
+		return nil, apperr.NotFound("attachment", id)
+	}
+
+	return a, nil
+}
@@ -0,0 +1,178 @@
+package calendar
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"go.uber.org/mock/gomock"
+	"gofr.dev/pkg/gofr"
+	"gofr.dev/pkg/gofr/container"
+
+	"TaskManager2/apperr"
+	"TaskManager2/models"
+	"TaskManager2/utils"
+)
+
+func TestService_Tokens(t *testing.T) {
+	var ctx *gofr.Context
+
+	controller := gomock.NewController(t)
+	mockStore := NewMockStore(controller)
+	mockUsers := NewMockUserService(controller)
+	calendarService := New(mockStore, mockUsers, NewMockTaskService(controller))
+
+	var saved string
+
+	mockUsers.EXPECT().GetByID(ctx, int64(1)).Return(&models.User{ID: 1}, nil).Times(2)
+	mockStore.EXPECT().SaveToken(ctx, int64(1), gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ *gofr.Context, _ int64, h string, _ time.Time) error {
+			saved = h
+
+			return nil
+		})
+
+	token, err := calendarService.CreateToken(ctx, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(token.Token) != 2*tokenBytes || token.Path != "/calendar/"+token.Token+".ics" || saved != hash(token.Token) {
+		t.Errorf("expected a new token stored as its hash, got %+v and %q", token, saved)
+	}
+
+	mockStore.EXPECT().DeleteToken(ctx, int64(1)).Return(nil)
+
+	err = calendarService.DeleteToken(ctx, 1)
+	if err != nil {
+		t.Error(err)
+	}
+
+	mockUsers.EXPECT().GetByID(ctx, int64(2)).Return(nil, apperr.NotFound("user", 2))
+
+	_, err = calendarService.CreateToken(ctx, 2)
+	if !errors.Is(err, apperr.NotFound("user", 2)) {
+		t.Errorf("expected not found, got %v", err)
+	}
+}
+
+func TestService_Feed(t *testing.T) {
+	var ctx *gofr.Context
+
+	controller := gomock.NewController(t)
+	mockStore := NewMockStore(controller)
+	mockUsers := NewMockUserService(controller)
+	mockTasks := NewMockTaskService(controller)
+	calendarService := New(mockStore, mockUsers, mockTasks)
+
+	due := time.Date(2026, 10, 21, 9, 30, 0, 0, time.UTC)
+
+	mockStore.EXPECT().GetUser(ctx, hash("secret")).Return(int64(1), nil)
+	mockUsers.EXPECT().GetByID(ctx, int64(1)).Return(&models.User{ID: 1, Name: "Ada"}, nil)
+	mockTasks.EXPECT().GetByUser(ctx, int64(1), &models.TaskFilter{}).Return([]models.Task{
+		{ID: 9, Title: "Ship it", Status: true, DueDate: &due, Version: 3},
+		{ID: 10, Title: "Someday"},
+	}, nil)
+
+	feed, err := calendarService.Feed(ctx, "secret", "VEVENT")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, want := range []string{"X-WR-CALNAME:Tasks of Ada\r\n", "UID:task-9@taskmanager\r\n", "SEQUENCE:3\r\n",
+		"DTSTART:20261021T093000Z\r\n", "TRANSP:TRANSPARENT\r\n"} {
+		if !strings.Contains(string(feed), want) {
+			t.Errorf("expected the feed to contain %q, got %q", want, feed)
+		}
+	}
+
+	if strings.Contains(string(feed), "Someday") || strings.Count(string(feed), "BEGIN:VEVENT") != 1 {
+		t.Errorf("expected only the task with a due date, got %q", feed)
+	}
+
+	mockStore.EXPECT().GetUser(ctx, hash("guess")).Return(int64(0), nil)
+
+	_, err = calendarService.Feed(ctx, "guess", "")
+	if !errors.Is(err, errFeedNotFound) {
+		t.Errorf("expected not found, got %v", err)
+	}
+
+	_, err = calendarService.Feed(ctx, "secret", "vjournal")
+	if !errors.Is(err, apperr.Validation(apperr.Field("component", "must be vtodo or vevent"))) {
+		t.Errorf("expected a validation error, got %v", err)
+	}
+}
+
+func TestService_Import(t *testing.T) {
+	mockContainer, mock := container.NewMockContainer(t)
+	ctx := &gofr.Context{
+		Context:   t.Context(),
+		Request:   nil,
+		Container: mockContainer,
+	}
+
+	controller := gomock.NewController(t)
+	mockStore := NewMockStore(controller)
+	mockUsers := NewMockUserService(controller)
+	mockTasks := NewMockTaskService(controller)
+	calendarService := New(mockStore, mockUsers, mockTasks)
+
+	due := time.Date(2026, 10, 21, 9, 30, 0, 0, time.UTC)
+	data := []byte("BEGIN:VCALENDAR\r\n" +
+		"BEGIN:VTODO\r\nUID:a\r\nSUMMARY:Write report\r\nDUE:20261021T093000Z\r\nSTATUS:COMPLETED\r\nEND:VTODO\r\n" +
+		"BEGIN:VTODO\r\nUID:a\r\nSUMMARY:Write report again\r\nEND:VTODO\r\n" +
+		"BEGIN:VEVENT\r\nUID:b\r\nSUMMARY:Known\r\nEND:VEVENT\r\n" +
+		"BEGIN:VEVENT\r\nUID:task-9@taskmanager\r\nSUMMARY:Exported\r\nEND:VEVENT\r\n" +
+		"BEGIN:VEVENT\r\nUID:c\r\nSUMMARY:Off\r\nSTATUS:CANCELLED\r\nEND:VEVENT\r\n" +
+		"BEGIN:VTODO\r\nSUMMARY:\r\nEND:VTODO\r\n" +
+		"END:VCALENDAR\r\n")
+
+	mockUsers.EXPECT().GetByID(ctx, int64(1)).Return(&models.User{ID: 1}, nil).Times(2)
+	mock.SQL.ExpectBegin()
+	mockStore.EXPECT().Imported(ctx, int64(1), []string{"a", "a", "b", "task-9@taskmanager", "c"}).
+		Return(map[string]bool{"b": true}, nil)
+	mockTasks.EXPECT().Create(ctx, &models.Task{Title: "Write report", Status: true, UserID: 1, DueDate: &due}).Return(int64(11), nil)
+	mockStore.EXPECT().RecordImport(ctx, int64(1), "a", int64(11), gomock.Any()).Return(nil)
+	mockTasks.EXPECT().Create(ctx, &models.Task{Title: noTitle, UserID: 1}).Return(int64(12), nil)
+	mock.SQL.ExpectCommit()
+
+	result, err := calendarService.Import(ctx, 1, data)
+
+	expected := &models.CalendarImport{
+		Created: []int64{11, 12}, Duplicates: []string{"a", "b", "task-9@taskmanager"}, Cancelled: []string{"c"},
+	}
+	if err != nil || !reflect.DeepEqual(result, expected) {
+		t.Errorf("expected %+v, got %+v, %v", expected, result, err)
+	}
+
+	mock.SQL.ExpectBegin()
+	mockStore.EXPECT().Imported(ctx, int64(1), []string{"a", "a", "b", "task-9@taskmanager", "c"}).Return(nil, utils.ErrTest)
+	mock.SQL.ExpectRollback()
+
+	_, err = calendarService.Import(ctx, 1, data)
+	if !errors.Is(err, utils.ErrTest) {
+		t.Errorf("expected %v, got %v", utils.ErrTest, err)
+	}
+
+	mockUsers.EXPECT().GetByID(ctx, int64(1)).Return(&models.User{ID: 1}, nil)
+
+	_, err = calendarService.Import(ctx, 1, []byte("BEGIN:VTODO\r\nUID:a\r\n"))
+	if apperr.CodeOf(err) != apperr.CodeValidation {
+		t.Errorf("expected a validation error, got %v", err)
+	}
+}
+
+func TestIsExported(t *testing.T) {
+	for uid, expected := range map[string]bool{
+		"task-9@taskmanager":  true,
+		"task-x@taskmanager":  false,
+		"task-9@example.com":  false,
+		"event-9@taskmanager": false,
+	} {
+		if got := isExported(uid); got != expected {
+			t.Errorf("expected %v for %q, got %v", expected, uid, got)
+		}
+	}
+}
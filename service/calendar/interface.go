@@ -0,0 +1,26 @@
+package calendar
+
+import (
+	"time"
+
+	"gofr.dev/pkg/gofr"
+
+	"TaskManager2/models"
+)
+
+type Store interface {
+	SaveToken(*gofr.Context, int64, string, time.Time) error
+	DeleteToken(*gofr.Context, int64) error
+	GetUser(*gofr.Context, string) (int64, error)
+	Imported(*gofr.Context, int64, []string) (map[string]bool, error)
+	RecordImport(*gofr.Context, int64, string, int64, time.Time) error
+}
+
+type UserService interface {
+	GetByID(*gofr.Context, int64) (*models.User, error)
+}
+
+type TaskService interface {
+	Create(*gofr.Context, *models.Task) (int64, error)
+	GetByUser(*gofr.Context, int64, *models.TaskFilter) ([]models.Task, error)
+}
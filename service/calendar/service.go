@@ -0,0 +1,281 @@
+package calendar
+
+import (
+	"bytes"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"gofr.dev/pkg/gofr"
+
+	"TaskManager2/apperr"
+	"TaskManager2/ical"
+	"TaskManager2/models"
+	"TaskManager2/utils"
+)
+
+const (
+	tokenBytes = 32
+
+	// uidDomain ends the UIDs of exported tasks, task-<id>@taskmanager, so
+	// that importing an exported calendar skips the tasks it came from.
+	uidDomain = "@taskmanager"
+
+	maxEntries   = 1000
+	maxUIDLength = 255
+
+	// The limits of the task model, which longer entries are truncated to.
+	maxTitleLength       = 150
+	maxDescriptionLength = 10000
+
+	noTitle = "(no title)"
+)
+
+var errFeedNotFound = apperr.NotFound("calendar", "for this token")
+
+type service struct {
+	store Store
+	users UserService
+	tasks TaskService
+}
+
+func New(store Store, users UserService, tasks TaskService) *service {
+	return &service{store: store, users: users, tasks: tasks}
+}
+
+// CreateToken gives the user a new calendar feed token, which stops the one
+// they had from working.
+func (s *service) CreateToken(ctx *gofr.Context, userID int64) (*models.CalendarToken, error) {
+	_, err := s.users.GetByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	b := make([]byte, tokenBytes)
+
+	_, err = rand.Read(b)
+	if err != nil {
+		return nil, err
+	}
+
+	t := models.CalendarToken{UserID: userID, Token: hex.EncodeToString(b), CreatedAt: time.Now().UTC().Truncate(time.Second)}
+	t.Path = "/calendar/" + t.Token + ".ics"
+
+	err = s.store.SaveToken(ctx, userID, hash(t.Token), t.CreatedAt)
+	if err != nil {
+		return nil, err
+	}
+
+	return &t, nil
+}
+
+func (s *service) DeleteToken(ctx *gofr.Context, userID int64) error {
+	_, err := s.users.GetByID(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	return s.store.DeleteToken(ctx, userID)
+}
+
+// Feed renders the tasks with a due date of the user with the token as an
+// iCalendar file, with each task as a to-do, or as an event when component
+// is vevent.
+func (s *service) Feed(ctx *gofr.Context, token, component string) ([]byte, error) {
+	kind, err := kindOf(component)
+	if err != nil {
+		return nil, err
+	}
+
+	userID, err := s.store.GetUser(ctx, hash(token))
+	if err != nil {
+		return nil, err
+	}
+
+	if userID == 0 {
+		return nil, errFeedNotFound
+	}
+
+	user, err := s.users.GetByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	tasks, err := s.tasks.GetByUser(ctx, userID, &models.TaskFilter{})
+	if err != nil {
+		return nil, err
+	}
+
+	var entries []ical.Entry
+
+	for _, t := range tasks {
+		if t.DueDate == nil {
+			continue
+		}
+
+		entries = append(entries, ical.Entry{
+			UID: fmt.Sprintf("task-%d%s", t.ID, uidDomain), Summary: t.Title, Description: t.Description, Due: t.DueDate,
+			Done: t.Status, Sequence: t.Version,
+		})
+	}
+
+	var b bytes.Buffer
+
+	err = ical.Write(&b, "Tasks of "+user.Name, kind, entries, time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	return b.Bytes(), nil
+}
+
+func kindOf(component string) (string, error) {
+	switch strings.ToLower(component) {
+	case "", "vtodo":
+		return ical.Todo, nil
+	case "vevent":
+		return ical.Event, nil
+	default:
+		return "", apperr.Validation(apperr.Field("component", "must be vtodo or vevent"))
+	}
+}
+
+// Import adds the to-dos and events of an iCalendar file as tasks of the
+// user, with the summary as title, the description and the due time, or the
+// start of events. Completed to-dos are added as done tasks, and cancelled
+// entries are skipped. Entries with a UID the user imported before, or that
+// of one of their exported tasks, are skipped as duplicates, and all others
+// are added in one transaction.
+func (s *service) Import(ctx *gofr.Context, userID int64, data []byte) (*models.CalendarImport, error) {
+	entries, err := s.parse(ctx, userID, data)
+	if err != nil {
+		return nil, err
+	}
+
+	uids := make([]string, 0, len(entries))
+	for _, e := range entries {
+		if e.UID != "" {
+			uids = append(uids, e.UID)
+		}
+	}
+
+	result := models.CalendarImport{Created: []int64{}, Duplicates: []string{}, Cancelled: []string{}}
+
+	err = utils.WithTx(ctx, func() error {
+		seen, err := s.store.Imported(ctx, userID, uids)
+		if err != nil {
+			return err
+		}
+
+		now := time.Now().UTC().Truncate(time.Second)
+
+		for _, e := range entries {
+			switch {
+			case e.Cancelled:
+				result.Cancelled = append(result.Cancelled, e.UID)
+
+				continue
+			case e.UID != "" && (seen[e.UID] || isExported(e.UID)):
+				result.Duplicates = append(result.Duplicates, e.UID)
+
+				continue
+			}
+
+			id, err := s.tasks.Create(ctx, task(userID, &e))
+			if err != nil {
+				return err
+			}
+
+			if e.UID != "" {
+				seen[e.UID] = true
+
+				err = s.store.RecordImport(ctx, userID, e.UID, id, now)
+				if err != nil {
+					return err
+				}
+			}
+
+			result.Created = append(result.Created, id)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &result, nil
+}
+
+// parse reads the entries of an import for an existing user.
+func (s *service) parse(ctx *gofr.Context, userID int64, data []byte) ([]ical.Entry, error) {
+	_, err := s.users.GetByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	entries, err := ical.Parse(bytes.NewReader(data))
+	if err != nil {
+		return nil, apperr.Validation(apperr.Field("calendar", "must be an iCalendar file: "+err.Error()))
+	}
+
+	if len(entries) > maxEntries {
+		return nil, apperr.Validation(apperr.Field("calendar", fmt.Sprintf("must have at most %d to-dos and events", maxEntries)))
+	}
+
+	for _, e := range entries {
+		if len(e.UID) > maxUIDLength {
+			return nil, apperr.Validation(apperr.Field("calendar", fmt.Sprintf("UIDs must be at most %d characters", maxUIDLength)))
+		}
+	}
+
+	return entries, nil
+}
+
+// isExported reports whether the UID is that of an exported task, which is
+// a duplicate whether it was exported for this user or another one.
+func isExported(uid string) bool {
+	id, ok := strings.CutSuffix(uid, uidDomain)
+	if !ok {
+		return false
+	}
+
+	id, ok = strings.CutPrefix(id, "task-")
+	if !ok {
+		return false
+	}
+
+	_, err := strconv.ParseInt(id, 10, 64)
+
+	return err == nil
+}
+
+func task(userID int64, e *ical.Entry) *models.Task {
+	t := &models.Task{
+		Title:       utils.Truncate(strings.TrimSpace(e.Summary), maxTitleLength),
+		Description: utils.Truncate(e.Description, maxDescriptionLength),
+		Status:      e.Done,
+		UserID:      userID,
+	}
+
+	if t.Title == "" {
+		t.Title = noTitle
+	}
+
+	if e.Due != nil {
+		due := e.Due.UTC()
+		t.DueDate = &due
+	}
+
+	return t
+}
+
+func hash(token string) string {
+	sum := sha256.Sum256([]byte(token))
+
+	return hex.EncodeToString(sum[:])
+}
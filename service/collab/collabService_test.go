@@ -0,0 +1,442 @@
+package collab
+
+import (
+	"encoding/json"
+	"errors"
+	"reflect"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"go.uber.org/mock/gomock"
+	"gofr.dev/pkg/gofr"
+	"gofr.dev/pkg/gofr/container"
+
+	"TaskManager2/apperr"
+	"TaskManager2/middleware"
+	"TaskManager2/models"
+	"TaskManager2/utils"
+)
+
+// inbox records the messages written to a connection.
+type inbox struct {
+	messages []models.BoardMessage
+}
+
+func (i *inbox) send(b []byte) error {
+	var msg models.BoardMessage
+
+	err := json.Unmarshal(b, &msg)
+	if err != nil {
+		return err
+	}
+
+	i.messages = append(i.messages, msg)
+
+	return nil
+}
+
+func newContext(t *testing.T, actor string) *gofr.Context {
+	t.Helper()
+
+	mockContainer, _ := container.NewMockContainer(t)
+
+	return &gofr.Context{
+		Context:   middleware.WithMetadata(t.Context(), actor, "r1"),
+		Request:   nil,
+		Container: mockContainer,
+	}
+}
+
+// signalled matches the board signal appended to the event log for msg.
+func signalled(t *testing.T, msg *models.BoardMessage) gomock.Matcher {
+	t.Helper()
+
+	return gomock.Cond(func(e *models.StreamEvent) bool {
+		var got models.BoardMessage
+
+		err := json.Unmarshal(e.Payload, &got)
+		if err != nil {
+			return false
+		}
+
+		return e.Event == "board."+msg.Type && e.TaskID == msg.TaskID && reflect.DeepEqual(&got, msg)
+	})
+}
+
+func TestService_Join(t *testing.T) {
+	controller := gomock.NewController(t)
+	mockStore := NewMockStore(controller)
+	mockHub := NewMockHub(controller)
+	mockTasks := NewMockTaskService(controller)
+	collabService := New(mockStore, mockHub, mockTasks, "")
+
+	ctx := newContext(t, "alice")
+	live := make(chan models.StreamEvent)
+
+	testcases := []struct {
+		description      string
+		ctx              *gofr.Context
+		board            int64
+		mockExpect       func()
+		expectedResponse *models.BoardMessage
+		expectedError    error
+	}{
+		{
+			"subscribe error",
+			ctx,
+			0,
+			func() {
+				mockStore.EXPECT().Append(ctx, gomock.Any()).Return(int64(1), nil)
+				mockHub.EXPECT().Subscribe(ctx, models.StreamFilter{}).Return(nil, nil, utils.ErrTest)
+			},
+			nil,
+			utils.ErrTest,
+		},
+		{
+			"success",
+			ctx,
+			7,
+			func() {
+				mockTasks.EXPECT().GetByID(ctx, int64(7)).Return(&models.Task{ID: 7}, nil)
+				mockStore.EXPECT().Append(ctx, signalled(t, &models.BoardMessage{Type: "join", Board: 7, User: "alice"})).
+					Return(int64(1), nil)
+				mockHub.EXPECT().Subscribe(ctx, models.StreamFilter{}).Return(live, func() {}, nil)
+			},
+			&models.BoardMessage{Type: "presence", Board: 7, Users: []string{"alice"}},
+			nil,
+		},
+		{
+			"no actor",
+			newContext(t, ""),
+			7,
+			func() {},
+			nil,
+			errNoActor,
+		},
+		{
+			"negative board",
+			ctx,
+			-1,
+			func() {},
+			nil,
+			errInvalidBoard,
+		},
+		{
+			"unknown board",
+			ctx,
+			8,
+			func() {
+				mockTasks.EXPECT().GetByID(ctx, int64(8)).Return(nil, apperr.NotFound("task", 8))
+			},
+			nil,
+			apperr.NotFound("task", 8),
+		},
+		{
+			"store error",
+			ctx,
+			0,
+			func() {
+				mockStore.EXPECT().Append(ctx, gomock.Any()).Return(int64(0), utils.ErrTest)
+			},
+			nil,
+			utils.ErrTest,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.description, func(t *testing.T) {
+			tc.mockExpect()
+
+			res, err := collabService.Join(tc.ctx, tc.description, func([]byte) error { return nil }, tc.board)
+			if !errors.Is(err, tc.expectedError) {
+				t.Errorf("error, expected %v, got %v", tc.expectedError, err)
+			}
+
+			if !reflect.DeepEqual(res, tc.expectedResponse) {
+				t.Errorf("expected: %+v, got: %+v", tc.expectedResponse, res)
+			}
+		})
+	}
+}
+
+func TestService_Relay(t *testing.T) {
+	controller := gomock.NewController(t)
+	mockStore := NewMockStore(controller)
+	mockHub := NewMockHub(controller)
+	collabService := New(mockStore, mockHub, NewMockTaskService(controller), "")
+
+	ctx := newContext(t, "alice")
+	data := json.RawMessage(`{"column":"done","position":2}`)
+
+	err := collabService.Relay(ctx, "c1", &models.BoardMessage{Type: "move", TaskID: 3})
+	if !errors.Is(err, errNotJoined) {
+		t.Errorf("expected %v, got %v", errNotJoined, err)
+	}
+
+	mockStore.EXPECT().Append(ctx, gomock.Any()).Return(int64(1), nil)
+	mockHub.EXPECT().Subscribe(gomock.Any(), models.StreamFilter{}).Return(make(chan models.StreamEvent), func() {}, nil)
+
+	_, err = collabService.Join(ctx, "c1", func([]byte) error { return nil }, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = collabService.Relay(ctx, "c1", &models.BoardMessage{Type: "move"})
+	if !errors.Is(err, errMissingTaskID) {
+		t.Errorf("expected %v, got %v", errMissingTaskID, err)
+	}
+
+	// The board and user come from the connection, not the message.
+	mockStore.EXPECT().Append(ctx, signalled(t, &models.BoardMessage{Type: "move", TaskID: 3, User: "alice", Data: data})).
+		Return(int64(2), nil)
+
+	err = collabService.Relay(ctx, "c1", &models.BoardMessage{Type: "move", Board: 9, TaskID: 3, User: "bob", Data: data})
+	if err != nil {
+		t.Error(err)
+	}
+
+	// Typing on the same task again within typingInterval is not relayed.
+	mockStore.EXPECT().Append(ctx, signalled(t, &models.BoardMessage{Type: "typing", TaskID: 3, User: "alice"})).
+		Return(int64(3), nil)
+	mockStore.EXPECT().Append(ctx, signalled(t, &models.BoardMessage{Type: "typing", TaskID: 4, User: "alice"})).
+		Return(int64(4), nil)
+
+	for _, taskID := range []int64{3, 3, 4} {
+		err = collabService.Relay(ctx, "c1", &models.BoardMessage{Type: "typing", TaskID: taskID})
+		if err != nil {
+			t.Error(err)
+		}
+	}
+}
+
+func TestService_Leave(t *testing.T) {
+	controller := gomock.NewController(t)
+	mockStore := NewMockStore(controller)
+	mockHub := NewMockHub(controller)
+	collabService := New(mockStore, mockHub, NewMockTaskService(controller), "")
+
+	ctx := newContext(t, "alice")
+	cancelled := false
+
+	mockStore.EXPECT().Append(ctx, gomock.Any()).Return(int64(1), nil).Times(2)
+	mockHub.EXPECT().Subscribe(gomock.Any(), models.StreamFilter{}).Return(make(chan models.StreamEvent), func() { cancelled = true }, nil)
+
+	for _, conn := range []string{"c1", "c2"} {
+		_, err := collabService.Join(ctx, conn, func([]byte) error { return nil }, 0)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// Alice is still on the board through c2.
+	err := collabService.Leave(ctx, "c1")
+	if err != nil || cancelled {
+		t.Errorf("expected alice to stay, got %v, cancelled %v", err, cancelled)
+	}
+
+	mockStore.EXPECT().Append(ctx, signalled(t, &models.BoardMessage{Type: "leave", User: "alice"})).Return(int64(2), nil)
+
+	err = collabService.Leave(ctx, "c2")
+	if err != nil || !cancelled {
+		t.Errorf("expected alice to leave and the subscription to end, got %v, cancelled %v", err, cancelled)
+	}
+
+	err = collabService.Leave(ctx, "c3")
+	if err != nil {
+		t.Errorf("expected leaving without joining to be ignored, got %v", err)
+	}
+}
+
+func TestService_Deliver(t *testing.T) {
+	controller := gomock.NewController(t)
+	mockStore := NewMockStore(controller)
+	mockHub := NewMockHub(controller)
+	mockTasks := NewMockTaskService(controller)
+	collabService := New(mockStore, mockHub, mockTasks, "")
+
+	alice, bob := newContext(t, "alice"), newContext(t, "bob")
+	aliceInbox, bobInbox := &inbox{}, &inbox{}
+	board := int64(7)
+
+	mockTasks.EXPECT().GetByID(alice, board).Return(&models.Task{ID: board}, nil)
+	mockStore.EXPECT().Append(gomock.Any(), gomock.Any()).Return(int64(1), nil).Times(2)
+	mockHub.EXPECT().Subscribe(gomock.Any(), models.StreamFilter{}).Return(make(chan models.StreamEvent), func() {}, nil)
+
+	_, err := collabService.Join(alice, "c1", aliceInbox.send, board)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = collabService.Join(bob, "c2", bobInbox.send, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	payload := []byte(`{"id":"e1","event":"task.updated"}`)
+	move, _ := json.Marshal(&models.BoardMessage{Type: "move", Board: board, TaskID: 3, User: "carol"})
+	ownMove, _ := json.Marshal(&models.BoardMessage{Type: "move", Board: board, TaskID: 3, User: "alice"})
+	join, _ := json.Marshal(&models.BoardMessage{Type: "join", Board: board, User: "carol"})
+
+	for _, e := range []models.StreamEvent{
+		{Event: "task.updated", TaskID: 3, ParentID: &board, Payload: payload},
+		{Event: "board.move", Payload: ownMove},
+		{Event: "board.move", Payload: move},
+		{Event: "board.join", Payload: join},
+		{Event: "board.join", Payload: join},
+	} {
+		collabService.deliver(&e)
+	}
+
+	expected := []models.BoardMessage{
+		{Type: "task.updated", Board: board, TaskID: 3, Event: payload},
+		{Type: "move", Board: board, TaskID: 3, User: "carol"},
+		{Type: "presence", Board: board, Users: []string{"carol"}},
+	}
+	if !reflect.DeepEqual(aliceInbox.messages, expected) {
+		t.Errorf("expected %+v, got %+v", expected, aliceInbox.messages)
+	}
+
+	if len(bobInbox.messages) != 0 {
+		t.Errorf("expected nothing for the other board, got %+v", bobInbox.messages)
+	}
+}
+
+func TestService_DeliverToStalledConnection(t *testing.T) {
+	controller := gomock.NewController(t)
+	mockStore := NewMockStore(controller)
+	mockHub := NewMockHub(controller)
+	collabService := New(mockStore, mockHub, NewMockTaskService(controller), "")
+
+	ctx := newContext(t, "alice")
+	stalled, written := make(chan struct{}), make(chan struct{})
+
+	mockStore.EXPECT().Append(ctx, gomock.Any()).Return(int64(1), nil).Times(2)
+	mockHub.EXPECT().Subscribe(gomock.Any(), models.StreamFilter{}).Return(make(chan models.StreamEvent), func() {}, nil)
+
+	_, err := collabService.Join(ctx, "c1", func([]byte) error {
+		close(written)
+		<-stalled
+
+		return nil
+	}, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	go collabService.deliver(&models.StreamEvent{Event: "task.updated", TaskID: 3, Payload: []byte(`{}`)})
+
+	<-written
+
+	// Other connections join and leave while the write to c1 hangs.
+	_, err = collabService.Join(ctx, "c2", func([]byte) error { return nil }, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = collabService.Leave(ctx, "c2")
+	if err != nil {
+		t.Error(err)
+	}
+
+	close(stalled)
+}
+
+func TestService_Heartbeat(t *testing.T) {
+	controller := gomock.NewController(t)
+	mockStore := NewMockStore(controller)
+	mockHub := NewMockHub(controller)
+	collabService := New(mockStore, mockHub, NewMockTaskService(controller), "")
+
+	ctx := newContext(t, "alice")
+	received := &inbox{}
+
+	mockStore.EXPECT().Append(ctx, gomock.Any()).Return(int64(1), nil)
+	mockHub.EXPECT().Subscribe(gomock.Any(), models.StreamFilter{}).Return(make(chan models.StreamEvent), func() {}, nil)
+
+	_, err := collabService.Join(ctx, "c1", received.send, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	collabService.presence[0] = map[string]time.Time{"alice": time.Now().Add(time.Minute), "bob": time.Now().Add(-time.Second)}
+
+	mockStore.EXPECT().Append(ctx, signalled(t, &models.BoardMessage{Type: "join", User: "alice"})).Return(int64(2), nil)
+
+	beats, err := collabService.Heartbeat(ctx)
+	if err != nil || beats != 1 {
+		t.Errorf("expected 1 heartbeat, got %d, %v", beats, err)
+	}
+
+	expected := []models.BoardMessage{{Type: "presence", Users: []string{"alice"}}}
+	if !reflect.DeepEqual(received.messages, expected) {
+		t.Errorf("expected bob to expire, got %+v", received.messages)
+	}
+
+	mockStore.EXPECT().Append(ctx, gomock.Any()).Return(int64(0), utils.ErrTest)
+
+	_, err = collabService.Heartbeat(ctx)
+	if !errors.Is(err, utils.ErrTest) {
+		t.Errorf("expected %v, got %v", utils.ErrTest, err)
+	}
+}
+
+func TestService_Token(t *testing.T) {
+	collabService := New(nil, nil, nil, "secret")
+
+	token, err := collabService.IssueToken(&models.BoardTokenRequest{User: "alice.b"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if until := time.Until(token.ExpiresAt); until <= 0 || until > tokenTTL {
+		t.Errorf("expected the token to expire within %v, got %v", tokenTTL, token.ExpiresAt)
+	}
+
+	user, err := collabService.Authenticate(token.Token)
+	if err != nil || user != "alice.b" {
+		t.Errorf("expected alice.b, got %q, %v", user, err)
+	}
+
+	other, err := New(nil, nil, nil, "other").IssueToken(&models.BoardTokenRequest{User: "alice"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expired := "YWxpY2U." + strconv.FormatInt(time.Now().Add(-time.Second).Unix(), 10)
+
+	for _, tc := range []struct {
+		description string
+		token       string
+	}{
+		{"empty", ""},
+		{"other secret", other.Token},
+		{"forged user", "Ym9i" + token.Token[strings.IndexByte(token.Token, '.'):]},
+		{"expired", expired + "." + collabService.sign(expired)},
+	} {
+		t.Run(tc.description, func(t *testing.T) {
+			_, err := collabService.Authenticate(tc.token)
+			if !errors.Is(err, errInvalidToken) {
+				t.Errorf("expected %v, got %v", errInvalidToken, err)
+			}
+		})
+	}
+
+	_, err = collabService.IssueToken(&models.BoardTokenRequest{})
+	if !errors.Is(err, apperr.Validation(apperr.Field("user", "is required"))) {
+		t.Errorf("expected the user to be required, got %v", err)
+	}
+
+	unsigned := New(nil, nil, nil, "")
+
+	_, err = unsigned.IssueToken(&models.BoardTokenRequest{User: "alice"})
+	if !errors.Is(err, errNoTokenSecret) {
+		t.Errorf("expected %v, got %v", errNoTokenSecret, err)
+	}
+
+	_, err = unsigned.Authenticate(token.Token)
+	if !errors.Is(err, errNoTokenSecret) {
+		t.Errorf("expected %v, got %v", errNoTokenSecret, err)
+	}
+}
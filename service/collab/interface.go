@@ -0,0 +1,19 @@
+package collab
+
+import (
+	"gofr.dev/pkg/gofr"
+
+	"TaskManager2/models"
+)
+
+type Store interface {
+	Append(*gofr.Context, *models.StreamEvent) (int64, error)
+}
+
+type Hub interface {
+	Subscribe(*gofr.Context, models.StreamFilter) (<-chan models.StreamEvent, func(), error)
+}
+
+type TaskService interface {
+	GetByID(*gofr.Context, int64) (*models.Task, error)
+}
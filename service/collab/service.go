@@ -0,0 +1,426 @@
+package collab
+
+import (
+	"encoding/json"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"gofr.dev/pkg/gofr"
+
+	"TaskManager2/apperr"
+	"TaskManager2/middleware"
+	"TaskManager2/models"
+)
+
+const (
+	signalPrefix = "board."
+	taskPrefix   = "task."
+
+	// Viewers are kept on the board by heartbeats, so that those of a
+	// replica that went away without saying goodbye drop out.
+	presenceTTL = 90 * time.Second
+
+	typingInterval = 3 * time.Second
+)
+
+var (
+	errNoActor       = apperr.Forbidden("the board channel needs a board token")
+	errInvalidBoard  = apperr.Validation(apperr.Field("board", "must not be negative"))
+	errMissingTaskID = apperr.Validation(apperr.Field("task_id", "is required"))
+	errNotJoined     = apperr.Validation(apperr.Field("type", "join a board first"))
+)
+
+type viewer struct {
+	board int64
+	user  string
+}
+
+type member struct {
+	user  string
+	board int64
+	send  func([]byte) error
+	typed map[int64]time.Time
+}
+
+// letter is a message addressed to the members of a board while holding the
+// lock and posted after releasing it, so that a slow connection holds up only
+// the messages to it and not the rooms of the replica.
+type letter struct {
+	to      []func([]byte) error
+	payload []byte
+}
+
+// post writes the letter. A failed write is not handled here: the connection
+// is taken off the board when reading from it fails too.
+func (l *letter) post() {
+	if l == nil {
+		return
+	}
+
+	for _, send := range l.to {
+		_ = send(l.payload)
+	}
+}
+
+// service keeps the connections of this replica in rooms per board. Board
+// signals such as moves are appended to the event log next to the task
+// events, so that every replica relays both to the members of its rooms.
+type service struct {
+	store  Store
+	hub    Hub
+	tasks  TaskService
+	secret []byte
+
+	mu       sync.Mutex
+	members  map[string]*member
+	presence map[int64]map[string]time.Time
+	live     <-chan models.StreamEvent
+	cancel   func()
+}
+
+// New returns the board service. Board tokens are signed with secret, which
+// must be the same on every replica; an empty secret disables them.
+func New(store Store, hub Hub, tasks TaskService, secret string) *service {
+	return &service{
+		store:    store,
+		hub:      hub,
+		tasks:    tasks,
+		secret:   []byte(secret),
+		members:  map[string]*member{},
+		presence: map[int64]map[string]time.Time{},
+	}
+}
+
+// Join moves the connection to the board and returns who is viewing it. The
+// messages of the board are written with send.
+// Viewers on other replicas are only known once they have joined or sent a
+// heartbeat since this replica started listening.
+func (s *service) Join(ctx *gofr.Context, conn string, send func([]byte) error, board int64) (*models.BoardMessage, error) {
+	user := middleware.Actor(ctx)
+	if user == "" {
+		return nil, errNoActor
+	}
+
+	if board < 0 {
+		return nil, errInvalidBoard
+	}
+
+	if board > 0 {
+		_, err := s.tasks.GetByID(ctx, board)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	err := s.Leave(ctx, conn)
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.signal(ctx, &models.BoardMessage{Type: models.BoardJoin, Board: board, User: user})
+	if err != nil {
+		return nil, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.live == nil {
+		err = s.listen(ctx)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	s.members[conn] = &member{user: user, board: board, send: send, typed: map[int64]time.Time{}}
+
+	users := s.viewers(board)
+	if !slices.Contains(users, user) {
+		users = append(users, user)
+		slices.Sort(users)
+	}
+
+	return &models.BoardMessage{Type: models.BoardPresence, Board: board, Users: users}, nil
+}
+
+// Leave takes the connection off its board. The user stays on the board while
+// they have another connection to it on this replica.
+func (s *service) Leave(ctx *gofr.Context, conn string) error {
+	s.mu.Lock()
+
+	m, ok := s.members[conn]
+	if !ok {
+		s.mu.Unlock()
+
+		return nil
+	}
+
+	delete(s.members, conn)
+
+	if len(s.members) == 0 && s.live != nil {
+		s.cancel()
+		s.live, s.cancel = nil, nil
+		clear(s.presence)
+	}
+
+	stays := slices.ContainsFunc(s.room(m.board), func(o *member) bool { return o.user == m.user })
+
+	s.mu.Unlock()
+
+	if stays {
+		return nil
+	}
+
+	return s.signal(ctx, &models.BoardMessage{Type: models.BoardLeave, Board: m.board, User: m.user})
+}
+
+// Relay sends a move or typing indicator to the board of the connection.
+// Typing indicators are sent at most once every typingInterval per task.
+func (s *service) Relay(ctx *gofr.Context, conn string, msg *models.BoardMessage) error {
+	if msg.TaskID == 0 {
+		return errMissingTaskID
+	}
+
+	s.mu.Lock()
+
+	m, ok := s.members[conn]
+	if !ok {
+		s.mu.Unlock()
+
+		return errNotJoined
+	}
+
+	if msg.Type == models.BoardTyping {
+		now := time.Now()
+		if now.Before(m.typed[msg.TaskID]) {
+			s.mu.Unlock()
+
+			return nil
+		}
+
+		for id, until := range m.typed {
+			if now.After(until) {
+				delete(m.typed, id)
+			}
+		}
+
+		m.typed[msg.TaskID] = now.Add(typingInterval)
+	}
+
+	out := &models.BoardMessage{Type: msg.Type, Board: m.board, TaskID: msg.TaskID, User: m.user, Data: msg.Data}
+
+	s.mu.Unlock()
+
+	return s.signal(ctx, out)
+}
+
+// Heartbeat drops the viewers whose presence expired and renews that of the
+// users connected to this replica. It returns how many it renewed.
+func (s *service) Heartbeat(ctx *gofr.Context) (int, error) {
+	s.mu.Lock()
+
+	var letters []*letter
+
+	now := time.Now()
+
+	for board, users := range s.presence {
+		expired := false
+
+		for user, until := range users {
+			if now.After(until) {
+				delete(users, user)
+
+				expired = true
+			}
+		}
+
+		if len(users) == 0 {
+			delete(s.presence, board)
+		}
+
+		if expired {
+			letters = append(letters, s.presenceLetter(board))
+		}
+	}
+
+	beats := map[viewer]bool{}
+	for _, m := range s.members {
+		beats[viewer{board: m.board, user: m.user}] = true
+	}
+
+	s.mu.Unlock()
+
+	for _, l := range letters {
+		l.post()
+	}
+
+	for v := range beats {
+		err := s.signal(ctx, &models.BoardMessage{Type: models.BoardJoin, Board: v.board, User: v.user})
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	return len(beats), nil
+}
+
+func (s *service) signal(ctx *gofr.Context, msg *models.BoardMessage) error {
+	payload, err := json.Marshal(msg)
+	if err != nil {
+		return err
+	}
+
+	e := &models.StreamEvent{Event: signalPrefix + msg.Type, TaskID: msg.TaskID, Payload: payload, CreatedAt: time.Now().UTC()}
+	if msg.Board != 0 {
+		e.ParentID = &msg.Board
+	}
+
+	_, err = s.store.Append(ctx, e)
+
+	return err
+}
+
+// listen subscribes to the event log while this replica has connections. The
+// hub drops subscribers that fall behind, in which case it subscribes again;
+// what was missed is not replayed. When subscribing again fails, the next
+// connection to join tries once more.
+func (s *service) listen(ctx *gofr.Context) error {
+	live, cancel, err := s.hub.Subscribe(ctx, models.StreamFilter{})
+	if err != nil {
+		return err
+	}
+
+	s.live, s.cancel = live, cancel
+
+	go func() {
+		for e := range live {
+			s.deliver(&e)
+		}
+
+		s.mu.Lock()
+		defer s.mu.Unlock()
+
+		if s.live != live {
+			return
+		}
+
+		s.live, s.cancel = nil, nil
+
+		err := s.listen(ctx)
+		if err != nil {
+			ctx.Logger.Errorf("subscribing to the event log again: %v", err)
+		}
+	}()
+
+	return nil
+}
+
+func (s *service) deliver(e *models.StreamEvent) {
+	s.mu.Lock()
+	l := s.address(e)
+	s.mu.Unlock()
+
+	l.post()
+}
+
+// address returns the letter the event makes for the members of this
+// replica, or nil if there is none.
+func (s *service) address(e *models.StreamEvent) *letter {
+	switch {
+	case strings.HasPrefix(e.Event, taskPrefix):
+		var board int64
+		if e.ParentID != nil {
+			board = *e.ParentID
+		}
+
+		return s.letter(board, "", &models.BoardMessage{Type: e.Event, Board: board, TaskID: e.TaskID, Event: e.Payload})
+	case strings.HasPrefix(e.Event, signalPrefix):
+		var msg models.BoardMessage
+
+		err := json.Unmarshal(e.Payload, &msg)
+		if err != nil {
+			return nil
+		}
+
+		switch msg.Type {
+		case models.BoardJoin, models.BoardLeave:
+			return s.track(&msg)
+		default:
+			return s.letter(msg.Board, msg.User, &msg)
+		}
+	}
+
+	return nil
+}
+
+// track updates who is viewing the board and tells its members when that
+// changes. A join also serves as heartbeat.
+func (s *service) track(msg *models.BoardMessage) *letter {
+	users := s.presence[msg.Board]
+	if users == nil {
+		users = map[string]time.Time{}
+		s.presence[msg.Board] = users
+	}
+
+	_, known := users[msg.User]
+
+	if msg.Type == models.BoardLeave {
+		delete(users, msg.User)
+	} else {
+		users[msg.User] = time.Now().Add(presenceTTL)
+	}
+
+	if known == (msg.Type == models.BoardJoin) {
+		return nil
+	}
+
+	return s.presenceLetter(msg.Board)
+}
+
+func (s *service) presenceLetter(board int64) *letter {
+	return s.letter(board, "", &models.BoardMessage{Type: models.BoardPresence, Board: board, Users: s.viewers(board)})
+}
+
+// letter addresses the message to the members of the board other than the
+// given user.
+func (s *service) letter(board int64, skip string, msg *models.BoardMessage) *letter {
+	payload, err := json.Marshal(msg)
+	if err != nil {
+		return nil
+	}
+
+	l := &letter{payload: payload}
+
+	for _, m := range s.room(board) {
+		if m.user != skip {
+			l.to = append(l.to, m.send)
+		}
+	}
+
+	return l
+}
+
+func (s *service) room(board int64) []*member {
+	var members []*member
+
+	for _, m := range s.members {
+		if m.board == board {
+			members = append(members, m)
+		}
+	}
+
+	return members
+}
+
+func (s *service) viewers(board int64) []string {
+	users := make([]string, 0, len(s.presence[board]))
+	for user := range s.presence[board] {
+		users = append(users, user)
+	}
+
+	slices.Sort(users)
+
+	return users
+}
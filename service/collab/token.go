@@ -0,0 +1,75 @@
+package collab
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"strconv"
+	"strings"
+	"time"
+
+	"TaskManager2/apperr"
+	"TaskManager2/models"
+	"TaskManager2/validate"
+)
+
+// tokenTTL is how long a board token can be used to open a connection. It is
+// only checked on the upgrade, so an open connection outlives it.
+const tokenTTL = time.Minute
+
+var (
+	errNoTokenSecret = apperr.Forbidden("board tokens are not configured")
+	errInvalidToken  = apperr.Forbidden("the board token is invalid or expired")
+)
+
+// IssueToken returns a board token for a user authenticated by the caller.
+// Browsers cannot set headers on a WebSocket upgrade, so they get a token
+// first and pass it as the token query parameter of /ws/board.
+func (s *service) IssueToken(req *models.BoardTokenRequest) (*models.BoardToken, error) {
+	err := validate.Struct(req)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(s.secret) == 0 {
+		return nil, errNoTokenSecret
+	}
+
+	expires := time.Now().UTC().Add(tokenTTL).Truncate(time.Second)
+	payload := base64.RawURLEncoding.EncodeToString([]byte(req.User)) + "." + strconv.FormatInt(expires.Unix(), 10)
+
+	return &models.BoardToken{Token: payload + "." + s.sign(payload), ExpiresAt: expires}, nil
+}
+
+// Authenticate returns the user a board token was issued to.
+func (s *service) Authenticate(token string) (string, error) {
+	if len(s.secret) == 0 {
+		return "", errNoTokenSecret
+	}
+
+	i := strings.LastIndexByte(token, '.')
+	if i < 0 || !hmac.Equal([]byte(token[i+1:]), []byte(s.sign(token[:i]))) {
+		return "", errInvalidToken
+	}
+
+	encoded, expiry, _ := strings.Cut(token[:i], ".")
+
+	unix, err := strconv.ParseInt(expiry, 10, 64)
+	if err != nil || time.Now().After(time.Unix(unix, 0)) {
+		return "", errInvalidToken
+	}
+
+	user, err := base64.RawURLEncoding.DecodeString(encoded)
+	if err != nil || len(user) == 0 {
+		return "", errInvalidToken
+	}
+
+	return string(user), nil
+}
+
+func (s *service) sign(payload string) string {
+	mac := hmac.New(sha256.New, s.secret)
+	mac.Write([]byte(payload))
+
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
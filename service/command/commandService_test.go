@@ -0,0 +1,196 @@
+package command
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"go.uber.org/mock/gomock"
+	"gofr.dev/pkg/gofr"
+	"gofr.dev/pkg/gofr/container"
+
+	"TaskManager2/apperr"
+	"TaskManager2/middleware"
+	"TaskManager2/models"
+	"TaskManager2/utils"
+)
+
+func TestService_Apply(t *testing.T) {
+	mockContainer, mock := container.NewMockContainer(t)
+	ctx := &gofr.Context{
+		Context:   t.Context(),
+		Request:   nil,
+		Container: mockContainer,
+	}
+
+	controller := gomock.NewController(t)
+	mockStore := NewMockStore(controller)
+	mockTasks := NewMockTaskService(controller)
+	commandService := New(mockStore, mockTasks)
+
+	title := "renamed"
+	done := true
+	task := &models.Task{Title: "release", UserID: 1}
+
+	testcases := []struct {
+		description   string
+		input         *models.TaskCommand
+		mockExpect    func()
+		expected      bool
+		expectedError error
+	}{
+		{
+			"create",
+			&models.TaskCommand{ID: "m1", Version: 1, Type: "task.create", Actor: "7", Task: task},
+			func() {
+				mock.SQL.ExpectBegin()
+				mockStore.EXPECT().MarkProcessed(ctx, "m1", gomock.Any()).Return(true, nil)
+				mockTasks.EXPECT().Create(ctx, task).DoAndReturn(func(ctx *gofr.Context, _ *models.Task) (int64, error) {
+					if middleware.Actor(ctx) != "7" || middleware.RequestID(ctx) != "m1" {
+						t.Errorf("expected actor 7 and request id m1, got %q and %q", middleware.Actor(ctx), middleware.RequestID(ctx))
+					}
+
+					return 3, nil
+				})
+				mock.SQL.ExpectCommit()
+			},
+			true,
+			nil,
+		},
+		{
+			"update",
+			&models.TaskCommand{ID: "m2", Version: 1, Type: "task.update", TaskID: 3, TaskVersion: 2,
+				Patch: &models.TaskPatch{Title: &title}},
+			func() {
+				mock.SQL.ExpectBegin()
+				mockStore.EXPECT().MarkProcessed(ctx, "m2", gomock.Any()).Return(true, nil)
+				mockTasks.EXPECT().Patch(ctx, int64(3), &models.TaskPatch{Title: &title, Version: 2}).Return(&models.Task{ID: 3}, nil)
+				mock.SQL.ExpectCommit()
+			},
+			true,
+			nil,
+		},
+		{
+			"complete",
+			&models.TaskCommand{ID: "m3", Version: 1, Type: "task.complete", TaskID: 3},
+			func() {
+				mock.SQL.ExpectBegin()
+				mockStore.EXPECT().MarkProcessed(ctx, "m3", gomock.Any()).Return(true, nil)
+				mockTasks.EXPECT().Patch(ctx, int64(3), &models.TaskPatch{Status: &done}).Return(&models.Task{ID: 3}, nil)
+				mock.SQL.ExpectCommit()
+			},
+			true,
+			nil,
+		},
+		{
+			"redelivered",
+			&models.TaskCommand{ID: "m1", Version: 1, Type: "task.create", Task: task},
+			func() {
+				mock.SQL.ExpectBegin()
+				mockStore.EXPECT().MarkProcessed(ctx, "m1", gomock.Any()).Return(false, nil)
+				mock.SQL.ExpectCommit()
+			},
+			false,
+			nil,
+		},
+		{
+			"task error rolls back",
+			&models.TaskCommand{ID: "m4", Version: 1, Type: "task.complete", TaskID: 9},
+			func() {
+				mock.SQL.ExpectBegin()
+				mockStore.EXPECT().MarkProcessed(ctx, "m4", gomock.Any()).Return(true, nil)
+				mockTasks.EXPECT().Patch(ctx, int64(9), gomock.Any()).Return(nil, apperr.NotFound("task", 9))
+				mock.SQL.ExpectRollback()
+			},
+			false,
+			apperr.NotFound("task", 9),
+		},
+		{
+			"store error",
+			&models.TaskCommand{ID: "m5", Version: 1, Type: "task.complete", TaskID: 9},
+			func() {
+				mock.SQL.ExpectBegin()
+				mockStore.EXPECT().MarkProcessed(ctx, "m5", gomock.Any()).Return(false, utils.ErrTest)
+				mock.SQL.ExpectRollback()
+			},
+			false,
+			utils.ErrTest,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.description, func(t *testing.T) {
+			tc.mockExpect()
+
+			applied, err := commandService.Apply(ctx, tc.input)
+			if applied != tc.expected || !errors.Is(err, tc.expectedError) {
+				t.Errorf("expected %v, %v, got %v, %v", tc.expected, tc.expectedError, applied, err)
+			}
+
+			if middleware.RequestID(ctx) != "" {
+				t.Error("expected the command metadata to be removed from ctx")
+			}
+		})
+	}
+
+	if err := mock.SQL.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestService_ApplyInvalid(t *testing.T) {
+	var ctx *gofr.Context
+
+	commandService := New(nil, nil)
+
+	testcases := []struct {
+		description string
+		input       *models.TaskCommand
+		field       string
+	}{
+		{"no id", &models.TaskCommand{Version: 1, Type: "task.complete", TaskID: 1}, "id"},
+		{"id too long", &models.TaskCommand{ID: strings.Repeat("m", middleware.MaxRequestIDLength+1), Version: 1,
+			Type: "task.complete", TaskID: 1}, "id"},
+		{"actor too long", &models.TaskCommand{ID: "m1", Version: 1, Type: "task.complete", TaskID: 1,
+			Actor: strings.Repeat("a", middleware.MaxActorLength+1)}, "actor"},
+		{"unknown version", &models.TaskCommand{ID: "m1", Version: 2, Type: "task.complete", TaskID: 1}, "version"},
+		{"unknown type", &models.TaskCommand{ID: "m1", Version: 1, Type: "task.archive"}, "type"},
+		{"create without task", &models.TaskCommand{ID: "m1", Version: 1, Type: "task.create"}, "task"},
+		{"update without task id", &models.TaskCommand{ID: "m1", Version: 1, Type: "task.update",
+			Patch: &models.TaskPatch{}}, "task_id"},
+		{"update without patch", &models.TaskCommand{ID: "m1", Version: 1, Type: "task.update", TaskID: 1}, "patch"},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.description, func(t *testing.T) {
+			_, err := commandService.Apply(ctx, tc.input)
+
+			var appErr *apperr.Error
+			if !errors.As(err, &appErr) || appErr.Code != apperr.CodeValidation || len(appErr.Fields) != 1 ||
+				appErr.Fields[0].Field != tc.field {
+				t.Errorf("expected a validation error on %s, got %v", tc.field, err)
+			}
+		})
+	}
+}
+
+func TestService_Purge(t *testing.T) {
+	var ctx *gofr.Context
+
+	controller := gomock.NewController(t)
+	mockStore := NewMockStore(controller)
+
+	mockStore.EXPECT().Purge(ctx, gomock.Any()).DoAndReturn(func(_ *gofr.Context, before time.Time) (int64, error) {
+		if age := time.Since(before); age < time.Hour || age > time.Hour+time.Minute {
+			t.Errorf("expected commands processed an hour ago, got %v", before)
+		}
+
+		return 2, nil
+	})
+
+	purged, err := New(mockStore, nil).Purge(ctx, time.Hour)
+	if err != nil || purged != 2 {
+		t.Errorf("expected 2 purged, got %d, %v", purged, err)
+	}
+}
@@ -0,0 +1,19 @@
+package command
+
+import (
+	"time"
+
+	"gofr.dev/pkg/gofr"
+
+	"TaskManager2/models"
+)
+
+type Store interface {
+	MarkProcessed(*gofr.Context, string, time.Time) (bool, error)
+	Purge(*gofr.Context, time.Time) (int64, error)
+}
+
+type TaskService interface {
+	Create(*gofr.Context, *models.Task) (int64, error)
+	Patch(*gofr.Context, int64, *models.TaskPatch) (*models.Task, error)
+}
@@ -0,0 +1,114 @@
+package command
+
+import (
+	"fmt"
+	"time"
+
+	"gofr.dev/pkg/gofr"
+
+	"TaskManager2/apperr"
+	"TaskManager2/middleware"
+	"TaskManager2/models"
+	"TaskManager2/utils"
+)
+
+type service struct {
+	store Store
+	tasks TaskService
+}
+
+func New(store Store, tasks TaskService) *service {
+	return &service{store: store, tasks: tasks}
+}
+
+// Apply runs the command through the task service as its actor, with the
+// command ID as request ID. The command is marked processed in the same
+// transaction as the change, so a redelivered command is skipped and reports
+// applied false, and a failed one is retried in full.
+func (s *service) Apply(ctx *gofr.Context, cmd *models.TaskCommand) (bool, error) {
+	err := check(cmd)
+	if err != nil {
+		return false, err
+	}
+
+	parent := ctx.Context
+	ctx.Context = middleware.WithMetadata(parent, cmd.Actor, cmd.ID)
+
+	defer func() { ctx.Context = parent }()
+
+	var applied bool
+
+	err = utils.WithTx(ctx, func() error {
+		applied, err = s.store.MarkProcessed(ctx, cmd.ID, time.Now().UTC().Truncate(time.Second))
+		if err != nil || !applied {
+			return err
+		}
+
+		switch cmd.Type {
+		case models.CommandTaskCreate:
+			_, err = s.tasks.Create(ctx, cmd.Task)
+		case models.CommandTaskUpdate:
+			cmd.Patch.Version = cmd.TaskVersion
+			_, err = s.tasks.Patch(ctx, cmd.TaskID, cmd.Patch)
+		case models.CommandTaskComplete:
+			done := true
+			_, err = s.tasks.Patch(ctx, cmd.TaskID, &models.TaskPatch{Status: &done, Version: cmd.TaskVersion})
+		}
+
+		return err
+	})
+	if err != nil {
+		return false, err
+	}
+
+	return applied, nil
+}
+
+// Purge forgets the commands processed longer than age ago. A command
+// redelivered after that is applied again.
+func (s *service) Purge(ctx *gofr.Context, age time.Duration) (int64, error) {
+	return s.store.Purge(ctx, time.Now().UTC().Add(-age))
+}
+
+// check rejects commands that can never be applied, whatever the state of
+// the tasks.
+func check(cmd *models.TaskCommand) error {
+	var invalid []apperr.FieldError
+
+	// The ID and actor are recorded in the audit log, so they must fit its columns.
+	if cmd.ID == "" || len(cmd.ID) > middleware.MaxRequestIDLength {
+		invalid = append(invalid, apperr.Field("id", fmt.Sprintf("must be between 1 and %d characters", middleware.MaxRequestIDLength)))
+	}
+
+	if len(cmd.Actor) > middleware.MaxActorLength {
+		invalid = append(invalid, apperr.Field("actor", fmt.Sprintf("must be at most %d characters", middleware.MaxActorLength)))
+	}
+
+	if cmd.Version != models.TaskCommandVersion {
+		invalid = append(invalid, apperr.Field("version", fmt.Sprintf("must be %d", models.TaskCommandVersion)))
+	}
+
+	switch cmd.Type {
+	case models.CommandTaskCreate:
+		if cmd.Task == nil {
+			invalid = append(invalid, apperr.Field("task", "is required"))
+		}
+	case models.CommandTaskUpdate, models.CommandTaskComplete:
+		if cmd.TaskID <= 0 {
+			invalid = append(invalid, apperr.Field("task_id", "must be a positive integer"))
+		}
+
+		if cmd.Type == models.CommandTaskUpdate && cmd.Patch == nil {
+			invalid = append(invalid, apperr.Field("patch", "is required"))
+		}
+	default:
+		invalid = append(invalid, apperr.Field("type", fmt.Sprintf("must be %s, %s or %s",
+			models.CommandTaskCreate, models.CommandTaskUpdate, models.CommandTaskComplete)))
+	}
+
+	if len(invalid) > 0 {
+		return apperr.Validation(invalid...)
+	}
+
+	return nil
+}
@@ -0,0 +1,157 @@
+package comment
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"go.uber.org/mock/gomock"
+	"gofr.dev/pkg/gofr"
+
+	"TaskManager2/apperr"
+	"TaskManager2/models"
+	"TaskManager2/utils"
+)
+
+func TestService_Create(t *testing.T) {
+	ctx := &gofr.Context{Context: t.Context()}
+
+	controller := gomock.NewController(t)
+	mockStore := NewMockStore(controller)
+	mockTaskSvc := NewMockTaskService(controller)
+	mockIndex := NewMockSearchIndex(controller)
+	commentService := New(mockStore, mockTaskSvc, mockIndex)
+
+	doc := models.SearchDocument{Kind: models.SearchKindComment, ID: 5, TaskID: 3, Text: "Looks good"}
+
+	tests := []struct {
+		description string
+		input       *models.Comment
+		mockExpect  func()
+		expectedErr error
+	}{
+		{
+			description: "success",
+			input:       &models.Comment{TaskID: 3, Body: "Looks good"},
+			mockExpect: func() {
+				mockTaskSvc.EXPECT().GetByID(ctx, int64(3)).Return(&models.Task{ID: 3}, nil)
+				mockStore.EXPECT().Create(ctx, gomock.Any()).Return(int64(5), nil)
+				mockIndex.EXPECT().Index(ctx, doc).Return(nil)
+			},
+		},
+		{
+			description: "empty body",
+			input:       &models.Comment{TaskID: 3},
+			mockExpect:  func() {},
+			expectedErr: apperr.Validation(apperr.Field("body", "is required")),
+		},
+		{
+			description: "task not found",
+			input:       &models.Comment{TaskID: 3, Body: "Looks good"},
+			mockExpect: func() {
+				mockTaskSvc.EXPECT().GetByID(ctx, int64(3)).Return(nil, apperr.NotFound("task", 3))
+			},
+			expectedErr: apperr.NotFound("task", 3),
+		},
+		{
+			description: "store error",
+			input:       &models.Comment{TaskID: 3, Body: "Looks good"},
+			mockExpect: func() {
+				mockTaskSvc.EXPECT().GetByID(ctx, int64(3)).Return(&models.Task{ID: 3}, nil)
+				mockStore.EXPECT().Create(ctx, gomock.Any()).Return(int64(0), utils.ErrTest)
+			},
+			expectedErr: utils.ErrTest,
+		},
+		{
+			description: "index error",
+			input:       &models.Comment{TaskID: 3, Body: "Looks good"},
+			mockExpect: func() {
+				mockTaskSvc.EXPECT().GetByID(ctx, int64(3)).Return(&models.Task{ID: 3}, nil)
+				mockStore.EXPECT().Create(ctx, gomock.Any()).Return(int64(5), nil)
+				mockIndex.EXPECT().Index(ctx, doc).Return(utils.ErrTest)
+			},
+			expectedErr: utils.ErrTest,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.description, func(t *testing.T) {
+			tc.mockExpect()
+
+			created, err := commentService.Create(ctx, tc.input)
+			if !errors.Is(err, tc.expectedErr) {
+				t.Fatalf("expected error %v, got %v", tc.expectedErr, err)
+			}
+
+			if err != nil {
+				return
+			}
+
+			if created.ID != 5 || created.TaskID != 3 || created.Body != "Looks good" {
+				t.Errorf("unexpected comment %+v", created)
+			}
+
+			if time.Since(created.CreatedAt) > time.Minute {
+				t.Errorf("expected created_at to be now, got %v", created.CreatedAt)
+			}
+		})
+	}
+}
+
+func TestService_GetByTask(t *testing.T) {
+	var ctx *gofr.Context
+
+	controller := gomock.NewController(t)
+	mockStore := NewMockStore(controller)
+	mockTaskSvc := NewMockTaskService(controller)
+	commentService := New(mockStore, mockTaskSvc, NewMockSearchIndex(controller))
+
+	comments := []models.Comment{{ID: 1, TaskID: 3, Body: "First"}}
+
+	tests := []struct {
+		description string
+		mockExpect  func()
+		expected    []models.Comment
+		expectedErr error
+	}{
+		{
+			description: "success",
+			mockExpect: func() {
+				mockTaskSvc.EXPECT().GetByID(ctx, int64(3)).Return(&models.Task{ID: 3}, nil)
+				mockStore.EXPECT().GetByTask(ctx, int64(3)).Return(comments, nil)
+			},
+			expected: comments,
+		},
+		{
+			description: "task not found",
+			mockExpect: func() {
+				mockTaskSvc.EXPECT().GetByID(ctx, int64(3)).Return(nil, apperr.NotFound("task", 3))
+			},
+			expectedErr: apperr.NotFound("task", 3),
+		},
+		{
+			description: "store error",
+			mockExpect: func() {
+				mockTaskSvc.EXPECT().GetByID(ctx, int64(3)).Return(&models.Task{ID: 3}, nil)
+				mockStore.EXPECT().GetByTask(ctx, int64(3)).Return(nil, utils.ErrTest)
+			},
+			expectedErr: utils.ErrTest,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.description, func(t *testing.T) {
+			tc.mockExpect()
+
+			got, err := commentService.GetByTask(ctx, 3)
+			if !errors.Is(err, tc.expectedErr) {
+				t.Errorf("expected error %v, got %v", tc.expectedErr, err)
+			}
+
+			if !reflect.DeepEqual(got, tc.expected) {
+				t.Errorf("expected %v, got %v", tc.expected, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,20 @@
+package comment
+
+import (
+	"gofr.dev/pkg/gofr"
+
+	"TaskManager2/models"
+)
+
+type Store interface {
+	Create(*gofr.Context, *models.Comment) (int64, error)
+	GetByTask(*gofr.Context, int64) ([]models.Comment, error)
+}
+
+type TaskService interface {
+	GetByID(*gofr.Context, int64) (*models.Task, error)
+}
+
+type SearchIndex interface {
+	Index(*gofr.Context, models.SearchDocument) error
+}
@@ -0,0 +1,64 @@
+package comment
+
+import (
+	"time"
+
+	"gofr.dev/pkg/gofr"
+
+	"TaskManager2/middleware"
+	"TaskManager2/models"
+	"TaskManager2/validate"
+)
+
+type service struct {
+	store       Store
+	taskService TaskService
+	index       SearchIndex
+}
+
+func New(store Store, taskSvc TaskService, index SearchIndex) *service {
+	return &service{store: store, taskService: taskSvc, index: index}
+}
+
+// Create adds a comment by the current actor to an existing task and makes it searchable.
+func (s *service) Create(ctx *gofr.Context, c *models.Comment) (*models.Comment, error) {
+	err := validate.Struct(c)
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = s.taskService.GetByID(ctx, c.TaskID)
+	if err != nil {
+		return nil, err
+	}
+
+	created := *c
+	created.Author = middleware.Actor(ctx)
+	created.CreatedAt = time.Now().UTC().Truncate(time.Second)
+
+	created.ID, err = s.store.Create(ctx, &created)
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.index.Index(ctx, models.SearchDocument{Kind: models.SearchKindComment, ID: created.ID, TaskID: created.TaskID, Text: created.Body})
+	if err != nil {
+		return nil, err
+	}
+
+	return &created, nil
+}
+
+func (s *service) GetByTask(ctx *gofr.Context, taskID int64) ([]models.Comment, error) {
+	_, err := s.taskService.GetByID(ctx, taskID)
+	if err != nil {
+		return nil, err
+	}
+
+	comments, err := s.store.GetByTask(ctx, taskID)
+	if err != nil {
+		return nil, err
+	}
+
+	return comments, nil
+}
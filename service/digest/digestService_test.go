@@ -0,0 +1,354 @@
+package digest
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"go.uber.org/mock/gomock"
+	"gofr.dev/pkg/gofr"
+
+	"TaskManager2/apperr"
+	"TaskManager2/mail"
+	"TaskManager2/models"
+	"TaskManager2/utils"
+)
+
+func TestSchedule(t *testing.T) {
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Monday 19 October 2026, 09:30 in Berlin.
+	now := time.Date(2026, 10, 19, 7, 30, 0, 0, time.UTC)
+
+	tests := []struct {
+		description string
+		preference  models.DigestPreference
+		from        time.Time
+		at          time.Time
+	}{
+		{
+			"daily, already passed today",
+			models.DigestPreference{Frequency: "daily", TimeOfDay: "08:00"},
+			time.Date(2026, 10, 18, 6, 0, 0, 0, time.UTC),
+			time.Date(2026, 10, 19, 6, 0, 0, 0, time.UTC),
+		},
+		{
+			"daily, later today",
+			models.DigestPreference{Frequency: "daily", TimeOfDay: "10:00"},
+			time.Date(2026, 10, 17, 8, 0, 0, 0, time.UTC),
+			time.Date(2026, 10, 18, 8, 0, 0, 0, time.UTC),
+		},
+		{
+			"weekly, across the end of summer time",
+			models.DigestPreference{Frequency: "weekly", TimeOfDay: "08:00", Weekday: "friday"},
+			time.Date(2026, 10, 9, 6, 0, 0, 0, time.UTC),
+			time.Date(2026, 10, 16, 6, 0, 0, 0, time.UTC),
+		},
+		{
+			"weekly, today",
+			models.DigestPreference{Frequency: "weekly", TimeOfDay: "09:30", Weekday: "monday"},
+			time.Date(2026, 10, 12, 7, 30, 0, 0, time.UTC),
+			time.Date(2026, 10, 19, 7, 30, 0, 0, time.UTC),
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.description, func(t *testing.T) {
+			from, at := schedule(&tc.preference, berlin, now)
+			if !from.Equal(tc.from) || !at.Equal(tc.at) {
+				t.Errorf("expected %v to %v, got %v to %v", tc.from, tc.at, from, at)
+			}
+		})
+	}
+
+	// The week before 25 October 2026 ends summer time, so it is an hour longer.
+	from, at := schedule(&models.DigestPreference{Frequency: "weekly", TimeOfDay: "08:00", Weekday: "monday"}, berlin,
+		time.Date(2026, 10, 26, 12, 0, 0, 0, time.UTC))
+	if at.Sub(from) != 7*24*time.Hour+time.Hour {
+		t.Errorf("expected a week and an hour, got %v", at.Sub(from))
+	}
+}
+
+func TestDue(t *testing.T) {
+	now := time.Date(2026, 10, 19, 9, 0, 0, 0, time.UTC)
+	at := time.Date(2026, 10, 19, 8, 0, 0, 0, time.UTC)
+	earlier := at.AddDate(0, 0, -1)
+
+	tests := []struct {
+		description string
+		preference  models.DigestPreference
+		due         bool
+	}{
+		{"never sent", models.DigestPreference{Frequency: "daily", TimeOfDay: "08:00", Timezone: "UTC"}, true},
+		{"sent before", models.DigestPreference{Frequency: "daily", TimeOfDay: "08:00", Timezone: "UTC", LastDigest: &earlier}, true},
+		{"already sent", models.DigestPreference{Frequency: "daily", TimeOfDay: "08:00", Timezone: "UTC", LastDigest: &at}, false},
+		{"changed since", models.DigestPreference{Frequency: "daily", TimeOfDay: "08:00", Timezone: "UTC", UpdatedAt: now}, false},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.description, func(t *testing.T) {
+			d, ok := due(&tc.preference, now)
+			if ok != tc.due {
+				t.Fatalf("expected due %v, got %v", tc.due, ok)
+			}
+
+			if ok && (!d.PeriodStart.Equal(earlier) || !d.PeriodEnd.Equal(at) || !d.ClaimedUntil.Equal(now.Add(claimDuration))) {
+				t.Errorf("expected the digest from %v to %v, got %+v", earlier, at, d)
+			}
+		})
+	}
+}
+
+func TestWindow(t *testing.T) {
+	tokyo, err := time.LoadLocation("Asia/Tokyo")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Sent at 08:00 on 20 October in Tokyo.
+	at := time.Date(2026, 10, 19, 23, 0, 0, 0, time.UTC)
+	d := &models.Digest{Frequency: "weekly", PeriodStart: at.AddDate(0, 0, -7), PeriodEnd: at}
+
+	expected := &models.DigestWindow{
+		From: at.AddDate(0, 0, -7), To: at,
+		DueFrom: time.Date(2026, 10, 19, 15, 0, 0, 0, time.UTC), DueTo: time.Date(2026, 10, 26, 15, 0, 0, 0, time.UTC),
+	}
+
+	if got := window(d, tokyo); !reflect.DeepEqual(got, expected) {
+		t.Errorf("expected %+v, got %+v", expected, got)
+	}
+}
+
+func TestService_SavePreference(t *testing.T) {
+	var ctx *gofr.Context
+
+	controller := gomock.NewController(t)
+	mockStore := NewMockStore(controller)
+	mockUsers := NewMockUserService(controller)
+	digestService := New(mockStore, NewMockTaskStore(controller), mockUsers, NewMockMailer(controller))
+
+	tests := []struct {
+		description   string
+		input         *models.DigestPreference
+		mockExpect    func()
+		expected      *models.DigestPreference
+		expectedError error
+	}{
+		{
+			"weekly",
+			&models.DigestPreference{UserID: 1, Frequency: "weekly", TimeOfDay: "07:30", Timezone: "Europe/Berlin", Weekday: "Monday"},
+			func() {
+				mockUsers.EXPECT().GetByID(ctx, int64(1)).Return(&models.User{ID: 1}, nil)
+				mockStore.EXPECT().SavePreference(ctx, gomock.Any()).Return(nil)
+			},
+			&models.DigestPreference{UserID: 1, Frequency: "weekly", TimeOfDay: "07:30", Timezone: "Europe/Berlin", Weekday: "monday"},
+			nil,
+		},
+		{
+			"daily drops the weekday",
+			&models.DigestPreference{UserID: 1, Frequency: "daily", TimeOfDay: "07:30", Timezone: "UTC", Weekday: "monday"},
+			func() {
+				mockUsers.EXPECT().GetByID(ctx, int64(1)).Return(&models.User{ID: 1}, nil)
+				mockStore.EXPECT().SavePreference(ctx, gomock.Any()).Return(nil)
+			},
+			&models.DigestPreference{UserID: 1, Frequency: "daily", TimeOfDay: "07:30", Timezone: "UTC"},
+			nil,
+		},
+		{
+			"invalid fields",
+			&models.DigestPreference{UserID: 1, Frequency: "hourly", TimeOfDay: "7am", Timezone: "Mars/Olympus"},
+			func() {},
+			nil,
+			apperr.Validation(
+				apperr.Field("frequency", "must be one of off, daily, weekly"),
+				apperr.Field("time_of_day", "must be a time as HH:MM"),
+				apperr.Field("timezone", "must be an IANA time zone name"),
+			),
+		},
+		{
+			"weekly without a weekday",
+			&models.DigestPreference{UserID: 1, Frequency: "weekly", TimeOfDay: "07:30", Timezone: "UTC"},
+			func() {},
+			nil,
+			apperr.Validation(apperr.Field("weekday", "must be a day of the week")),
+		},
+		{
+			"unknown user",
+			&models.DigestPreference{UserID: 2, Frequency: "off", TimeOfDay: "07:30", Timezone: "UTC"},
+			func() {
+				mockUsers.EXPECT().GetByID(ctx, int64(2)).Return(nil, apperr.NotFound("user", 2))
+			},
+			nil,
+			apperr.NotFound("user", 2),
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.description, func(t *testing.T) {
+			tc.mockExpect()
+
+			saved, err := digestService.SavePreference(ctx, tc.input)
+			if !errors.Is(err, tc.expectedError) {
+				t.Fatalf("expected %v, got %v", tc.expectedError, err)
+			}
+
+			if !reflect.DeepEqual(err, tc.expectedError) && tc.expectedError != nil {
+				t.Errorf("expected %+v, got %+v", tc.expectedError, err)
+			}
+
+			if saved != nil {
+				saved.UpdatedAt = time.Time{}
+			}
+
+			if !reflect.DeepEqual(saved, tc.expected) {
+				t.Errorf("expected %+v, got %+v", tc.expected, saved)
+			}
+		})
+	}
+}
+
+func TestService_PreferenceWithoutMailer(t *testing.T) {
+	var ctx *gofr.Context
+
+	controller := gomock.NewController(t)
+	mockStore := NewMockStore(controller)
+	mockUsers := NewMockUserService(controller)
+	digestService := New(mockStore, NewMockTaskStore(controller), mockUsers, nil)
+
+	_, err := digestService.SavePreference(ctx, &models.DigestPreference{UserID: 1, Frequency: "daily", TimeOfDay: "07:30", Timezone: "UTC"})
+	if !errors.Is(err, apperr.Validation(apperr.Field("frequency", "must be off, email is not configured"))) {
+		t.Errorf("expected a validation error, got %v", err)
+	}
+
+	mockUsers.EXPECT().GetByID(ctx, int64(1)).Return(&models.User{ID: 1}, nil)
+	mockStore.EXPECT().GetPreference(ctx, int64(1)).Return(nil, nil)
+
+	p, err := digestService.GetPreference(ctx, 1)
+
+	expected := &models.DigestPreference{UserID: 1, Frequency: "off", TimeOfDay: "08:00", Timezone: "UTC"}
+	if err != nil || !reflect.DeepEqual(p, expected) {
+		t.Errorf("expected %+v, got %+v, %v", expected, p, err)
+	}
+}
+
+func TestService_Send(t *testing.T) {
+	var ctx *gofr.Context
+
+	controller := gomock.NewController(t)
+	mockStore := NewMockStore(controller)
+	mockTasks := NewMockTaskStore(controller)
+	mockUsers := NewMockUserService(controller)
+	mockMailer := NewMockMailer(controller)
+	digestService := New(mockStore, mockTasks, mockUsers, mockMailer)
+
+	// Scheduled at midnight UTC every day, so always due.
+	preferences := []models.DigestPreference{
+		{UserID: 1, Frequency: "daily", TimeOfDay: "00:00", Timezone: "UTC"},
+		{UserID: 2, Frequency: "daily", TimeOfDay: "00:00", Timezone: "UTC"},
+		{UserID: 3, Frequency: "daily", TimeOfDay: "00:00", Timezone: "UTC"},
+	}
+	ada := &models.User{ID: 1, Name: "Ada", Email: "ada@example.com"}
+
+	mockStore.EXPECT().Scheduled(ctx).Return(preferences, nil)
+
+	// User 1 gets a digest.
+	mockStore.EXPECT().Claim(ctx, gomock.Any(), gomock.Any()).DoAndReturn(func(_ *gofr.Context, d *models.Digest, _ time.Time) (bool, error) {
+		if d.UserID != 1 || d.PeriodEnd.Sub(d.PeriodStart) != 24*time.Hour {
+			t.Errorf("expected a daily digest of user 1, got %+v", d)
+		}
+
+		d.ID = 7
+
+		return true, nil
+	})
+	mockUsers.EXPECT().GetByID(ctx, int64(1)).Return(ada, nil)
+	mockTasks.EXPECT().Digest(ctx, int64(1), gomock.Any(), 50).Return(&models.DigestTasks{Overdue: []models.Task{{Title: "Ship it"}}}, nil)
+	mockMailer.EXPECT().Send(ctx, gomock.Any()).DoAndReturn(func(_ *gofr.Context, msg *mail.Message) error {
+		if msg.To != "ada@example.com" || !strings.Contains(msg.Text, "Overdue (1)\n  - Ship it") {
+			t.Errorf("expected the digest of Ada, got %+v", msg)
+		}
+
+		return nil
+	})
+	mockStore.EXPECT().SaveAttempt(ctx, gomock.Any()).DoAndReturn(func(_ *gofr.Context, d *models.Digest) error {
+		if d.ID != 7 || d.Status != "sent" || d.Attempts != 1 || d.SentAt == nil {
+			t.Errorf("expected digest 7 to be sent, got %+v", d)
+		}
+
+		return nil
+	})
+
+	// User 2's digest is claimed by another replica.
+	mockStore.EXPECT().Claim(ctx, gomock.Any(), gomock.Any()).Return(false, nil)
+
+	// User 3's digest fails for the last time.
+	mockStore.EXPECT().Claim(ctx, gomock.Any(), gomock.Any()).DoAndReturn(func(_ *gofr.Context, d *models.Digest, _ time.Time) (bool, error) {
+		d.ID, d.Attempts = 8, 2
+
+		return true, nil
+	})
+	mockUsers.EXPECT().GetByID(ctx, int64(3)).Return(&models.User{ID: 3}, nil)
+	mockTasks.EXPECT().Digest(ctx, int64(3), gomock.Any(), 50).Return(&models.DigestTasks{}, nil)
+	mockMailer.EXPECT().Send(ctx, gomock.Any()).Return(utils.ErrTest)
+	mockStore.EXPECT().SaveAttempt(ctx, gomock.Any()).DoAndReturn(func(_ *gofr.Context, d *models.Digest) error {
+		if d.ID != 8 || d.Status != "failed" || d.Attempts != 3 || d.LastError != utils.ErrTest.Error() {
+			t.Errorf("expected digest 8 to have failed, got %+v", d)
+		}
+
+		return nil
+	})
+
+	sent, failed, err := digestService.Send(ctx)
+	if err != nil || sent != 1 || failed != 1 {
+		t.Errorf("expected 1 sent and 1 failed, got %d, %d, %v", sent, failed, err)
+	}
+
+	mockStore.EXPECT().Scheduled(ctx).Return(nil, utils.ErrTest)
+
+	_, _, err = digestService.Send(ctx)
+	if !errors.Is(err, utils.ErrTest) {
+		t.Errorf("expected %v, got %v", utils.ErrTest, err)
+	}
+}
+
+func TestRenderer_Message(t *testing.T) {
+	due := time.Date(2026, 10, 19, 15, 0, 0, 0, time.UTC)
+	at := time.Date(2026, 10, 19, 6, 0, 0, 0, time.UTC)
+	tasks := &models.DigestTasks{
+		Due:      []models.Task{{Title: "Ship <it>", DueDate: &due}},
+		Assigned: []models.Task{{Title: "Review"}},
+	}
+
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	msg, err := newRenderer().message(&models.User{Name: "Ada", Email: "ada@example.com"},
+		&models.Digest{Frequency: "daily", PeriodEnd: at}, tasks, berlin)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if msg.Subject != "Your daily task digest for Monday 19 October 2026" {
+		t.Errorf("unexpected subject %q", msg.Subject)
+	}
+
+	expected := "Hi Ada,\n\nHere is your daily digest for Monday 19 October 2026.\n\n" +
+		"Due today (1)\n  - Ship <it> (due Mon 19 Oct 17:00)\n\n" +
+		"Overdue (0)\n  None\n\n" +
+		"Completed (0)\n  None\n\n" +
+		"Newly assigned (1)\n  - Review\n"
+	if msg.Text != expected {
+		t.Errorf("expected text %q, got %q", expected, msg.Text)
+	}
+
+	if !strings.Contains(msg.HTML, "<li>Ship &lt;it&gt; <small>(due Mon 19 Oct 17:00)</small></li>") {
+		t.Errorf("expected the HTML to list the escaped title, got %q", msg.HTML)
+	}
+}
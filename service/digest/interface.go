@@ -0,0 +1,31 @@
+package digest
+
+import (
+	"time"
+
+	"gofr.dev/pkg/gofr"
+
+	"TaskManager2/mail"
+	"TaskManager2/models"
+)
+
+type Store interface {
+	GetPreference(*gofr.Context, int64) (*models.DigestPreference, error)
+	SavePreference(*gofr.Context, *models.DigestPreference) error
+	Scheduled(*gofr.Context) ([]models.DigestPreference, error)
+	Claim(*gofr.Context, *models.Digest, time.Time) (bool, error)
+	SaveAttempt(*gofr.Context, *models.Digest) error
+	GetByUser(*gofr.Context, int64, int) ([]models.Digest, error)
+}
+
+type TaskStore interface {
+	Digest(*gofr.Context, int64, *models.DigestWindow, int) (*models.DigestTasks, error)
+}
+
+type UserService interface {
+	GetByID(*gofr.Context, int64) (*models.User, error)
+}
+
+type Mailer interface {
+	Send(*gofr.Context, *mail.Message) error
+}
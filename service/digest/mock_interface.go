@@ -0,0 +1,248 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: interface.go
+//
+// Generated by this command:
+//
+//	mockgen -source=interface.go -destination=mock_interface.go -package=digest
+//
+
+// Package digest is a generated GoMock package.
+package digest
+
+import (
+	mail "TaskManager2/mail"
+	models "TaskManager2/models"
+	reflect "reflect"
+	time "time"
+
+	gomock "go.uber.org/mock/gomock"
+	gofr "gofr.dev/pkg/gofr"
+)
+
+// MockStore is a mock of Store interface.
+type MockStore struct {
+	ctrl     *gomock.Controller
+	recorder *MockStoreMockRecorder
+	isgomock struct{}
+}
+
+// MockStoreMockRecorder is the mock recorder for MockStore.
+type MockStoreMockRecorder struct {
+	mock *MockStore
+}
+
+// NewMockStore creates a new mock instance.
+func NewMockStore(ctrl *gomock.Controller) *MockStore {
+	mock := &MockStore{ctrl: ctrl}
+	mock.recorder = &MockStoreMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockStore) EXPECT() *MockStoreMockRecorder {
+	return m.recorder
+}
+
+// Claim mocks base method.
+func (m *MockStore) Claim(arg0 *gofr.Context, arg1 *models.Digest, arg2 time.Time) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Claim", arg0, arg1, arg2)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Claim indicates an expected call of Claim.
+func (mr *MockStoreMockRecorder) Claim(arg0, arg1, arg2 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Claim", reflect.TypeOf((*MockStore)(nil).Claim), arg0, arg1, arg2)
+}
+
+// GetByUser mocks base method.
+func (m *MockStore) GetByUser(arg0 *gofr.Context, arg1 int64, arg2 int) ([]models.Digest, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByUser", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]models.Digest)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByUser indicates an expected call of GetByUser.
+func (mr *MockStoreMockRecorder) GetByUser(arg0, arg1, arg2 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByUser", reflect.TypeOf((*MockStore)(nil).GetByUser), arg0, arg1, arg2)
+}
+
+// GetPreference mocks base method.
+func (m *MockStore) GetPreference(arg0 *gofr.Context, arg1 int64) (*models.DigestPreference, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPreference", arg0, arg1)
+	ret0, _ := ret[0].(*models.DigestPreference)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPreference indicates an expected call of GetPreference.
+func (mr *MockStoreMockRecorder) GetPreference(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPreference", reflect.TypeOf((*MockStore)(nil).GetPreference), arg0, arg1)
+}
+
+// SaveAttempt mocks base method.
+func (m *MockStore) SaveAttempt(arg0 *gofr.Context, arg1 *models.Digest) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveAttempt", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SaveAttempt indicates an expected call of SaveAttempt.
+func (mr *MockStoreMockRecorder) SaveAttempt(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveAttempt", reflect.TypeOf((*MockStore)(nil).SaveAttempt), arg0, arg1)
+}
+
+// SavePreference mocks base method.
+func (m *MockStore) SavePreference(arg0 *gofr.Context, arg1 *models.DigestPreference) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SavePreference", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SavePreference indicates an expected call of SavePreference.
+func (mr *MockStoreMockRecorder) SavePreference(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SavePreference", reflect.TypeOf((*MockStore)(nil).SavePreference), arg0, arg1)
+}
+
+// Scheduled mocks base method.
+func (m *MockStore) Scheduled(arg0 *gofr.Context) ([]models.DigestPreference, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Scheduled", arg0)
+	ret0, _ := ret[0].([]models.DigestPreference)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Scheduled indicates an expected call of Scheduled.
+func (mr *MockStoreMockRecorder) Scheduled(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Scheduled", reflect.TypeOf((*MockStore)(nil).Scheduled), arg0)
+}
+
+// MockTaskStore is a mock of TaskStore interface.
+type MockTaskStore struct {
+	ctrl     *gomock.Controller
+	recorder *MockTaskStoreMockRecorder
+	isgomock struct{}
+}
+
+// MockTaskStoreMockRecorder is the mock recorder for MockTaskStore.
+type MockTaskStoreMockRecorder struct {
+	mock *MockTaskStore
+}
+
+// NewMockTaskStore creates a new mock instance.
+func NewMockTaskStore(ctrl *gomock.Controller) *MockTaskStore {
+	mock := &MockTaskStore{ctrl: ctrl}
+	mock.recorder = &MockTaskStoreMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTaskStore) EXPECT() *MockTaskStoreMockRecorder {
+	return m.recorder
+}
+
+// Digest mocks base method.
+func (m *MockTaskStore) Digest(arg0 *gofr.Context, arg1 int64, arg2 *models.DigestWindow, arg3 int) (*models.DigestTasks, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Digest", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(*models.DigestTasks)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Digest indicates an expected call of Digest.
+func (mr *MockTaskStoreMockRecorder) Digest(arg0, arg1, arg2, arg3 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Digest", reflect.TypeOf((*MockTaskStore)(nil).Digest), arg0, arg1, arg2, arg3)
+}
+
+// MockUserService is a mock of UserService interface.
+type MockUserService struct {
+	ctrl     *gomock.Controller
+	recorder *MockUserServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockUserServiceMockRecorder is the mock recorder for MockUserService.
+type MockUserServiceMockRecorder struct {
+	mock *MockUserService
+}
+
+// NewMockUserService creates a new mock instance.
+func NewMockUserService(ctrl *gomock.Controller) *MockUserService {
+	mock := &MockUserService{ctrl: ctrl}
+	mock.recorder = &MockUserServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockUserService) EXPECT() *MockUserServiceMockRecorder {
+	return m.recorder
+}
+
+// GetByID mocks base method.
+func (m *MockUserService) GetByID(arg0 *gofr.Context, arg1 int64) (*models.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByID", arg0, arg1)
+	ret0, _ := ret[0].(*models.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByID indicates an expected call of GetByID.
+func (mr *MockUserServiceMockRecorder) GetByID(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockUserService)(nil).GetByID), arg0, arg1)
+}
+
+// MockMailer is a mock of Mailer interface.
+type MockMailer struct {
+	ctrl     *gomock.Controller
+	recorder *MockMailerMockRecorder
+	isgomock struct{}
+}
+
+// MockMailerMockRecorder is the mock recorder for MockMailer.
+type MockMailerMockRecorder struct {
+	mock *MockMailer
+}
+
+// NewMockMailer creates a new mock instance.
+func NewMockMailer(ctrl *gomock.Controller) *MockMailer {
+	mock := &MockMailer{ctrl: ctrl}
+	mock.recorder = &MockMailerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockMailer) EXPECT() *MockMailerMockRecorder {
+	return m.recorder
+}
+
+// Send mocks base method.
+func (m *MockMailer) Send(arg0 *gofr.Context, arg1 *mail.Message) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Send", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Send indicates an expected call of Send.
+func (mr *MockMailerMockRecorder) Send(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Send", reflect.TypeOf((*MockMailer)(nil).Send), arg0, arg1)
+}
@@ -0,0 +1,120 @@
+package digest
+
+import (
+	"bytes"
+	htmltemplate "html/template"
+	"text/template"
+	"time"
+
+	"TaskManager2/mail"
+	"TaskManager2/models"
+)
+
+const dueLayout = "Mon 2 Jan 15:04"
+
+const textTemplate = `Hi {{.Name}},
+
+Here is your {{.Frequency}} digest for {{.Date}}.
+{{range .Sections}}
+{{.Title}} ({{len .Tasks}})
+{{range .Tasks}}  - {{.Title}}{{if .Due}} (due {{.Due}}){{end}}
+{{else}}  None
+{{end}}{{end}}`
+
+const htmlTemplate = `<!DOCTYPE html>
+<html>
+<body>
+<p>Hi {{.Name}},</p>
+<p>Here is your {{.Frequency}} digest for {{.Date}}.</p>
+{{range .Sections}}<h3>{{.Title}} ({{len .Tasks}})</h3>
+{{if .Tasks}}<ul>
+{{range .Tasks}}<li>{{.Title}}{{if .Due}} <small>(due {{.Due}})</small>{{end}}</li>
+{{end}}</ul>
+{{else}}<p>None</p>
+{{end}}{{end}}</body>
+</html>
+`
+
+type renderer struct {
+	text *template.Template
+	html *htmltemplate.Template
+}
+
+func newRenderer() *renderer {
+	return &renderer{
+		text: template.Must(template.New("digest.txt").Parse(textTemplate)),
+		html: htmltemplate.Must(htmltemplate.New("digest.html").Parse(htmlTemplate)),
+	}
+}
+
+type page struct {
+	Name      string
+	Frequency string
+	Date      string
+	Sections  []section
+}
+
+type section struct {
+	Title string
+	Tasks []item
+}
+
+type item struct {
+	Title string
+	Due   string
+}
+
+// message renders the digest as plain text and HTML, with due dates in the
+// user's time zone.
+func (r *renderer) message(user *models.User, d *models.Digest, tasks *models.DigestTasks, loc *time.Location) (*mail.Message, error) {
+	due := "Due today"
+	if d.Frequency == models.DigestWeekly {
+		due = "Due this week"
+	}
+
+	p := page{
+		Name:      user.Name,
+		Frequency: d.Frequency,
+		Date:      d.PeriodEnd.In(loc).Format("Monday 2 January 2006"),
+		Sections: []section{
+			{due, items(tasks.Due, loc)},
+			{"Overdue", items(tasks.Overdue, loc)},
+			{"Completed", items(tasks.Completed, loc)},
+			{"Newly assigned", items(tasks.Assigned, loc)},
+		},
+	}
+
+	var text, html bytes.Buffer
+
+	err := r.text.Execute(&text, p)
+	if err != nil {
+		return nil, err
+	}
+
+	err = r.html.Execute(&html, p)
+	if err != nil {
+		return nil, err
+	}
+
+	return &mail.Message{
+		To:      user.Email,
+		Subject: "Your " + d.Frequency + " task digest for " + p.Date,
+		Text:    text.String(),
+		HTML:    html.String(),
+	}, nil
+}
+
+func items(tasks []models.Task, loc *time.Location) []item {
+	list := make([]item, 0, len(tasks))
+
+	for _, t := range tasks {
+		i := item{Title: t.Title}
+		if t.DueDate != nil {
+			i.Due = t.DueDate.In(loc).Format(dueLayout)
+		}
+
+		list = append(list, i)
+	}
+
+	return list
+}
@@ -0,0 +1,313 @@
+package digest
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"gofr.dev/pkg/gofr"
+
+	"TaskManager2/apperr"
+	"TaskManager2/models"
+	"TaskManager2/utils"
+	"TaskManager2/validate"
+)
+
+const (
+	// A digest is attempted maxAttempts times, retryDelay apart.
+	maxAttempts = 3
+	retryDelay  = 10 * time.Minute
+
+	// claimDuration outlasts the SMTP timeout, so a digest is only sent again
+	// by another replica when the one that claimed it has died.
+	claimDuration = 5 * time.Minute
+
+	sectionLimit   = 50
+	maxErrorLength = 500
+
+	defaultLimit = 20
+	maxLimit     = 100
+
+	clockLayout = "15:04"
+)
+
+type service struct {
+	store  Store
+	tasks  TaskStore
+	users  UserService
+	mailer Mailer
+	render *renderer
+}
+
+// New returns a service sending digests through mailer. Without a mailer,
+// digests can only be turned off.
+func New(store Store, tasks TaskStore, users UserService, mailer Mailer) *service {
+	return &service{store: store, tasks: tasks, users: users, mailer: mailer, render: newRenderer()}
+}
+
+// GetPreference returns the digest preference of the user, which is off at
+// 08:00 UTC until the user sets one.
+func (s *service) GetPreference(ctx *gofr.Context, userID int64) (*models.DigestPreference, error) {
+	_, err := s.users.GetByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	p, err := s.store.GetPreference(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if p == nil {
+		p = &models.DigestPreference{UserID: userID, Frequency: models.DigestOff, TimeOfDay: "08:00", Timezone: "UTC"}
+	}
+
+	return p, nil
+}
+
+func (s *service) SavePreference(ctx *gofr.Context, p *models.DigestPreference) (*models.DigestPreference, error) {
+	err := s.check(p)
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = s.users.GetByID(ctx, p.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	saved := *p
+	saved.Weekday = strings.ToLower(p.Weekday)
+	saved.UpdatedAt = time.Now().UTC().Truncate(time.Second)
+
+	if saved.Frequency != models.DigestWeekly {
+		saved.Weekday = ""
+	}
+
+	err = s.store.SavePreference(ctx, &saved)
+	if err != nil {
+		return nil, err
+	}
+
+	return &saved, nil
+}
+
+func (s *service) check(p *models.DigestPreference) error {
+	err := validate.Struct(p)
+	if err != nil {
+		return err
+	}
+
+	var invalid []apperr.FieldError
+
+	switch p.Frequency {
+	case models.DigestOff:
+	case models.DigestDaily, models.DigestWeekly:
+		if s.mailer == nil {
+			invalid = append(invalid, apperr.Field("frequency", "must be off, email is not configured"))
+		}
+	default:
+		invalid = append(invalid, apperr.Field("frequency", "must be one of off, daily, weekly"))
+	}
+
+	if _, err := time.Parse(clockLayout, p.TimeOfDay); err != nil {
+		invalid = append(invalid, apperr.Field("time_of_day", "must be a time as HH:MM"))
+	}
+
+	if _, err := time.LoadLocation(p.Timezone); err != nil || p.Timezone == "Local" {
+		invalid = append(invalid, apperr.Field("timezone", "must be an IANA time zone name"))
+	}
+
+	if _, ok := weekday(p.Weekday); p.Frequency == models.DigestWeekly && !ok {
+		invalid = append(invalid, apperr.Field("weekday", "must be a day of the week"))
+	}
+
+	if len(invalid) > 0 {
+		return apperr.Validation(invalid...)
+	}
+
+	return nil
+}
+
+// GetDigests lists the digests of the user, newest first. A zero limit means
+// defaultLimit.
+func (s *service) GetDigests(ctx *gofr.Context, userID int64, limit int) ([]models.Digest, error) {
+	switch {
+	case limit == 0:
+		limit = defaultLimit
+	case limit < 0 || limit > maxLimit:
+		return nil, apperr.Validation(apperr.Field("limit", fmt.Sprintf("must be between 1 and %d", maxLimit)))
+	}
+
+	_, err := s.users.GetByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.store.GetByUser(ctx, userID, limit)
+}
+
+// Send emails the digests that are due and returns how many were sent and how
+// many failed. Digests claimed by another replica are skipped.
+func (s *service) Send(ctx *gofr.Context) (int, int, error) {
+	now := time.Now().UTC()
+
+	preferences, err := s.store.Scheduled(ctx)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	var sent, failed int
+
+	for i := range preferences {
+		p := &preferences[i]
+
+		d, ok := due(p, now)
+		if !ok {
+			continue
+		}
+
+		claimed, err := s.store.Claim(ctx, d, now)
+		if err != nil {
+			return sent, failed, err
+		}
+
+		if !claimed {
+			continue
+		}
+
+		err = s.send(ctx, p, d)
+		if err == nil {
+			sent++
+
+			continue
+		}
+
+		failed++
+
+		fail(d, err, now)
+
+		err = s.store.SaveAttempt(ctx, d)
+		if err != nil {
+			return sent, failed, err
+		}
+	}
+
+	return sent, failed, nil
+}
+
+// send emails a digest and records that it was sent. The email is sent again
+// if recording that fails.
+func (s *service) send(ctx *gofr.Context, p *models.DigestPreference, d *models.Digest) error {
+	loc, err := time.LoadLocation(p.Timezone)
+	if err != nil {
+		return err
+	}
+
+	user, err := s.users.GetByID(ctx, d.UserID)
+	if err != nil {
+		return err
+	}
+
+	tasks, err := s.tasks.Digest(ctx, d.UserID, window(d, loc), sectionLimit)
+	if err != nil {
+		return err
+	}
+
+	msg, err := s.render.message(user, d, tasks, loc)
+	if err != nil {
+		return err
+	}
+
+	err = s.mailer.Send(ctx, msg)
+	if err != nil {
+		return err
+	}
+
+	sentAt := time.Now().UTC().Truncate(time.Second)
+	d.Status = models.DigestSent
+	d.Attempts++
+	d.LastError = ""
+	d.SentAt = &sentAt
+
+	return s.store.SaveAttempt(ctx, d)
+}
+
+// fail records a failed attempt: the digest is claimed until it is due again,
+// or has failed once it has used up its attempts.
+func fail(d *models.Digest, err error, now time.Time) {
+	d.Attempts++
+	d.LastError = utils.Truncate(err.Error(), maxErrorLength)
+	d.ClaimedUntil = now.Add(retryDelay)
+
+	if d.Attempts >= maxAttempts {
+		d.Status = models.DigestFailed
+	}
+}
+
+// due returns the digest of the preference that is due at now, if any: the
+// one scheduled last, unless it was already sent or failed, or was scheduled
+// before the preference was last changed.
+func due(p *models.DigestPreference, now time.Time) (*models.Digest, bool) {
+	loc, err := time.LoadLocation(p.Timezone)
+	if err != nil {
+		return nil, false
+	}
+
+	from, at := schedule(p, loc, now)
+
+	if at.Before(p.UpdatedAt) || (p.LastDigest != nil && !at.After(*p.LastDigest)) {
+		return nil, false
+	}
+
+	return &models.Digest{
+		UserID: p.UserID, Frequency: p.Frequency, PeriodStart: from, PeriodEnd: at, ClaimedUntil: now.Add(claimDuration),
+	}, true
+}
+
+// schedule returns the latest time at or before now that the preference
+// schedules a digest at, and the scheduled time before it, in UTC.
+func schedule(p *models.DigestPreference, loc *time.Location, now time.Time) (time.Time, time.Time) {
+	clock, _ := time.Parse(clockLayout, p.TimeOfDay)
+	local := now.In(loc)
+
+	at := time.Date(local.Year(), local.Month(), local.Day(), clock.Hour(), clock.Minute(), 0, 0, loc)
+	if at.After(local) {
+		at = at.AddDate(0, 0, -1)
+	}
+
+	if p.Frequency != models.DigestWeekly {
+		return at.AddDate(0, 0, -1).UTC(), at.UTC()
+	}
+
+	day, _ := weekday(p.Weekday)
+	for at.Weekday() != day {
+		at = at.AddDate(0, 0, -1)
+	}
+
+	return at.AddDate(0, 0, -7).UTC(), at.UTC()
+}
+
+// window selects the tasks of a digest: those due on the day it is sent, or
+// in the week starting that day for weekly digests, in the user's time zone.
+func window(d *models.Digest, loc *time.Location) *models.DigestWindow {
+	days := 1
+	if d.Frequency == models.DigestWeekly {
+		days = 7
+	}
+
+	at := d.PeriodEnd.In(loc)
+	day := time.Date(at.Year(), at.Month(), at.Day(), 0, 0, 0, 0, loc)
+
+	return &models.DigestWindow{From: d.PeriodStart, To: d.PeriodEnd, DueFrom: day.UTC(), DueTo: day.AddDate(0, 0, days).UTC()}
+}
+
+func weekday(name string) (time.Weekday, bool) {
+	for day := time.Sunday; day <= time.Saturday; day++ {
+		if strings.EqualFold(day.String(), name) {
+			return day, true
+		}
+	}
+
+	return 0, false
+}
@@ -0,0 +1,204 @@
+package inbound
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"go.uber.org/mock/gomock"
+	"gofr.dev/pkg/gofr"
+	"gofr.dev/pkg/gofr/container"
+
+	"TaskManager2/apperr"
+	"TaskManager2/middleware"
+	"TaskManager2/models"
+	"TaskManager2/utils"
+)
+
+const (
+	newEmail = "From: Ada <ada@example.com>\r\nSubject: Fwd: Ship it\r\nMessage-ID: <one@example.com>\r\n" +
+		"Content-Type: multipart/mixed; boundary=b\r\n\r\n" +
+		"--b\r\nContent-Type: text/plain\r\n\r\nPlease ship it.\r\n" +
+		"--b\r\nContent-Type: text/plain\r\nContent-Disposition: attachment; filename=notes.txt\r\n\r\nhello\r\n" +
+		"--b--\r\n"
+	replyEmail = "From: ada@example.com\r\nSubject: Re: Ship it\r\nMessage-ID: <two@example.com>\r\n" +
+		"In-Reply-To: <one@example.com>\r\n\r\nShipped.\r\n\r\nOn Monday, Ada wrote:\r\n> Please ship it.\r\n"
+)
+
+func TestService_Receive(t *testing.T) {
+	mockContainer, mock := container.NewMockContainer(t)
+	ctx := &gofr.Context{
+		Context:   t.Context(),
+		Request:   nil,
+		Container: mockContainer,
+	}
+
+	controller := gomock.NewController(t)
+	mockStore := NewMockStore(controller)
+	mockAttachments := NewMockAttachmentStore(controller)
+	mockUsers := NewMockUserService(controller)
+	mockTasks := NewMockTaskService(controller)
+	mockComments := NewMockCommentService(controller)
+	inboundService := New(mockStore, mockAttachments, mockUsers, mockTasks, mockComments)
+
+	user := &models.User{ID: 1, Email: "ada@example.com"}
+	commentID := int64(4)
+
+	testcases := []struct {
+		description   string
+		raw           string
+		mockExpect    func()
+		expected      *models.InboundEmail
+		expectedError error
+	}{
+		{
+			"new task with an attachment",
+			newEmail,
+			func() {
+				mockUsers.EXPECT().GetByEmail(ctx, "ada@example.com").Return(user, nil)
+				mock.SQL.ExpectBegin()
+				mockStore.EXPECT().GetByMessageID(ctx, "one@example.com").Return(nil, nil)
+				mockStore.EXPECT().FindTask(ctx, nil).Return(int64(0), nil)
+				mockTasks.EXPECT().Create(ctx, &models.Task{Title: "Ship it", Description: "Please ship it.", UserID: 1}).
+					DoAndReturn(func(c *gofr.Context, _ *models.Task) (int64, error) {
+						if actor := middleware.Actor(c); actor != "1" {
+							t.Errorf("expected the sender as actor, got %q", actor)
+						}
+
+						return 9, nil
+					})
+				mockAttachments.EXPECT().Create(ctx, gomock.Any()).DoAndReturn(func(_ *gofr.Context, a *models.Attachment) (int64, error) {
+					if a.TaskID != 9 || a.CommentID != nil || a.Filename != "notes.txt" || string(a.Content) != "hello" || a.Size != 5 {
+						t.Errorf("unexpected attachment %+v", a)
+					}
+
+					return 2, nil
+				})
+				mockStore.EXPECT().Create(ctx, gomock.Any()).Return(int64(5), nil)
+				mock.SQL.ExpectCommit()
+			},
+			&models.InboundEmail{ID: 5, MessageID: "one@example.com", UserID: 1, TaskID: 9, Attachments: []models.Attachment{
+				{ID: 2, TaskID: 9, Filename: "notes.txt", ContentType: "text/plain", Size: 5},
+			}},
+			nil,
+		},
+		{
+			"reply becomes a comment",
+			replyEmail,
+			func() {
+				mockUsers.EXPECT().GetByEmail(ctx, "ada@example.com").Return(user, nil)
+				mock.SQL.ExpectBegin()
+				mockStore.EXPECT().GetByMessageID(ctx, "two@example.com").Return(nil, nil)
+				mockStore.EXPECT().FindTask(ctx, []string{"one@example.com"}).Return(int64(9), nil)
+				mockComments.EXPECT().Create(ctx, &models.Comment{TaskID: 9, Body: "Shipped."}).
+					Return(&models.Comment{ID: 4, TaskID: 9, Body: "Shipped."}, nil)
+				mockStore.EXPECT().Create(ctx, gomock.Any()).Return(int64(6), nil)
+				mock.SQL.ExpectCommit()
+			},
+			&models.InboundEmail{ID: 6, MessageID: "two@example.com", UserID: 1, TaskID: 9, CommentID: &commentID},
+			nil,
+		},
+		{
+			"redelivered",
+			replyEmail,
+			func() {
+				mockUsers.EXPECT().GetByEmail(ctx, "ada@example.com").Return(user, nil)
+				mock.SQL.ExpectBegin()
+				mockStore.EXPECT().GetByMessageID(ctx, "two@example.com").
+					Return(&models.InboundEmail{ID: 6, MessageID: "two@example.com", UserID: 1, TaskID: 9, CommentID: &commentID}, nil)
+				mock.SQL.ExpectCommit()
+			},
+			&models.InboundEmail{ID: 6, MessageID: "two@example.com", UserID: 1, TaskID: 9, CommentID: &commentID},
+			nil,
+		},
+		{
+			"comment fails",
+			replyEmail,
+			func() {
+				mockUsers.EXPECT().GetByEmail(ctx, "ada@example.com").Return(user, nil)
+				mock.SQL.ExpectBegin()
+				mockStore.EXPECT().GetByMessageID(ctx, "two@example.com").Return(nil, nil)
+				mockStore.EXPECT().FindTask(ctx, []string{"one@example.com"}).Return(int64(9), nil)
+				mockComments.EXPECT().Create(ctx, gomock.Any()).Return(nil, utils.ErrTest)
+				mock.SQL.ExpectRollback()
+			},
+			nil,
+			utils.ErrTest,
+		},
+		{
+			"unknown sender",
+			newEmail,
+			func() {
+				mockUsers.EXPECT().GetByEmail(ctx, "ada@example.com").Return(nil, apperr.NotFound("user", "ada@example.com"))
+			},
+			nil,
+			apperr.Forbidden("ada@example.com is not the email of a user"),
+		},
+		{
+			"not an email",
+			"Hello",
+			func() {},
+			nil,
+			errInvalidMessage,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.description, func(t *testing.T) {
+			tc.mockExpect()
+
+			received, err := inboundService.Receive(ctx, []byte(tc.raw))
+			if !errors.Is(err, tc.expectedError) {
+				t.Fatalf("expected %v, got %v", tc.expectedError, err)
+			}
+
+			if received != nil {
+				for i := range received.Attachments {
+					received.Attachments[i].CreatedAt = received.CreatedAt
+				}
+
+				if tc.expected != nil {
+					for i := range tc.expected.Attachments {
+						tc.expected.Attachments[i].CreatedAt = received.CreatedAt
+					}
+
+					tc.expected.CreatedAt = received.CreatedAt
+				}
+			}
+
+			if !reflect.DeepEqual(received, tc.expected) {
+				t.Errorf("expected %+v, got %+v", tc.expected, received)
+			}
+
+			if middleware.Actor(ctx) != "" {
+				t.Errorf("expected the actor to be restored, got %q", middleware.Actor(ctx))
+			}
+		})
+	}
+}
+
+func TestTitle(t *testing.T) {
+	for subject, expected := range map[string]string{
+		"Ship it":             "Ship it",
+		"RE: Fwd: re:Ship it": "Ship it",
+		"  Re:  ":             noSubject,
+		"":                    noSubject,
+	} {
+		if got := title(subject); got != expected {
+			t.Errorf("expected %q for %q, got %q", expected, subject, got)
+		}
+	}
+}
+
+func TestReply(t *testing.T) {
+	for text, expected := range map[string]string{
+		"Shipped.\n\nOn Monday, Ada wrote:\n> Please ship it.":           "Shipped.",
+		"Shipped.\n> quoted\nThanks":                                     "Shipped.\nThanks",
+		"Done\n\n-----Original Message-----\nFrom: Ada\nPlease ship it.": "Done",
+		"> only quoted": noText,
+	} {
+		if got := reply(text); got != expected {
+			t.Errorf("expected %q for %q, got %q", expected, text, got)
+		}
+	}
+}
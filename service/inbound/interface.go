@@ -0,0 +1,29 @@
+package inbound
+
+import (
+	"gofr.dev/pkg/gofr"
+
+	"TaskManager2/models"
+)
+
+type Store interface {
+	GetByMessageID(*gofr.Context, string) (*models.InboundEmail, error)
+	FindTask(*gofr.Context, []string) (int64, error)
+	Create(*gofr.Context, *models.InboundEmail) (int64, error)
+}
+
+type AttachmentStore interface {
+	Create(*gofr.Context, *models.Attachment) (int64, error)
+}
+
+type UserService interface {
+	GetByEmail(*gofr.Context, string) (*models.User, error)
+}
+
+type TaskService interface {
+	Create(*gofr.Context, *models.Task) (int64, error)
+}
+
+type CommentService interface {
+	Create(*gofr.Context, *models.Comment) (*models.Comment, error)
+}
@@ -0,0 +1,206 @@
+package inbound
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"gofr.dev/pkg/gofr"
+
+	"TaskManager2/apperr"
+	"TaskManager2/mail"
+	"TaskManager2/middleware"
+	"TaskManager2/models"
+	"TaskManager2/utils"
+)
+
+const (
+	// The limits of the task and comment models, which longer emails are
+	// truncated to.
+	maxTitleLength       = 150
+	maxDescriptionLength = 10000
+	maxCommentLength     = 5000
+
+	// filename and content_type columns hold at most this many characters.
+	maxFilenameLength = 255
+
+	noSubject = "(no subject)"
+	noText    = "(no text)"
+)
+
+var errInvalidMessage = apperr.Validation(apperr.Field("message", "must be an RFC 822 email with a From address"))
+
+type service struct {
+	store       Store
+	attachments AttachmentStore
+	users       UserService
+	tasks       TaskService
+	comments    CommentService
+}
+
+func New(store Store, attachments AttachmentStore, users UserService, tasks TaskService, comments CommentService) *service {
+	return &service{store: store, attachments: attachments, users: users, tasks: tasks, comments: comments}
+}
+
+// Receive turns a raw email from a user into a task assigned to them, with
+// the subject as title and the body as description. A reply to an email that
+// became a task is added to that task as a comment instead, without the
+// quoted text. Attachments are stored with the task or comment.
+//
+// The sender is the actor of the changes, and everything is recorded in one
+// transaction with the message ID, so a redelivered email returns the email
+// recorded the first time and changes nothing.
+func (s *service) Receive(ctx *gofr.Context, raw []byte) (*models.InboundEmail, error) {
+	msg, err := mail.Parse(raw)
+	if err != nil {
+		return nil, errInvalidMessage
+	}
+
+	user, err := s.users.GetByEmail(ctx, msg.From)
+	if apperr.CodeOf(err) == apperr.CodeNotFound {
+		return nil, apperr.Forbidden(fmt.Sprintf("%s is not the email of a user", msg.From))
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	parent := ctx.Context
+	ctx.Context = middleware.WithMetadata(parent, strconv.FormatInt(user.ID, 10), middleware.RequestID(parent))
+
+	defer func() { ctx.Context = parent }()
+
+	var received *models.InboundEmail
+
+	err = utils.WithTx(ctx, func() error {
+		if msg.MessageID != "" {
+			received, err = s.store.GetByMessageID(ctx, msg.MessageID)
+			if err != nil || received != nil {
+				return err
+			}
+		}
+
+		received, err = s.record(ctx, user, msg)
+
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return received, nil
+}
+
+// record adds the task or comment of the email and its attachments.
+func (s *service) record(ctx *gofr.Context, user *models.User, msg *mail.Received) (*models.InboundEmail, error) {
+	taskID, err := s.store.FindTask(ctx, msg.References)
+	if err != nil {
+		return nil, err
+	}
+
+	e := models.InboundEmail{MessageID: msg.MessageID, UserID: user.ID, TaskID: taskID, CreatedAt: time.Now().UTC().Truncate(time.Second)}
+
+	if taskID == 0 {
+		e.TaskID, err = s.tasks.Create(ctx, &models.Task{
+			Title:       title(msg.Subject),
+			Description: utils.Truncate(msg.Text, maxDescriptionLength),
+			UserID:      user.ID,
+		})
+	} else {
+		var c *models.Comment
+
+		c, err = s.comments.Create(ctx, &models.Comment{TaskID: taskID, Body: utils.Truncate(reply(msg.Text), maxCommentLength)})
+		if c != nil {
+			e.CommentID = &c.ID
+		}
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	for _, a := range msg.Attachments {
+		attachment := models.Attachment{
+			TaskID:      e.TaskID,
+			CommentID:   e.CommentID,
+			Filename:    filename(a.Filename),
+			ContentType: utils.Truncate(a.ContentType, maxFilenameLength),
+			Size:        int64(len(a.Content)),
+			Content:     a.Content,
+			CreatedAt:   e.CreatedAt,
+		}
+
+		attachment.ID, err = s.attachments.Create(ctx, &attachment)
+		if err != nil {
+			return nil, err
+		}
+
+		attachment.Content = nil
+		e.Attachments = append(e.Attachments, attachment)
+	}
+
+	e.ID, err = s.store.Create(ctx, &e)
+	if err != nil {
+		return nil, err
+	}
+
+	return &e, nil
+}
+
+// title is the subject without reply and forward prefixes.
+func title(subject string) string {
+	subject = strings.TrimSpace(subject)
+
+	for stripped := true; stripped; {
+		stripped = false
+
+		for _, prefix := range []string{"re:", "fwd:", "fw:"} {
+			if len(subject) >= len(prefix) && strings.EqualFold(subject[:len(prefix)], prefix) {
+				subject = strings.TrimSpace(subject[len(prefix):])
+				stripped = true
+			}
+		}
+	}
+
+	if subject == "" {
+		return noSubject
+	}
+
+	return utils.Truncate(subject, maxTitleLength)
+}
+
+// reply is the text of a reply without the quoted email: lines starting with
+// ">", and everything from an attribution line such as "On ... wrote:" or an
+// Outlook "Original Message" separator on.
+func reply(text string) string {
+	var lines []string
+
+	for _, line := range strings.Split(text, "\n") {
+		trimmed := strings.TrimSpace(line)
+		if strings.HasPrefix(trimmed, "On ") && strings.HasSuffix(trimmed, "wrote:") ||
+			strings.Contains(trimmed, "Original Message") && strings.HasPrefix(trimmed, "-----") {
+			break
+		}
+
+		if !strings.HasPrefix(trimmed, ">") {
+			lines = append(lines, line)
+		}
+	}
+
+	text = strings.TrimSpace(strings.Join(lines, "\n"))
+	if text == "" {
+		return noText
+	}
+
+	return text
+}
+
+func filename(name string) string {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return "attachment"
+	}
+
+	return utils.Truncate(name, maxFilenameLength)
+}
@@ -0,0 +1,18 @@
+package notification
+
+import (
+	"time"
+
+	"gofr.dev/pkg/gofr"
+
+	"TaskManager2/models"
+)
+
+type Store interface {
+	GetByUser(*gofr.Context, int64, bool, int) ([]models.Notification, error)
+	MarkRead(*gofr.Context, int64, int64, time.Time) error
+}
+
+type UserService interface {
+	GetByID(*gofr.Context, int64) (*models.User, error)
+}
@@ -0,0 +1,105 @@
+package notification
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"go.uber.org/mock/gomock"
+	"gofr.dev/pkg/gofr"
+
+	"TaskManager2/apperr"
+	"TaskManager2/models"
+	"TaskManager2/utils"
+)
+
+func TestService_GetByUser(t *testing.T) {
+	var ctx *gofr.Context
+
+	controller := gomock.NewController(t)
+	mockStore := NewMockStore(controller)
+	mockUsers := NewMockUserService(controller)
+	notificationService := New(mockStore, mockUsers)
+
+	notifications := []models.Notification{{ID: 2, UserID: 1, TaskID: 9, ReminderID: 3, Message: "Reminder: Ship it"}}
+
+	testcases := []struct {
+		description   string
+		unread        bool
+		limit         int
+		mockExpect    func()
+		expected      []models.Notification
+		expectedError error
+	}{
+		{
+			"default limit",
+			true,
+			0,
+			func() {
+				mockUsers.EXPECT().GetByID(ctx, int64(1)).Return(&models.User{ID: 1}, nil)
+				mockStore.EXPECT().GetByUser(ctx, int64(1), true, 50).Return(notifications, nil)
+			},
+			notifications,
+			nil,
+		},
+		{
+			"limit too large",
+			false,
+			501,
+			func() {},
+			nil,
+			apperr.Validation(apperr.Field("limit", "must be between 1 and 500")),
+		},
+		{
+			"unknown user",
+			false,
+			10,
+			func() {
+				mockUsers.EXPECT().GetByID(ctx, int64(1)).Return(nil, apperr.NotFound("user", 1))
+			},
+			nil,
+			apperr.NotFound("user", 1),
+		},
+		{
+			"store error",
+			false,
+			10,
+			func() {
+				mockUsers.EXPECT().GetByID(ctx, int64(1)).Return(&models.User{ID: 1}, nil)
+				mockStore.EXPECT().GetByUser(ctx, int64(1), false, 10).Return(nil, utils.ErrTest)
+			},
+			nil,
+			utils.ErrTest,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.description, func(t *testing.T) {
+			tc.mockExpect()
+
+			res, err := notificationService.GetByUser(ctx, 1, tc.unread, tc.limit)
+			if !errors.Is(err, tc.expectedError) {
+				t.Errorf("expected %v, got %v", tc.expectedError, err)
+			}
+
+			if !reflect.DeepEqual(res, tc.expected) {
+				t.Errorf("expected %+v, got %+v", tc.expected, res)
+			}
+		})
+	}
+}
+
+func TestService_MarkRead(t *testing.T) {
+	var ctx *gofr.Context
+
+	controller := gomock.NewController(t)
+	mockStore := NewMockStore(controller)
+	notificationService := New(mockStore, NewMockUserService(controller))
+
+	mockStore.EXPECT().MarkRead(ctx, int64(1), int64(2), gomock.Any()).Return(apperr.NotFound("notification", 2))
+
+	err := notificationService.MarkRead(ctx, 1, 2)
+	if !errors.Is(err, apperr.NotFound("notification", 2)) {
+		t.Errorf("expected not found, got %v", err)
+	}
+}
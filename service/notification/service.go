@@ -0,0 +1,47 @@
+package notification
+
+import (
+	"fmt"
+	"time"
+
+	"gofr.dev/pkg/gofr"
+
+	"TaskManager2/apperr"
+	"TaskManager2/models"
+)
+
+const (
+	defaultLimit = 50
+	maxLimit     = 500
+)
+
+type service struct {
+	store Store
+	users UserService
+}
+
+func New(store Store, users UserService) *service {
+	return &service{store: store, users: users}
+}
+
+// GetByUser lists the in-app notifications of a user, newest first. A zero
+// limit means defaultLimit.
+func (s *service) GetByUser(ctx *gofr.Context, userID int64, unread bool, limit int) ([]models.Notification, error) {
+	switch {
+	case limit == 0:
+		limit = defaultLimit
+	case limit < 0 || limit > maxLimit:
+		return nil, apperr.Validation(apperr.Field("limit", fmt.Sprintf("must be between 1 and %d", maxLimit)))
+	}
+
+	_, err := s.users.GetByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.store.GetByUser(ctx, userID, unread, limit)
+}
+
+func (s *service) MarkRead(ctx *gofr.Context, userID, id int64) error {
+	return s.store.MarkRead(ctx, userID, id, time.Now().UTC().Truncate(time.Second))
+}
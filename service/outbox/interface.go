@@ -0,0 +1,17 @@
+package outbox
+
+import (
+	"time"
+
+	"gofr.dev/pkg/gofr"
+
+	"TaskManager2/models"
+)
+
+type Store interface {
+	Create(*gofr.Context, *models.OutboxMessage) (int64, error)
+	Due(*gofr.Context, time.Time, int) ([]models.OutboxMessage, error)
+	Claim(*gofr.Context, int64, time.Time, time.Time) (bool, error)
+	SaveAttempt(*gofr.Context, *models.OutboxMessage) error
+	Purge(*gofr.Context, time.Time) (int64, error)
+}
@@ -0,0 +1,176 @@
+package outbox
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"go.uber.org/mock/gomock"
+	"gofr.dev/pkg/gofr"
+
+	"TaskManager2/models"
+	"TaskManager2/utils"
+)
+
+type publisher struct {
+	fail     map[string]bool
+	messages [][]byte
+}
+
+func (p *publisher) Publish(_ context.Context, topic string, message []byte) error {
+	if topic != "events" {
+		return errors.New("unexpected topic " + topic)
+	}
+
+	if p.fail[string(message)] {
+		return utils.ErrTest
+	}
+
+	p.messages = append(p.messages, message)
+
+	return nil
+}
+
+func TestService_Record(t *testing.T) {
+	var ctx *gofr.Context
+
+	controller := gomock.NewController(t)
+	mockStore := NewMockStore(controller)
+	outboxService := New(mockStore, "events")
+	event := &models.Event{ID: "e1", Name: "task.created", Data: &models.Task{ID: 9, Title: "release"}}
+
+	mockStore.EXPECT().Create(ctx, gomock.Any()).DoAndReturn(func(_ *gofr.Context, m *models.OutboxMessage) (int64, error) {
+		var e models.Event
+
+		err := json.Unmarshal(m.Payload, &e)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if m.EventID != "e1" || m.Event != "task.created" || e.ID != "e1" || m.NextAttemptAt.IsZero() {
+			t.Errorf("unexpected message %+v", m)
+		}
+
+		return 1, nil
+	})
+
+	err := outboxService.Record(ctx, event)
+	if err != nil {
+		t.Error(err)
+	}
+
+	mockStore.EXPECT().Create(ctx, gomock.Any()).Return(int64(0), utils.ErrTest)
+
+	err = outboxService.Record(ctx, event)
+	if !errors.Is(err, utils.ErrTest) {
+		t.Errorf("expected %v, got %v", utils.ErrTest, err)
+	}
+}
+
+func TestService_Relay(t *testing.T) {
+	var ctx *gofr.Context
+
+	controller := gomock.NewController(t)
+	mockStore := NewMockStore(controller)
+	outboxService := New(mockStore, "events")
+	pub := &publisher{fail: map[string]bool{`{"id":"e2"}`: true}}
+
+	due := []models.OutboxMessage{
+		{ID: 1, EventID: "e1", Payload: []byte(`{"id":"e1"}`)},
+		{ID: 2, EventID: "e2", Payload: []byte(`{"id":"e2"}`), Attempts: 1},
+		{ID: 3, EventID: "e3", Payload: []byte(`{"id":"e3"}`)},
+	}
+
+	mockStore.EXPECT().Due(ctx, gomock.Any(), 10).Return(due, nil)
+	mockStore.EXPECT().Claim(ctx, int64(1), gomock.Any(), gomock.Any()).Return(true, nil)
+	mockStore.EXPECT().SaveAttempt(ctx, gomock.Any()).DoAndReturn(func(_ *gofr.Context, m *models.OutboxMessage) error {
+		if m.ID != 1 || m.PublishedAt == nil || m.Attempts != 1 {
+			t.Errorf("expected message 1 published, got %+v", m)
+		}
+
+		return nil
+	})
+	mockStore.EXPECT().Claim(ctx, int64(2), gomock.Any(), gomock.Any()).Return(true, nil)
+	mockStore.EXPECT().SaveAttempt(ctx, gomock.Any()).DoAndReturn(func(_ *gofr.Context, m *models.OutboxMessage) error {
+		if m.ID != 2 || m.PublishedAt != nil || m.Attempts != 2 || m.LastError != utils.ErrTest.Error() {
+			t.Errorf("expected message 2 to fail, got %+v", m)
+		}
+
+		if wait := time.Until(m.NextAttemptAt); wait < 9*time.Second || wait > 10*time.Second {
+			t.Errorf("expected a retry in 10s, got %v", wait)
+		}
+
+		return nil
+	})
+	mockStore.EXPECT().Claim(ctx, int64(3), gomock.Any(), gomock.Any()).Return(false, nil)
+
+	published, failed, err := outboxService.Relay(ctx, pub, 10)
+	if err != nil || published != 1 || failed != 1 {
+		t.Errorf("expected 1 published and 1 failed, got %d, %d, %v", published, failed, err)
+	}
+
+	if len(pub.messages) != 1 || string(pub.messages[0]) != `{"id":"e1"}` {
+		t.Errorf("expected only e1 published, got %q", pub.messages)
+	}
+}
+
+func TestService_RelayErrors(t *testing.T) {
+	var ctx *gofr.Context
+
+	controller := gomock.NewController(t)
+	mockStore := NewMockStore(controller)
+	outboxService := New(mockStore, "events")
+	due := []models.OutboxMessage{{ID: 1, Payload: []byte(`{}`)}}
+
+	tests := []struct {
+		description string
+		mockExpect  func()
+	}{
+		{"due error", func() {
+			mockStore.EXPECT().Due(ctx, gomock.Any(), 10).Return(nil, utils.ErrTest)
+		}},
+		{"claim error", func() {
+			mockStore.EXPECT().Due(ctx, gomock.Any(), 10).Return(due, nil)
+			mockStore.EXPECT().Claim(ctx, int64(1), gomock.Any(), gomock.Any()).Return(false, utils.ErrTest)
+		}},
+		{"save error", func() {
+			mockStore.EXPECT().Due(ctx, gomock.Any(), 10).Return(due, nil)
+			mockStore.EXPECT().Claim(ctx, int64(1), gomock.Any(), gomock.Any()).Return(true, nil)
+			mockStore.EXPECT().SaveAttempt(ctx, gomock.Any()).Return(utils.ErrTest)
+		}},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.description, func(t *testing.T) {
+			tc.mockExpect()
+
+			_, _, err := outboxService.Relay(ctx, &publisher{}, 10)
+			if !errors.Is(err, utils.ErrTest) {
+				t.Errorf("expected %v, got %v", utils.ErrTest, err)
+			}
+		})
+	}
+}
+
+func TestService_Purge(t *testing.T) {
+	var ctx *gofr.Context
+
+	controller := gomock.NewController(t)
+	mockStore := NewMockStore(controller)
+	outboxService := New(mockStore, "events")
+
+	mockStore.EXPECT().Purge(ctx, gomock.Any()).DoAndReturn(func(_ *gofr.Context, before time.Time) (int64, error) {
+		if age := time.Since(before); age < time.Hour || age > time.Hour+time.Minute {
+			t.Errorf("expected messages published an hour ago, got %v", before)
+		}
+
+		return 4, nil
+	})
+
+	purged, err := outboxService.Purge(ctx, time.Hour)
+	if err != nil || purged != 4 {
+		t.Errorf("expected 4 purged, got %d, %v", purged, err)
+	}
+}
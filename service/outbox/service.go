@@ -0,0 +1,105 @@
+package outbox
+
+import (
+	"encoding/json"
+	"time"
+
+	"gofr.dev/pkg/gofr"
+	"gofr.dev/pkg/gofr/datasource/pubsub"
+
+	"TaskManager2/models"
+	"TaskManager2/utils"
+)
+
+const (
+	// A message is retried until the broker accepts it, backing off from
+	// firstRetry to maxRetry.
+	firstRetry = 5 * time.Second
+	maxRetry   = 5 * time.Minute
+
+	// claimDuration outlasts a publish, so a message is only published again
+	// by another relay when the one that claimed it has died.
+	claimDuration = time.Minute
+
+	maxErrorLength = 500
+)
+
+type service struct {
+	store Store
+	topic string
+}
+
+// New returns an outbox that relays events to the given topic.
+func New(store Store, topic string) *service {
+	return &service{store: store, topic: topic}
+}
+
+// Record writes the event to the outbox. It joins the transaction on ctx, so
+// the event is kept exactly when the change it reports is committed.
+func (s *service) Record(ctx *gofr.Context, e *models.Event) error {
+	payload, err := json.Marshal(e)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now().UTC().Truncate(time.Second)
+
+	_, err = s.store.Create(ctx, &models.OutboxMessage{EventID: e.ID, Event: e.Name, Payload: payload, NextAttemptAt: now, CreatedAt: now})
+
+	return err
+}
+
+// Relay publishes up to limit due messages and returns how many were
+// published and how many failed. Delivery is at least once: a message is only
+// marked published after the broker has accepted it, so a crash in between
+// publishes it again. Consumers drop duplicates by the event ID.
+func (s *service) Relay(ctx *gofr.Context, publisher pubsub.Publisher, limit int) (int, int, error) {
+	now := time.Now().UTC()
+
+	due, err := s.store.Due(ctx, now, limit)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	var published, failed int
+
+	for i := range due {
+		m := &due[i]
+
+		claimed, err := s.store.Claim(ctx, m.ID, now, now.Add(claimDuration))
+		if err != nil {
+			return published, failed, err
+		}
+
+		if !claimed {
+			continue
+		}
+
+		m.Attempts++
+
+		err = publisher.Publish(ctx, s.topic, m.Payload)
+		if err != nil {
+			m.NextAttemptAt = time.Now().UTC().Add(utils.Backoff(m.Attempts, firstRetry, maxRetry))
+			m.LastError = utils.Truncate(err.Error(), maxErrorLength)
+			failed++
+		} else {
+			publishedAt := time.Now().UTC()
+			m.PublishedAt = &publishedAt
+			m.NextAttemptAt = publishedAt
+			m.LastError = ""
+			published++
+		}
+
+		err = s.store.SaveAttempt(ctx, m)
+		if err != nil {
+			return published, failed, err
+		}
+	}
+
+	return published, failed, nil
+}
+
+// Purge deletes the messages published longer than age ago.
+func (s *service) Purge(ctx *gofr.Context, age time.Duration) (int64, error) {
+	return s.store.Purge(ctx, time.Now().UTC().Add(-age))
+}
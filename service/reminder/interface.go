@@ -0,0 +1,32 @@
+package reminder
+
+import (
+	"time"
+
+	"gofr.dev/pkg/gofr"
+
+	"TaskManager2/models"
+)
+
+type Store interface {
+	Create(*gofr.Context, *models.Reminder) (int64, error)
+	GetByID(*gofr.Context, int64) (*models.Reminder, error)
+	GetByTask(*gofr.Context, int64) ([]models.Reminder, error)
+	Delete(*gofr.Context, int64) error
+	Due(*gofr.Context, time.Time, int) ([]models.Reminder, error)
+	Claim(*gofr.Context, int64, time.Time, time.Time) (bool, error)
+	SaveAttempt(*gofr.Context, *models.Reminder) error
+}
+
+type TaskService interface {
+	GetByID(*gofr.Context, int64) (*models.Task, error)
+}
+
+type UserService interface {
+	GetByID(*gofr.Context, int64) (*models.User, error)
+}
+
+// Notifier sends due reminders over one channel.
+type Notifier interface {
+	Notify(*gofr.Context, *models.ReminderNotice) error
+}
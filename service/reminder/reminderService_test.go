@@ -0,0 +1,206 @@
+package reminder
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"go.uber.org/mock/gomock"
+	"gofr.dev/pkg/gofr"
+	"gofr.dev/pkg/gofr/container"
+
+	"TaskManager2/apperr"
+	"TaskManager2/models"
+	"TaskManager2/utils"
+)
+
+func TestService_Create(t *testing.T) {
+	var ctx *gofr.Context
+
+	controller := gomock.NewController(t)
+	mockStore := NewMockStore(controller)
+	mockTasks := NewMockTaskService(controller)
+	reminderService := New(mockStore, mockTasks, NewMockUserService(controller),
+		map[string]Notifier{"email": NewMockNotifier(controller), "in_app": NewMockNotifier(controller)})
+
+	before := 30
+	negative := -5
+	past := time.Now().Add(-time.Hour)
+
+	testcases := []struct {
+		description   string
+		input         *models.Reminder
+		mockExpect    func()
+		expected      *models.Reminder
+		expectedError error
+	}{
+		{
+			"relative to the due date",
+			&models.Reminder{TaskID: 9, MinutesBeforeDue: &before, Channel: "email", Status: "sent"},
+			func() {
+				mockTasks.EXPECT().GetByID(ctx, int64(9)).Return(&models.Task{ID: 9}, nil)
+				mockStore.EXPECT().Create(ctx, gomock.Any()).Return(int64(3), nil)
+			},
+			&models.Reminder{ID: 3, TaskID: 9, MinutesBeforeDue: &before, Channel: "email", Status: "pending"},
+			nil,
+		},
+		{
+			"neither time nor offset",
+			&models.Reminder{TaskID: 9, Channel: "sms"},
+			func() {},
+			nil,
+			apperr.Validation(
+				apperr.Field("remind_at", "set either remind_at or minutes_before_due"),
+				apperr.Field("channel", "must be one of email, in_app"),
+			),
+		},
+		{
+			"in the past",
+			&models.Reminder{TaskID: 9, RemindAt: &past, Channel: "in_app"},
+			func() {},
+			nil,
+			apperr.Validation(apperr.Field("remind_at", "must be in the future")),
+		},
+		{
+			"negative offset",
+			&models.Reminder{TaskID: 9, MinutesBeforeDue: &negative, Channel: "in_app"},
+			func() {},
+			nil,
+			apperr.Validation(apperr.Field("minutes_before_due", "must not be negative")),
+		},
+		{
+			"unknown task",
+			&models.Reminder{TaskID: 8, MinutesBeforeDue: &before, Channel: "in_app"},
+			func() {
+				mockTasks.EXPECT().GetByID(ctx, int64(8)).Return(nil, apperr.NotFound("task", 8))
+			},
+			nil,
+			apperr.NotFound("task", 8),
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.description, func(t *testing.T) {
+			tc.mockExpect()
+
+			created, err := reminderService.Create(ctx, tc.input)
+			if !errors.Is(err, tc.expectedError) {
+				t.Fatalf("expected %v, got %v", tc.expectedError, err)
+			}
+
+			if !reflect.DeepEqual(err, tc.expectedError) && tc.expectedError != nil {
+				t.Errorf("expected %+v, got %+v", tc.expectedError, err)
+			}
+
+			if created != nil {
+				created.CreatedAt = time.Time{}
+			}
+
+			if !reflect.DeepEqual(created, tc.expected) {
+				t.Errorf("expected %+v, got %+v", tc.expected, created)
+			}
+		})
+	}
+}
+
+func TestService_Delete(t *testing.T) {
+	var ctx *gofr.Context
+
+	controller := gomock.NewController(t)
+	mockStore := NewMockStore(controller)
+	reminderService := New(mockStore, NewMockTaskService(controller), NewMockUserService(controller), nil)
+
+	mockStore.EXPECT().GetByID(ctx, int64(3)).Return(&models.Reminder{ID: 3, TaskID: 9}, nil).Times(2)
+	mockStore.EXPECT().Delete(ctx, int64(3)).Return(nil)
+
+	err := reminderService.Delete(ctx, 9, 3)
+	if err != nil {
+		t.Error(err)
+	}
+
+	err = reminderService.Delete(ctx, 8, 3)
+	if !errors.Is(err, apperr.NotFound("reminder", 3)) {
+		t.Errorf("expected not found, got %v", err)
+	}
+}
+
+func TestService_Dispatch(t *testing.T) {
+	mockContainer, mock := container.NewMockContainer(t)
+	ctx := &gofr.Context{
+		Context:   t.Context(),
+		Request:   nil,
+		Container: mockContainer,
+	}
+
+	controller := gomock.NewController(t)
+	mockStore := NewMockStore(controller)
+	mockTasks := NewMockTaskService(controller)
+	mockUsers := NewMockUserService(controller)
+	mockNotifier := NewMockNotifier(controller)
+	reminderService := New(mockStore, mockTasks, mockUsers, map[string]Notifier{"in_app": mockNotifier})
+
+	task := &models.Task{ID: 9, Title: "Ship it", UserID: 1}
+	user := &models.User{ID: 1, Email: "ada@example.com"}
+	due := []models.Reminder{
+		{ID: 3, TaskID: 9, Channel: "in_app", Status: "pending"},
+		{ID: 4, TaskID: 9, Channel: "in_app", Status: "pending"},
+		{ID: 5, TaskID: 9, Channel: "in_app", Status: "pending", Attempts: 4},
+		{ID: 6, TaskID: 9, Channel: "email", Status: "pending"},
+	}
+
+	mockStore.EXPECT().Due(ctx, gomock.Any(), 10).Return(due, nil)
+
+	// Reminder 3 is sent and recorded in one transaction.
+	mockStore.EXPECT().Claim(ctx, int64(3), gomock.Any(), gomock.Any()).Return(true, nil)
+	mockTasks.EXPECT().GetByID(ctx, int64(9)).Return(task, nil).Times(2)
+	mockUsers.EXPECT().GetByID(ctx, int64(1)).Return(user, nil).Times(2)
+	mock.SQL.ExpectBegin()
+	mockNotifier.EXPECT().Notify(ctx, &models.ReminderNotice{Reminder: due[0], Task: *task, User: *user}).Return(nil)
+	mockStore.EXPECT().SaveAttempt(ctx, gomock.Any()).DoAndReturn(func(_ *gofr.Context, r *models.Reminder) error {
+		if r.ID != 3 || r.Status != "sent" || r.Attempts != 1 || r.SentAt == nil {
+			t.Errorf("expected reminder 3 to be sent, got %+v", r)
+		}
+
+		return nil
+	})
+	mock.SQL.ExpectCommit()
+
+	// Reminder 4 is claimed by another replica.
+	mockStore.EXPECT().Claim(ctx, int64(4), gomock.Any(), gomock.Any()).Return(false, nil)
+
+	// Reminder 5 fails for the last time.
+	mockStore.EXPECT().Claim(ctx, int64(5), gomock.Any(), gomock.Any()).Return(true, nil)
+	mock.SQL.ExpectBegin()
+	mockNotifier.EXPECT().Notify(ctx, gomock.Any()).Return(utils.ErrTest)
+	mock.SQL.ExpectRollback()
+	mockStore.EXPECT().SaveAttempt(ctx, gomock.Any()).DoAndReturn(func(_ *gofr.Context, r *models.Reminder) error {
+		if r.ID != 5 || r.Status != "failed" || r.Attempts != 5 || r.LastError != utils.ErrTest.Error() {
+			t.Errorf("expected reminder 5 to have failed, got %+v", r)
+		}
+
+		return nil
+	})
+
+	// Reminder 6 uses a channel that is no longer configured and is retried.
+	mockStore.EXPECT().Claim(ctx, int64(6), gomock.Any(), gomock.Any()).Return(true, nil)
+	mockStore.EXPECT().SaveAttempt(ctx, gomock.Any()).DoAndReturn(func(_ *gofr.Context, r *models.Reminder) error {
+		if r.ID != 6 || r.Status != "pending" || r.Attempts != 1 || r.NextAttemptAt == nil {
+			t.Errorf("expected reminder 6 to be retried, got %+v", r)
+		}
+
+		return nil
+	})
+
+	sent, failed, err := reminderService.Dispatch(ctx, 10)
+	if err != nil || sent != 1 || failed != 2 {
+		t.Errorf("expected 1 sent and 2 failed, got %d, %d, %v", sent, failed, err)
+	}
+
+	mockStore.EXPECT().Due(ctx, gomock.Any(), 10).Return(nil, utils.ErrTest)
+
+	_, _, err = reminderService.Dispatch(ctx, 10)
+	if !errors.Is(err, utils.ErrTest) {
+		t.Errorf("expected %v, got %v", utils.ErrTest, err)
+	}
+}
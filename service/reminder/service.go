@@ -0,0 +1,225 @@
+package reminder
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+	"time"
+
+	"gofr.dev/pkg/gofr"
+
+	"TaskManager2/apperr"
+	"TaskManager2/models"
+	"TaskManager2/utils"
+	"TaskManager2/validate"
+)
+
+const (
+	// A reminder is attempted maxAttempts times, backing off from firstRetry
+	// to maxRetry.
+	maxAttempts = 5
+	firstRetry  = time.Minute
+	maxRetry    = time.Hour
+
+	// claimDuration outlasts the notifier timeouts, so a reminder is only sent
+	// again by another replica when the one that claimed it has died.
+	claimDuration = 5 * time.Minute
+
+	maxErrorLength = 500
+)
+
+var errNoNotifier = errors.New("no notifier for channel")
+
+type service struct {
+	store     Store
+	tasks     TaskService
+	users     UserService
+	notifiers map[string]Notifier
+}
+
+// New returns a service sending reminders through the given notifiers, keyed
+// by channel. Reminders can only be created for those channels.
+func New(store Store, tasks TaskService, users UserService, notifiers map[string]Notifier) *service {
+	return &service{store: store, tasks: tasks, users: users, notifiers: notifiers}
+}
+
+func (s *service) Create(ctx *gofr.Context, r *models.Reminder) (*models.Reminder, error) {
+	err := s.check(r)
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = s.tasks.GetByID(ctx, r.TaskID)
+	if err != nil {
+		return nil, err
+	}
+
+	created := models.Reminder{
+		TaskID: r.TaskID, MinutesBeforeDue: r.MinutesBeforeDue, Channel: r.Channel, Status: models.ReminderPending,
+		CreatedAt: time.Now().UTC().Truncate(time.Second),
+	}
+
+	if r.RemindAt != nil {
+		at := r.RemindAt.UTC().Truncate(time.Second)
+		created.RemindAt = &at
+	}
+
+	created.ID, err = s.store.Create(ctx, &created)
+	if err != nil {
+		return nil, err
+	}
+
+	return &created, nil
+}
+
+// check validates a new reminder, which is either at a time in the future or
+// relative to the due date of its task.
+func (s *service) check(r *models.Reminder) error {
+	err := validate.Struct(r)
+	if err != nil {
+		return err
+	}
+
+	var invalid []apperr.FieldError
+
+	switch {
+	case (r.RemindAt == nil) == (r.MinutesBeforeDue == nil):
+		invalid = append(invalid, apperr.Field("remind_at", "set either remind_at or minutes_before_due"))
+	case r.RemindAt != nil && !r.RemindAt.After(time.Now()):
+		invalid = append(invalid, apperr.Field("remind_at", "must be in the future"))
+	case r.MinutesBeforeDue != nil && *r.MinutesBeforeDue < 0:
+		invalid = append(invalid, apperr.Field("minutes_before_due", "must not be negative"))
+	}
+
+	if _, ok := s.notifiers[r.Channel]; !ok {
+		channels := slices.Sorted(maps.Keys(s.notifiers))
+		invalid = append(invalid, apperr.Field("channel", "must be one of "+strings.Join(channels, ", ")))
+	}
+
+	if len(invalid) > 0 {
+		return apperr.Validation(invalid...)
+	}
+
+	return nil
+}
+
+func (s *service) GetByTask(ctx *gofr.Context, taskID int64) ([]models.Reminder, error) {
+	_, err := s.tasks.GetByID(ctx, taskID)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.store.GetByTask(ctx, taskID)
+}
+
+func (s *service) Delete(ctx *gofr.Context, taskID, id int64) error {
+	r, err := s.store.GetByID(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	if r.TaskID != taskID {
+		return apperr.NotFound("reminder", id)
+	}
+
+	return s.store.Delete(ctx, id)
+}
+
+// Dispatch sends up to limit due reminders and returns how many were sent and
+// how many failed. Reminders claimed by another replica are skipped.
+func (s *service) Dispatch(ctx *gofr.Context, limit int) (int, int, error) {
+	now := time.Now().UTC()
+
+	due, err := s.store.Due(ctx, now, limit)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	var sent, failed int
+
+	for i := range due {
+		r := &due[i]
+
+		claimed, err := s.store.Claim(ctx, r.ID, now, now.Add(claimDuration))
+		if err != nil {
+			return sent, failed, err
+		}
+
+		if !claimed {
+			continue
+		}
+
+		err = s.send(ctx, r)
+		if err == nil {
+			sent++
+
+			continue
+		}
+
+		failed++
+
+		fail(r, err)
+
+		err = s.store.SaveAttempt(ctx, r)
+		if err != nil {
+			return sent, failed, err
+		}
+	}
+
+	return sent, failed, nil
+}
+
+// send notifies the assignee of the task and marks the reminder sent in one
+// transaction, so that notifiers writing to the database notify exactly once.
+func (s *service) send(ctx *gofr.Context, r *models.Reminder) error {
+	notifier, ok := s.notifiers[r.Channel]
+	if !ok {
+		return fmt.Errorf("%w %s", errNoNotifier, r.Channel)
+	}
+
+	task, err := s.tasks.GetByID(ctx, r.TaskID)
+	if err != nil {
+		return err
+	}
+
+	user, err := s.users.GetByID(ctx, task.UserID)
+	if err != nil {
+		return err
+	}
+
+	return utils.WithTx(ctx, func() error {
+		err := notifier.Notify(ctx, &models.ReminderNotice{Reminder: *r, Task: *task, User: *user})
+		if err != nil {
+			return err
+		}
+
+		now := time.Now().UTC().Truncate(time.Second)
+		sent := *r
+		sent.Status = models.ReminderSent
+		sent.Attempts++
+		sent.NextAttemptAt = nil
+		sent.LastError = ""
+		sent.SentAt = &now
+
+		return s.store.SaveAttempt(ctx, &sent)
+	})
+}
+
+// fail records a failed attempt: the reminder is due again after a backoff,
+// or has failed once it has used up its attempts.
+func fail(r *models.Reminder, err error) {
+	r.Attempts++
+	r.LastError = utils.Truncate(err.Error(), maxErrorLength)
+
+	if r.Attempts >= maxAttempts {
+		r.Status = models.ReminderFailed
+		r.NextAttemptAt = nil
+
+		return
+	}
+
+	next := time.Now().UTC().Add(utils.Backoff(r.Attempts, firstRetry, maxRetry))
+	r.NextAttemptAt = &next
+}
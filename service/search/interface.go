@@ -0,0 +1,12 @@
+package search
+
+import (
+	"gofr.dev/pkg/gofr"
+
+	"TaskManager2/models"
+	"TaskManager2/search"
+)
+
+type Index interface {
+	Search(*gofr.Context, *search.Query, int) ([]models.SearchHit, error)
+}
@@ -0,0 +1,88 @@
+package search
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"go.uber.org/mock/gomock"
+	"gofr.dev/pkg/gofr"
+
+	"TaskManager2/apperr"
+	"TaskManager2/models"
+	"TaskManager2/search"
+	"TaskManager2/utils"
+)
+
+func TestService_Search(t *testing.T) {
+	var ctx *gofr.Context
+
+	controller := gomock.NewController(t)
+	mockIndex := NewMockIndex(controller)
+	searchService := New(mockIndex)
+
+	query, _ := search.Parse("deploy")
+
+	tests := []struct {
+		description string
+		q           string
+		limit       int
+		mockExpect  func()
+		expected    []models.SearchHit
+		expectedErr error
+	}{
+		{
+			description: "default limit with snippets",
+			q:           "deploy",
+			mockExpect: func() {
+				mockIndex.EXPECT().Search(ctx, query, 20).
+					Return([]models.SearchHit{{Kind: "task", ID: 1, TaskID: 1, Score: 1, Text: "Deploy it"}}, nil)
+			},
+			expected: []models.SearchHit{{Kind: "task", ID: 1, TaskID: 1, Score: 1, Text: "Deploy it", Snippet: "<mark>Deploy</mark> it"}},
+		},
+		{
+			description: "explicit limit",
+			q:           "deploy",
+			limit:       5,
+			mockExpect: func() {
+				mockIndex.EXPECT().Search(ctx, query, 5).Return(nil, nil)
+			},
+		},
+		{
+			description: "invalid query",
+			q:           `"deploy`,
+			mockExpect:  func() {},
+			expectedErr: apperr.Validation(apperr.Field("q", "has an unterminated quote at position 0")),
+		},
+		{
+			description: "limit out of range",
+			q:           "deploy",
+			limit:       101,
+			mockExpect:  func() {},
+			expectedErr: apperr.Validation(apperr.Field("limit", "must be between 1 and 100")),
+		},
+		{
+			description: "index error",
+			q:           "deploy",
+			mockExpect: func() {
+				mockIndex.EXPECT().Search(ctx, query, 20).Return(nil, utils.ErrTest)
+			},
+			expectedErr: utils.ErrTest,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.description, func(t *testing.T) {
+			tc.mockExpect()
+
+			hits, err := searchService.Search(ctx, tc.q, tc.limit)
+			if !errors.Is(err, tc.expectedErr) {
+				t.Errorf("expected error %v, got %v", tc.expectedErr, err)
+			}
+
+			if !reflect.DeepEqual(hits, tc.expected) {
+				t.Errorf("expected %v, got %v", tc.expected, hits)
+			}
+		})
+	}
+}
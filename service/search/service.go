@@ -0,0 +1,51 @@
+package search
+
+import (
+	"fmt"
+
+	"gofr.dev/pkg/gofr"
+
+	"TaskManager2/apperr"
+	"TaskManager2/models"
+	"TaskManager2/search"
+)
+
+const (
+	defaultLimit = 20
+	maxLimit     = 100
+)
+
+type service struct {
+	index Index
+}
+
+func New(index Index) *service {
+	return &service{index: index}
+}
+
+// Search returns the best hits for the query, each with a highlighted snippet.
+// A zero limit means defaultLimit.
+func (s *service) Search(ctx *gofr.Context, q string, limit int) ([]models.SearchHit, error) {
+	query, err := search.Parse(q)
+	if err != nil {
+		return nil, err
+	}
+
+	switch {
+	case limit == 0:
+		limit = defaultLimit
+	case limit < 0 || limit > maxLimit:
+		return nil, apperr.Validation(apperr.Field("limit", fmt.Sprintf("must be between 1 and %d", maxLimit)))
+	}
+
+	hits, err := s.index.Search(ctx, query, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range hits {
+		hits[i].Snippet = search.Snippet(hits[i].Text, query)
+	}
+
+	return hits, nil
+}
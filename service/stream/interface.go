@@ -0,0 +1,14 @@
+package stream
+
+import (
+	"gofr.dev/pkg/gofr"
+
+	"TaskManager2/models"
+)
+
+type Store interface {
+	Append(*gofr.Context, *models.StreamEvent) (int64, error)
+	After(*gofr.Context, int64, int) ([]models.StreamEvent, error)
+	Bounds(*gofr.Context) (int64, int64, error)
+	Prune(*gofr.Context, int64) (int64, error)
+}
@@ -0,0 +1,232 @@
+package stream
+
+import (
+	"encoding/json"
+	"strings"
+	"sync"
+
+	"gofr.dev/pkg/gofr"
+
+	"TaskManager2/models"
+)
+
+const (
+	subscriberBuffer = 64
+	pollBatch        = 500
+
+	// Event IDs are allotted when a transaction inserts its event but become
+	// visible when it commits, so a lower ID can appear after a higher one.
+	// Each poll rereads the lookback IDs below the cursor to catch those.
+	lookback = 100
+)
+
+// service keeps the events of all replicas in a shared log and fans them
+// out to the stream subscribers of this replica. The log holds the latest size
+// events, which is how far back a reconnecting client can resume.
+type service struct {
+	store Store
+	size  int
+
+	polling sync.Mutex
+	cursor  int64
+	synced  bool
+	seen    map[int64]bool
+
+	mu          sync.Mutex
+	subscribers map[chan models.StreamEvent]models.StreamFilter
+}
+
+func New(store Store, size int) *service {
+	return &service{
+		store:       store,
+		size:        size,
+		seen:        map[int64]bool{},
+		subscribers: map[chan models.StreamEvent]models.StreamFilter{},
+	}
+}
+
+// Record appends task events to the log, within the transaction of the
+// change. Other events are not streamed.
+func (s *service) Record(ctx *gofr.Context, e *models.Event) error {
+	task, ok := e.Data.(*models.Task)
+	if !ok || !strings.HasPrefix(e.Name, "task.") {
+		return nil
+	}
+
+	payload, err := json.Marshal(e)
+	if err != nil {
+		return err
+	}
+
+	_, err = s.store.Append(ctx, &models.StreamEvent{
+		Event: e.Name, TaskID: task.ID, UserID: task.UserID, ParentID: task.ParentID, Payload: payload, CreatedAt: e.OccurredAt,
+	})
+
+	return err
+}
+
+// Subscribe returns a channel of the events logged from now on that match the
+// filter and a function that ends the subscription. A subscriber that falls
+// more than subscriberBuffer events behind is dropped and its channel closed;
+// it can resume with Replay.
+func (s *service) Subscribe(ctx *gofr.Context, filter models.StreamFilter) (<-chan models.StreamEvent, func(), error) {
+	// Holding polling keeps a poll from finding the service idle between the
+	// sync and the subscription, which would lose the place just recorded.
+	s.polling.Lock()
+	defer s.polling.Unlock()
+
+	if !s.synced {
+		err := s.sync(ctx)
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+
+	ch := make(chan models.StreamEvent, subscriberBuffer)
+
+	s.mu.Lock()
+	s.subscribers[ch] = filter
+	s.mu.Unlock()
+
+	return ch, func() {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+
+		if _, ok := s.subscribers[ch]; ok {
+			delete(s.subscribers, ch)
+			close(ch)
+		}
+	}, nil
+}
+
+// Replay lists the logged events after the given ID that match the filter.
+// It reports false when events after that ID have already been pruned, in
+// which case the client has to reload what it shows.
+func (s *service) Replay(ctx *gofr.Context, after int64, filter models.StreamFilter) ([]models.StreamEvent, bool, error) {
+	first, last, err := s.store.Bounds(ctx)
+	if err != nil {
+		return nil, false, err
+	}
+
+	if after >= last {
+		return nil, true, nil
+	}
+
+	events, err := s.store.After(ctx, after, s.size)
+	if err != nil {
+		return nil, false, err
+	}
+
+	var matched []models.StreamEvent
+
+	for i := range events {
+		if filter.Matches(&events[i]) {
+			matched = append(matched, events[i])
+		}
+	}
+
+	return matched, after >= first-1, nil
+}
+
+// Poll sends the events logged since the last poll to the subscribers and
+// returns how many there were. With no subscribers it only forgets its place,
+// and the next subscriber records the end of the log for it again. Polls
+// running into each other are skipped.
+func (s *service) Poll(ctx *gofr.Context) (int, error) {
+	if !s.polling.TryLock() {
+		return 0, nil
+	}
+
+	defer s.polling.Unlock()
+
+	s.mu.Lock()
+	idle := len(s.subscribers) == 0
+	s.mu.Unlock()
+
+	if idle {
+		s.synced = false
+
+		return 0, nil
+	}
+
+	events, err := s.store.After(ctx, max(s.cursor-lookback, 0), pollBatch)
+	if err != nil {
+		return 0, err
+	}
+
+	var sent int
+
+	for i := range events {
+		e := events[i]
+		if s.seen[e.ID] || e.ID <= s.cursor-lookback {
+			continue
+		}
+
+		s.seen[e.ID] = true
+		s.cursor = max(s.cursor, e.ID)
+		s.broadcast(&e)
+		sent++
+	}
+
+	for id := range s.seen {
+		if id <= s.cursor-lookback {
+			delete(s.seen, id)
+		}
+	}
+
+	return sent, nil
+}
+
+// sync moves the cursor to the end of the log and marks the events the next
+// poll rereads as already sent.
+func (s *service) sync(ctx *gofr.Context) error {
+	_, last, err := s.store.Bounds(ctx)
+	if err != nil {
+		return err
+	}
+
+	events, err := s.store.After(ctx, max(last-lookback, 0), lookback)
+	if err != nil {
+		return err
+	}
+
+	clear(s.seen)
+
+	for i := range events {
+		if events[i].ID <= last {
+			s.seen[events[i].ID] = true
+		}
+	}
+
+	s.cursor, s.synced = last, true
+
+	return nil
+}
+
+func (s *service) broadcast(e *models.StreamEvent) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for ch, filter := range s.subscribers {
+		if !filter.Matches(e) {
+			continue
+		}
+
+		select {
+		case ch <- *e:
+		default:
+			delete(s.subscribers, ch)
+			close(ch)
+		}
+	}
+}
+
+// Prune deletes all but the latest size events from the log.
+func (s *service) Prune(ctx *gofr.Context) (int64, error) {
+	_, last, err := s.store.Bounds(ctx)
+	if err != nil || last <= int64(s.size) {
+		return 0, err
+	}
+
+	return s.store.Prune(ctx, last-int64(s.size))
+}
@@ -0,0 +1,264 @@
+package stream
+
+import (
+	"encoding/json"
+	"errors"
+	"reflect"
+	"testing"
+
+	"go.uber.org/mock/gomock"
+	"gofr.dev/pkg/gofr"
+
+	"TaskManager2/models"
+	"TaskManager2/utils"
+)
+
+func TestService_Record(t *testing.T) {
+	var ctx *gofr.Context
+
+	controller := gomock.NewController(t)
+	mockStore := NewMockStore(controller)
+	streamService := New(mockStore, 100)
+	parentID := int64(2)
+	task := &models.Task{ID: 9, UserID: 1, ParentID: &parentID}
+
+	mockStore.EXPECT().Append(ctx, gomock.Any()).DoAndReturn(func(_ *gofr.Context, e *models.StreamEvent) (int64, error) {
+		var event models.Event
+
+		err := json.Unmarshal(e.Payload, &event)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if e.Event != "task.updated" || e.TaskID != 9 || e.UserID != 1 || *e.ParentID != 2 || event.ID != "e1" {
+			t.Errorf("unexpected stream event %+v", e)
+		}
+
+		return 1, nil
+	})
+
+	err := streamService.Record(ctx, &models.Event{ID: "e1", Name: "task.updated", Data: task})
+	if err != nil {
+		t.Error(err)
+	}
+
+	err = streamService.Record(ctx, &models.Event{ID: "e2", Name: "user.created", Data: &models.User{ID: 1}})
+	if err != nil {
+		t.Errorf("expected user events to be ignored, got %v", err)
+	}
+
+	mockStore.EXPECT().Append(ctx, gomock.Any()).Return(int64(0), utils.ErrTest)
+
+	err = streamService.Record(ctx, &models.Event{ID: "e3", Name: "task.deleted", Data: task})
+	if !errors.Is(err, utils.ErrTest) {
+		t.Errorf("expected %v, got %v", utils.ErrTest, err)
+	}
+}
+
+func TestService_Replay(t *testing.T) {
+	var ctx *gofr.Context
+
+	controller := gomock.NewController(t)
+	mockStore := NewMockStore(controller)
+	streamService := New(mockStore, 100)
+	logged := []models.StreamEvent{{ID: 11, UserID: 1}, {ID: 12, UserID: 2}, {ID: 13, UserID: 1}}
+
+	testcases := []struct {
+		description      string
+		after            int64
+		mockExpect       func()
+		expected         []models.StreamEvent
+		expectedComplete bool
+		expectedError    error
+	}{
+		{
+			"resume",
+			10,
+			func() {
+				mockStore.EXPECT().Bounds(ctx).Return(int64(5), int64(13), nil)
+				mockStore.EXPECT().After(ctx, int64(10), 100).Return(logged, nil)
+			},
+			[]models.StreamEvent{logged[0], logged[2]},
+			true,
+			nil,
+		},
+		{
+			"pruned",
+			2,
+			func() {
+				mockStore.EXPECT().Bounds(ctx).Return(int64(11), int64(13), nil)
+				mockStore.EXPECT().After(ctx, int64(2), 100).Return(logged, nil)
+			},
+			[]models.StreamEvent{logged[0], logged[2]},
+			false,
+			nil,
+		},
+		{
+			"up to date",
+			13,
+			func() {
+				mockStore.EXPECT().Bounds(ctx).Return(int64(11), int64(13), nil)
+			},
+			nil,
+			true,
+			nil,
+		},
+		{
+			"store error",
+			10,
+			func() {
+				mockStore.EXPECT().Bounds(ctx).Return(int64(0), int64(0), utils.ErrTest)
+			},
+			nil,
+			false,
+			utils.ErrTest,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.description, func(t *testing.T) {
+			tc.mockExpect()
+
+			events, complete, err := streamService.Replay(ctx, tc.after, models.StreamFilter{UserID: 1})
+			if !reflect.DeepEqual(events, tc.expected) || complete != tc.expectedComplete || !errors.Is(err, tc.expectedError) {
+				t.Errorf("expected %+v, %v, %v, got %+v, %v, %v", tc.expected, tc.expectedComplete, tc.expectedError,
+					events, complete, err)
+			}
+		})
+	}
+}
+
+func TestService_Poll(t *testing.T) {
+	var ctx *gofr.Context
+
+	controller := gomock.NewController(t)
+	mockStore := NewMockStore(controller)
+	streamService := New(mockStore, 100)
+
+	// Nobody is listening, so the log is not read.
+	sent, err := streamService.Poll(ctx)
+	if err != nil || sent != 0 {
+		t.Fatalf("expected an idle poll, got %d, %v", sent, err)
+	}
+
+	mockStore.EXPECT().Bounds(ctx).Return(int64(0), int64(0), utils.ErrTest)
+
+	_, _, err = streamService.Subscribe(ctx, models.StreamFilter{})
+	if !errors.Is(err, utils.ErrTest) {
+		t.Fatalf("expected %v, got %v", utils.ErrTest, err)
+	}
+
+	// The first subscriber records the end of the log, the second finds it
+	// recorded.
+	mockStore.EXPECT().Bounds(ctx).Return(int64(1), int64(150), nil)
+	mockStore.EXPECT().After(ctx, int64(50), 100).Return([]models.StreamEvent{{ID: 149}, {ID: 150}}, nil)
+
+	all, cancelAll, err := streamService.Subscribe(ctx, models.StreamFilter{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer cancelAll()
+
+	mine, cancelMine, err := streamService.Subscribe(ctx, models.StreamFilter{UserID: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Event 151 was logged before the first poll, and is sent. Event 148
+	// committed after 151, and is still sent.
+	mockStore.EXPECT().After(ctx, int64(50), pollBatch).
+		Return([]models.StreamEvent{{ID: 149}, {ID: 150}, {ID: 151, UserID: 1}}, nil)
+	mockStore.EXPECT().After(ctx, int64(51), pollBatch).
+		Return([]models.StreamEvent{{ID: 148, UserID: 2}, {ID: 149}, {ID: 150}, {ID: 151, UserID: 1}}, nil)
+
+	for _, expected := range []int{1, 1} {
+		sent, err = streamService.Poll(ctx)
+		if err != nil || sent != expected {
+			t.Errorf("expected %d sent, got %d, %v", expected, sent, err)
+		}
+	}
+
+	if got := []int64{(<-all).ID, (<-all).ID}; !reflect.DeepEqual(got, []int64{151, 148}) {
+		t.Errorf("expected events 151 and 148, got %v", got)
+	}
+
+	if got := <-mine; got.ID != 151 || len(mine) != 0 {
+		t.Errorf("expected only event 151 for user 1, got %v and %d more", got.ID, len(mine))
+	}
+
+	cancelMine()
+
+	if _, ok := <-mine; ok {
+		t.Error("expected the channel to be closed after cancelling")
+	}
+
+	mockStore.EXPECT().After(ctx, int64(51), pollBatch).Return(nil, utils.ErrTest)
+
+	_, err = streamService.Poll(ctx)
+	if !errors.Is(err, utils.ErrTest) {
+		t.Errorf("expected %v, got %v", utils.ErrTest, err)
+	}
+}
+
+func TestService_PollDropsSlowSubscribers(t *testing.T) {
+	var ctx *gofr.Context
+
+	controller := gomock.NewController(t)
+	mockStore := NewMockStore(controller)
+	streamService := New(mockStore, 100)
+
+	mockStore.EXPECT().Bounds(ctx).Return(int64(0), int64(0), nil)
+	mockStore.EXPECT().After(ctx, int64(0), lookback).Return(nil, nil)
+
+	slow, cancel, err := streamService.Subscribe(ctx, models.StreamFilter{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer cancel()
+
+	backlog := make([]models.StreamEvent, subscriberBuffer+1)
+	for i := range backlog {
+		backlog[i].ID = int64(i + 1)
+	}
+
+	mockStore.EXPECT().After(ctx, int64(0), pollBatch).Return(backlog, nil)
+
+	_, err = streamService.Poll(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	received := 0
+	for range slow {
+		received++
+	}
+
+	if received != subscriberBuffer {
+		t.Errorf("expected the subscriber to get %d events and be dropped, got %d", subscriberBuffer, received)
+	}
+}
+
+func TestService_Prune(t *testing.T) {
+	var ctx *gofr.Context
+
+	controller := gomock.NewController(t)
+	mockStore := NewMockStore(controller)
+	streamService := New(mockStore, 100)
+
+	mockStore.EXPECT().Bounds(ctx).Return(int64(1), int64(80), nil)
+
+	pruned, err := streamService.Prune(ctx)
+	if err != nil || pruned != 0 {
+		t.Errorf("expected nothing pruned below the size, got %d, %v", pruned, err)
+	}
+
+	mockStore.EXPECT().Bounds(ctx).Return(int64(1), int64(250), nil)
+	mockStore.EXPECT().Prune(ctx, int64(150)).Return(int64(150), nil)
+
+	pruned, err = streamService.Prune(ctx)
+	if err != nil || pruned != 150 {
+		t.Errorf("expected 150 pruned, got %d, %v", pruned, err)
+	}
+}
@@ -1,6 +1,8 @@
 package task
 
 import (
+	"time"
+
 	"gofr.dev/pkg/gofr"
 
 	"TaskManager2/models"
@@ -8,12 +10,38 @@ import (
 
 type Store interface {
 	Create(*gofr.Context, *models.Task) (int64, error)
-	GetAll(*gofr.Context) ([]models.Task, error)
+	CreateTree(*gofr.Context, []models.Task) ([]models.Task, error)
+	GetAll(*gofr.Context, *models.TaskFilter) ([]models.Task, error)
+	Summary(*gofr.Context, int64, time.Time) (*models.TaskSummary, error)
 	GetByID(*gofr.Context, int64) (*models.Task, error)
 	Update(*gofr.Context, *models.Task) error
-	Delete(*gofr.Context, int64) error
+	Patch(*gofr.Context, int64, *models.TaskPatch) error
+	GetMany(*gofr.Context, []int64) (map[int64]models.Task, error)
+	Page(*gofr.Context, int64, int) ([]models.Task, error)
+	CreateBatch(*gofr.Context, []models.Task) ([]int64, error)
+	PatchBatch(*gofr.Context, []models.TaskPatch) error
+	DeleteBatch(*gofr.Context, []int64) ([]models.Task, error)
+	Delete(*gofr.Context, int64) ([]models.Task, error)
+	GetTrash(*gofr.Context) ([]models.Task, error)
+	Restore(*gofr.Context, int64) ([]models.Task, error)
+	Purge(*gofr.Context, time.Time) ([]models.Task, error)
 }
 
 type UserService interface {
 	GetByID(*gofr.Context, int64) (*models.User, error)
 }
+
+type AuditStore interface {
+	Create(*gofr.Context, *models.AuditEntry) error
+	CreateBatch(*gofr.Context, []*models.AuditEntry) error
+	GetByEntity(*gofr.Context, string, int64) ([]models.AuditEntry, error)
+}
+
+type SearchIndex interface {
+	Index(*gofr.Context, models.SearchDocument) error
+	Remove(*gofr.Context, string, int64) error
+}
+
+type Events interface {
+	Emit(*gofr.Context, string, any) error
+}
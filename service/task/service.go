@@ -1,28 +1,62 @@
 package task
 
 import (
+	"errors"
+	"fmt"
+	"time"
+
 	"gofr.dev/pkg/gofr"
 
+	"TaskManager2/apperr"
+	"TaskManager2/audit"
+	"TaskManager2/markdown"
 	"TaskManager2/models"
+	"TaskManager2/utils"
+	"TaskManager2/validate"
 )
 
 type service struct {
 	store       Store
 	userService UserService
+	auditStore  AuditStore
+	index       SearchIndex
+	events      Events
 }
 
-func New(store Store, userSvc UserService) *service {
-	return &service{store: store, userService: userSvc}
+func New(store Store, userSvc UserService, auditStore AuditStore, index SearchIndex, events Events) *service {
+	return &service{store: store, userService: userSvc, auditStore: auditStore, index: index, events: events}
 }
 
 func (s *service) Create(ctx *gofr.Context, task *models.Task) (int64, error) {
+	err := validate.Struct(task)
+	if err != nil {
+		return 0, err
+	}
+
 	// validate if user exists
-	_, err := s.userService.GetByID(ctx, task.UserID)
+	_, err = s.userService.GetByID(ctx, task.UserID)
+	if apperr.CodeOf(err) == apperr.CodeNotFound {
+		return 0, apperr.Validation(apperr.Field("user_id", "user does not exist"))
+	}
+
 	if err != nil {
 		return 0, err
 	}
 
-	id, err := s.store.Create(ctx, task)
+	var id int64
+
+	err = utils.WithTx(ctx, func() error {
+		id, err = s.store.Create(ctx, task)
+		if err != nil {
+			return err
+		}
+
+		created := *task
+		created.ID = id
+		created.Version = 1
+
+		return s.record(ctx, id, audit.ActionCreate, nil, &created)
+	})
 	if err != nil {
 		return 0, err
 	}
@@ -30,8 +64,31 @@ func (s *service) Create(ctx *gofr.Context, task *models.Task) (int64, error) {
 	return id, nil
 }
 
-func (s *service) GetAll(ctx *gofr.Context) ([]models.Task, error) {
-	tasks, err := s.store.GetAll(ctx)
+// CreateTree creates task trees that were already checked, such as those
+// rendered from a template, and records every task in them as Create does.
+func (s *service) CreateTree(ctx *gofr.Context, tasks []models.Task) ([]models.Task, error) {
+	var (
+		created []models.Task
+		err     error
+	)
+
+	err = utils.WithTx(ctx, func() error {
+		created, err = s.store.CreateTree(ctx, tasks)
+		if err != nil {
+			return err
+		}
+
+		return s.recordTree(ctx, created)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return created, nil
+}
+
+func (s *service) GetAll(ctx *gofr.Context, filter *models.TaskFilter) ([]models.Task, error) {
+	tasks, err := s.store.GetAll(ctx, filter)
 	if err != nil {
 		return nil, err
 	}
@@ -39,6 +96,38 @@ func (s *service) GetAll(ctx *gofr.Context) ([]models.Task, error) {
 	return tasks, nil
 }
 
+// GetByUser lists the tasks assigned to an existing user.
+func (s *service) GetByUser(ctx *gofr.Context, userID int64, filter *models.TaskFilter) ([]models.Task, error) {
+	_, err := s.userService.GetByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	f := *filter
+	f.UserID = &userID
+
+	tasks, err := s.store.GetAll(ctx, &f)
+	if err != nil {
+		return nil, err
+	}
+
+	return tasks, nil
+}
+
+func (s *service) Summary(ctx *gofr.Context, userID int64) (*models.TaskSummary, error) {
+	_, err := s.userService.GetByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	summary, err := s.store.Summary(ctx, userID, time.Now().UTC())
+	if err != nil {
+		return nil, err
+	}
+
+	return summary, nil
+}
+
 func (s *service) GetByID(ctx *gofr.Context, id int64) (*models.Task, error) {
 	task, err := s.store.GetByID(ctx, id)
 	if err != nil {
@@ -48,20 +137,698 @@ func (s *service) GetByID(ctx *gofr.Context, id int64) (*models.Task, error) {
 	return task, nil
 }
 
+// RenderDescription renders the Markdown description of the task as HTML that
+// is safe to embed in a page.
+func (s *service) RenderDescription(ctx *gofr.Context, id int64) (*models.RenderedDescription, error) {
+	task, err := s.store.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.RenderedDescription{TaskID: task.ID, Version: task.Version, HTML: markdown.ToHTML(task.Description)}, nil
+}
+
 func (s *service) Update(ctx *gofr.Context, task *models.Task) error {
-	err := s.store.Update(ctx, task)
+	err := validate.Struct(task)
 	if err != nil {
 		return err
 	}
 
-	return nil
+	return utils.WithTx(ctx, func() error {
+		before, err := s.store.GetByID(ctx, task.ID)
+		if err != nil {
+			return err
+		}
+
+		err = s.store.Update(ctx, task)
+		if err != nil {
+			return err
+		}
+
+		after, err := s.store.GetByID(ctx, task.ID)
+		if err != nil {
+			return err
+		}
+
+		return s.record(ctx, task.ID, audit.ActionUpdate, before, after)
+	})
+}
+
+// Patch applies a merge patch to the task and returns the patched task.
+func (s *service) Patch(ctx *gofr.Context, id int64, patch *models.TaskPatch) (*models.Task, error) {
+	err := validate.Struct(patch)
+	if err != nil {
+		return nil, err
+	}
+
+	var after *models.Task
+
+	err = utils.WithTx(ctx, func() error {
+		var before *models.Task
+
+		before, err = s.store.GetByID(ctx, id)
+		if err != nil {
+			return err
+		}
+
+		if patch.Empty() {
+			after = before
+
+			return nil
+		}
+
+		err = s.store.Patch(ctx, id, patch)
+		if err != nil {
+			return err
+		}
+
+		after, err = s.store.GetByID(ctx, id)
+		if err != nil {
+			return err
+		}
+
+		return s.record(ctx, id, audit.ActionUpdate, before, after)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return after, nil
 }
 
+// Delete moves the task to the trash with its subtasks, each of which is
+// audited and announced as deleted too.
 func (s *service) Delete(ctx *gofr.Context, id int64) error {
-	err := s.store.Delete(ctx, id)
+	return utils.WithTx(ctx, func() error {
+		before, err := s.store.GetByID(ctx, id)
+		if err != nil {
+			return err
+		}
+
+		subtasks, err := s.store.Delete(ctx, id)
+		if err != nil {
+			return err
+		}
+
+		err = s.record(ctx, id, audit.ActionDelete, before, nil)
+		if err != nil {
+			return err
+		}
+
+		for i := range subtasks {
+			err = s.record(ctx, subtasks[i].ID, audit.ActionDelete, &subtasks[i], nil)
+			if err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+}
+
+// Page returns up to limit live tasks with an ID above after, ordered by ID,
+// for walking all tasks without holding them in memory at once.
+func (s *service) Page(ctx *gofr.Context, after int64, limit int) ([]models.Task, error) {
+	return s.store.Page(ctx, after, limit)
+}
+
+func (s *service) GetTrash(ctx *gofr.Context) ([]models.Task, error) {
+	tasks, err := s.store.GetTrash(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return tasks, nil
+}
+
+// Restore takes the task out of the trash with the subtasks deleted with it,
+// each of which is audited and announced as restored too.
+func (s *service) Restore(ctx *gofr.Context, id int64) error {
+	return utils.WithTx(ctx, func() error {
+		subtasks, err := s.store.Restore(ctx, id)
+		if err != nil {
+			return err
+		}
+
+		after, err := s.store.GetByID(ctx, id)
+		if err != nil {
+			return err
+		}
+
+		err = s.record(ctx, id, audit.ActionRestore, nil, after)
+		if err != nil {
+			return err
+		}
+
+		for _, restored := range subtasks {
+			restored.Version++
+
+			err = s.record(ctx, restored.ID, audit.ActionRestore, nil, &restored)
+			if err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+}
+
+// PurgeTrash permanently deletes tasks that have been in the trash longer than
+// the retention period, with an audit entry for each.
+func (s *service) PurgeTrash(ctx *gofr.Context, retention time.Duration) (int64, error) {
+	var purged []models.Task
+
+	err := utils.WithTx(ctx, func() error {
+		var err error
+
+		purged, err = s.store.Purge(ctx, time.Now().Add(-retention))
+		if err != nil {
+			return err
+		}
+
+		entries := make([]*models.AuditEntry, 0, len(purged))
+
+		for i := range purged {
+			entries, err = appendEntry(ctx, entries, purged[i].ID, audit.ActionPurge, &purged[i], nil)
+			if err != nil {
+				return err
+			}
+		}
+
+		return s.auditStore.CreateBatch(ctx, entries)
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return int64(len(purged)), nil
+}
+
+func (s *service) GetHistory(ctx *gofr.Context, id int64) ([]models.AuditEntry, error) {
+	entries, err := s.auditStore.GetByEntity(ctx, audit.EntityTask, id)
+	if err != nil {
+		return nil, err
+	}
+
+	return entries, nil
+}
+
+// maxBulkOperations caps the number of operations in a bulk request.
+const maxBulkOperations = 500
+
+// Bulk runs a batch of task operations in one transaction. Every operation is
+// checked before anything is written: an atomic batch is rejected as a whole
+// if any check fails, a best-effort batch applies the operations that passed.
+func (s *service) Bulk(ctx *gofr.Context, req *models.BulkRequest) (*models.BulkResponse, error) {
+	mode := req.Mode
+	if mode == "" {
+		mode = models.BulkAtomic
+	}
+
+	switch {
+	case mode != models.BulkAtomic && mode != models.BulkBestEffort:
+		return nil, apperr.Validation(apperr.Field("mode", "must be atomic or best_effort"))
+	case len(req.Operations) == 0:
+		return nil, apperr.Validation(apperr.Field("operations", "is required"))
+	case len(req.Operations) > maxBulkOperations:
+		return nil, apperr.Validation(apperr.Field("operations", fmt.Sprintf("must have at most %d items", maxBulkOperations)))
+	}
+
+	ops := req.Operations
+	errs := make([]error, len(ops))
+	seen := make(map[int64]int)
+
+	for i := range ops {
+		errs[i] = checkOperation(i, &ops[i])
+		if errs[i] != nil || ops[i].Op == models.BulkCreate {
+			continue
+		}
+
+		if j, ok := seen[ops[i].ID]; ok {
+			errs[i] = apperr.Validation(apperr.Field(fmt.Sprintf("operations[%d].id", i), fmt.Sprintf("is already used by operations[%d]", j)))
+
+			continue
+		}
+
+		seen[ops[i].ID] = i
+	}
+
+	err := s.checkUsers(ctx, ops, errs)
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([]models.BulkResult, len(ops))
+
+	err = utils.WithTx(ctx, func() error {
+		var existing map[int64]models.Task
+
+		existing, err = s.checkTasks(ctx, ops, errs)
+		if err != nil {
+			return err
+		}
+
+		if mode == models.BulkAtomic {
+			err = rejection(errs)
+			if err != nil {
+				return err
+			}
+		}
+
+		return s.applyBulk(ctx, ops, errs, existing, results)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	res := &models.BulkResponse{Mode: mode, Results: results}
+
+	for i := range results {
+		results[i].Index = i
+		results[i].Op = ops[i].Op
+
+		if results[i].ID == 0 {
+			results[i].ID = ops[i].ID
+		}
+
+		if errs[i] == nil {
+			results[i].Status = models.BulkOK
+			res.Succeeded++
+
+			continue
+		}
+
+		results[i].Status = models.BulkFailed
+		results[i].Error = bulkError(errs[i])
+		res.Failed++
+	}
+
+	return res, nil
+}
+
+// checkOperation validates an operation on its own, without touching the database.
+func checkOperation(i int, op *models.BulkOperation) error {
+	path := fmt.Sprintf("operations[%d]", i)
+
+	switch op.Op {
+	case models.BulkCreate:
+		if op.Task == nil {
+			return apperr.Validation(apperr.Field(path+".task", "is required"))
+		}
+
+		if len(op.Task.Subtasks) > 0 {
+			return apperr.Validation(apperr.Field(path+".task.subtasks", "are not supported in bulk operations"))
+		}
+
+		return validate.Value(path+".task", op.Task)
+	case models.BulkUpdate, models.BulkTransition, models.BulkDelete:
+		if op.ID <= 0 {
+			return apperr.Validation(apperr.Field(path+".id", "is required"))
+		}
+
+		if op.Op == models.BulkTransition && (op.Patch == nil || op.Patch.Status == nil) {
+			return apperr.Validation(apperr.Field(path+".status", "is required"))
+		}
+
+		if op.Op == models.BulkUpdate && (op.Patch == nil || op.Patch.Empty()) {
+			return apperr.Validation(apperr.Field(path+".patch", "must change at least one field"))
+		}
+
+		if op.Patch != nil {
+			return validate.Value(path+".patch", op.Patch)
+		}
+
+		return nil
+	default:
+		return apperr.Validation(apperr.Field(path+".op", "must be create, update, delete or transition"))
+	}
+}
+
+// checkUsers fails the creates assigned to users that do not exist, looking
+// each distinct user up once.
+func (s *service) checkUsers(ctx *gofr.Context, ops []models.BulkOperation, errs []error) error {
+	exists := make(map[int64]bool)
+
+	for i, op := range ops {
+		if errs[i] != nil || op.Op != models.BulkCreate {
+			continue
+		}
+
+		ok, checked := exists[op.Task.UserID]
+		if !checked {
+			_, err := s.userService.GetByID(ctx, op.Task.UserID)
+			if err != nil && apperr.CodeOf(err) != apperr.CodeNotFound {
+				return err
+			}
+
+			ok = err == nil
+			exists[op.Task.UserID] = ok
+		}
+
+		if !ok {
+			errs[i] = apperr.Validation(apperr.Field(fmt.Sprintf("operations[%d].task.user_id", i), "user does not exist"))
+		}
+	}
+
+	return nil
+}
+
+// checkTasks locks the tasks targeted by the remaining operations and the
+// parents of the remaining creates, and fails the operations whose task is
+// missing or at another version than requested, and the creates whose parent
+// is missing, trashed or deleted by the same batch.
+func (s *service) checkTasks(ctx *gofr.Context, ops []models.BulkOperation, errs []error) (map[int64]models.Task, error) {
+	var ids []int64
+
+	for i, op := range ops {
+		switch {
+		case errs[i] != nil:
+		case op.Op != models.BulkCreate:
+			ids = append(ids, op.ID)
+		case op.Task.ParentID != nil:
+			ids = append(ids, *op.Task.ParentID)
+		}
+	}
+
+	existing, err := s.store.GetMany(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+
+	deleted := make(map[int64]bool)
+
+	for i, op := range ops {
+		if errs[i] != nil || op.Op == models.BulkCreate {
+			continue
+		}
+
+		t, ok := existing[op.ID]
+
+		switch {
+		case !ok:
+			errs[i] = apperr.NotFound("task", op.ID)
+		case op.Version != 0 && op.Version != t.Version:
+			errs[i] = apperr.PreconditionFailed(fmt.Sprintf("task %d was modified concurrently, current version is %d", op.ID, t.Version))
+		case op.Op == models.BulkDelete:
+			deleted[op.ID] = true
+		}
+	}
+
+	for i, op := range ops {
+		if errs[i] != nil || op.Op != models.BulkCreate || op.Task.ParentID == nil {
+			continue
+		}
+
+		field := fmt.Sprintf("operations[%d].task.parent_id", i)
+
+		if _, ok := existing[*op.Task.ParentID]; !ok {
+			errs[i] = apperr.Validation(apperr.Field(field, "task does not exist"))
+		} else if deleted[*op.Task.ParentID] {
+			errs[i] = apperr.Validation(apperr.Field(field, "task is deleted by this batch"))
+		}
+	}
+
+	return existing, nil
+}
+
+// rejection gathers the failures of an atomic batch into one validation
+// error, reporting non-validation failures against the whole operation.
+func rejection(errs []error) error {
+	var fields []apperr.FieldError
+
+	for i, err := range errs {
+		if err == nil {
+			continue
+		}
+
+		var appErr *apperr.Error
+		if errors.As(err, &appErr) && appErr.Code == apperr.CodeValidation {
+			fields = append(fields, appErr.Fields...)
+
+			continue
+		}
+
+		fields = append(fields, apperr.Field(fmt.Sprintf("operations[%d]", i), err.Error()))
+	}
+
+	if len(fields) == 0 {
+		return nil
+	}
+
+	return apperr.Validation(fields...)
+}
+
+// applyBulk writes the operations that passed their checks with one batched
+// call per kind, records them in the audit log and the search index, and
+// fills in the created IDs.
+func (s *service) applyBulk(ctx *gofr.Context, ops []models.BulkOperation, errs []error, existing map[int64]models.Task,
+	results []models.BulkResult) error {
+	var (
+		creates   []models.Task
+		createdAt []int
+		patches   []models.TaskPatch
+		deletes   []int64
+	)
+
+	for i, op := range ops {
+		if errs[i] != nil {
+			continue
+		}
+
+		switch op.Op {
+		case models.BulkCreate:
+			creates = append(creates, *op.Task)
+			createdAt = append(createdAt, i)
+		case models.BulkUpdate, models.BulkTransition:
+			p := *op.Patch
+			p.ID = op.ID
+			patches = append(patches, p)
+		case models.BulkDelete:
+			deletes = append(deletes, op.ID)
+		}
+	}
+
+	var entries []*models.AuditEntry
+
+	if len(creates) > 0 {
+		ids, err := s.store.CreateBatch(ctx, creates)
+		if err != nil {
+			return err
+		}
+
+		for k, i := range createdAt {
+			created := creates[k]
+			created.ID = ids[k]
+			created.Version = 1
+			results[i].ID = ids[k]
+
+			entries, err = appendEntry(ctx, entries, created.ID, audit.ActionCreate, nil, &created)
+			if err != nil {
+				return err
+			}
+
+			err = s.notify(ctx, created.ID, audit.ActionCreate, nil, &created)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	if len(patches) > 0 {
+		err := s.store.PatchBatch(ctx, patches)
+		if err != nil {
+			return err
+		}
+
+		for i := range patches {
+			before := existing[patches[i].ID]
+			after := patched(before, &patches[i])
+
+			entries, err = appendEntry(ctx, entries, before.ID, audit.ActionUpdate, &before, &after)
+			if err != nil {
+				return err
+			}
+
+			err = s.notify(ctx, after.ID, audit.ActionUpdate, &before, &after)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	if len(deletes) > 0 {
+		var err error
+
+		entries, err = s.deleteBulk(ctx, deletes, existing, entries)
+		if err != nil {
+			return err
+		}
+	}
+
+	return s.auditStore.CreateBatch(ctx, entries)
+}
+
+// deleteBulk trashes the tasks with their subtasks, and audits and announces
+// the deletion of each.
+func (s *service) deleteBulk(ctx *gofr.Context, ids []int64, existing map[int64]models.Task,
+	entries []*models.AuditEntry) ([]*models.AuditEntry, error) {
+	subtasks, err := s.store.DeleteBatch(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+
+	trashed := make([]models.Task, 0, len(ids)+len(subtasks))
+	for _, id := range ids {
+		trashed = append(trashed, existing[id])
+	}
+
+	trashed = append(trashed, subtasks...)
+
+	for i := range trashed {
+		before := &trashed[i]
+
+		entries, err = appendEntry(ctx, entries, before.ID, audit.ActionDelete, before, nil)
+		if err != nil {
+			return nil, err
+		}
+
+		err = s.notify(ctx, before.ID, audit.ActionDelete, before, nil)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return entries, nil
+}
+
+// patched returns t as PatchBatch leaves it.
+func patched(t models.Task, p *models.TaskPatch) models.Task {
+	if p.Title != nil {
+		t.Title = *p.Title
+	}
+
+	if p.Description != nil {
+		t.Description = *p.Description
+	}
+
+	if p.Status != nil {
+		t.Status = *p.Status
+	}
+
+	if p.DueDate != nil || p.ClearDueDate {
+		t.DueDate = p.DueDate
+	}
+
+	t.Version++
+
+	return t
+}
+
+func appendEntry(ctx *gofr.Context, entries []*models.AuditEntry, id int64, action string,
+	before, after *models.Task) ([]*models.AuditEntry, error) {
+	entry, err := audit.NewEntry(ctx, audit.EntityTask, id, action, before, after)
+	if err != nil {
+		return nil, err
+	}
+
+	return append(entries, entry), nil
+}
+
+func bulkError(err error) *models.BulkError {
+	var appErr *apperr.Error
+	if !errors.As(err, &appErr) {
+		return &models.BulkError{Code: "internal", Message: err.Error()}
+	}
+
+	return &models.BulkError{Code: appErr.Code, Message: appErr.Message, Details: appErr.Fields}
+}
+
+// record audits a change to a task and notifies the search index and event subscribers of it.
+func (s *service) record(ctx *gofr.Context, id int64, action string, before, after *models.Task) error {
+	entry, err := audit.NewEntry(ctx, audit.EntityTask, id, action, before, after)
+	if err != nil {
+		return err
+	}
+
+	err = s.auditStore.Create(ctx, entry)
+	if err != nil {
+		return err
+	}
+
+	return s.notify(ctx, id, action, before, after)
+}
+
+// recordTree records the creation of every task in the trees, setting the
+// version each task was created at.
+func (s *service) recordTree(ctx *gofr.Context, tasks []models.Task) error {
+	for i := range tasks {
+		tasks[i].Version = 1
+
+		created := tasks[i]
+		created.Subtasks = nil
+
+		err := s.record(ctx, created.ID, audit.ActionCreate, nil, &created)
+		if err != nil {
+			return err
+		}
+
+		err = s.recordTree(ctx, tasks[i].Subtasks)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// notify brings the search index in line with a change to a task and emits
+// the events it causes, with the task as it is after the change, or as it was
+// before a deletion.
+func (s *service) notify(ctx *gofr.Context, id int64, action string, before, after *models.Task) error {
+	err := s.reindex(ctx, id, after)
 	if err != nil {
 		return err
 	}
 
+	data := after
+	if data == nil {
+		data = before
+	}
+
+	for _, event := range taskEvents(action, before, after) {
+		err = s.events.Emit(ctx, event, data)
+		if err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
+
+func taskEvents(action string, before, after *models.Task) []string {
+	switch action {
+	case audit.ActionCreate:
+		return []string{models.EventTaskCreated}
+	case audit.ActionUpdate:
+		if !before.Status && after.Status {
+			return []string{models.EventTaskUpdated, models.EventTaskCompleted}
+		}
+
+		return []string{models.EventTaskUpdated}
+	case audit.ActionDelete:
+		return []string{models.EventTaskDeleted}
+	case audit.ActionRestore:
+		return []string{models.EventTaskRestored}
+	}
+
+	return nil
+}
+
+// reindex indexes the task as it is after a change, or removes it when it is gone.
+func (s *service) reindex(ctx *gofr.Context, id int64, after *models.Task) error {
+	if after == nil {
+		return s.index.Remove(ctx, models.SearchKindTask, id)
+	}
+
+	return s.index.Index(ctx, models.SearchDocument{Kind: models.SearchKindTask, ID: id, TaskID: id, Text: after.SearchText()})
+}
@@ -2,43 +2,132 @@ package task
 
 import (
 	"errors"
+	"reflect"
 	"testing"
+	"time"
 
 	"go.uber.org/mock/gomock"
 	"gofr.dev/pkg/gofr"
+	"gofr.dev/pkg/gofr/container"
 
+	"TaskManager2/apperr"
 	"TaskManager2/models"
+	"TaskManager2/search"
 	"TaskManager2/utils"
 )
 
 func TestService_Create(t *testing.T) {
-	var ctx *gofr.Context
+	mockContainer, mock := container.NewMockContainer(t)
+	ctx := &gofr.Context{
+		Context:   t.Context(),
+		Request:   nil,
+		Container: mockContainer,
+	}
 
 	controller := gomock.NewController(t)
 	mockStore := NewMockStore(controller)
 	mockUserSvc := NewMockUserService(controller)
-	taskService := New(mockStore, mockUserSvc)
+	mockAuditStore := NewMockAuditStore(controller)
+	mockEvents := NewMockEvents(controller)
+	index := search.NewMemory()
+	taskService := New(mockStore, mockUserSvc, mockAuditStore, index, mockEvents)
 
 	tests := []struct {
 		description string
 		input       *models.Task
+		mockExpect  func(*models.Task)
 		expectedID  int64
 		expectedErr error
 	}{
-		{"success", &models.Task{}, 0, nil},
-		{"user not validated", &models.Task{UserID: 10}, 0, utils.ErrTest},
-		{"create error", &models.Task{UserID: 11}, 0, utils.ErrTest},
+		{
+			"success",
+			&models.Task{Title: "test", UserID: 1},
+			func(task *models.Task) {
+				mockUserSvc.EXPECT().GetByID(ctx, task.UserID).Return(&models.User{}, nil)
+				mock.SQL.ExpectBegin()
+				mockStore.EXPECT().Create(ctx, task).Return(int64(5), nil)
+				mockAuditStore.EXPECT().Create(ctx, gomock.Any()).
+					DoAndReturn(func(_ *gofr.Context, e *models.AuditEntry) error {
+						if e.Entity != "task" || e.EntityID != 5 || e.Action != "create" {
+							t.Errorf("unexpected audit entry %+v", e)
+						}
+
+						return nil
+					})
+				mockEvents.EXPECT().Emit(ctx, "task.created", &models.Task{ID: 5, Title: "test", UserID: 1, Version: 1}).Return(nil)
+				mock.SQL.ExpectCommit()
+			},
+			5,
+			nil,
+		},
+		{
+			"user not validated",
+			&models.Task{Title: "test", UserID: 10},
+			func(task *models.Task) {
+				mockUserSvc.EXPECT().GetByID(ctx, task.UserID).Return(nil, utils.ErrTest)
+			},
+			0,
+			utils.ErrTest,
+		},
+		{
+			"empty description",
+			&models.Task{Title: " ", UserID: 1},
+			func(*models.Task) {},
+			0,
+			apperr.Validation(apperr.Field("title", "is required")),
+		},
+		{
+			"user does not exist",
+			&models.Task{Title: "test", UserID: 13},
+			func(task *models.Task) {
+				mockUserSvc.EXPECT().GetByID(ctx, task.UserID).Return(nil, apperr.NotFound("user", 13))
+			},
+			0,
+			apperr.Validation(apperr.Field("user_id", "user does not exist")),
+		},
+		{
+			"create error",
+			&models.Task{Title: "test", UserID: 11},
+			func(task *models.Task) {
+				mockUserSvc.EXPECT().GetByID(ctx, task.UserID).Return(&models.User{}, nil)
+				mock.SQL.ExpectBegin()
+				mockStore.EXPECT().Create(ctx, task).Return(int64(0), utils.ErrTest)
+				mock.SQL.ExpectRollback()
+			},
+			0,
+			utils.ErrTest,
+		},
+		{
+			"audit error",
+			&models.Task{Title: "test", UserID: 12},
+			func(task *models.Task) {
+				mockUserSvc.EXPECT().GetByID(ctx, task.UserID).Return(&models.User{}, nil)
+				mock.SQL.ExpectBegin()
+				mockStore.EXPECT().Create(ctx, task).Return(int64(6), nil)
+				mockAuditStore.EXPECT().Create(ctx, gomock.Any()).Return(utils.ErrTest)
+				mock.SQL.ExpectRollback()
+			},
+			0,
+			utils.ErrTest,
+		},
+		{
+			"emit error",
+			&models.Task{Title: "other", UserID: 14},
+			func(task *models.Task) {
+				mockUserSvc.EXPECT().GetByID(ctx, task.UserID).Return(&models.User{}, nil)
+				mock.SQL.ExpectBegin()
+				mockStore.EXPECT().Create(ctx, task).Return(int64(7), nil)
+				mockAuditStore.EXPECT().Create(ctx, gomock.Any()).Return(nil)
+				mockEvents.EXPECT().Emit(ctx, "task.created", gomock.Any()).Return(utils.ErrTest)
+				mock.SQL.ExpectRollback()
+			},
+			0,
+			utils.ErrTest,
+		},
 	}
 
 	for _, tc := range tests {
-		if tc.input.UserID != 10 {
-			mockStore.EXPECT().Create(ctx, tc.input).Return(tc.expectedID, tc.expectedErr)
-			mockUserSvc.EXPECT().GetByID(ctx, tc.input.UserID).Return(&models.User{}, nil)
-		}
-
-		if tc.input.UserID == 10 {
-			mockUserSvc.EXPECT().GetByID(ctx, tc.input.UserID).Return(nil, tc.expectedErr)
-		}
+		tc.mockExpect(tc.input)
 
 		id, err := taskService.Create(ctx, tc.input)
 		if !errors.Is(err, tc.expectedErr) {
@@ -49,6 +138,69 @@ func TestService_Create(t *testing.T) {
 			t.Errorf("Expected id %d, got %d", tc.expectedID, id)
 		}
 	}
+
+	q, _ := search.Parse("test")
+
+	hits, _ := index.Search(ctx, q, 10)
+	if len(hits) != 1 || hits[0].ID != 5 {
+		t.Errorf("expected only the created task to be indexed, got %+v", hits)
+	}
+}
+
+func TestService_CreateTree(t *testing.T) {
+	mockContainer, mock := container.NewMockContainer(t)
+	ctx := &gofr.Context{
+		Context:   t.Context(),
+		Request:   nil,
+		Container: mockContainer,
+	}
+
+	controller := gomock.NewController(t)
+	mockStore := NewMockStore(controller)
+	mockAuditStore := NewMockAuditStore(controller)
+	mockEvents := NewMockEvents(controller)
+	index := search.NewMemory()
+	taskService := New(mockStore, NewMockUserService(controller), mockAuditStore, index, mockEvents)
+
+	input := []models.Task{{Title: "Release", UserID: 2, Subtasks: []models.Task{{Title: "Announce", UserID: 2}}}}
+	inserted := []models.Task{{ID: 1, Title: "Release", UserID: 2, Subtasks: []models.Task{{ID: 2, Title: "Announce", UserID: 2}}}}
+	want := []models.Task{{ID: 1, Title: "Release", UserID: 2, Version: 1,
+		Subtasks: []models.Task{{ID: 2, Title: "Announce", UserID: 2, Version: 1}}}}
+
+	mock.SQL.ExpectBegin()
+	mockStore.EXPECT().CreateTree(ctx, input).Return(inserted, nil)
+	mockAuditStore.EXPECT().Create(ctx, audited(1, "create")).Return(nil)
+	mockEvents.EXPECT().Emit(ctx, "task.created", &models.Task{ID: 1, Title: "Release", UserID: 2, Version: 1}).Return(nil)
+	mockAuditStore.EXPECT().Create(ctx, audited(2, "create")).Return(nil)
+	mockEvents.EXPECT().Emit(ctx, "task.created", &models.Task{ID: 2, Title: "Announce", UserID: 2, Version: 1}).Return(nil)
+	mock.SQL.ExpectCommit()
+
+	created, err := taskService.CreateTree(ctx, input)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if !reflect.DeepEqual(created, want) {
+		t.Errorf("expected %+v, got %+v", want, created)
+	}
+
+	q, _ := search.Parse("announce")
+
+	if hits, _ := index.Search(ctx, q, 10); len(hits) != 1 || hits[0].ID != 2 {
+		t.Errorf("expected the subtask to be indexed, got %+v", hits)
+	}
+
+	mock.SQL.ExpectBegin()
+	mockStore.EXPECT().CreateTree(ctx, input).Return(nil, utils.ErrTest)
+	mock.SQL.ExpectRollback()
+
+	if _, err = taskService.CreateTree(ctx, input); !errors.Is(err, utils.ErrTest) {
+		t.Errorf("expected %v, got %v", utils.ErrTest, err)
+	}
+
+	if err = mock.SQL.ExpectationsWereMet(); err != nil {
+		t.Errorf("unmet expectations: %v", err)
+	}
 }
 
 func TestService_GetAll(t *testing.T) {
@@ -57,7 +209,7 @@ func TestService_GetAll(t *testing.T) {
 	controller := gomock.NewController(t)
 	mockStore := NewMockStore(controller)
 	mockUserSvc := NewMockUserService(controller)
-	taskService := New(mockStore, mockUserSvc)
+	taskService := New(mockStore, mockUserSvc, NewMockAuditStore(controller), search.NewMemory(), NewMockEvents(controller))
 
 	testcases := []struct {
 		description   string
@@ -69,22 +221,143 @@ func TestService_GetAll(t *testing.T) {
 	}
 
 	for _, test := range testcases {
-		mockStore.EXPECT().GetAll(ctx).Return(test.expected, test.expectedError)
+		mockStore.EXPECT().GetAll(ctx, &models.TaskFilter{}).Return(test.expected, test.expectedError)
 
-		_, err := taskService.GetAll(ctx)
+		_, err := taskService.GetAll(ctx, &models.TaskFilter{})
 		if !errors.Is(err, test.expectedError) {
 			t.Errorf("Test Failed: (%s) Expected: (%s) Actual: (%s)", test.description, test.expectedError, err)
 		}
 	}
 }
 
+func TestService_GetByUser(t *testing.T) {
+	var ctx *gofr.Context
+
+	controller := gomock.NewController(t)
+	mockStore := NewMockStore(controller)
+	mockUserSvc := NewMockUserService(controller)
+	taskService := New(mockStore, mockUserSvc, NewMockAuditStore(controller), search.NewMemory(), NewMockEvents(controller))
+
+	status, userID := true, int64(2)
+	filter := &models.TaskFilter{Status: &status}
+
+	testcases := []struct {
+		description   string
+		mockExpect    func()
+		expected      []models.Task
+		expectedError error
+	}{
+		{
+			"success",
+			func() {
+				mockUserSvc.EXPECT().GetByID(ctx, userID).Return(&models.User{ID: userID}, nil)
+				mockStore.EXPECT().GetAll(ctx, &models.TaskFilter{UserID: &userID, Status: &status}).Return([]models.Task{{ID: 1}}, nil)
+			},
+			[]models.Task{{ID: 1}},
+			nil,
+		},
+		{
+			"user not found",
+			func() {
+				mockUserSvc.EXPECT().GetByID(ctx, userID).Return(nil, apperr.NotFound("user", userID))
+			},
+			nil,
+			apperr.NotFound("user", userID),
+		},
+		{
+			"store error",
+			func() {
+				mockUserSvc.EXPECT().GetByID(ctx, userID).Return(&models.User{ID: userID}, nil)
+				mockStore.EXPECT().GetAll(ctx, gomock.Any()).Return(nil, utils.ErrTest)
+			},
+			nil,
+			utils.ErrTest,
+		},
+	}
+
+	for _, tc := range testcases {
+		tc.mockExpect()
+
+		tasks, err := taskService.GetByUser(ctx, userID, filter)
+		if !errors.Is(err, tc.expectedError) {
+			t.Errorf("%s: expected error %v, got %v", tc.description, tc.expectedError, err)
+		}
+
+		if !reflect.DeepEqual(tasks, tc.expected) {
+			t.Errorf("%s: expected %v, got %v", tc.description, tc.expected, tasks)
+		}
+	}
+
+	if filter.UserID != nil {
+		t.Errorf("expected the caller's filter to be left untouched")
+	}
+}
+
+func TestService_Summary(t *testing.T) {
+	var ctx *gofr.Context
+
+	controller := gomock.NewController(t)
+	mockStore := NewMockStore(controller)
+	mockUserSvc := NewMockUserService(controller)
+	taskService := New(mockStore, mockUserSvc, NewMockAuditStore(controller), search.NewMemory(), NewMockEvents(controller))
+
+	summary := &models.TaskSummary{UserID: 2, ByStatus: map[string]int64{"open": 1, "done": 0}}
+
+	testcases := []struct {
+		description   string
+		mockExpect    func()
+		expected      *models.TaskSummary
+		expectedError error
+	}{
+		{
+			"success",
+			func() {
+				mockUserSvc.EXPECT().GetByID(ctx, int64(2)).Return(&models.User{ID: 2}, nil)
+				mockStore.EXPECT().Summary(ctx, int64(2), gomock.Any()).Return(summary, nil)
+			},
+			summary,
+			nil,
+		},
+		{
+			"user not found",
+			func() {
+				mockUserSvc.EXPECT().GetByID(ctx, int64(2)).Return(nil, apperr.NotFound("user", 2))
+			},
+			nil,
+			apperr.NotFound("user", 2),
+		},
+		{
+			"store error",
+			func() {
+				mockUserSvc.EXPECT().GetByID(ctx, int64(2)).Return(&models.User{ID: 2}, nil)
+				mockStore.EXPECT().Summary(ctx, int64(2), gomock.Any()).Return(nil, utils.ErrTest)
+			},
+			nil,
+			utils.ErrTest,
+		},
+	}
+
+	for _, tc := range testcases {
+		tc.mockExpect()
+
+		got, err := taskService.Summary(ctx, 2)
+		if !errors.Is(err, tc.expectedError) {
+			t.Errorf("%s: expected error %v, got %v", tc.description, tc.expectedError, err)
+		}
+
+		if !reflect.DeepEqual(got, tc.expected) {
+			t.Errorf("%s: expected %v, got %v", tc.description, tc.expected, got)
+		}
+	}
+}
+
 func TestService_GetByID(t *testing.T) {
 	var ctx *gofr.Context
 
 	controller := gomock.NewController(t)
 	mockStore := NewMockStore(controller)
 	mockUserSvc := NewMockUserService(controller)
-	taskService := New(mockStore, mockUserSvc)
+	taskService := New(mockStore, mockUserSvc, NewMockAuditStore(controller), search.NewMemory(), NewMockEvents(controller))
 
 	testcases := []struct {
 		description   string
@@ -106,56 +379,729 @@ func TestService_GetByID(t *testing.T) {
 	}
 }
 
-func TestService_Update(t *testing.T) {
+func TestService_RenderDescription(t *testing.T) {
 	var ctx *gofr.Context
 
+	controller := gomock.NewController(t)
+	mockStore := NewMockStore(controller)
+	taskService := New(mockStore, NewMockUserService(controller), NewMockAuditStore(controller), search.NewMemory(), NewMockEvents(controller))
+
+	testcases := []struct {
+		description   string
+		task          *models.Task
+		storeErr      error
+		expected      *models.RenderedDescription
+		expectedError error
+	}{
+		{
+			"success",
+			&models.Task{ID: 1, Title: "release", Description: "# Notes\n<script>x</script>", Version: 2},
+			nil,
+			&models.RenderedDescription{TaskID: 1, Version: 2, HTML: "<h1>Notes</h1>\n<p>&lt;script&gt;x&lt;/script&gt;</p>\n"},
+			nil,
+		},
+		{"store GetByID method error", nil, utils.ErrTest, nil, utils.ErrTest},
+	}
+
+	for _, tc := range testcases {
+		mockStore.EXPECT().GetByID(ctx, int64(1)).Return(tc.task, tc.storeErr)
+
+		rendered, err := taskService.RenderDescription(ctx, 1)
+		if !errors.Is(err, tc.expectedError) {
+			t.Errorf("Expected error: %s, got %s", tc.expectedError, err)
+		}
+
+		if !reflect.DeepEqual(rendered, tc.expected) {
+			t.Errorf("Expected %+v, got %+v", tc.expected, rendered)
+		}
+	}
+}
+
+func TestService_Update(t *testing.T) {
+	mockContainer, mock := container.NewMockContainer(t)
+	ctx := &gofr.Context{
+		Context:   t.Context(),
+		Request:   nil,
+		Container: mockContainer,
+	}
+
+	controller := gomock.NewController(t)
+	mockStore := NewMockStore(controller)
+	mockUserSvc := NewMockUserService(controller)
+	mockAuditStore := NewMockAuditStore(controller)
+	taskService := New(mockStore, mockUserSvc, mockAuditStore, search.NewMemory(), anyEvents(controller))
+
+	input := &models.Task{ID: 1, Title: "final"}
+
+	testcases := []struct {
+		description   string
+		mockExpect    func()
+		expectedError error
+	}{
+		{
+			"success",
+			func() {
+				mock.SQL.ExpectBegin()
+				mockStore.EXPECT().GetByID(ctx, int64(1)).Return(&models.Task{ID: 1, Title: "draft"}, nil)
+				mockStore.EXPECT().Update(ctx, input).Return(nil)
+				mockStore.EXPECT().GetByID(ctx, int64(1)).Return(&models.Task{ID: 1, Title: "final"}, nil)
+				mockAuditStore.EXPECT().Create(ctx, gomock.Any()).
+					DoAndReturn(func(_ *gofr.Context, e *models.AuditEntry) error {
+						want := map[string]models.Change{"title": {Before: "draft", After: "final"}}
+						if e.Action != "update" || !reflect.DeepEqual(e.Changes, want) {
+							t.Errorf("unexpected audit entry %+v", e)
+						}
+
+						return nil
+					})
+				mock.SQL.ExpectCommit()
+			},
+			nil,
+		},
+		{
+			"task not found",
+			func() {
+				mock.SQL.ExpectBegin()
+				mockStore.EXPECT().GetByID(ctx, int64(1)).Return(nil, utils.ErrTest)
+				mock.SQL.ExpectRollback()
+			},
+			utils.ErrTest,
+		},
+		{
+			"store Update method error",
+			func() {
+				mock.SQL.ExpectBegin()
+				mockStore.EXPECT().GetByID(ctx, int64(1)).Return(&models.Task{ID: 1}, nil)
+				mockStore.EXPECT().Update(ctx, input).Return(utils.ErrTest)
+				mock.SQL.ExpectRollback()
+			},
+			utils.ErrTest,
+		},
+	}
+
+	for _, tc := range testcases {
+		tc.mockExpect()
+
+		err := taskService.Update(ctx, input)
+		if !errors.Is(err, tc.expectedError) {
+			t.Errorf("Expected error: %s, got %s", tc.expectedError, err)
+		}
+	}
+}
+
+func TestService_Patch(t *testing.T) {
+	mockContainer, mock := container.NewMockContainer(t)
+	ctx := &gofr.Context{
+		Context:   t.Context(),
+		Request:   nil,
+		Container: mockContainer,
+	}
+
 	controller := gomock.NewController(t)
 	mockStore := NewMockStore(controller)
 	mockUserSvc := NewMockUserService(controller)
-	taskService := New(mockStore, mockUserSvc)
+	mockAuditStore := NewMockAuditStore(controller)
+	taskService := New(mockStore, mockUserSvc, mockAuditStore, search.NewMemory(), anyEvents(controller))
+
+	status := true
+	patch := &models.TaskPatch{Status: &status}
 
 	testcases := []struct {
 		description   string
-		input         *models.Task
+		input         *models.TaskPatch
+		mockExpect    func()
+		expected      *models.Task
 		expectedError error
 	}{
-		{"success", &models.Task{}, nil},
-		{"store Update method error", nil, utils.ErrTest},
+		{
+			"success",
+			patch,
+			func() {
+				mock.SQL.ExpectBegin()
+				mockStore.EXPECT().GetByID(ctx, int64(1)).Return(&models.Task{ID: 1, Title: "draft", Version: 1}, nil)
+				mockStore.EXPECT().Patch(ctx, int64(1), patch).Return(nil)
+				mockStore.EXPECT().GetByID(ctx, int64(1)).Return(&models.Task{ID: 1, Title: "draft", Status: true, Version: 2}, nil)
+				mockAuditStore.EXPECT().Create(ctx, gomock.Any()).
+					DoAndReturn(func(_ *gofr.Context, e *models.AuditEntry) error {
+						want := map[string]models.Change{
+							"status":  {Before: false, After: true},
+							"version": {Before: float64(1), After: float64(2)},
+						}
+						if e.Action != "update" || !reflect.DeepEqual(e.Changes, want) {
+							t.Errorf("unexpected audit entry %+v", e)
+						}
+
+						return nil
+					})
+				mock.SQL.ExpectCommit()
+			},
+			&models.Task{ID: 1, Title: "draft", Status: true, Version: 2},
+			nil,
+		},
+		{
+			"invalid patch",
+			&models.TaskPatch{Title: new(string)},
+			func() {},
+			nil,
+			apperr.Validation(apperr.Field("title", "is required")),
+		},
+		{
+			"empty patch",
+			&models.TaskPatch{},
+			func() {
+				mock.SQL.ExpectBegin()
+				mockStore.EXPECT().GetByID(ctx, int64(1)).Return(&models.Task{ID: 1, Title: "draft", Version: 1}, nil)
+				mock.SQL.ExpectCommit()
+			},
+			&models.Task{ID: 1, Title: "draft", Version: 1},
+			nil,
+		},
+		{
+			"task not found",
+			patch,
+			func() {
+				mock.SQL.ExpectBegin()
+				mockStore.EXPECT().GetByID(ctx, int64(1)).Return(nil, utils.ErrTest)
+				mock.SQL.ExpectRollback()
+			},
+			nil,
+			utils.ErrTest,
+		},
+		{
+			"store Patch method error",
+			patch,
+			func() {
+				mock.SQL.ExpectBegin()
+				mockStore.EXPECT().GetByID(ctx, int64(1)).Return(&models.Task{ID: 1}, nil)
+				mockStore.EXPECT().Patch(ctx, int64(1), patch).Return(utils.ErrTest)
+				mock.SQL.ExpectRollback()
+			},
+			nil,
+			utils.ErrTest,
+		},
 	}
 
 	for _, tc := range testcases {
-		mockStore.EXPECT().Update(ctx, tc.input).Return(tc.expectedError)
+		tc.mockExpect()
 
-		err := taskService.Update(ctx, tc.input)
+		task, err := taskService.Patch(ctx, 1, tc.input)
 		if !errors.Is(err, tc.expectedError) {
 			t.Errorf("Expected error: %s, got %s", tc.expectedError, err)
 		}
+
+		if !reflect.DeepEqual(task, tc.expected) {
+			t.Errorf("Expected task: %v, got %v", tc.expected, task)
+		}
 	}
 }
 
 func TestService_Delete(t *testing.T) {
+	mockContainer, mock := container.NewMockContainer(t)
+	ctx := &gofr.Context{
+		Context:   t.Context(),
+		Request:   nil,
+		Container: mockContainer,
+	}
+
+	controller := gomock.NewController(t)
+	mockStore := NewMockStore(controller)
+	mockUserSvc := NewMockUserService(controller)
+	mockAuditStore := NewMockAuditStore(controller)
+	taskService := New(mockStore, mockUserSvc, mockAuditStore, search.NewMemory(), anyEvents(controller))
+	parentID := int64(1)
+
+	testcases := []struct {
+		description   string
+		mockExpect    func()
+		expectedError error
+	}{
+		{
+			"success",
+			func() {
+				mock.SQL.ExpectBegin()
+				mockStore.EXPECT().GetByID(ctx, int64(1)).Return(&models.Task{ID: 1}, nil)
+				mockStore.EXPECT().Delete(ctx, int64(1)).Return([]models.Task{{ID: 2, ParentID: &parentID}}, nil)
+				gomock.InOrder(
+					mockAuditStore.EXPECT().Create(ctx, audited(1, "delete")).Return(nil),
+					mockAuditStore.EXPECT().Create(ctx, audited(2, "delete")).Return(nil),
+				)
+				mock.SQL.ExpectCommit()
+			},
+			nil,
+		},
+		{
+			"store Delete method error",
+			func() {
+				mock.SQL.ExpectBegin()
+				mockStore.EXPECT().GetByID(ctx, int64(1)).Return(&models.Task{ID: 1}, nil)
+				mockStore.EXPECT().Delete(ctx, int64(1)).Return(nil, utils.ErrTest)
+				mock.SQL.ExpectRollback()
+			},
+			utils.ErrTest,
+		},
+	}
+
+	for _, tc := range testcases {
+		tc.mockExpect()
+
+		err := taskService.Delete(ctx, 1)
+		if !errors.Is(err, tc.expectedError) {
+			t.Errorf("Expected error: %s, got %s", tc.expectedError, err)
+		}
+	}
+}
+
+func TestService_GetTrash(t *testing.T) {
 	var ctx *gofr.Context
 
 	controller := gomock.NewController(t)
 	mockStore := NewMockStore(controller)
 	mockUserSvc := NewMockUserService(controller)
-	taskService := New(mockStore, mockUserSvc)
+	taskService := New(mockStore, mockUserSvc, NewMockAuditStore(controller), search.NewMemory(), NewMockEvents(controller))
 
 	testcases := []struct {
 		description   string
-		input         int64
+		expected      []models.Task
+		expectedError error
+	}{
+		{"success", []models.Task{{}}, nil},
+		{"store GetTrash method error", nil, utils.ErrTest},
+	}
+
+	for _, tc := range testcases {
+		mockStore.EXPECT().GetTrash(ctx).Return(tc.expected, tc.expectedError)
+
+		_, err := taskService.GetTrash(ctx)
+		if !errors.Is(err, tc.expectedError) {
+			t.Errorf("Expected error: %s, got %s", tc.expectedError, err)
+		}
+	}
+}
+
+func TestService_Page(t *testing.T) {
+	var ctx *gofr.Context
+
+	controller := gomock.NewController(t)
+	mockStore := NewMockStore(controller)
+	taskService := New(mockStore, NewMockUserService(controller), NewMockAuditStore(controller), search.NewMemory(),
+		NewMockEvents(controller))
+
+	mockStore.EXPECT().Page(ctx, int64(10), 2).Return([]models.Task{{ID: 11}, {ID: 12}}, nil)
+
+	tasks, err := taskService.Page(ctx, 10, 2)
+	if err != nil || len(tasks) != 2 {
+		t.Errorf("expected 2 tasks, got %v, %v", tasks, err)
+	}
+
+	mockStore.EXPECT().Page(ctx, int64(0), 2).Return(nil, utils.ErrTest)
+
+	_, err = taskService.Page(ctx, 0, 2)
+	if !errors.Is(err, utils.ErrTest) {
+		t.Errorf("expected %v, got %v", utils.ErrTest, err)
+	}
+}
+
+func TestService_Restore(t *testing.T) {
+	mockContainer, mock := container.NewMockContainer(t)
+	ctx := &gofr.Context{
+		Context:   t.Context(),
+		Request:   nil,
+		Container: mockContainer,
+	}
+
+	controller := gomock.NewController(t)
+	mockStore := NewMockStore(controller)
+	mockUserSvc := NewMockUserService(controller)
+	mockAuditStore := NewMockAuditStore(controller)
+	taskService := New(mockStore, mockUserSvc, mockAuditStore, search.NewMemory(), anyEvents(controller))
+
+	testcases := []struct {
+		description   string
+		mockExpect    func()
+		expectedError error
+	}{
+		{
+			"success",
+			func() {
+				mock.SQL.ExpectBegin()
+				mockStore.EXPECT().Restore(ctx, int64(1)).Return([]models.Task{{ID: 2, Version: 2}}, nil)
+				mockStore.EXPECT().GetByID(ctx, int64(1)).Return(&models.Task{ID: 1}, nil)
+				gomock.InOrder(
+					mockAuditStore.EXPECT().Create(ctx, audited(1, "restore")).Return(nil),
+					mockAuditStore.EXPECT().Create(ctx, gomock.Cond(func(e *models.AuditEntry) bool {
+						return e.EntityID == 2 && e.Action == "restore" && e.Changes["version"].After == float64(3)
+					})).Return(nil),
+				)
+				mock.SQL.ExpectCommit()
+			},
+			nil,
+		},
+		{
+			"store Restore method error",
+			func() {
+				mock.SQL.ExpectBegin()
+				mockStore.EXPECT().Restore(ctx, int64(1)).Return(nil, utils.ErrTest)
+				mock.SQL.ExpectRollback()
+			},
+			utils.ErrTest,
+		},
+	}
+
+	for _, tc := range testcases {
+		tc.mockExpect()
+
+		err := taskService.Restore(ctx, 1)
+		if !errors.Is(err, tc.expectedError) {
+			t.Errorf("Expected error: %s, got %s", tc.expectedError, err)
+		}
+	}
+}
+
+func TestService_GetHistory(t *testing.T) {
+	var ctx *gofr.Context
+
+	controller := gomock.NewController(t)
+	mockStore := NewMockStore(controller)
+	mockUserSvc := NewMockUserService(controller)
+	mockAuditStore := NewMockAuditStore(controller)
+	taskService := New(mockStore, mockUserSvc, mockAuditStore, search.NewMemory(), anyEvents(controller))
+
+	testcases := []struct {
+		description   string
+		expected      []models.AuditEntry
 		expectedError error
 	}{
-		{"success", 1, nil},
-		{"store Delete method error", 0, utils.ErrTest},
+		{"success", []models.AuditEntry{{ID: 1}}, nil},
+		{"audit store error", nil, utils.ErrTest},
 	}
 
 	for _, tc := range testcases {
-		mockStore.EXPECT().Delete(ctx, tc.input).Return(tc.expectedError)
+		mockAuditStore.EXPECT().GetByEntity(ctx, "task", int64(1)).Return(tc.expected, tc.expectedError)
 
-		err := taskService.Delete(ctx, tc.input)
+		entries, err := taskService.GetHistory(ctx, 1)
 		if !errors.Is(err, tc.expectedError) {
 			t.Errorf("Expected error: %s, got %s", tc.expectedError, err)
 		}
+
+		if len(entries) != len(tc.expected) {
+			t.Errorf("Expected %d entries, got %d", len(tc.expected), len(entries))
+		}
+	}
+}
+
+func TestService_PurgeTrash(t *testing.T) {
+	mockContainer, mock := container.NewMockContainer(t)
+	ctx := &gofr.Context{
+		Context:   t.Context(),
+		Request:   nil,
+		Container: mockContainer,
+	}
+
+	controller := gomock.NewController(t)
+	mockStore := NewMockStore(controller)
+	mockAuditStore := NewMockAuditStore(controller)
+	taskService := New(mockStore, NewMockUserService(controller), mockAuditStore, search.NewMemory(), NewMockEvents(controller))
+
+	purged := []models.Task{{ID: 1, Title: "old"}, {ID: 2, Title: "older"}}
+
+	testcases := []struct {
+		description   string
+		mockExpect    func()
+		expected      int64
+		expectedError error
+	}{
+		{
+			"success",
+			func() {
+				mock.SQL.ExpectBegin()
+				mockStore.EXPECT().Purge(ctx, gomock.Any()).
+					DoAndReturn(func(_ *gofr.Context, before time.Time) ([]models.Task, error) {
+						if age := time.Since(before); age < 30*24*time.Hour {
+							t.Errorf("expected cutoff at least 30 days ago, got %s", age)
+						}
+
+						return purged, nil
+					})
+				mockAuditStore.EXPECT().CreateBatch(ctx, gomock.Any()).
+					DoAndReturn(func(_ *gofr.Context, entries []*models.AuditEntry) error {
+						if len(entries) != 2 || entries[0].EntityID != 1 || entries[1].EntityID != 2 ||
+							entries[0].Action != "purge" || entries[1].Changes["title"].Before != "older" {
+							t.Errorf("expected a purge entry for each task, got %+v", entries)
+						}
+
+						return nil
+					})
+				mock.SQL.ExpectCommit()
+			},
+			2,
+			nil,
+		},
+		{
+			"store Purge method error",
+			func() {
+				mock.SQL.ExpectBegin()
+				mockStore.EXPECT().Purge(ctx, gomock.Any()).Return(nil, utils.ErrTest)
+				mock.SQL.ExpectRollback()
+			},
+			0,
+			utils.ErrTest,
+		},
+		{
+			"audit error",
+			func() {
+				mock.SQL.ExpectBegin()
+				mockStore.EXPECT().Purge(ctx, gomock.Any()).Return(purged, nil)
+				mockAuditStore.EXPECT().CreateBatch(ctx, gomock.Any()).Return(utils.ErrTest)
+				mock.SQL.ExpectRollback()
+			},
+			0,
+			utils.ErrTest,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.description, func(t *testing.T) {
+			tc.mockExpect()
+
+			count, err := taskService.PurgeTrash(ctx, 30*24*time.Hour)
+			if !errors.Is(err, tc.expectedError) {
+				t.Errorf("Expected error: %s, got %s", tc.expectedError, err)
+			}
+
+			if count != tc.expected {
+				t.Errorf("Expected purged %d, got %d", tc.expected, count)
+			}
+		})
+	}
+}
+
+func TestService_Bulk(t *testing.T) {
+	mockContainer, mock := container.NewMockContainer(t)
+	ctx := &gofr.Context{
+		Context:   t.Context(),
+		Request:   nil,
+		Container: mockContainer,
+	}
+
+	controller := gomock.NewController(t)
+	mockStore := NewMockStore(controller)
+	mockUserSvc := NewMockUserService(controller)
+	mockAuditStore := NewMockAuditStore(controller)
+	taskService := New(mockStore, mockUserSvc, mockAuditStore, search.NewMemory(), anyEvents(controller))
+
+	done := true
+	create := models.BulkOperation{Op: models.BulkCreate, Task: &models.Task{Title: "new", UserID: 1}}
+	transition := models.BulkOperation{Op: models.BulkTransition, ID: 2, Patch: &models.TaskPatch{Status: &done}}
+	remove := models.BulkOperation{Op: models.BulkDelete, ID: 3}
+	existing := map[int64]models.Task{2: {ID: 2, Title: "open", UserID: 1, Version: 3}, 3: {ID: 3, Title: "stale", UserID: 1, Version: 1}}
+	parentLive, parentMissing, parentDeleted := int64(2), int64(8), int64(3)
+	expectAudit := func(actions ...string) {
+		mockAuditStore.EXPECT().CreateBatch(ctx, gomock.Any()).
+			DoAndReturn(func(_ *gofr.Context, entries []*models.AuditEntry) error {
+				got := make([]string, len(entries))
+				for i, e := range entries {
+					got[i] = e.Action
+				}
+
+				if !reflect.DeepEqual(got, actions) {
+					t.Errorf("expected audit actions %v, got %v", actions, got)
+				}
+
+				return nil
+			})
+	}
+
+	testcases := []struct {
+		description   string
+		input         *models.BulkRequest
+		mockExpect    func()
+		expected      *models.BulkResponse
+		expectedError error
+	}{
+		{
+			"atomic success",
+			&models.BulkRequest{Operations: []models.BulkOperation{create, transition, remove}},
+			func() {
+				mockUserSvc.EXPECT().GetByID(ctx, int64(1)).Return(&models.User{ID: 1}, nil)
+				mock.SQL.ExpectBegin()
+				mockStore.EXPECT().GetMany(ctx, []int64{2, 3}).Return(existing, nil)
+				mockStore.EXPECT().CreateBatch(ctx, []models.Task{*create.Task}).Return([]int64{10}, nil)
+				mockStore.EXPECT().PatchBatch(ctx, []models.TaskPatch{{ID: 2, Status: &done}}).Return(nil)
+				mockStore.EXPECT().DeleteBatch(ctx, []int64{3}).Return([]models.Task{{ID: 4}}, nil)
+				expectAudit("create", "update", "delete", "delete")
+				mock.SQL.ExpectCommit()
+			},
+			&models.BulkResponse{Mode: models.BulkAtomic, Succeeded: 3, Results: []models.BulkResult{
+				{Index: 0, Op: "create", ID: 10, Status: models.BulkOK},
+				{Index: 1, Op: "transition", ID: 2, Status: models.BulkOK},
+				{Index: 2, Op: "delete", ID: 3, Status: models.BulkOK},
+			}},
+			nil,
+		},
+		{
+			"atomic rejects the whole batch",
+			&models.BulkRequest{Operations: []models.BulkOperation{
+				transition,
+				{Op: models.BulkCreate, Task: &models.Task{UserID: 1}},
+			}},
+			func() {
+				mock.SQL.ExpectBegin()
+				mockStore.EXPECT().GetMany(ctx, []int64{2}).Return(map[int64]models.Task{}, nil)
+				mock.SQL.ExpectRollback()
+			},
+			nil,
+			apperr.Validation(apperr.Field("operations[0]", "task 2 not found"), apperr.Field("operations[1].task.title", "is required")),
+		},
+		{
+			"best effort reports failures",
+			&models.BulkRequest{Mode: models.BulkBestEffort, Operations: []models.BulkOperation{
+				{Op: models.BulkUpdate, ID: 2, Version: 1, Patch: &models.TaskPatch{Status: &done}},
+				remove,
+				{Op: models.BulkCreate, Task: &models.Task{Title: "orphan", UserID: 9}},
+				{Op: models.BulkDelete, ID: 3},
+			}},
+			func() {
+				mockUserSvc.EXPECT().GetByID(ctx, int64(9)).Return(nil, apperr.NotFound("user", 9))
+				mock.SQL.ExpectBegin()
+				mockStore.EXPECT().GetMany(ctx, []int64{2, 3}).Return(existing, nil)
+				mockStore.EXPECT().DeleteBatch(ctx, []int64{3}).Return(nil, nil)
+				expectAudit("delete")
+				mock.SQL.ExpectCommit()
+			},
+			&models.BulkResponse{Mode: models.BulkBestEffort, Succeeded: 1, Failed: 3, Results: []models.BulkResult{
+				{Index: 0, Op: "update", ID: 2, Status: models.BulkFailed, Error: &models.BulkError{
+					Code: apperr.CodePreconditionFailed, Message: "task 2 was modified concurrently, current version is 3"}},
+				{Index: 1, Op: "delete", ID: 3, Status: models.BulkOK},
+				{Index: 2, Op: "create", Status: models.BulkFailed, Error: &models.BulkError{
+					Code: apperr.CodeValidation, Message: "invalid operations[2].task.user_id",
+					Details: []apperr.FieldError{{Field: "operations[2].task.user_id", Reason: "user does not exist"}}}},
+				{Index: 3, Op: "delete", ID: 3, Status: models.BulkFailed, Error: &models.BulkError{
+					Code: apperr.CodeValidation, Message: "invalid operations[3].id",
+					Details: []apperr.FieldError{{Field: "operations[3].id", Reason: "is already used by operations[1]"}}}},
+			}},
+			nil,
+		},
+		{
+			"best effort checks the parents of creates",
+			&models.BulkRequest{Mode: models.BulkBestEffort, Operations: []models.BulkOperation{
+				{Op: models.BulkCreate, Task: &models.Task{Title: "child", UserID: 1, ParentID: &parentLive}},
+				{Op: models.BulkCreate, Task: &models.Task{Title: "orphan", UserID: 1, ParentID: &parentMissing}},
+				{Op: models.BulkCreate, Task: &models.Task{Title: "doomed", UserID: 1, ParentID: &parentDeleted}},
+				remove,
+			}},
+			func() {
+				mockUserSvc.EXPECT().GetByID(ctx, int64(1)).Return(&models.User{ID: 1}, nil)
+				mock.SQL.ExpectBegin()
+				mockStore.EXPECT().GetMany(ctx, []int64{2, 8, 3, 3}).Return(existing, nil)
+				mockStore.EXPECT().CreateBatch(ctx, []models.Task{{Title: "child", UserID: 1, ParentID: &parentLive}}).
+					Return([]int64{10}, nil)
+				mockStore.EXPECT().DeleteBatch(ctx, []int64{3}).Return(nil, nil)
+				expectAudit("create", "delete")
+				mock.SQL.ExpectCommit()
+			},
+			&models.BulkResponse{Mode: models.BulkBestEffort, Succeeded: 2, Failed: 2, Results: []models.BulkResult{
+				{Index: 0, Op: "create", ID: 10, Status: models.BulkOK},
+				{Index: 1, Op: "create", Status: models.BulkFailed, Error: &models.BulkError{
+					Code: apperr.CodeValidation, Message: "invalid operations[1].task.parent_id",
+					Details: []apperr.FieldError{{Field: "operations[1].task.parent_id", Reason: "task does not exist"}}}},
+				{Index: 2, Op: "create", Status: models.BulkFailed, Error: &models.BulkError{
+					Code: apperr.CodeValidation, Message: "invalid operations[2].task.parent_id",
+					Details: []apperr.FieldError{{Field: "operations[2].task.parent_id", Reason: "task is deleted by this batch"}}}},
+				{Index: 3, Op: "delete", ID: 3, Status: models.BulkOK},
+			}},
+			nil,
+		},
+		{
+			"invalid mode",
+			&models.BulkRequest{Mode: "eventually", Operations: []models.BulkOperation{remove}},
+			func() {},
+			nil,
+			apperr.Validation(apperr.Field("mode", "must be atomic or best_effort")),
+		},
+		{
+			"too many operations",
+			&models.BulkRequest{Operations: make([]models.BulkOperation, maxBulkOperations+1)},
+			func() {},
+			nil,
+			apperr.Validation(apperr.Field("operations", "must have at most 500 items")),
+		},
+		{
+			"user lookup error",
+			&models.BulkRequest{Operations: []models.BulkOperation{create}},
+			func() {
+				mockUserSvc.EXPECT().GetByID(ctx, int64(1)).Return(nil, utils.ErrTest)
+			},
+			nil,
+			utils.ErrTest,
+		},
+		{
+			"store error",
+			&models.BulkRequest{Operations: []models.BulkOperation{remove}},
+			func() {
+				mock.SQL.ExpectBegin()
+				mockStore.EXPECT().GetMany(ctx, []int64{3}).Return(existing, nil)
+				mockStore.EXPECT().DeleteBatch(ctx, []int64{3}).Return(nil, utils.ErrTest)
+				mock.SQL.ExpectRollback()
+			},
+			nil,
+			utils.ErrTest,
+		},
+	}
+
+	for _, tc := range testcases {
+		tc.mockExpect()
+
+		res, err := taskService.Bulk(ctx, tc.input)
+		if !errors.Is(err, tc.expectedError) {
+			t.Errorf("%s: expected error: %s, got %s", tc.description, tc.expectedError, err)
+		}
+
+		if !reflect.DeepEqual(res, tc.expected) {
+			t.Errorf("%s: expected response: %+v, got %+v", tc.description, tc.expected, res)
+		}
+	}
+
+	if err := mock.SQL.ExpectationsWereMet(); err != nil {
+		t.Errorf("unmet expectations: %v", err)
+	}
+}
+
+// anyEvents accepts every event, for tests that are not about events.
+// audited matches the audit entry of the action on the task.
+func audited(id int64, action string) gomock.Matcher {
+	return gomock.Cond(func(e *models.AuditEntry) bool { return e.EntityID == id && e.Action == action })
+}
+
+func anyEvents(controller *gomock.Controller) *MockEvents {
+	events := NewMockEvents(controller)
+	events.EXPECT().Emit(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+
+	return events
+}
+
+func TestTaskEvents(t *testing.T) {
+	open, done := &models.Task{ID: 1}, &models.Task{ID: 1, Status: true}
+
+	tests := []struct {
+		description string
+		action      string
+		before      *models.Task
+		after       *models.Task
+		want        []string
+	}{
+		{"create", "create", nil, open, []string{"task.created"}},
+		{"update", "update", open, open, []string{"task.updated"}},
+		{"completion", "update", open, done, []string{"task.updated", "task.completed"}},
+		{"reopening", "update", done, open, []string{"task.updated"}},
+		{"delete", "delete", done, nil, []string{"task.deleted"}},
+		{"restore", "restore", nil, done, []string{"task.restored"}},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.description, func(t *testing.T) {
+			if got := taskEvents(tc.action, tc.before, tc.after); !reflect.DeepEqual(got, tc.want) {
+				t.Errorf("expected %v, got %v", tc.want, got)
+			}
+		})
 	}
 }
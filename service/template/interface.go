@@ -0,0 +1,21 @@
+package template
+
+import (
+	"gofr.dev/pkg/gofr"
+
+	"TaskManager2/models"
+)
+
+type Store interface {
+	Create(*gofr.Context, *models.Template) (int64, error)
+	GetAll(*gofr.Context) ([]models.Template, error)
+	GetByID(*gofr.Context, int64) (*models.Template, error)
+}
+
+type TaskService interface {
+	CreateTree(*gofr.Context, []models.Task) ([]models.Task, error)
+}
+
+type UserService interface {
+	GetByID(*gofr.Context, int64) (*models.User, error)
+}
@@ -0,0 +1,165 @@
+package template
+
+import (
+	"regexp"
+	"sort"
+	"time"
+
+	"gofr.dev/pkg/gofr"
+
+	"TaskManager2/apperr"
+	"TaskManager2/models"
+	"TaskManager2/validate"
+)
+
+var placeholder = regexp.MustCompile(`\{\{\s*(\w+)\s*\}\}`)
+
+type service struct {
+	store       Store
+	taskService TaskService
+	userService UserService
+}
+
+func New(store Store, taskSvc TaskService, userSvc UserService) *service {
+	return &service{store: store, taskService: taskSvc, userService: userSvc}
+}
+
+func (s *service) Create(ctx *gofr.Context, template *models.Template) (int64, error) {
+	err := validate.Struct(template)
+	if err != nil {
+		return 0, err
+	}
+
+	id, err := s.store.Create(ctx, template)
+	if err != nil {
+		return 0, err
+	}
+
+	return id, nil
+}
+
+func (s *service) GetAll(ctx *gofr.Context) ([]models.Template, error) {
+	templates, err := s.store.GetAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return templates, nil
+}
+
+func (s *service) GetByID(ctx *gofr.Context, id int64) (*models.Template, error) {
+	template, err := s.store.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	return template, nil
+}
+
+// Instantiate creates the template's task tree for the given user, resolving
+// relative due dates against the start date and substituting placeholders.
+func (s *service) Instantiate(ctx *gofr.Context, id int64, req *models.Instantiation) ([]models.Task, error) {
+	template, err := s.store.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	// validate if user exists
+	_, err = s.userService.GetByID(ctx, req.UserID)
+	if apperr.CodeOf(err) == apperr.CodeNotFound {
+		return nil, apperr.Validation(apperr.Field("user_id", "user does not exist"))
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	start := time.Now()
+	if req.StartDate != nil {
+		start = *req.StartDate
+	}
+
+	r := renderer{variables: req.Variables, missing: map[string]bool{}}
+	tasks := r.tasks(template.Tasks, req.UserID, start)
+
+	if len(r.missing) > 0 {
+		names := make([]string, 0, len(r.missing))
+		for name := range r.missing {
+			names = append(names, name)
+		}
+
+		sort.Strings(names)
+
+		fields := make([]apperr.FieldError, len(names))
+		for i, name := range names {
+			fields[i] = apperr.Field("variables."+name, "required by the template")
+		}
+
+		return nil, apperr.Validation(fields...)
+	}
+
+	// substituted variables can push a title or description past its limit
+	err = validate.Value("tasks", tasks)
+	if err != nil {
+		return nil, err
+	}
+
+	created, err := s.taskService.CreateTree(ctx, tasks)
+	if err != nil {
+		return nil, err
+	}
+
+	return created, nil
+}
+
+// renderer turns template tasks into tasks, recording any placeholder that has
+// no matching variable.
+type renderer struct {
+	variables map[string]string
+	missing   map[string]bool
+}
+
+func (r renderer) tasks(templates []models.TemplateTask, userID int64, start time.Time) []models.Task {
+	tasks := make([]models.Task, 0, len(templates))
+
+	for _, tt := range templates {
+		task := models.Task{
+			Title:       r.text(tt.Title),
+			Description: r.text(tt.Description),
+			UserID:      userID,
+			Subtasks:    r.tasks(tt.Subtasks, userID, start),
+		}
+
+		if tt.DueInDays != nil {
+			due := start.AddDate(0, 0, *tt.DueInDays)
+			task.DueDate = &due
+		}
+
+		for _, tag := range tt.Tags {
+			task.Tags = append(task.Tags, r.text(tag))
+		}
+
+		for _, item := range tt.Checklist {
+			task.Checklist = append(task.Checklist, models.ChecklistItem{Text: r.text(item)})
+		}
+
+		tasks = append(tasks, task)
+	}
+
+	return tasks
+}
+
+func (r renderer) text(s string) string {
+	return placeholder.ReplaceAllStringFunc(s, func(match string) string {
+		name := placeholder.FindStringSubmatch(match)[1]
+
+		value, ok := r.variables[name]
+		if !ok {
+			r.missing[name] = true
+
+			return match
+		}
+
+		return value
+	})
+}
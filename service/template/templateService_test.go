@@ -0,0 +1,177 @@
+package template
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"go.uber.org/mock/gomock"
+	"gofr.dev/pkg/gofr"
+	"gofr.dev/pkg/gofr/container"
+
+	"TaskManager2/apperr"
+	"TaskManager2/models"
+	"TaskManager2/utils"
+)
+
+func TestService_Create(t *testing.T) {
+	var ctx *gofr.Context
+
+	controller := gomock.NewController(t)
+	mockStore := NewMockStore(controller)
+	templateService := New(mockStore, NewMockTaskService(controller), NewMockUserService(controller))
+
+	valid := []models.TemplateTask{{Title: "Release {{release_version}}", Subtasks: []models.TemplateTask{{Title: "Announce"}}}}
+
+	tests := []struct {
+		description string
+		input       *models.Template
+		mockExpect  bool
+		expectedID  int64
+		expectedErr error
+	}{
+		{"success", &models.Template{Name: "release", Tasks: valid}, true, 1, nil},
+		{"create error", &models.Template{Name: "release", Tasks: valid}, true, 0, utils.ErrTest},
+		{"missing name and tasks", &models.Template{}, false, 0,
+			apperr.Validation(apperr.Field("name", "is required"), apperr.Field("tasks", "is required"))},
+		{"name too long", &models.Template{Name: strings.Repeat("n", 101), Tasks: valid}, false, 0,
+			apperr.Validation(apperr.Field("name", "must be at most 100 characters"))},
+		{"subtask without a title", &models.Template{Name: "release", Tasks: []models.TemplateTask{
+			{Title: "Release", Subtasks: []models.TemplateTask{{Title: " "}}}}}, false, 0,
+			apperr.Validation(apperr.Field("tasks[0].subtasks[0].title", "is required"))},
+	}
+
+	for _, tc := range tests {
+		if tc.mockExpect {
+			mockStore.EXPECT().Create(ctx, tc.input).Return(tc.expectedID, tc.expectedErr)
+		}
+
+		id, err := templateService.Create(ctx, tc.input)
+		if !errors.Is(err, tc.expectedErr) {
+			t.Errorf("expected error %s, got %s", tc.expectedErr, err)
+		}
+
+		if id != tc.expectedID {
+			t.Errorf("Expected id %d, got %d", tc.expectedID, id)
+		}
+	}
+}
+
+func TestService_Instantiate(t *testing.T) {
+	mockContainer, _ := container.NewMockContainer(t)
+	ctx := &gofr.Context{
+		Context:   t.Context(),
+		Request:   nil,
+		Container: mockContainer,
+	}
+
+	controller := gomock.NewController(t)
+	mockStore := NewMockStore(controller)
+	mockTaskSvc := NewMockTaskService(controller)
+	mockUserSvc := NewMockUserService(controller)
+	templateService := New(mockStore, mockTaskSvc, mockUserSvc)
+
+	start := time.Date(2026, 10, 1, 9, 0, 0, 0, time.UTC)
+	due := start.AddDate(0, 0, 3)
+	three := 3
+
+	template := &models.Template{ID: 1, Name: "release", Tasks: []models.TemplateTask{{
+		Title:     "Release {{release_version}}",
+		DueInDays: &three,
+		Tags:      []string{"release"},
+		Checklist: []string{"Tag {{ release_version }}"},
+		Subtasks:  []models.TemplateTask{{Title: "Announce"}},
+	}}}
+
+	wantTasks := []models.Task{{
+		Title:     "Release 1.2",
+		UserID:    2,
+		DueDate:   &due,
+		Tags:      []string{"release"},
+		Checklist: []models.ChecklistItem{{Text: "Tag 1.2"}},
+		Subtasks:  []models.Task{{Title: "Announce", UserID: 2, Subtasks: []models.Task{}}},
+	}}
+
+	tests := []struct {
+		description string
+		req         *models.Instantiation
+		mockExpect  func()
+		expectedErr error
+	}{
+		{
+			description: "success",
+			req:         &models.Instantiation{UserID: 2, StartDate: &start, Variables: map[string]string{"release_version": "1.2"}},
+			mockExpect: func() {
+				mockStore.EXPECT().GetByID(ctx, int64(1)).Return(template, nil)
+				mockUserSvc.EXPECT().GetByID(ctx, int64(2)).Return(&models.User{ID: 2}, nil)
+				mockTaskSvc.EXPECT().CreateTree(ctx, gomock.Any()).
+					DoAndReturn(func(_ *gofr.Context, tasks []models.Task) ([]models.Task, error) {
+						if !reflect.DeepEqual(tasks, wantTasks) {
+							t.Errorf("expected tasks %+v, got %+v", wantTasks, tasks)
+						}
+
+						return tasks, nil
+					})
+			},
+			expectedErr: nil,
+		},
+		{
+			description: "missing variable",
+			req:         &models.Instantiation{UserID: 2, StartDate: &start},
+			mockExpect: func() {
+				mockStore.EXPECT().GetByID(ctx, int64(1)).Return(template, nil)
+				mockUserSvc.EXPECT().GetByID(ctx, int64(2)).Return(&models.User{ID: 2}, nil)
+			},
+			expectedErr: apperr.Validation(apperr.Field("variables.release_version", "required by the template")),
+		},
+		{
+			description: "template not found",
+			req:         &models.Instantiation{UserID: 2},
+			mockExpect: func() {
+				mockStore.EXPECT().GetByID(ctx, int64(1)).Return(nil, utils.ErrTest)
+			},
+			expectedErr: utils.ErrTest,
+		},
+		{
+			description: "user not validated",
+			req:         &models.Instantiation{UserID: 2},
+			mockExpect: func() {
+				mockStore.EXPECT().GetByID(ctx, int64(1)).Return(template, nil)
+				mockUserSvc.EXPECT().GetByID(ctx, int64(2)).Return(nil, utils.ErrTest)
+			},
+			expectedErr: utils.ErrTest,
+		},
+		{
+			description: "user does not exist",
+			req:         &models.Instantiation{UserID: 2},
+			mockExpect: func() {
+				mockStore.EXPECT().GetByID(ctx, int64(1)).Return(template, nil)
+				mockUserSvc.EXPECT().GetByID(ctx, int64(2)).Return(nil, apperr.NotFound("user", 2))
+			},
+			expectedErr: apperr.Validation(apperr.Field("user_id", "user does not exist")),
+		},
+		{
+			description: "create error",
+			req:         &models.Instantiation{UserID: 2, Variables: map[string]string{"release_version": "1.2"}},
+			mockExpect: func() {
+				mockStore.EXPECT().GetByID(ctx, int64(1)).Return(template, nil)
+				mockUserSvc.EXPECT().GetByID(ctx, int64(2)).Return(&models.User{ID: 2}, nil)
+				mockTaskSvc.EXPECT().CreateTree(ctx, gomock.Any()).Return(nil, utils.ErrTest)
+			},
+			expectedErr: utils.ErrTest,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.description, func(t *testing.T) {
+			tc.mockExpect()
+
+			_, err := templateService.Instantiate(ctx, 1, tc.req)
+			if !reflect.DeepEqual(err, tc.expectedErr) {
+				t.Errorf("expected error %v, got %v", tc.expectedErr, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,124 @@
+package transfer
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"TaskManager2/models"
+)
+
+// tagSeparator joins the tags of a task in a CSV cell.
+const tagSeparator = "|"
+
+// encoder writes tasks in an export format.
+type encoder interface {
+	encode(*models.Task) error
+	// flush writes out what is buffered, and end finishes the export.
+	flush() error
+	end() error
+}
+
+// newEncoder returns an encoder for format, or false for unknown formats.
+func newEncoder(w io.Writer, format string) (encoder, bool) {
+	switch format {
+	case models.FormatCSV:
+		cw := csv.NewWriter(w)
+		// Write errors are kept by the writer and reported when flushing.
+		_ = cw.Write(csvColumns())
+
+		return &csvEncoder{w: cw}, true
+	case models.FormatJSON:
+		return &jsonEncoder{w: w, enc: json.NewEncoder(w), array: true}, true
+	case models.FormatNDJSON:
+		return &jsonEncoder{w: w, enc: json.NewEncoder(w)}, true
+	default:
+		return nil, false
+	}
+}
+
+// csvColumns are the columns of CSV exports. Import reads the ones named
+// like an importable field and ignores the others.
+func csvColumns() []string {
+	return []string{"id", "title", "description", "status", "user_id", "parent_id", "due_date", "tags", "version"}
+}
+
+type csvEncoder struct {
+	w *csv.Writer
+}
+
+func (e *csvEncoder) encode(t *models.Task) error {
+	var parentID, dueDate string
+
+	if t.ParentID != nil {
+		parentID = strconv.FormatInt(*t.ParentID, 10)
+	}
+
+	if t.DueDate != nil {
+		dueDate = t.DueDate.UTC().Format(time.RFC3339)
+	}
+
+	return e.w.Write([]string{
+		strconv.FormatInt(t.ID, 10), t.Title, t.Description, strconv.FormatBool(t.Status), strconv.FormatInt(t.UserID, 10),
+		parentID, dueDate, strings.Join(t.Tags, tagSeparator), strconv.FormatInt(t.Version, 10),
+	})
+}
+
+func (e *csvEncoder) flush() error {
+	e.w.Flush()
+
+	return e.w.Error()
+}
+
+func (e *csvEncoder) end() error {
+	return e.flush()
+}
+
+// jsonEncoder writes a JSON array of tasks, or one task per line when it is
+// not an array.
+type jsonEncoder struct {
+	w     io.Writer
+	enc   *json.Encoder
+	array bool
+	n     int
+}
+
+func (e *jsonEncoder) encode(t *models.Task) error {
+	if e.array {
+		sep := ","
+		if e.n == 0 {
+			sep = "["
+		}
+
+		_, err := io.WriteString(e.w, sep)
+		if err != nil {
+			return err
+		}
+	}
+
+	e.n++
+
+	return e.enc.Encode(t)
+}
+
+func (*jsonEncoder) flush() error {
+	return nil
+}
+
+func (e *jsonEncoder) end() error {
+	if !e.array {
+		return nil
+	}
+
+	end := "]\n"
+	if e.n == 0 {
+		end = "[]\n"
+	}
+
+	_, err := io.WriteString(e.w, end)
+
+	return err
+}
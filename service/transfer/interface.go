@@ -0,0 +1,27 @@
+package transfer
+
+import (
+	"time"
+
+	"gofr.dev/pkg/gofr"
+
+	"TaskManager2/models"
+)
+
+type Store interface {
+	Create(*gofr.Context, *models.ImportJob) (int64, error)
+	GetByID(*gofr.Context, int64) (*models.ImportJob, error)
+	Next(*gofr.Context, time.Time) (*models.ImportJob, error)
+	Claim(*gofr.Context, int64, time.Time, time.Time) (bool, error)
+	SaveProgress(*gofr.Context, int64, string, int, time.Time) error
+	Save(*gofr.Context, *models.ImportJob) error
+}
+
+type TaskService interface {
+	Create(*gofr.Context, *models.Task) (int64, error)
+	Page(*gofr.Context, int64, int) ([]models.Task, error)
+}
+
+type UserService interface {
+	GetByID(*gofr.Context, int64) (*models.User, error)
+}
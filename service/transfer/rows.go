@@ -0,0 +1,428 @@
+package transfer
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"TaskManager2/apperr"
+	"TaskManager2/models"
+)
+
+const (
+	fieldTitle       = "title"
+	fieldDescription = "description"
+	fieldStatus      = "status"
+	fieldUserID      = "user_id"
+	fieldDueDate     = "due_date"
+	fieldTags        = "tags"
+
+	dateFormat = "2006-01-02"
+
+	// byteOrderMark starts the CSV files saved by some spreadsheets.
+	byteOrderMark = "\ufeff"
+)
+
+var (
+	errNotObject  = errors.New("rows must be JSON objects")
+	errNotText    = errors.New("must be text")
+	errNotBoolean = errors.New("must be true, false, yes or no")
+	errNotInteger = errors.New("must be an integer")
+	errNotDate    = errors.New("must be a date, such as 2026-10-20, or an RFC 3339 time")
+	errNotTags    = errors.New("must be a list of tags, or tags separated by " + tagSeparator)
+)
+
+// row holds the values of a data row by task field, and its number.
+type row struct {
+	n      int
+	values map[string]any
+}
+
+// importable reports whether field is a task field that imports set. IDs,
+// parents and versions are assigned anew.
+func importable(field string) bool {
+	switch field {
+	case fieldTitle, fieldDescription, fieldStatus, fieldUserID, fieldDueDate, fieldTags:
+		return true
+	default:
+		return false
+	}
+}
+
+// checkMapping rejects mappings to fields that are not importable, and
+// several columns mapped to the same field.
+func checkMapping(mapping map[string]string) error {
+	var fields []apperr.FieldError
+
+	sources := make(map[string]string, len(mapping))
+
+	for column, field := range mapping {
+		switch {
+		case !importable(field):
+			fields = append(fields, apperr.Field("mapping."+column, "must be one of title, description, status, user_id, due_date, tags"))
+		case sources[field] != "":
+			fields = append(fields, apperr.Field("mapping."+column, "maps to "+field+" like "+sources[field]))
+		default:
+			sources[field] = column
+		}
+	}
+
+	if len(fields) > 0 {
+		return apperr.Validation(fields...)
+	}
+
+	return nil
+}
+
+// target returns the field a column is imported into: the one it is mapped
+// to, or without a mapping the field it is named after.
+func target(mapping map[string]string, column string) (string, bool) {
+	if len(mapping) > 0 {
+		field, ok := mapping[column]
+
+		return field, ok
+	}
+
+	return column, importable(column)
+}
+
+// readRows reads the rows of the data in format, keeping the mapped columns.
+func readRows(format string, data []byte, mapping map[string]string) ([]row, error) {
+	switch format {
+	case models.FormatCSV:
+		return readCSV(data, mapping)
+	case models.FormatJSON:
+		return readJSON(data, mapping)
+	case models.FormatNDJSON:
+		return readNDJSON(data, mapping)
+	default:
+		return nil, errInvalidFormat
+	}
+}
+
+// readCSV reads CSV data with a header naming the columns.
+func readCSV(data []byte, mapping map[string]string) ([]row, error) {
+	r := csv.NewReader(bytes.NewReader(bytes.TrimPrefix(data, []byte(byteOrderMark))))
+
+	header, err := r.Read()
+	if errors.Is(err, io.EOF) {
+		return nil, nil
+	}
+
+	if err != nil {
+		return nil, dataError(err)
+	}
+
+	fields := make([]string, len(header))
+	named := make(map[string]bool, len(header))
+
+	for i, column := range header {
+		column = strings.TrimSpace(column)
+		named[column] = true
+
+		if field, ok := target(mapping, column); ok {
+			fields[i] = field
+		}
+	}
+
+	err = checkColumns(mapping, named, fields)
+	if err != nil {
+		return nil, err
+	}
+
+	var rows []row
+
+	for n := 1; ; n++ {
+		record, err := r.Read()
+		if errors.Is(err, io.EOF) {
+			return rows, nil
+		}
+
+		if err != nil {
+			return nil, dataError(err)
+		}
+
+		values := make(map[string]any, len(fields))
+
+		for i, field := range fields {
+			if field != "" {
+				values[field] = record[i]
+			}
+		}
+
+		rows = append(rows, row{n: n, values: values})
+	}
+}
+
+// checkColumns rejects mappings of columns the header does not have, and
+// headers without a column to import.
+func checkColumns(mapping map[string]string, named map[string]bool, fields []string) error {
+	var missing []apperr.FieldError
+
+	for column := range mapping {
+		if !named[column] {
+			missing = append(missing, apperr.Field("mapping."+column, "is not a column of the data"))
+		}
+	}
+
+	if len(missing) > 0 {
+		return apperr.Validation(missing...)
+	}
+
+	for _, field := range fields {
+		if field != "" {
+			return nil
+		}
+	}
+
+	return apperr.Validation(apperr.Field("data", "has no column to import; name the columns like task fields or map them"))
+}
+
+// readJSON reads a JSON array of objects.
+func readJSON(data []byte, mapping map[string]string) ([]row, error) {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+
+	var objects []json.RawMessage
+
+	err := dec.Decode(&objects)
+	if err != nil {
+		return nil, dataError(err)
+	}
+
+	rows := make([]row, 0, len(objects))
+
+	for i, raw := range objects {
+		r, err := readObject(raw, i+1, mapping)
+		if err != nil {
+			return nil, err
+		}
+
+		rows = append(rows, r)
+	}
+
+	return rows, nil
+}
+
+// readNDJSON reads one JSON object per line. Rows are numbered by line, and
+// blank lines are skipped.
+func readNDJSON(data []byte, mapping map[string]string) ([]row, error) {
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	scanner.Buffer(nil, len(data)+1)
+
+	var rows []row
+
+	for n := 1; scanner.Scan(); n++ {
+		line := bytes.TrimSpace(scanner.Bytes())
+		if len(line) == 0 {
+			continue
+		}
+
+		r, err := readObject(line, n, mapping)
+		if err != nil {
+			return nil, err
+		}
+
+		rows = append(rows, r)
+	}
+
+	if scanner.Err() != nil {
+		return nil, dataError(scanner.Err())
+	}
+
+	return rows, nil
+}
+
+func readObject(raw []byte, n int, mapping map[string]string) (row, error) {
+	dec := json.NewDecoder(bytes.NewReader(raw))
+	dec.UseNumber()
+
+	var object map[string]any
+
+	err := dec.Decode(&object)
+	if err == nil && object == nil {
+		err = errNotObject
+	}
+
+	if err != nil {
+		return row{}, dataError(fmt.Errorf("row %d: %w", n, err))
+	}
+
+	values := make(map[string]any, len(object))
+
+	for key, value := range object {
+		if field, ok := target(mapping, key); ok {
+			values[field] = value
+		}
+	}
+
+	return row{n: n, values: values}, nil
+}
+
+func dataError(err error) error {
+	return apperr.Validation(apperr.Field("data", "cannot be read: "+err.Error()))
+}
+
+// task converts a row to a task, reporting the values that do not convert.
+// Text values are read as they are, and so are the numbers, booleans and tag
+// lists of JSON rows.
+func (r *row) task() (models.Task, []apperr.FieldError) {
+	var (
+		t    models.Task
+		errs []apperr.FieldError
+		err  error
+	)
+
+	check := func(field string, err error) {
+		if err != nil {
+			errs = append(errs, apperr.Field(field, err.Error()))
+		}
+	}
+
+	t.Title, err = text(r.values[fieldTitle])
+	check(fieldTitle, err)
+
+	t.Description, err = text(r.values[fieldDescription])
+	check(fieldDescription, err)
+
+	t.Status, err = boolean(r.values[fieldStatus])
+	check(fieldStatus, err)
+
+	t.UserID, err = integer(r.values[fieldUserID])
+	check(fieldUserID, err)
+
+	t.DueDate, err = date(r.values[fieldDueDate])
+	check(fieldDueDate, err)
+
+	t.Tags, err = tags(r.values[fieldTags])
+	check(fieldTags, err)
+
+	t.Title = strings.TrimSpace(t.Title)
+
+	return t, errs
+}
+
+func text(v any) (string, error) {
+	switch v := v.(type) {
+	case nil:
+		return "", nil
+	case string:
+		return v, nil
+	default:
+		return "", errNotText
+	}
+}
+
+func boolean(v any) (bool, error) {
+	switch v := v.(type) {
+	case nil:
+		return false, nil
+	case bool:
+		return v, nil
+	case string:
+		switch strings.ToLower(strings.TrimSpace(v)) {
+		case "", "no":
+			return false, nil
+		case "yes":
+			return true, nil
+		}
+
+		b, err := strconv.ParseBool(strings.TrimSpace(v))
+		if err != nil {
+			return false, errNotBoolean
+		}
+
+		return b, nil
+	default:
+		return false, errNotBoolean
+	}
+}
+
+func integer(v any) (int64, error) {
+	var s string
+
+	switch v := v.(type) {
+	case nil:
+		return 0, nil
+	case json.Number:
+		s = v.String()
+	case string:
+		s = strings.TrimSpace(v)
+	default:
+		return 0, errNotInteger
+	}
+
+	if s == "" {
+		return 0, nil
+	}
+
+	i, err := strconv.ParseInt(s, 10, 64)
+	if err != nil {
+		return 0, errNotInteger
+	}
+
+	return i, nil
+}
+
+func date(v any) (*time.Time, error) {
+	s, err := text(v)
+	if err != nil {
+		return nil, errNotDate
+	}
+
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return nil, nil
+	}
+
+	t, err := time.Parse(time.RFC3339, s)
+	if err != nil {
+		t, err = time.Parse(dateFormat, s)
+	}
+
+	if err != nil {
+		return nil, errNotDate
+	}
+
+	t = t.UTC()
+
+	return &t, nil
+}
+
+func tags(v any) ([]string, error) {
+	var values []string
+
+	switch v := v.(type) {
+	case nil:
+	case string:
+		values = strings.Split(v, tagSeparator)
+	case []any:
+		for _, tag := range v {
+			s, ok := tag.(string)
+			if !ok {
+				return nil, errNotTags
+			}
+
+			values = append(values, s)
+		}
+	default:
+		return nil, errNotTags
+	}
+
+	var tags []string
+
+	for _, tag := range values {
+		if tag = strings.TrimSpace(tag); tag != "" {
+			tags = append(tags, tag)
+		}
+	}
+
+	return tags, nil
+}
@@ -0,0 +1,107 @@
+package transfer
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+	"time"
+
+	"TaskManager2/apperr"
+	"TaskManager2/models"
+)
+
+func TestReadRows(t *testing.T) {
+	tests := []struct {
+		description string
+		format      string
+		data        string
+		mapping     map[string]string
+		expected    []row
+	}{
+		{
+			"csv export",
+			"csv",
+			"\ufeffid,title,status,parent_id,tags\n7,\"Ship, now\",true,3,a|b\n",
+			nil,
+			[]row{{n: 1, values: map[string]any{"title": "Ship, now", "status": "true", "tags": "a|b"}}},
+		},
+		{
+			"mapped csv",
+			"csv",
+			"Name,Title\nShip it,ignored\n",
+			map[string]string{"Name": "title"},
+			[]row{{n: 1, values: map[string]any{"title": "Ship it"}}},
+		},
+		{
+			"json",
+			"json",
+			`[{"Name":"Ship it","user_id":1,"tags":["a"]}]`,
+			map[string]string{"Name": "title"},
+			[]row{{n: 1, values: map[string]any{"title": "Ship it"}}},
+		},
+		{
+			"ndjson numbered by line",
+			"ndjson",
+			"{\"title\":\"Ship it\",\"user_id\":1}\n\n{\"title\":\"Pack\",\"id\":2}\n",
+			nil,
+			[]row{
+				{n: 1, values: map[string]any{"title": "Ship it", "user_id": json.Number("1")}},
+				{n: 3, values: map[string]any{"title": "Pack"}},
+			},
+		},
+		{"empty csv", "csv", "", nil, nil},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.description, func(t *testing.T) {
+			rows, err := readRows(tc.format, []byte(tc.data), tc.mapping)
+			if err != nil || !reflect.DeepEqual(rows, tc.expected) {
+				t.Errorf("expected %+v, got %+v, %v", tc.expected, rows, err)
+			}
+		})
+	}
+
+	for format, data := range map[string]string{
+		"csv":    "title,status\nShip it\n",
+		"json":   `{"title":"Ship it"}`,
+		"ndjson": "{\"title\":\"Ship it\"}\nnull\n",
+	} {
+		_, err := readRows(format, []byte(data), nil)
+		if apperr.CodeOf(err) != apperr.CodeValidation {
+			t.Errorf("expected a validation error for %s %q, got %v", format, data, err)
+		}
+	}
+}
+
+func TestRow_Task(t *testing.T) {
+	due := time.Date(2026, 10, 21, 7, 30, 0, 0, time.UTC)
+
+	r := row{values: map[string]any{
+		"title": " Ship it ", "description": "Soon", "status": "Yes", "user_id": " 2 ", "due_date": "2026-10-21T09:30:00+02:00",
+		"tags": " a || b ",
+	}}
+
+	task, errs := r.task()
+
+	expected := models.Task{Title: "Ship it", Description: "Soon", Status: true, UserID: 2, DueDate: &due, Tags: []string{"a", "b"}}
+	if errs != nil || !reflect.DeepEqual(task, expected) {
+		t.Errorf("expected %+v, got %+v, %v", expected, task, errs)
+	}
+
+	r = row{values: map[string]any{
+		"title": json.Number("1"), "status": "maybe", "user_id": json.Number("1.5"), "due_date": "tomorrow", "tags": []any{"a", true},
+	}}
+
+	_, errs = r.task()
+
+	expectedErrs := []apperr.FieldError{
+		{Field: "title", Reason: errNotText.Error()},
+		{Field: "status", Reason: errNotBoolean.Error()},
+		{Field: "user_id", Reason: errNotInteger.Error()},
+		{Field: "due_date", Reason: errNotDate.Error()},
+		{Field: "tags", Reason: errNotTags.Error()},
+	}
+	if !reflect.DeepEqual(errs, expectedErrs) {
+		t.Errorf("expected %+v, got %+v", expectedErrs, errs)
+	}
+}
@@ -0,0 +1,413 @@
+package transfer
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"time"
+
+	"gofr.dev/pkg/gofr"
+
+	"TaskManager2/apperr"
+	"TaskManager2/middleware"
+	"TaskManager2/models"
+	"TaskManager2/utils"
+	"TaskManager2/validate"
+)
+
+const (
+	exportPage = 500
+
+	maxDataSize = 10 << 20
+	maxRows     = 50000
+	// Imports of more than syncRows rows run as jobs, reporting their
+	// progress every progressRows rows.
+	syncRows     = 500
+	progressRows = 100
+	maxRowErrors = 100
+
+	// A job is attempted maxAttempts times, retryDelay apart. Its claim is
+	// extended with each progress report, so it only runs out when the worker
+	// has died.
+	maxAttempts   = 3
+	retryDelay    = time.Minute
+	claimDuration = 5 * time.Minute
+
+	maxErrorLength = 500
+)
+
+var errInvalidFormat = apperr.Validation(apperr.Field("format", "must be one of csv, json, ndjson"))
+
+type service struct {
+	store Store
+	tasks TaskService
+	users UserService
+}
+
+func New(store Store, tasks TaskService, users UserService) *service {
+	return &service{store: store, tasks: tasks, users: users}
+}
+
+// Export writes every live task in format, ordered by ID. The tasks are read
+// a page at a time and written as they are read, so tasks changed meanwhile
+// are exported as they were when their page was read. Nothing is written
+// when the first page cannot be read.
+func (s *service) Export(ctx *gofr.Context, format string, w io.Writer) error {
+	enc, ok := newEncoder(w, format)
+	if !ok {
+		return errInvalidFormat
+	}
+
+	tasks, err := s.tasks.Page(ctx, 0, exportPage)
+	if err != nil {
+		return err
+	}
+
+	for {
+		for i := range tasks {
+			err = enc.encode(&tasks[i])
+			if err != nil {
+				return err
+			}
+		}
+
+		if len(tasks) < exportPage {
+			return enc.end()
+		}
+
+		err = enc.flush()
+		if err != nil {
+			return err
+		}
+
+		tasks, err = s.tasks.Page(ctx, tasks[len(tasks)-1].ID, exportPage)
+		if err != nil {
+			return err
+		}
+	}
+}
+
+// Import validates the rows of the request and, unless it is a dry run,
+// creates a task for each in one transaction. Rows without a user are
+// assigned to the caller. Nothing is imported when a row is invalid, which
+// fails the request. Imports of many rows are queued as a job instead, whose
+// progress and outcome GetJob reports.
+func (s *service) Import(ctx *gofr.Context, req *models.ImportRequest) (*models.ImportJob, error) {
+	rows, err := parse(req)
+	if err != nil {
+		return nil, err
+	}
+
+	job := &models.ImportJob{
+		Actor: middleware.Actor(ctx), Format: req.Format, Mapping: req.Mapping, DryRun: req.DryRun, Status: models.ImportPending,
+		Total: len(rows), Errors: []models.ImportRowError{}, CreatedAt: time.Now().UTC().Truncate(time.Second),
+	}
+
+	if len(rows) > syncRows {
+		job.Data = []byte(req.Data)
+
+		job.ID, err = s.store.Create(ctx, job)
+		if err != nil {
+			return nil, err
+		}
+
+		return job, nil
+	}
+
+	tasks, err := s.check(ctx, job, rows, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	switch job.Status {
+	case models.ImportInvalid:
+		if job.DryRun {
+			return job, nil
+		}
+
+		return nil, rowsError(job.Errors)
+	case models.ImportValidated:
+		return job, nil
+	}
+
+	err = utils.WithTx(ctx, func() error {
+		return s.commit(ctx, job, rows, tasks, nil)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return job, nil
+}
+
+// parse checks an import request and reads its rows.
+func parse(req *models.ImportRequest) ([]row, error) {
+	err := validate.Struct(req)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(req.Data) > maxDataSize {
+		return nil, apperr.Validation(apperr.Field("data", fmt.Sprintf("must be at most %d bytes", maxDataSize)))
+	}
+
+	err = checkMapping(req.Mapping)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := readRows(req.Format, []byte(req.Data), req.Mapping)
+	if err != nil {
+		return nil, err
+	}
+
+	switch {
+	case len(rows) == 0:
+		return nil, apperr.Validation(apperr.Field("data", "has no rows"))
+	case len(rows) > maxRows:
+		return nil, apperr.Validation(apperr.Field("data", fmt.Sprintf("must have at most %d rows", maxRows)))
+	}
+
+	return rows, nil
+}
+
+// rowsError reports the invalid rows of an import as a validation error,
+// with fields such as rows[3].title.
+func rowsError(errs []models.ImportRowError) error {
+	fields := make([]apperr.FieldError, len(errs))
+	for i, e := range errs {
+		fields[i] = apperr.Field(fmt.Sprintf("rows[%d].%s", e.Row, e.Field), e.Reason)
+	}
+
+	return apperr.Validation(fields...)
+}
+
+// progress records how many rows of a job went through its current status.
+type progress func(status string, processed int) error
+
+func (p progress) report(status string, processed int) error {
+	if p == nil || processed%progressRows != 0 {
+		return nil
+	}
+
+	return p(status, processed)
+}
+
+// check converts the rows to tasks and validates them like the task service
+// does. It sets the status the job continues with: invalid with the first
+// row errors, validated for a valid dry run, and importing otherwise.
+func (s *service) check(ctx *gofr.Context, job *models.ImportJob, rows []row, p progress) ([]models.Task, error) {
+	job.Status, job.Processed = models.ImportValidating, 0
+	job.Errors, job.ErrorCount = []models.ImportRowError{}, 0
+
+	actor, _ := strconv.ParseInt(job.Actor, 10, 64)
+	users := make(map[int64]bool)
+	tasks := make([]models.Task, 0, len(rows))
+
+	for i := range rows {
+		t, fields := rows[i].task()
+		if t.UserID == 0 {
+			t.UserID = actor
+		}
+
+		fields, err := s.validate(ctx, &t, fields, users)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, f := range fields {
+			job.ErrorCount++
+
+			if len(job.Errors) < maxRowErrors {
+				job.Errors = append(job.Errors, models.ImportRowError{Row: rows[i].n, Field: f.Field, Reason: f.Reason})
+			}
+		}
+
+		tasks = append(tasks, t)
+		job.Processed++
+
+		err = p.report(models.ImportValidating, job.Processed)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	switch {
+	case job.ErrorCount > 0:
+		job.Status = models.ImportInvalid
+	case job.DryRun:
+		job.Status = models.ImportValidated
+	default:
+		job.Status = models.ImportImporting
+	}
+
+	return tasks, nil
+}
+
+// validate adds the rule violations of t, and a missing user, to the
+// conversion errors of its row. users caches which users exist.
+func (s *service) validate(ctx *gofr.Context, t *models.Task, fields []apperr.FieldError,
+	users map[int64]bool) ([]apperr.FieldError, error) {
+	var invalid *apperr.Error
+	if err := validate.Struct(t); errors.As(err, &invalid) {
+		fields = append(fields, invalid.Fields...)
+	}
+
+	if t.UserID == 0 {
+		return append(fields, apperr.Field(fieldUserID, "is required")), nil
+	}
+
+	exists, ok := users[t.UserID]
+	if !ok {
+		_, err := s.users.GetByID(ctx, t.UserID)
+		if err != nil && apperr.CodeOf(err) != apperr.CodeNotFound {
+			return nil, err
+		}
+
+		exists = err == nil
+		users[t.UserID] = exists
+	}
+
+	if !exists {
+		fields = append(fields, apperr.Field(fieldUserID, "user does not exist"))
+	}
+
+	return fields, nil
+}
+
+// commit creates the tasks of a validated import. It runs in the caller's
+// transaction, so an error leaves none of them created.
+func (s *service) commit(ctx *gofr.Context, job *models.ImportJob, rows []row, tasks []models.Task, p progress) error {
+	job.Status, job.Processed = models.ImportImporting, 0
+
+	for i := range tasks {
+		_, err := s.tasks.Create(ctx, &tasks[i])
+		if err != nil {
+			return fmt.Errorf("importing row %d: %w", rows[i].n, err)
+		}
+
+		job.Processed++
+		job.Created++
+
+		err = p.report(models.ImportImporting, job.Processed)
+		if err != nil {
+			return err
+		}
+	}
+
+	job.Status = models.ImportSucceeded
+
+	return nil
+}
+
+// GetJob returns an import job of the caller.
+func (s *service) GetJob(ctx *gofr.Context, id int64) (*models.ImportJob, error) {
+	job, err := s.store.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if job.Actor != middleware.Actor(ctx) {
+		return nil, apperr.NotFound("import job", id)
+	}
+
+	return job, nil
+}
+
+// Process runs the next queued import job, reporting whether there was one.
+// A job is finished in the transaction that imports its tasks, so a worker
+// dying midway leaves nothing imported and the job to be run again.
+func (s *service) Process(ctx *gofr.Context) (bool, error) {
+	now := time.Now().UTC()
+
+	job, err := s.store.Next(ctx, now)
+	if err != nil || job == nil {
+		return false, err
+	}
+
+	claimed, err := s.store.Claim(ctx, job.ID, now, now.Add(claimDuration))
+	if err != nil || !claimed {
+		return false, err
+	}
+
+	job.Attempts++
+
+	err = s.run(ctx, job)
+	if err != nil {
+		return true, s.fail(ctx, job, err)
+	}
+
+	return true, nil
+}
+
+// run imports a claimed job as the caller that queued it. Progress is saved
+// through a context outside the import transaction, so that it can be seen
+// while the job runs.
+func (s *service) run(ctx *gofr.Context, job *models.ImportJob) error {
+	parent := ctx.Context
+	ctx.Context = middleware.WithMetadata(parent, job.Actor, "import-"+strconv.FormatInt(job.ID, 10))
+
+	defer func() { ctx.Context = parent }()
+
+	outside := &gofr.Context{Context: ctx.Context, Container: ctx.Container}
+	p := func(status string, processed int) error {
+		return s.store.SaveProgress(outside, job.ID, status, processed, time.Now().UTC().Add(claimDuration))
+	}
+
+	rows, err := readRows(job.Format, job.Data, job.Mapping)
+	if err != nil {
+		return err
+	}
+
+	tasks, err := s.check(ctx, job, rows, p)
+	if err != nil {
+		return err
+	}
+
+	job.LastError = ""
+
+	if job.Status != models.ImportImporting {
+		return s.finish(ctx, job)
+	}
+
+	return utils.WithTx(ctx, func() error {
+		err := s.commit(ctx, job, rows, tasks, p)
+		if err != nil {
+			return err
+		}
+
+		return s.finish(ctx, job)
+	})
+}
+
+func (s *service) finish(ctx *gofr.Context, job *models.ImportJob) error {
+	now := time.Now().UTC().Truncate(time.Second)
+	job.ClaimedUntil = nil
+	job.FinishedAt = &now
+
+	return s.store.Save(ctx, job)
+}
+
+// fail records a failed attempt: the job is queued again after a delay, or
+// has failed once it has used up its attempts.
+func (s *service) fail(ctx *gofr.Context, job *models.ImportJob, cause error) error {
+	job.Processed, job.Created = 0, 0
+	job.LastError = cause.Error()
+
+	if len(job.LastError) > maxErrorLength {
+		job.LastError = job.LastError[:maxErrorLength]
+	}
+
+	if job.Attempts >= maxAttempts {
+		job.Status = models.ImportFailed
+
+		return s.finish(ctx, job)
+	}
+
+	retry := time.Now().UTC().Add(retryDelay).Truncate(time.Second)
+	job.Status = models.ImportPending
+	job.ClaimedUntil = &retry
+
+	return s.store.Save(ctx, job)
+}
@@ -0,0 +1,421 @@
+package transfer
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"go.uber.org/mock/gomock"
+	"gofr.dev/pkg/gofr"
+	"gofr.dev/pkg/gofr/container"
+
+	"TaskManager2/apperr"
+	"TaskManager2/middleware"
+	"TaskManager2/models"
+	"TaskManager2/utils"
+)
+
+func TestService_Export(t *testing.T) {
+	var ctx *gofr.Context
+
+	controller := gomock.NewController(t)
+	mockTasks := NewMockTaskService(controller)
+	transferService := New(NewMockStore(controller), mockTasks, NewMockUserService(controller))
+
+	due := time.Date(2026, 10, 21, 9, 30, 0, 0, time.UTC)
+	parentID := int64(1)
+	tasks := []models.Task{
+		{ID: 1, Title: "Ship it", Description: "Now, \"really\"", UserID: 1, Tags: []string{"home", "urgent"}, Version: 2},
+		{ID: 2, Title: "Pack", Status: true, UserID: 1, ParentID: &parentID, DueDate: &due, Version: 1},
+	}
+
+	tests := []struct {
+		description string
+		format      string
+		tasks       []models.Task
+		expected    string
+	}{
+		{
+			"csv", "csv", tasks,
+			"id,title,description,status,user_id,parent_id,due_date,tags,version\n" +
+				"1,Ship it,\"Now, \"\"really\"\"\",false,1,,,home|urgent,2\n" +
+				"2,Pack,,true,1,1,2026-10-21T09:30:00Z,,1\n",
+		},
+		{
+			"json", "json", tasks,
+			`[{"id":1,"title":"Ship it","description":"Now, \"really\"","status":false,"user_id":1,"tags":["home","urgent"],"version":2}` +
+				"\n" + `,{"id":2,"title":"Pack","status":true,"user_id":1,"parent_id":1,"due_date":"2026-10-21T09:30:00Z","version":1}` +
+				"\n]\n",
+		},
+		{
+			"ndjson", "ndjson", tasks[1:],
+			`{"id":2,"title":"Pack","status":true,"user_id":1,"parent_id":1,"due_date":"2026-10-21T09:30:00Z","version":1}` + "\n",
+		},
+		{"empty csv", "csv", nil, "id,title,description,status,user_id,parent_id,due_date,tags,version\n"},
+		{"empty json", "json", nil, "[]\n"},
+		{"empty ndjson", "ndjson", nil, ""},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.description, func(t *testing.T) {
+			var b bytes.Buffer
+
+			mockTasks.EXPECT().Page(ctx, int64(0), exportPage).Return(tc.tasks, nil)
+
+			err := transferService.Export(ctx, tc.format, &b)
+			if err != nil || b.String() != tc.expected {
+				t.Errorf("expected %q, got %q, %v", tc.expected, b.String(), err)
+			}
+		})
+	}
+}
+
+func TestService_Export_Pages(t *testing.T) {
+	var ctx *gofr.Context
+
+	controller := gomock.NewController(t)
+	mockTasks := NewMockTaskService(controller)
+	transferService := New(NewMockStore(controller), mockTasks, NewMockUserService(controller))
+
+	page := make([]models.Task, exportPage)
+	for i := range page {
+		page[i] = models.Task{ID: int64(i + 1), Title: "task"}
+	}
+
+	var b bytes.Buffer
+
+	mockTasks.EXPECT().Page(ctx, int64(0), exportPage).Return(page, nil)
+	mockTasks.EXPECT().Page(ctx, int64(exportPage), exportPage).Return(nil, utils.ErrTest)
+
+	err := transferService.Export(ctx, "ndjson", &b)
+	if !errors.Is(err, utils.ErrTest) || strings.Count(b.String(), "\n") != exportPage {
+		t.Errorf("expected the first page and %v, got %d lines and %v", utils.ErrTest, strings.Count(b.String(), "\n"), err)
+	}
+
+	b.Reset()
+
+	mockTasks.EXPECT().Page(ctx, int64(0), exportPage).Return(nil, utils.ErrTest)
+
+	err = transferService.Export(ctx, "csv", &b)
+	if !errors.Is(err, utils.ErrTest) || b.Len() != 0 {
+		t.Errorf("expected nothing written and %v, got %q and %v", utils.ErrTest, b.String(), err)
+	}
+
+	err = transferService.Export(ctx, "xml", &b)
+	if !errors.Is(err, errInvalidFormat) {
+		t.Errorf("expected %v, got %v", errInvalidFormat, err)
+	}
+}
+
+func TestService_Import(t *testing.T) {
+	mockContainer, mock := container.NewMockContainer(t)
+	ctx := &gofr.Context{
+		Context:   middleware.WithMetadata(t.Context(), "1", "r1"),
+		Request:   nil,
+		Container: mockContainer,
+	}
+
+	controller := gomock.NewController(t)
+	mockStore := NewMockStore(controller)
+	mockTasks := NewMockTaskService(controller)
+	mockUsers := NewMockUserService(controller)
+	transferService := New(mockStore, mockTasks, mockUsers)
+
+	due := time.Date(2026, 10, 21, 0, 0, 0, 0, time.UTC)
+	data := "Name,Owner,Done,Due,Labels\nShip it,,yes,2026-10-21,home|urgent\nPack,1,false,,\n"
+	mapping := map[string]string{"Name": "title", "Owner": "user_id", "Done": "status", "Due": "due_date", "Labels": "tags"}
+
+	mockUsers.EXPECT().GetByID(ctx, int64(1)).Return(&models.User{ID: 1}, nil)
+	mock.SQL.ExpectBegin()
+	mockTasks.EXPECT().Create(ctx, &models.Task{Title: "Ship it", Status: true, UserID: 1, DueDate: &due,
+		Tags: []string{"home", "urgent"}}).Return(int64(11), nil)
+	mockTasks.EXPECT().Create(ctx, &models.Task{Title: "Pack", UserID: 1}).Return(int64(12), nil)
+	mock.SQL.ExpectCommit()
+
+	job, err := transferService.Import(ctx, &models.ImportRequest{Format: "csv", Data: data, Mapping: mapping})
+	if err != nil || job.ID != 0 || job.Status != models.ImportSucceeded || job.Total != 2 || job.Processed != 2 || job.Created != 2 {
+		t.Errorf("expected an import of 2 tasks, got %+v, %v", job, err)
+	}
+
+	invalid := "{\"title\":\"Ship it\",\"user_id\":2}\n\n{\"title\":\"\",\"status\":\"maybe\",\"user_id\":2}\n"
+
+	mockUsers.EXPECT().GetByID(ctx, int64(2)).Return(nil, apperr.NotFound("user", 2))
+
+	job, err = transferService.Import(ctx, &models.ImportRequest{Format: "ndjson", Data: invalid, DryRun: true})
+
+	expected := []models.ImportRowError{
+		{Row: 1, Field: "user_id", Reason: "user does not exist"},
+		{Row: 3, Field: "status", Reason: "must be true, false, yes or no"},
+		{Row: 3, Field: "title", Reason: "is required"},
+		{Row: 3, Field: "user_id", Reason: "user does not exist"},
+	}
+	if err != nil || job.Status != models.ImportInvalid || job.ErrorCount != 4 || !reflect.DeepEqual(job.Errors, expected) {
+		t.Errorf("expected the row errors %+v, got %+v, %v", expected, job, err)
+	}
+
+	mockUsers.EXPECT().GetByID(ctx, int64(2)).Return(nil, apperr.NotFound("user", 2))
+
+	_, err = transferService.Import(ctx, &models.ImportRequest{Format: "ndjson", Data: invalid})
+	if !errors.Is(err, apperr.Validation(apperr.Field("rows[1].user_id", ""), apperr.Field("rows[3].status", ""),
+		apperr.Field("rows[3].title", ""), apperr.Field("rows[3].user_id", ""))) {
+		t.Errorf("expected the row errors as a validation error, got %v", err)
+	}
+
+	mockUsers.EXPECT().GetByID(ctx, int64(1)).Return(&models.User{ID: 1}, nil)
+
+	job, err = transferService.Import(ctx, &models.ImportRequest{Format: "json", Data: `[{"title":"Ship it"}]`, DryRun: true})
+	if err != nil || job.Status != models.ImportValidated || job.Created != 0 {
+		t.Errorf("expected a validated dry run, got %+v, %v", job, err)
+	}
+
+	mockUsers.EXPECT().GetByID(ctx, int64(1)).Return(nil, utils.ErrTest)
+
+	_, err = transferService.Import(ctx, &models.ImportRequest{Format: "json", Data: `[{"title":"Ship it"}]`})
+	if !errors.Is(err, utils.ErrTest) {
+		t.Errorf("expected %v, got %v", utils.ErrTest, err)
+	}
+}
+
+func TestService_Import_Job(t *testing.T) {
+	ctx := &gofr.Context{Context: middleware.WithMetadata(t.Context(), "1", "r1")}
+
+	controller := gomock.NewController(t)
+	mockStore := NewMockStore(controller)
+	transferService := New(mockStore, NewMockTaskService(controller), NewMockUserService(controller))
+
+	data := "title\n" + strings.Repeat("Ship it\n", syncRows+1)
+
+	mockStore.EXPECT().Create(ctx, gomock.Any()).DoAndReturn(func(_ *gofr.Context, j *models.ImportJob) (int64, error) {
+		if j.Actor != "1" || j.Status != models.ImportPending || j.Total != syncRows+1 || string(j.Data) != data {
+			t.Errorf("expected a pending job with the data, got %+v", j)
+		}
+
+		return 3, nil
+	})
+
+	job, err := transferService.Import(ctx, &models.ImportRequest{Format: "csv", Data: data})
+	if err != nil || job.ID != 3 {
+		t.Errorf("expected job 3, got %+v, %v", job, err)
+	}
+
+	mockStore.EXPECT().Create(ctx, gomock.Any()).Return(int64(0), utils.ErrTest)
+
+	_, err = transferService.Import(ctx, &models.ImportRequest{Format: "csv", Data: data})
+	if !errors.Is(err, utils.ErrTest) {
+		t.Errorf("expected %v, got %v", utils.ErrTest, err)
+	}
+}
+
+func TestService_Import_Invalid(t *testing.T) {
+	var ctx *gofr.Context
+
+	controller := gomock.NewController(t)
+	transferService := New(NewMockStore(controller), NewMockTaskService(controller), NewMockUserService(controller))
+
+	tests := []struct {
+		description string
+		req         models.ImportRequest
+		field       string
+	}{
+		{"no data", models.ImportRequest{Format: "csv"}, "data"},
+		{"unknown format", models.ImportRequest{Format: "xml", Data: "<tasks/>"}, "format"},
+		{"mapped to an unknown field", models.ImportRequest{Format: "csv", Data: "Name\nx\n", Mapping: map[string]string{"Name": "id"}},
+			"mapping.Name"},
+		{"mapped column missing", models.ImportRequest{Format: "csv", Data: "Name\nx\n", Mapping: map[string]string{"Title": "title"}},
+			"mapping.Title"},
+		{"no known column", models.ImportRequest{Format: "csv", Data: "Name\nx\n"}, "data"},
+		{"no rows", models.ImportRequest{Format: "csv", Data: "title\n"}, "data"},
+		{"malformed", models.ImportRequest{Format: "json", Data: `[{"title":`}, "data"},
+		{"too large", models.ImportRequest{Format: "csv", Data: strings.Repeat("x", maxDataSize+1)}, "data"},
+		{"too many rows", models.ImportRequest{Format: "csv", Data: "title\n" + strings.Repeat("x\n", maxRows+1)}, "data"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.description, func(t *testing.T) {
+			_, err := transferService.Import(ctx, &tc.req)
+
+			var e *apperr.Error
+			if !errors.As(err, &e) || len(e.Fields) != 1 || e.Fields[0].Field != tc.field {
+				t.Errorf("expected a validation error of %s, got %v", tc.field, err)
+			}
+		})
+	}
+}
+
+func TestService_GetJob(t *testing.T) {
+	ctx := &gofr.Context{Context: middleware.WithMetadata(t.Context(), "1", "r1")}
+
+	controller := gomock.NewController(t)
+	mockStore := NewMockStore(controller)
+	transferService := New(mockStore, NewMockTaskService(controller), NewMockUserService(controller))
+
+	mockStore.EXPECT().GetByID(ctx, int64(3)).Return(&models.ImportJob{ID: 3, Actor: "1"}, nil)
+
+	job, err := transferService.GetJob(ctx, 3)
+	if err != nil || job.ID != 3 {
+		t.Errorf("expected job 3, got %+v, %v", job, err)
+	}
+
+	mockStore.EXPECT().GetByID(ctx, int64(4)).Return(&models.ImportJob{ID: 4, Actor: "2"}, nil)
+
+	_, err = transferService.GetJob(ctx, 4)
+	if !errors.Is(err, apperr.NotFound("import job", 4)) {
+		t.Errorf("expected the job of another user not to be found, got %v", err)
+	}
+
+	mockStore.EXPECT().GetByID(ctx, int64(5)).Return(nil, utils.ErrTest)
+
+	_, err = transferService.GetJob(ctx, 5)
+	if !errors.Is(err, utils.ErrTest) {
+		t.Errorf("expected %v, got %v", utils.ErrTest, err)
+	}
+}
+
+func TestService_Process(t *testing.T) {
+	mockContainer, mock := container.NewMockContainer(t)
+	ctx := &gofr.Context{
+		Context:   t.Context(),
+		Request:   nil,
+		Container: mockContainer,
+	}
+
+	controller := gomock.NewController(t)
+	mockStore := NewMockStore(controller)
+	mockTasks := NewMockTaskService(controller)
+	mockUsers := NewMockUserService(controller)
+	transferService := New(mockStore, mockTasks, mockUsers)
+
+	data := []byte(strings.Repeat("{\"title\":\"Ship it\"}\n", progressRows))
+	job := func(attempts int) *models.ImportJob {
+		return &models.ImportJob{ID: 3, Actor: "1", Format: "ndjson", Data: data, Status: models.ImportPending,
+			Total: progressRows, Attempts: attempts}
+	}
+	saved := func(expected *models.ImportJob) func(*gofr.Context, *models.ImportJob) error {
+		return func(_ *gofr.Context, j *models.ImportJob) error {
+			if j.Status != expected.Status || j.Processed != expected.Processed || j.Created != expected.Created ||
+				j.LastError != expected.LastError || (j.FinishedAt != nil) != (expected.FinishedAt != nil) ||
+				(j.ClaimedUntil != nil) != (expected.ClaimedUntil != nil) {
+				t.Errorf("expected %s with %d processed, %d created and %q saved, got %s with %d, %d and %q",
+					expected.Status, expected.Processed, expected.Created, expected.LastError, j.Status, j.Processed, j.Created, j.LastError)
+			}
+
+			return nil
+		}
+	}
+	// Progress is saved outside the import transaction, through another context.
+	outside := func(c *gofr.Context, _ int64, _ string, _ int, _ time.Time) error {
+		if c == ctx {
+			t.Error("expected progress saved through another context")
+		}
+
+		return nil
+	}
+	finished := time.Now()
+
+	tests := []struct {
+		description string
+		mockExpect  func()
+		processed   bool
+		expectedErr error
+	}{
+		{
+			"nothing queued",
+			func() {
+				mockStore.EXPECT().Next(ctx, gomock.Any()).Return(nil, nil)
+			},
+			false, nil,
+		},
+		{
+			"claimed by another worker",
+			func() {
+				mockStore.EXPECT().Next(ctx, gomock.Any()).Return(job(0), nil)
+				mockStore.EXPECT().Claim(ctx, int64(3), gomock.Any(), gomock.Any()).Return(false, nil)
+			},
+			false, nil,
+		},
+		{
+			"imported",
+			func() {
+				mockStore.EXPECT().Next(ctx, gomock.Any()).Return(job(0), nil)
+				mockStore.EXPECT().Claim(ctx, int64(3), gomock.Any(), gomock.Any()).Return(true, nil)
+				mockUsers.EXPECT().GetByID(ctx, int64(1)).Return(&models.User{ID: 1}, nil)
+				mockStore.EXPECT().SaveProgress(gomock.Any(), int64(3), models.ImportValidating, progressRows, gomock.Any()).DoAndReturn(outside)
+				mock.SQL.ExpectBegin()
+				mockTasks.EXPECT().Create(ctx, &models.Task{Title: "Ship it", UserID: 1}).Return(int64(11), nil).Times(progressRows)
+				mockStore.EXPECT().SaveProgress(gomock.Any(), int64(3), models.ImportImporting, progressRows, gomock.Any()).DoAndReturn(outside)
+				mockStore.EXPECT().Save(ctx, gomock.Any()).DoAndReturn(saved(&models.ImportJob{
+					Status: models.ImportSucceeded, Processed: progressRows, Created: progressRows, FinishedAt: &finished,
+				}))
+				mock.SQL.ExpectCommit()
+			},
+			true, nil,
+		},
+		{
+			"invalid",
+			func() {
+				mockStore.EXPECT().Next(ctx, gomock.Any()).Return(job(0), nil)
+				mockStore.EXPECT().Claim(ctx, int64(3), gomock.Any(), gomock.Any()).Return(true, nil)
+				mockUsers.EXPECT().GetByID(ctx, int64(1)).Return(nil, apperr.NotFound("user", 1))
+				mockStore.EXPECT().SaveProgress(gomock.Any(), int64(3), models.ImportValidating, progressRows, gomock.Any()).DoAndReturn(outside)
+				mockStore.EXPECT().Save(ctx, gomock.Any()).DoAndReturn(saved(&models.ImportJob{
+					Status: models.ImportInvalid, Processed: progressRows, FinishedAt: &finished,
+				}))
+			},
+			true, nil,
+		},
+		{
+			"retried",
+			func() {
+				mockStore.EXPECT().Next(ctx, gomock.Any()).Return(job(0), nil)
+				mockStore.EXPECT().Claim(ctx, int64(3), gomock.Any(), gomock.Any()).Return(true, nil)
+				mockUsers.EXPECT().GetByID(ctx, int64(1)).Return(&models.User{ID: 1}, nil)
+				mockStore.EXPECT().SaveProgress(gomock.Any(), int64(3), models.ImportValidating, progressRows, gomock.Any()).DoAndReturn(outside)
+				mock.SQL.ExpectBegin()
+				mockTasks.EXPECT().Create(ctx, gomock.Any()).Return(int64(0), utils.ErrTest)
+				mock.SQL.ExpectRollback()
+				mockStore.EXPECT().Save(ctx, gomock.Any()).DoAndReturn(saved(&models.ImportJob{
+					Status: models.ImportPending, LastError: fmt.Sprintf("importing row 1: %v", utils.ErrTest), ClaimedUntil: &finished,
+				}))
+			},
+			true, nil,
+		},
+		{
+			"failed",
+			func() {
+				mockStore.EXPECT().Next(ctx, gomock.Any()).Return(job(maxAttempts-1), nil)
+				mockStore.EXPECT().Claim(ctx, int64(3), gomock.Any(), gomock.Any()).Return(true, nil)
+				mockUsers.EXPECT().GetByID(ctx, int64(1)).Return(nil, utils.ErrTest)
+				mockStore.EXPECT().Save(ctx, gomock.Any()).DoAndReturn(saved(&models.ImportJob{
+					Status: models.ImportFailed, LastError: utils.ErrTest.Error(), FinishedAt: &finished,
+				}))
+			},
+			true, nil,
+		},
+		{
+			"next error",
+			func() {
+				mockStore.EXPECT().Next(ctx, gomock.Any()).Return(nil, utils.ErrTest)
+			},
+			false, utils.ErrTest,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.description, func(t *testing.T) {
+			tc.mockExpect()
+
+			processed, err := transferService.Process(ctx)
+			if processed != tc.processed || !errors.Is(err, tc.expectedErr) {
+				t.Errorf("expected %v, %v, got %v, %v", tc.processed, tc.expectedErr, processed, err)
+			}
+		})
+	}
+
+	if err := mock.SQL.ExpectationsWereMet(); err != nil {
+		t.Errorf("unmet expectations: %v", err)
+	}
+}
@@ -9,4 +9,13 @@ import (
 type Store interface {
 	Create(*gofr.Context, *models.User) (int64, error)
 	GetByID(*gofr.Context, int64) (*models.User, error)
+	GetByEmail(*gofr.Context, string) (*models.User, error)
+}
+
+type AuditStore interface {
+	Create(*gofr.Context, *models.AuditEntry) error
+}
+
+type Events interface {
+	Emit(*gofr.Context, string, any) error
 }
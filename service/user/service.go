@@ -1,21 +1,59 @@
 package user
 
 import (
+	"strings"
+
 	"gofr.dev/pkg/gofr"
 
+	"TaskManager2/audit"
 	"TaskManager2/models"
+	"TaskManager2/utils"
+	"TaskManager2/validate"
 )
 
 type service struct {
-	store Store
+	store      Store
+	auditStore AuditStore
+	events     Events
 }
 
-func New(store Store) *service {
-	return &service{store: store}
+func New(store Store, auditStore AuditStore, events Events) *service {
+	return &service{store: store, auditStore: auditStore, events: events}
 }
 
 func (s *service) Create(ctx *gofr.Context, user *models.User) (int64, error) {
-	id, err := s.store.Create(ctx, user)
+	user.Email = normalizeEmail(user.Email)
+
+	err := validate.Struct(user)
+	if err != nil {
+		return 0, err
+	}
+
+	var id int64
+
+	err = utils.WithTx(ctx, func() error {
+		id, err = s.store.Create(ctx, user)
+		if err != nil {
+			return err
+		}
+
+		created := *user
+		created.ID = id
+
+		var entry *models.AuditEntry
+
+		entry, err = audit.NewEntry(ctx, audit.EntityUser, id, audit.ActionCreate, nil, &created)
+		if err != nil {
+			return err
+		}
+
+		err = s.auditStore.Create(ctx, entry)
+		if err != nil {
+			return err
+		}
+
+		return s.events.Emit(ctx, models.EventUserCreated, &created)
+	})
 	if err != nil {
 		return 0, err
 	}
@@ -31,3 +69,18 @@ func (s *service) GetByID(ctx *gofr.Context, id int64) (*models.User, error) {
 
 	return user, nil
 }
+
+func (s *service) GetByEmail(ctx *gofr.Context, email string) (*models.User, error) {
+	user, err := s.store.GetByEmail(ctx, normalizeEmail(email))
+	if err != nil {
+		return nil, err
+	}
+
+	return user, nil
+}
+
+// normalizeEmail makes emails compare case-insensitively. They are stored in
+// this form, which the unique index on users.email relies on.
+func normalizeEmail(email string) string {
+	return strings.ToLower(strings.TrimSpace(email))
+}
@@ -3,34 +3,126 @@ package user
 import (
 	"errors"
 	"fmt"
+	"reflect"
 	"testing"
 
 	"go.uber.org/mock/gomock"
 	"gofr.dev/pkg/gofr"
+	"gofr.dev/pkg/gofr/container"
 
+	"TaskManager2/apperr"
 	"TaskManager2/models"
 	"TaskManager2/utils"
 )
 
 func TestCreate(t *testing.T) {
-	var ctx *gofr.Context
+	mockContainer, mock := container.NewMockContainer(t)
+	ctx := &gofr.Context{
+		Context:   t.Context(),
+		Request:   nil,
+		Container: mockContainer,
+	}
 
 	controller := gomock.NewController(t)
 	mockStore := NewMockStore(controller)
-	userService := New(mockStore)
+	mockAuditStore := NewMockAuditStore(controller)
+	mockEvents := NewMockEvents(controller)
+	userService := New(mockStore, mockAuditStore, mockEvents)
 
 	testCases := []struct {
 		description   string
 		input         models.User
+		mockExpect    func(*models.User)
 		expectedID    int64
 		expectedError error
 	}{
-		{"success", models.User{ID: 1, Name: "test1"}, 1, nil},
-		{"store create method error", models.User{ID: 1, Name: "test1"}, 0, utils.ErrTest},
+		{
+			"success",
+			models.User{Name: "test1", Email: "test1@example.com"},
+			func(u *models.User) {
+				mock.SQL.ExpectBegin()
+				mockStore.EXPECT().Create(ctx, u).Return(int64(1), nil)
+				mockAuditStore.EXPECT().Create(ctx, gomock.Any()).
+					DoAndReturn(func(_ *gofr.Context, e *models.AuditEntry) error {
+						if e.Entity != "user" || e.EntityID != 1 || e.Action != "create" {
+							t.Errorf("unexpected audit entry %+v", e)
+						}
+
+						return nil
+					})
+				mockEvents.EXPECT().Emit(ctx, "user.created", &models.User{ID: 1, Name: "test1", Email: "test1@example.com"}).Return(nil)
+				mock.SQL.ExpectCommit()
+			},
+			1,
+			nil,
+		},
+		{
+			"email normalised",
+			models.User{Name: "test1", Email: " Test1@Example.COM "},
+			func(u *models.User) {
+				mock.SQL.ExpectBegin()
+				mockStore.EXPECT().Create(ctx, gomock.Any()).
+					DoAndReturn(func(_ *gofr.Context, got *models.User) (int64, error) {
+						if got.Email != "test1@example.com" {
+							t.Errorf("expected normalised email, got %q", got.Email)
+						}
+
+						return 2, nil
+					})
+				mockAuditStore.EXPECT().Create(ctx, gomock.Any()).Return(nil)
+				mockEvents.EXPECT().Emit(ctx, "user.created", gomock.Any()).Return(nil)
+				mock.SQL.ExpectCommit()
+			},
+			2,
+			nil,
+		},
+		{
+			"validation error",
+			models.User{Name: " ", Email: "test1"},
+			func(*models.User) {},
+			0,
+			apperr.Validation(apperr.Field("name", "is required"), apperr.Field("email", "must be a valid email address")),
+		},
+		{
+			"store create method error",
+			models.User{Name: "test1", Email: "test1@example.com"},
+			func(u *models.User) {
+				mock.SQL.ExpectBegin()
+				mockStore.EXPECT().Create(ctx, u).Return(int64(0), utils.ErrTest)
+				mock.SQL.ExpectRollback()
+			},
+			0,
+			utils.ErrTest,
+		},
+		{
+			"audit error",
+			models.User{Name: "test1", Email: "test1@example.com"},
+			func(u *models.User) {
+				mock.SQL.ExpectBegin()
+				mockStore.EXPECT().Create(ctx, u).Return(int64(1), nil)
+				mockAuditStore.EXPECT().Create(ctx, gomock.Any()).Return(utils.ErrTest)
+				mock.SQL.ExpectRollback()
+			},
+			0,
+			utils.ErrTest,
+		},
+		{
+			"emit error",
+			models.User{Name: "test1", Email: "test1@example.com"},
+			func(u *models.User) {
+				mock.SQL.ExpectBegin()
+				mockStore.EXPECT().Create(ctx, u).Return(int64(1), nil)
+				mockAuditStore.EXPECT().Create(ctx, gomock.Any()).Return(nil)
+				mockEvents.EXPECT().Emit(ctx, "user.created", gomock.Any()).Return(utils.ErrTest)
+				mock.SQL.ExpectRollback()
+			},
+			0,
+			utils.ErrTest,
+		},
 	}
 
 	for _, tc := range testCases {
-		mockStore.EXPECT().Create(ctx, &tc.input).Return(tc.expectedID, tc.expectedError)
+		tc.mockExpect(&tc.input)
 
 		id, err := userService.Create(ctx, &tc.input)
 		if !errors.Is(err, tc.expectedError) {
@@ -48,7 +140,7 @@ func TestService_GetByID(t *testing.T) {
 
 	controller := gomock.NewController(t)
 	mockStore := NewMockStore(controller)
-	userService := New(mockStore)
+	userService := New(mockStore, NewMockAuditStore(controller), NewMockEvents(controller))
 
 	testCases := []struct {
 		description   string
@@ -74,3 +166,34 @@ func TestService_GetByID(t *testing.T) {
 		}
 	}
 }
+
+func TestService_GetByEmail(t *testing.T) {
+	var ctx *gofr.Context
+
+	controller := gomock.NewController(t)
+	mockStore := NewMockStore(controller)
+	userService := New(mockStore, NewMockAuditStore(controller), NewMockEvents(controller))
+
+	testCases := []struct {
+		description   string
+		input         string
+		expected      *models.User
+		expectedError error
+	}{
+		{"success", "Test1@Example.com ", &models.User{ID: 1, Email: "test1@example.com"}, nil},
+		{"not found", "test1@example.com", nil, apperr.NotFound("user", "test1@example.com")},
+	}
+
+	for _, tc := range testCases {
+		mockStore.EXPECT().GetByEmail(ctx, "test1@example.com").Return(tc.expected, tc.expectedError)
+
+		user, err := userService.GetByEmail(ctx, tc.input)
+		if !errors.Is(err, tc.expectedError) {
+			t.Errorf("Expected error %v, got %v", tc.expectedError, err)
+		}
+
+		if !reflect.DeepEqual(user, tc.expected) {
+			t.Errorf("Expected: %v, got %v", tc.expected, user)
+		}
+	}
+}
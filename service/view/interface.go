@@ -0,0 +1,25 @@
+package view
+
+import (
+	"gofr.dev/pkg/gofr"
+
+	"TaskManager2/models"
+)
+
+type Store interface {
+	Create(*gofr.Context, *models.View) (int64, error)
+	GetByID(*gofr.Context, int64) (*models.View, error)
+	GetVisible(*gofr.Context, string) ([]models.View, error)
+	Update(*gofr.Context, *models.View) error
+	Delete(*gofr.Context, int64) error
+	TagIDs(*gofr.Context, []string) (map[string]int64, error)
+	TagNames(*gofr.Context, []int64) (map[int64]string, error)
+}
+
+type TaskService interface {
+	GetAll(*gofr.Context, *models.TaskFilter) ([]models.Task, error)
+}
+
+type AuditStore interface {
+	Create(*gofr.Context, *models.AuditEntry) error
+}
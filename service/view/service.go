@@ -0,0 +1,504 @@
+package view
+
+import (
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"gofr.dev/pkg/gofr"
+
+	"TaskManager2/apperr"
+	"TaskManager2/audit"
+	"TaskManager2/middleware"
+	"TaskManager2/models"
+	"TaskManager2/query"
+	"TaskManager2/utils"
+	"TaskManager2/validate"
+)
+
+var errNoActor = apperr.Forbidden("views belong to a user, send the X-User-ID header")
+
+type service struct {
+	store       Store
+	taskService TaskService
+	auditStore  AuditStore
+}
+
+func New(store Store, taskSvc TaskService, auditStore AuditStore) *service {
+	return &service{store: store, taskService: taskSvc, auditStore: auditStore}
+}
+
+// Create saves a view owned by the current actor.
+func (s *service) Create(ctx *gofr.Context, v *models.View) (*models.View, error) {
+	owner := middleware.Actor(ctx)
+	if owner == "" {
+		return nil, errNoActor
+	}
+
+	created := *v
+	created.Owner = owner
+	created.Version = 1
+	created.UpdatedAt = time.Now().UTC().Truncate(time.Second)
+
+	err := s.check(ctx, &created)
+	if err != nil {
+		return nil, err
+	}
+
+	err = utils.WithTx(ctx, func() error {
+		created.ID, err = s.store.Create(ctx, &created)
+		if err != nil {
+			return err
+		}
+
+		return s.record(ctx, created.ID, audit.ActionCreate, nil, &created)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &created, nil
+}
+
+// GetAll lists the views the current actor owns and those others shared with them.
+func (s *service) GetAll(ctx *gofr.Context) ([]models.View, error) {
+	views, err := s.store.GetVisible(ctx, middleware.Actor(ctx))
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = s.followTags(ctx, views)
+	if err != nil {
+		return nil, err
+	}
+
+	return views, nil
+}
+
+func (s *service) GetByID(ctx *gofr.Context, id int64) (*models.View, error) {
+	v, _, err := s.get(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	return v, nil
+}
+
+// Update replaces a view owned by the current actor. A non-zero v.Version
+// makes the update conditional on the view still being at that version.
+func (s *service) Update(ctx *gofr.Context, v *models.View) (*models.View, error) {
+	updated := *v
+	updated.UpdatedAt = time.Now().UTC().Truncate(time.Second)
+
+	err := s.check(ctx, &updated)
+	if err != nil {
+		return nil, err
+	}
+
+	err = utils.WithTx(ctx, func() error {
+		var before *models.View
+
+		before, err = s.owned(ctx, v.ID)
+		if err != nil {
+			return err
+		}
+
+		if v.Version != 0 && v.Version != before.Version {
+			return apperr.PreconditionFailed(fmt.Sprintf("view %d was modified concurrently, current version is %d", v.ID, before.Version))
+		}
+
+		updated.Owner = before.Owner
+		updated.Version = before.Version
+
+		err = s.store.Update(ctx, &updated)
+		if err != nil {
+			return err
+		}
+
+		updated.Version++
+
+		return s.record(ctx, v.ID, audit.ActionUpdate, before, &updated)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &updated, nil
+}
+
+func (s *service) Delete(ctx *gofr.Context, id int64) error {
+	return utils.WithTx(ctx, func() error {
+		before, err := s.owned(ctx, id)
+		if err != nil {
+			return err
+		}
+
+		err = s.store.Delete(ctx, id)
+		if err != nil {
+			return err
+		}
+
+		return s.record(ctx, id, audit.ActionDelete, before, nil)
+	})
+}
+
+// Tasks runs the view: it lists the tasks matching its filter in its order,
+// groups them and keeps only its columns. Relative times in the filter are
+// resolved against the current time. A view whose filter names a deleted tag
+// or no longer parses is reported as a conflict rather than matching nothing.
+func (s *service) Tasks(ctx *gofr.Context, id int64) (*models.ViewResult, error) {
+	v, missing, err := s.get(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(missing) > 0 {
+		return nil, apperr.Conflict(fmt.Sprintf("view %d refers to the deleted tags %s, update its filter",
+			id, strings.Join(missing, ", ")))
+	}
+
+	filter := models.TaskFilter{Sort: sortKeys(v)}
+
+	if v.Filter != "" {
+		filter.Query, err = query.Parse(v.Filter, time.Now().UTC())
+		if err != nil {
+			return nil, apperr.Conflict(fmt.Sprintf("the filter of view %d is no longer valid, update it: %v", id, err))
+		}
+	}
+
+	tasks, err := s.taskService.GetAll(ctx, &filter)
+	if err != nil {
+		return nil, err
+	}
+
+	groups, err := group(tasks, v.GroupBy, v.Columns)
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.ViewResult{ViewID: v.ID, Version: v.Version, Groups: groups}, nil
+}
+
+// get returns a view visible to the current actor, following renamed tags,
+// along with the names of pinned tags that no longer exist.
+func (s *service) get(ctx *gofr.Context, id int64) (*models.View, []string, error) {
+	v, err := s.store.GetByID(ctx, id)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	actor := middleware.Actor(ctx)
+	if v.Owner != actor && !slices.Contains(v.SharedWith, actor) {
+		return nil, nil, apperr.NotFound("view", id)
+	}
+
+	views := []models.View{*v}
+
+	missing, err := s.followTags(ctx, views)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return &views[0], missing[id], nil
+}
+
+// owned returns a view the current actor may change.
+func (s *service) owned(ctx *gofr.Context, id int64) (*models.View, error) {
+	v, _, err := s.get(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if v.Owner != middleware.Actor(ctx) {
+		return nil, apperr.Forbidden(fmt.Sprintf("view %d is shared by %s and only they can change it", id, v.Owner))
+	}
+
+	return v, nil
+}
+
+// followTags rewrites the filters of the views to use the current names of
+// the tags they pinned. It returns the names of pinned tags that no longer
+// exist, by view ID.
+func (s *service) followTags(ctx *gofr.Context, views []models.View) (map[int64][]string, error) {
+	var ids []int64
+
+	for _, v := range views {
+		for _, t := range v.Tags {
+			ids = append(ids, t.ID)
+		}
+	}
+
+	if len(ids) == 0 {
+		return nil, nil
+	}
+
+	names, err := s.store.TagNames(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+
+	missing := make(map[int64][]string)
+
+	for i := range views {
+		v := &views[i]
+		renames := make(map[string]string)
+
+		for j, t := range v.Tags {
+			name, ok := names[t.ID]
+
+			switch {
+			case !ok:
+				missing[v.ID] = append(missing[v.ID], t.Name)
+			case name != t.Name:
+				renames[t.Name] = name
+				v.Tags[j].Name = name
+			}
+		}
+
+		if len(renames) > 0 {
+			v.Filter, err = query.RenameTags(v.Filter, renames)
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	return missing, nil
+}
+
+// check validates the view and pins the tags its filter names.
+func (s *service) check(ctx *gofr.Context, v *models.View) error {
+	if v.Visibility == "" {
+		v.Visibility = models.ViewPrivate
+	}
+
+	err := validate.Struct(v)
+	if err != nil {
+		return err
+	}
+
+	invalid := checkLayout(v)
+
+	var tags []string
+
+	if v.Filter != "" {
+		var e query.Expr
+
+		e, err = query.Parse(v.Filter, time.Now().UTC())
+		if err != nil {
+			invalid = append(invalid, apperr.Field("filter", err.Error()))
+		} else {
+			tags = query.Tags(e)
+		}
+	}
+
+	v.Tags, err = s.pin(ctx, tags)
+	if err != nil {
+		return err
+	}
+
+	for _, name := range tags {
+		if !slices.ContainsFunc(v.Tags, func(t models.ViewTag) bool { return t.Name == name }) {
+			invalid = append(invalid, apperr.Field("filter", fmt.Sprintf("refers to the unknown tag %q", name)))
+		}
+	}
+
+	if len(invalid) > 0 {
+		return apperr.Validation(invalid...)
+	}
+
+	return nil
+}
+
+// pin looks up the IDs of the named tags, leaving out unknown ones.
+func (s *service) pin(ctx *gofr.Context, names []string) ([]models.ViewTag, error) {
+	if len(names) == 0 {
+		return nil, nil
+	}
+
+	ids, err := s.store.TagIDs(ctx, names)
+	if err != nil {
+		return nil, err
+	}
+
+	var tags []models.ViewTag
+
+	for _, name := range names {
+		if id, ok := ids[name]; ok {
+			tags = append(tags, models.ViewTag{ID: id, Name: name})
+		}
+	}
+
+	return tags, nil
+}
+
+// checkLayout checks the sharing, sort keys, columns and grouping. Columns
+// are the JSON names of the task fields that listings return.
+func checkLayout(v *models.View) []apperr.FieldError {
+	var (
+		sortFields  = []string{query.FieldID, query.FieldTitle, query.FieldStatus, query.FieldUser, query.FieldParent, query.FieldDue}
+		columnNames = []string{"id", "title", "description", "status", "user_id", "parent_id", "due_date", "version"}
+		groupFields = []string{query.FieldStatus, query.FieldUser, query.FieldParent}
+		invalid     []apperr.FieldError
+	)
+
+	invalid = append(invalid, checkSharing(v)...)
+	invalid = append(invalid, checkList("sort", keyFields(v.Sort), sortFields, "optionally prefixed with -")...)
+	invalid = append(invalid, checkList("columns", v.Columns, columnNames, "")...)
+
+	if v.GroupBy != "" && !slices.Contains(groupFields, v.GroupBy) {
+		invalid = append(invalid, apperr.Field("group_by", "must be one of "+strings.Join(groupFields, ", ")))
+	}
+
+	return invalid
+}
+
+// checkSharing checks that a shared view names the users it is shared with
+// and a private one names none.
+func checkSharing(v *models.View) []apperr.FieldError {
+	switch {
+	case v.Visibility == models.ViewShared && len(v.SharedWith) == 0:
+		return []apperr.FieldError{apperr.Field("shared_with", "must name at least one user for a shared view")}
+	case v.Visibility == models.ViewShared:
+		return checkList("shared_with", v.SharedWith, v.SharedWith, "")
+	case v.Visibility != models.ViewPrivate:
+		return []apperr.FieldError{apperr.Field("visibility", "must be private or shared")}
+	case len(v.SharedWith) > 0:
+		return []apperr.FieldError{apperr.Field("shared_with", "must be empty for a private view")}
+	}
+
+	return nil
+}
+
+// checkList reports the items that are not allowed or repeat an earlier item.
+func checkList(name string, items, allowed []string, note string) []apperr.FieldError {
+	var invalid []apperr.FieldError
+
+	for i, item := range items {
+		field := fmt.Sprintf("%s[%d]", name, i)
+
+		switch {
+		case !slices.Contains(allowed, item):
+			reason := "must be one of " + strings.Join(allowed, ", ")
+			if note != "" {
+				reason += ", " + note
+			}
+
+			invalid = append(invalid, apperr.Field(field, reason))
+		case slices.Contains(items[:i], item):
+			invalid = append(invalid, apperr.Field(field, "repeats an earlier entry"))
+		}
+	}
+
+	return invalid
+}
+
+func keyFields(sort []string) []string {
+	fields := make([]string, len(sort))
+	for i, key := range sort {
+		fields[i] = strings.TrimPrefix(key, "-")
+	}
+
+	return fields
+}
+
+// sortKeys orders by the grouping field first, so that groups are contiguous,
+// unless the view already sorts by it.
+func sortKeys(v *models.View) []models.TaskSort {
+	var sorts []models.TaskSort
+
+	if v.GroupBy != "" && !slices.Contains(keyFields(v.Sort), v.GroupBy) {
+		sorts = append(sorts, models.TaskSort{Field: v.GroupBy})
+	}
+
+	for _, key := range v.Sort {
+		field, desc := strings.CutPrefix(key, "-")
+		sorts = append(sorts, models.TaskSort{Field: field, Desc: desc})
+	}
+
+	return sorts
+}
+
+// group splits tasks sorted by the grouping field into runs with the same key
+// and reduces each task to the columns, or keeps all of its fields when no
+// columns are set.
+func group(tasks []models.Task, groupBy string, columns []string) ([]models.ViewGroup, error) {
+	groups := []models.ViewGroup{}
+
+	for i := range tasks {
+		row, err := project(&tasks[i], columns)
+		if err != nil {
+			return nil, err
+		}
+
+		key := groupKey(&tasks[i], groupBy)
+
+		if len(groups) == 0 || groups[len(groups)-1].Key != key {
+			groups = append(groups, models.ViewGroup{Key: key})
+		}
+
+		last := &groups[len(groups)-1]
+		last.Tasks = append(last.Tasks, row)
+	}
+
+	if len(groups) == 0 && groupBy == "" {
+		groups = append(groups, models.ViewGroup{Tasks: []map[string]any{}})
+	}
+
+	return groups, nil
+}
+
+func groupKey(t *models.Task, groupBy string) any {
+	switch groupBy {
+	case query.FieldStatus:
+		if t.Status {
+			return "done"
+		}
+
+		return "open"
+	case query.FieldUser:
+		return t.UserID
+	case query.FieldParent:
+		if t.ParentID != nil {
+			return *t.ParentID
+		}
+	}
+
+	return nil
+}
+
+func project(t *models.Task, columns []string) (map[string]any, error) {
+	data, err := json.Marshal(t)
+	if err != nil {
+		return nil, err
+	}
+
+	var fields map[string]any
+
+	err = json.Unmarshal(data, &fields)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(columns) == 0 {
+		return fields, nil
+	}
+
+	row := make(map[string]any, len(columns))
+	for _, c := range columns {
+		row[c] = fields[c]
+	}
+
+	return row, nil
+}
+
+func (s *service) record(ctx *gofr.Context, id int64, action string, before, after *models.View) error {
+	entry, err := audit.NewEntry(ctx, audit.EntityView, id, action, before, after)
+	if err != nil {
+		return err
+	}
+
+	return s.auditStore.Create(ctx, entry)
+}
@@ -0,0 +1,424 @@
+package view
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+	"time"
+
+	"go.uber.org/mock/gomock"
+	"gofr.dev/pkg/gofr"
+	"gofr.dev/pkg/gofr/container"
+
+	"TaskManager2/apperr"
+	"TaskManager2/middleware"
+	"TaskManager2/models"
+	"TaskManager2/query"
+	"TaskManager2/utils"
+)
+
+// newContext returns a context for a request made by actor.
+func newContext(t *testing.T, actor string) (*gofr.Context, *container.Mocks) {
+	t.Helper()
+
+	mockContainer, mock := container.NewMockContainer(t)
+	req := httptest.NewRequest(http.MethodGet, "/view", http.NoBody)
+
+	if actor != "" {
+		req.Header.Set(middleware.ActorHeader, actor)
+	}
+
+	middleware.RequestMetadata(http.HandlerFunc(func(_ http.ResponseWriter, r *http.Request) {
+		req = r
+	})).ServeHTTP(httptest.NewRecorder(), req)
+
+	return &gofr.Context{Context: req.Context(), Request: nil, Container: mockContainer}, mock
+}
+
+func TestService_Create(t *testing.T) {
+	ctx, mock := newContext(t, "7")
+	controller := gomock.NewController(t)
+	mockStore := NewMockStore(controller)
+	mockAuditStore := NewMockAuditStore(controller)
+	viewService := New(mockStore, NewMockTaskService(controller), mockAuditStore)
+	_, syntaxErr := query.Parse("colour:red", time.Now())
+
+	tests := []struct {
+		description string
+		ctx         *gofr.Context
+		input       *models.View
+		mockExpect  func()
+		expectedErr error
+	}{
+		{
+			description: "success",
+			ctx:         ctx,
+			input:       &models.View{Name: "Bugs", Filter: "tag:bug AND status:open", Sort: []string{"-due"}, GroupBy: "user"},
+			mockExpect: func() {
+				mockStore.EXPECT().TagIDs(ctx, []string{"bug"}).Return(map[string]int64{"bug": 3}, nil)
+				mock.SQL.ExpectBegin()
+				mockStore.EXPECT().Create(ctx, gomock.Any()).DoAndReturn(func(_ *gofr.Context, v *models.View) (int64, error) {
+					want := []models.ViewTag{{ID: 3, Name: "bug"}}
+					if v.Owner != "7" || v.Visibility != models.ViewPrivate || v.Version != 1 || !reflect.DeepEqual(v.Tags, want) {
+						t.Errorf("unexpected view %+v", v)
+					}
+
+					return 9, nil
+				})
+				mockAuditStore.EXPECT().Create(ctx, gomock.Any()).Return(nil)
+				mock.SQL.ExpectCommit()
+			},
+		},
+		{
+			description: "no actor",
+			ctx:         &gofr.Context{Context: t.Context()},
+			input:       &models.View{Name: "Bugs"},
+			mockExpect:  func() {},
+			expectedErr: errNoActor,
+		},
+		{
+			description: "invalid layout",
+			ctx:         ctx,
+			input: &models.View{
+				Name: "Bugs", Visibility: "team", Sort: []string{"due", "-due", "name"}, Columns: []string{"id", "tags"}, GroupBy: "tag",
+			},
+			mockExpect: func() {},
+			expectedErr: apperr.Validation(
+				apperr.Field("visibility", "must be private or shared"),
+				apperr.Field("sort[1]", "repeats an earlier entry"),
+				apperr.Field("sort[2]", "must be one of id, title, status, user, parent, due, optionally prefixed with -"),
+				apperr.Field("columns[1]", "must be one of id, title, description, status, user_id, parent_id, due_date, version"),
+				apperr.Field("group_by", "must be one of status, user, parent"),
+			),
+		},
+		{
+			description: "shared with nobody",
+			ctx:         ctx,
+			input:       &models.View{Name: "Bugs", Visibility: models.ViewShared},
+			mockExpect:  func() {},
+			expectedErr: apperr.Validation(apperr.Field("shared_with", "must name at least one user for a shared view")),
+		},
+		{
+			description: "private view shared with users",
+			ctx:         ctx,
+			input:       &models.View{Name: "Bugs", SharedWith: []string{"8"}},
+			mockExpect:  func() {},
+			expectedErr: apperr.Validation(apperr.Field("shared_with", "must be empty for a private view")),
+		},
+		{
+			description: "shared with a user twice",
+			ctx:         ctx,
+			input:       &models.View{Name: "Bugs", Visibility: models.ViewShared, SharedWith: []string{"8", "9", "8"}},
+			mockExpect:  func() {},
+			expectedErr: apperr.Validation(apperr.Field("shared_with[2]", "repeats an earlier entry")),
+		},
+		{
+			description: "invalid filter",
+			ctx:         ctx,
+			input:       &models.View{Name: "Bugs", Filter: "colour:red"},
+			mockExpect:  func() {},
+			expectedErr: apperr.Validation(apperr.Field("filter", syntaxErr.Error())),
+		},
+		{
+			description: "unknown tag",
+			ctx:         ctx,
+			input:       &models.View{Name: "Bugs", Filter: "tag:bug OR tag:gone"},
+			mockExpect: func() {
+				mockStore.EXPECT().TagIDs(ctx, []string{"bug", "gone"}).Return(map[string]int64{"bug": 3}, nil)
+			},
+			expectedErr: apperr.Validation(apperr.Field("filter", `refers to the unknown tag "gone"`)),
+		},
+		{
+			description: "create error",
+			ctx:         ctx,
+			input:       &models.View{Name: "Bugs"},
+			mockExpect: func() {
+				mock.SQL.ExpectBegin()
+				mockStore.EXPECT().Create(ctx, gomock.Any()).Return(int64(0), utils.ErrTest)
+				mock.SQL.ExpectRollback()
+			},
+			expectedErr: utils.ErrTest,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.description, func(t *testing.T) {
+			tc.mockExpect()
+
+			v, err := viewService.Create(tc.ctx, tc.input)
+			if !errors.Is(err, tc.expectedErr) {
+				t.Errorf("expected error %v, got %v", tc.expectedErr, err)
+			}
+
+			if err == nil && (v.ID != 9 || v.Owner != "7") {
+				t.Errorf("unexpected view %+v", v)
+			}
+		})
+	}
+}
+
+func TestService_GetByID(t *testing.T) {
+	ctx, _ := newContext(t, "7")
+	controller := gomock.NewController(t)
+	mockStore := NewMockStore(controller)
+	viewService := New(mockStore, NewMockTaskService(controller), NewMockAuditStore(controller))
+
+	tests := []struct {
+		description string
+		mockExpect  func()
+		expected    *models.View
+		expectedErr error
+	}{
+		{
+			description: "follows renamed tags",
+			mockExpect: func() {
+				mockStore.EXPECT().GetByID(ctx, int64(9)).Return(&models.View{
+					ID: 9, Owner: "8", Visibility: models.ViewShared, SharedWith: []string{"7"}, Filter: "tag:bug AND due<now+1w",
+					Tags: []models.ViewTag{{ID: 3, Name: "bug"}},
+				}, nil)
+				mockStore.EXPECT().TagNames(ctx, []int64{3}).Return(map[int64]string{3: "defect"}, nil)
+			},
+			expected: &models.View{
+				ID: 9, Owner: "8", Visibility: models.ViewShared, SharedWith: []string{"7"}, Filter: `tag:"defect" AND due<now+1w`,
+				Tags: []models.ViewTag{{ID: 3, Name: "defect"}},
+			},
+		},
+		{
+			description: "private view of another user",
+			mockExpect: func() {
+				mockStore.EXPECT().GetByID(ctx, int64(9)).Return(&models.View{ID: 9, Owner: "8", Visibility: models.ViewPrivate}, nil)
+			},
+			expectedErr: apperr.NotFound("view", 9),
+		},
+		{
+			description: "shared with other users",
+			mockExpect: func() {
+				mockStore.EXPECT().GetByID(ctx, int64(9)).
+					Return(&models.View{ID: 9, Owner: "8", Visibility: models.ViewShared, SharedWith: []string{"9"}}, nil)
+			},
+			expectedErr: apperr.NotFound("view", 9),
+		},
+		{
+			description: "store error",
+			mockExpect: func() {
+				mockStore.EXPECT().GetByID(ctx, int64(9)).Return(nil, utils.ErrTest)
+			},
+			expectedErr: utils.ErrTest,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.description, func(t *testing.T) {
+			tc.mockExpect()
+
+			v, err := viewService.GetByID(ctx, 9)
+			if !errors.Is(err, tc.expectedErr) {
+				t.Errorf("expected error %v, got %v", tc.expectedErr, err)
+			}
+
+			if !reflect.DeepEqual(v, tc.expected) {
+				t.Errorf("expected %+v, got %+v", tc.expected, v)
+			}
+		})
+	}
+}
+
+func TestService_GetAll(t *testing.T) {
+	ctx, _ := newContext(t, "7")
+	controller := gomock.NewController(t)
+	mockStore := NewMockStore(controller)
+	viewService := New(mockStore, NewMockTaskService(controller), NewMockAuditStore(controller))
+
+	mockStore.EXPECT().GetVisible(ctx, "7").Return([]models.View{
+		{ID: 1, Owner: "7", Filter: "tag:bug", Tags: []models.ViewTag{{ID: 3, Name: "bug"}}},
+		{
+			ID: 2, Owner: "8", Visibility: models.ViewShared, SharedWith: []string{"7"}, Filter: "tag:ops",
+			Tags: []models.ViewTag{{ID: 4, Name: "ops"}},
+		},
+	}, nil)
+	mockStore.EXPECT().TagNames(ctx, []int64{3, 4}).Return(map[int64]string{3: "bug", 4: "infra"}, nil)
+
+	views, err := viewService.GetAll(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if views[0].Filter != "tag:bug" || views[1].Filter != `tag:"infra"` {
+		t.Errorf("expected only the second filter to follow the rename, got %+v", views)
+	}
+}
+
+func TestService_Update(t *testing.T) {
+	ctx, mock := newContext(t, "7")
+	controller := gomock.NewController(t)
+	mockStore := NewMockStore(controller)
+	mockAuditStore := NewMockAuditStore(controller)
+	viewService := New(mockStore, NewMockTaskService(controller), mockAuditStore)
+
+	tests := []struct {
+		description string
+		input       *models.View
+		mockExpect  func()
+		expectedErr error
+	}{
+		{
+			description: "success",
+			input:       &models.View{ID: 9, Name: "Open", Filter: "status:open", Version: 2},
+			mockExpect: func() {
+				mock.SQL.ExpectBegin()
+				mockStore.EXPECT().GetByID(ctx, int64(9)).Return(&models.View{ID: 9, Owner: "7", Version: 2}, nil)
+				mockStore.EXPECT().Update(ctx, gomock.Any()).DoAndReturn(func(_ *gofr.Context, v *models.View) error {
+					if v.Owner != "7" || v.Version != 2 || v.Visibility != models.ViewPrivate {
+						t.Errorf("unexpected view %+v", v)
+					}
+
+					return nil
+				})
+				mockAuditStore.EXPECT().Create(ctx, gomock.Any()).Return(nil)
+				mock.SQL.ExpectCommit()
+			},
+		},
+		{
+			description: "stale version",
+			input:       &models.View{ID: 9, Name: "Open", Version: 1},
+			mockExpect: func() {
+				mock.SQL.ExpectBegin()
+				mockStore.EXPECT().GetByID(ctx, int64(9)).Return(&models.View{ID: 9, Owner: "7", Version: 2}, nil)
+				mock.SQL.ExpectRollback()
+			},
+			expectedErr: apperr.PreconditionFailed("view 9 was modified concurrently, current version is 2"),
+		},
+		{
+			description: "shared by another user",
+			input:       &models.View{ID: 9, Name: "Open"},
+			mockExpect: func() {
+				mock.SQL.ExpectBegin()
+				mockStore.EXPECT().GetByID(ctx, int64(9)).
+					Return(&models.View{ID: 9, Owner: "8", Visibility: models.ViewShared, SharedWith: []string{"7"}}, nil)
+				mock.SQL.ExpectRollback()
+			},
+			expectedErr: apperr.Forbidden("view 9 is shared by 8 and only they can change it"),
+		},
+		{
+			description: "invalid",
+			input:       &models.View{ID: 9},
+			mockExpect:  func() {},
+			expectedErr: apperr.Validation(apperr.Field("name", "is required")),
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.description, func(t *testing.T) {
+			tc.mockExpect()
+
+			v, err := viewService.Update(ctx, tc.input)
+			if !errors.Is(err, tc.expectedErr) {
+				t.Errorf("expected error %v, got %v", tc.expectedErr, err)
+			}
+
+			if err == nil && v.Version != 3 {
+				t.Errorf("expected version 3, got %d", v.Version)
+			}
+		})
+	}
+}
+
+func TestService_Delete(t *testing.T) {
+	ctx, mock := newContext(t, "7")
+	controller := gomock.NewController(t)
+	mockStore := NewMockStore(controller)
+	mockAuditStore := NewMockAuditStore(controller)
+	viewService := New(mockStore, NewMockTaskService(controller), mockAuditStore)
+
+	mock.SQL.ExpectBegin()
+	mockStore.EXPECT().GetByID(ctx, int64(9)).Return(&models.View{ID: 9, Owner: "7"}, nil)
+	mockStore.EXPECT().Delete(ctx, int64(9)).Return(nil)
+	mockAuditStore.EXPECT().Create(ctx, gomock.Any()).Return(nil)
+	mock.SQL.ExpectCommit()
+
+	err := viewService.Delete(ctx, 9)
+	if err != nil {
+		t.Error(err)
+	}
+}
+
+func TestService_Tasks(t *testing.T) {
+	ctx, _ := newContext(t, "7")
+	controller := gomock.NewController(t)
+	mockStore := NewMockStore(controller)
+	mockTaskSvc := NewMockTaskService(controller)
+	viewService := New(mockStore, mockTaskSvc, NewMockAuditStore(controller))
+	parent := int64(1)
+
+	tests := []struct {
+		description string
+		mockExpect  func()
+		expected    *models.ViewResult
+		expectedErr error
+	}{
+		{
+			description: "grouped",
+			mockExpect: func() {
+				mockStore.EXPECT().GetByID(ctx, int64(9)).Return(&models.View{
+					ID: 9, Owner: "7", Filter: "user:2", Sort: []string{"-due"}, Columns: []string{"id", "parent_id"},
+					GroupBy: "status", Version: 4,
+				}, nil)
+				mockTaskSvc.EXPECT().GetAll(ctx, gomock.Any()).DoAndReturn(func(_ *gofr.Context, f *models.TaskFilter) ([]models.Task, error) {
+					want := []models.TaskSort{{Field: "status"}, {Field: "due", Desc: true}}
+					if !reflect.DeepEqual(f.Sort, want) || f.Query == nil || f.Query.String() != "user:2" {
+						t.Errorf("unexpected filter %+v", f)
+					}
+
+					return []models.Task{{ID: 2, UserID: 2}, {ID: 3, UserID: 2, ParentID: &parent}, {ID: 1, UserID: 2, Status: true}}, nil
+				})
+			},
+			expected: &models.ViewResult{ViewID: 9, Version: 4, Groups: []models.ViewGroup{
+				{Key: "open", Tasks: []map[string]any{{"id": float64(2), "parent_id": nil}, {"id": float64(3), "parent_id": float64(1)}}},
+				{Key: "done", Tasks: []map[string]any{{"id": float64(1), "parent_id": nil}}},
+			}},
+		},
+		{
+			description: "ungrouped and empty",
+			mockExpect: func() {
+				mockStore.EXPECT().GetByID(ctx, int64(9)).Return(&models.View{ID: 9, Owner: "7", Version: 1}, nil)
+				mockTaskSvc.EXPECT().GetAll(ctx, &models.TaskFilter{}).Return(nil, nil)
+			},
+			expected: &models.ViewResult{ViewID: 9, Version: 1, Groups: []models.ViewGroup{{Tasks: []map[string]any{}}}},
+		},
+		{
+			description: "deleted tag",
+			mockExpect: func() {
+				mockStore.EXPECT().GetByID(ctx, int64(9)).Return(&models.View{
+					ID: 9, Owner: "7", Filter: "tag:bug", Tags: []models.ViewTag{{ID: 3, Name: "bug"}},
+				}, nil)
+				mockStore.EXPECT().TagNames(ctx, []int64{3}).Return(map[int64]string{}, nil)
+			},
+			expectedErr: apperr.Conflict("view 9 refers to the deleted tags bug, update its filter"),
+		},
+		{
+			description: "task service error",
+			mockExpect: func() {
+				mockStore.EXPECT().GetByID(ctx, int64(9)).Return(&models.View{ID: 9, Owner: "7"}, nil)
+				mockTaskSvc.EXPECT().GetAll(ctx, gomock.Any()).Return(nil, utils.ErrTest)
+			},
+			expectedErr: utils.ErrTest,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.description, func(t *testing.T) {
+			tc.mockExpect()
+
+			res, err := viewService.Tasks(ctx, 9)
+			if !errors.Is(err, tc.expectedErr) {
+				t.Errorf("expected error %v, got %v", tc.expectedErr, err)
+			}
+
+			if !reflect.DeepEqual(res, tc.expected) {
+				t.Errorf("expected %+v, got %+v", tc.expected, res)
+			}
+		})
+	}
+}
@@ -0,0 +1,25 @@
+package webhook
+
+import (
+	"time"
+
+	"gofr.dev/pkg/gofr"
+
+	"TaskManager2/models"
+)
+
+type Store interface {
+	Create(*gofr.Context, *models.Webhook) (int64, error)
+	GetAll(*gofr.Context) ([]models.Webhook, error)
+	GetByID(*gofr.Context, int64) (*models.Webhook, error)
+	Update(*gofr.Context, *models.Webhook) error
+	Delete(*gofr.Context, int64) error
+	Subscribers(*gofr.Context, string) ([]int64, error)
+	CreateDeliveries(*gofr.Context, []models.WebhookDelivery) error
+	GetDeliveries(*gofr.Context, *models.DeliveryFilter) ([]models.WebhookDelivery, error)
+	GetDelivery(*gofr.Context, int64) (*models.WebhookDelivery, error)
+	Replay(*gofr.Context, int64, time.Time) (int64, error)
+	Due(*gofr.Context, time.Time, int) ([]models.WebhookDelivery, error)
+	Claim(*gofr.Context, int64, time.Time, time.Time) (bool, error)
+	SaveAttempt(*gofr.Context, *models.WebhookDelivery) error
+}
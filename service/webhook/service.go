@@ -0,0 +1,444 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+
+	"gofr.dev/pkg/gofr"
+
+	"TaskManager2/apperr"
+	"TaskManager2/models"
+	"TaskManager2/utils"
+	"TaskManager2/validate"
+)
+
+const (
+	minSecretLength = 16
+
+	defaultDeliveryLimit = 50
+	maxDeliveryLimit     = 500
+
+	// A delivery is attempted maxAttempts times, backing off from firstRetry
+	// to maxRetry.
+	maxAttempts = 8
+	firstRetry  = 30 * time.Second
+	maxRetry    = time.Hour
+
+	// claimDuration outlasts the client timeout, so a delivery is only sent
+	// again by another worker when the one that claimed it has died.
+	claimDuration = time.Minute
+
+	maxErrorLength = 500
+	maxBodyRead    = 64 << 10
+)
+
+// Delivery request headers. The signature is the hex HMAC-SHA256, keyed with
+// the webhook secret, of the timestamp, a dot and the body.
+const (
+	headerEvent     = "X-Webhook-Event"
+	headerDelivery  = "X-Webhook-Delivery"
+	headerTimestamp = "X-Webhook-Timestamp"
+	headerSignature = "X-Webhook-Signature"
+)
+
+var errInternalTarget = apperr.Validation(apperr.Field("url", "must not point to a loopback, private or link-local address"))
+
+// events lists the event types a webhook can subscribe to.
+func events() []string {
+	return []string{
+		models.EventTaskCreated, models.EventTaskUpdated, models.EventTaskCompleted, models.EventTaskDeleted,
+		models.EventTaskRestored, models.EventTaskReminder, models.EventUserCreated,
+	}
+}
+
+type service struct {
+	store   Store
+	client  *http.Client
+	resolve func(context.Context, string) ([]net.IPAddr, error)
+}
+
+func New(store Store, client *http.Client) *service {
+	return &service{store: store, client: client, resolve: net.DefaultResolver.LookupIPAddr}
+}
+
+// Client returns a client for sending deliveries that refuses to connect to
+// internal addresses, so a receiver whose name later resolves to one is not
+// reached either. It connects directly, as a proxy would be internal.
+func Client(timeout time.Duration) *http.Client {
+	return &http.Client{
+		Timeout: timeout,
+		Transport: &http.Transport{
+			DialContext:         (&net.Dialer{Timeout: timeout, Control: refuseInternal}).DialContext,
+			TLSHandshakeTimeout: timeout,
+			ForceAttemptHTTP2:   true,
+		},
+	}
+}
+
+func (s *service) Create(ctx *gofr.Context, w *models.Webhook) (*models.Webhook, error) {
+	created := *w
+	created.CreatedAt = time.Now().UTC().Truncate(time.Second)
+
+	err := check(&created)
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.checkTarget(ctx, created.URL)
+	if err != nil {
+		return nil, err
+	}
+
+	created.ID, err = s.store.Create(ctx, &created)
+	if err != nil {
+		return nil, err
+	}
+
+	created.Secret = ""
+
+	return &created, nil
+}
+
+func (s *service) GetAll(ctx *gofr.Context) ([]models.Webhook, error) {
+	webhooks, err := s.store.GetAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range webhooks {
+		webhooks[i].Secret = ""
+	}
+
+	return webhooks, nil
+}
+
+func (s *service) GetByID(ctx *gofr.Context, id int64) (*models.Webhook, error) {
+	w, err := s.store.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	w.Secret = ""
+
+	return w, nil
+}
+
+// Update replaces the URL and events of the webhook, and its secret when a new
+// one is given.
+func (s *service) Update(ctx *gofr.Context, w *models.Webhook) (*models.Webhook, error) {
+	existing, err := s.store.GetByID(ctx, w.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	updated := *w
+	updated.CreatedAt = existing.CreatedAt
+
+	if updated.Secret == "" {
+		updated.Secret = existing.Secret
+	}
+
+	err = check(&updated)
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.checkTarget(ctx, updated.URL)
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.store.Update(ctx, &updated)
+	if err != nil {
+		return nil, err
+	}
+
+	updated.Secret = ""
+
+	return &updated, nil
+}
+
+func (s *service) Delete(ctx *gofr.Context, id int64) error {
+	_, err := s.store.GetByID(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	return s.store.Delete(ctx, id)
+}
+
+// check validates the webhook and sorts its events, dropping duplicates.
+func check(w *models.Webhook) error {
+	err := validate.Struct(w)
+	if err != nil {
+		return err
+	}
+
+	var invalid []apperr.FieldError
+
+	u, err := url.Parse(w.URL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		invalid = append(invalid, apperr.Field("url", "must be an absolute http or https URL"))
+	}
+
+	if len([]rune(w.Secret)) < minSecretLength {
+		invalid = append(invalid, apperr.Field("secret", fmt.Sprintf("must be at least %d characters", minSecretLength)))
+	}
+
+	for i, event := range w.Events {
+		if !slices.Contains(events(), event) {
+			invalid = append(invalid, apperr.Field(fmt.Sprintf("events[%d]", i), "must be one of "+strings.Join(events(), ", ")))
+		}
+	}
+
+	if len(invalid) > 0 {
+		return apperr.Validation(invalid...)
+	}
+
+	w.Events = slices.Compact(slices.Sorted(slices.Values(w.Events)))
+
+	return nil
+}
+
+// checkTarget rejects a checked URL whose host is or resolves to an internal
+// address, so that webhooks cannot be used to reach the internal network.
+func (s *service) checkTarget(ctx context.Context, rawURL string) error {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return err
+	}
+
+	addrs, err := s.resolve(ctx, u.Hostname())
+	if err != nil {
+		return apperr.Validation(apperr.Field("url", "has a host that could not be resolved"))
+	}
+
+	for _, addr := range addrs {
+		if internal(addr.IP) {
+			return errInternalTarget
+		}
+	}
+
+	return nil
+}
+
+// refuseInternal is a dialer control that fails connections to internal addresses.
+func refuseInternal(_, address string, _ syscall.RawConn) error {
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return err
+	}
+
+	if ip := net.ParseIP(host); ip == nil || internal(ip) {
+		return errInternalTarget
+	}
+
+	return nil
+}
+
+func internal(ip net.IP) bool {
+	return ip.IsLoopback() || ip.IsPrivate() || ip.IsUnspecified() || ip.IsLinkLocalUnicast() ||
+		ip.IsLinkLocalMulticast() || ip.IsInterfaceLocalMulticast() || ip.IsMulticast()
+}
+
+// Record queues a delivery of the event to every webhook subscribed to it. It
+// joins the transaction on ctx, so the deliveries are queued exactly when the
+// change they report is committed.
+func (s *service) Record(ctx *gofr.Context, e *models.Event) error {
+	subscribed, err := s.store.Subscribers(ctx, e.Name)
+	if err != nil {
+		return err
+	}
+
+	if len(subscribed) == 0 {
+		return nil
+	}
+
+	payload, err := json.Marshal(e)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now().UTC().Truncate(time.Second)
+
+	deliveries := make([]models.WebhookDelivery, len(subscribed))
+	for i, id := range subscribed {
+		deliveries[i] = models.WebhookDelivery{
+			WebhookID: id, Event: e.Name, Payload: payload, Status: models.DeliveryPending, NextAttemptAt: &now, CreatedAt: now,
+		}
+	}
+
+	return s.store.CreateDeliveries(ctx, deliveries)
+}
+
+// GetDeliveries lists the deliveries of a webhook, newest first. A zero limit
+// means defaultDeliveryLimit.
+func (s *service) GetDeliveries(ctx *gofr.Context, f *models.DeliveryFilter) ([]models.WebhookDelivery, error) {
+	switch {
+	case f.Limit == 0:
+		f.Limit = defaultDeliveryLimit
+	case f.Limit < 0 || f.Limit > maxDeliveryLimit:
+		return nil, apperr.Validation(apperr.Field("limit", fmt.Sprintf("must be between 1 and %d", maxDeliveryLimit)))
+	}
+
+	if f.Status != "" && !slices.Contains([]string{models.DeliveryPending, models.DeliveryDelivered, models.DeliveryDead}, f.Status) {
+		return nil, apperr.Validation(apperr.Field("status", "must be pending, delivered or dead"))
+	}
+
+	_, err := s.store.GetByID(ctx, f.WebhookID)
+	if err != nil {
+		return nil, err
+	}
+
+	deliveries, err := s.store.GetDeliveries(ctx, f)
+	if err != nil {
+		return nil, err
+	}
+
+	return deliveries, nil
+}
+
+// Replay queues a fresh copy of a delivered or dead delivery. The copy has its
+// own delivery ID but carries the same event ID.
+func (s *service) Replay(ctx *gofr.Context, webhookID, deliveryID int64) (*models.WebhookDelivery, error) {
+	d, err := s.store.GetDelivery(ctx, deliveryID)
+	if err != nil {
+		return nil, err
+	}
+
+	if d.WebhookID != webhookID {
+		return nil, apperr.NotFound("delivery", deliveryID)
+	}
+
+	if d.Status == models.DeliveryPending {
+		return nil, apperr.Conflict(fmt.Sprintf("delivery %d is still pending", deliveryID))
+	}
+
+	id, err := s.store.Replay(ctx, deliveryID, time.Now().UTC().Truncate(time.Second))
+	if err != nil {
+		return nil, err
+	}
+
+	return s.store.GetDelivery(ctx, id)
+}
+
+// Deliver sends up to limit due deliveries and returns how many were delivered
+// and how many failed. Deliveries claimed by another worker are skipped.
+func (s *service) Deliver(ctx *gofr.Context, limit int) (int, int, error) {
+	now := time.Now().UTC()
+
+	due, err := s.store.Due(ctx, now, limit)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	var delivered, failed int
+
+	for i := range due {
+		d := &due[i]
+
+		claimed, err := s.store.Claim(ctx, d.ID, now, now.Add(claimDuration))
+		if err != nil {
+			return delivered, failed, err
+		}
+
+		if !claimed {
+			continue
+		}
+
+		s.attempt(ctx, d)
+
+		err = s.store.SaveAttempt(ctx, d)
+		if err != nil {
+			return delivered, failed, err
+		}
+
+		if d.Status == models.DeliveryDelivered {
+			delivered++
+		} else {
+			failed++
+		}
+	}
+
+	return delivered, failed, nil
+}
+
+// attempt sends the delivery and updates it with the outcome: delivered, due
+// again after a backoff, or dead once it has used up its attempts.
+func (s *service) attempt(ctx *gofr.Context, d *models.WebhookDelivery) {
+	d.Attempts++
+
+	code, err := s.send(ctx, d)
+	now := time.Now().UTC()
+
+	d.ResponseCode = code
+	d.NextAttemptAt = nil
+
+	switch {
+	case err == nil:
+		d.Status = models.DeliveryDelivered
+		d.LastError = ""
+		d.DeliveredAt = &now
+	case d.Attempts >= maxAttempts:
+		d.Status = models.DeliveryDead
+		d.LastError = utils.Truncate(err.Error(), maxErrorLength)
+	default:
+		next := now.Add(utils.Backoff(d.Attempts, firstRetry, maxRetry))
+		d.NextAttemptAt = &next
+		d.LastError = utils.Truncate(err.Error(), maxErrorLength)
+	}
+}
+
+// send posts the signed payload and returns the response status code. Any
+// status other than 2xx is an error.
+func (s *service) send(ctx *gofr.Context, d *models.WebhookDelivery) (int, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, d.URL, bytes.NewReader(d.Payload))
+	if err != nil {
+		return 0, err
+	}
+
+	timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(headerEvent, d.Event)
+	req.Header.Set(headerDelivery, strconv.FormatInt(d.ID, 10))
+	req.Header.Set(headerTimestamp, timestamp)
+	req.Header.Set(headerSignature, "sha256="+sign(d.Secret, timestamp, d.Payload))
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+
+	defer resp.Body.Close()
+
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, maxBodyRead))
+
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+		return resp.StatusCode, fmt.Errorf("receiver responded %s", resp.Status)
+	}
+
+	return resp.StatusCode, nil
+}
+
+func sign(secret, timestamp string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp + "."))
+	mac.Write(body)
+
+	return hex.EncodeToString(mac.Sum(nil))
+}
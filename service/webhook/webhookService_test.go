@@ -0,0 +1,589 @@
+package webhook
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strconv"
+	"testing"
+	"time"
+
+	"go.uber.org/mock/gomock"
+	"gofr.dev/pkg/gofr"
+	"gofr.dev/pkg/gofr/container"
+
+	"TaskManager2/apperr"
+	"TaskManager2/models"
+	"TaskManager2/utils"
+)
+
+const secret = "0123456789abcdef"
+
+// resolve stands in for DNS: internal.example.com has an internal address
+// among public ones, and unknown.example.com does not resolve.
+func resolve(_ context.Context, host string) ([]net.IPAddr, error) {
+	switch host {
+	case "internal.example.com":
+		return []net.IPAddr{{IP: net.ParseIP("93.184.216.34")}, {IP: net.ParseIP("10.0.0.5")}}, nil
+	case "unknown.example.com":
+		return nil, &net.DNSError{Err: "no such host", Name: host, IsNotFound: true}
+	}
+
+	if ip := net.ParseIP(host); ip != nil {
+		return []net.IPAddr{{IP: ip}}, nil
+	}
+
+	return []net.IPAddr{{IP: net.ParseIP("93.184.216.34")}}, nil
+}
+
+func TestService_Create(t *testing.T) {
+	var ctx *gofr.Context
+
+	controller := gomock.NewController(t)
+	mockStore := NewMockStore(controller)
+	webhookService := New(mockStore, http.DefaultClient)
+	webhookService.resolve = resolve
+	internalURL := apperr.Validation(apperr.Field("url", "must not point to a loopback, private or link-local address"))
+
+	tests := []struct {
+		description   string
+		input         *models.Webhook
+		mockExpect    func()
+		expected      *models.Webhook
+		expectedError error
+	}{
+		{
+			description: "success",
+			input: &models.Webhook{URL: "https://example.com/hook", Secret: secret,
+				Events: []string{"task.completed", "task.created", "task.completed"}},
+			mockExpect: func() {
+				mockStore.EXPECT().Create(ctx, gomock.Any()).DoAndReturn(func(_ *gofr.Context, w *models.Webhook) (int64, error) {
+					if w.Secret != secret || w.CreatedAt.IsZero() {
+						t.Errorf("expected the secret and creation time to be stored, got %+v", w)
+					}
+
+					return 4, nil
+				})
+			},
+			expected: &models.Webhook{ID: 4, URL: "https://example.com/hook", Events: []string{"task.completed", "task.created"}},
+		},
+		{
+			description: "invalid webhook",
+			input:       &models.Webhook{URL: "ftp://example.com", Secret: "short", Events: []string{"task.created", "task.renamed"}},
+			mockExpect:  func() {},
+			expectedError: apperr.Validation(
+				apperr.Field("url", "must be an absolute http or https URL"),
+				apperr.Field("secret", "must be at least 16 characters"),
+				apperr.Field("events[1]", "must be one of task.created, task.updated, task.completed, task.deleted, "+
+					"task.restored, task.reminder, user.created"),
+			),
+		},
+		{
+			description:   "loopback address",
+			input:         &models.Webhook{URL: "http://127.0.0.1:8000/hook", Secret: secret, Events: []string{"task.created"}},
+			mockExpect:    func() {},
+			expectedError: internalURL,
+		},
+		{
+			description:   "link-local address",
+			input:         &models.Webhook{URL: "http://[fe80::1]/hook", Secret: secret, Events: []string{"task.created"}},
+			mockExpect:    func() {},
+			expectedError: internalURL,
+		},
+		{
+			description:   "host resolving to a private address",
+			input:         &models.Webhook{URL: "https://internal.example.com/hook", Secret: secret, Events: []string{"task.created"}},
+			mockExpect:    func() {},
+			expectedError: internalURL,
+		},
+		{
+			description:   "unresolvable host",
+			input:         &models.Webhook{URL: "https://unknown.example.com/hook", Secret: secret, Events: []string{"task.created"}},
+			mockExpect:    func() {},
+			expectedError: apperr.Validation(apperr.Field("url", "has a host that could not be resolved")),
+		},
+		{
+			description:   "missing fields",
+			input:         &models.Webhook{},
+			mockExpect:    func() {},
+			expectedError: apperr.Validation(apperr.Field("url", "is required"), apperr.Field("events", "is required")),
+		},
+		{
+			description: "store error",
+			input:       &models.Webhook{URL: "http://example.com", Secret: secret, Events: []string{"user.created"}},
+			mockExpect: func() {
+				mockStore.EXPECT().Create(ctx, gomock.Any()).Return(int64(0), utils.ErrTest)
+			},
+			expectedError: utils.ErrTest,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.description, func(t *testing.T) {
+			tc.mockExpect()
+
+			created, err := webhookService.Create(ctx, tc.input)
+			if !errors.Is(err, tc.expectedError) {
+				t.Errorf("expected error %v, got %v", tc.expectedError, err)
+			}
+
+			if created != nil {
+				created.CreatedAt = time.Time{}
+			}
+
+			if !reflect.DeepEqual(created, tc.expected) {
+				t.Errorf("expected %+v, got %+v", tc.expected, created)
+			}
+		})
+	}
+}
+
+func TestService_Read(t *testing.T) {
+	var ctx *gofr.Context
+
+	controller := gomock.NewController(t)
+	mockStore := NewMockStore(controller)
+	webhookService := New(mockStore, http.DefaultClient)
+
+	mockStore.EXPECT().GetAll(ctx).Return([]models.Webhook{{ID: 1, Secret: secret}}, nil)
+
+	webhooks, err := webhookService.GetAll(ctx)
+	if err != nil || !reflect.DeepEqual(webhooks, []models.Webhook{{ID: 1}}) {
+		t.Errorf("expected the webhooks without secrets, got %+v, %v", webhooks, err)
+	}
+
+	mockStore.EXPECT().GetByID(ctx, int64(1)).Return(&models.Webhook{ID: 1, Secret: secret}, nil)
+
+	w, err := webhookService.GetByID(ctx, 1)
+	if err != nil || !reflect.DeepEqual(w, &models.Webhook{ID: 1}) {
+		t.Errorf("expected the webhook without its secret, got %+v, %v", w, err)
+	}
+
+	mockStore.EXPECT().GetByID(ctx, int64(2)).Return(nil, utils.ErrTest)
+
+	_, err = webhookService.GetByID(ctx, 2)
+	if !errors.Is(err, utils.ErrTest) {
+		t.Errorf("expected %v, got %v", utils.ErrTest, err)
+	}
+}
+
+func TestService_Update(t *testing.T) {
+	var ctx *gofr.Context
+
+	controller := gomock.NewController(t)
+	mockStore := NewMockStore(controller)
+	webhookService := New(mockStore, http.DefaultClient)
+	createdAt := time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC)
+	existing := &models.Webhook{ID: 3, URL: "https://old.example.com", Secret: secret, Events: []string{"task.created"}, CreatedAt: createdAt}
+
+	webhookService.resolve = resolve
+
+	tests := []struct {
+		description   string
+		input         *models.Webhook
+		mockExpect    func()
+		expectedError error
+	}{
+		{
+			description: "secret kept",
+			input:       &models.Webhook{ID: 3, URL: "https://new.example.com", Events: []string{"task.deleted"}},
+			mockExpect: func() {
+				mockStore.EXPECT().GetByID(ctx, int64(3)).Return(existing, nil)
+				mockStore.EXPECT().Update(ctx, &models.Webhook{ID: 3, URL: "https://new.example.com", Secret: secret,
+					Events: []string{"task.deleted"}, CreatedAt: createdAt}).Return(nil)
+			},
+		},
+		{
+			description: "secret replaced",
+			input:       &models.Webhook{ID: 3, URL: "https://new.example.com", Secret: "fedcba9876543210", Events: []string{"task.deleted"}},
+			mockExpect: func() {
+				mockStore.EXPECT().GetByID(ctx, int64(3)).Return(existing, nil)
+				mockStore.EXPECT().Update(ctx, &models.Webhook{ID: 3, URL: "https://new.example.com", Secret: "fedcba9876543210",
+					Events: []string{"task.deleted"}, CreatedAt: createdAt}).Return(nil)
+			},
+		},
+		{
+			description: "not found",
+			input:       &models.Webhook{ID: 3},
+			mockExpect: func() {
+				mockStore.EXPECT().GetByID(ctx, int64(3)).Return(nil, apperr.NotFound("webhook", 3))
+			},
+			expectedError: apperr.NotFound("webhook", 3),
+		},
+		{
+			description: "invalid",
+			input:       &models.Webhook{ID: 3, URL: "https://new.example.com"},
+			mockExpect: func() {
+				mockStore.EXPECT().GetByID(ctx, int64(3)).Return(existing, nil)
+			},
+			expectedError: apperr.Validation(apperr.Field("events", "is required")),
+		},
+		{
+			description: "internal address",
+			input:       &models.Webhook{ID: 3, URL: "https://internal.example.com", Events: []string{"task.deleted"}},
+			mockExpect: func() {
+				mockStore.EXPECT().GetByID(ctx, int64(3)).Return(existing, nil)
+			},
+			expectedError: apperr.Validation(apperr.Field("url", "must not point to a loopback, private or link-local address")),
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.description, func(t *testing.T) {
+			tc.mockExpect()
+
+			updated, err := webhookService.Update(ctx, tc.input)
+			if !errors.Is(err, tc.expectedError) {
+				t.Errorf("expected error %v, got %v", tc.expectedError, err)
+			}
+
+			if updated != nil && updated.Secret != "" {
+				t.Errorf("expected the secret to be left out, got %+v", updated)
+			}
+		})
+	}
+}
+
+func TestService_Delete(t *testing.T) {
+	var ctx *gofr.Context
+
+	controller := gomock.NewController(t)
+	mockStore := NewMockStore(controller)
+	webhookService := New(mockStore, http.DefaultClient)
+
+	mockStore.EXPECT().GetByID(ctx, int64(3)).Return(&models.Webhook{ID: 3}, nil)
+	mockStore.EXPECT().Delete(ctx, int64(3)).Return(nil)
+
+	err := webhookService.Delete(ctx, 3)
+	if err != nil {
+		t.Error(err)
+	}
+
+	mockStore.EXPECT().GetByID(ctx, int64(4)).Return(nil, apperr.NotFound("webhook", 4))
+
+	err = webhookService.Delete(ctx, 4)
+	if !errors.Is(err, apperr.NotFound("webhook", 4)) {
+		t.Errorf("expected not found, got %v", err)
+	}
+}
+
+func TestService_Record(t *testing.T) {
+	mockContainer, _ := container.NewMockContainer(t)
+	ctx := &gofr.Context{
+		Context:   t.Context(),
+		Request:   nil,
+		Container: mockContainer,
+	}
+
+	controller := gomock.NewController(t)
+	mockStore := NewMockStore(controller)
+	webhookService := New(mockStore, http.DefaultClient)
+
+	mockStore.EXPECT().Subscribers(ctx, "task.created").Return([]int64{1, 3}, nil)
+	mockStore.EXPECT().CreateDeliveries(ctx, gomock.Any()).DoAndReturn(func(_ *gofr.Context, deliveries []models.WebhookDelivery) error {
+		if len(deliveries) != 2 || deliveries[0].WebhookID != 1 || deliveries[1].WebhookID != 3 {
+			t.Fatalf("expected deliveries to webhooks 1 and 3, got %+v", deliveries)
+		}
+
+		var event models.Event
+
+		err := json.Unmarshal(deliveries[0].Payload, &event)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		data, _ := event.Data.(map[string]any)
+		if event.ID != "e1" || event.Name != "task.created" || data["title"] != "release" {
+			t.Errorf("unexpected payload %s", deliveries[0].Payload)
+		}
+
+		if deliveries[0].Status != models.DeliveryPending || deliveries[0].NextAttemptAt == nil {
+			t.Errorf("expected a pending delivery due now, got %+v", deliveries[0])
+		}
+
+		return nil
+	})
+
+	err := webhookService.Record(ctx, &models.Event{ID: "e1", Name: "task.created", Data: &models.Task{ID: 9, Title: "release"}})
+	if err != nil {
+		t.Error(err)
+	}
+
+	mockStore.EXPECT().Subscribers(ctx, "task.deleted").Return(nil, nil)
+
+	err = webhookService.Record(ctx, &models.Event{ID: "e2", Name: "task.deleted", Data: &models.Task{ID: 9}})
+	if err != nil {
+		t.Errorf("expected no deliveries without subscribers, got %v", err)
+	}
+
+	mockStore.EXPECT().Subscribers(ctx, "task.created").Return(nil, utils.ErrTest)
+
+	err = webhookService.Record(ctx, &models.Event{ID: "e3", Name: "task.created", Data: &models.Task{ID: 9}})
+	if !errors.Is(err, utils.ErrTest) {
+		t.Errorf("expected %v, got %v", utils.ErrTest, err)
+	}
+}
+
+func TestService_GetDeliveries(t *testing.T) {
+	var ctx *gofr.Context
+
+	controller := gomock.NewController(t)
+	mockStore := NewMockStore(controller)
+	webhookService := New(mockStore, http.DefaultClient)
+	deliveries := []models.WebhookDelivery{{ID: 7, WebhookID: 1, Status: models.DeliveryDead}}
+
+	tests := []struct {
+		description   string
+		filter        models.DeliveryFilter
+		mockExpect    func()
+		expected      []models.WebhookDelivery
+		expectedError error
+	}{
+		{
+			description: "default limit",
+			filter:      models.DeliveryFilter{WebhookID: 1, Status: "dead"},
+			mockExpect: func() {
+				mockStore.EXPECT().GetByID(ctx, int64(1)).Return(&models.Webhook{ID: 1}, nil)
+				mockStore.EXPECT().GetDeliveries(ctx, &models.DeliveryFilter{WebhookID: 1, Status: "dead", Limit: 50}).Return(deliveries, nil)
+			},
+			expected: deliveries,
+		},
+		{
+			description:   "limit out of range",
+			filter:        models.DeliveryFilter{WebhookID: 1, Limit: 501},
+			mockExpect:    func() {},
+			expectedError: apperr.Validation(apperr.Field("limit", "must be between 1 and 500")),
+		},
+		{
+			description:   "unknown status",
+			filter:        models.DeliveryFilter{WebhookID: 1, Status: "failed"},
+			mockExpect:    func() {},
+			expectedError: apperr.Validation(apperr.Field("status", "must be pending, delivered or dead")),
+		},
+		{
+			description: "webhook not found",
+			filter:      models.DeliveryFilter{WebhookID: 2, Limit: 10},
+			mockExpect: func() {
+				mockStore.EXPECT().GetByID(ctx, int64(2)).Return(nil, apperr.NotFound("webhook", 2))
+			},
+			expectedError: apperr.NotFound("webhook", 2),
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.description, func(t *testing.T) {
+			tc.mockExpect()
+
+			got, err := webhookService.GetDeliveries(ctx, &tc.filter)
+			if !errors.Is(err, tc.expectedError) {
+				t.Errorf("expected error %v, got %v", tc.expectedError, err)
+			}
+
+			if !reflect.DeepEqual(got, tc.expected) {
+				t.Errorf("expected %+v, got %+v", tc.expected, got)
+			}
+		})
+	}
+}
+
+func TestService_Replay(t *testing.T) {
+	var ctx *gofr.Context
+
+	controller := gomock.NewController(t)
+	mockStore := NewMockStore(controller)
+	webhookService := New(mockStore, http.DefaultClient)
+	replayed := &models.WebhookDelivery{ID: 8, WebhookID: 1, Status: models.DeliveryPending}
+
+	tests := []struct {
+		description   string
+		webhookID     int64
+		mockExpect    func()
+		expected      *models.WebhookDelivery
+		expectedError error
+	}{
+		{
+			description: "success",
+			webhookID:   1,
+			mockExpect: func() {
+				mockStore.EXPECT().GetDelivery(ctx, int64(7)).Return(&models.WebhookDelivery{ID: 7, WebhookID: 1, Status: models.DeliveryDead}, nil)
+				mockStore.EXPECT().Replay(ctx, int64(7), gomock.Any()).Return(int64(8), nil)
+				mockStore.EXPECT().GetDelivery(ctx, int64(8)).Return(replayed, nil)
+			},
+			expected: replayed,
+		},
+		{
+			description: "delivery of another webhook",
+			webhookID:   2,
+			mockExpect: func() {
+				mockStore.EXPECT().GetDelivery(ctx, int64(7)).Return(&models.WebhookDelivery{ID: 7, WebhookID: 1, Status: models.DeliveryDead}, nil)
+			},
+			expectedError: apperr.NotFound("delivery", 7),
+		},
+		{
+			description: "still pending",
+			webhookID:   1,
+			mockExpect: func() {
+				mockStore.EXPECT().GetDelivery(ctx, int64(7)).Return(&models.WebhookDelivery{ID: 7, WebhookID: 1, Status: models.DeliveryPending}, nil)
+			},
+			expectedError: apperr.Conflict("delivery 7 is still pending"),
+		},
+		{
+			description: "replay error",
+			webhookID:   1,
+			mockExpect: func() {
+				mockStore.EXPECT().GetDelivery(ctx, int64(7)).
+					Return(&models.WebhookDelivery{ID: 7, WebhookID: 1, Status: models.DeliveryDelivered}, nil)
+				mockStore.EXPECT().Replay(ctx, int64(7), gomock.Any()).Return(int64(0), utils.ErrTest)
+			},
+			expectedError: utils.ErrTest,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.description, func(t *testing.T) {
+			tc.mockExpect()
+
+			got, err := webhookService.Replay(ctx, tc.webhookID, 7)
+			if !errors.Is(err, tc.expectedError) {
+				t.Errorf("expected error %v, got %v", tc.expectedError, err)
+			}
+
+			if !reflect.DeepEqual(got, tc.expected) {
+				t.Errorf("expected %+v, got %+v", tc.expected, got)
+			}
+		})
+	}
+}
+
+func TestService_Deliver(t *testing.T) {
+	mockContainer, _ := container.NewMockContainer(t)
+	ctx := &gofr.Context{
+		Context:   t.Context(),
+		Request:   nil,
+		Container: mockContainer,
+	}
+
+	var received []*http.Request
+
+	receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		timestamp := r.Header.Get(headerTimestamp)
+
+		if r.Header.Get(headerSignature) != "sha256="+sign(secret, timestamp, body) {
+			t.Errorf("bad signature %q for %s", r.Header.Get(headerSignature), body)
+		}
+
+		if ts, _ := strconv.ParseInt(timestamp, 10, 64); time.Since(time.Unix(ts, 0)) > time.Minute {
+			t.Errorf("stale timestamp %q", timestamp)
+		}
+
+		received = append(received, r)
+
+		if r.URL.Path == "/fail" {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}
+	}))
+	defer receiver.Close()
+
+	controller := gomock.NewController(t)
+	mockStore := NewMockStore(controller)
+	webhookService := New(mockStore, receiver.Client())
+	payload := []byte(`{"id":"e1","event":"task.created"}`)
+
+	due := []models.WebhookDelivery{
+		{ID: 1, WebhookID: 1, Event: "task.created", Payload: payload, Status: models.DeliveryPending, URL: receiver.URL + "/ok", Secret: secret},
+		{ID: 2, WebhookID: 2, Event: "task.created", Payload: payload, Status: models.DeliveryPending, Attempts: 2,
+			URL: receiver.URL + "/fail", Secret: secret},
+		{ID: 3, WebhookID: 2, Event: "task.created", Payload: payload, Status: models.DeliveryPending, Attempts: 7,
+			URL: receiver.URL + "/fail", Secret: secret},
+		{ID: 4, WebhookID: 1, Event: "task.created", Payload: payload, Status: models.DeliveryPending, URL: receiver.URL + "/ok", Secret: secret},
+	}
+
+	mockStore.EXPECT().Due(ctx, gomock.Any(), 10).Return(due, nil)
+	mockStore.EXPECT().Claim(ctx, int64(1), gomock.Any(), gomock.Any()).Return(true, nil)
+	mockStore.EXPECT().Claim(ctx, int64(2), gomock.Any(), gomock.Any()).Return(true, nil)
+	mockStore.EXPECT().Claim(ctx, int64(3), gomock.Any(), gomock.Any()).Return(true, nil)
+	mockStore.EXPECT().Claim(ctx, int64(4), gomock.Any(), gomock.Any()).Return(false, nil)
+
+	saved := map[int64]models.WebhookDelivery{}
+
+	mockStore.EXPECT().SaveAttempt(ctx, gomock.Any()).DoAndReturn(func(_ *gofr.Context, d *models.WebhookDelivery) error {
+		saved[d.ID] = *d
+
+		return nil
+	}).Times(3)
+
+	start := time.Now()
+
+	delivered, failed, err := webhookService.Deliver(ctx, 10)
+	if err != nil || delivered != 1 || failed != 2 {
+		t.Fatalf("expected 1 delivered and 2 failed, got %d, %d, %v", delivered, failed, err)
+	}
+
+	if len(received) != 3 || received[0].Header.Get(headerDelivery) != "1" || received[0].Header.Get(headerEvent) != "task.created" {
+		t.Errorf("expected deliveries 1, 2 and 3 to be sent, got %d requests", len(received))
+	}
+
+	if d := saved[1]; d.Status != models.DeliveryDelivered || d.Attempts != 1 || d.ResponseCode != http.StatusOK || d.DeliveredAt == nil {
+		t.Errorf("expected delivery 1 to be delivered, got %+v", d)
+	}
+
+	retry := saved[2]
+	if retry.Status != models.DeliveryPending || retry.Attempts != 3 || retry.ResponseCode != http.StatusServiceUnavailable ||
+		retry.LastError != "receiver responded 503 Service Unavailable" {
+		t.Errorf("expected delivery 2 to stay pending, got %+v", retry)
+	}
+
+	if retry.NextAttemptAt == nil || retry.NextAttemptAt.Before(start.Add(2*time.Minute)) {
+		t.Errorf("expected delivery 2 to be retried after 2 minutes, got %v", retry.NextAttemptAt)
+	}
+
+	if d := saved[3]; d.Status != models.DeliveryDead || d.Attempts != 8 || d.NextAttemptAt != nil {
+		t.Errorf("expected delivery 3 to be dead, got %+v", d)
+	}
+}
+
+func TestService_DeliverErrors(t *testing.T) {
+	var ctx *gofr.Context
+
+	controller := gomock.NewController(t)
+	mockStore := NewMockStore(controller)
+	webhookService := New(mockStore, http.DefaultClient)
+
+	mockStore.EXPECT().Due(ctx, gomock.Any(), 10).Return(nil, utils.ErrTest)
+
+	_, _, err := webhookService.Deliver(ctx, 10)
+	if !errors.Is(err, utils.ErrTest) {
+		t.Errorf("expected %v, got %v", utils.ErrTest, err)
+	}
+
+	mockStore.EXPECT().Due(ctx, gomock.Any(), 10).Return([]models.WebhookDelivery{{ID: 1}}, nil)
+	mockStore.EXPECT().Claim(ctx, int64(1), gomock.Any(), gomock.Any()).Return(false, utils.ErrTest)
+
+	_, _, err = webhookService.Deliver(ctx, 10)
+	if !errors.Is(err, utils.ErrTest) {
+		t.Errorf("expected %v, got %v", utils.ErrTest, err)
+	}
+}
+
+func TestClient_RefusesInternalAddresses(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		t.Error("expected the receiver not to be reached")
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	resp, err := Client(time.Second).Post(server.URL, "application/json", http.NoBody)
+	if err == nil {
+		resp.Body.Close()
+	}
+
+	if !errors.Is(err, errInternalTarget) {
+		t.Errorf("expected %v, got %v", errInternalTarget, err)
+	}
+}
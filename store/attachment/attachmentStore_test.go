@@ -0,0 +1,83 @@
+package attachment
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"gofr.dev/pkg/gofr"
+	"gofr.dev/pkg/gofr/container"
+
+	"TaskManager2/apperr"
+	"TaskManager2/models"
+	"TaskManager2/utils"
+)
+
+func TestStore_Attachments(t *testing.T) {
+	mockContainer, mock := container.NewMockContainer(t)
+	ctx := &gofr.Context{
+		Context:   t.Context(),
+		Request:   nil,
+		Container: mockContainer,
+	}
+
+	attachmentStore := New()
+	createdAt := time.Date(2026, 10, 20, 9, 0, 0, 0, time.UTC)
+	commentID := int64(4)
+	a := &models.Attachment{ID: 2, TaskID: 9, CommentID: &commentID, Filename: "notes.txt", ContentType: "text/plain", Size: 5,
+		Content: []byte("hello"), CreatedAt: createdAt}
+
+	mock.SQL.ExpectExec("INSERT INTO attachments (task_id, comment_id, filename, content_type, size, content, created_at) "+
+		"VALUES (?, ?, ?, ?, ?, ?, ?)").
+		WithArgs(9, &commentID, "notes.txt", "text/plain", 5, []byte("hello"), createdAt).
+		WillReturnResult(sqlmock.NewResult(2, 1))
+
+	id, err := attachmentStore.Create(ctx, a)
+	if err != nil || id != 2 {
+		t.Errorf("expected id 2, got %d, %v", id, err)
+	}
+
+	query := "SELECT id, task_id, comment_id, filename, content_type, size, content, created_at FROM attachments WHERE id = ?"
+	columns := []string{"id", "task_id", "comment_id", "filename", "content_type", "size", "content", "created_at"}
+
+	mock.SQL.ExpectQuery(query).WithArgs(2).
+		WillReturnRows(sqlmock.NewRows(columns).AddRow(2, 9, 4, "notes.txt", "text/plain", 5, []byte("hello"), createdAt))
+
+	got, err := attachmentStore.GetByID(ctx, 2)
+	if err != nil || !reflect.DeepEqual(got, a) {
+		t.Errorf("expected %+v, got %+v, %v", a, got, err)
+	}
+
+	mock.SQL.ExpectQuery(query).WithArgs(3).WillReturnRows(sqlmock.NewRows(columns))
+
+	_, err = attachmentStore.GetByID(ctx, 3)
+	if !errors.Is(err, apperr.NotFound("attachment", 3)) {
+		t.Errorf("expected not found, got %v", err)
+	}
+
+	listQuery := "SELECT id, task_id, comment_id, filename, content_type, size, created_at FROM attachments WHERE task_id = ? ORDER BY id"
+
+	mock.SQL.ExpectQuery(listQuery).WithArgs(9).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "task_id", "comment_id", "filename", "content_type", "size", "created_at"}).
+			AddRow(1, 9, nil, "logo.png", "image/png", 10, createdAt).
+			AddRow(2, 9, 4, "notes.txt", "text/plain", 5, createdAt))
+
+	attachments, err := attachmentStore.GetByTask(ctx, 9)
+
+	listed := *a
+	listed.Content = nil
+	expected := []models.Attachment{{ID: 1, TaskID: 9, Filename: "logo.png", ContentType: "image/png", Size: 10, CreatedAt: createdAt}, listed}
+
+	if err != nil || !reflect.DeepEqual(attachments, expected) {
+		t.Errorf("expected %+v, got %+v, %v", expected, attachments, err)
+	}
+
+	mock.SQL.ExpectQuery(listQuery).WithArgs(9).WillReturnError(utils.ErrTest)
+
+	_, err = attachmentStore.GetByTask(ctx, 9)
+	if !errors.Is(err, utils.ErrTest) {
+		t.Errorf("expected %v, got %v", utils.ErrTest, err)
+	}
+}
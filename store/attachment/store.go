@@ -0,0 +1,92 @@
+package attachment
+
+import (
+	"database/sql"
+	"errors"
+
+	"gofr.dev/pkg/gofr"
+
+	"TaskManager2/apperr"
+	"TaskManager2/models"
+	"TaskManager2/utils"
+)
+
+type store struct {
+}
+
+func New() *store {
+	return &store{}
+}
+
+func (store) Create(ctx *gofr.Context, a *models.Attachment) (int64, error) {
+	res, err := utils.DB(ctx).Exec("INSERT INTO attachments (task_id, comment_id, filename, content_type, size, content, created_at) "+
+		"VALUES (?, ?, ?, ?, ?, ?, ?)", a.TaskID, a.CommentID, a.Filename, a.ContentType, a.Size, a.Content, a.CreatedAt)
+	if err != nil {
+		return 0, err
+	}
+
+	return res.LastInsertId()
+}
+
+// GetByID returns the attachment with its content.
+func (store) GetByID(ctx *gofr.Context, id int64) (*models.Attachment, error) {
+	var (
+		a         models.Attachment
+		commentID sql.NullInt64
+	)
+
+	err := utils.DB(ctx).QueryRow("SELECT id, task_id, comment_id, filename, content_type, size, content, created_at "+
+		"FROM attachments WHERE id = ?", id).
+		Scan(&a.ID, &a.TaskID, &commentID, &a.Filename, &a.ContentType, &a.Size, &a.Content, &a.CreatedAt)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, apperr.NotFound("attachment", id)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	if commentID.Valid {
+		a.CommentID = &commentID.Int64
+	}
+
+	return &a, nil
+}
+
+// GetByTask lists the attachments of a task without their content, oldest
+// first.
+func (store) GetByTask(ctx *gofr.Context, taskID int64) ([]models.Attachment, error) {
+	rows, err := utils.DB(ctx).Query("SELECT id, task_id, comment_id, filename, content_type, size, created_at "+
+		"FROM attachments WHERE task_id = ? ORDER BY id", taskID)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	var attachments []models.Attachment
+
+	for rows.Next() {
+		var (
+			a         models.Attachment
+			commentID sql.NullInt64
+		)
+
+		err = rows.Scan(&a.ID, &a.TaskID, &commentID, &a.Filename, &a.ContentType, &a.Size, &a.CreatedAt)
+		if err != nil {
+			return nil, err
+		}
+
+		if commentID.Valid {
+			a.CommentID = &commentID.Int64
+		}
+
+		attachments = append(attachments, a)
+	}
+
+	if rows.Err() != nil {
+		return nil, rows.Err()
+	}
+
+	return attachments, nil
+}
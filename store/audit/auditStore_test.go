@@ -0,0 +1,199 @@
+package audit
+
+import (
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"gofr.dev/pkg/gofr"
+	"gofr.dev/pkg/gofr/container"
+
+	"TaskManager2/models"
+	"TaskManager2/utils"
+)
+
+func TestStore_Create(t *testing.T) {
+	mockContainer, mock := container.NewMockContainer(t)
+	ctx := &gofr.Context{
+		Context:   t.Context(),
+		Request:   nil,
+		Container: mockContainer,
+	}
+
+	auditStore := New()
+	query := "INSERT INTO audit_log (entity, entity_id, action, actor, request_id, changes, created_at) VALUES (?, ?, ?, ?, ?, ?, ?)"
+	createdAt := time.Date(2026, 10, 1, 9, 0, 0, 0, time.UTC)
+	entry := &models.AuditEntry{
+		Entity:    "task",
+		EntityID:  1,
+		Action:    "update",
+		Actor:     "7",
+		RequestID: "req-1",
+		Changes:   map[string]models.Change{"desc": {Before: "draft", After: "final"}},
+		CreatedAt: createdAt,
+	}
+
+	tests := []struct {
+		description   string
+		mockExpect    func()
+		expectedError bool
+	}{
+		{
+			description: "success",
+			mockExpect: func() {
+				mock.SQL.ExpectExec(query).
+					WithArgs("task", 1, "update", "7", "req-1", []byte(`{"desc":{"before":"draft","after":"final"}}`), createdAt).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+			},
+			expectedError: false,
+		},
+		{
+			description: "exec error",
+			mockExpect: func() {
+				mock.SQL.ExpectExec(query).WillReturnError(utils.ErrTest)
+			},
+			expectedError: true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.description, func(t *testing.T) {
+			tc.mockExpect()
+
+			err := auditStore.Create(ctx, entry)
+			if (err != nil) != tc.expectedError {
+				t.Errorf("expected err: %v, got: %v", tc.expectedError, err)
+			}
+		})
+	}
+}
+
+func TestStore_CreateBatch(t *testing.T) {
+	mockContainer, mock := container.NewMockContainer(t)
+	ctx := &gofr.Context{
+		Context:   t.Context(),
+		Request:   nil,
+		Container: mockContainer,
+	}
+
+	auditStore := New()
+	query := "INSERT INTO audit_log (entity, entity_id, action, actor, request_id, changes, created_at) " +
+		"VALUES (?, ?, ?, ?, ?, ?, ?), (?, ?, ?, ?, ?, ?, ?)"
+	createdAt := time.Date(2026, 10, 1, 9, 0, 0, 0, time.UTC)
+	entries := []*models.AuditEntry{
+		{Entity: "task", EntityID: 1, Action: "create", Actor: "7", RequestID: "req-1",
+			Changes: map[string]models.Change{"desc": {After: "new"}}, CreatedAt: createdAt},
+		{Entity: "task", EntityID: 2, Action: "delete", Actor: "7", RequestID: "req-1",
+			Changes: map[string]models.Change{"desc": {Before: "old"}}, CreatedAt: createdAt},
+	}
+
+	tests := []struct {
+		description   string
+		input         []*models.AuditEntry
+		mockExpect    func()
+		expectedError bool
+	}{
+		{
+			description: "success",
+			input:       entries,
+			mockExpect: func() {
+				mock.SQL.ExpectExec(query).
+					WithArgs("task", 1, "create", "7", "req-1", []byte(`{"desc":{"before":null,"after":"new"}}`), createdAt,
+						"task", 2, "delete", "7", "req-1", []byte(`{"desc":{"before":"old","after":null}}`), createdAt).
+					WillReturnResult(sqlmock.NewResult(1, 2))
+			},
+			expectedError: false,
+		},
+		{
+			description:   "no entries",
+			mockExpect:    func() {},
+			expectedError: false,
+		},
+		{
+			description: "exec error",
+			input:       entries,
+			mockExpect: func() {
+				mock.SQL.ExpectExec(query).WillReturnError(utils.ErrTest)
+			},
+			expectedError: true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.description, func(t *testing.T) {
+			tc.mockExpect()
+
+			err := auditStore.CreateBatch(ctx, tc.input)
+			if (err != nil) != tc.expectedError {
+				t.Errorf("expected err: %v, got: %v", tc.expectedError, err)
+			}
+		})
+	}
+
+	if err := mock.SQL.ExpectationsWereMet(); err != nil {
+		t.Errorf("unmet expectations: %v", err)
+	}
+}
+
+func TestStore_GetByEntity(t *testing.T) {
+	mockContainer, mock := container.NewMockContainer(t)
+	ctx := &gofr.Context{
+		Context:   t.Context(),
+		Request:   nil,
+		Container: mockContainer,
+	}
+
+	auditStore := New()
+	query := "SELECT id, entity, entity_id, action, actor, request_id, changes, created_at FROM audit_log " +
+		"WHERE entity = ? AND entity_id = ? ORDER BY id"
+	columns := []string{"id", "entity", "entity_id", "action", "actor", "request_id", "changes", "created_at"}
+
+	tests := []struct {
+		description   string
+		mockExpect    func()
+		wantLen       int
+		expectedError bool
+	}{
+		{
+			description: "success",
+			mockExpect: func() {
+				rows := sqlmock.NewRows(columns).
+					AddRow(1, "task", 1, "create", "7", "req-1", `{"desc":{"before":null,"after":"draft"}}`, time.Now()).
+					AddRow(2, "task", 1, "update", "7", "req-2", `{"desc":{"before":"draft","after":"final"}}`, time.Now())
+				mock.SQL.ExpectQuery(query).WithArgs("task", 1).WillReturnRows(rows)
+			},
+			wantLen:       2,
+			expectedError: false,
+		},
+		{
+			description: "query error",
+			mockExpect: func() {
+				mock.SQL.ExpectQuery(query).WithArgs("task", 1).WillReturnError(utils.ErrTest)
+			},
+			expectedError: true,
+		},
+		{
+			description: "invalid changes",
+			mockExpect: func() {
+				rows := sqlmock.NewRows(columns).AddRow(1, "task", 1, "create", "", "", `{`, time.Now())
+				mock.SQL.ExpectQuery(query).WithArgs("task", 1).WillReturnRows(rows)
+			},
+			expectedError: true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.description, func(t *testing.T) {
+			tc.mockExpect()
+
+			entries, err := auditStore.GetByEntity(ctx, "task", 1)
+			if (err != nil) != tc.expectedError {
+				t.Errorf("expected error = %v, got = %v", tc.expectedError, err)
+			}
+
+			if len(entries) != tc.wantLen {
+				t.Errorf("expected entry count = %d, got = %d", tc.wantLen, len(entries))
+			}
+		})
+	}
+}
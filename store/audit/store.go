@@ -0,0 +1,97 @@
+package audit
+
+import (
+	"encoding/json"
+	"strings"
+
+	"gofr.dev/pkg/gofr"
+
+	"TaskManager2/models"
+	"TaskManager2/utils"
+)
+
+// store only ever appends to audit_log; the table's triggers reject updates and deletes.
+type store struct {
+}
+
+func New() *store {
+	return &store{}
+}
+
+func (store) Create(ctx *gofr.Context, e *models.AuditEntry) error {
+	db := utils.DB(ctx)
+
+	changes, err := json.Marshal(e.Changes)
+	if err != nil {
+		return err
+	}
+
+	_, err = db.Exec("INSERT INTO audit_log (entity, entity_id, action, actor, request_id, changes, created_at) "+
+		"VALUES (?, ?, ?, ?, ?, ?, ?)", e.Entity, e.EntityID, e.Action, e.Actor, e.RequestID, changes, e.CreatedAt)
+
+	return err
+}
+
+// CreateBatch appends several entries with a single multi-row insert.
+func (store) CreateBatch(ctx *gofr.Context, entries []*models.AuditEntry) error {
+	if len(entries) == 0 {
+		return nil
+	}
+
+	values := make([]string, len(entries))
+	args := make([]any, 0, 7*len(entries))
+
+	for i, e := range entries {
+		changes, err := json.Marshal(e.Changes)
+		if err != nil {
+			return err
+		}
+
+		values[i] = "(?, ?, ?, ?, ?, ?, ?)"
+		args = append(args, e.Entity, e.EntityID, e.Action, e.Actor, e.RequestID, changes, e.CreatedAt)
+	}
+
+	_, err := utils.DB(ctx).Exec("INSERT INTO audit_log (entity, entity_id, action, actor, request_id, changes, created_at) VALUES "+
+		strings.Join(values, ", "), args...)
+
+	return err
+}
+
+func (store) GetByEntity(ctx *gofr.Context, entity string, id int64) ([]models.AuditEntry, error) {
+	db := utils.DB(ctx)
+
+	rows, err := db.Query("SELECT id, entity, entity_id, action, actor, request_id, changes, created_at FROM audit_log "+
+		"WHERE entity = ? AND entity_id = ? ORDER BY id", entity, id)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	var entries []models.AuditEntry
+
+	for rows.Next() {
+		var (
+			e       models.AuditEntry
+			changes []byte
+		)
+
+		err = rows.Scan(&e.ID, &e.Entity, &e.EntityID, &e.Action, &e.Actor, &e.RequestID, &changes, &e.CreatedAt)
+		if err != nil {
+			return nil, err
+		}
+
+		err = json.Unmarshal(changes, &e.Changes)
+		if err != nil {
+			return nil, err
+		}
+
+		entries = append(entries, e)
+	}
+
+	if rows.Err() != nil {
+		return nil, rows.Err()
+	}
+
+	return entries, nil
+}
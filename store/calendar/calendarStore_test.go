@@ -0,0 +1,103 @@
+package calendar
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/go-sql-driver/mysql"
+	"gofr.dev/pkg/gofr"
+	"gofr.dev/pkg/gofr/container"
+
+	"TaskManager2/apperr"
+)
+
+func TestStore_Tokens(t *testing.T) {
+	mockContainer, mock := container.NewMockContainer(t)
+	ctx := &gofr.Context{
+		Context:   t.Context(),
+		Request:   nil,
+		Container: mockContainer,
+	}
+
+	calendarStore := New()
+	createdAt := time.Date(2026, 10, 20, 11, 0, 0, 0, time.UTC)
+
+	mock.SQL.ExpectExec("INSERT INTO calendar_tokens (user_id, token_hash, created_at) VALUES (?, ?, ?) "+
+		"ON DUPLICATE KEY UPDATE token_hash = VALUES(token_hash), created_at = VALUES(created_at)").
+		WithArgs(1, "hash", createdAt).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	err := calendarStore.SaveToken(ctx, 1, "hash", createdAt)
+	if err != nil {
+		t.Error(err)
+	}
+
+	mock.SQL.ExpectQuery("SELECT user_id FROM calendar_tokens WHERE token_hash = ?").WithArgs("hash").
+		WillReturnRows(sqlmock.NewRows([]string{"user_id"}).AddRow(1))
+
+	userID, err := calendarStore.GetUser(ctx, "hash")
+	if err != nil || userID != 1 {
+		t.Errorf("expected user 1, got %d, %v", userID, err)
+	}
+
+	mock.SQL.ExpectQuery("SELECT user_id FROM calendar_tokens WHERE token_hash = ?").WithArgs("other").
+		WillReturnRows(sqlmock.NewRows([]string{"user_id"}))
+
+	userID, err = calendarStore.GetUser(ctx, "other")
+	if err != nil || userID != 0 {
+		t.Errorf("expected no user, got %d, %v", userID, err)
+	}
+
+	mock.SQL.ExpectExec("DELETE FROM calendar_tokens WHERE user_id = ?").WithArgs(1).WillReturnResult(sqlmock.NewResult(0, 1))
+
+	err = calendarStore.DeleteToken(ctx, 1)
+	if err != nil {
+		t.Error(err)
+	}
+}
+
+func TestStore_Imports(t *testing.T) {
+	mockContainer, mock := container.NewMockContainer(t)
+	ctx := &gofr.Context{
+		Context:   t.Context(),
+		Request:   nil,
+		Container: mockContainer,
+	}
+
+	calendarStore := New()
+	createdAt := time.Date(2026, 10, 20, 11, 0, 0, 0, time.UTC)
+
+	imported, err := calendarStore.Imported(ctx, 1, nil)
+	if err != nil || len(imported) != 0 {
+		t.Errorf("expected nothing imported, got %v, %v", imported, err)
+	}
+
+	mock.SQL.ExpectQuery("SELECT uid FROM calendar_imports WHERE user_id = ? AND uid IN (?, ?)").WithArgs(1, "a", "b").
+		WillReturnRows(sqlmock.NewRows([]string{"uid"}).AddRow("b"))
+
+	imported, err = calendarStore.Imported(ctx, 1, []string{"a", "b"})
+	if err != nil || !reflect.DeepEqual(imported, map[string]bool{"b": true}) {
+		t.Errorf("expected b imported, got %v, %v", imported, err)
+	}
+
+	mock.SQL.ExpectExec("INSERT INTO calendar_imports (user_id, uid, task_id, created_at) VALUES (?, ?, ?, ?)").
+		WithArgs(1, "a", 9, createdAt).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	err = calendarStore.RecordImport(ctx, 1, "a", 9, createdAt)
+	if err != nil {
+		t.Error(err)
+	}
+
+	mock.SQL.ExpectExec("INSERT INTO calendar_imports (user_id, uid, task_id, created_at) VALUES (?, ?, ?, ?)").
+		WithArgs(1, "a", 9, createdAt).
+		WillReturnError(&mysql.MySQLError{Number: errDuplicateEntry})
+
+	err = calendarStore.RecordImport(ctx, 1, "a", 9, createdAt)
+	if !errors.Is(err, apperr.Conflict("calendar entry a was already imported")) {
+		t.Errorf("expected conflict, got %v", err)
+	}
+}
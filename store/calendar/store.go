@@ -0,0 +1,111 @@
+package calendar
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/go-sql-driver/mysql"
+	"gofr.dev/pkg/gofr"
+
+	"TaskManager2/apperr"
+	"TaskManager2/utils"
+)
+
+const errDuplicateEntry = 1062
+
+type store struct {
+}
+
+func New() *store {
+	return &store{}
+}
+
+// SaveToken sets the token of the user, replacing the one it had.
+func (store) SaveToken(ctx *gofr.Context, userID int64, hash string, createdAt time.Time) error {
+	_, err := utils.DB(ctx).Exec("INSERT INTO calendar_tokens (user_id, token_hash, created_at) VALUES (?, ?, ?) "+
+		"ON DUPLICATE KEY UPDATE token_hash = VALUES(token_hash), created_at = VALUES(created_at)", userID, hash, createdAt)
+
+	return err
+}
+
+func (store) DeleteToken(ctx *gofr.Context, userID int64) error {
+	_, err := utils.DB(ctx).Exec("DELETE FROM calendar_tokens WHERE user_id = ?", userID)
+
+	return err
+}
+
+// GetUser returns the user with the token hash, or 0 when there is none.
+func (store) GetUser(ctx *gofr.Context, hash string) (int64, error) {
+	var userID int64
+
+	err := utils.DB(ctx).QueryRow("SELECT user_id FROM calendar_tokens WHERE token_hash = ?", hash).Scan(&userID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return 0, nil
+	}
+
+	return userID, err
+}
+
+// Imported returns which of the UIDs the user has imported before.
+func (store) Imported(ctx *gofr.Context, userID int64, uids []string) (map[string]bool, error) {
+	imported := make(map[string]bool)
+	if len(uids) == 0 {
+		return imported, nil
+	}
+
+	args := make([]any, 0, len(uids)+1)
+	args = append(args, userID)
+
+	for _, uid := range uids {
+		args = append(args, uid)
+	}
+
+	rows, err := utils.DB(ctx).Query("SELECT uid FROM calendar_imports WHERE user_id = ? AND uid IN ("+placeholders(len(uids))+")", args...)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	for rows.Next() {
+		var uid string
+
+		err = rows.Scan(&uid)
+		if err != nil {
+			return nil, err
+		}
+
+		imported[uid] = true
+	}
+
+	if rows.Err() != nil {
+		return nil, rows.Err()
+	}
+
+	return imported, nil
+}
+
+// RecordImport remembers that the entry with the UID became the task. An
+// entry imported before is a conflict.
+func (store) RecordImport(ctx *gofr.Context, userID int64, uid string, taskID int64, createdAt time.Time) error {
+	_, err := utils.DB(ctx).Exec("INSERT INTO calendar_imports (user_id, uid, task_id, created_at) VALUES (?, ?, ?, ?)",
+		userID, uid, taskID, createdAt)
+	if isDuplicateKey(err) {
+		return apperr.Conflict(fmt.Sprintf("calendar entry %s was already imported", uid))
+	}
+
+	return err
+}
+
+func placeholders(n int) string {
+	return strings.TrimSuffix(strings.Repeat("?, ", n), ", ")
+}
+
+func isDuplicateKey(err error) bool {
+	var mysqlErr *mysql.MySQLError
+
+	return errors.As(err, &mysqlErr) && mysqlErr.Number == errDuplicateEntry
+}
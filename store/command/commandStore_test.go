@@ -0,0 +1,72 @@
+package command
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/go-sql-driver/mysql"
+	"gofr.dev/pkg/gofr"
+	"gofr.dev/pkg/gofr/container"
+
+	"TaskManager2/utils"
+)
+
+func TestStore_MarkProcessed(t *testing.T) {
+	mockContainer, mock := container.NewMockContainer(t)
+	ctx := &gofr.Context{
+		Context:   t.Context(),
+		Request:   nil,
+		Container: mockContainer,
+	}
+
+	commandStore := New()
+	now := time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC)
+	insert := "INSERT INTO processed_commands (message_id, processed_at) VALUES (?, ?)"
+	duplicate := &mysql.MySQLError{Number: 1062, Message: "Duplicate entry 'm1' for key 'PRIMARY'"}
+
+	tests := []struct {
+		description string
+		result      error
+		expected    bool
+		expectedErr error
+	}{
+		{"first delivery", nil, true, nil},
+		{"redelivery", duplicate, false, nil},
+		{"db error", utils.ErrTest, false, utils.ErrTest},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.description, func(t *testing.T) {
+			if tc.result == nil {
+				mock.SQL.ExpectExec(insert).WithArgs("m1", now).WillReturnResult(sqlmock.NewResult(0, 1))
+			} else {
+				mock.SQL.ExpectExec(insert).WithArgs("m1", now).WillReturnError(tc.result)
+			}
+
+			processed, err := commandStore.MarkProcessed(ctx, "m1", now)
+			if processed != tc.expected || !errors.Is(err, tc.expectedErr) {
+				t.Errorf("expected %v, %v, got %v, %v", tc.expected, tc.expectedErr, processed, err)
+			}
+		})
+	}
+}
+
+func TestStore_Purge(t *testing.T) {
+	mockContainer, mock := container.NewMockContainer(t)
+	ctx := &gofr.Context{
+		Context:   t.Context(),
+		Request:   nil,
+		Container: mockContainer,
+	}
+
+	now := time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC)
+
+	mock.SQL.ExpectExec("DELETE FROM processed_commands WHERE processed_at < ?").WithArgs(now).WillReturnResult(sqlmock.NewResult(0, 3))
+
+	purged, err := New().Purge(ctx, now)
+	if err != nil || purged != 3 {
+		t.Errorf("expected 3 purged, got %d, %v", purged, err)
+	}
+}
@@ -0,0 +1,54 @@
+package command
+
+import (
+	"errors"
+	"time"
+
+	"github.com/go-sql-driver/mysql"
+	"gofr.dev/pkg/gofr"
+
+	"TaskManager2/utils"
+)
+
+// errDuplicateEntry is the MySQL error number for a unique key violation.
+const errDuplicateEntry = 1062
+
+type store struct {
+}
+
+func New() *store {
+	return &store{}
+}
+
+// MarkProcessed records that the message was applied and reports false when
+// it already had been. Within the transaction that applies the message, a
+// concurrent consumer of the same message waits on the primary key and then
+// sees it as processed.
+func (store) MarkProcessed(ctx *gofr.Context, messageID string, at time.Time) (bool, error) {
+	_, err := utils.DB(ctx).Exec("INSERT INTO processed_commands (message_id, processed_at) VALUES (?, ?)", messageID, at)
+	if err == nil {
+		return true, nil
+	}
+
+	if isDuplicateKey(err) {
+		return false, nil
+	}
+
+	return false, err
+}
+
+// Purge forgets the messages processed before the given time.
+func (store) Purge(ctx *gofr.Context, before time.Time) (int64, error) {
+	res, err := utils.DB(ctx).Exec("DELETE FROM processed_commands WHERE processed_at < ?", before)
+	if err != nil {
+		return 0, err
+	}
+
+	return res.RowsAffected()
+}
+
+func isDuplicateKey(err error) bool {
+	var mysqlErr *mysql.MySQLError
+
+	return errors.As(err, &mysqlErr) && mysqlErr.Number == errDuplicateEntry
+}
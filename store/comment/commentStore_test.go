@@ -0,0 +1,128 @@
+package comment
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"gofr.dev/pkg/gofr"
+	"gofr.dev/pkg/gofr/container"
+
+	"TaskManager2/models"
+	"TaskManager2/utils"
+)
+
+func TestStore_Create(t *testing.T) {
+	mockContainer, mock := container.NewMockContainer(t)
+	ctx := &gofr.Context{
+		Context:   t.Context(),
+		Request:   nil,
+		Container: mockContainer,
+	}
+
+	commentStore := New()
+	query := "INSERT INTO task_comments (task_id, author, body, created_at) VALUES (?, ?, ?, ?)"
+	createdAt := time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC)
+	c := &models.Comment{TaskID: 3, Author: "7", Body: "Looks good", CreatedAt: createdAt}
+
+	tests := []struct {
+		description   string
+		mockExpect    func()
+		expected      int64
+		expectedError bool
+	}{
+		{
+			description: "success",
+			mockExpect: func() {
+				mock.SQL.ExpectExec(query).WithArgs(3, "7", "Looks good", createdAt).WillReturnResult(sqlmock.NewResult(5, 1))
+			},
+			expected: 5,
+		},
+		{
+			description: "exec error",
+			mockExpect: func() {
+				mock.SQL.ExpectExec(query).WillReturnError(utils.ErrTest)
+			},
+			expectedError: true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.description, func(t *testing.T) {
+			tc.mockExpect()
+
+			id, err := commentStore.Create(ctx, c)
+			if (err != nil) != tc.expectedError {
+				t.Errorf("expected err: %v, got: %v", tc.expectedError, err)
+			}
+
+			if id != tc.expected {
+				t.Errorf("expected id %d, got %d", tc.expected, id)
+			}
+		})
+	}
+}
+
+func TestStore_GetByTask(t *testing.T) {
+	mockContainer, mock := container.NewMockContainer(t)
+	ctx := &gofr.Context{
+		Context:   t.Context(),
+		Request:   nil,
+		Container: mockContainer,
+	}
+
+	commentStore := New()
+	query := "SELECT id, task_id, author, body, created_at FROM task_comments WHERE task_id = ? ORDER BY id"
+	columns := []string{"id", "task_id", "author", "body", "created_at"}
+	createdAt := time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		description   string
+		mockExpect    func()
+		expected      []models.Comment
+		expectedError bool
+	}{
+		{
+			description: "success",
+			mockExpect: func() {
+				mock.SQL.ExpectQuery(query).WithArgs(3).WillReturnRows(sqlmock.NewRows(columns).
+					AddRow(1, 3, "7", "First", createdAt).
+					AddRow(2, 3, "8", "Second", createdAt))
+			},
+			expected: []models.Comment{
+				{ID: 1, TaskID: 3, Author: "7", Body: "First", CreatedAt: createdAt},
+				{ID: 2, TaskID: 3, Author: "8", Body: "Second", CreatedAt: createdAt},
+			},
+		},
+		{
+			description: "query error",
+			mockExpect: func() {
+				mock.SQL.ExpectQuery(query).WillReturnError(utils.ErrTest)
+			},
+			expectedError: true,
+		},
+		{
+			description: "scan error",
+			mockExpect: func() {
+				mock.SQL.ExpectQuery(query).WillReturnRows(sqlmock.NewRows(columns).AddRow("x", 3, "7", "First", createdAt))
+			},
+			expectedError: true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.description, func(t *testing.T) {
+			tc.mockExpect()
+
+			comments, err := commentStore.GetByTask(ctx, 3)
+			if (err != nil) != tc.expectedError {
+				t.Errorf("expected err: %v, got: %v", tc.expectedError, err)
+			}
+
+			if !reflect.DeepEqual(comments, tc.expected) {
+				t.Errorf("expected %v, got %v", tc.expected, comments)
+			}
+		})
+	}
+}
@@ -0,0 +1,63 @@
+package comment
+
+import (
+	"gofr.dev/pkg/gofr"
+
+	"TaskManager2/models"
+	"TaskManager2/utils"
+)
+
+type store struct {
+}
+
+func New() *store {
+	return &store{}
+}
+
+func (store) Create(ctx *gofr.Context, c *models.Comment) (int64, error) {
+	db := utils.DB(ctx)
+
+	res, err := db.Exec("INSERT INTO task_comments (task_id, author, body, created_at) VALUES (?, ?, ?, ?)",
+		c.TaskID, c.Author, c.Body, c.CreatedAt)
+	if err != nil {
+		return 0, err
+	}
+
+	id, err := res.LastInsertId()
+	if err != nil {
+		return 0, err
+	}
+
+	return id, nil
+}
+
+// GetByTask lists the comments on a task, oldest first.
+func (store) GetByTask(ctx *gofr.Context, taskID int64) ([]models.Comment, error) {
+	db := utils.DB(ctx)
+
+	rows, err := db.Query("SELECT id, task_id, author, body, created_at FROM task_comments WHERE task_id = ? ORDER BY id", taskID)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	var comments []models.Comment
+
+	for rows.Next() {
+		var c models.Comment
+
+		err = rows.Scan(&c.ID, &c.TaskID, &c.Author, &c.Body, &c.CreatedAt)
+		if err != nil {
+			return nil, err
+		}
+
+		comments = append(comments, c)
+	}
+
+	if rows.Err() != nil {
+		return nil, rows.Err()
+	}
+
+	return comments, nil
+}
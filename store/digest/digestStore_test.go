@@ -0,0 +1,196 @@
+package digest
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/go-sql-driver/mysql"
+	"gofr.dev/pkg/gofr"
+	"gofr.dev/pkg/gofr/container"
+
+	"TaskManager2/models"
+	"TaskManager2/utils"
+)
+
+var columns = []string{"id", "user_id", "frequency", "period_start", "period_end", "status", "attempts", "claimed_until", "last_error",
+	"sent_at", "created_at"}
+
+func TestStore_Preferences(t *testing.T) {
+	mockContainer, mock := container.NewMockContainer(t)
+	ctx := &gofr.Context{
+		Context:   t.Context(),
+		Request:   nil,
+		Container: mockContainer,
+	}
+
+	digestStore := New()
+	updatedAt := time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC)
+	p := &models.DigestPreference{UserID: 1, Frequency: "weekly", TimeOfDay: "08:00", Timezone: "Europe/Berlin", Weekday: "monday",
+		UpdatedAt: updatedAt}
+	query := "SELECT user_id, frequency, time_of_day, timezone, weekday, updated_at FROM digest_preferences WHERE user_id = ?"
+
+	mock.SQL.ExpectQuery(query).WithArgs(1).
+		WillReturnRows(sqlmock.NewRows([]string{"user_id", "frequency", "time_of_day", "timezone", "weekday", "updated_at"}))
+
+	got, err := digestStore.GetPreference(ctx, 1)
+	if err != nil || got != nil {
+		t.Errorf("expected no preference, got %+v, %v", got, err)
+	}
+
+	mock.SQL.ExpectExec("INSERT INTO digest_preferences (user_id, frequency, time_of_day, timezone, weekday, updated_at) "+
+		"VALUES (?, ?, ?, ?, ?, ?) ON DUPLICATE KEY UPDATE frequency = VALUES(frequency), time_of_day = VALUES(time_of_day), "+
+		"timezone = VALUES(timezone), weekday = VALUES(weekday), updated_at = VALUES(updated_at)").
+		WithArgs(1, "weekly", "08:00", "Europe/Berlin", "monday", updatedAt).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	err = digestStore.SavePreference(ctx, p)
+	if err != nil {
+		t.Error(err)
+	}
+
+	mock.SQL.ExpectQuery(query).WithArgs(1).
+		WillReturnRows(sqlmock.NewRows([]string{"user_id", "frequency", "time_of_day", "timezone", "weekday", "updated_at"}).
+			AddRow(1, "weekly", "08:00", "Europe/Berlin", "monday", updatedAt))
+
+	got, err = digestStore.GetPreference(ctx, 1)
+	if err != nil || !reflect.DeepEqual(got, p) {
+		t.Errorf("expected %+v, got %+v, %v", p, got, err)
+	}
+
+	mock.SQL.ExpectQuery("SELECT p.user_id, p.frequency, p.time_of_day, p.timezone, p.weekday, p.updated_at, "+
+		"(SELECT MAX(d.period_end) FROM digests d WHERE d.user_id = p.user_id AND d.status <> ?) "+
+		"FROM digest_preferences p WHERE p.frequency <> ? ORDER BY p.user_id").
+		WithArgs("pending", "off").
+		WillReturnRows(sqlmock.NewRows([]string{"user_id", "frequency", "time_of_day", "timezone", "weekday", "updated_at", "last"}).
+			AddRow(1, "weekly", "08:00", "Europe/Berlin", "monday", updatedAt, updatedAt).
+			AddRow(2, "daily", "07:30", "UTC", "", updatedAt, nil))
+
+	scheduled, err := digestStore.Scheduled(ctx)
+
+	first := *p
+	first.LastDigest = &updatedAt
+	expected := []models.DigestPreference{first, {UserID: 2, Frequency: "daily", TimeOfDay: "07:30", Timezone: "UTC", UpdatedAt: updatedAt}}
+
+	if err != nil || !reflect.DeepEqual(scheduled, expected) {
+		t.Errorf("expected %+v, got %+v, %v", expected, scheduled, err)
+	}
+}
+
+func TestStore_Claim(t *testing.T) {
+	mockContainer, mock := container.NewMockContainer(t)
+	ctx := &gofr.Context{
+		Context:   t.Context(),
+		Request:   nil,
+		Container: mockContainer,
+	}
+
+	digestStore := New()
+	now := time.Date(2026, 10, 19, 8, 0, 30, 0, time.UTC)
+	at := time.Date(2026, 10, 19, 8, 0, 0, 0, time.UTC)
+	until := now.Add(5 * time.Minute)
+	insert := "INSERT INTO digests (user_id, frequency, period_start, period_end, status, attempts, claimed_until, created_at) " +
+		"VALUES (?, ?, ?, ?, ?, ?, ?, ?)"
+	update := "UPDATE digests SET claimed_until = ? WHERE user_id = ? AND period_end = ? AND status = ? AND claimed_until <= ?"
+	duplicate := &mysql.MySQLError{Number: 1062, Message: "Duplicate entry '1-2026-10-19 08:00:00' for key 'uq_digests_period'"}
+	newDigest := func() *models.Digest {
+		return &models.Digest{UserID: 1, Frequency: "daily", PeriodStart: at.AddDate(0, 0, -1), PeriodEnd: at, ClaimedUntil: until}
+	}
+
+	tests := []struct {
+		description   string
+		mockExpect    func()
+		claimed       bool
+		expected      *models.Digest
+		expectedError error
+	}{
+		{
+			description: "new digest",
+			mockExpect: func() {
+				mock.SQL.ExpectExec(insert).WithArgs(1, "daily", at.AddDate(0, 0, -1), at, "pending", 0, until, now).
+					WillReturnResult(sqlmock.NewResult(7, 1))
+			},
+			claimed: true,
+			expected: &models.Digest{ID: 7, UserID: 1, Frequency: "daily", PeriodStart: at.AddDate(0, 0, -1), PeriodEnd: at,
+				Status: "pending", ClaimedUntil: until, CreatedAt: now},
+		},
+		{
+			description: "pending digest to retry",
+			mockExpect: func() {
+				mock.SQL.ExpectExec(insert).WillReturnError(duplicate)
+				mock.SQL.ExpectExec(update).WithArgs(until, 1, at, "pending", now).WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.SQL.ExpectQuery("SELECT "+digestColumns+" FROM digests WHERE user_id = ? AND period_end = ?").WithArgs(1, at).
+					WillReturnRows(sqlmock.NewRows(columns).AddRow(7, 1, "daily", at.AddDate(0, 0, -1), at, "pending", 1, until, "timeout", nil, at))
+			},
+			claimed: true,
+			expected: &models.Digest{ID: 7, UserID: 1, Frequency: "daily", PeriodStart: at.AddDate(0, 0, -1), PeriodEnd: at,
+				Status: "pending", Attempts: 1, ClaimedUntil: until, LastError: "timeout", CreatedAt: at},
+		},
+		{
+			description: "claimed by another replica",
+			mockExpect: func() {
+				mock.SQL.ExpectExec(insert).WillReturnError(duplicate)
+				mock.SQL.ExpectExec(update).WillReturnResult(sqlmock.NewResult(0, 0))
+			},
+			expected: newDigest(),
+		},
+		{
+			description: "insert error",
+			mockExpect: func() {
+				mock.SQL.ExpectExec(insert).WillReturnError(utils.ErrTest)
+			},
+			expected:      newDigest(),
+			expectedError: utils.ErrTest,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.description, func(t *testing.T) {
+			tc.mockExpect()
+
+			d := newDigest()
+
+			claimed, err := digestStore.Claim(ctx, d, now)
+			if !errors.Is(err, tc.expectedError) || claimed != tc.claimed {
+				t.Errorf("expected %v, %v, got %v, %v", tc.claimed, tc.expectedError, claimed, err)
+			}
+
+			if !reflect.DeepEqual(d, tc.expected) {
+				t.Errorf("expected %+v, got %+v", tc.expected, d)
+			}
+		})
+	}
+}
+
+func TestStore_Digests(t *testing.T) {
+	mockContainer, mock := container.NewMockContainer(t)
+	ctx := &gofr.Context{
+		Context:   t.Context(),
+		Request:   nil,
+		Container: mockContainer,
+	}
+
+	digestStore := New()
+	at := time.Date(2026, 10, 19, 8, 0, 0, 0, time.UTC)
+	sent := &models.Digest{ID: 7, UserID: 1, Frequency: "daily", PeriodStart: at.AddDate(0, 0, -1), PeriodEnd: at, Status: "sent",
+		Attempts: 1, ClaimedUntil: at, SentAt: &at, CreatedAt: at}
+
+	mock.SQL.ExpectExec("UPDATE digests SET status = ?, attempts = ?, claimed_until = ?, last_error = ?, sent_at = ? WHERE id = ?").
+		WithArgs("sent", 1, at, "", &at, 7).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	err := digestStore.SaveAttempt(ctx, sent)
+	if err != nil {
+		t.Error(err)
+	}
+
+	mock.SQL.ExpectQuery("SELECT "+digestColumns+" FROM digests WHERE user_id = ? ORDER BY period_end DESC LIMIT ?").WithArgs(1, 20).
+		WillReturnRows(sqlmock.NewRows(columns).AddRow(7, 1, "daily", at.AddDate(0, 0, -1), at, "sent", 1, at, "", at, at))
+
+	digests, err := digestStore.GetByUser(ctx, 1, 20)
+	if err != nil || !reflect.DeepEqual(digests, []models.Digest{*sent}) {
+		t.Errorf("expected %+v, got %+v, %v", []models.Digest{*sent}, digests, err)
+	}
+}
@@ -0,0 +1,193 @@
+package digest
+
+import (
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/go-sql-driver/mysql"
+	"gofr.dev/pkg/gofr"
+
+	"TaskManager2/models"
+	"TaskManager2/utils"
+)
+
+const errDuplicateEntry = 1062
+
+const digestColumns = "id, user_id, frequency, period_start, period_end, status, attempts, claimed_until, last_error, sent_at, created_at"
+
+type store struct {
+}
+
+func New() *store {
+	return &store{}
+}
+
+// GetPreference returns the digest preference of the user, or nil when the
+// user has none.
+func (store) GetPreference(ctx *gofr.Context, userID int64) (*models.DigestPreference, error) {
+	var p models.DigestPreference
+
+	err := utils.DB(ctx).QueryRow("SELECT user_id, frequency, time_of_day, timezone, weekday, updated_at FROM digest_preferences "+
+		"WHERE user_id = ?", userID).Scan(&p.UserID, &p.Frequency, &p.TimeOfDay, &p.Timezone, &p.Weekday, &p.UpdatedAt)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &p, nil
+}
+
+func (store) SavePreference(ctx *gofr.Context, p *models.DigestPreference) error {
+	_, err := utils.DB(ctx).Exec("INSERT INTO digest_preferences (user_id, frequency, time_of_day, timezone, weekday, updated_at) "+
+		"VALUES (?, ?, ?, ?, ?, ?) ON DUPLICATE KEY UPDATE frequency = VALUES(frequency), time_of_day = VALUES(time_of_day), "+
+		"timezone = VALUES(timezone), weekday = VALUES(weekday), updated_at = VALUES(updated_at)",
+		p.UserID, p.Frequency, p.TimeOfDay, p.Timezone, p.Weekday, p.UpdatedAt)
+
+	return err
+}
+
+// Scheduled lists the preferences of the users who get digests, each with the
+// end of the period of their last digest that is no longer pending.
+func (store) Scheduled(ctx *gofr.Context) ([]models.DigestPreference, error) {
+	rows, err := utils.DB(ctx).Query("SELECT p.user_id, p.frequency, p.time_of_day, p.timezone, p.weekday, p.updated_at, "+
+		"(SELECT MAX(d.period_end) FROM digests d WHERE d.user_id = p.user_id AND d.status <> ?) "+
+		"FROM digest_preferences p WHERE p.frequency <> ? ORDER BY p.user_id", models.DigestPending, models.DigestOff)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	var preferences []models.DigestPreference
+
+	for rows.Next() {
+		var (
+			p    models.DigestPreference
+			last sql.NullTime
+		)
+
+		err = rows.Scan(&p.UserID, &p.Frequency, &p.TimeOfDay, &p.Timezone, &p.Weekday, &p.UpdatedAt, &last)
+		if err != nil {
+			return nil, err
+		}
+
+		if last.Valid {
+			p.LastDigest = &last.Time
+		}
+
+		preferences = append(preferences, p)
+	}
+
+	if rows.Err() != nil {
+		return nil, rows.Err()
+	}
+
+	return preferences, nil
+}
+
+// Claim records a new pending digest claimed until d.ClaimedUntil, or claims
+// the recorded one for the same user and period when it is still pending and
+// its claim has run out at now. On success d holds the recorded digest.
+func (store) Claim(ctx *gofr.Context, d *models.Digest, now time.Time) (bool, error) {
+	db := utils.DB(ctx)
+
+	res, err := db.Exec("INSERT INTO digests (user_id, frequency, period_start, period_end, status, attempts, claimed_until, created_at) "+
+		"VALUES (?, ?, ?, ?, ?, ?, ?, ?)", d.UserID, d.Frequency, d.PeriodStart, d.PeriodEnd, models.DigestPending, 0, d.ClaimedUntil, now)
+	if err == nil {
+		d.ID, err = res.LastInsertId()
+		d.Status = models.DigestPending
+		d.CreatedAt = now
+
+		return true, err
+	}
+
+	if !isDuplicateKey(err) {
+		return false, err
+	}
+
+	res, err = db.Exec("UPDATE digests SET claimed_until = ? WHERE user_id = ? AND period_end = ? AND status = ? AND claimed_until <= ?",
+		d.ClaimedUntil, d.UserID, d.PeriodEnd, models.DigestPending, now)
+	if err != nil {
+		return false, err
+	}
+
+	claimed, err := res.RowsAffected()
+	if err != nil || claimed == 0 {
+		return false, err
+	}
+
+	err = scanDigest(db.QueryRow("SELECT "+digestColumns+" FROM digests WHERE user_id = ? AND period_end = ?", d.UserID, d.PeriodEnd), d)
+	if err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+func (store) SaveAttempt(ctx *gofr.Context, d *models.Digest) error {
+	_, err := utils.DB(ctx).Exec("UPDATE digests SET status = ?, attempts = ?, claimed_until = ?, last_error = ?, sent_at = ? WHERE id = ?",
+		d.Status, d.Attempts, d.ClaimedUntil, d.LastError, d.SentAt, d.ID)
+
+	return err
+}
+
+// GetByUser lists up to limit digests of the user, newest first.
+func (store) GetByUser(ctx *gofr.Context, userID int64, limit int) ([]models.Digest, error) {
+	rows, err := utils.DB(ctx).Query("SELECT "+digestColumns+" FROM digests WHERE user_id = ? ORDER BY period_end DESC LIMIT ?",
+		userID, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	var digests []models.Digest
+
+	for rows.Next() {
+		var d models.Digest
+
+		err = scanDigest(rows, &d)
+		if err != nil {
+			return nil, err
+		}
+
+		digests = append(digests, d)
+	}
+
+	if rows.Err() != nil {
+		return nil, rows.Err()
+	}
+
+	return digests, nil
+}
+
+type scanner interface {
+	Scan(dest ...any) error
+}
+
+func scanDigest(row scanner, d *models.Digest) error {
+	var sentAt sql.NullTime
+
+	err := row.Scan(&d.ID, &d.UserID, &d.Frequency, &d.PeriodStart, &d.PeriodEnd, &d.Status, &d.Attempts, &d.ClaimedUntil,
+		&d.LastError, &sentAt, &d.CreatedAt)
+	if err != nil {
+		return err
+	}
+
+	d.SentAt = nil
+	if sentAt.Valid {
+		d.SentAt = &sentAt.Time
+	}
+
+	return nil
+}
+
+func isDuplicateKey(err error) bool {
+	var mysqlErr *mysql.MySQLError
+
+	return errors.As(err, &mysqlErr) && mysqlErr.Number == errDuplicateEntry
+}
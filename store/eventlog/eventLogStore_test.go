@@ -0,0 +1,72 @@
+package eventlog
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"gofr.dev/pkg/gofr"
+	"gofr.dev/pkg/gofr/container"
+
+	"TaskManager2/models"
+)
+
+func TestStore(t *testing.T) {
+	mockContainer, mock := container.NewMockContainer(t)
+	ctx := &gofr.Context{
+		Context:   t.Context(),
+		Request:   nil,
+		Container: mockContainer,
+	}
+
+	eventLogStore := New()
+	now := time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC)
+	parentID := int64(2)
+	payload := []byte(`{"id":"e1"}`)
+
+	mock.SQL.ExpectExec("INSERT INTO event_log (event, task_id, user_id, parent_id, payload, created_at) VALUES (?, ?, ?, ?, ?, ?)").
+		WithArgs("task.created", 9, 1, &parentID, payload, now).
+		WillReturnResult(sqlmock.NewResult(41, 1))
+
+	id, err := eventLogStore.Append(ctx, &models.StreamEvent{Event: "task.created", TaskID: 9, UserID: 1, ParentID: &parentID,
+		Payload: payload, CreatedAt: now})
+	if err != nil || id != 41 {
+		t.Errorf("expected id 41, got %d, %v", id, err)
+	}
+
+	mock.SQL.ExpectQuery("SELECT id, event, task_id, user_id, parent_id, payload, created_at FROM event_log "+
+		"WHERE id > ? ORDER BY id LIMIT ?").
+		WithArgs(40, 10).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "event", "task_id", "user_id", "parent_id", "payload", "created_at"}).
+			AddRow(41, "task.created", 9, 1, 2, payload, now).
+			AddRow(42, "task.deleted", 8, 1, nil, payload, now))
+
+	events, err := eventLogStore.After(ctx, 40, 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []models.StreamEvent{
+		{ID: 41, Event: "task.created", TaskID: 9, UserID: 1, ParentID: &parentID, Payload: payload, CreatedAt: now},
+		{ID: 42, Event: "task.deleted", TaskID: 8, UserID: 1, Payload: payload, CreatedAt: now},
+	}
+	if !reflect.DeepEqual(events, want) {
+		t.Errorf("expected %+v, got %+v", want, events)
+	}
+
+	mock.SQL.ExpectQuery("SELECT COALESCE(MIN(id), 0), COALESCE(MAX(id), 0) FROM event_log").
+		WillReturnRows(sqlmock.NewRows([]string{"min", "max"}).AddRow(3, 42))
+
+	first, last, err := eventLogStore.Bounds(ctx)
+	if err != nil || first != 3 || last != 42 {
+		t.Errorf("expected bounds 3 and 42, got %d, %d, %v", first, last, err)
+	}
+
+	mock.SQL.ExpectExec("DELETE FROM event_log WHERE id <= ?").WithArgs(32).WillReturnResult(sqlmock.NewResult(0, 30))
+
+	pruned, err := eventLogStore.Prune(ctx, 32)
+	if err != nil || pruned != 30 {
+		t.Errorf("expected 30 pruned, got %d, %v", pruned, err)
+	}
+}
@@ -0,0 +1,87 @@
+package eventlog
+
+import (
+	"database/sql"
+
+	"gofr.dev/pkg/gofr"
+
+	"TaskManager2/models"
+	"TaskManager2/utils"
+)
+
+type store struct {
+}
+
+func New() *store {
+	return &store{}
+}
+
+func (store) Append(ctx *gofr.Context, e *models.StreamEvent) (int64, error) {
+	res, err := utils.DB(ctx).Exec("INSERT INTO event_log (event, task_id, user_id, parent_id, payload, created_at) "+
+		"VALUES (?, ?, ?, ?, ?, ?)", e.Event, e.TaskID, e.UserID, e.ParentID, e.Payload, e.CreatedAt)
+	if err != nil {
+		return 0, err
+	}
+
+	return res.LastInsertId()
+}
+
+// After lists up to limit events with an ID above the given one, in order.
+func (store) After(ctx *gofr.Context, id int64, limit int) ([]models.StreamEvent, error) {
+	rows, err := utils.DB(ctx).Query("SELECT id, event, task_id, user_id, parent_id, payload, created_at FROM event_log "+
+		"WHERE id > ? ORDER BY id LIMIT ?", id, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	var events []models.StreamEvent
+
+	for rows.Next() {
+		var (
+			e        models.StreamEvent
+			parentID sql.NullInt64
+		)
+
+		err = rows.Scan(&e.ID, &e.Event, &e.TaskID, &e.UserID, &parentID, &e.Payload, &e.CreatedAt)
+		if err != nil {
+			return nil, err
+		}
+
+		if parentID.Valid {
+			e.ParentID = &parentID.Int64
+		}
+
+		events = append(events, e)
+	}
+
+	if rows.Err() != nil {
+		return nil, rows.Err()
+	}
+
+	return events, nil
+}
+
+// Bounds returns the lowest and highest event IDs in the log, both zero when
+// it is empty.
+func (store) Bounds(ctx *gofr.Context) (int64, int64, error) {
+	var first, last int64
+
+	err := utils.DB(ctx).QueryRow("SELECT COALESCE(MIN(id), 0), COALESCE(MAX(id), 0) FROM event_log").Scan(&first, &last)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	return first, last, nil
+}
+
+// Prune deletes the events with an ID up to the given one.
+func (store) Prune(ctx *gofr.Context, upTo int64) (int64, error) {
+	res, err := utils.DB(ctx).Exec("DELETE FROM event_log WHERE id <= ?", upTo)
+	if err != nil {
+		return 0, err
+	}
+
+	return res.RowsAffected()
+}
@@ -0,0 +1,281 @@
+package idempotency
+
+import (
+	"database/sql/driver"
+	"errors"
+	"net/http"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/go-sql-driver/mysql"
+	"gofr.dev/pkg/gofr"
+	"gofr.dev/pkg/gofr/container"
+
+	"TaskManager2/models"
+	"TaskManager2/utils"
+)
+
+func TestStore_Claim(t *testing.T) {
+	mockContainer, mock := container.NewMockContainer(t)
+	ctx := &gofr.Context{
+		Context:   t.Context(),
+		Request:   nil,
+		Container: mockContainer,
+	}
+
+	idempotencyStore := New()
+	now := time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC)
+	expiresAt := now.Add(time.Minute)
+	rec := &models.IdempotencyRecord{Actor: "7", Endpoint: "POST /task", Key: "k1", Fingerprint: "abc", ClaimToken: "c1",
+		ExpiresAt: expiresAt}
+
+	deleteQuery := "DELETE FROM idempotency_keys WHERE actor = ? AND endpoint = ? AND idem_key = ? AND expires_at < ?"
+	insertQuery := "INSERT INTO idempotency_keys (actor, endpoint, idem_key, fingerprint, claim_token, expires_at) " +
+		"VALUES (?, ?, ?, ?, ?, ?)"
+	selectQuery := "SELECT fingerprint, status_code, headers, body, expires_at FROM idempotency_keys " +
+		"WHERE actor = ? AND endpoint = ? AND idem_key = ?"
+	columns := []string{"fingerprint", "status_code", "headers", "body", "expires_at"}
+	duplicate := &mysql.MySQLError{Number: 1062, Message: "Duplicate entry '7-POST /task-k1' for key 'PRIMARY'"}
+
+	expectClaim := func() *sqlmock.ExpectedExec {
+		mock.SQL.ExpectExec(deleteQuery).WithArgs("7", "POST /task", "k1", now).WillReturnResult(sqlmock.NewResult(0, 0))
+
+		return mock.SQL.ExpectExec(insertQuery).WithArgs("7", "POST /task", "k1", "abc", "c1", expiresAt)
+	}
+
+	tests := []struct {
+		description   string
+		mockExpect    func()
+		expected      *models.IdempotencyRecord
+		expectedError bool
+	}{
+		{
+			description: "claimed",
+			mockExpect: func() {
+				expectClaim().WillReturnResult(sqlmock.NewResult(0, 1))
+			},
+		},
+		{
+			description: "completed by an earlier request",
+			mockExpect: func() {
+				expectClaim().WillReturnError(duplicate)
+				mock.SQL.ExpectQuery(selectQuery).WithArgs("7", "POST /task", "k1").
+					WillReturnRows(sqlmock.NewRows(columns).
+						AddRow("abc", 201, []byte(`{"Content-Type":["application/json"]}`), []byte(`{"data":1}`), expiresAt))
+			},
+			expected: &models.IdempotencyRecord{Actor: "7", Endpoint: "POST /task", Key: "k1", Fingerprint: "abc",
+				StatusCode: 201, Header: http.Header{"Content-Type": {"application/json"}}, Body: []byte(`{"data":1}`),
+				ExpiresAt: expiresAt},
+		},
+		{
+			description: "released by the holder before the read",
+			mockExpect: func() {
+				expectClaim().WillReturnError(duplicate)
+				mock.SQL.ExpectQuery(selectQuery).WithArgs("7", "POST /task", "k1").WillReturnRows(sqlmock.NewRows(columns))
+				expectClaim().WillReturnResult(sqlmock.NewResult(0, 1))
+			},
+		},
+		{
+			description: "released repeatedly",
+			mockExpect: func() {
+				for range claimAttempts {
+					expectClaim().WillReturnError(duplicate)
+					mock.SQL.ExpectQuery(selectQuery).WithArgs("7", "POST /task", "k1").WillReturnRows(sqlmock.NewRows(columns))
+				}
+			},
+			expectedError: true,
+		},
+		{
+			description: "held by a request in flight",
+			mockExpect: func() {
+				expectClaim().WillReturnError(duplicate)
+				mock.SQL.ExpectQuery(selectQuery).WithArgs("7", "POST /task", "k1").
+					WillReturnRows(sqlmock.NewRows(columns).AddRow("abc", nil, nil, nil, expiresAt))
+			},
+			expected: &models.IdempotencyRecord{Actor: "7", Endpoint: "POST /task", Key: "k1", Fingerprint: "abc", ExpiresAt: expiresAt},
+		},
+		{
+			description: "delete error",
+			mockExpect: func() {
+				mock.SQL.ExpectExec(deleteQuery).WithArgs("7", "POST /task", "k1", now).WillReturnError(utils.ErrTest)
+			},
+			expectedError: true,
+		},
+		{
+			description: "insert error",
+			mockExpect: func() {
+				expectClaim().WillReturnError(utils.ErrTest)
+			},
+			expectedError: true,
+		},
+		{
+			description: "select error",
+			mockExpect: func() {
+				expectClaim().WillReturnError(duplicate)
+				mock.SQL.ExpectQuery(selectQuery).WithArgs("7", "POST /task", "k1").WillReturnError(utils.ErrTest)
+			},
+			expectedError: true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.description, func(t *testing.T) {
+			tc.mockExpect()
+
+			existing, err := idempotencyStore.Claim(ctx, rec, now)
+			if (err != nil) != tc.expectedError {
+				t.Errorf("expected error: %v, got: %v", tc.expectedError, err)
+			}
+
+			if !reflect.DeepEqual(existing, tc.expected) {
+				t.Errorf("expected: %+v, got: %+v", tc.expected, existing)
+			}
+		})
+	}
+
+	if err := mock.SQL.ExpectationsWereMet(); err != nil {
+		t.Errorf("unmet expectations: %v", err)
+	}
+}
+
+func TestStore_Complete(t *testing.T) {
+	mockContainer, mock := container.NewMockContainer(t)
+	ctx := &gofr.Context{
+		Context:   t.Context(),
+		Request:   nil,
+		Container: mockContainer,
+	}
+
+	idempotencyStore := New()
+	expiresAt := time.Date(2026, 10, 20, 12, 0, 0, 0, time.UTC)
+	rec := &models.IdempotencyRecord{Actor: "7", Endpoint: "POST /task", Key: "k1", ClaimToken: "c1", StatusCode: 201,
+		Header: http.Header{"Content-Type": {"application/json"}}, Body: []byte(`{"data":1}`), ExpiresAt: expiresAt}
+	query := "UPDATE idempotency_keys SET status_code = ?, headers = ?, body = ?, expires_at = ? " +
+		"WHERE actor = ? AND endpoint = ? AND idem_key = ? AND claim_token = ?"
+
+	tests := []struct {
+		description   string
+		result        driver.Result
+		err           error
+		expectedError error
+	}{
+		{"success", sqlmock.NewResult(0, 1), nil, nil},
+		{"claim taken over", sqlmock.NewResult(0, 0), nil, errClaimLost},
+		{"exec error", nil, utils.ErrTest, utils.ErrTest},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.description, func(t *testing.T) {
+			exec := mock.SQL.ExpectExec(query).
+				WithArgs(201, []byte(`{"Content-Type":["application/json"]}`), []byte(`{"data":1}`), expiresAt, "7", "POST /task", "k1", "c1")
+			if tc.err != nil {
+				exec.WillReturnError(tc.err)
+			} else {
+				exec.WillReturnResult(tc.result)
+			}
+
+			err := idempotencyStore.Complete(ctx, rec)
+			if !errors.Is(err, tc.expectedError) {
+				t.Errorf("expected error: %v, got: %v", tc.expectedError, err)
+			}
+		})
+	}
+}
+
+func TestStore_Extend(t *testing.T) {
+	mockContainer, mock := container.NewMockContainer(t)
+	ctx := &gofr.Context{
+		Context:   t.Context(),
+		Request:   nil,
+		Container: mockContainer,
+	}
+
+	idempotencyStore := New()
+	until := time.Date(2026, 10, 20, 12, 0, 0, 0, time.UTC)
+	rec := &models.IdempotencyRecord{Actor: "7", Endpoint: "POST /task", Key: "k1", ClaimToken: "c1"}
+	query := "UPDATE idempotency_keys SET expires_at = ? WHERE actor = ? AND endpoint = ? AND idem_key = ? AND claim_token = ? " +
+		"AND status_code IS NULL"
+
+	mock.SQL.ExpectExec(query).WithArgs(until, "7", "POST /task", "k1", "c1").WillReturnResult(sqlmock.NewResult(0, 1))
+
+	if err := idempotencyStore.Extend(ctx, rec, until); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+
+	mock.SQL.ExpectExec(query).WithArgs(until, "7", "POST /task", "k1", "c1").WillReturnResult(sqlmock.NewResult(0, 0))
+
+	if err := idempotencyStore.Extend(ctx, rec, until); !errors.Is(err, errClaimLost) {
+		t.Errorf("expected %v, got %v", errClaimLost, err)
+	}
+}
+
+func TestStore_Release(t *testing.T) {
+	mockContainer, mock := container.NewMockContainer(t)
+	ctx := &gofr.Context{
+		Context:   t.Context(),
+		Request:   nil,
+		Container: mockContainer,
+	}
+
+	idempotencyStore := New()
+	rec := &models.IdempotencyRecord{Actor: "7", Endpoint: "POST /task", Key: "k1", ClaimToken: "c1"}
+
+	mock.SQL.ExpectExec("DELETE FROM idempotency_keys WHERE actor = ? AND endpoint = ? AND idem_key = ? AND claim_token = ?").
+		WithArgs("7", "POST /task", "k1", "c1").WillReturnResult(sqlmock.NewResult(0, 1))
+
+	if err := idempotencyStore.Release(ctx, rec); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+func TestStore_Purge(t *testing.T) {
+	mockContainer, mock := container.NewMockContainer(t)
+	ctx := &gofr.Context{
+		Context:   t.Context(),
+		Request:   nil,
+		Container: mockContainer,
+	}
+
+	idempotencyStore := New()
+	now := time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC)
+	query := "DELETE FROM idempotency_keys WHERE expires_at < ?"
+
+	tests := []struct {
+		description   string
+		mockExpect    func()
+		expected      int64
+		expectedError bool
+	}{
+		{
+			description: "success",
+			mockExpect: func() {
+				mock.SQL.ExpectExec(query).WithArgs(now).WillReturnResult(sqlmock.NewResult(0, 4))
+			},
+			expected: 4,
+		},
+		{
+			description: "exec error",
+			mockExpect: func() {
+				mock.SQL.ExpectExec(query).WithArgs(now).WillReturnError(utils.ErrTest)
+			},
+			expectedError: true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.description, func(t *testing.T) {
+			tc.mockExpect()
+
+			purged, err := idempotencyStore.Purge(ctx, now)
+			if (err != nil) != tc.expectedError {
+				t.Errorf("expected error: %v, got: %v", tc.expectedError, err)
+			}
+
+			if purged != tc.expected {
+				t.Errorf("expected %d purged, got %d", tc.expected, purged)
+			}
+		})
+	}
+}
@@ -0,0 +1,162 @@
+package idempotency
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/go-sql-driver/mysql"
+	"gofr.dev/pkg/gofr"
+
+	"TaskManager2/apperr"
+	"TaskManager2/models"
+	"TaskManager2/utils"
+)
+
+const (
+	// errDuplicateEntry is the MySQL error number for a unique key violation.
+	errDuplicateEntry = 1062
+
+	// claimAttempts bounds the retries of a claim whose holder releases the
+	// key between the insert and the read.
+	claimAttempts = 3
+)
+
+const (
+	whereKey   = " WHERE actor = ? AND endpoint = ? AND idem_key = ?"
+	whereClaim = whereKey + " AND claim_token = ?"
+)
+
+var errClaimLost = errors.New("the idempotency claim expired and was taken over")
+
+type store struct {
+}
+
+func New() *store {
+	return &store{}
+}
+
+// Claim reserves the record's key until its ExpiresAt, replacing a record that
+// expired before now. It returns nil once the key is claimed, or the record
+// that already holds it. The primary key makes concurrent claims safe: only
+// one insert succeeds and the others read the winner's record, or try again
+// if the winner released the key in between.
+func (store) Claim(ctx *gofr.Context, rec *models.IdempotencyRecord, now time.Time) (*models.IdempotencyRecord, error) {
+	db := utils.DB(ctx)
+
+	for range claimAttempts {
+		existing, err := claim(db, rec, now)
+		if !errors.Is(err, sql.ErrNoRows) {
+			return existing, err
+		}
+	}
+
+	return nil, apperr.Conflict(fmt.Sprintf("Idempotency-Key %q is being claimed by concurrent requests", rec.Key))
+}
+
+func claim(db utils.Executor, rec *models.IdempotencyRecord, now time.Time) (*models.IdempotencyRecord, error) {
+	_, err := db.Exec("DELETE FROM idempotency_keys"+whereKey+" AND expires_at < ?", rec.Actor, rec.Endpoint, rec.Key, now)
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = db.Exec("INSERT INTO idempotency_keys (actor, endpoint, idem_key, fingerprint, claim_token, expires_at) "+
+		"VALUES (?, ?, ?, ?, ?, ?)", rec.Actor, rec.Endpoint, rec.Key, rec.Fingerprint, rec.ClaimToken, rec.ExpiresAt)
+	if err == nil {
+		return nil, nil
+	}
+
+	if !isDuplicateKey(err) {
+		return nil, err
+	}
+
+	var (
+		existing   = models.IdempotencyRecord{Actor: rec.Actor, Endpoint: rec.Endpoint, Key: rec.Key}
+		statusCode sql.NullInt64
+		header     []byte
+	)
+
+	err = db.QueryRow("SELECT fingerprint, status_code, headers, body, expires_at FROM idempotency_keys"+whereKey,
+		rec.Actor, rec.Endpoint, rec.Key).Scan(&existing.Fingerprint, &statusCode, &header, &existing.Body, &existing.ExpiresAt)
+	if err != nil {
+		return nil, err
+	}
+
+	existing.StatusCode = int(statusCode.Int64)
+
+	if header != nil {
+		err = json.Unmarshal(header, &existing.Header)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return &existing, nil
+}
+
+// Extend keeps the claim of a request still in flight until the given time.
+func (store) Extend(ctx *gofr.Context, rec *models.IdempotencyRecord, until time.Time) error {
+	res, err := utils.DB(ctx).Exec("UPDATE idempotency_keys SET expires_at = ?"+whereClaim+" AND status_code IS NULL",
+		until, rec.Actor, rec.Endpoint, rec.Key, rec.ClaimToken)
+	if err != nil {
+		return err
+	}
+
+	return claimHeld(res)
+}
+
+// Complete stores the response of a claimed key and keeps it until ExpiresAt.
+// It fails if the claim was taken over by another request.
+func (store) Complete(ctx *gofr.Context, rec *models.IdempotencyRecord) error {
+	header, err := json.Marshal(rec.Header)
+	if err != nil {
+		return err
+	}
+
+	res, err := utils.DB(ctx).Exec("UPDATE idempotency_keys SET status_code = ?, headers = ?, body = ?, expires_at = ?"+whereClaim,
+		rec.StatusCode, header, rec.Body, rec.ExpiresAt, rec.Actor, rec.Endpoint, rec.Key, rec.ClaimToken)
+	if err != nil {
+		return err
+	}
+
+	return claimHeld(res)
+}
+
+// Release drops a claim so that the key can be retried, unless it was taken
+// over by another request.
+func (store) Release(ctx *gofr.Context, rec *models.IdempotencyRecord) error {
+	_, err := utils.DB(ctx).Exec("DELETE FROM idempotency_keys"+whereClaim, rec.Actor, rec.Endpoint, rec.Key, rec.ClaimToken)
+
+	return err
+}
+
+func claimHeld(res sql.Result) error {
+	n, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if n == 0 {
+		return errClaimLost
+	}
+
+	return nil
+}
+
+// Purge deletes the records that expired before the given time.
+func (store) Purge(ctx *gofr.Context, before time.Time) (int64, error) {
+	res, err := utils.DB(ctx).Exec("DELETE FROM idempotency_keys WHERE expires_at < ?", before)
+	if err != nil {
+		return 0, err
+	}
+
+	return res.RowsAffected()
+}
+
+func isDuplicateKey(err error) bool {
+	var mysqlErr *mysql.MySQLError
+
+	return errors.As(err, &mysqlErr) && mysqlErr.Number == errDuplicateEntry
+}
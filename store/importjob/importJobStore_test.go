@@ -0,0 +1,188 @@
+package importjob
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"gofr.dev/pkg/gofr"
+	"gofr.dev/pkg/gofr/container"
+
+	"TaskManager2/apperr"
+	"TaskManager2/models"
+	"TaskManager2/utils"
+)
+
+const selectJob = "SELECT id, actor, format, mapping, dry_run, status, total, processed, created, errors, error_count, attempts, " +
+	"claimed_until, last_error, created_at, finished_at"
+
+func jobRows(extra ...string) *sqlmock.Rows {
+	return sqlmock.NewRows(append([]string{"id", "actor", "format", "mapping", "dry_run", "status", "total", "processed", "created",
+		"errors", "error_count", "attempts", "claimed_until", "last_error", "created_at", "finished_at"}, extra...))
+}
+
+func TestStore_Create(t *testing.T) {
+	mockContainer, mock := container.NewMockContainer(t)
+	ctx := &gofr.Context{
+		Context:   t.Context(),
+		Request:   nil,
+		Container: mockContainer,
+	}
+
+	jobStore := New()
+	createdAt := time.Date(2026, 10, 20, 12, 0, 0, 0, time.UTC)
+	query := "INSERT INTO import_jobs (actor, format, mapping, dry_run, data, status, total, created_at) VALUES (?, ?, ?, ?, ?, ?, ?, ?)"
+
+	mock.SQL.ExpectExec(query).
+		WithArgs("1", "csv", []byte(`{"Name":"title"}`), false, []byte("Name\nShip it\n"), "pending", 1, createdAt).
+		WillReturnResult(sqlmock.NewResult(3, 1))
+
+	id, err := jobStore.Create(ctx, &models.ImportJob{
+		Actor: "1", Format: "csv", Mapping: map[string]string{"Name": "title"}, Data: []byte("Name\nShip it\n"),
+		Status: "pending", Total: 1, CreatedAt: createdAt,
+	})
+	if err != nil || id != 3 {
+		t.Errorf("expected job 3, got %d, %v", id, err)
+	}
+
+	mock.SQL.ExpectExec(query).WithArgs("", "json", nil, true, []byte("[]"), "pending", 0, createdAt).WillReturnError(utils.ErrTest)
+
+	_, err = jobStore.Create(ctx, &models.ImportJob{Format: "json", DryRun: true, Data: []byte("[]"), Status: "pending",
+		CreatedAt: createdAt})
+	if !errors.Is(err, utils.ErrTest) {
+		t.Errorf("expected %v, got %v", utils.ErrTest, err)
+	}
+}
+
+func TestStore_GetByID(t *testing.T) {
+	mockContainer, mock := container.NewMockContainer(t)
+	ctx := &gofr.Context{
+		Context:   t.Context(),
+		Request:   nil,
+		Container: mockContainer,
+	}
+
+	jobStore := New()
+	createdAt := time.Date(2026, 10, 20, 12, 0, 0, 0, time.UTC)
+	finishedAt := createdAt.Add(time.Minute)
+
+	mock.SQL.ExpectQuery(selectJob + " FROM import_jobs WHERE id = ?").WithArgs(int64(3)).
+		WillReturnRows(jobRows().AddRow(3, "1", "csv", []byte(`{"Name":"title"}`), false, "invalid", 2, 2, 0,
+			[]byte(`[{"row":2,"field":"title","reason":"is required"}]`), 1, 1, nil, "", createdAt, finishedAt))
+
+	job, err := jobStore.GetByID(ctx, 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := &models.ImportJob{
+		ID: 3, Actor: "1", Format: "csv", Mapping: map[string]string{"Name": "title"}, Status: "invalid", Total: 2, Processed: 2,
+		Errors: []models.ImportRowError{{Row: 2, Field: "title", Reason: "is required"}}, ErrorCount: 1, Attempts: 1,
+		CreatedAt: createdAt, FinishedAt: &finishedAt,
+	}
+	if !reflect.DeepEqual(job, expected) {
+		t.Errorf("expected %+v, got %+v", expected, job)
+	}
+
+	mock.SQL.ExpectQuery(selectJob + " FROM import_jobs WHERE id = ?").WithArgs(int64(4)).WillReturnRows(jobRows())
+
+	_, err = jobStore.GetByID(ctx, 4)
+	if !errors.Is(err, apperr.NotFound("import job", 4)) {
+		t.Errorf("expected not found, got %v", err)
+	}
+}
+
+func TestStore_NextAndClaim(t *testing.T) {
+	mockContainer, mock := container.NewMockContainer(t)
+	ctx := &gofr.Context{
+		Context:   t.Context(),
+		Request:   nil,
+		Container: mockContainer,
+	}
+
+	jobStore := New()
+	now := time.Date(2026, 10, 20, 12, 0, 0, 0, time.UTC)
+	until := now.Add(time.Minute)
+	next := selectJob + ", data FROM import_jobs WHERE status IN (?, ?, ?) AND (claimed_until IS NULL OR claimed_until <= ?) " +
+		"ORDER BY id LIMIT 1"
+
+	mock.SQL.ExpectQuery(next).WithArgs("pending", "validating", "importing", now).
+		WillReturnRows(jobRows("data").AddRow(3, "1", "ndjson", nil, false, "pending", 1, 0, 0, nil, 0, 0, nil, "", now, nil,
+			[]byte(`{"title":"Ship it"}`)))
+
+	job, err := jobStore.Next(ctx, now)
+	if err != nil || job.ID != 3 || string(job.Data) != `{"title":"Ship it"}` || job.Mapping != nil || job.Errors != nil {
+		t.Errorf("expected job 3 with its data, got %+v, %v", job, err)
+	}
+
+	mock.SQL.ExpectQuery(next).WithArgs("pending", "validating", "importing", now).WillReturnRows(jobRows("data"))
+
+	job, err = jobStore.Next(ctx, now)
+	if err != nil || job != nil {
+		t.Errorf("expected no job, got %+v, %v", job, err)
+	}
+
+	claim := "UPDATE import_jobs SET claimed_until = ?, attempts = attempts + 1 " +
+		"WHERE id = ? AND status IN (?, ?, ?) AND (claimed_until IS NULL OR claimed_until <= ?)"
+
+	mock.SQL.ExpectExec(claim).WithArgs(until, int64(3), "pending", "validating", "importing", now).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.SQL.ExpectExec(claim).WithArgs(until, int64(3), "pending", "validating", "importing", now).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+
+	for _, expected := range []bool{true, false} {
+		claimed, err := jobStore.Claim(ctx, 3, now, until)
+		if err != nil || claimed != expected {
+			t.Errorf("expected claimed %v, got %v, %v", expected, claimed, err)
+		}
+	}
+}
+
+func TestStore_Save(t *testing.T) {
+	mockContainer, mock := container.NewMockContainer(t)
+	ctx := &gofr.Context{
+		Context:   t.Context(),
+		Request:   nil,
+		Container: mockContainer,
+	}
+
+	jobStore := New()
+	now := time.Date(2026, 10, 20, 12, 0, 0, 0, time.UTC)
+
+	mock.SQL.ExpectExec("UPDATE import_jobs SET status = ?, processed = ?, claimed_until = ? WHERE id = ?").
+		WithArgs("importing", 100, now, int64(3)).WillReturnResult(sqlmock.NewResult(0, 1))
+
+	err := jobStore.SaveProgress(ctx, 3, "importing", 100, now)
+	if err != nil {
+		t.Error(err)
+	}
+
+	update := "UPDATE import_jobs SET status = ?, processed = ?, created = ?, errors = ?, error_count = ?, claimed_until = ?, " +
+		"last_error = ?, finished_at = ?"
+
+	mock.SQL.ExpectExec(update+" WHERE id = ?").WithArgs("pending", 10, 0, nil, 0, &now, "timeout", nil, int64(3)).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	err = jobStore.Save(ctx, &models.ImportJob{ID: 3, Status: "pending", Processed: 10, ClaimedUntil: &now, LastError: "timeout"})
+	if err != nil {
+		t.Error(err)
+	}
+
+	mock.SQL.ExpectExec(update+", data = '' WHERE id = ?").
+		WithArgs("invalid", 2, 0, []byte(`[{"row":1,"field":"title","reason":"is required"}]`), 1, nil, "", &now, int64(3)).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	err = jobStore.Save(ctx, &models.ImportJob{
+		ID: 3, Status: "invalid", Processed: 2, Errors: []models.ImportRowError{{Row: 1, Field: "title", Reason: "is required"}},
+		ErrorCount: 1, FinishedAt: &now,
+	})
+	if err != nil {
+		t.Error(err)
+	}
+
+	if err := mock.SQL.ExpectationsWereMet(); err != nil {
+		t.Errorf("unmet expectations: %v", err)
+	}
+}
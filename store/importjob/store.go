@@ -0,0 +1,180 @@
+package importjob
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"gofr.dev/pkg/gofr"
+
+	"TaskManager2/apperr"
+	"TaskManager2/models"
+	"TaskManager2/utils"
+)
+
+const jobColumns = "id, actor, format, mapping, dry_run, status, total, processed, created, errors, error_count, attempts, " +
+	"claimed_until, last_error, created_at, finished_at"
+
+type store struct {
+}
+
+func New() *store {
+	return &store{}
+}
+
+func (store) Create(ctx *gofr.Context, j *models.ImportJob) (int64, error) {
+	mapping, err := nullJSON(j.Mapping, len(j.Mapping) == 0)
+	if err != nil {
+		return 0, err
+	}
+
+	res, err := utils.DB(ctx).Exec("INSERT INTO import_jobs (actor, format, mapping, dry_run, data, status, total, created_at) "+
+		"VALUES (?, ?, ?, ?, ?, ?, ?, ?)", j.Actor, j.Format, mapping, j.DryRun, j.Data, j.Status, j.Total, j.CreatedAt)
+	if err != nil {
+		return 0, err
+	}
+
+	return res.LastInsertId()
+}
+
+// GetByID returns the job without its data.
+func (store) GetByID(ctx *gofr.Context, id int64) (*models.ImportJob, error) {
+	j, err := scanJob(utils.DB(ctx).QueryRow("SELECT "+jobColumns+" FROM import_jobs WHERE id = ?", id))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, apperr.NotFound("import job", id)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return j, nil
+}
+
+// Next returns the oldest unfinished job that is not claimed, with its data,
+// or nil when there is none.
+func (store) Next(ctx *gofr.Context, now time.Time) (*models.ImportJob, error) {
+	var data []byte
+
+	j, err := scanJob(utils.DB(ctx).QueryRow("SELECT "+jobColumns+", data FROM import_jobs "+
+		"WHERE status IN (?, ?, ?) AND (claimed_until IS NULL OR claimed_until <= ?) ORDER BY id LIMIT 1",
+		models.ImportPending, models.ImportValidating, models.ImportImporting, now), &data)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	j.Data = data
+
+	return j, nil
+}
+
+// Claim reserves an unfinished job until the given time and counts an
+// attempt. Of several workers racing for a job only one succeeds, and one
+// that dies leaves the job to the others once the claim runs out.
+func (store) Claim(ctx *gofr.Context, id int64, now, until time.Time) (bool, error) {
+	res, err := utils.DB(ctx).Exec("UPDATE import_jobs SET claimed_until = ?, attempts = attempts + 1 "+
+		"WHERE id = ? AND status IN (?, ?, ?) AND (claimed_until IS NULL OR claimed_until <= ?)",
+		until, id, models.ImportPending, models.ImportValidating, models.ImportImporting, now)
+	if err != nil {
+		return false, err
+	}
+
+	n, err := res.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+
+	return n == 1, nil
+}
+
+// SaveProgress records the status and processed rows of a running job and
+// extends its claim.
+func (store) SaveProgress(ctx *gofr.Context, id int64, status string, processed int, until time.Time) error {
+	_, err := utils.DB(ctx).Exec("UPDATE import_jobs SET status = ?, processed = ?, claimed_until = ? WHERE id = ?",
+		status, processed, until, id)
+
+	return err
+}
+
+// Save records the outcome of an attempt. The data of a finished job is
+// dropped, as it is no longer needed.
+func (store) Save(ctx *gofr.Context, j *models.ImportJob) error {
+	errs, err := nullJSON(j.Errors, len(j.Errors) == 0)
+	if err != nil {
+		return err
+	}
+
+	query := "UPDATE import_jobs SET status = ?, processed = ?, created = ?, errors = ?, error_count = ?, claimed_until = ?, " +
+		"last_error = ?, finished_at = ?"
+	if j.FinishedAt != nil {
+		query += ", data = ''"
+	}
+
+	_, err = utils.DB(ctx).Exec(query+" WHERE id = ?", j.Status, j.Processed, j.Created, errs, j.ErrorCount, j.ClaimedUntil,
+		j.LastError, j.FinishedAt, j.ID)
+
+	return err
+}
+
+type scanner interface {
+	Scan(dest ...any) error
+}
+
+func scanJob(row scanner, extra ...any) (*models.ImportJob, error) {
+	var (
+		j            models.ImportJob
+		mapping      []byte
+		errs         []byte
+		claimedUntil sql.NullTime
+		finishedAt   sql.NullTime
+	)
+
+	err := row.Scan(append([]any{&j.ID, &j.Actor, &j.Format, &mapping, &j.DryRun, &j.Status, &j.Total, &j.Processed, &j.Created,
+		&errs, &j.ErrorCount, &j.Attempts, &claimedUntil, &j.LastError, &j.CreatedAt, &finishedAt}, extra...)...)
+	if err != nil {
+		return nil, err
+	}
+
+	if mapping != nil {
+		err = json.Unmarshal(mapping, &j.Mapping)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if errs != nil {
+		err = json.Unmarshal(errs, &j.Errors)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if claimedUntil.Valid {
+		j.ClaimedUntil = &claimedUntil.Time
+	}
+
+	if finishedAt.Valid {
+		j.FinishedAt = &finishedAt.Time
+	}
+
+	return &j, nil
+}
+
+// nullJSON encodes v, or returns nil to store NULL when it is empty.
+func nullJSON(v any, empty bool) (any, error) {
+	if empty {
+		return nil, nil
+	}
+
+	b, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+
+	return b, nil
+}
@@ -0,0 +1,100 @@
+package inbound
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/go-sql-driver/mysql"
+	"gofr.dev/pkg/gofr"
+	"gofr.dev/pkg/gofr/container"
+
+	"TaskManager2/apperr"
+	"TaskManager2/models"
+)
+
+func TestStore_InboundEmails(t *testing.T) {
+	mockContainer, mock := container.NewMockContainer(t)
+	ctx := &gofr.Context{
+		Context:   t.Context(),
+		Request:   nil,
+		Container: mockContainer,
+	}
+
+	inboundStore := New()
+	createdAt := time.Date(2026, 10, 20, 9, 0, 0, 0, time.UTC)
+	commentID := int64(4)
+	query := "SELECT id, message_id, user_id, task_id, comment_id, created_at FROM inbound_emails WHERE message_id = ?"
+	columns := []string{"id", "message_id", "user_id", "task_id", "comment_id", "created_at"}
+
+	mock.SQL.ExpectQuery(query).WithArgs("one@example.com").WillReturnRows(sqlmock.NewRows(columns))
+
+	got, err := inboundStore.GetByMessageID(ctx, "one@example.com")
+	if err != nil || got != nil {
+		t.Errorf("expected no email, got %+v, %v", got, err)
+	}
+
+	mock.SQL.ExpectQuery(query).WithArgs("two@example.com").
+		WillReturnRows(sqlmock.NewRows(columns).AddRow(2, "two@example.com", 1, 9, 4, createdAt))
+
+	got, err = inboundStore.GetByMessageID(ctx, "two@example.com")
+
+	expected := &models.InboundEmail{ID: 2, MessageID: "two@example.com", UserID: 1, TaskID: 9, CommentID: &commentID, CreatedAt: createdAt}
+	if err != nil || !reflect.DeepEqual(got, expected) {
+		t.Errorf("expected %+v, got %+v, %v", expected, got, err)
+	}
+
+	mock.SQL.ExpectExec("INSERT INTO inbound_emails (message_id, user_id, task_id, comment_id, created_at) VALUES (?, ?, ?, ?, ?)").
+		WithArgs(nil, 1, 9, nil, createdAt).
+		WillReturnResult(sqlmock.NewResult(3, 1))
+
+	id, err := inboundStore.Create(ctx, &models.InboundEmail{UserID: 1, TaskID: 9, CreatedAt: createdAt})
+	if err != nil || id != 3 {
+		t.Errorf("expected id 3, got %d, %v", id, err)
+	}
+
+	mock.SQL.ExpectExec("INSERT INTO inbound_emails (message_id, user_id, task_id, comment_id, created_at) VALUES (?, ?, ?, ?, ?)").
+		WithArgs("two@example.com", 1, 9, &commentID, createdAt).
+		WillReturnError(&mysql.MySQLError{Number: errDuplicateEntry})
+
+	_, err = inboundStore.Create(ctx, expected)
+	if !errors.Is(err, apperr.Conflict("email two@example.com was already received")) {
+		t.Errorf("expected conflict, got %v", err)
+	}
+}
+
+func TestStore_FindTask(t *testing.T) {
+	mockContainer, mock := container.NewMockContainer(t)
+	ctx := &gofr.Context{
+		Context:   t.Context(),
+		Request:   nil,
+		Container: mockContainer,
+	}
+
+	inboundStore := New()
+	query := "SELECT i.task_id FROM inbound_emails i JOIN tasks t ON t.id = i.task_id " +
+		"WHERE i.message_id IN (?, ?) AND t.deleted_at IS NULL ORDER BY i.id DESC LIMIT 1"
+
+	taskID, err := inboundStore.FindTask(ctx, nil)
+	if err != nil || taskID != 0 {
+		t.Errorf("expected no task, got %d, %v", taskID, err)
+	}
+
+	mock.SQL.ExpectQuery(query).WithArgs("one@example.com", "zero@example.com").
+		WillReturnRows(sqlmock.NewRows([]string{"task_id"}).AddRow(9))
+
+	taskID, err = inboundStore.FindTask(ctx, []string{"one@example.com", "zero@example.com"})
+	if err != nil || taskID != 9 {
+		t.Errorf("expected task 9, got %d, %v", taskID, err)
+	}
+
+	mock.SQL.ExpectQuery(query).WithArgs("one@example.com", "zero@example.com").
+		WillReturnRows(sqlmock.NewRows([]string{"task_id"}))
+
+	taskID, err = inboundStore.FindTask(ctx, []string{"one@example.com", "zero@example.com"})
+	if err != nil || taskID != 0 {
+		t.Errorf("expected no task, got %d, %v", taskID, err)
+	}
+}
@@ -0,0 +1,104 @@
+package inbound
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/go-sql-driver/mysql"
+	"gofr.dev/pkg/gofr"
+
+	"TaskManager2/apperr"
+	"TaskManager2/models"
+	"TaskManager2/utils"
+)
+
+const errDuplicateEntry = 1062
+
+type store struct {
+}
+
+func New() *store {
+	return &store{}
+}
+
+// GetByMessageID returns the email received with the message ID, or nil when
+// there is none.
+func (store) GetByMessageID(ctx *gofr.Context, messageID string) (*models.InboundEmail, error) {
+	var (
+		e         models.InboundEmail
+		commentID sql.NullInt64
+	)
+
+	err := utils.DB(ctx).QueryRow("SELECT id, message_id, user_id, task_id, comment_id, created_at FROM inbound_emails "+
+		"WHERE message_id = ?", messageID).Scan(&e.ID, &e.MessageID, &e.UserID, &e.TaskID, &commentID, &e.CreatedAt)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	if commentID.Valid {
+		e.CommentID = &commentID.Int64
+	}
+
+	return &e, nil
+}
+
+// FindTask returns the task of the latest received email among the message
+// IDs, or 0 when none of them is known or their task is deleted.
+func (store) FindTask(ctx *gofr.Context, messageIDs []string) (int64, error) {
+	if len(messageIDs) == 0 {
+		return 0, nil
+	}
+
+	args := make([]any, len(messageIDs))
+	for i, id := range messageIDs {
+		args[i] = id
+	}
+
+	var taskID int64
+
+	err := utils.DB(ctx).QueryRow("SELECT i.task_id FROM inbound_emails i JOIN tasks t ON t.id = i.task_id "+
+		"WHERE i.message_id IN ("+placeholders(len(args))+") AND t.deleted_at IS NULL ORDER BY i.id DESC LIMIT 1", args...).
+		Scan(&taskID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return 0, nil
+	}
+
+	return taskID, err
+}
+
+// Create records a received email. Its message ID is stored as NULL when
+// empty, and a message ID received before is a conflict.
+func (store) Create(ctx *gofr.Context, e *models.InboundEmail) (int64, error) {
+	var messageID *string
+	if e.MessageID != "" {
+		messageID = &e.MessageID
+	}
+
+	res, err := utils.DB(ctx).Exec("INSERT INTO inbound_emails (message_id, user_id, task_id, comment_id, created_at) "+
+		"VALUES (?, ?, ?, ?, ?)", messageID, e.UserID, e.TaskID, e.CommentID, e.CreatedAt)
+	if isDuplicateKey(err) {
+		return 0, apperr.Conflict(fmt.Sprintf("email %s was already received", e.MessageID))
+	}
+
+	if err != nil {
+		return 0, err
+	}
+
+	return res.LastInsertId()
+}
+
+func placeholders(n int) string {
+	return strings.TrimSuffix(strings.Repeat("?, ", n), ", ")
+}
+
+func isDuplicateKey(err error) bool {
+	var mysqlErr *mysql.MySQLError
+
+	return errors.As(err, &mysqlErr) && mysqlErr.Number == errDuplicateEntry
+}
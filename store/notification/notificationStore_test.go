@@ -0,0 +1,66 @@
+package notification
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"gofr.dev/pkg/gofr"
+	"gofr.dev/pkg/gofr/container"
+
+	"TaskManager2/apperr"
+	"TaskManager2/models"
+)
+
+func TestStore(t *testing.T) {
+	mockContainer, mock := container.NewMockContainer(t)
+	ctx := &gofr.Context{
+		Context:   t.Context(),
+		Request:   nil,
+		Container: mockContainer,
+	}
+
+	notificationStore := New()
+	now := time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC)
+	n := models.Notification{ID: 5, UserID: 1, TaskID: 9, ReminderID: 3, Message: "Reminder: Ship it", CreatedAt: now}
+
+	mock.SQL.ExpectExec("INSERT INTO notifications (user_id, task_id, reminder_id, message, created_at) VALUES (?, ?, ?, ?, ?)").
+		WithArgs(1, 9, 3, "Reminder: Ship it", now).
+		WillReturnResult(sqlmock.NewResult(5, 1))
+
+	id, err := notificationStore.Create(ctx, &n)
+	if err != nil || id != 5 {
+		t.Errorf("expected id 5, got %d, %v", id, err)
+	}
+
+	mock.SQL.ExpectQuery("SELECT id, user_id, task_id, reminder_id, message, created_at, read_at FROM notifications "+
+		"WHERE user_id = ? AND read_at IS NULL ORDER BY id DESC LIMIT ?").
+		WithArgs(1, 50).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "user_id", "task_id", "reminder_id", "message", "created_at", "read_at"}).
+			AddRow(5, 1, 9, 3, "Reminder: Ship it", now, nil))
+
+	notifications, err := notificationStore.GetByUser(ctx, 1, true, 50)
+	if err != nil || !reflect.DeepEqual(notifications, []models.Notification{n}) {
+		t.Errorf("expected %+v, got %+v, %v", []models.Notification{n}, notifications, err)
+	}
+
+	mock.SQL.ExpectQuery("SELECT EXISTS (SELECT 1 FROM notifications WHERE id = ? AND user_id = ?)").WithArgs(5, 1).
+		WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(true))
+	mock.SQL.ExpectExec("UPDATE notifications SET read_at = ? WHERE id = ? AND read_at IS NULL").WithArgs(now, 5).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	err = notificationStore.MarkRead(ctx, 1, 5, now)
+	if err != nil {
+		t.Error(err)
+	}
+
+	mock.SQL.ExpectQuery("SELECT EXISTS (SELECT 1 FROM notifications WHERE id = ? AND user_id = ?)").WithArgs(5, 2).
+		WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(false))
+
+	err = notificationStore.MarkRead(ctx, 2, 5, now)
+	if !errors.Is(err, apperr.NotFound("notification", 5)) {
+		t.Errorf("expected not found, got %v", err)
+	}
+}
@@ -0,0 +1,93 @@
+package notification
+
+import (
+	"database/sql"
+	"time"
+
+	"gofr.dev/pkg/gofr"
+
+	"TaskManager2/apperr"
+	"TaskManager2/models"
+	"TaskManager2/utils"
+)
+
+type store struct {
+}
+
+func New() *store {
+	return &store{}
+}
+
+func (store) Create(ctx *gofr.Context, n *models.Notification) (int64, error) {
+	res, err := utils.DB(ctx).Exec("INSERT INTO notifications (user_id, task_id, reminder_id, message, created_at) VALUES (?, ?, ?, ?, ?)",
+		n.UserID, n.TaskID, n.ReminderID, n.Message, n.CreatedAt)
+	if err != nil {
+		return 0, err
+	}
+
+	return res.LastInsertId()
+}
+
+// GetByUser lists up to limit notifications of the user, newest first, only
+// the unread ones if asked to.
+func (store) GetByUser(ctx *gofr.Context, userID int64, unread bool, limit int) ([]models.Notification, error) {
+	where := "user_id = ?"
+	if unread {
+		where += " AND read_at IS NULL"
+	}
+
+	rows, err := utils.DB(ctx).Query("SELECT id, user_id, task_id, reminder_id, message, created_at, read_at FROM notifications "+
+		"WHERE "+where+" ORDER BY id DESC LIMIT ?", userID, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	var notifications []models.Notification
+
+	for rows.Next() {
+		var (
+			n      models.Notification
+			readAt sql.NullTime
+		)
+
+		err = rows.Scan(&n.ID, &n.UserID, &n.TaskID, &n.ReminderID, &n.Message, &n.CreatedAt, &readAt)
+		if err != nil {
+			return nil, err
+		}
+
+		if readAt.Valid {
+			n.ReadAt = &readAt.Time
+		}
+
+		notifications = append(notifications, n)
+	}
+
+	if rows.Err() != nil {
+		return nil, rows.Err()
+	}
+
+	return notifications, nil
+}
+
+// MarkRead marks a notification of the user as read at the given time, unless
+// it already is.
+func (store) MarkRead(ctx *gofr.Context, userID, id int64, at time.Time) error {
+	db := utils.DB(ctx)
+
+	var exists bool
+
+	err := db.QueryRow("SELECT EXISTS (SELECT 1 FROM notifications WHERE id = ? AND user_id = ?)", id, userID).Scan(&exists)
+	if err != nil {
+		return err
+	}
+
+	if !exists {
+		return apperr.NotFound("notification", id)
+	}
+
+	_, err = db.Exec("UPDATE notifications SET read_at = ? WHERE id = ? AND read_at IS NULL", at, id)
+
+	return err
+}
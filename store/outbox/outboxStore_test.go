@@ -0,0 +1,103 @@
+package outbox
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"gofr.dev/pkg/gofr"
+	"gofr.dev/pkg/gofr/container"
+
+	"TaskManager2/models"
+	"TaskManager2/utils"
+)
+
+func TestStore_Create(t *testing.T) {
+	mockContainer, mock := container.NewMockContainer(t)
+	ctx := &gofr.Context{
+		Context:   t.Context(),
+		Request:   nil,
+		Container: mockContainer,
+	}
+
+	outboxStore := New()
+	now := time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC)
+	payload := []byte(`{"id":"e1"}`)
+
+	mock.SQL.ExpectExec("INSERT INTO outbox (event_id, event, payload, next_attempt_at, created_at) VALUES (?, ?, ?, ?, ?)").
+		WithArgs("e1", "task.created", payload, now, now).
+		WillReturnResult(sqlmock.NewResult(5, 1))
+
+	id, err := outboxStore.Create(ctx, &models.OutboxMessage{EventID: "e1", Event: "task.created", Payload: payload,
+		NextAttemptAt: now, CreatedAt: now})
+	if err != nil || id != 5 {
+		t.Errorf("expected id 5, got %d, %v", id, err)
+	}
+
+	mock.SQL.ExpectExec("INSERT INTO outbox (event_id, event, payload, next_attempt_at, created_at) VALUES (?, ?, ?, ?, ?)").
+		WillReturnError(utils.ErrTest)
+
+	_, err = outboxStore.Create(ctx, &models.OutboxMessage{})
+	if !errors.Is(err, utils.ErrTest) {
+		t.Errorf("expected %v, got %v", utils.ErrTest, err)
+	}
+}
+
+func TestStore_Relay(t *testing.T) {
+	mockContainer, mock := container.NewMockContainer(t)
+	ctx := &gofr.Context{
+		Context:   t.Context(),
+		Request:   nil,
+		Container: mockContainer,
+	}
+
+	outboxStore := New()
+	now := time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC)
+	until := now.Add(time.Minute)
+	payload := []byte(`{"id":"e1"}`)
+
+	mock.SQL.ExpectQuery("SELECT id, event_id, event, payload, attempts FROM outbox "+
+		"WHERE published_at IS NULL AND next_attempt_at <= ? ORDER BY id LIMIT ?").
+		WithArgs(now, 10).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "event_id", "event", "payload", "attempts"}).AddRow(5, "e1", "task.created", payload, 2))
+
+	due, err := outboxStore.Due(ctx, now, 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []models.OutboxMessage{{ID: 5, EventID: "e1", Event: "task.created", Payload: payload, Attempts: 2}}
+	if !reflect.DeepEqual(due, want) {
+		t.Errorf("expected %+v, got %+v", want, due)
+	}
+
+	claim := "UPDATE outbox SET next_attempt_at = ? WHERE id = ? AND published_at IS NULL AND next_attempt_at <= ?"
+
+	mock.SQL.ExpectExec(claim).WithArgs(until, 5, now).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.SQL.ExpectExec(claim).WithArgs(until, 5, now).WillReturnResult(sqlmock.NewResult(0, 0))
+
+	for _, expected := range []bool{true, false} {
+		claimed, err := outboxStore.Claim(ctx, 5, now, until)
+		if err != nil || claimed != expected {
+			t.Errorf("expected claimed %v, got %v, %v", expected, claimed, err)
+		}
+	}
+
+	mock.SQL.ExpectExec("UPDATE outbox SET attempts = ?, next_attempt_at = ?, last_error = ?, published_at = ? WHERE id = ?").
+		WithArgs(3, now, "", &now, 5).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	err = outboxStore.SaveAttempt(ctx, &models.OutboxMessage{ID: 5, Attempts: 3, NextAttemptAt: now, PublishedAt: &now})
+	if err != nil {
+		t.Error(err)
+	}
+
+	mock.SQL.ExpectExec("DELETE FROM outbox WHERE published_at < ?").WithArgs(now).WillReturnResult(sqlmock.NewResult(0, 7))
+
+	purged, err := outboxStore.Purge(ctx, now)
+	if err != nil || purged != 7 {
+		t.Errorf("expected 7 purged, got %d, %v", purged, err)
+	}
+}
@@ -0,0 +1,96 @@
+package outbox
+
+import (
+	"time"
+
+	"gofr.dev/pkg/gofr"
+
+	"TaskManager2/models"
+	"TaskManager2/utils"
+)
+
+type store struct {
+}
+
+func New() *store {
+	return &store{}
+}
+
+func (store) Create(ctx *gofr.Context, m *models.OutboxMessage) (int64, error) {
+	res, err := utils.DB(ctx).Exec("INSERT INTO outbox (event_id, event, payload, next_attempt_at, created_at) VALUES (?, ?, ?, ?, ?)",
+		m.EventID, m.Event, m.Payload, m.NextAttemptAt, m.CreatedAt)
+	if err != nil {
+		return 0, err
+	}
+
+	return res.LastInsertId()
+}
+
+// Due lists up to limit unpublished messages whose next attempt is due at now,
+// in the order they were written.
+func (store) Due(ctx *gofr.Context, now time.Time, limit int) ([]models.OutboxMessage, error) {
+	rows, err := utils.DB(ctx).Query("SELECT id, event_id, event, payload, attempts FROM outbox "+
+		"WHERE published_at IS NULL AND next_attempt_at <= ? ORDER BY id LIMIT ?", now, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	var messages []models.OutboxMessage
+
+	for rows.Next() {
+		var m models.OutboxMessage
+
+		err = rows.Scan(&m.ID, &m.EventID, &m.Event, &m.Payload, &m.Attempts)
+		if err != nil {
+			return nil, err
+		}
+
+		messages = append(messages, m)
+	}
+
+	if rows.Err() != nil {
+		return nil, rows.Err()
+	}
+
+	return messages, nil
+}
+
+// Claim reserves a due message until the given time by moving its next
+// attempt there, so that of several relays only one publishes it. A relay
+// that dies while publishing leaves the message due again once the claim runs
+// out.
+func (store) Claim(ctx *gofr.Context, id int64, now, until time.Time) (bool, error) {
+	res, err := utils.DB(ctx).Exec("UPDATE outbox SET next_attempt_at = ? "+
+		"WHERE id = ? AND published_at IS NULL AND next_attempt_at <= ?", until, id, now)
+	if err != nil {
+		return false, err
+	}
+
+	n, err := res.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+
+	return n == 1, nil
+}
+
+// SaveAttempt records the outcome of a publish: the attempt count, next
+// attempt, error and publication time of m.
+func (store) SaveAttempt(ctx *gofr.Context, m *models.OutboxMessage) error {
+	_, err := utils.DB(ctx).Exec("UPDATE outbox SET attempts = ?, next_attempt_at = ?, last_error = ?, published_at = ? WHERE id = ?",
+		m.Attempts, m.NextAttemptAt, m.LastError, m.PublishedAt, m.ID)
+
+	return err
+}
+
+// Purge deletes the messages published before the given time.
+func (store) Purge(ctx *gofr.Context, before time.Time) (int64, error) {
+	res, err := utils.DB(ctx).Exec("DELETE FROM outbox WHERE published_at < ?", before)
+	if err != nil {
+		return 0, err
+	}
+
+	return res.RowsAffected()
+}
@@ -0,0 +1,117 @@
+package reminder
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"gofr.dev/pkg/gofr"
+	"gofr.dev/pkg/gofr/container"
+
+	"TaskManager2/apperr"
+	"TaskManager2/models"
+	"TaskManager2/utils"
+)
+
+var columns = []string{"id", "task_id", "remind_at", "minutes_before_due", "channel", "status", "attempts", "next_attempt_at",
+	"last_error", "sent_at", "created_at"}
+
+func TestStore_Reminders(t *testing.T) {
+	mockContainer, mock := container.NewMockContainer(t)
+	ctx := &gofr.Context{
+		Context:   t.Context(),
+		Request:   nil,
+		Container: mockContainer,
+	}
+
+	reminderStore := New()
+	createdAt := time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC)
+	before := 30
+	r := &models.Reminder{ID: 3, TaskID: 9, MinutesBeforeDue: &before, Channel: models.ChannelEmail, Status: models.ReminderPending,
+		CreatedAt: createdAt}
+
+	mock.SQL.ExpectExec("INSERT INTO reminders (task_id, remind_at, minutes_before_due, channel, status, created_at) "+
+		"VALUES (?, ?, ?, ?, ?, ?)").
+		WithArgs(9, nil, &before, "email", "pending", createdAt).
+		WillReturnResult(sqlmock.NewResult(3, 1))
+
+	id, err := reminderStore.Create(ctx, r)
+	if err != nil || id != 3 {
+		t.Errorf("expected id 3, got %d, %v", id, err)
+	}
+
+	mock.SQL.ExpectQuery("SELECT " + reminderColumns + " FROM reminders r WHERE r.task_id = ? ORDER BY r.id").WithArgs(9).
+		WillReturnRows(sqlmock.NewRows(columns).AddRow(3, 9, nil, 30, "email", "pending", 0, nil, "", nil, createdAt))
+
+	reminders, err := reminderStore.GetByTask(ctx, 9)
+	if err != nil || !reflect.DeepEqual(reminders, []models.Reminder{*r}) {
+		t.Errorf("expected %+v, got %+v, %v", []models.Reminder{*r}, reminders, err)
+	}
+
+	mock.SQL.ExpectQuery("SELECT " + reminderColumns + " FROM reminders r WHERE r.id = ?").WithArgs(4).
+		WillReturnRows(sqlmock.NewRows(columns))
+
+	_, err = reminderStore.GetByID(ctx, 4)
+	if !errors.Is(err, apperr.NotFound("reminder", 4)) {
+		t.Errorf("expected not found, got %v", err)
+	}
+
+	mock.SQL.ExpectExec("DELETE FROM reminders WHERE id = ?").WithArgs(3).WillReturnError(utils.ErrTest)
+
+	err = reminderStore.Delete(ctx, 3)
+	if !errors.Is(err, utils.ErrTest) {
+		t.Errorf("expected %v, got %v", utils.ErrTest, err)
+	}
+}
+
+func TestStore_Dispatch(t *testing.T) {
+	mockContainer, mock := container.NewMockContainer(t)
+	ctx := &gofr.Context{
+		Context:   t.Context(),
+		Request:   nil,
+		Container: mockContainer,
+	}
+
+	reminderStore := New()
+	now := time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC)
+	until := now.Add(time.Minute)
+
+	mock.SQL.ExpectQuery("SELECT "+reminderColumns+" FROM reminders r JOIN tasks t ON t.id = r.task_id "+
+		"WHERE r.status = ? AND NOT t.status AND t.deleted_at IS NULL "+
+		"AND COALESCE(r.next_attempt_at, r.remind_at, t.due_date - INTERVAL r.minutes_before_due MINUTE) <= ? "+
+		"ORDER BY r.id LIMIT ?").
+		WithArgs("pending", now, 10).
+		WillReturnRows(sqlmock.NewRows(columns).AddRow(3, 9, now, nil, "in_app", "pending", 1, now, "timeout", nil, now))
+
+	due, err := reminderStore.Due(ctx, now, 10)
+
+	expected := []models.Reminder{{ID: 3, TaskID: 9, RemindAt: &now, Channel: "in_app", Status: "pending", Attempts: 1,
+		NextAttemptAt: &now, LastError: "timeout", CreatedAt: now}}
+	if err != nil || !reflect.DeepEqual(due, expected) {
+		t.Errorf("expected %+v, got %+v, %v", expected, due, err)
+	}
+
+	for _, affected := range []int64{1, 0} {
+		mock.SQL.ExpectExec("UPDATE reminders SET next_attempt_at = ? "+
+			"WHERE id = ? AND status = ? AND (next_attempt_at IS NULL OR next_attempt_at <= ?)").
+			WithArgs(until, 3, "pending", now).
+			WillReturnResult(sqlmock.NewResult(0, affected))
+
+		claimed, err := reminderStore.Claim(ctx, 3, now, until)
+		if err != nil || claimed != (affected == 1) {
+			t.Errorf("expected claimed %v, got %v, %v", affected == 1, claimed, err)
+		}
+	}
+
+	mock.SQL.ExpectExec("UPDATE reminders SET status = ?, attempts = ?, next_attempt_at = ?, last_error = ?, sent_at = ? "+
+		"WHERE id = ?").
+		WithArgs("sent", 2, nil, "", &now, 3).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	err = reminderStore.SaveAttempt(ctx, &models.Reminder{ID: 3, Status: "sent", Attempts: 2, SentAt: &now})
+	if err != nil {
+		t.Error(err)
+	}
+}
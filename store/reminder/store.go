@@ -0,0 +1,145 @@
+package reminder
+
+import (
+	"database/sql"
+	"time"
+
+	"gofr.dev/pkg/gofr"
+
+	"TaskManager2/apperr"
+	"TaskManager2/models"
+	"TaskManager2/utils"
+)
+
+const reminderColumns = "r.id, r.task_id, r.remind_at, r.minutes_before_due, r.channel, r.status, r.attempts, r.next_attempt_at, " +
+	"r.last_error, r.sent_at, r.created_at"
+
+type store struct {
+}
+
+func New() *store {
+	return &store{}
+}
+
+func (store) Create(ctx *gofr.Context, r *models.Reminder) (int64, error) {
+	res, err := utils.DB(ctx).Exec("INSERT INTO reminders (task_id, remind_at, minutes_before_due, channel, status, created_at) "+
+		"VALUES (?, ?, ?, ?, ?, ?)", r.TaskID, r.RemindAt, r.MinutesBeforeDue, r.Channel, r.Status, r.CreatedAt)
+	if err != nil {
+		return 0, err
+	}
+
+	return res.LastInsertId()
+}
+
+func (store) GetByID(ctx *gofr.Context, id int64) (*models.Reminder, error) {
+	reminders, err := queryReminders(utils.DB(ctx), "SELECT "+reminderColumns+" FROM reminders r WHERE r.id = ?", id)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(reminders) == 0 {
+		return nil, apperr.NotFound("reminder", id)
+	}
+
+	return &reminders[0], nil
+}
+
+func (store) GetByTask(ctx *gofr.Context, taskID int64) ([]models.Reminder, error) {
+	return queryReminders(utils.DB(ctx), "SELECT "+reminderColumns+" FROM reminders r WHERE r.task_id = ? ORDER BY r.id", taskID)
+}
+
+func (store) Delete(ctx *gofr.Context, id int64) error {
+	_, err := utils.DB(ctx).Exec("DELETE FROM reminders WHERE id = ?", id)
+
+	return err
+}
+
+// Due lists up to limit pending reminders of open tasks that are due at now,
+// oldest first. A reminder is due at its next attempt once it has one, and
+// otherwise at its time or the given minutes before the due date of the task.
+func (store) Due(ctx *gofr.Context, now time.Time, limit int) ([]models.Reminder, error) {
+	return queryReminders(utils.DB(ctx), "SELECT "+reminderColumns+" FROM reminders r JOIN tasks t ON t.id = r.task_id "+
+		"WHERE r.status = ? AND NOT t.status AND t.deleted_at IS NULL "+
+		"AND COALESCE(r.next_attempt_at, r.remind_at, t.due_date - INTERVAL r.minutes_before_due MINUTE) <= ? "+
+		"ORDER BY r.id LIMIT ?", models.ReminderPending, now, limit)
+}
+
+// Claim reserves a due reminder until the given time by moving its next
+// attempt there. Of several dispatchers racing for a reminder only one
+// succeeds, and one that dies while sending leaves the reminder due again
+// once the claim runs out.
+func (store) Claim(ctx *gofr.Context, id int64, now, until time.Time) (bool, error) {
+	res, err := utils.DB(ctx).Exec("UPDATE reminders SET next_attempt_at = ? "+
+		"WHERE id = ? AND status = ? AND (next_attempt_at IS NULL OR next_attempt_at <= ?)", until, id, models.ReminderPending, now)
+	if err != nil {
+		return false, err
+	}
+
+	n, err := res.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+
+	return n == 1, nil
+}
+
+// SaveAttempt records the outcome of an attempt: the status, attempt count,
+// next attempt, error and send time of r.
+func (store) SaveAttempt(ctx *gofr.Context, r *models.Reminder) error {
+	_, err := utils.DB(ctx).Exec("UPDATE reminders SET status = ?, attempts = ?, next_attempt_at = ?, last_error = ?, sent_at = ? "+
+		"WHERE id = ?", r.Status, r.Attempts, r.NextAttemptAt, r.LastError, r.SentAt, r.ID)
+
+	return err
+}
+
+func queryReminders(db utils.Executor, query string, args ...any) ([]models.Reminder, error) {
+	rows, err := db.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	var reminders []models.Reminder
+
+	for rows.Next() {
+		var (
+			r           models.Reminder
+			remindAt    sql.NullTime
+			before      sql.NullInt64
+			nextAttempt sql.NullTime
+			sentAt      sql.NullTime
+		)
+
+		err = rows.Scan(&r.ID, &r.TaskID, &remindAt, &before, &r.Channel, &r.Status, &r.Attempts, &nextAttempt, &r.LastError,
+			&sentAt, &r.CreatedAt)
+		if err != nil {
+			return nil, err
+		}
+
+		if remindAt.Valid {
+			r.RemindAt = &remindAt.Time
+		}
+
+		if before.Valid {
+			minutes := int(before.Int64)
+			r.MinutesBeforeDue = &minutes
+		}
+
+		if nextAttempt.Valid {
+			r.NextAttemptAt = &nextAttempt.Time
+		}
+
+		if sentAt.Valid {
+			r.SentAt = &sentAt.Time
+		}
+
+		reminders = append(reminders, r)
+	}
+
+	if rows.Err() != nil {
+		return nil, rows.Err()
+	}
+
+	return reminders, nil
+}
@@ -0,0 +1,82 @@
+package search
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"gofr.dev/pkg/gofr"
+	"gofr.dev/pkg/gofr/container"
+
+	"TaskManager2/models"
+	"TaskManager2/search"
+	"TaskManager2/utils"
+)
+
+func TestStore_Search(t *testing.T) {
+	mockContainer, mock := container.NewMockContainer(t)
+	ctx := &gofr.Context{
+		Context:   t.Context(),
+		Request:   nil,
+		Container: mockContainer,
+	}
+
+	searchStore := New()
+	columns := []string{"kind", "id", "task_id", "text", "score"}
+	against := `+deploy +rel* +"release notes"`
+
+	q, err := search.Parse(`deploy rel* "release notes"`)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		description   string
+		mockExpect    func()
+		expected      []models.SearchHit
+		expectedError bool
+	}{
+		{
+			description: "success",
+			mockExpect: func() {
+				mock.SQL.ExpectQuery(searchQuery).WithArgs(against, against, against, against, 20).
+					WillReturnRows(sqlmock.NewRows(columns).
+						AddRow("task", 1, 1, "Deploy the release notes", 2.5).
+						AddRow("comment", 4, 2, "Release notes deploy", 1.25))
+			},
+			expected: []models.SearchHit{
+				{Kind: "task", ID: 1, TaskID: 1, Text: "Deploy the release notes", Score: 2.5},
+				{Kind: "comment", ID: 4, TaskID: 2, Text: "Release notes deploy", Score: 1.25},
+			},
+		},
+		{
+			description: "query error",
+			mockExpect: func() {
+				mock.SQL.ExpectQuery(searchQuery).WillReturnError(utils.ErrTest)
+			},
+			expectedError: true,
+		},
+		{
+			description: "scan error",
+			mockExpect: func() {
+				mock.SQL.ExpectQuery(searchQuery).WillReturnRows(sqlmock.NewRows(columns).AddRow("task", "x", 1, "Deploy", 1.0))
+			},
+			expectedError: true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.description, func(t *testing.T) {
+			tc.mockExpect()
+
+			hits, err := searchStore.Search(ctx, q, 20)
+			if (err != nil) != tc.expectedError {
+				t.Errorf("expected err: %v, got: %v", tc.expectedError, err)
+			}
+
+			if !reflect.DeepEqual(hits, tc.expected) {
+				t.Errorf("expected %v, got %v", tc.expected, hits)
+			}
+		})
+	}
+}
@@ -0,0 +1,72 @@
+package search
+
+import (
+	"gofr.dev/pkg/gofr"
+
+	"TaskManager2/models"
+	"TaskManager2/search"
+	"TaskManager2/utils"
+)
+
+// taskText joins the title and description of a task as models.Task.SearchText does.
+const taskText = `CONCAT_WS('\n\n', title, NULLIF(description, ''))`
+
+// searchQuery ranks live tasks and the comments on them together. Each
+// MATCH uses the FULLTEXT index on its columns, and the placeholders all
+// take the same boolean-mode query.
+const searchQuery = "SELECT kind, id, task_id, text, score FROM (" +
+	"SELECT 'task' AS kind, id, id AS task_id, " + taskText + " AS text, MATCH(title, description) AGAINST (? IN BOOLEAN MODE) AS score " +
+	"FROM tasks WHERE deleted_at IS NULL AND MATCH(title, description) AGAINST (? IN BOOLEAN MODE) " +
+	"UNION ALL " +
+	"SELECT 'comment', c.id, c.task_id, c.body, MATCH(c.body) AGAINST (? IN BOOLEAN MODE) " +
+	"FROM task_comments c JOIN tasks t ON t.id = c.task_id " +
+	"WHERE t.deleted_at IS NULL AND MATCH(c.body) AGAINST (? IN BOOLEAN MODE)" +
+	") hits ORDER BY score DESC, kind DESC, id LIMIT ?"
+
+// store searches through MySQL FULLTEXT indexes. InnoDB keeps them up to date
+// itself, so Index and Remove have nothing to do. Unlike search.Memory, MySQL
+// ignores stopwords and words shorter than innodb_ft_min_token_size.
+type store struct {
+}
+
+func New() *store {
+	return &store{}
+}
+
+func (store) Index(*gofr.Context, models.SearchDocument) error {
+	return nil
+}
+
+func (store) Remove(*gofr.Context, string, int64) error {
+	return nil
+}
+
+func (store) Search(ctx *gofr.Context, q *search.Query, limit int) ([]models.SearchHit, error) {
+	against := q.BooleanMode()
+
+	rows, err := utils.DB(ctx).Query(searchQuery, against, against, against, against, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	var hits []models.SearchHit
+
+	for rows.Next() {
+		var h models.SearchHit
+
+		err = rows.Scan(&h.Kind, &h.ID, &h.TaskID, &h.Text, &h.Score)
+		if err != nil {
+			return nil, err
+		}
+
+		hits = append(hits, h)
+	}
+
+	if rows.Err() != nil {
+		return nil, rows.Err()
+	}
+
+	return hits, nil
+}
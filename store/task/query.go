@@ -0,0 +1,160 @@
+package task
+
+import (
+	"strings"
+
+	"TaskManager2/models"
+	"TaskManager2/query"
+)
+
+// hasTag is true when the task carries the tag bound to the placeholder.
+const hasTag = "EXISTS (SELECT 1 FROM task_tags tt JOIN tags tg ON tg.id = tt.tag_id WHERE tt.task_id = tasks.id AND tg.name = ?)"
+
+// compileQuery translates a filter expression into a condition on tasks. The
+// expression only selects among fixed SQL fragments; every value it carries
+// is bound to a placeholder.
+func compileQuery(e query.Expr) (string, []any) {
+	var c queryCompiler
+
+	c.expr(e)
+
+	return c.sql.String(), c.args
+}
+
+type queryCompiler struct {
+	sql  strings.Builder
+	args []any
+}
+
+func (c *queryCompiler) expr(e query.Expr) {
+	switch e := e.(type) {
+	case query.And:
+		c.binary(e.Left, " AND ", e.Right)
+	case query.Or:
+		c.binary(e.Left, " OR ", e.Right)
+	case query.Not:
+		c.sql.WriteString("NOT (")
+		c.expr(e.Expr)
+		c.sql.WriteString(")")
+	case query.Comparison:
+		c.comparison(e)
+	}
+}
+
+func (c *queryCompiler) binary(left query.Expr, op string, right query.Expr) {
+	c.sql.WriteString("(")
+	c.expr(left)
+	c.sql.WriteString(op)
+	c.expr(right)
+	c.sql.WriteString(")")
+}
+
+// comparison writes a single test. On nullable columns != uses <=>, so that
+// tasks without a parent or due date count as different from any value.
+func (c *queryCompiler) comparison(cmp query.Comparison) {
+	switch cmp.Field {
+	case query.FieldTag:
+		if cmp.Op == query.OpNe {
+			c.sql.WriteString("NOT ")
+		}
+
+		c.sql.WriteString(hasTag)
+		c.args = append(c.args, cmp.Value)
+
+		return
+	case query.FieldTitle, query.FieldDesc:
+		if cmp.Op == query.OpMatch {
+			c.sql.WriteString(fieldColumn(cmp.Field) + ` LIKE ? ESCAPE '\\'`)
+			c.args = append(c.args, "%"+escapeLike(cmp.Value.(string))+"%")
+
+			return
+		}
+	}
+
+	column := fieldColumn(cmp.Field)
+
+	switch {
+	case cmp.Value == nil && cmp.Op == query.OpNe:
+		c.sql.WriteString(column + " IS NOT NULL")
+
+		return
+	case cmp.Value == nil:
+		c.sql.WriteString(column + " IS NULL")
+
+		return
+	case cmp.Op == query.OpNe && (cmp.Field == query.FieldParent || cmp.Field == query.FieldDue):
+		c.sql.WriteString("NOT (" + column + " <=> ?)")
+	default:
+		c.sql.WriteString(column + sqlOperator(cmp.Op))
+	}
+
+	c.args = append(c.args, cmp.Value)
+}
+
+// fieldColumn maps a filter field other than tag to its column.
+func fieldColumn(field string) string {
+	switch field {
+	case query.FieldTitle:
+		return "title"
+	case query.FieldDesc:
+		return "description"
+	case query.FieldUser:
+		return "user_id"
+	case query.FieldParent:
+		return "parent_id"
+	case query.FieldStatus:
+		return "status"
+	case query.FieldDue:
+		return "due_date"
+	}
+
+	return "id"
+}
+
+// orderClause orders by the sort keys and then by ID, so that the order is
+// always total. MySQL sorts NULLs first, so tasks without a due date come
+// first when sorting by due date ascending.
+func orderClause(sorts []models.TaskSort) string {
+	keys := make([]string, 0, len(sorts)+1)
+	byID := false
+
+	for _, s := range sorts {
+		key := fieldColumn(s.Field)
+		byID = byID || key == "id"
+
+		if s.Desc {
+			key += " DESC"
+		}
+
+		keys = append(keys, key)
+	}
+
+	if !byID {
+		keys = append(keys, "id")
+	}
+
+	return strings.Join(keys, ", ")
+}
+
+func sqlOperator(op query.Op) string {
+	switch op {
+	case query.OpNe:
+		return " <> ?"
+	case query.OpLt:
+		return " < ?"
+	case query.OpLe:
+		return " <= ?"
+	case query.OpGt:
+		return " > ?"
+	case query.OpGe:
+		return " >= ?"
+	case query.OpMatch, query.OpEq:
+	}
+
+	return " = ?"
+}
+
+// escapeLike makes LIKE wildcards in s match themselves.
+func escapeLike(s string) string {
+	return strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(s)
+}
@@ -0,0 +1,123 @@
+package task
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"gofr.dev/pkg/gofr"
+	"gofr.dev/pkg/gofr/container"
+
+	"TaskManager2/models"
+	"TaskManager2/query"
+)
+
+func TestCompileQuery(t *testing.T) {
+	now := time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC)
+	tag := "EXISTS (SELECT 1 FROM task_tags tt JOIN tags tg ON tg.id = tt.tag_id WHERE tt.task_id = tasks.id AND tg.name = ?)"
+
+	tests := []struct {
+		filter   string
+		wantSQL  string
+		wantArgs []any
+	}{
+		{
+			"status:open AND (tag:bug OR due<now+7d)",
+			"(status = ? AND (" + tag + " OR due_date < ?))",
+			[]any{false, "bug", now.AddDate(0, 0, 7)},
+		},
+		{
+			"NOT tag:bug AND tag!=ops",
+			"(NOT (" + tag + ") AND NOT " + tag + ")",
+			[]any{"bug", "ops"},
+		},
+		{
+			`desc:"50%_off\" now" OR desc="exact" OR desc!=other`,
+			`((description LIKE ? ESCAPE '\\' OR description = ?) OR description <> ?)`,
+			[]any{`%50\%\_off" now%`, "exact", "other"},
+		},
+		{
+			`title:release AND title!="Release notes"`,
+			`(title LIKE ? ESCAPE '\\' AND title <> ?)`,
+			[]any{"%release%", "Release notes"},
+		},
+		{
+			"id>=10 AND id<20 AND id>1 AND id<=5 AND user:3 AND user!=4",
+			"(((((id >= ? AND id < ?) AND id > ?) AND id <= ?) AND user_id = ?) AND user_id <> ?)",
+			[]any{int64(10), int64(20), int64(1), int64(5), int64(3), int64(4)},
+		},
+		{
+			"parent:none OR parent!=none OR parent!=2 OR due!=2026-10-01",
+			"(((parent_id IS NULL OR parent_id IS NOT NULL) OR NOT (parent_id <=> ?)) OR NOT (due_date <=> ?))",
+			[]any{int64(2), time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC)},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.filter, func(t *testing.T) {
+			e, err := query.Parse(tc.filter, now)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			sql, args := compileQuery(e)
+			if sql != tc.wantSQL {
+				t.Errorf("expected SQL\n%s\ngot\n%s", tc.wantSQL, sql)
+			}
+
+			if !reflect.DeepEqual(args, tc.wantArgs) {
+				t.Errorf("expected args %v, got %v", tc.wantArgs, args)
+			}
+		})
+	}
+}
+
+func TestStore_GetAll_Query(t *testing.T) {
+	mockContainer, mock := container.NewMockContainer(t)
+	ctx := &gofr.Context{
+		Context:   t.Context(),
+		Request:   nil,
+		Container: mockContainer,
+	}
+
+	taskStore := New()
+	columns := []string{"id", "title", "description", "status", "user_id", "parent_id", "due_date", "version"}
+	userID := int64(2)
+
+	e, err := query.Parse("status:done OR user=3", time.Now())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	mock.SQL.ExpectQuery("SELECT id, title, description, status, user_id, parent_id, due_date, version FROM tasks "+
+		"WHERE deleted_at IS NULL AND user_id = ? AND (status = ? OR user_id = ?) ORDER BY id").
+		WithArgs(userID, true, int64(3)).
+		WillReturnRows(sqlmock.NewRows(columns).AddRow(1, "test", "", true, "2", nil, nil, 1))
+
+	tasks, err := taskStore.GetAll(ctx, &models.TaskFilter{UserID: &userID, Query: e})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(tasks) != 1 {
+		t.Errorf("expected 1 task, got %d", len(tasks))
+	}
+}
+
+func TestOrderClause(t *testing.T) {
+	tests := []struct {
+		sorts []models.TaskSort
+		want  string
+	}{
+		{nil, "id"},
+		{[]models.TaskSort{{Field: query.FieldDue}, {Field: query.FieldStatus, Desc: true}}, "due_date, status DESC, id"},
+		{[]models.TaskSort{{Field: query.FieldID, Desc: true}}, "id DESC"},
+	}
+
+	for _, tc := range tests {
+		if got := orderClause(tc.sorts); got != tc.want {
+			t.Errorf("expected %s, got %s", tc.want, got)
+		}
+	}
+}
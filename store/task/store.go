@@ -1,14 +1,34 @@
 package task
 
 import (
+	"database/sql"
 	"errors"
+	"fmt"
+	"strings"
+	"time"
 
 	"gofr.dev/pkg/gofr"
 
+	"TaskManager2/apperr"
 	"TaskManager2/models"
+	"TaskManager2/utils"
 )
 
-var errNotFound = errors.New("task not found")
+// taskColumns lists the columns read by scanTask, in order.
+const taskColumns = "id, title, description, status, user_id, parent_id, due_date, version"
+
+// setCompletedAt keeps completed_at in step with the status bound to the first
+// placeholder: it is set the first time a task is done and cleared on reopening.
+const setCompletedAt = "completed_at = CASE WHEN ? THEN COALESCE(completed_at, ?) END"
+
+// versionMismatch is returned when a conditional write finds the task at another version.
+func versionMismatch(id, current int64) error {
+	return apperr.PreconditionFailed(fmt.Sprintf("task %d was modified concurrently, current version is %d", id, current))
+}
+
+type scanner interface {
+	Scan(dest ...any) error
+}
 
 type store struct {
 }
@@ -17,36 +37,280 @@ func New() *store {
 	return &store{}
 }
 
-func (store) Create(ctx *gofr.Context, t *models.Task) (int64, error) {
-	db := ctx.SQL
-
-	res, err := db.Exec("INSERT INTO tasks (description, status, user_id) VALUES ( ?, ?, ?)", t.Desc, t.Status, t.UserID)
+func (s store) Create(ctx *gofr.Context, t *models.Task) (int64, error) {
+	created, err := s.CreateTree(ctx, []models.Task{*t})
 	if err != nil {
 		return 0, err
 	}
 
-	id, err := res.LastInsertId()
+	return created[0].ID, nil
+}
+
+// CreateTree inserts the tasks along with their tags, checklists and subtasks
+// in a single transaction. The returned tree carries the generated IDs.
+func (store) CreateTree(ctx *gofr.Context, tasks []models.Task) ([]models.Task, error) {
+	var (
+		created []models.Task
+		err     error
+	)
+
+	err = utils.WithTx(ctx, func() error {
+		created, err = insertTasks(utils.DB(ctx), tasks, nil)
+
+		return err
+	})
 	if err != nil {
-		return 0, err
+		return nil, err
+	}
+
+	return created, nil
+}
+
+func insertTasks(db utils.Executor, tasks []models.Task, parentID *int64) ([]models.Task, error) {
+	created := make([]models.Task, 0, len(tasks))
+
+	for i := range tasks {
+		t := tasks[i]
+		t.ParentID = parentID
+
+		now := time.Now().UTC()
+
+		var completedAt *time.Time
+		if t.Status {
+			completedAt = &now
+		}
+
+		res, err := db.Exec("INSERT INTO tasks (title, description, status, user_id, parent_id, due_date, completed_at, created_at) "+
+			"VALUES (?, ?, ?, ?, ?, ?, ?, ?)", t.Title, t.Description, t.Status, t.UserID, t.ParentID, t.DueDate, completedAt, now)
+		if err != nil {
+			return nil, err
+		}
+
+		t.ID, err = res.LastInsertId()
+		if err != nil {
+			return nil, err
+		}
+
+		err = insertTags(db, t.ID, t.Tags)
+		if err != nil {
+			return nil, err
+		}
+
+		err = insertChecklist(db, t.ID, t.Checklist)
+		if err != nil {
+			return nil, err
+		}
+
+		t.Subtasks, err = insertTasks(db, t.Subtasks, &t.ID)
+		if err != nil {
+			return nil, err
+		}
+
+		created = append(created, t)
 	}
 
-	return id, nil
+	return created, nil
+}
+
+func insertTags(db utils.Executor, taskID int64, tags []string) error {
+	for _, tag := range tags {
+		// LAST_INSERT_ID(id) makes an existing tag report its own id.
+		res, err := db.Exec("INSERT INTO tags (name) VALUES (?) ON DUPLICATE KEY UPDATE id = LAST_INSERT_ID(id)", tag)
+		if err != nil {
+			return err
+		}
+
+		tagID, err := res.LastInsertId()
+		if err != nil {
+			return err
+		}
+
+		_, err = db.Exec("INSERT IGNORE INTO task_tags (task_id, tag_id) VALUES (?, ?)", taskID, tagID)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func insertChecklist(db utils.Executor, taskID int64, items []models.ChecklistItem) error {
+	for i, item := range items {
+		_, err := db.Exec("INSERT INTO task_checklist_items (task_id, position, text, done) VALUES (?, ?, ?, ?)",
+			taskID, i, item.Text, item.Done)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
 }
 
-func (store) GetAll(ctx *gofr.Context) ([]models.Task, error) {
-	db := ctx.SQL
+// scanTask reads the task columns in their select order, followed by any extra columns.
+func scanTask(row scanner, extra ...any) (models.Task, error) {
+	var (
+		t        models.Task
+		parentID sql.NullInt64
+		dueDate  sql.NullTime
+	)
 
-	rows, err := db.Query("SELECT * FROM tasks")
+	err := row.Scan(append([]any{&t.ID, &t.Title, &t.Description, &t.Status, &t.UserID, &parentID, &dueDate, &t.Version}, extra...)...)
+	if err != nil {
+		return models.Task{}, err
+	}
+
+	if parentID.Valid {
+		t.ParentID = &parentID.Int64
+	}
+
+	if dueDate.Valid {
+		t.DueDate = &dueDate.Time
+	}
+
+	return t, nil
+}
+
+// GetAll lists the tasks matching the filter, in the filter's order.
+func (store) GetAll(ctx *gofr.Context, f *models.TaskFilter) ([]models.Task, error) {
+	where, args := filterClause(f)
+	db := utils.DB(ctx)
+
+	rows, err := db.Query("SELECT "+taskColumns+" FROM tasks WHERE "+where+" ORDER BY "+orderClause(f.Sort), args...)
 	if err != nil {
 		return nil, err
 	}
 
+	defer rows.Close()
+
 	var tasks []models.Task
 
 	for rows.Next() {
 		var t models.Task
 
-		err = rows.Scan(&t.ID, &t.Desc, &t.Status, &t.UserID)
+		t, err = scanTask(rows)
+		if err != nil {
+			return nil, err
+		}
+
+		tasks = append(tasks, t)
+	}
+
+	if rows.Err() != nil {
+		return nil, rows.Err()
+	}
+
+	return tasks, nil
+}
+
+func filterClause(f *models.TaskFilter) (string, []any) {
+	conds := []string{"deleted_at IS NULL"}
+
+	var args []any
+
+	if f.UserID != nil {
+		conds = append(conds, "user_id = ?")
+		args = append(args, *f.UserID)
+	}
+
+	if f.Status != nil {
+		conds = append(conds, "status = ?")
+		args = append(args, *f.Status)
+	}
+
+	if f.Tag != "" {
+		conds = append(conds, hasTag)
+		args = append(args, f.Tag)
+	}
+
+	if f.DueBefore != nil {
+		conds = append(conds, "due_date < ?")
+		args = append(args, *f.DueBefore)
+	}
+
+	if f.DueAfter != nil {
+		conds = append(conds, "due_date >= ?")
+		args = append(args, *f.DueAfter)
+	}
+
+	if f.Query != nil {
+		cond, queryArgs := compileQuery(f.Query)
+		conds = append(conds, cond)
+		args = append(args, queryArgs...)
+	}
+
+	return strings.Join(conds, " AND "), args
+}
+
+// Summary counts the user's tasks by status, the open tasks past their due date
+// and the tasks completed in the last 7 and 30 days, as seen at now.
+func (store) Summary(ctx *gofr.Context, userID int64, now time.Time) (*models.TaskSummary, error) {
+	db := utils.DB(ctx)
+	row := db.QueryRow("SELECT COUNT(*), COALESCE(SUM(status), 0), COALESCE(SUM(NOT status AND due_date < ?), 0), "+
+		"COALESCE(SUM(completed_at >= ?), 0), COALESCE(SUM(completed_at >= ?), 0) "+
+		"FROM tasks WHERE user_id = ? AND deleted_at IS NULL",
+		now, now.AddDate(0, 0, -7), now.AddDate(0, 0, -30), userID)
+
+	var total, done int64
+
+	summary := models.TaskSummary{UserID: userID}
+
+	err := row.Scan(&total, &done, &summary.Overdue, &summary.CompletedLast7Days, &summary.CompletedLast30Days)
+	if err != nil {
+		return nil, err
+	}
+
+	summary.ByStatus = map[string]int64{"open": total - done, "done": done}
+
+	return &summary, nil
+}
+
+// Digest lists the user's tasks in each section of a digest, up to limit per
+// section, soonest due or oldest first.
+func (store) Digest(ctx *gofr.Context, userID int64, w *models.DigestWindow, limit int) (*models.DigestTasks, error) {
+	var (
+		db     = utils.DB(ctx)
+		digest models.DigestTasks
+	)
+
+	sections := []struct {
+		tasks *[]models.Task
+		where string
+		order string
+		args  []any
+	}{
+		{&digest.Due, "NOT status AND due_date >= ? AND due_date < ?", "due_date", []any{w.DueFrom, w.DueTo}},
+		{&digest.Overdue, "NOT status AND due_date < ?", "due_date", []any{w.DueFrom}},
+		{&digest.Completed, "status AND completed_at >= ? AND completed_at < ?", "completed_at", []any{w.From, w.To}},
+		{&digest.Assigned, "created_at >= ? AND created_at < ?", "created_at", []any{w.From, w.To}},
+	}
+
+	for _, section := range sections {
+		args := append(append([]any{userID}, section.args...), limit)
+
+		tasks, err := queryTasks(db, "SELECT "+taskColumns+" FROM tasks WHERE user_id = ? AND deleted_at IS NULL AND "+
+			section.where+" ORDER BY "+section.order+", id LIMIT ?", args...)
+		if err != nil {
+			return nil, err
+		}
+
+		*section.tasks = tasks
+	}
+
+	return &digest, nil
+}
+
+func queryTasks(db utils.Executor, query string, args ...any) ([]models.Task, error) {
+	rows, err := db.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	var tasks []models.Task
+
+	for rows.Next() {
+		t, err := scanTask(rows)
 		if err != nil {
 			return nil, err
 		}
@@ -62,12 +326,24 @@ func (store) GetAll(ctx *gofr.Context) ([]models.Task, error) {
 }
 
 func (store) GetByID(ctx *gofr.Context, id int64) (*models.Task, error) {
-	db := ctx.SQL
-	row := db.QueryRow("SELECT id, description, status, user_id FROM tasks WHERE id = ?", id)
+	db := utils.DB(ctx)
+	row := db.QueryRow("SELECT "+taskColumns+" FROM tasks WHERE id = ? AND deleted_at IS NULL", id)
+
+	t, err := scanTask(row)
+	if errors.Is(err, sql.ErrNoRows) {
+		return &models.Task{}, apperr.NotFound("task", id)
+	}
 
-	var t models.Task
+	if err != nil {
+		return &models.Task{}, err
+	}
 
-	err := row.Scan(&t.ID, &t.Desc, &t.Status, &t.UserID)
+	t.Tags, err = getTags(ctx, t.ID)
+	if err != nil {
+		return &models.Task{}, err
+	}
+
+	t.Checklist, err = getChecklist(ctx, t.ID)
 	if err != nil {
 		return &models.Task{}, err
 	}
@@ -75,10 +351,63 @@ func (store) GetByID(ctx *gofr.Context, id int64) (*models.Task, error) {
 	return &t, nil
 }
 
+func getTags(ctx *gofr.Context, taskID int64) ([]string, error) {
+	rows, err := utils.DB(ctx).Query("SELECT t.name FROM task_tags tt JOIN tags t ON t.id = tt.tag_id WHERE tt.task_id = ? ORDER BY t.name",
+		taskID)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	var tags []string
+
+	for rows.Next() {
+		var tag string
+
+		err = rows.Scan(&tag)
+		if err != nil {
+			return nil, err
+		}
+
+		tags = append(tags, tag)
+	}
+
+	return tags, rows.Err()
+}
+
+func getChecklist(ctx *gofr.Context, taskID int64) ([]models.ChecklistItem, error) {
+	rows, err := utils.DB(ctx).Query("SELECT id, text, done FROM task_checklist_items WHERE task_id = ? ORDER BY position", taskID)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	var items []models.ChecklistItem
+
+	for rows.Next() {
+		var item models.ChecklistItem
+
+		err = rows.Scan(&item.ID, &item.Text, &item.Done)
+		if err != nil {
+			return nil, err
+		}
+
+		items = append(items, item)
+	}
+
+	return items, rows.Err()
+}
+
+// Update overwrites the task and increments its version. A non-zero t.Version
+// makes the update conditional on the task still being at that version.
 func (store) Update(ctx *gofr.Context, t *models.Task) error {
-	db := ctx.SQL
+	db := utils.DB(ctx)
 
-	res, err := db.Exec("UPDATE tasks SET description = ?, status = ? WHERE id = ?", t.Desc, t.Status, t.ID)
+	res, err := db.Exec("UPDATE tasks SET title = ?, description = ?, status = ?, due_date = ?, "+setCompletedAt+
+		", version = version + 1 WHERE id = ? AND deleted_at IS NULL AND (? = 0 OR version = ?)",
+		t.Title, t.Description, t.Status, t.DueDate, t.Status, time.Now().UTC(), t.ID, t.Version, t.Version)
 	if err != nil {
 		return err
 	}
@@ -89,16 +418,46 @@ func (store) Update(ctx *gofr.Context, t *models.Task) error {
 	}
 
 	if rows == 0 {
-		return errNotFound
+		return missingOrModified(db, t.ID)
 	}
 
 	return nil
 }
 
-func (store) Delete(ctx *gofr.Context, id int64) error {
-	db := ctx.SQL
+// Patch updates only the columns set in the patch and increments the version.
+func (store) Patch(ctx *gofr.Context, id int64, p *models.TaskPatch) error {
+	var (
+		set  []string
+		args []any
+	)
+
+	if p.Title != nil {
+		set = append(set, "title = ?")
+		args = append(args, *p.Title)
+	}
+
+	if p.Description != nil {
+		set = append(set, "description = ?")
+		args = append(args, *p.Description)
+	}
 
-	res, err := db.Exec("DELETE FROM tasks WHERE id = ?", id)
+	if p.Status != nil {
+		set = append(set, "status = ?", setCompletedAt)
+		args = append(args, *p.Status, *p.Status, time.Now().UTC())
+	}
+
+	if p.DueDate != nil || p.ClearDueDate {
+		set = append(set, "due_date = ?")
+		args = append(args, p.DueDate)
+	}
+
+	set = append(set, "version = version + 1")
+	args = append(args, id, p.Version, p.Version)
+
+	db := utils.DB(ctx)
+
+	res, err := db.Exec("UPDATE tasks SET "+strings.Join(set, ", ")+
+		" WHERE id = ? AND deleted_at IS NULL AND (? = 0 OR version = ?)", args...)
 	if err != nil {
 		return err
 	}
@@ -109,8 +468,603 @@ func (store) Delete(ctx *gofr.Context, id int64) error {
 	}
 
 	if rows == 0 {
-		return errNotFound
+		return missingOrModified(db, id)
 	}
 
 	return nil
 }
+
+// missingOrModified explains why a conditional write to the task matched no rows.
+func missingOrModified(db utils.Executor, id int64) error {
+	var current int64
+
+	err := db.QueryRow("SELECT version FROM tasks WHERE id = ? AND deleted_at IS NULL", id).Scan(&current)
+	if errors.Is(err, sql.ErrNoRows) {
+		return apperr.NotFound("task", id)
+	}
+
+	if err != nil {
+		return err
+	}
+
+	return versionMismatch(id, current)
+}
+
+// Delete moves the task and its subtasks to the trash. All of them share the
+// same deleted_at so that Restore can bring the subtree back together. It
+// returns the subtasks as they were before, without their tags and checklists.
+func (store) Delete(ctx *gofr.Context, id int64) ([]models.Task, error) {
+	deletedAt := time.Now().UTC().Truncate(time.Second)
+
+	var (
+		subtasks []models.Task
+		err      error
+	)
+
+	err = utils.WithTx(ctx, func() error {
+		subtasks, err = trash(utils.DB(ctx), id, deletedAt)
+
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return subtasks, nil
+}
+
+func trash(db utils.Executor, id int64, deletedAt time.Time) ([]models.Task, error) {
+	res, err := db.Exec("UPDATE tasks SET deleted_at = ?, version = version + 1 WHERE id = ? AND deleted_at IS NULL", deletedAt, id)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := res.RowsAffected()
+	if err != nil {
+		return nil, err
+	}
+
+	if rows == 0 {
+		return nil, apperr.NotFound("task", id)
+	}
+
+	return moveSubtasks(db, id, nil, &deletedAt)
+}
+
+// moveSubtasks sets deleted_at to `to` on every subtask below parentID whose
+// deleted_at currently equals `from`, where nil stands for NULL. It returns
+// the subtasks as they were before.
+func moveSubtasks(db utils.Executor, parentID int64, from, to *time.Time) ([]models.Task, error) {
+	subtasks, err := queryTasks(db, "SELECT "+taskColumns+" FROM tasks WHERE parent_id = ? AND deleted_at <=> ?", parentID, from)
+	if err != nil {
+		return nil, err
+	}
+
+	moved := subtasks
+
+	for _, t := range subtasks {
+		_, err = db.Exec("UPDATE tasks SET deleted_at = ?, version = version + 1 WHERE id = ?", to, t.ID)
+		if err != nil {
+			return nil, err
+		}
+
+		var below []models.Task
+
+		below, err = moveSubtasks(db, t.ID, from, to)
+		if err != nil {
+			return nil, err
+		}
+
+		moved = append(moved, below...)
+	}
+
+	return moved, nil
+}
+
+func (store) GetTrash(ctx *gofr.Context) ([]models.Task, error) {
+	db := utils.DB(ctx)
+
+	rows, err := db.Query("SELECT " + taskColumns + ", deleted_at FROM tasks WHERE deleted_at IS NOT NULL ORDER BY deleted_at DESC")
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	var tasks []models.Task
+
+	for rows.Next() {
+		var (
+			t         models.Task
+			deletedAt time.Time
+		)
+
+		t, err = scanTask(rows, &deletedAt)
+		if err != nil {
+			return nil, err
+		}
+
+		t.DeletedAt = &deletedAt
+		tasks = append(tasks, t)
+	}
+
+	if rows.Err() != nil {
+		return nil, rows.Err()
+	}
+
+	return tasks, nil
+}
+
+// Restore takes a task out of the trash together with the subtasks that were
+// deleted with it. A task whose parent is still in the trash is detached from
+// it, so purging the parent later does not cascade to the restored task. It
+// returns the restored subtasks as they were in the trash, without their tags
+// and checklists.
+func (store) Restore(ctx *gofr.Context, id int64) ([]models.Task, error) {
+	var (
+		subtasks []models.Task
+		err      error
+	)
+
+	err = utils.WithTx(ctx, func() error {
+		subtasks, err = restore(utils.DB(ctx), id)
+
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return subtasks, nil
+}
+
+func restore(tx utils.Executor, id int64) ([]models.Task, error) {
+	row := tx.QueryRow("SELECT t.deleted_at, p.deleted_at FROM tasks t LEFT JOIN tasks p ON p.id = t.parent_id "+
+		"WHERE t.id = ? AND t.deleted_at IS NOT NULL", id)
+
+	var deletedAt, parentDeletedAt sql.NullTime
+
+	err := row.Scan(&deletedAt, &parentDeletedAt)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, apperr.NotFound("task", id)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	query := "UPDATE tasks SET deleted_at = NULL, version = version + 1 WHERE id = ?"
+	if parentDeletedAt.Valid {
+		query = "UPDATE tasks SET deleted_at = NULL, parent_id = NULL, version = version + 1 WHERE id = ?"
+	}
+
+	_, err = tx.Exec(query, id)
+	if err != nil {
+		return nil, err
+	}
+
+	return moveSubtasks(tx, id, &deletedAt.Time, nil)
+}
+
+// Purge permanently removes tasks that were trashed before the given time and
+// returns them as they were, without their tags and checklists.
+func (store) Purge(ctx *gofr.Context, before time.Time) ([]models.Task, error) {
+	var (
+		purged []models.Task
+		err    error
+	)
+
+	err = utils.WithTx(ctx, func() error {
+		db := utils.DB(ctx)
+
+		purged, err = queryTasks(db, "SELECT "+taskColumns+" FROM tasks WHERE deleted_at IS NOT NULL AND deleted_at < ? FOR UPDATE", before)
+		if err != nil || len(purged) == 0 {
+			return err
+		}
+
+		ids := make([]int64, len(purged))
+		for i := range purged {
+			ids[i] = purged[i].ID
+		}
+
+		in, args := inList(ids)
+
+		_, err = db.Exec("DELETE FROM tasks WHERE id IN "+in, args...)
+
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return purged, nil
+}
+
+// GetMany returns the live tasks among ids keyed by ID, without their tags and
+// checklists. The rows stay locked until the surrounding transaction ends.
+func (store) GetMany(ctx *gofr.Context, ids []int64) (map[int64]models.Task, error) {
+	tasks := make(map[int64]models.Task, len(ids))
+	if len(ids) == 0 {
+		return tasks, nil
+	}
+
+	in, args := inList(ids)
+
+	rows, err := utils.DB(ctx).Query("SELECT "+taskColumns+" FROM tasks WHERE id IN "+in+" AND deleted_at IS NULL FOR UPDATE", args...)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	for rows.Next() {
+		var t models.Task
+
+		t, err = scanTask(rows)
+		if err != nil {
+			return nil, err
+		}
+
+		tasks[t.ID] = t
+	}
+
+	if rows.Err() != nil {
+		return nil, rows.Err()
+	}
+
+	return tasks, nil
+}
+
+// Page returns up to limit live tasks with an ID above after, ordered by ID
+// and with their tags. Walking the tasks page by page keeps both the memory
+// used and the time any single query holds a connection bounded.
+func (store) Page(ctx *gofr.Context, after int64, limit int) ([]models.Task, error) {
+	rows, err := utils.DB(ctx).Query("SELECT "+taskColumns+" FROM tasks WHERE id > ? AND deleted_at IS NULL ORDER BY id LIMIT ?",
+		after, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	var tasks []models.Task
+
+	for rows.Next() {
+		var t models.Task
+
+		t, err = scanTask(rows)
+		if err != nil {
+			return nil, err
+		}
+
+		tasks = append(tasks, t)
+	}
+
+	if rows.Err() != nil {
+		return nil, rows.Err()
+	}
+
+	if len(tasks) == 0 {
+		return tasks, nil
+	}
+
+	return tasks, pageTags(ctx, tasks)
+}
+
+// pageTags loads the tags of a page of tasks with a single query.
+func pageTags(ctx *gofr.Context, tasks []models.Task) error {
+	ids := make([]int64, len(tasks))
+	index := make(map[int64]int, len(tasks))
+
+	for i := range tasks {
+		ids[i] = tasks[i].ID
+		index[tasks[i].ID] = i
+	}
+
+	in, args := inList(ids)
+
+	rows, err := utils.DB(ctx).Query("SELECT tt.task_id, t.name FROM task_tags tt JOIN tags t ON t.id = tt.tag_id "+
+		"WHERE tt.task_id IN "+in+" ORDER BY tt.task_id, t.name", args...)
+	if err != nil {
+		return err
+	}
+
+	defer rows.Close()
+
+	for rows.Next() {
+		var (
+			taskID int64
+			tag    string
+		)
+
+		err = rows.Scan(&taskID, &tag)
+		if err != nil {
+			return err
+		}
+
+		i := index[taskID]
+		tasks[i].Tags = append(tasks[i].Tags, tag)
+	}
+
+	return rows.Err()
+}
+
+// CreateBatch inserts flat tasks, their tags and their checklists, ignoring
+// Subtasks. Each task gets its own insert, as the IDs of a multi-row insert are
+// only consecutive when auto_increment_increment is 1; the tags and checklists
+// take one multi-row statement per table.
+func (store) CreateBatch(ctx *gofr.Context, tasks []models.Task) ([]int64, error) {
+	if len(tasks) == 0 {
+		return nil, nil
+	}
+
+	var (
+		ids []int64
+		err error
+	)
+
+	err = utils.WithTx(ctx, func() error {
+		ids, err = insertBatch(utils.DB(ctx), tasks)
+
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return ids, nil
+}
+
+func insertBatch(db utils.Executor, tasks []models.Task) ([]int64, error) {
+	now := time.Now().UTC()
+	ids := make([]int64, len(tasks))
+
+	for i, t := range tasks {
+		var completedAt *time.Time
+		if t.Status {
+			completedAt = &now
+		}
+
+		res, err := db.Exec("INSERT INTO tasks (title, description, status, user_id, parent_id, due_date, completed_at, created_at) "+
+			"VALUES (?, ?, ?, ?, ?, ?, ?, ?)", t.Title, t.Description, t.Status, t.UserID, t.ParentID, t.DueDate, completedAt, now)
+		if err != nil {
+			return nil, err
+		}
+
+		ids[i], err = res.LastInsertId()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	err := insertTagsBatch(db, ids, tasks)
+	if err != nil {
+		return nil, err
+	}
+
+	err = insertChecklistBatch(db, ids, tasks)
+	if err != nil {
+		return nil, err
+	}
+
+	return ids, nil
+}
+
+// insertTagsBatch matches tag names case-insensitively, as the tags collation
+// does, so "Bug" and "bug" share the tag stored first.
+func insertTagsBatch(db utils.Executor, ids []int64, tasks []models.Task) error {
+	var names []any
+
+	seen := make(map[string]bool)
+
+	for _, t := range tasks {
+		for _, tag := range t.Tags {
+			if key := strings.ToLower(tag); !seen[key] {
+				seen[key] = true
+				names = append(names, tag)
+			}
+		}
+	}
+
+	if len(names) == 0 {
+		return nil
+	}
+
+	_, err := db.Exec("INSERT INTO tags (name) VALUES "+tuples(len(names), "(?)")+" ON DUPLICATE KEY UPDATE id = id", names...)
+	if err != nil {
+		return err
+	}
+
+	rows, err := db.Query("SELECT id, name FROM tags WHERE name IN ("+tuples(len(names), "?")+")", names...)
+	if err != nil {
+		return err
+	}
+
+	tagIDs := make(map[string]int64, len(names))
+
+	for rows.Next() {
+		var (
+			id   int64
+			name string
+		)
+
+		err = rows.Scan(&id, &name)
+		if err != nil {
+			rows.Close()
+
+			return err
+		}
+
+		tagIDs[strings.ToLower(name)] = id
+	}
+
+	rows.Close()
+
+	if rows.Err() != nil {
+		return rows.Err()
+	}
+
+	var args []any
+
+	for i, t := range tasks {
+		for _, tag := range t.Tags {
+			args = append(args, ids[i], tagIDs[strings.ToLower(tag)])
+		}
+	}
+
+	_, err = db.Exec("INSERT IGNORE INTO task_tags (task_id, tag_id) VALUES "+tuples(len(args)/2, "(?, ?)"), args...)
+
+	return err
+}
+
+func insertChecklistBatch(db utils.Executor, ids []int64, tasks []models.Task) error {
+	var args []any
+
+	for i, t := range tasks {
+		for pos, item := range t.Checklist {
+			args = append(args, ids[i], pos, item.Text, item.Done)
+		}
+	}
+
+	if len(args) == 0 {
+		return nil
+	}
+
+	_, err := db.Exec("INSERT INTO task_checklist_items (task_id, position, text, done) VALUES "+
+		tuples(len(args)/4, "(?, ?, ?, ?)"), args...)
+
+	return err
+}
+
+// caseExpr builds `column = CASE id WHEN ? THEN ... ELSE column END`, which
+// lets a single UPDATE give each row its own value.
+type caseExpr struct {
+	column string
+	whens  []string
+	args   []any
+}
+
+func (c *caseExpr) when(id int64, then string, args ...any) {
+	c.whens = append(c.whens, "WHEN ? THEN "+then)
+	c.args = append(append(c.args, id), args...)
+}
+
+func (c *caseExpr) String() string {
+	return c.column + " = CASE id " + strings.Join(c.whens, " ") + " ELSE " + c.column + " END"
+}
+
+// PatchBatch applies patches to several tasks with one UPDATE. Unlike Patch it
+// ignores versions: callers compare them against the rows locked by GetMany.
+func (store) PatchBatch(ctx *gofr.Context, patches []models.TaskPatch) error {
+	if len(patches) == 0 {
+		return nil
+	}
+
+	var (
+		title     = &caseExpr{column: "title"}
+		desc      = &caseExpr{column: "description"}
+		status    = &caseExpr{column: "status"}
+		completed = &caseExpr{column: "completed_at"}
+		due       = &caseExpr{column: "due_date"}
+		now       = time.Now().UTC()
+		ids       = make([]int64, len(patches))
+	)
+
+	for i, p := range patches {
+		ids[i] = p.ID
+
+		if p.Title != nil {
+			title.when(p.ID, "?", *p.Title)
+		}
+
+		if p.Description != nil {
+			desc.when(p.ID, "?", *p.Description)
+		}
+
+		if p.Status != nil {
+			status.when(p.ID, "?", *p.Status)
+			completed.when(p.ID, "CASE WHEN ? THEN COALESCE(completed_at, ?) END", *p.Status, now)
+		}
+
+		if p.DueDate != nil || p.ClearDueDate {
+			due.when(p.ID, "?", p.DueDate)
+		}
+	}
+
+	var (
+		set  []string
+		args []any
+	)
+
+	for _, c := range []*caseExpr{title, desc, status, completed, due} {
+		if len(c.whens) > 0 {
+			set = append(set, c.String())
+			args = append(args, c.args...)
+		}
+	}
+
+	in, idArgs := inList(ids)
+	set = append(set, "version = version + 1")
+
+	_, err := utils.DB(ctx).Exec("UPDATE tasks SET "+strings.Join(set, ", ")+" WHERE id IN "+in+" AND deleted_at IS NULL",
+		append(args, idArgs...)...)
+
+	return err
+}
+
+// DeleteBatch moves the tasks and their subtasks to the trash, one tree level
+// per statement. As with Delete, everything trashed shares one deleted_at. It
+// returns the subtasks as they were before, without their tags and checklists.
+func (store) DeleteBatch(ctx *gofr.Context, ids []int64) ([]models.Task, error) {
+	deletedAt := time.Now().UTC().Truncate(time.Second)
+
+	var subtasks []models.Task
+
+	err := utils.WithTx(ctx, func() error {
+		db := utils.DB(ctx)
+
+		for len(ids) > 0 {
+			in, args := inList(ids)
+
+			_, err := db.Exec("UPDATE tasks SET deleted_at = ?, version = version + 1 WHERE id IN "+in+" AND deleted_at IS NULL",
+				append([]any{deletedAt}, args...)...)
+			if err != nil {
+				return err
+			}
+
+			level, err := queryTasks(db, "SELECT "+taskColumns+" FROM tasks WHERE parent_id IN "+in+" AND deleted_at IS NULL", args...)
+			if err != nil {
+				return err
+			}
+
+			subtasks = append(subtasks, level...)
+
+			ids = make([]int64, len(level))
+			for i := range level {
+				ids[i] = level[i].ID
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return subtasks, nil
+}
+
+// tuples repeats tuple n times, comma separated, for multi-row statements.
+func tuples(n int, tuple string) string {
+	return strings.TrimSuffix(strings.Repeat(tuple+", ", n), ", ")
+}
+
+// inList returns an `IN` list with a placeholder per id, along with the ids as arguments.
+func inList(ids []int64) (string, []any) {
+	args := make([]any, len(ids))
+	for i, id := range ids {
+		args[i] = id
+	}
+
+	return "(" + tuples(len(ids), "?") + ")", args
+}
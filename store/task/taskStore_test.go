@@ -2,12 +2,16 @@ package task
 
 import (
 	"database/sql"
+	"errors"
+	"reflect"
 	"testing"
+	"time"
 
 	"github.com/DATA-DOG/go-sqlmock"
 	"gofr.dev/pkg/gofr"
 	"gofr.dev/pkg/gofr/container"
 
+	"TaskManager2/apperr"
 	"TaskManager2/models"
 	"TaskManager2/utils"
 )
@@ -41,7 +45,8 @@ func TestStore_Create(t *testing.T) {
 	}
 
 	taskStore := New()
-	query := "INSERT INTO tasks (description, status, user_id) VALUES ( ?, ?, ?)"
+	query := "INSERT INTO tasks (title, description, status, user_id, parent_id, due_date, completed_at, created_at) " +
+		"VALUES (?, ?, ?, ?, ?, ?, ?, ?)"
 
 	tests := []struct {
 		description   string
@@ -54,20 +59,67 @@ func TestStore_Create(t *testing.T) {
 			description: "success",
 			input:       &models.Task{},
 			mockExpect: func() {
+				mock.SQL.ExpectBegin()
 				mock.SQL.ExpectExec(query).
-					WithArgs("", false, 0).
+					WithArgs("", "", false, 0, nil, nil, nil, sqlmock.AnyArg()).
 					WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.SQL.ExpectCommit()
 			},
 			wantID:        1,
 			expectedError: false,
 		},
+		{
+			description: "success with tags and checklist",
+			input:       &models.Task{Title: "release", Tags: []string{"ops"}, Checklist: []models.ChecklistItem{{Text: "tag build"}}},
+			mockExpect: func() {
+				mock.SQL.ExpectBegin()
+				mock.SQL.ExpectExec(query).
+					WithArgs("release", "", false, 0, nil, nil, nil, sqlmock.AnyArg()).
+					WillReturnResult(sqlmock.NewResult(2, 1))
+				mock.SQL.ExpectExec("INSERT INTO tags (name) VALUES (?) ON DUPLICATE KEY UPDATE id = LAST_INSERT_ID(id)").
+					WithArgs("ops").
+					WillReturnResult(sqlmock.NewResult(5, 1))
+				mock.SQL.ExpectExec("INSERT IGNORE INTO task_tags (task_id, tag_id) VALUES (?, ?)").
+					WithArgs(2, 5).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.SQL.ExpectExec("INSERT INTO task_checklist_items (task_id, position, text, done) VALUES (?, ?, ?, ?)").
+					WithArgs(2, 0, "tag build", false).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.SQL.ExpectCommit()
+			},
+			wantID:        2,
+			expectedError: false,
+		},
+		{
+			description: "done task records completion",
+			input:       &models.Task{Title: "shipped", Status: true},
+			mockExpect: func() {
+				mock.SQL.ExpectBegin()
+				mock.SQL.ExpectExec(query).
+					WithArgs("shipped", "", true, 0, nil, nil, sqlmock.AnyArg(), sqlmock.AnyArg()).
+					WillReturnResult(sqlmock.NewResult(3, 1))
+				mock.SQL.ExpectCommit()
+			},
+			wantID:        3,
+			expectedError: false,
+		},
+		{
+			description: "begin error",
+			input:       &models.Task{},
+			mockExpect: func() {
+				mock.SQL.ExpectBegin().WillReturnError(utils.ErrTest)
+			},
+			expectedError: true,
+		},
 		{
 			description: "exec error",
-			input:       &models.Task{Desc: ""},
+			input:       &models.Task{Title: ""},
 			mockExpect: func() {
+				mock.SQL.ExpectBegin()
 				mock.SQL.ExpectExec(query).
-					WithArgs("", false, 0).
+					WithArgs("", "", false, 0, nil, nil, nil, sqlmock.AnyArg()).
 					WillReturnError(utils.ErrTest)
+				mock.SQL.ExpectRollback()
 			},
 			expectedError: true,
 		},
@@ -75,9 +127,23 @@ func TestStore_Create(t *testing.T) {
 			description: "lastInsertID error",
 			input:       &models.Task{},
 			mockExpect: func() {
+				mock.SQL.ExpectBegin()
 				mock.SQL.ExpectExec(query).
-					WithArgs("", false, 0).
+					WithArgs("", "", false, 0, nil, nil, nil, sqlmock.AnyArg()).
 					WillReturnResult(lastInsertIDErrorResult{})
+				mock.SQL.ExpectRollback()
+			},
+			expectedError: true,
+		},
+		{
+			description: "commit error",
+			input:       &models.Task{},
+			mockExpect: func() {
+				mock.SQL.ExpectBegin()
+				mock.SQL.ExpectExec(query).
+					WithArgs("", "", false, 0, nil, nil, nil, sqlmock.AnyArg()).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.SQL.ExpectCommit().WillReturnError(utils.ErrTest)
 			},
 			expectedError: true,
 		},
@@ -109,26 +175,46 @@ func TestStore_GetAll(t *testing.T) {
 	}
 
 	taskStore := New()
-	query := "SELECT * FROM tasks"
+	query := "SELECT id, title, description, status, user_id, parent_id, due_date, version FROM tasks WHERE deleted_at IS NULL ORDER BY id"
+	columns := []string{"id", "title", "description", "status", "user_id", "parent_id", "due_date", "version"}
+
+	userID, status := int64(2), true
+	before, after := time.Date(2026, 11, 1, 0, 0, 0, 0, time.UTC), time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC)
 
 	tests := []struct {
 		description   string
+		filter        *models.TaskFilter
 		mockExpect    func()
 		wantLen       int
 		expectedError bool
 	}{
 		{
 			description: "success",
+			filter:      &models.TaskFilter{},
 			mockExpect: func() {
-				rows := sqlmock.NewRows([]string{"id", "desc", "status", "user_id"}).
-					AddRow(1, "test", false, "1")
+				rows := sqlmock.NewRows(columns).AddRow(1, "test", "", false, "1", nil, nil, 1)
 				mock.SQL.ExpectQuery(query).WillReturnRows(rows)
 			},
 			wantLen:       1,
 			expectedError: false,
 		},
+		{
+			description: "all filters",
+			filter:      &models.TaskFilter{UserID: &userID, Status: &status, Tag: "ops", DueBefore: &before, DueAfter: &after},
+			mockExpect: func() {
+				mock.SQL.ExpectQuery("SELECT id, title, description, status, user_id, parent_id, due_date, version FROM tasks "+
+					"WHERE deleted_at IS NULL AND user_id = ? AND status = ? AND EXISTS (SELECT 1 FROM task_tags tt "+
+					"JOIN tags tg ON tg.id = tt.tag_id WHERE tt.task_id = tasks.id AND tg.name = ?) AND due_date < ? AND due_date >= ? "+
+					"ORDER BY id").
+					WithArgs(userID, true, "ops", before, after).
+					WillReturnRows(sqlmock.NewRows(columns).AddRow(1, "test", "", true, "2", nil, before, 1))
+			},
+			wantLen:       1,
+			expectedError: false,
+		},
 		{
 			description: "query error",
+			filter:      &models.TaskFilter{},
 			mockExpect: func() {
 				mock.SQL.ExpectQuery(query).WillReturnError(sql.ErrNoRows)
 			},
@@ -137,8 +223,9 @@ func TestStore_GetAll(t *testing.T) {
 		},
 		{
 			description: "scan error",
+			filter:      &models.TaskFilter{},
 			mockExpect: func() {
-				rows := sqlmock.NewRows([]string{"id", "desc", "status"}).
+				rows := sqlmock.NewRows([]string{"id", "title", "status"}).
 					AddRow(1, "test", false)
 				mock.SQL.ExpectQuery(query).WillReturnRows(rows)
 			},
@@ -147,9 +234,10 @@ func TestStore_GetAll(t *testing.T) {
 		},
 		{
 			description: "row error",
+			filter:      &models.TaskFilter{},
 			mockExpect: func() {
-				rows := sqlmock.NewRows([]string{"id", "desc", "status", "user_id"}).
-					AddRow(1, "test", false, "1").
+				rows := sqlmock.NewRows(columns).
+					AddRow(1, "test", "", false, "1", nil, nil, 1).
 					RowError(0, utils.ErrTest)
 				mock.SQL.ExpectQuery(query).WillReturnRows(rows)
 			},
@@ -162,7 +250,7 @@ func TestStore_GetAll(t *testing.T) {
 		t.Run(tc.description, func(t *testing.T) {
 			tc.mockExpect()
 
-			tasks, err := taskStore.GetAll(ctx)
+			tasks, err := taskStore.GetAll(ctx, tc.filter)
 
 			if (err != nil) != tc.expectedError {
 				t.Errorf("expected error = %v, got = %v", tc.expectedError, err)
@@ -173,6 +261,124 @@ func TestStore_GetAll(t *testing.T) {
 			}
 		})
 	}
+
+	if err := mock.SQL.ExpectationsWereMet(); err != nil {
+		t.Errorf("unmet expectations: %v", err)
+	}
+}
+
+func TestStore_Summary(t *testing.T) {
+	mockContainer, mock := container.NewMockContainer(t)
+	ctx := &gofr.Context{
+		Context:   t.Context(),
+		Request:   nil,
+		Container: mockContainer,
+	}
+
+	taskStore := New()
+	query := "SELECT COUNT(*), COALESCE(SUM(status), 0), COALESCE(SUM(NOT status AND due_date < ?), 0), " +
+		"COALESCE(SUM(completed_at >= ?), 0), COALESCE(SUM(completed_at >= ?), 0) " +
+		"FROM tasks WHERE user_id = ? AND deleted_at IS NULL"
+	now := time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		description   string
+		mockExpect    func()
+		want          *models.TaskSummary
+		expectedError error
+	}{
+		{
+			description: "success",
+			mockExpect: func() {
+				mock.SQL.ExpectQuery(query).
+					WithArgs(now, now.AddDate(0, 0, -7), now.AddDate(0, 0, -30), int64(2)).
+					WillReturnRows(sqlmock.NewRows([]string{"total", "done", "overdue", "last_7", "last_30"}).AddRow(10, 4, 2, 1, 3))
+			},
+			want: &models.TaskSummary{
+				UserID:              2,
+				ByStatus:            map[string]int64{"open": 6, "done": 4},
+				Overdue:             2,
+				CompletedLast7Days:  1,
+				CompletedLast30Days: 3,
+			},
+		},
+		{
+			description: "query error",
+			mockExpect: func() {
+				mock.SQL.ExpectQuery(query).WillReturnError(utils.ErrTest)
+			},
+			expectedError: utils.ErrTest,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.description, func(t *testing.T) {
+			tc.mockExpect()
+
+			got, err := taskStore.Summary(ctx, 2, now)
+			if !errors.Is(err, tc.expectedError) {
+				t.Errorf("expected error = %v, got = %v", tc.expectedError, err)
+			}
+
+			if !reflect.DeepEqual(got, tc.want) {
+				t.Errorf("expected %+v, got %+v", tc.want, got)
+			}
+		})
+	}
+}
+
+func TestStore_Digest(t *testing.T) {
+	mockContainer, mock := container.NewMockContainer(t)
+	ctx := &gofr.Context{
+		Context:   t.Context(),
+		Request:   nil,
+		Container: mockContainer,
+	}
+
+	taskStore := New()
+	columns := []string{"id", "title", "description", "status", "user_id", "parent_id", "due_date", "version"}
+	at := time.Date(2026, 10, 19, 8, 0, 0, 0, time.UTC)
+	window := &models.DigestWindow{From: at.AddDate(0, 0, -1), To: at, DueFrom: at.Add(-8 * time.Hour), DueTo: at.Add(16 * time.Hour)}
+	query := func(where, order string) string {
+		return "SELECT " + taskColumns + " FROM tasks WHERE user_id = ? AND deleted_at IS NULL AND " + where +
+			" ORDER BY " + order + ", id LIMIT ?"
+	}
+
+	mock.SQL.ExpectQuery(query("NOT status AND due_date >= ? AND due_date < ?", "due_date")).
+		WithArgs(int64(2), window.DueFrom, window.DueTo, 50).
+		WillReturnRows(sqlmock.NewRows(columns).AddRow(1, "Ship it", "", false, 2, nil, at, 1))
+	mock.SQL.ExpectQuery(query("NOT status AND due_date < ?", "due_date")).
+		WithArgs(int64(2), window.DueFrom, 50).
+		WillReturnRows(sqlmock.NewRows(columns))
+	mock.SQL.ExpectQuery(query("status AND completed_at >= ? AND completed_at < ?", "completed_at")).
+		WithArgs(int64(2), window.From, window.To, 50).
+		WillReturnRows(sqlmock.NewRows(columns).AddRow(3, "Review", "", true, 2, nil, nil, 2))
+	mock.SQL.ExpectQuery(query("created_at >= ? AND created_at < ?", "created_at")).
+		WithArgs(int64(2), window.From, window.To, 50).
+		WillReturnError(utils.ErrTest)
+
+	_, err := taskStore.Digest(ctx, 2, window, 50)
+	if !errors.Is(err, utils.ErrTest) {
+		t.Errorf("expected %v, got %v", utils.ErrTest, err)
+	}
+
+	for _, q := range []string{
+		query("NOT status AND due_date >= ? AND due_date < ?", "due_date"),
+		query("NOT status AND due_date < ?", "due_date"),
+		query("status AND completed_at >= ? AND completed_at < ?", "completed_at"),
+	} {
+		mock.SQL.ExpectQuery(q).WillReturnRows(sqlmock.NewRows(columns))
+	}
+
+	mock.SQL.ExpectQuery(query("created_at >= ? AND created_at < ?", "created_at")).
+		WillReturnRows(sqlmock.NewRows(columns).AddRow(4, "New", "", false, 2, nil, nil, 1))
+
+	got, err := taskStore.Digest(ctx, 2, window, 50)
+
+	expected := &models.DigestTasks{Assigned: []models.Task{{ID: 4, Title: "New", UserID: 2, Version: 1}}}
+	if err != nil || !reflect.DeepEqual(got, expected) {
+		t.Errorf("expected %+v, got %+v, %v", expected, got, err)
+	}
 }
 
 func TestStore_GetByID(t *testing.T) {
@@ -184,7 +390,9 @@ func TestStore_GetByID(t *testing.T) {
 	}
 
 	taskStore := New()
-	query := "SELECT id, description, status, user_id FROM tasks WHERE id = ?"
+	query := "SELECT id, title, description, status, user_id, parent_id, due_date, version FROM tasks WHERE id = ? AND deleted_at IS NULL"
+	tagsQuery := "SELECT t.name FROM task_tags tt JOIN tags t ON t.id = tt.tag_id WHERE tt.task_id = ? ORDER BY t.name"
+	checklistQuery := "SELECT id, text, done FROM task_checklist_items WHERE task_id = ? ORDER BY position"
 
 	tests := []struct {
 		description   string
@@ -197,18 +405,56 @@ func TestStore_GetByID(t *testing.T) {
 			description: "success",
 			inputID:     1,
 			mockExpect: func() {
-				rows := sqlmock.NewRows([]string{"id", "desc", "status", "user_id"}).
-					AddRow(1, "test", false, "1")
+				rows := sqlmock.NewRows([]string{"id", "title", "description", "status", "user_id", "parent_id", "due_date", "version"}).
+					AddRow(1, "test", "", false, "1", 4, nil, 1)
 				mock.SQL.ExpectQuery(query).WithArgs(1).WillReturnRows(rows)
+				mock.SQL.ExpectQuery(tagsQuery).WithArgs(1).
+					WillReturnRows(sqlmock.NewRows([]string{"name"}).AddRow("ops"))
+				mock.SQL.ExpectQuery(checklistQuery).WithArgs(1).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "text", "done"}).AddRow(1, "tag build", true))
 			},
 			want:          &models.Task{ID: 1},
 			expectedError: false,
 		},
+		{
+			description: "tags query error",
+			inputID:     1,
+			mockExpect: func() {
+				rows := sqlmock.NewRows([]string{"id", "title", "description", "status", "user_id", "parent_id", "due_date", "version"}).
+					AddRow(1, "test", "", false, "1", nil, nil, 1)
+				mock.SQL.ExpectQuery(query).WithArgs(1).WillReturnRows(rows)
+				mock.SQL.ExpectQuery(tagsQuery).WithArgs(1).WillReturnError(utils.ErrTest)
+			},
+			want:          nil,
+			expectedError: true,
+		},
+		{
+			description: "checklist query error",
+			inputID:     1,
+			mockExpect: func() {
+				rows := sqlmock.NewRows([]string{"id", "title", "description", "status", "user_id", "parent_id", "due_date", "version"}).
+					AddRow(1, "test", "", false, "1", nil, nil, 1)
+				mock.SQL.ExpectQuery(query).WithArgs(1).WillReturnRows(rows)
+				mock.SQL.ExpectQuery(tagsQuery).WithArgs(1).WillReturnRows(sqlmock.NewRows([]string{"name"}))
+				mock.SQL.ExpectQuery(checklistQuery).WithArgs(1).WillReturnError(utils.ErrTest)
+			},
+			want:          nil,
+			expectedError: true,
+		},
+		{
+			description: "not found",
+			inputID:     2,
+			mockExpect: func() {
+				mock.SQL.ExpectQuery(query).WithArgs(2).WillReturnError(sql.ErrNoRows)
+			},
+			want:          nil,
+			expectedError: true,
+		},
 		{
 			description: "scan error - missing user_id",
 			inputID:     1,
 			mockExpect: func() {
-				rows := sqlmock.NewRows([]string{"id", "desc", "status"}).
+				rows := sqlmock.NewRows([]string{"id", "title", "status"}).
 					AddRow(1, "test", false)
 				mock.SQL.ExpectQuery(query).WithArgs(1).WillReturnRows(rows)
 			},
@@ -242,53 +488,88 @@ func TestStore_Update(t *testing.T) {
 	}
 
 	taskStore := New()
-	query := "UPDATE tasks SET description = ?, status = ? WHERE id = ?"
+	query := "UPDATE tasks SET title = ?, description = ?, status = ?, due_date = ?, " +
+		"completed_at = CASE WHEN ? THEN COALESCE(completed_at, ?) END, version = version + 1 " +
+		"WHERE id = ? AND deleted_at IS NULL AND (? = 0 OR version = ?)"
+	versionQuery := "SELECT version FROM tasks WHERE id = ? AND deleted_at IS NULL"
 
 	tests := []struct {
 		description   string
 		input         *models.Task
 		mockExpect    func()
-		expectedError bool
+		expectedError error
 	}{
 		{
 			description: "success",
-			input:       &models.Task{ID: 1, Desc: "test", Status: true},
+			input:       &models.Task{ID: 1, Title: "test", Status: true},
 			mockExpect: func() {
 				mock.SQL.ExpectExec(query).
-					WithArgs("test", true, int64(1)).
+					WithArgs("test", "", true, nil, true, sqlmock.AnyArg(), int64(1), int64(0), int64(0)).
 					WillReturnResult(sqlmock.NewResult(0, 1))
 			},
-			expectedError: false,
 		},
 		{
-			description: "no rows affected",
-			input:       &models.Task{ID: 2, Desc: "test", Status: false},
+			description: "success at expected version",
+			input:       &models.Task{ID: 1, Title: "test", Status: true, Version: 3},
+			mockExpect: func() {
+				mock.SQL.ExpectExec(query).
+					WithArgs("test", "", true, nil, true, sqlmock.AnyArg(), int64(1), int64(3), int64(3)).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+			},
+		},
+		{
+			description: "not found",
+			input:       &models.Task{ID: 2, Title: "test", Status: false},
 			mockExpect: func() {
 				mock.SQL.ExpectExec(query).
-					WithArgs("test", false, int64(2)).
+					WithArgs("test", "", false, nil, false, sqlmock.AnyArg(), int64(2), int64(0), int64(0)).
 					WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.SQL.ExpectQuery(versionQuery).WithArgs(int64(2)).WillReturnError(sql.ErrNoRows)
 			},
-			expectedError: true,
+			expectedError: apperr.NotFound("task", 2),
+		},
+		{
+			description: "version mismatch",
+			input:       &models.Task{ID: 2, Title: "test", Status: false, Version: 3},
+			mockExpect: func() {
+				mock.SQL.ExpectExec(query).
+					WithArgs("test", "", false, nil, false, sqlmock.AnyArg(), int64(2), int64(3), int64(3)).
+					WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.SQL.ExpectQuery(versionQuery).WithArgs(int64(2)).
+					WillReturnRows(sqlmock.NewRows([]string{"version"}).AddRow(4))
+			},
+			expectedError: versionMismatch(2, 4),
+		},
+		{
+			description: "version lookup error",
+			input:       &models.Task{ID: 2, Title: "test", Status: false, Version: 3},
+			mockExpect: func() {
+				mock.SQL.ExpectExec(query).
+					WithArgs("test", "", false, nil, false, sqlmock.AnyArg(), int64(2), int64(3), int64(3)).
+					WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.SQL.ExpectQuery(versionQuery).WithArgs(int64(2)).WillReturnError(utils.ErrTest)
+			},
+			expectedError: utils.ErrTest,
 		},
 		{
 			description: "exec error",
-			input:       &models.Task{ID: 3, Desc: "fail", Status: false},
+			input:       &models.Task{ID: 3, Title: "fail", Status: false},
 			mockExpect: func() {
 				mock.SQL.ExpectExec(query).
-					WithArgs("fail", false, int64(3)).
+					WithArgs("fail", "", false, nil, false, sqlmock.AnyArg(), int64(3), int64(0), int64(0)).
 					WillReturnError(utils.ErrTest)
 			},
-			expectedError: true,
+			expectedError: utils.ErrTest,
 		},
 		{
 			description: "rowsAffected error",
-			input:       &models.Task{ID: 1, Desc: "test", Status: true},
+			input:       &models.Task{ID: 1, Title: "test", Status: true},
 			mockExpect: func() {
 				mock.SQL.ExpectExec(query).
-					WithArgs("test", true, int64(1)).
+					WithArgs("test", "", true, nil, true, sqlmock.AnyArg(), int64(1), int64(0), int64(0)).
 					WillReturnResult(rowsAffectedErrorResult{})
 			},
-			expectedError: true,
+			expectedError: utils.ErrTest,
 		},
 	}
 
@@ -297,11 +578,114 @@ func TestStore_Update(t *testing.T) {
 			tc.mockExpect()
 
 			err := taskStore.Update(ctx, tc.input)
-			if (err != nil) != tc.expectedError {
+			if !errors.Is(err, tc.expectedError) {
+				t.Errorf("expected error: %v, got: %v", tc.expectedError, err)
+			}
+		})
+	}
+
+	if err := mock.SQL.ExpectationsWereMet(); err != nil {
+		t.Errorf("unmet expectations: %v", err)
+	}
+}
+
+func TestStore_Patch(t *testing.T) {
+	mockContainer, mock := container.NewMockContainer(t)
+	ctx := &gofr.Context{
+		Context:   t.Context(),
+		Request:   nil,
+		Container: mockContainer,
+	}
+
+	taskStore := New()
+	where := " WHERE id = ? AND deleted_at IS NULL AND (? = 0 OR version = ?)"
+	completedAt := "completed_at = CASE WHEN ? THEN COALESCE(completed_at, ?) END"
+	statusQuery := "UPDATE tasks SET status = ?, " + completedAt + ", version = version + 1" + where
+	title, desc, status := "final", "## Notes", true
+	dueDate := time.Date(2026, 11, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		description   string
+		input         *models.TaskPatch
+		mockExpect    func()
+		expectedError error
+	}{
+		{
+			description: "status only",
+			input:       &models.TaskPatch{Status: &status},
+			mockExpect: func() {
+				mock.SQL.ExpectExec(statusQuery).
+					WithArgs(true, true, sqlmock.AnyArg(), int64(1), int64(0), int64(0)).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+			},
+		},
+		{
+			description: "all fields at expected version",
+			input:       &models.TaskPatch{Title: &title, Description: &desc, Status: &status, DueDate: &dueDate, Version: 2},
+			mockExpect: func() {
+				mock.SQL.ExpectExec("UPDATE tasks SET title = ?, description = ?, status = ?, "+completedAt+
+					", due_date = ?, version = version + 1"+where).
+					WithArgs("final", "## Notes", true, true, sqlmock.AnyArg(), &dueDate, int64(1), int64(2), int64(2)).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+			},
+		},
+		{
+			description: "clear due date",
+			input:       &models.TaskPatch{ClearDueDate: true},
+			mockExpect: func() {
+				mock.SQL.ExpectExec("UPDATE tasks SET due_date = ?, version = version + 1"+where).
+					WithArgs(nil, int64(1), int64(0), int64(0)).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+			},
+		},
+		{
+			description: "version mismatch",
+			input:       &models.TaskPatch{Status: &status, Version: 2},
+			mockExpect: func() {
+				mock.SQL.ExpectExec(statusQuery).
+					WithArgs(true, true, sqlmock.AnyArg(), int64(1), int64(2), int64(2)).
+					WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.SQL.ExpectQuery("SELECT version FROM tasks WHERE id = ? AND deleted_at IS NULL").WithArgs(int64(1)).
+					WillReturnRows(sqlmock.NewRows([]string{"version"}).AddRow(3))
+			},
+			expectedError: versionMismatch(1, 3),
+		},
+		{
+			description: "exec error",
+			input:       &models.TaskPatch{Status: &status},
+			mockExpect: func() {
+				mock.SQL.ExpectExec(statusQuery).
+					WithArgs(true, true, sqlmock.AnyArg(), int64(1), int64(0), int64(0)).
+					WillReturnError(utils.ErrTest)
+			},
+			expectedError: utils.ErrTest,
+		},
+		{
+			description: "rowsAffected error",
+			input:       &models.TaskPatch{Status: &status},
+			mockExpect: func() {
+				mock.SQL.ExpectExec(statusQuery).
+					WithArgs(true, true, sqlmock.AnyArg(), int64(1), int64(0), int64(0)).
+					WillReturnResult(rowsAffectedErrorResult{})
+			},
+			expectedError: utils.ErrTest,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.description, func(t *testing.T) {
+			tc.mockExpect()
+
+			err := taskStore.Patch(ctx, 1, tc.input)
+			if !errors.Is(err, tc.expectedError) {
 				t.Errorf("expected error: %v, got: %v", tc.expectedError, err)
 			}
 		})
 	}
+
+	if err := mock.SQL.ExpectationsWereMet(); err != nil {
+		t.Errorf("unmet expectations: %v", err)
+	}
 }
 
 func TestStore_Delete(t *testing.T) {
@@ -313,31 +697,58 @@ func TestStore_Delete(t *testing.T) {
 	}
 
 	taskStore := New()
-	query := "DELETE FROM tasks WHERE id = ?"
+	query := "UPDATE tasks SET deleted_at = ?, version = version + 1 WHERE id = ? AND deleted_at IS NULL"
+	subtasksQuery := "SELECT id, title, description, status, user_id, parent_id, due_date, version FROM tasks " +
+		"WHERE parent_id = ? AND deleted_at <=> ?"
+	columns := []string{"id", "title", "description", "status", "user_id", "parent_id", "due_date", "version"}
+	parentID := int64(1)
 
 	tests := []struct {
 		description   string
 		inputID       int64
 		mockExpect    func()
+		expected      []models.Task
 		expectedError bool
 	}{
 		{
 			description: "success",
 			inputID:     1,
 			mockExpect: func() {
+				mock.SQL.ExpectBegin()
 				mock.SQL.ExpectExec(query).
-					WithArgs(int64(1)).
+					WithArgs(sqlmock.AnyArg(), int64(1)).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.SQL.ExpectQuery(subtasksQuery).
+					WithArgs(int64(1), nil).
+					WillReturnRows(sqlmock.NewRows(columns).AddRow(2, "sub", "", false, 1, 1, nil, 3))
+				mock.SQL.ExpectExec("UPDATE tasks SET deleted_at = ?, version = version + 1 WHERE id = ?").
+					WithArgs(sqlmock.AnyArg(), int64(2)).
 					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.SQL.ExpectQuery(subtasksQuery).
+					WithArgs(int64(2), nil).
+					WillReturnRows(sqlmock.NewRows(columns))
+				mock.SQL.ExpectCommit()
 			},
+			expected:      []models.Task{{ID: 2, Title: "sub", UserID: 1, ParentID: &parentID, Version: 3}},
 			expectedError: false,
 		},
+		{
+			description: "begin error",
+			inputID:     1,
+			mockExpect: func() {
+				mock.SQL.ExpectBegin().WillReturnError(utils.ErrTest)
+			},
+			expectedError: true,
+		},
 		{
 			description: "no rows affected",
 			inputID:     2,
 			mockExpect: func() {
+				mock.SQL.ExpectBegin()
 				mock.SQL.ExpectExec(query).
-					WithArgs(int64(2)).
+					WithArgs(sqlmock.AnyArg(), int64(2)).
 					WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.SQL.ExpectRollback()
 			},
 			expectedError: true,
 		},
@@ -345,9 +756,11 @@ func TestStore_Delete(t *testing.T) {
 			description: "exec error",
 			inputID:     3,
 			mockExpect: func() {
+				mock.SQL.ExpectBegin()
 				mock.SQL.ExpectExec(query).
-					WithArgs(int64(3)).
+					WithArgs(sqlmock.AnyArg(), int64(3)).
 					WillReturnError(utils.ErrTest)
+				mock.SQL.ExpectRollback()
 			},
 			expectedError: true,
 		},
@@ -355,9 +768,26 @@ func TestStore_Delete(t *testing.T) {
 			description: "rowsAffected error",
 			inputID:     1,
 			mockExpect: func() {
+				mock.SQL.ExpectBegin()
 				mock.SQL.ExpectExec(query).
-					WithArgs(int64(1)).
+					WithArgs(sqlmock.AnyArg(), int64(1)).
 					WillReturnResult(rowsAffectedErrorResult{})
+				mock.SQL.ExpectRollback()
+			},
+			expectedError: true,
+		},
+		{
+			description: "subtasks query error",
+			inputID:     1,
+			mockExpect: func() {
+				mock.SQL.ExpectBegin()
+				mock.SQL.ExpectExec(query).
+					WithArgs(sqlmock.AnyArg(), int64(1)).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.SQL.ExpectQuery(subtasksQuery).
+					WithArgs(int64(1), nil).
+					WillReturnError(utils.ErrTest)
+				mock.SQL.ExpectRollback()
 			},
 			expectedError: true,
 		},
@@ -367,10 +797,666 @@ func TestStore_Delete(t *testing.T) {
 		t.Run(tc.description, func(t *testing.T) {
 			tc.mockExpect()
 
-			err := taskStore.Delete(ctx, tc.inputID)
+			subtasks, err := taskStore.Delete(ctx, tc.inputID)
 			if (err != nil) != tc.expectedError {
 				t.Errorf("expected error: %v, got: %v", tc.expectedError, err)
 			}
+
+			if !reflect.DeepEqual(subtasks, tc.expected) {
+				t.Errorf("expected subtasks %+v, got %+v", tc.expected, subtasks)
+			}
+
+			if err = mock.SQL.ExpectationsWereMet(); err != nil {
+				t.Errorf("unmet expectations: %v", err)
+			}
+		})
+	}
+}
+
+func TestStore_GetTrash(t *testing.T) {
+	mockContainer, mock := container.NewMockContainer(t)
+	ctx := &gofr.Context{
+		Context:   t.Context(),
+		Request:   nil,
+		Container: mockContainer,
+	}
+
+	taskStore := New()
+	query := "SELECT id, title, description, status, user_id, parent_id, due_date, version, deleted_at FROM tasks " +
+		"WHERE deleted_at IS NOT NULL ORDER BY deleted_at DESC"
+
+	tests := []struct {
+		description   string
+		mockExpect    func()
+		wantLen       int
+		expectedError bool
+	}{
+		{
+			description: "success",
+			mockExpect: func() {
+				rows := sqlmock.NewRows([]string{"id", "title", "description", "status", "user_id", "parent_id", "due_date", "version", "deleted_at"}).
+					AddRow(1, "test", "", false, 1, nil, nil, 1, time.Now())
+				mock.SQL.ExpectQuery(query).WillReturnRows(rows)
+			},
+			wantLen:       1,
+			expectedError: false,
+		},
+		{
+			description: "query error",
+			mockExpect: func() {
+				mock.SQL.ExpectQuery(query).WillReturnError(utils.ErrTest)
+			},
+			expectedError: true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.description, func(t *testing.T) {
+			tc.mockExpect()
+
+			tasks, err := taskStore.GetTrash(ctx)
+			if (err != nil) != tc.expectedError {
+				t.Errorf("expected error = %v, got = %v", tc.expectedError, err)
+			}
+
+			if len(tasks) != tc.wantLen {
+				t.Errorf("expected task count = %d, got = %d", tc.wantLen, len(tasks))
+			}
+		})
+	}
+}
+
+func TestStore_Restore(t *testing.T) {
+	mockContainer, mock := container.NewMockContainer(t)
+	ctx := &gofr.Context{
+		Context:   t.Context(),
+		Request:   nil,
+		Container: mockContainer,
+	}
+
+	taskStore := New()
+	query := "SELECT t.deleted_at, p.deleted_at FROM tasks t LEFT JOIN tasks p ON p.id = t.parent_id " +
+		"WHERE t.id = ? AND t.deleted_at IS NOT NULL"
+	subtasksQuery := "SELECT id, title, description, status, user_id, parent_id, due_date, version FROM tasks " +
+		"WHERE parent_id = ? AND deleted_at <=> ?"
+	columns := []string{"id", "title", "description", "status", "user_id", "parent_id", "due_date", "version"}
+	parentID := int64(1)
+	deletedAt := time.Date(2026, 10, 1, 9, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		description   string
+		mockExpect    func()
+		expected      []models.Task
+		expectedError bool
+	}{
+		{
+			description: "success",
+			mockExpect: func() {
+				mock.SQL.ExpectBegin()
+				mock.SQL.ExpectQuery(query).WithArgs(int64(1)).
+					WillReturnRows(sqlmock.NewRows([]string{"deleted_at", "parent_deleted_at"}).AddRow(deletedAt, nil))
+				mock.SQL.ExpectExec("UPDATE tasks SET deleted_at = NULL, version = version + 1 WHERE id = ?").
+					WithArgs(int64(1)).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.SQL.ExpectQuery(subtasksQuery).WithArgs(int64(1), deletedAt).
+					WillReturnRows(sqlmock.NewRows(columns).AddRow(2, "sub", "", false, 1, 1, nil, 3))
+				mock.SQL.ExpectExec("UPDATE tasks SET deleted_at = ?, version = version + 1 WHERE id = ?").
+					WithArgs(nil, int64(2)).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.SQL.ExpectQuery(subtasksQuery).WithArgs(int64(2), deletedAt).
+					WillReturnRows(sqlmock.NewRows(columns))
+				mock.SQL.ExpectCommit()
+			},
+			expected:      []models.Task{{ID: 2, Title: "sub", UserID: 1, ParentID: &parentID, Version: 3}},
+			expectedError: false,
+		},
+		{
+			description: "parent still in trash",
+			mockExpect: func() {
+				mock.SQL.ExpectBegin()
+				mock.SQL.ExpectQuery(query).WithArgs(int64(1)).
+					WillReturnRows(sqlmock.NewRows([]string{"deleted_at", "parent_deleted_at"}).AddRow(deletedAt, deletedAt))
+				mock.SQL.ExpectExec("UPDATE tasks SET deleted_at = NULL, parent_id = NULL, version = version + 1 WHERE id = ?").
+					WithArgs(int64(1)).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.SQL.ExpectQuery(subtasksQuery).WithArgs(int64(1), deletedAt).
+					WillReturnRows(sqlmock.NewRows(columns))
+				mock.SQL.ExpectCommit()
+			},
+			expectedError: false,
+		},
+		{
+			description: "not in trash",
+			mockExpect: func() {
+				mock.SQL.ExpectBegin()
+				mock.SQL.ExpectQuery(query).WithArgs(int64(1)).WillReturnError(sql.ErrNoRows)
+				mock.SQL.ExpectRollback()
+			},
+			expectedError: true,
+		},
+		{
+			description: "exec error",
+			mockExpect: func() {
+				mock.SQL.ExpectBegin()
+				mock.SQL.ExpectQuery(query).WithArgs(int64(1)).
+					WillReturnRows(sqlmock.NewRows([]string{"deleted_at", "parent_deleted_at"}).AddRow(deletedAt, nil))
+				mock.SQL.ExpectExec("UPDATE tasks SET deleted_at = NULL, version = version + 1 WHERE id = ?").
+					WithArgs(int64(1)).
+					WillReturnError(utils.ErrTest)
+				mock.SQL.ExpectRollback()
+			},
+			expectedError: true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.description, func(t *testing.T) {
+			tc.mockExpect()
+
+			subtasks, err := taskStore.Restore(ctx, 1)
+			if (err != nil) != tc.expectedError {
+				t.Errorf("expected error: %v, got: %v", tc.expectedError, err)
+			}
+
+			if !reflect.DeepEqual(subtasks, tc.expected) {
+				t.Errorf("expected subtasks %+v, got %+v", tc.expected, subtasks)
+			}
+
+			if err = mock.SQL.ExpectationsWereMet(); err != nil {
+				t.Errorf("unmet expectations: %v", err)
+			}
+		})
+	}
+}
+
+func TestStore_Purge(t *testing.T) {
+	mockContainer, mock := container.NewMockContainer(t)
+	ctx := &gofr.Context{
+		Context:   t.Context(),
+		Request:   nil,
+		Container: mockContainer,
+	}
+
+	taskStore := New()
+	query := "SELECT id, title, description, status, user_id, parent_id, due_date, version FROM tasks " +
+		"WHERE deleted_at IS NOT NULL AND deleted_at < ? FOR UPDATE"
+	columns := []string{"id", "title", "description", "status", "user_id", "parent_id", "due_date", "version"}
+	before := time.Date(2026, 9, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		description   string
+		mockExpect    func()
+		expected      []models.Task
+		expectedError bool
+	}{
+		{
+			description: "success",
+			mockExpect: func() {
+				mock.SQL.ExpectBegin()
+				mock.SQL.ExpectQuery(query).WithArgs(before).
+					WillReturnRows(sqlmock.NewRows(columns).AddRow(1, "old", "", false, 1, nil, nil, 2).AddRow(4, "older", "", true, 1, nil, nil, 5))
+				mock.SQL.ExpectExec("DELETE FROM tasks WHERE id IN (?, ?)").WithArgs(int64(1), int64(4)).
+					WillReturnResult(sqlmock.NewResult(0, 2))
+				mock.SQL.ExpectCommit()
+			},
+			expected:      []models.Task{{ID: 1, Title: "old", UserID: 1, Version: 2}, {ID: 4, Title: "older", Status: true, UserID: 1, Version: 5}},
+			expectedError: false,
+		},
+		{
+			description: "nothing to purge",
+			mockExpect: func() {
+				mock.SQL.ExpectBegin()
+				mock.SQL.ExpectQuery(query).WithArgs(before).WillReturnRows(sqlmock.NewRows(columns))
+				mock.SQL.ExpectCommit()
+			},
+			expectedError: false,
+		},
+		{
+			description: "query error",
+			mockExpect: func() {
+				mock.SQL.ExpectBegin()
+				mock.SQL.ExpectQuery(query).WithArgs(before).WillReturnError(utils.ErrTest)
+				mock.SQL.ExpectRollback()
+			},
+			expectedError: true,
+		},
+		{
+			description: "exec error",
+			mockExpect: func() {
+				mock.SQL.ExpectBegin()
+				mock.SQL.ExpectQuery(query).WithArgs(before).
+					WillReturnRows(sqlmock.NewRows(columns).AddRow(1, "old", "", false, 1, nil, nil, 2))
+				mock.SQL.ExpectExec("DELETE FROM tasks WHERE id IN (?)").WithArgs(int64(1)).WillReturnError(utils.ErrTest)
+				mock.SQL.ExpectRollback()
+			},
+			expectedError: true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.description, func(t *testing.T) {
+			tc.mockExpect()
+
+			purged, err := taskStore.Purge(ctx, before)
+			if (err != nil) != tc.expectedError {
+				t.Errorf("expected error: %v, got: %v", tc.expectedError, err)
+			}
+
+			if !reflect.DeepEqual(purged, tc.expected) {
+				t.Errorf("expected purged %+v, got %+v", tc.expected, purged)
+			}
+
+			if err = mock.SQL.ExpectationsWereMet(); err != nil {
+				t.Errorf("unmet expectations: %v", err)
+			}
+		})
+	}
+}
+
+func TestStore_GetMany(t *testing.T) {
+	mockContainer, mock := container.NewMockContainer(t)
+	ctx := &gofr.Context{
+		Context:   t.Context(),
+		Request:   nil,
+		Container: mockContainer,
+	}
+
+	taskStore := New()
+	query := "SELECT id, title, description, status, user_id, parent_id, due_date, version FROM tasks " +
+		"WHERE id IN (?, ?) AND deleted_at IS NULL FOR UPDATE"
+	columns := []string{"id", "title", "description", "status", "user_id", "parent_id", "due_date", "version"}
+
+	tests := []struct {
+		description   string
+		inputIDs      []int64
+		mockExpect    func()
+		expected      map[int64]models.Task
+		expectedError error
+	}{
+		{
+			description: "some missing",
+			inputIDs:    []int64{1, 2},
+			mockExpect: func() {
+				mock.SQL.ExpectQuery(query).WithArgs(int64(1), int64(2)).
+					WillReturnRows(sqlmock.NewRows(columns).AddRow(1, "task 1", "", false, 1, nil, nil, 3))
+			},
+			expected: map[int64]models.Task{1: {ID: 1, Title: "task 1", UserID: 1, Version: 3}},
+		},
+		{
+			description: "no ids",
+			mockExpect:  func() {},
+			expected:    map[int64]models.Task{},
+		},
+		{
+			description: "query error",
+			inputIDs:    []int64{1, 2},
+			mockExpect: func() {
+				mock.SQL.ExpectQuery(query).WithArgs(int64(1), int64(2)).WillReturnError(utils.ErrTest)
+			},
+			expectedError: utils.ErrTest,
+		},
+		{
+			description: "scan error",
+			inputIDs:    []int64{1, 2},
+			mockExpect: func() {
+				mock.SQL.ExpectQuery(query).WithArgs(int64(1), int64(2)).
+					WillReturnRows(sqlmock.NewRows(columns).AddRow("x", "task 1", "", false, 1, nil, nil, 3))
+			},
+			expectedError: errors.New("sql: Scan error"),
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.description, func(t *testing.T) {
+			tc.mockExpect()
+
+			res, err := taskStore.GetMany(ctx, tc.inputIDs)
+			if (err != nil) != (tc.expectedError != nil) {
+				t.Errorf("expected error: %v, got: %v", tc.expectedError, err)
+			}
+
+			if err == nil && !reflect.DeepEqual(res, tc.expected) {
+				t.Errorf("expected: %v, got: %v", tc.expected, res)
+			}
+		})
+	}
+
+	if err := mock.SQL.ExpectationsWereMet(); err != nil {
+		t.Errorf("unmet expectations: %v", err)
+	}
+}
+
+func TestStore_Page(t *testing.T) {
+	mockContainer, mock := container.NewMockContainer(t)
+	ctx := &gofr.Context{
+		Context:   t.Context(),
+		Request:   nil,
+		Container: mockContainer,
+	}
+
+	taskStore := New()
+	query := "SELECT id, title, description, status, user_id, parent_id, due_date, version FROM tasks " +
+		"WHERE id > ? AND deleted_at IS NULL ORDER BY id LIMIT ?"
+	tagQuery := "SELECT tt.task_id, t.name FROM task_tags tt JOIN tags t ON t.id = tt.tag_id " +
+		"WHERE tt.task_id IN (?, ?) ORDER BY tt.task_id, t.name"
+	columns := []string{"id", "title", "description", "status", "user_id", "parent_id", "due_date", "version"}
+
+	tests := []struct {
+		description   string
+		mockExpect    func()
+		expected      []models.Task
+		expectedError error
+	}{
+		{
+			description: "tasks with tags",
+			mockExpect: func() {
+				mock.SQL.ExpectQuery(query).WithArgs(int64(10), 2).WillReturnRows(sqlmock.NewRows(columns).
+					AddRow(11, "task 11", "", false, 1, nil, nil, 1).AddRow(12, "task 12", "", true, 2, nil, nil, 4))
+				mock.SQL.ExpectQuery(tagQuery).WithArgs(int64(11), int64(12)).
+					WillReturnRows(sqlmock.NewRows([]string{"task_id", "name"}).AddRow(12, "home").AddRow(12, "urgent"))
+			},
+			expected: []models.Task{
+				{ID: 11, Title: "task 11", UserID: 1, Version: 1},
+				{ID: 12, Title: "task 12", Status: true, UserID: 2, Tags: []string{"home", "urgent"}, Version: 4},
+			},
+		},
+		{
+			description: "last page",
+			mockExpect: func() {
+				mock.SQL.ExpectQuery(query).WithArgs(int64(10), 2).WillReturnRows(sqlmock.NewRows(columns))
+			},
+			expected: nil,
+		},
+		{
+			description: "query error",
+			mockExpect: func() {
+				mock.SQL.ExpectQuery(query).WithArgs(int64(10), 2).WillReturnError(utils.ErrTest)
+			},
+			expectedError: utils.ErrTest,
+		},
+		{
+			description: "tag query error",
+			mockExpect: func() {
+				mock.SQL.ExpectQuery(query).WithArgs(int64(10), 2).WillReturnRows(sqlmock.NewRows(columns).
+					AddRow(11, "task 11", "", false, 1, nil, nil, 1).AddRow(12, "task 12", "", true, 2, nil, nil, 4))
+				mock.SQL.ExpectQuery(tagQuery).WithArgs(int64(11), int64(12)).WillReturnError(utils.ErrTest)
+			},
+			expectedError: utils.ErrTest,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.description, func(t *testing.T) {
+			tc.mockExpect()
+
+			res, err := taskStore.Page(ctx, 10, 2)
+			if !errors.Is(err, tc.expectedError) {
+				t.Errorf("expected error: %v, got: %v", tc.expectedError, err)
+			}
+
+			if err == nil && !reflect.DeepEqual(res, tc.expected) {
+				t.Errorf("expected: %v, got: %v", tc.expected, res)
+			}
+		})
+	}
+
+	if err := mock.SQL.ExpectationsWereMet(); err != nil {
+		t.Errorf("unmet expectations: %v", err)
+	}
+}
+
+func TestStore_CreateBatch(t *testing.T) {
+	mockContainer, mock := container.NewMockContainer(t)
+	ctx := &gofr.Context{
+		Context:   t.Context(),
+		Request:   nil,
+		Container: mockContainer,
+	}
+
+	taskStore := New()
+	insertTask := "INSERT INTO tasks (title, description, status, user_id, parent_id, due_date, completed_at, created_at) " +
+		"VALUES (?, ?, ?, ?, ?, ?, ?, ?)"
+	tasks := []models.Task{
+		{Title: "first", UserID: 1, Tags: []string{"infra", "urgent"}, Checklist: []models.ChecklistItem{{Text: "step"}}},
+		// The tags collation is case-insensitive, so "Urgent" is the "urgent" tag.
+		{Title: "second", Status: true, UserID: 2, Tags: []string{"Urgent"}},
+	}
+	expectFirst := func() *sqlmock.ExpectedExec {
+		return mock.SQL.ExpectExec(insertTask).WithArgs("first", "", false, int64(1), nil, nil, nil, sqlmock.AnyArg())
+	}
+	// The IDs are 7 apart, as under Group Replication's auto_increment_increment.
+	expectTasks := func() {
+		expectFirst().WillReturnResult(sqlmock.NewResult(7, 1))
+		mock.SQL.ExpectExec(insertTask).WithArgs("second", "", true, int64(2), nil, nil, sqlmock.AnyArg(), sqlmock.AnyArg()).
+			WillReturnResult(sqlmock.NewResult(14, 1))
+	}
+
+	tests := []struct {
+		description   string
+		mockExpect    func()
+		expected      []int64
+		expectedError error
+	}{
+		{
+			description: "tasks with tags and checklist",
+			mockExpect: func() {
+				mock.SQL.ExpectBegin()
+				expectTasks()
+				mock.SQL.ExpectExec("INSERT INTO tags (name) VALUES (?), (?) ON DUPLICATE KEY UPDATE id = id").
+					WithArgs("infra", "urgent").WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.SQL.ExpectQuery("SELECT id, name FROM tags WHERE name IN (?, ?)").WithArgs("infra", "urgent").
+					WillReturnRows(sqlmock.NewRows([]string{"id", "name"}).AddRow(3, "infra").AddRow(4, "urgent"))
+				mock.SQL.ExpectExec("INSERT IGNORE INTO task_tags (task_id, tag_id) VALUES (?, ?), (?, ?), (?, ?)").
+					WithArgs(int64(7), int64(3), int64(7), int64(4), int64(14), int64(4)).WillReturnResult(sqlmock.NewResult(0, 3))
+				mock.SQL.ExpectExec("INSERT INTO task_checklist_items (task_id, position, text, done) VALUES (?, ?, ?, ?)").
+					WithArgs(int64(7), 0, "step", false).WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.SQL.ExpectCommit()
+			},
+			expected: []int64{7, 14},
+		},
+		{
+			description: "insert error",
+			mockExpect: func() {
+				mock.SQL.ExpectBegin()
+				expectFirst().WillReturnError(utils.ErrTest)
+				mock.SQL.ExpectRollback()
+			},
+			expectedError: utils.ErrTest,
+		},
+		{
+			description: "lastInsertId error",
+			mockExpect: func() {
+				mock.SQL.ExpectBegin()
+				expectFirst().WillReturnResult(lastInsertIDErrorResult{})
+				mock.SQL.ExpectRollback()
+			},
+			expectedError: utils.ErrTest,
+		},
+		{
+			description: "tag lookup error",
+			mockExpect: func() {
+				mock.SQL.ExpectBegin()
+				expectTasks()
+				mock.SQL.ExpectExec("INSERT INTO tags (name) VALUES (?), (?) ON DUPLICATE KEY UPDATE id = id").
+					WithArgs("infra", "urgent").WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.SQL.ExpectQuery("SELECT id, name FROM tags WHERE name IN (?, ?)").WithArgs("infra", "urgent").
+					WillReturnError(utils.ErrTest)
+				mock.SQL.ExpectRollback()
+			},
+			expectedError: utils.ErrTest,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.description, func(t *testing.T) {
+			tc.mockExpect()
+
+			ids, err := taskStore.CreateBatch(ctx, tasks)
+			if !errors.Is(err, tc.expectedError) {
+				t.Errorf("expected error: %v, got: %v", tc.expectedError, err)
+			}
+
+			if !reflect.DeepEqual(ids, tc.expected) {
+				t.Errorf("expected: %v, got: %v", tc.expected, ids)
+			}
+
+			if err = mock.SQL.ExpectationsWereMet(); err != nil {
+				t.Errorf("unmet expectations: %v", err)
+			}
+		})
+	}
+}
+
+func TestStore_PatchBatch(t *testing.T) {
+	mockContainer, mock := container.NewMockContainer(t)
+	ctx := &gofr.Context{
+		Context:   t.Context(),
+		Request:   nil,
+		Container: mockContainer,
+	}
+
+	taskStore := New()
+	desc, done := "renamed", true
+	dueDate := time.Date(2026, 11, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		description   string
+		input         []models.TaskPatch
+		mockExpect    func()
+		expectedError error
+	}{
+		{
+			description: "mixed patches",
+			input: []models.TaskPatch{
+				{ID: 1, Title: &desc, DueDate: &dueDate},
+				{ID: 2, Status: &done},
+				{ID: 3, ClearDueDate: true},
+			},
+			mockExpect: func() {
+				mock.SQL.ExpectExec("UPDATE tasks SET title = CASE id WHEN ? THEN ? ELSE title END, "+
+					"status = CASE id WHEN ? THEN ? ELSE status END, "+
+					"completed_at = CASE id WHEN ? THEN CASE WHEN ? THEN COALESCE(completed_at, ?) END ELSE completed_at END, "+
+					"due_date = CASE id WHEN ? THEN ? WHEN ? THEN ? ELSE due_date END, version = version + 1 "+
+					"WHERE id IN (?, ?, ?) AND deleted_at IS NULL").
+					WithArgs(int64(1), "renamed", int64(2), true, int64(2), true, sqlmock.AnyArg(),
+						int64(1), &dueDate, int64(3), nil, int64(1), int64(2), int64(3)).
+					WillReturnResult(sqlmock.NewResult(0, 3))
+			},
+		},
+		{
+			description: "no patches",
+			mockExpect:  func() {},
+		},
+		{
+			description: "exec error",
+			input:       []models.TaskPatch{{ID: 2, Status: &done}},
+			mockExpect: func() {
+				mock.SQL.ExpectExec("UPDATE tasks SET status = CASE id WHEN ? THEN ? ELSE status END, "+
+					"completed_at = CASE id WHEN ? THEN CASE WHEN ? THEN COALESCE(completed_at, ?) END ELSE completed_at END, "+
+					"version = version + 1 WHERE id IN (?) AND deleted_at IS NULL").
+					WithArgs(int64(2), true, int64(2), true, sqlmock.AnyArg(), int64(2)).
+					WillReturnError(utils.ErrTest)
+			},
+			expectedError: utils.ErrTest,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.description, func(t *testing.T) {
+			tc.mockExpect()
+
+			err := taskStore.PatchBatch(ctx, tc.input)
+			if !errors.Is(err, tc.expectedError) {
+				t.Errorf("expected error: %v, got: %v", tc.expectedError, err)
+			}
+		})
+	}
+
+	if err := mock.SQL.ExpectationsWereMet(); err != nil {
+		t.Errorf("unmet expectations: %v", err)
+	}
+}
+
+func TestStore_DeleteBatch(t *testing.T) {
+	mockContainer, mock := container.NewMockContainer(t)
+	ctx := &gofr.Context{
+		Context:   t.Context(),
+		Request:   nil,
+		Container: mockContainer,
+	}
+
+	taskStore := New()
+	trashQuery := "UPDATE tasks SET deleted_at = ?, version = version + 1 WHERE id IN (?, ?) AND deleted_at IS NULL"
+	selectSubtasks := "SELECT id, title, description, status, user_id, parent_id, due_date, version FROM tasks WHERE parent_id IN "
+	childrenQuery := selectSubtasks + "(?, ?) AND deleted_at IS NULL"
+	columns := []string{"id", "title", "description", "status", "user_id", "parent_id", "due_date", "version"}
+	parentID := int64(2)
+	ids := []int64{1, 2}
+
+	tests := []struct {
+		description   string
+		mockExpect    func()
+		expected      []models.Task
+		expectedError error
+	}{
+		{
+			description: "tasks and their subtasks",
+			mockExpect: func() {
+				mock.SQL.ExpectBegin()
+				mock.SQL.ExpectExec(trashQuery).WithArgs(sqlmock.AnyArg(), int64(1), int64(2)).
+					WillReturnResult(sqlmock.NewResult(0, 2))
+				mock.SQL.ExpectQuery(childrenQuery).WithArgs(int64(1), int64(2)).
+					WillReturnRows(sqlmock.NewRows(columns).AddRow(5, "sub", "", false, 1, 2, nil, 1))
+				mock.SQL.ExpectExec("UPDATE tasks SET deleted_at = ?, version = version + 1 WHERE id IN (?) AND deleted_at IS NULL").
+					WithArgs(sqlmock.AnyArg(), int64(5)).WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.SQL.ExpectQuery(selectSubtasks + "(?) AND deleted_at IS NULL").WithArgs(int64(5)).
+					WillReturnRows(sqlmock.NewRows(columns))
+				mock.SQL.ExpectCommit()
+			},
+			expected: []models.Task{{ID: 5, Title: "sub", UserID: 1, ParentID: &parentID, Version: 1}},
+		},
+		{
+			description: "exec error",
+			mockExpect: func() {
+				mock.SQL.ExpectBegin()
+				mock.SQL.ExpectExec(trashQuery).WithArgs(sqlmock.AnyArg(), int64(1), int64(2)).WillReturnError(utils.ErrTest)
+				mock.SQL.ExpectRollback()
+			},
+			expectedError: utils.ErrTest,
+		},
+		{
+			description: "subtasks query error",
+			mockExpect: func() {
+				mock.SQL.ExpectBegin()
+				mock.SQL.ExpectExec(trashQuery).WithArgs(sqlmock.AnyArg(), int64(1), int64(2)).
+					WillReturnResult(sqlmock.NewResult(0, 2))
+				mock.SQL.ExpectQuery(childrenQuery).WithArgs(int64(1), int64(2)).WillReturnError(utils.ErrTest)
+				mock.SQL.ExpectRollback()
+			},
+			expectedError: utils.ErrTest,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.description, func(t *testing.T) {
+			tc.mockExpect()
+
+			subtasks, err := taskStore.DeleteBatch(ctx, ids)
+			if !errors.Is(err, tc.expectedError) {
+				t.Errorf("expected error: %v, got: %v", tc.expectedError, err)
+			}
+
+			if !reflect.DeepEqual(subtasks, tc.expected) {
+				t.Errorf("expected subtasks %+v, got %+v", tc.expected, subtasks)
+			}
+
+			if ids[0] != 1 || ids[1] != 2 {
+				t.Errorf("expected the ids to be left alone, got %v", ids)
+			}
+
+			if err = mock.SQL.ExpectationsWereMet(); err != nil {
+				t.Errorf("unmet expectations: %v", err)
+			}
 		})
 	}
 }
@@ -0,0 +1,105 @@
+package template
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+
+	"gofr.dev/pkg/gofr"
+
+	"TaskManager2/apperr"
+	"TaskManager2/models"
+	"TaskManager2/utils"
+)
+
+type store struct {
+}
+
+func New() *store {
+	return &store{}
+}
+
+func (store) Create(ctx *gofr.Context, t *models.Template) (int64, error) {
+	db := utils.DB(ctx)
+
+	definition, err := json.Marshal(t.Tasks)
+	if err != nil {
+		return 0, err
+	}
+
+	res, err := db.Exec("INSERT INTO task_templates (name, definition) VALUES (?, ?)", t.Name, definition)
+	if err != nil {
+		return 0, err
+	}
+
+	id, err := res.LastInsertId()
+	if err != nil {
+		return 0, err
+	}
+
+	return id, nil
+}
+
+func (store) GetAll(ctx *gofr.Context) ([]models.Template, error) {
+	db := utils.DB(ctx)
+
+	rows, err := db.Query("SELECT id, name, definition FROM task_templates")
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	var templates []models.Template
+
+	for rows.Next() {
+		var (
+			t          models.Template
+			definition []byte
+		)
+
+		err = rows.Scan(&t.ID, &t.Name, &definition)
+		if err != nil {
+			return nil, err
+		}
+
+		err = json.Unmarshal(definition, &t.Tasks)
+		if err != nil {
+			return nil, err
+		}
+
+		templates = append(templates, t)
+	}
+
+	if rows.Err() != nil {
+		return nil, rows.Err()
+	}
+
+	return templates, nil
+}
+
+func (store) GetByID(ctx *gofr.Context, id int64) (*models.Template, error) {
+	db := utils.DB(ctx)
+	row := db.QueryRow("SELECT id, name, definition FROM task_templates WHERE id = ?", id)
+
+	var (
+		t          models.Template
+		definition []byte
+	)
+
+	err := row.Scan(&t.ID, &t.Name, &definition)
+	if errors.Is(err, sql.ErrNoRows) {
+		return &models.Template{}, apperr.NotFound("template", id)
+	}
+
+	if err != nil {
+		return &models.Template{}, err
+	}
+
+	err = json.Unmarshal(definition, &t.Tasks)
+	if err != nil {
+		return &models.Template{}, err
+	}
+
+	return &t, nil
+}
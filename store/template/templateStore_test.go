@@ -0,0 +1,184 @@
+package template
+
+import (
+	"database/sql"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"gofr.dev/pkg/gofr"
+	"gofr.dev/pkg/gofr/container"
+
+	"TaskManager2/models"
+	"TaskManager2/utils"
+)
+
+func TestStore_Create(t *testing.T) {
+	mockContainer, mock := container.NewMockContainer(t)
+	ctx := &gofr.Context{
+		Context:   t.Context(),
+		Request:   nil,
+		Container: mockContainer,
+	}
+
+	templateStore := New()
+	query := "INSERT INTO task_templates (name, definition) VALUES (?, ?)"
+	input := &models.Template{Name: "release", Tasks: []models.TemplateTask{{Title: "ship {{release_version}}"}}}
+	definition := []byte(`[{"title":"ship {{release_version}}"}]`)
+
+	tests := []struct {
+		description   string
+		mockExpect    func()
+		wantID        int64
+		expectedError bool
+	}{
+		{
+			description: "success",
+			mockExpect: func() {
+				mock.SQL.ExpectExec(query).
+					WithArgs("release", definition).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+			},
+			wantID:        1,
+			expectedError: false,
+		},
+		{
+			description: "exec error",
+			mockExpect: func() {
+				mock.SQL.ExpectExec(query).
+					WithArgs("release", definition).
+					WillReturnError(utils.ErrTest)
+			},
+			expectedError: true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.description, func(t *testing.T) {
+			tc.mockExpect()
+
+			id, err := templateStore.Create(ctx, input)
+
+			if (err != nil) != tc.expectedError {
+				t.Errorf("expected err: %v, got: %v", tc.expectedError, err)
+			}
+
+			if id != tc.wantID {
+				t.Errorf("expected id: %d, got: %d", tc.wantID, id)
+			}
+		})
+	}
+}
+
+func TestStore_GetAll(t *testing.T) {
+	mockContainer, mock := container.NewMockContainer(t)
+	ctx := &gofr.Context{
+		Context:   t.Context(),
+		Request:   nil,
+		Container: mockContainer,
+	}
+
+	templateStore := New()
+	query := "SELECT id, name, definition FROM task_templates"
+
+	tests := []struct {
+		description   string
+		mockExpect    func()
+		wantLen       int
+		expectedError bool
+	}{
+		{
+			description: "success",
+			mockExpect: func() {
+				rows := sqlmock.NewRows([]string{"id", "name", "definition"}).
+					AddRow(1, "release", `[{"title":"ship"}]`)
+				mock.SQL.ExpectQuery(query).WillReturnRows(rows)
+			},
+			wantLen:       1,
+			expectedError: false,
+		},
+		{
+			description: "query error",
+			mockExpect: func() {
+				mock.SQL.ExpectQuery(query).WillReturnError(utils.ErrTest)
+			},
+			expectedError: true,
+		},
+		{
+			description: "invalid definition",
+			mockExpect: func() {
+				rows := sqlmock.NewRows([]string{"id", "name", "definition"}).
+					AddRow(1, "release", `{`)
+				mock.SQL.ExpectQuery(query).WillReturnRows(rows)
+			},
+			expectedError: true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.description, func(t *testing.T) {
+			tc.mockExpect()
+
+			templates, err := templateStore.GetAll(ctx)
+
+			if (err != nil) != tc.expectedError {
+				t.Errorf("expected error = %v, got = %v", tc.expectedError, err)
+			}
+
+			if len(templates) != tc.wantLen {
+				t.Errorf("expected template count = %d, got = %d", tc.wantLen, len(templates))
+			}
+		})
+	}
+}
+
+func TestStore_GetByID(t *testing.T) {
+	mockContainer, mock := container.NewMockContainer(t)
+	ctx := &gofr.Context{
+		Context:   t.Context(),
+		Request:   nil,
+		Container: mockContainer,
+	}
+
+	templateStore := New()
+	query := "SELECT id, name, definition FROM task_templates WHERE id = ?"
+
+	tests := []struct {
+		description   string
+		mockExpect    func()
+		wantTasks     int
+		expectedError bool
+	}{
+		{
+			description: "success",
+			mockExpect: func() {
+				rows := sqlmock.NewRows([]string{"id", "name", "definition"}).
+					AddRow(1, "release", `[{"title":"ship","subtasks":[{"title":"tag"}]}]`)
+				mock.SQL.ExpectQuery(query).WithArgs(1).WillReturnRows(rows)
+			},
+			wantTasks:     1,
+			expectedError: false,
+		},
+		{
+			description: "not found",
+			mockExpect: func() {
+				mock.SQL.ExpectQuery(query).WithArgs(1).WillReturnError(sql.ErrNoRows)
+			},
+			expectedError: true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.description, func(t *testing.T) {
+			tc.mockExpect()
+
+			got, err := templateStore.GetByID(ctx, 1)
+			if (err != nil) != tc.expectedError {
+				t.Errorf("expected error = %v, got error = %v", tc.expectedError, err)
+			}
+
+			if len(got.Tasks) != tc.wantTasks {
+				t.Errorf("expected %d tasks, got %d", tc.wantTasks, len(got.Tasks))
+			}
+		})
+	}
+}
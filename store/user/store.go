@@ -1,11 +1,21 @@
 package user
 
 import (
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/go-sql-driver/mysql"
 	"gofr.dev/pkg/gofr"
 
+	"TaskManager2/apperr"
 	"TaskManager2/models"
+	"TaskManager2/utils"
 )
 
+// errDuplicateEntry is the MySQL error number for a unique key violation.
+const errDuplicateEntry = 1062
+
 type store struct {
 }
 
@@ -14,9 +24,13 @@ func New() *store {
 }
 
 func (store) Create(ctx *gofr.Context, u *models.User) (int64, error) {
-	db := ctx.SQL
+	db := utils.DB(ctx)
 
 	res, err := db.Exec("INSERT INTO users (name, email) VALUES ( ?, ?)", u.Name, u.Email)
+	if isDuplicateKey(err) {
+		return 0, apperr.Conflict(fmt.Sprintf("a user with email %s already exists", u.Email))
+	}
+
 	if err != nil {
 		return 0, err
 	}
@@ -30,15 +44,44 @@ func (store) Create(ctx *gofr.Context, u *models.User) (int64, error) {
 }
 
 func (store) GetByID(ctx *gofr.Context, id int64) (*models.User, error) {
-	db := ctx.SQL
+	db := utils.DB(ctx)
 	row := db.QueryRow("SELECT id, name, email FROM users WHERE id = ?", id)
 
 	var u models.User
 
 	err := row.Scan(&u.ID, &u.Name, &u.Email)
+	if errors.Is(err, sql.ErrNoRows) {
+		return &models.User{}, apperr.NotFound("user", id)
+	}
+
+	if err != nil {
+		return &models.User{}, err
+	}
+
+	return &u, nil
+}
+
+// GetByEmail looks the user up by its normalised email address.
+func (store) GetByEmail(ctx *gofr.Context, email string) (*models.User, error) {
+	db := utils.DB(ctx)
+	row := db.QueryRow("SELECT id, name, email FROM users WHERE email = ?", email)
+
+	var u models.User
+
+	err := row.Scan(&u.ID, &u.Name, &u.Email)
+	if errors.Is(err, sql.ErrNoRows) {
+		return &models.User{}, apperr.NotFound("user", email)
+	}
+
 	if err != nil {
 		return &models.User{}, err
 	}
 
 	return &u, nil
 }
+
+func isDuplicateKey(err error) bool {
+	var mysqlErr *mysql.MySQLError
+
+	return errors.As(err, &mysqlErr) && mysqlErr.Number == errDuplicateEntry
+}
@@ -1,12 +1,17 @@
 package user
 
 import (
+	"database/sql"
+	"errors"
+	"reflect"
 	"testing"
 
 	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/go-sql-driver/mysql"
 	"gofr.dev/pkg/gofr"
 	"gofr.dev/pkg/gofr/container"
 
+	"TaskManager2/apperr"
 	"TaskManager2/models"
 	"TaskManager2/utils"
 )
@@ -38,6 +43,7 @@ func TestStore_Create(t *testing.T) {
 		mockExpect    func()
 		wantID        int64
 		expectedError bool
+		errCode       apperr.Code
 	}{
 		{
 			description: "success",
@@ -59,6 +65,17 @@ func TestStore_Create(t *testing.T) {
 			wantID:        0,
 			expectedError: true,
 		},
+		{
+			description: "duplicate email",
+			input:       &models.User{Name: "dup", Email: "test@example.com"},
+			mockExpect: func() {
+				mock.SQL.ExpectExec(query).WithArgs("dup", "test@example.com").
+					WillReturnError(&mysql.MySQLError{Number: 1062, Message: "Duplicate entry 'test@example.com' for key 'idx_users_email'"})
+			},
+			wantID:        0,
+			expectedError: true,
+			errCode:       apperr.CodeConflict,
+		},
 		{
 			description: "last inserted error",
 			input:       &models.User{Name: "test", Email: "test@example.com"},
@@ -81,6 +98,10 @@ func TestStore_Create(t *testing.T) {
 		if id != tc.wantID {
 			t.Errorf("expected id: %v, got: %v", tc.wantID, id)
 		}
+
+		if apperr.CodeOf(err) != tc.errCode {
+			t.Errorf("expected error code: %q, got: %q", tc.errCode, apperr.CodeOf(err))
+		}
 	}
 }
 
@@ -112,6 +133,15 @@ func TestStore_GetByID(t *testing.T) {
 			want:          &models.User{ID: 1, Name: "test", Email: "test"},
 			expectedError: false,
 		},
+		{
+			description: "not found",
+			inputID:     2,
+			mockExpect: func() {
+				mock.SQL.ExpectQuery(query).WithArgs(2).WillReturnError(sql.ErrNoRows)
+			},
+			want:          &models.User{},
+			expectedError: true,
+		},
 		{
 			description: "scan error",
 			inputID:     1,
@@ -139,3 +169,62 @@ func TestStore_GetByID(t *testing.T) {
 		})
 	}
 }
+
+func TestStore_GetByEmail(t *testing.T) {
+	mockContainer, mock := container.NewMockContainer(t)
+	ctx := &gofr.Context{
+		Context:   t.Context(),
+		Request:   nil,
+		Container: mockContainer,
+	}
+
+	userStore := New()
+	query := "SELECT id, name, email FROM users WHERE email = ?"
+
+	testcases := []struct {
+		description   string
+		mockExpect    func()
+		want          *models.User
+		expectedError error
+	}{
+		{
+			description: "success",
+			mockExpect: func() {
+				rows := sqlmock.NewRows([]string{"id", "name", "email"}).AddRow(1, "test", "test@example.com")
+				mock.SQL.ExpectQuery(query).WithArgs("test@example.com").WillReturnRows(rows)
+			},
+			want: &models.User{ID: 1, Name: "test", Email: "test@example.com"},
+		},
+		{
+			description: "not found",
+			mockExpect: func() {
+				mock.SQL.ExpectQuery(query).WithArgs("test@example.com").WillReturnError(sql.ErrNoRows)
+			},
+			want:          &models.User{},
+			expectedError: apperr.NotFound("user", "test@example.com"),
+		},
+		{
+			description: "query error",
+			mockExpect: func() {
+				mock.SQL.ExpectQuery(query).WithArgs("test@example.com").WillReturnError(utils.ErrTest)
+			},
+			want:          &models.User{},
+			expectedError: utils.ErrTest,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.description, func(t *testing.T) {
+			tc.mockExpect()
+
+			user, err := userStore.GetByEmail(ctx, "test@example.com")
+			if !errors.Is(err, tc.expectedError) {
+				t.Errorf("expected err: %v, got: %v", tc.expectedError, err)
+			}
+
+			if !reflect.DeepEqual(user, tc.want) {
+				t.Errorf("expected user: %v, got: %v", tc.want, user)
+			}
+		})
+	}
+}
@@ -0,0 +1,422 @@
+package view
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/go-sql-driver/mysql"
+	"gofr.dev/pkg/gofr"
+
+	"TaskManager2/apperr"
+	"TaskManager2/models"
+	"TaskManager2/utils"
+)
+
+// errDuplicateEntry is the MySQL error number for a unique key violation.
+const errDuplicateEntry = 1062
+
+const viewColumns = "id, owner, name, visibility, filter, sort, columns, group_by, version, updated_at"
+
+type store struct {
+}
+
+func New() *store {
+	return &store{}
+}
+
+func (store) Create(ctx *gofr.Context, v *models.View) (int64, error) {
+	db := utils.DB(ctx)
+
+	sort, columns, err := marshalLists(v)
+	if err != nil {
+		return 0, err
+	}
+
+	res, err := db.Exec("INSERT INTO views (owner, name, visibility, filter, sort, columns, group_by, version, updated_at) "+
+		"VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)", v.Owner, v.Name, v.Visibility, v.Filter, sort, columns, v.GroupBy, v.Version, v.UpdatedAt)
+	if isDuplicateKey(err) {
+		return 0, apperr.Conflict(fmt.Sprintf("you already have a view named %q", v.Name))
+	}
+
+	if err != nil {
+		return 0, err
+	}
+
+	id, err := res.LastInsertId()
+	if err != nil {
+		return 0, err
+	}
+
+	err = insertTags(db, id, v.Tags)
+	if err != nil {
+		return 0, err
+	}
+
+	return id, insertMembers(db, id, v.SharedWith)
+}
+
+func (store) GetByID(ctx *gofr.Context, id int64) (*models.View, error) {
+	db := utils.DB(ctx)
+
+	v, err := scanView(db.QueryRow("SELECT "+viewColumns+" FROM views WHERE id = ?", id))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, apperr.NotFound("view", id)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	views := []models.View{v}
+
+	err = loadDetails(db, views)
+	if err != nil {
+		return nil, err
+	}
+
+	return &views[0], nil
+}
+
+// GetVisible lists the views owned by owner and the views others shared with
+// owner, by ID.
+func (store) GetVisible(ctx *gofr.Context, owner string) ([]models.View, error) {
+	db := utils.DB(ctx)
+
+	rows, err := db.Query("SELECT "+viewColumns+" FROM views WHERE owner = ? "+
+		"OR id IN (SELECT view_id FROM view_members WHERE actor = ?) ORDER BY id", owner, owner)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	var views []models.View
+
+	for rows.Next() {
+		var v models.View
+
+		v, err = scanView(rows)
+		if err != nil {
+			return nil, err
+		}
+
+		views = append(views, v)
+	}
+
+	if rows.Err() != nil {
+		return nil, rows.Err()
+	}
+
+	err = loadDetails(db, views)
+	if err != nil {
+		return nil, err
+	}
+
+	return views, nil
+}
+
+// Update replaces the view, provided it is still at v.Version, and increments
+// the version.
+func (store) Update(ctx *gofr.Context, v *models.View) error {
+	db := utils.DB(ctx)
+
+	sort, columns, err := marshalLists(v)
+	if err != nil {
+		return err
+	}
+
+	res, err := db.Exec("UPDATE views SET name = ?, visibility = ?, filter = ?, sort = ?, columns = ?, group_by = ?, "+
+		"version = version + 1, updated_at = ? WHERE id = ? AND version = ?",
+		v.Name, v.Visibility, v.Filter, sort, columns, v.GroupBy, v.UpdatedAt, v.ID, v.Version)
+	if isDuplicateKey(err) {
+		return apperr.Conflict(fmt.Sprintf("you already have a view named %q", v.Name))
+	}
+
+	if err != nil {
+		return err
+	}
+
+	n, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if n == 0 {
+		return apperr.PreconditionFailed(fmt.Sprintf("view %d was modified concurrently", v.ID))
+	}
+
+	_, err = db.Exec("DELETE FROM view_tags WHERE view_id = ?", v.ID)
+	if err != nil {
+		return err
+	}
+
+	err = insertTags(db, v.ID, v.Tags)
+	if err != nil {
+		return err
+	}
+
+	_, err = db.Exec("DELETE FROM view_members WHERE view_id = ?", v.ID)
+	if err != nil {
+		return err
+	}
+
+	return insertMembers(db, v.ID, v.SharedWith)
+}
+
+func (store) Delete(ctx *gofr.Context, id int64) error {
+	_, err := utils.DB(ctx).Exec("DELETE FROM views WHERE id = ?", id)
+
+	return err
+}
+
+// TagIDs looks up the IDs of the named tags. Unknown names are left out.
+func (store) TagIDs(ctx *gofr.Context, names []string) (map[string]int64, error) {
+	ids := make(map[string]int64, len(names))
+	if len(names) == 0 {
+		return ids, nil
+	}
+
+	args := make([]any, len(names))
+	for i, name := range names {
+		args[i] = name
+	}
+
+	err := queryTags(utils.DB(ctx), "SELECT id, name FROM tags WHERE name IN ("+placeholders(len(names))+")", args,
+		func(id int64, name string) { ids[name] = id })
+	if err != nil {
+		return nil, err
+	}
+
+	return ids, nil
+}
+
+// TagNames looks up the current names of the tags. Unknown IDs are left out.
+func (store) TagNames(ctx *gofr.Context, ids []int64) (map[int64]string, error) {
+	names := make(map[int64]string, len(ids))
+	if len(ids) == 0 {
+		return names, nil
+	}
+
+	args := make([]any, len(ids))
+	for i, id := range ids {
+		args[i] = id
+	}
+
+	err := queryTags(utils.DB(ctx), "SELECT id, name FROM tags WHERE id IN ("+placeholders(len(ids))+")", args,
+		func(id int64, name string) { names[id] = name })
+	if err != nil {
+		return nil, err
+	}
+
+	return names, nil
+}
+
+func queryTags(db utils.Executor, query string, args []any, fn func(int64, string)) error {
+	rows, err := db.Query(query, args...)
+	if err != nil {
+		return err
+	}
+
+	defer rows.Close()
+
+	for rows.Next() {
+		var (
+			id   int64
+			name string
+		)
+
+		err = rows.Scan(&id, &name)
+		if err != nil {
+			return err
+		}
+
+		fn(id, name)
+	}
+
+	return rows.Err()
+}
+
+func insertTags(db utils.Executor, viewID int64, tags []models.ViewTag) error {
+	if len(tags) == 0 {
+		return nil
+	}
+
+	args := make([]any, 0, len(tags)*3)
+	for _, t := range tags {
+		args = append(args, viewID, t.ID, t.Name)
+	}
+
+	_, err := db.Exec("INSERT INTO view_tags (view_id, tag_id, name) VALUES "+
+		strings.TrimSuffix(strings.Repeat("(?, ?, ?), ", len(tags)), ", "), args...)
+
+	return err
+}
+
+func insertMembers(db utils.Executor, viewID int64, actors []string) error {
+	if len(actors) == 0 {
+		return nil
+	}
+
+	args := make([]any, 0, len(actors)*2)
+	for _, actor := range actors {
+		args = append(args, viewID, actor)
+	}
+
+	_, err := db.Exec("INSERT INTO view_members (view_id, actor) VALUES "+
+		strings.TrimSuffix(strings.Repeat("(?, ?), ", len(actors)), ", "), args...)
+
+	return err
+}
+
+// loadDetails fills in the tags and members of each view.
+func loadDetails(db utils.Executor, views []models.View) error {
+	err := loadTags(db, views)
+	if err != nil {
+		return err
+	}
+
+	return loadMembers(db, views)
+}
+
+// loadTags fills in the tags pinned by each view.
+func loadTags(db utils.Executor, views []models.View) error {
+	if len(views) == 0 {
+		return nil
+	}
+
+	index := make(map[int64]int, len(views))
+	args := make([]any, len(views))
+
+	for i, v := range views {
+		index[v.ID] = i
+		args[i] = v.ID
+	}
+
+	rows, err := db.Query("SELECT view_id, tag_id, name FROM view_tags WHERE view_id IN ("+placeholders(len(views))+") "+
+		"ORDER BY view_id, tag_id", args...)
+	if err != nil {
+		return err
+	}
+
+	defer rows.Close()
+
+	for rows.Next() {
+		var (
+			viewID int64
+			t      models.ViewTag
+		)
+
+		err = rows.Scan(&viewID, &t.ID, &t.Name)
+		if err != nil {
+			return err
+		}
+
+		i := index[viewID]
+		views[i].Tags = append(views[i].Tags, t)
+	}
+
+	return rows.Err()
+}
+
+// loadMembers fills in the users each view is shared with.
+func loadMembers(db utils.Executor, views []models.View) error {
+	if len(views) == 0 {
+		return nil
+	}
+
+	index := make(map[int64]int, len(views))
+	args := make([]any, len(views))
+
+	for i, v := range views {
+		index[v.ID] = i
+		args[i] = v.ID
+	}
+
+	rows, err := db.Query("SELECT view_id, actor FROM view_members WHERE view_id IN ("+placeholders(len(views))+") "+
+		"ORDER BY view_id, actor", args...)
+	if err != nil {
+		return err
+	}
+
+	defer rows.Close()
+
+	for rows.Next() {
+		var (
+			viewID int64
+			actor  string
+		)
+
+		err = rows.Scan(&viewID, &actor)
+		if err != nil {
+			return err
+		}
+
+		i := index[viewID]
+		views[i].SharedWith = append(views[i].SharedWith, actor)
+	}
+
+	return rows.Err()
+}
+
+type scanner interface {
+	Scan(dest ...any) error
+}
+
+func scanView(row scanner) (models.View, error) {
+	var (
+		v             models.View
+		sort, columns []byte
+	)
+
+	err := row.Scan(&v.ID, &v.Owner, &v.Name, &v.Visibility, &v.Filter, &sort, &columns, &v.GroupBy, &v.Version, &v.UpdatedAt)
+	if err != nil {
+		return models.View{}, err
+	}
+
+	err = json.Unmarshal(sort, &v.Sort)
+	if err != nil {
+		return models.View{}, err
+	}
+
+	err = json.Unmarshal(columns, &v.Columns)
+	if err != nil {
+		return models.View{}, err
+	}
+
+	return v, nil
+}
+
+func marshalLists(v *models.View) (sort, columns []byte, err error) {
+	sort, err = json.Marshal(nonNil(v.Sort))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	columns, err = json.Marshal(nonNil(v.Columns))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return sort, columns, nil
+}
+
+func nonNil(s []string) []string {
+	if s == nil {
+		return []string{}
+	}
+
+	return s
+}
+
+func placeholders(n int) string {
+	return strings.TrimSuffix(strings.Repeat("?, ", n), ", ")
+}
+
+func isDuplicateKey(err error) bool {
+	var mysqlErr *mysql.MySQLError
+
+	return errors.As(err, &mysqlErr) && mysqlErr.Number == errDuplicateEntry
+}
@@ -0,0 +1,324 @@
+package view
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/go-sql-driver/mysql"
+	"gofr.dev/pkg/gofr"
+	"gofr.dev/pkg/gofr/container"
+
+	"TaskManager2/apperr"
+	"TaskManager2/models"
+	"TaskManager2/utils"
+)
+
+const (
+	selectView     = "SELECT " + viewColumns + " FROM views WHERE id = ?"
+	selectViewTags = "SELECT view_id, tag_id, name FROM view_tags WHERE view_id IN (?) ORDER BY view_id, tag_id"
+	selectMembers  = "SELECT view_id, actor FROM view_members WHERE view_id IN (?) ORDER BY view_id, actor"
+)
+
+var columns = []string{"id", "owner", "name", "visibility", "filter", "sort", "columns", "group_by", "version", "updated_at"}
+
+func TestStore_Create(t *testing.T) {
+	mockContainer, mock := container.NewMockContainer(t)
+	ctx := &gofr.Context{
+		Context:   t.Context(),
+		Request:   nil,
+		Container: mockContainer,
+	}
+
+	viewStore := New()
+	insertView := "INSERT INTO views (owner, name, visibility, filter, sort, columns, group_by, version, updated_at) " +
+		"VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)"
+	insertTags := "INSERT INTO view_tags (view_id, tag_id, name) VALUES (?, ?, ?), (?, ?, ?)"
+	updatedAt := time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC)
+	v := &models.View{
+		Owner: "7", Name: "Bugs", Visibility: models.ViewPrivate, Filter: "tag:bug OR tag:ops",
+		Sort: []string{"-due"}, Version: 1, UpdatedAt: updatedAt,
+		Tags: []models.ViewTag{{ID: 3, Name: "bug"}, {ID: 4, Name: "ops"}},
+	}
+
+	tests := []struct {
+		description   string
+		mockExpect    func()
+		expectedID    int64
+		expectedError error
+	}{
+		{
+			description: "success",
+			mockExpect: func() {
+				mock.SQL.ExpectExec(insertView).
+					WithArgs("7", "Bugs", "private", "tag:bug OR tag:ops", []byte(`["-due"]`), []byte(`[]`), "", 1, updatedAt).
+					WillReturnResult(sqlmock.NewResult(9, 1))
+				mock.SQL.ExpectExec(insertTags).WithArgs(9, 3, "bug", 9, 4, "ops").WillReturnResult(sqlmock.NewResult(0, 2))
+			},
+			expectedID: 9,
+		},
+		{
+			description: "duplicate name",
+			mockExpect: func() {
+				mock.SQL.ExpectExec(insertView).WillReturnError(&mysql.MySQLError{Number: 1062})
+			},
+			expectedError: apperr.Conflict(`you already have a view named "Bugs"`),
+		},
+		{
+			description: "exec error",
+			mockExpect: func() {
+				mock.SQL.ExpectExec(insertView).WillReturnError(utils.ErrTest)
+			},
+			expectedError: utils.ErrTest,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.description, func(t *testing.T) {
+			tc.mockExpect()
+
+			id, err := viewStore.Create(ctx, v)
+			if !errors.Is(err, tc.expectedError) {
+				t.Errorf("expected error %v, got %v", tc.expectedError, err)
+			}
+
+			if id != tc.expectedID {
+				t.Errorf("expected id %d, got %d", tc.expectedID, id)
+			}
+		})
+	}
+}
+
+func TestStore_GetByID(t *testing.T) {
+	mockContainer, mock := container.NewMockContainer(t)
+	ctx := &gofr.Context{
+		Context:   t.Context(),
+		Request:   nil,
+		Container: mockContainer,
+	}
+
+	viewStore := New()
+	updatedAt := time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		description   string
+		mockExpect    func()
+		expected      *models.View
+		expectedError error
+	}{
+		{
+			description: "success",
+			mockExpect: func() {
+				mock.SQL.ExpectQuery(selectView).WithArgs(9).WillReturnRows(sqlmock.NewRows(columns).
+					AddRow(9, "7", "Bugs", "shared", "tag:bug", []byte(`["-due"]`), []byte(`["id","title"]`), "status", 2, updatedAt))
+				mock.SQL.ExpectQuery(selectViewTags).WithArgs(9).
+					WillReturnRows(sqlmock.NewRows([]string{"view_id", "tag_id", "name"}).AddRow(9, 3, "bug"))
+				mock.SQL.ExpectQuery(selectMembers).WithArgs(9).
+					WillReturnRows(sqlmock.NewRows([]string{"view_id", "actor"}).AddRow(9, "8").AddRow(9, "9"))
+			},
+			expected: &models.View{
+				ID: 9, Owner: "7", Name: "Bugs", Visibility: "shared", SharedWith: []string{"8", "9"}, Filter: "tag:bug",
+				Sort: []string{"-due"}, Columns: []string{"id", "title"}, GroupBy: "status", Version: 2, UpdatedAt: updatedAt,
+				Tags: []models.ViewTag{{ID: 3, Name: "bug"}},
+			},
+		},
+		{
+			description: "not found",
+			mockExpect: func() {
+				mock.SQL.ExpectQuery(selectView).WithArgs(9).WillReturnRows(sqlmock.NewRows(columns))
+			},
+			expectedError: apperr.NotFound("view", 9),
+		},
+		{
+			description: "members error",
+			mockExpect: func() {
+				mock.SQL.ExpectQuery(selectView).WithArgs(9).WillReturnRows(sqlmock.NewRows(columns).
+					AddRow(9, "7", "Bugs", "shared", "", []byte(`[]`), []byte(`[]`), "", 1, updatedAt))
+				mock.SQL.ExpectQuery(selectViewTags).WillReturnRows(sqlmock.NewRows([]string{"view_id", "tag_id", "name"}))
+				mock.SQL.ExpectQuery(selectMembers).WillReturnError(utils.ErrTest)
+			},
+			expectedError: utils.ErrTest,
+		},
+		{
+			description: "tags error",
+			mockExpect: func() {
+				mock.SQL.ExpectQuery(selectView).WithArgs(9).WillReturnRows(sqlmock.NewRows(columns).
+					AddRow(9, "7", "Bugs", "shared", "", []byte(`[]`), []byte(`[]`), "", 1, updatedAt))
+				mock.SQL.ExpectQuery(selectViewTags).WillReturnError(utils.ErrTest)
+			},
+			expectedError: utils.ErrTest,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.description, func(t *testing.T) {
+			tc.mockExpect()
+
+			v, err := viewStore.GetByID(ctx, 9)
+			if !errors.Is(err, tc.expectedError) {
+				t.Errorf("expected error %v, got %v", tc.expectedError, err)
+			}
+
+			if !reflect.DeepEqual(v, tc.expected) {
+				t.Errorf("expected %+v, got %+v", tc.expected, v)
+			}
+		})
+	}
+}
+
+func TestStore_GetVisible(t *testing.T) {
+	mockContainer, mock := container.NewMockContainer(t)
+	ctx := &gofr.Context{
+		Context:   t.Context(),
+		Request:   nil,
+		Container: mockContainer,
+	}
+
+	viewStore := New()
+	updatedAt := time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC)
+	selectViews := "SELECT " + viewColumns + " FROM views WHERE owner = ? " +
+		"OR id IN (SELECT view_id FROM view_members WHERE actor = ?) ORDER BY id"
+
+	mock.SQL.ExpectQuery(selectViews).WithArgs("7", "7").WillReturnRows(sqlmock.NewRows(columns).
+		AddRow(1, "7", "Mine", "private", "tag:bug", []byte(`[]`), []byte(`[]`), "", 1, updatedAt).
+		AddRow(2, "8", "Theirs", "shared", "tag:ops", []byte(`[]`), []byte(`[]`), "", 1, updatedAt))
+	mock.SQL.ExpectQuery("SELECT view_id, tag_id, name FROM view_tags WHERE view_id IN (?, ?) ORDER BY view_id, tag_id").
+		WithArgs(1, 2).
+		WillReturnRows(sqlmock.NewRows([]string{"view_id", "tag_id", "name"}).AddRow(1, 3, "bug").AddRow(2, 4, "ops"))
+	mock.SQL.ExpectQuery("SELECT view_id, actor FROM view_members WHERE view_id IN (?, ?) ORDER BY view_id, actor").
+		WithArgs(1, 2).WillReturnRows(sqlmock.NewRows([]string{"view_id", "actor"}).AddRow(2, "7"))
+
+	views, err := viewStore.GetVisible(ctx, "7")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(views) != 2 || !reflect.DeepEqual(views[1].Tags, []models.ViewTag{{ID: 4, Name: "ops"}}) ||
+		!reflect.DeepEqual(views[1].SharedWith, []string{"7"}) {
+		t.Errorf("unexpected views %+v", views)
+	}
+
+	mock.SQL.ExpectQuery(selectViews).WillReturnError(utils.ErrTest)
+
+	_, err = viewStore.GetVisible(ctx, "7")
+	if !errors.Is(err, utils.ErrTest) {
+		t.Errorf("expected %v, got %v", utils.ErrTest, err)
+	}
+}
+
+func TestStore_Update(t *testing.T) {
+	mockContainer, mock := container.NewMockContainer(t)
+	ctx := &gofr.Context{
+		Context:   t.Context(),
+		Request:   nil,
+		Container: mockContainer,
+	}
+
+	viewStore := New()
+	update := "UPDATE views SET name = ?, visibility = ?, filter = ?, sort = ?, columns = ?, group_by = ?, " +
+		"version = version + 1, updated_at = ? WHERE id = ? AND version = ?"
+	updatedAt := time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC)
+	v := &models.View{
+		ID: 9, Name: "Bugs", Visibility: "shared", SharedWith: []string{"8", "9"}, Filter: "tag:bug", Columns: []string{"id"},
+		Version: 2, UpdatedAt: updatedAt, Tags: []models.ViewTag{{ID: 3, Name: "bug"}},
+	}
+
+	tests := []struct {
+		description   string
+		mockExpect    func()
+		expectedError error
+	}{
+		{
+			description: "success",
+			mockExpect: func() {
+				mock.SQL.ExpectExec(update).
+					WithArgs("Bugs", "shared", "tag:bug", []byte(`[]`), []byte(`["id"]`), "", updatedAt, 9, 2).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.SQL.ExpectExec("DELETE FROM view_tags WHERE view_id = ?").WithArgs(9).WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.SQL.ExpectExec("INSERT INTO view_tags (view_id, tag_id, name) VALUES (?, ?, ?)").
+					WithArgs(9, 3, "bug").WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.SQL.ExpectExec("DELETE FROM view_members WHERE view_id = ?").WithArgs(9).WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.SQL.ExpectExec("INSERT INTO view_members (view_id, actor) VALUES (?, ?), (?, ?)").
+					WithArgs(9, "8", 9, "9").WillReturnResult(sqlmock.NewResult(0, 2))
+			},
+		},
+		{
+			description: "modified concurrently",
+			mockExpect: func() {
+				mock.SQL.ExpectExec(update).WillReturnResult(sqlmock.NewResult(0, 0))
+			},
+			expectedError: apperr.PreconditionFailed("view 9 was modified concurrently"),
+		},
+		{
+			description: "duplicate name",
+			mockExpect: func() {
+				mock.SQL.ExpectExec(update).WillReturnError(&mysql.MySQLError{Number: 1062})
+			},
+			expectedError: apperr.Conflict(`you already have a view named "Bugs"`),
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.description, func(t *testing.T) {
+			tc.mockExpect()
+
+			err := viewStore.Update(ctx, v)
+			if !errors.Is(err, tc.expectedError) {
+				t.Errorf("expected error %v, got %v", tc.expectedError, err)
+			}
+		})
+	}
+}
+
+func TestStore_Delete(t *testing.T) {
+	mockContainer, mock := container.NewMockContainer(t)
+	ctx := &gofr.Context{
+		Context:   t.Context(),
+		Request:   nil,
+		Container: mockContainer,
+	}
+
+	mock.SQL.ExpectExec("DELETE FROM views WHERE id = ?").WithArgs(9).WillReturnResult(sqlmock.NewResult(0, 1))
+
+	err := New().Delete(ctx, 9)
+	if err != nil {
+		t.Error(err)
+	}
+}
+
+func TestStore_Tags(t *testing.T) {
+	mockContainer, mock := container.NewMockContainer(t)
+	ctx := &gofr.Context{
+		Context:   t.Context(),
+		Request:   nil,
+		Container: mockContainer,
+	}
+
+	viewStore := New()
+	rows := func() *sqlmock.Rows {
+		return sqlmock.NewRows([]string{"id", "name"}).AddRow(3, "bug")
+	}
+
+	mock.SQL.ExpectQuery("SELECT id, name FROM tags WHERE name IN (?, ?)").WithArgs("bug", "gone").WillReturnRows(rows())
+
+	ids, err := viewStore.TagIDs(ctx, []string{"bug", "gone"})
+	if err != nil || !reflect.DeepEqual(ids, map[string]int64{"bug": 3}) {
+		t.Errorf("expected bug to be found, got %v, %v", ids, err)
+	}
+
+	mock.SQL.ExpectQuery("SELECT id, name FROM tags WHERE id IN (?, ?)").WithArgs(3, 5).WillReturnRows(rows())
+
+	names, err := viewStore.TagNames(ctx, []int64{3, 5})
+	if err != nil || !reflect.DeepEqual(names, map[int64]string{3: "bug"}) {
+		t.Errorf("expected tag 3 to be found, got %v, %v", names, err)
+	}
+
+	mock.SQL.ExpectQuery("SELECT id, name FROM tags WHERE id IN (?)").WillReturnError(utils.ErrTest)
+
+	_, err = viewStore.TagNames(ctx, []int64{3})
+	if !errors.Is(err, utils.ErrTest) {
+		t.Errorf("expected %v, got %v", utils.ErrTest, err)
+	}
+}
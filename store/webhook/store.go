@@ -0,0 +1,367 @@
+package webhook
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"strings"
+	"time"
+
+	"gofr.dev/pkg/gofr"
+
+	"TaskManager2/apperr"
+	"TaskManager2/models"
+	"TaskManager2/utils"
+)
+
+const (
+	webhookColumns  = "id, url, secret, events, created_at"
+	deliveryColumns = "id, webhook_id, event, payload, status, attempts, next_attempt_at, response_code, last_error, " +
+		"created_at, delivered_at"
+)
+
+type store struct {
+}
+
+func New() *store {
+	return &store{}
+}
+
+func (store) Create(ctx *gofr.Context, w *models.Webhook) (int64, error) {
+	events, err := json.Marshal(w.Events)
+	if err != nil {
+		return 0, err
+	}
+
+	var id int64
+
+	err = utils.WithTx(ctx, func() error {
+		id, err = insertWebhook(utils.DB(ctx), w, events)
+
+		return err
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return id, nil
+}
+
+func insertWebhook(db utils.Executor, w *models.Webhook, events []byte) (int64, error) {
+	res, err := db.Exec("INSERT INTO webhooks (url, secret, events, created_at) VALUES (?, ?, ?, ?)",
+		w.URL, w.Secret, events, w.CreatedAt)
+	if err != nil {
+		return 0, err
+	}
+
+	id, err := res.LastInsertId()
+	if err != nil {
+		return 0, err
+	}
+
+	return id, insertEvents(db, id, w.Events)
+}
+
+func (store) GetAll(ctx *gofr.Context) ([]models.Webhook, error) {
+	rows, err := utils.DB(ctx).Query("SELECT " + webhookColumns + " FROM webhooks ORDER BY id")
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	var webhooks []models.Webhook
+
+	for rows.Next() {
+		var w models.Webhook
+
+		w, err = scanWebhook(rows)
+		if err != nil {
+			return nil, err
+		}
+
+		webhooks = append(webhooks, w)
+	}
+
+	if rows.Err() != nil {
+		return nil, rows.Err()
+	}
+
+	return webhooks, nil
+}
+
+func (store) GetByID(ctx *gofr.Context, id int64) (*models.Webhook, error) {
+	w, err := scanWebhook(utils.DB(ctx).QueryRow("SELECT "+webhookColumns+" FROM webhooks WHERE id = ?", id))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, apperr.NotFound("webhook", id)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &w, nil
+}
+
+func (store) Update(ctx *gofr.Context, w *models.Webhook) error {
+	events, err := json.Marshal(w.Events)
+	if err != nil {
+		return err
+	}
+
+	return utils.WithTx(ctx, func() error {
+		db := utils.DB(ctx)
+
+		_, err = db.Exec("UPDATE webhooks SET url = ?, secret = ?, events = ? WHERE id = ?", w.URL, w.Secret, events, w.ID)
+		if err != nil {
+			return err
+		}
+
+		_, err = db.Exec("DELETE FROM webhook_events WHERE webhook_id = ?", w.ID)
+		if err != nil {
+			return err
+		}
+
+		return insertEvents(db, w.ID, w.Events)
+	})
+}
+
+// Subscribers lists the IDs of the webhooks subscribed to the event.
+func (store) Subscribers(ctx *gofr.Context, event string) ([]int64, error) {
+	rows, err := utils.DB(ctx).Query("SELECT webhook_id FROM webhook_events WHERE event = ? ORDER BY webhook_id", event)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	var ids []int64
+
+	for rows.Next() {
+		var id int64
+
+		err = rows.Scan(&id)
+		if err != nil {
+			return nil, err
+		}
+
+		ids = append(ids, id)
+	}
+
+	return ids, rows.Err()
+}
+
+func insertEvents(db utils.Executor, webhookID int64, events []string) error {
+	if len(events) == 0 {
+		return nil
+	}
+
+	args := make([]any, 0, 2*len(events))
+	for _, event := range events {
+		args = append(args, webhookID, event)
+	}
+
+	_, err := db.Exec("INSERT INTO webhook_events (webhook_id, event) VALUES "+tuples(len(events), "(?, ?)"), args...)
+
+	return err
+}
+
+// Delete removes the webhook together with its deliveries.
+func (store) Delete(ctx *gofr.Context, id int64) error {
+	_, err := utils.DB(ctx).Exec("DELETE FROM webhooks WHERE id = ?", id)
+
+	return err
+}
+
+type scanner interface {
+	Scan(dest ...any) error
+}
+
+func scanWebhook(row scanner) (models.Webhook, error) {
+	var (
+		w      models.Webhook
+		events []byte
+	)
+
+	err := row.Scan(&w.ID, &w.URL, &w.Secret, &events, &w.CreatedAt)
+	if err != nil {
+		return models.Webhook{}, err
+	}
+
+	err = json.Unmarshal(events, &w.Events)
+	if err != nil {
+		return models.Webhook{}, err
+	}
+
+	return w, nil
+}
+
+// CreateDeliveries queues the deliveries in a single insert.
+func (store) CreateDeliveries(ctx *gofr.Context, deliveries []models.WebhookDelivery) error {
+	if len(deliveries) == 0 {
+		return nil
+	}
+
+	args := make([]any, 0, 6*len(deliveries))
+	for _, d := range deliveries {
+		args = append(args, d.WebhookID, d.Event, []byte(d.Payload), d.Status, d.NextAttemptAt, d.CreatedAt)
+	}
+
+	_, err := utils.DB(ctx).Exec("INSERT INTO webhook_deliveries (webhook_id, event, payload, status, next_attempt_at, created_at) "+
+		"VALUES "+tuples(len(deliveries), "(?, ?, ?, ?, ?, ?)"), args...)
+
+	return err
+}
+
+// GetDeliveries lists the deliveries selected by f, newest first.
+func (store) GetDeliveries(ctx *gofr.Context, f *models.DeliveryFilter) ([]models.WebhookDelivery, error) {
+	where, args := "webhook_id = ?", []any{f.WebhookID}
+	if f.Status != "" {
+		where += " AND status = ?"
+
+		args = append(args, f.Status)
+	}
+
+	return queryDeliveries(utils.DB(ctx), "SELECT "+deliveryColumns+" FROM webhook_deliveries WHERE "+where+
+		" ORDER BY id DESC LIMIT ?", append(args, f.Limit)...)
+}
+
+func (store) GetDelivery(ctx *gofr.Context, id int64) (*models.WebhookDelivery, error) {
+	deliveries, err := queryDeliveries(utils.DB(ctx), "SELECT "+deliveryColumns+" FROM webhook_deliveries WHERE id = ?", id)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(deliveries) == 0 {
+		return nil, apperr.NotFound("delivery", id)
+	}
+
+	return &deliveries[0], nil
+}
+
+// Replay queues a copy of the delivery, due at now, and returns its ID.
+func (store) Replay(ctx *gofr.Context, id int64, now time.Time) (int64, error) {
+	res, err := utils.DB(ctx).Exec("INSERT INTO webhook_deliveries (webhook_id, event, payload, status, next_attempt_at, created_at) "+
+		"SELECT webhook_id, event, payload, ?, ?, ? FROM webhook_deliveries WHERE id = ?", models.DeliveryPending, now, now, id)
+	if err != nil {
+		return 0, err
+	}
+
+	return res.LastInsertId()
+}
+
+// Due lists up to limit pending deliveries whose next attempt is due at now,
+// oldest first, with the URL and secret of their webhook.
+func (store) Due(ctx *gofr.Context, now time.Time, limit int) ([]models.WebhookDelivery, error) {
+	rows, err := utils.DB(ctx).Query("SELECT d.id, d.webhook_id, d.event, d.payload, d.attempts, w.url, w.secret "+
+		"FROM webhook_deliveries d JOIN webhooks w ON w.id = d.webhook_id "+
+		"WHERE d.status = ? AND d.next_attempt_at <= ? ORDER BY d.next_attempt_at, d.id LIMIT ?",
+		models.DeliveryPending, now, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	var deliveries []models.WebhookDelivery
+
+	for rows.Next() {
+		d := models.WebhookDelivery{Status: models.DeliveryPending}
+
+		err = rows.Scan(&d.ID, &d.WebhookID, &d.Event, &d.Payload, &d.Attempts, &d.URL, &d.Secret)
+		if err != nil {
+			return nil, err
+		}
+
+		deliveries = append(deliveries, d)
+	}
+
+	if rows.Err() != nil {
+		return nil, rows.Err()
+	}
+
+	return deliveries, nil
+}
+
+// Claim reserves a due delivery until the given time by moving its next
+// attempt there. Of several workers racing for a delivery only one succeeds,
+// and a worker that dies while sending leaves the delivery due again once the
+// claim runs out.
+func (store) Claim(ctx *gofr.Context, id int64, now, until time.Time) (bool, error) {
+	res, err := utils.DB(ctx).Exec("UPDATE webhook_deliveries SET next_attempt_at = ? "+
+		"WHERE id = ? AND status = ? AND next_attempt_at <= ?", until, id, models.DeliveryPending, now)
+	if err != nil {
+		return false, err
+	}
+
+	n, err := res.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+
+	return n == 1, nil
+}
+
+// SaveAttempt records the outcome of an attempt: the status, attempt count,
+// next attempt, response code, error and delivery time of d.
+func (store) SaveAttempt(ctx *gofr.Context, d *models.WebhookDelivery) error {
+	var code *int
+	if d.ResponseCode != 0 {
+		code = &d.ResponseCode
+	}
+
+	_, err := utils.DB(ctx).Exec("UPDATE webhook_deliveries SET status = ?, attempts = ?, next_attempt_at = ?, "+
+		"response_code = ?, last_error = ?, delivered_at = ? WHERE id = ?",
+		d.Status, d.Attempts, d.NextAttemptAt, code, d.LastError, d.DeliveredAt, d.ID)
+
+	return err
+}
+
+func queryDeliveries(db utils.Executor, query string, args ...any) ([]models.WebhookDelivery, error) {
+	rows, err := db.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	var deliveries []models.WebhookDelivery
+
+	for rows.Next() {
+		var (
+			d           models.WebhookDelivery
+			nextAttempt sql.NullTime
+			code        sql.NullInt64
+			deliveredAt sql.NullTime
+		)
+
+		err = rows.Scan(&d.ID, &d.WebhookID, &d.Event, &d.Payload, &d.Status, &d.Attempts, &nextAttempt, &code, &d.LastError,
+			&d.CreatedAt, &deliveredAt)
+		if err != nil {
+			return nil, err
+		}
+
+		if nextAttempt.Valid {
+			d.NextAttemptAt = &nextAttempt.Time
+		}
+
+		if deliveredAt.Valid {
+			d.DeliveredAt = &deliveredAt.Time
+		}
+
+		d.ResponseCode = int(code.Int64)
+
+		deliveries = append(deliveries, d)
+	}
+
+	if rows.Err() != nil {
+		return nil, rows.Err()
+	}
+
+	return deliveries, nil
+}
+
+// tuples repeats a placeholder tuple n times, separated by commas.
+func tuples(n int, tuple string) string {
+	return strings.TrimSuffix(strings.Repeat(tuple+", ", n), ", ")
+}
@@ -0,0 +1,216 @@
+package webhook
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"gofr.dev/pkg/gofr"
+	"gofr.dev/pkg/gofr/container"
+
+	"TaskManager2/apperr"
+	"TaskManager2/models"
+	"TaskManager2/utils"
+)
+
+var (
+	columns      = []string{"id", "url", "secret", "events", "created_at"}
+	deliveryRows = []string{"id", "webhook_id", "event", "payload", "status", "attempts", "next_attempt_at", "response_code",
+		"last_error", "created_at", "delivered_at"}
+)
+
+func TestStore_Webhooks(t *testing.T) {
+	mockContainer, mock := container.NewMockContainer(t)
+	ctx := &gofr.Context{
+		Context:   t.Context(),
+		Request:   nil,
+		Container: mockContainer,
+	}
+
+	webhookStore := New()
+	createdAt := time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC)
+	w := &models.Webhook{ID: 4, URL: "https://example.com/hook", Secret: "s", Events: []string{"task.created"}, CreatedAt: createdAt}
+
+	mock.SQL.ExpectBegin()
+	mock.SQL.ExpectExec("INSERT INTO webhooks (url, secret, events, created_at) VALUES (?, ?, ?, ?)").
+		WithArgs("https://example.com/hook", "s", []byte(`["task.created"]`), createdAt).
+		WillReturnResult(sqlmock.NewResult(4, 1))
+	mock.SQL.ExpectExec("INSERT INTO webhook_events (webhook_id, event) VALUES (?, ?)").
+		WithArgs(4, "task.created").WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.SQL.ExpectCommit()
+
+	id, err := webhookStore.Create(ctx, w)
+	if err != nil || id != 4 {
+		t.Errorf("expected id 4, got %d, %v", id, err)
+	}
+
+	mock.SQL.ExpectQuery("SELECT " + webhookColumns + " FROM webhooks ORDER BY id").
+		WillReturnRows(sqlmock.NewRows(columns).AddRow(4, "https://example.com/hook", "s", []byte(`["task.created"]`), createdAt))
+
+	webhooks, err := webhookStore.GetAll(ctx)
+	if err != nil || !reflect.DeepEqual(webhooks, []models.Webhook{*w}) {
+		t.Errorf("expected %+v, got %+v, %v", []models.Webhook{*w}, webhooks, err)
+	}
+
+	mock.SQL.ExpectQuery("SELECT " + webhookColumns + " FROM webhooks WHERE id = ?").WithArgs(5).WillReturnRows(sqlmock.NewRows(columns))
+
+	_, err = webhookStore.GetByID(ctx, 5)
+	if !errors.Is(err, apperr.NotFound("webhook", 5)) {
+		t.Errorf("expected not found, got %v", err)
+	}
+
+	mock.SQL.ExpectBegin()
+	mock.SQL.ExpectExec("UPDATE webhooks SET url = ?, secret = ?, events = ? WHERE id = ?").
+		WithArgs("https://example.com/hook", "s", []byte(`["task.created"]`), 4).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.SQL.ExpectExec("DELETE FROM webhook_events WHERE webhook_id = ?").WithArgs(4).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.SQL.ExpectExec("INSERT INTO webhook_events (webhook_id, event) VALUES (?, ?)").
+		WithArgs(4, "task.created").WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.SQL.ExpectCommit()
+
+	err = webhookStore.Update(ctx, w)
+	if err != nil {
+		t.Error(err)
+	}
+
+	mock.SQL.ExpectBegin()
+	mock.SQL.ExpectExec("UPDATE webhooks SET url = ?, secret = ?, events = ? WHERE id = ?").WillReturnError(utils.ErrTest)
+	mock.SQL.ExpectRollback()
+
+	err = webhookStore.Update(ctx, w)
+	if !errors.Is(err, utils.ErrTest) {
+		t.Errorf("expected %v, got %v", utils.ErrTest, err)
+	}
+
+	mock.SQL.ExpectQuery("SELECT webhook_id FROM webhook_events WHERE event = ? ORDER BY webhook_id").WithArgs("task.created").
+		WillReturnRows(sqlmock.NewRows([]string{"webhook_id"}).AddRow(1).AddRow(4))
+
+	ids, err := webhookStore.Subscribers(ctx, "task.created")
+	if err != nil || !reflect.DeepEqual(ids, []int64{1, 4}) {
+		t.Errorf("expected subscribers 1 and 4, got %v, %v", ids, err)
+	}
+
+	mock.SQL.ExpectExec("DELETE FROM webhooks WHERE id = ?").WithArgs(4).WillReturnError(utils.ErrTest)
+
+	err = webhookStore.Delete(ctx, 4)
+	if !errors.Is(err, utils.ErrTest) {
+		t.Errorf("expected %v, got %v", utils.ErrTest, err)
+	}
+}
+
+func TestStore_Deliveries(t *testing.T) {
+	mockContainer, mock := container.NewMockContainer(t)
+	ctx := &gofr.Context{
+		Context:   t.Context(),
+		Request:   nil,
+		Container: mockContainer,
+	}
+
+	webhookStore := New()
+	now := time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC)
+	payload := []byte(`{"event":"task.created"}`)
+
+	mock.SQL.ExpectExec("INSERT INTO webhook_deliveries (webhook_id, event, payload, status, next_attempt_at, created_at) "+
+		"VALUES (?, ?, ?, ?, ?, ?), (?, ?, ?, ?, ?, ?)").
+		WithArgs(1, "task.created", payload, "pending", &now, now, 3, "task.created", payload, "pending", &now, now).
+		WillReturnResult(sqlmock.NewResult(0, 2))
+
+	err := webhookStore.CreateDeliveries(ctx, []models.WebhookDelivery{
+		{WebhookID: 1, Event: "task.created", Payload: payload, Status: "pending", NextAttemptAt: &now, CreatedAt: now},
+		{WebhookID: 3, Event: "task.created", Payload: payload, Status: "pending", NextAttemptAt: &now, CreatedAt: now},
+	})
+	if err != nil {
+		t.Error(err)
+	}
+
+	mock.SQL.ExpectQuery("SELECT "+deliveryColumns+" FROM webhook_deliveries WHERE webhook_id = ? AND status = ? "+
+		"ORDER BY id DESC LIMIT ?").
+		WithArgs(1, "dead", 50).
+		WillReturnRows(sqlmock.NewRows(deliveryRows).AddRow(7, 1, "task.created", payload, "dead", 8, nil, 503, "receiver responded", now, nil))
+
+	deliveries, err := webhookStore.GetDeliveries(ctx, &models.DeliveryFilter{WebhookID: 1, Status: "dead", Limit: 50})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []models.WebhookDelivery{{ID: 7, WebhookID: 1, Event: "task.created", Payload: payload, Status: "dead", Attempts: 8,
+		ResponseCode: 503, LastError: "receiver responded", CreatedAt: now}}
+	if !reflect.DeepEqual(deliveries, want) {
+		t.Errorf("expected %+v, got %+v", want, deliveries)
+	}
+
+	mock.SQL.ExpectQuery("SELECT " + deliveryColumns + " FROM webhook_deliveries WHERE id = ?").WithArgs(9).
+		WillReturnRows(sqlmock.NewRows(deliveryRows))
+
+	_, err = webhookStore.GetDelivery(ctx, 9)
+	if !errors.Is(err, apperr.NotFound("delivery", 9)) {
+		t.Errorf("expected not found, got %v", err)
+	}
+
+	mock.SQL.ExpectExec("INSERT INTO webhook_deliveries (webhook_id, event, payload, status, next_attempt_at, created_at) "+
+		"SELECT webhook_id, event, payload, ?, ?, ? FROM webhook_deliveries WHERE id = ?").
+		WithArgs("pending", now, now, 7).
+		WillReturnResult(sqlmock.NewResult(8, 1))
+
+	id, err := webhookStore.Replay(ctx, 7, now)
+	if err != nil || id != 8 {
+		t.Errorf("expected id 8, got %d, %v", id, err)
+	}
+}
+
+func TestStore_Worker(t *testing.T) {
+	mockContainer, mock := container.NewMockContainer(t)
+	ctx := &gofr.Context{
+		Context:   t.Context(),
+		Request:   nil,
+		Container: mockContainer,
+	}
+
+	webhookStore := New()
+	now := time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC)
+	until := now.Add(time.Minute)
+	payload := []byte(`{}`)
+
+	mock.SQL.ExpectQuery("SELECT d.id, d.webhook_id, d.event, d.payload, d.attempts, w.url, w.secret "+
+		"FROM webhook_deliveries d JOIN webhooks w ON w.id = d.webhook_id "+
+		"WHERE d.status = ? AND d.next_attempt_at <= ? ORDER BY d.next_attempt_at, d.id LIMIT ?").
+		WithArgs("pending", now, 10).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "webhook_id", "event", "payload", "attempts", "url", "secret"}).
+			AddRow(7, 1, "task.created", payload, 2, "https://example.com/hook", "s"))
+
+	due, err := webhookStore.Due(ctx, now, 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []models.WebhookDelivery{{ID: 7, WebhookID: 1, Event: "task.created", Payload: payload, Status: "pending", Attempts: 2,
+		URL: "https://example.com/hook", Secret: "s"}}
+	if !reflect.DeepEqual(due, want) {
+		t.Errorf("expected %+v, got %+v", want, due)
+	}
+
+	claim := "UPDATE webhook_deliveries SET next_attempt_at = ? WHERE id = ? AND status = ? AND next_attempt_at <= ?"
+
+	mock.SQL.ExpectExec(claim).WithArgs(until, 7, "pending", now).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.SQL.ExpectExec(claim).WithArgs(until, 7, "pending", now).WillReturnResult(sqlmock.NewResult(0, 0))
+
+	for _, expected := range []bool{true, false} {
+		claimed, err := webhookStore.Claim(ctx, 7, now, until)
+		if err != nil || claimed != expected {
+			t.Errorf("expected claimed %v, got %v, %v", expected, claimed, err)
+		}
+	}
+
+	mock.SQL.ExpectExec("UPDATE webhook_deliveries SET status = ?, attempts = ?, next_attempt_at = ?, "+
+		"response_code = ?, last_error = ?, delivered_at = ? WHERE id = ?").
+		WithArgs("pending", 3, &until, nil, "connection refused", nil, 7).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	err = webhookStore.SaveAttempt(ctx, &models.WebhookDelivery{ID: 7, Status: "pending", Attempts: 3, NextAttemptAt: &until,
+		LastError: "connection refused"})
+	if err != nil {
+		t.Error(err)
+	}
+}
@@ -0,0 +1,18 @@
+package utils
+
+import "time"
+
+// Backoff is the wait before retrying after the given number of failed
+// attempts: first after the first failure, doubling after each further one,
+// up to limit.
+func Backoff(attempts int, first, limit time.Duration) time.Duration {
+	wait := first
+	for range attempts - 1 {
+		wait *= 2
+		if wait >= limit {
+			return limit
+		}
+	}
+
+	return wait
+}
@@ -0,0 +1,25 @@
+package utils
+
+import (
+	"testing"
+	"time"
+)
+
+func TestBackoff(t *testing.T) {
+	tests := []struct {
+		attempts int
+		expected time.Duration
+	}{
+		{1, 5 * time.Second},
+		{2, 10 * time.Second},
+		{4, 40 * time.Second},
+		{7, 5 * time.Minute},
+		{50, 5 * time.Minute},
+	}
+
+	for _, tc := range tests {
+		if got := Backoff(tc.attempts, 5*time.Second, 5*time.Minute); got != tc.expected {
+			t.Errorf("Backoff(%d): expected %v, got %v", tc.attempts, tc.expected, got)
+		}
+	}
+}
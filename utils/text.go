@@ -0,0 +1,11 @@
+package utils
+
+// Truncate cuts s to at most n characters, for values stored in columns of
+// that length.
+func Truncate(s string, n int) string {
+	if r := []rune(s); len(r) > n {
+		return string(r[:n])
+	}
+
+	return s
+}
@@ -0,0 +1,22 @@
+package utils
+
+import "testing"
+
+func TestTruncate(t *testing.T) {
+	tests := []struct {
+		input    string
+		n        int
+		expected string
+	}{
+		{"short", 10, "short"},
+		{"exactly", 7, "exactly"},
+		{"truncated", 5, "trunc"},
+		{"héllo wörld", 7, "héllo w"},
+	}
+
+	for _, tc := range tests {
+		if got := Truncate(tc.input, tc.n); got != tc.expected {
+			t.Errorf("Truncate(%q, %d): expected %q, got %q", tc.input, tc.n, tc.expected, got)
+		}
+	}
+}
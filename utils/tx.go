@@ -0,0 +1,57 @@
+package utils
+
+import (
+	"context"
+	"database/sql"
+
+	"gofr.dev/pkg/gofr"
+	gofrSQL "gofr.dev/pkg/gofr/datasource/sql"
+)
+
+type txKey struct{}
+
+// Executor is satisfied by both the SQL connection and a transaction.
+type Executor interface {
+	Exec(query string, args ...any) (sql.Result, error)
+	Query(query string, args ...any) (*sql.Rows, error)
+	QueryRow(query string, args ...any) *sql.Row
+}
+
+// DB returns the transaction opened by WithTx on ctx, or the plain connection
+// when no transaction is in progress. Stores use it for every query so they
+// take part in a caller's transaction.
+func DB(ctx *gofr.Context) Executor {
+	if tx, ok := ctx.Value(txKey{}).(*gofrSQL.Tx); ok {
+		return tx
+	}
+
+	return ctx.SQL
+}
+
+// WithTx runs fn inside a transaction that is committed if fn returns nil and
+// rolled back otherwise. A call made while a transaction is already open joins
+// it, so services and stores can both declare their transactional boundaries.
+func WithTx(ctx *gofr.Context, fn func() error) error {
+	if _, ok := ctx.Value(txKey{}).(*gofrSQL.Tx); ok {
+		return fn()
+	}
+
+	tx, err := ctx.SQL.Begin()
+	if err != nil {
+		return err
+	}
+
+	parent := ctx.Context
+	ctx.Context = context.WithValue(parent, txKey{}, tx)
+
+	defer func() { ctx.Context = parent }()
+
+	err = fn()
+	if err != nil {
+		_ = tx.Rollback()
+
+		return err
+	}
+
+	return tx.Commit()
+}
@@ -0,0 +1,156 @@
+// Package validate checks models against the rules in their `validate` struct
+// tags and reports every violation at once as an apperr validation error.
+//
+// Rules are separated by commas:
+//
+//	required  the value is not zero; strings must not be blank
+//	max=N     strings have at most N characters, slices at most N items
+//	email     the string is a bare email address
+//	dive      the rules that follow apply to each slice element
+//
+// Nested structs and slices of structs are always checked. Nil pointers are
+// treated as absent and skipped. Field paths use the JSON names, for example
+// subtasks[0].checklist[1].text.
+package validate
+
+import (
+	"fmt"
+	"net/mail"
+	"reflect"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"TaskManager2/apperr"
+)
+
+// Struct validates v, which must be a struct or a pointer to one.
+func Struct(v any) error {
+	return Value("", v)
+}
+
+// Value validates v, prefixing the reported field paths with path.
+func Value(path string, v any) error {
+	var fields []apperr.FieldError
+
+	walk(path, reflect.ValueOf(v), &fields)
+
+	if len(fields) > 0 {
+		return apperr.Validation(fields...)
+	}
+
+	return nil
+}
+
+func walk(path string, v reflect.Value, fields *[]apperr.FieldError) {
+	switch v.Kind() {
+	case reflect.Pointer, reflect.Interface:
+		if !v.IsNil() {
+			walk(path, v.Elem(), fields)
+		}
+	case reflect.Struct:
+		t := v.Type()
+
+		for i := range t.NumField() {
+			f := t.Field(i)
+			if !f.IsExported() {
+				continue
+			}
+
+			fieldPath := join(path, jsonName(f))
+			fv := v.Field(i)
+
+			if tag := f.Tag.Get("validate"); tag != "" {
+				check(fieldPath, fv, strings.Split(tag, ","), fields)
+			}
+
+			walk(fieldPath, fv, fields)
+		}
+	case reflect.Slice, reflect.Array:
+		for i := range v.Len() {
+			walk(path+"["+strconv.Itoa(i)+"]", v.Index(i), fields)
+		}
+	default:
+	}
+}
+
+// check applies rules to v, reporting at most one violation per value.
+func check(path string, v reflect.Value, rules []string, fields *[]apperr.FieldError) {
+	if v.Kind() == reflect.Pointer {
+		if v.IsNil() {
+			return
+		}
+
+		v = v.Elem()
+	}
+
+	for i, rule := range rules {
+		name, arg, _ := strings.Cut(rule, "=")
+
+		if name == "dive" {
+			for j := range v.Len() {
+				check(path+"["+strconv.Itoa(j)+"]", v.Index(j), rules[i+1:], fields)
+			}
+
+			return
+		}
+
+		if reason := apply(name, arg, v); reason != "" {
+			*fields = append(*fields, apperr.Field(path, reason))
+
+			return
+		}
+	}
+}
+
+func apply(rule, arg string, v reflect.Value) string {
+	switch rule {
+	case "required":
+		if v.Kind() == reflect.String && strings.TrimSpace(v.String()) == "" || v.IsZero() {
+			return "is required"
+		}
+	case "max":
+		limit, err := strconv.Atoi(arg)
+		if err != nil {
+			panic(fmt.Sprintf("validate: invalid max %q", arg))
+		}
+
+		if v.Kind() == reflect.String && utf8.RuneCountInString(v.String()) > limit {
+			return fmt.Sprintf("must be at most %d characters", limit)
+		}
+
+		if (v.Kind() == reflect.Slice || v.Kind() == reflect.Array) && v.Len() > limit {
+			return fmt.Sprintf("must have at most %d items", limit)
+		}
+	case "email":
+		if v.String() == "" {
+			return ""
+		}
+
+		addr, err := mail.ParseAddress(v.String())
+		if err != nil || addr.Address != v.String() {
+			return "must be a valid email address"
+		}
+	default:
+		panic(fmt.Sprintf("validate: unknown rule %q", rule))
+	}
+
+	return ""
+}
+
+func jsonName(f reflect.StructField) string {
+	name, _, _ := strings.Cut(f.Tag.Get("json"), ",")
+	if name == "" || name == "-" {
+		return f.Name
+	}
+
+	return name
+}
+
+func join(path, name string) string {
+	if path == "" {
+		return name
+	}
+
+	return path + "." + name
+}
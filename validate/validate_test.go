@@ -0,0 +1,109 @@
+package validate
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	"TaskManager2/apperr"
+	"TaskManager2/models"
+)
+
+func TestStruct(t *testing.T) {
+	long := strings.Repeat("é", 151)
+	blank := " "
+
+	tests := []struct {
+		description string
+		input       any
+		want        []apperr.FieldError
+	}{
+		{
+			description: "valid user",
+			input:       &models.User{Name: "Bob", Email: "bob@example.com"},
+		},
+		{
+			description: "every user violation",
+			input:       &models.User{Name: " ", Email: "Bob <bob@example.com>"},
+			want: []apperr.FieldError{
+				{Field: "name", Reason: "is required"},
+				{Field: "email", Reason: "must be a valid email address"},
+			},
+		},
+		{
+			description: "missing email is only reported once",
+			input:       models.User{Name: "Bob"},
+			want:        []apperr.FieldError{{Field: "email", Reason: "is required"}},
+		},
+		{
+			description: "length counts characters",
+			input:       &models.Task{Title: strings.Repeat("é", 150)},
+		},
+		{
+			description: "nested task violations",
+			input: &models.Task{
+				Title: long,
+				Tags:  []string{"ops", ""},
+				Subtasks: []models.Task{
+					{Title: "ok"},
+					{Title: "ok", Checklist: []models.ChecklistItem{{Text: "ok"}, {Text: ""}}},
+				},
+			},
+			want: []apperr.FieldError{
+				{Field: "title", Reason: "must be at most 150 characters"},
+				{Field: "tags[1]", Reason: "is required"},
+				{Field: "subtasks[1].checklist[1].text", Reason: "is required"},
+			},
+		},
+		{
+			description: "absent patch fields are skipped",
+			input:       &models.TaskPatch{},
+		},
+		{
+			description: "present patch fields are checked",
+			input:       &models.TaskPatch{Title: &blank},
+			want:        []apperr.FieldError{{Field: "title", Reason: "is required"}},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.description, func(t *testing.T) {
+			err := Struct(tc.input)
+
+			if tc.want == nil {
+				if err != nil {
+					t.Errorf("expected no error, got %v", err)
+				}
+
+				return
+			}
+
+			var appErr *apperr.Error
+			if !errors.As(err, &appErr) || !reflect.DeepEqual(appErr.Fields, tc.want) {
+				t.Errorf("expected fields %v, got %v", tc.want, err)
+			}
+		})
+	}
+}
+
+func TestValue(t *testing.T) {
+	err := Value("tasks", []models.Task{{Title: "ok"}, {}})
+
+	want := apperr.Validation(apperr.Field("tasks[1].title", "is required"))
+	if !errors.Is(err, want) {
+		t.Errorf("expected %v, got %v", want, err)
+	}
+}
+
+func TestUnknownRule(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Errorf("expected a panic for an unknown rule")
+		}
+	}()
+
+	_ = Struct(struct {
+		Name string `validate:"uppercase"`
+	}{})
+}